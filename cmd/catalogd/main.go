@@ -2,11 +2,20 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/atam/atamlink/internal/app"
 )
 
 func main() {
+	// Subcommand `catalogd migrate ...` menjalankan migrasi lalu keluar
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.RunMigrate(os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
 	// 1. Buat instance aplikasi baru.
 	// Fungsi New() akan mengurus semua inisialisasi.
 	application, err := app.New()
@@ -17,4 +26,4 @@ func main() {
 	// 2. Jalankan aplikasi.
 	// Metode Run() akan mengurus start server dan graceful shutdown.
 	application.Run()
-}
\ No newline at end of file
+}
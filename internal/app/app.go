@@ -14,20 +14,45 @@ import (
 	"github.com/joho/godotenv"
 
 	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/database/migrations"
 	"github.com/atam/atamlink/internal/handler"
 	"github.com/atam/atamlink/internal/middleware"
 	auditRepo "github.com/atam/atamlink/internal/mod_audit/repository"
+	authRepo "github.com/atam/atamlink/internal/mod_auth/repository"
+	authUC "github.com/atam/atamlink/internal/mod_auth/usecase"
 	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
 	"github.com/atam/atamlink/internal/mod_business/usecase"
-	// catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
-	// catalogUC "github.com/atam/atamlink/internal/mod_catalog/usecase"
-	// masterRepo "github.com/atam/atamlink/internal/mod_master/repository"
-	// masterUC "github.com/atam/atamlink/internal/mod_master/usecase"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	catalogUC "github.com/atam/atamlink/internal/mod_catalog/usecase"
+	integrationRepo "github.com/atam/atamlink/internal/mod_integration/repository"
+	integrationUC "github.com/atam/atamlink/internal/mod_integration/usecase"
+	commentRepo "github.com/atam/atamlink/internal/mod_comment/repository"
+	commentUC "github.com/atam/atamlink/internal/mod_comment/usecase"
+	backupRepo "github.com/atam/atamlink/internal/mod_backup/repository"
+	backupUC "github.com/atam/atamlink/internal/mod_backup/usecase"
+	analyticsRepo "github.com/atam/atamlink/internal/mod_analytics/repository"
+	analyticsUC "github.com/atam/atamlink/internal/mod_analytics/usecase"
+	notificationRepo "github.com/atam/atamlink/internal/mod_notification/repository"
+	notificationUC "github.com/atam/atamlink/internal/mod_notification/usecase"
+	masterRepo "github.com/atam/atamlink/internal/mod_master/repository"
+	masterUC "github.com/atam/atamlink/internal/mod_master/usecase"
+	reviewRepo "github.com/atam/atamlink/internal/mod_review/repository"
+	reviewUC "github.com/atam/atamlink/internal/mod_review/usecase"
+	inquiryRepo "github.com/atam/atamlink/internal/mod_inquiry/repository"
+	inquiryUC "github.com/atam/atamlink/internal/mod_inquiry/usecase"
+	orderRepo "github.com/atam/atamlink/internal/mod_order/repository"
+	orderUC "github.com/atam/atamlink/internal/mod_order/usecase"
+	paymentRepo "github.com/atam/atamlink/internal/mod_payment/repository"
+	paymentUC "github.com/atam/atamlink/internal/mod_payment/usecase"
+	statusRepo "github.com/atam/atamlink/internal/mod_status/repository"
+	statusUC "github.com/atam/atamlink/internal/mod_status/usecase"
 	userRepo "github.com/atam/atamlink/internal/mod_user/repository"
 	// userUC "github.com/atam/atamlink/internal/mod_user/usecase"
 	"github.com/atam/atamlink/internal/service"
 	"github.com/atam/atamlink/pkg/database"
 	"github.com/atam/atamlink/pkg/logger"
+	"github.com/atam/atamlink/pkg/redis"
 	"github.com/atam/atamlink/pkg/utils"
 
 	// Import docs untuk swagger
@@ -44,7 +69,14 @@ type App struct {
 	Server *http.Server
 	Log    logger.Logger
 	DB     *sql.DB
+	Redis  *redis.Client
 	AuditService service.AuditService
+	Scheduler    *Scheduler
+	NotificationService service.NotificationService
+	CacheService service.CacheInvalidationService
+	SearchIndexer service.SearchIndexer
+	APIUsageService service.APIUsageService
+	ProfilerService service.ProfilerService
 }
 
 // New membuat dan mengonfigurasi instance aplikasi baru.
@@ -55,58 +87,324 @@ func New() (*App, error) {
 	// Inisialisasi komponen dasar
 	cfg := config.Load()
 	log := logger.New(cfg.Log.Level, cfg.Log.Format)
-	db, err := database.NewPostgresDB(cfg.Database)
+	db, err := database.NewPostgresDB(cfg.Database, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init database: %w", err)
 	}
 
+	if cfg.Database.MigrateOnStartup {
+		migrator, err := database.NewMigrator(db, migrations.FS, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init migrator: %w", err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to run migrations: %w", err)
+		}
+	}
+
+	// Redis opsional, dipakai untuk presence editor
+	var redisClient *redis.Client
+	if cfg.Redis.Addr != "" {
+		redisClient, err = redis.NewClient(cfg.Redis)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init redis: %w", err)
+		}
+	}
+
 	// Inisialisasi semua dependensi (DI Container)
 	// Services
 	validator := utils.NewValidator()
 	slugService := service.NewSlugService()
 	uploadService := service.NewUploadService(cfg.Upload)
+	marketplaceService := service.NewMarketplaceService(cfg.Integration)
+	paymentService := service.NewPaymentService(cfg.Payment)
+	vaultService := service.NewVaultService(cfg.Notification.VaultKey)
+	presenceService := service.NewPresenceService(redisClient, cfg.Presence.TTL)
+	rateLimiter := service.NewRateLimiter(redisClient, cfg.RateLimit.Window)
+	idempotencyStore := service.NewIdempotencyStore(cfg.Idempotency, redisClient)
+	mailService := service.NewMailService(cfg.Mail)
+
+	// Backup storage hanya disiapkan jika backup atau arsip diaktifkan
+	var backupStorage service.BackupStorage
+	if cfg.Backup.Enabled || cfg.Archive.Enabled {
+		backupStorage, err = service.NewLocalBackupStorage(cfg.Backup.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init backup storage: %w", err)
+		}
+	}
 	
+	// Storage hasil capture profile hanya disiapkan jika profiling diaktifkan
+	var profileStorage service.BackupStorage
+	if cfg.Profiling.Enabled {
+		profileStorage, err = service.NewLocalBackupStorage(cfg.Profiling.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init profile storage: %w", err)
+		}
+	}
+	profilerService := service.NewProfilerService(cfg.Profiling, profileStorage, log)
+
+	// Storage snapshot katalog publik, fallback tetap bisa membaca snapshot lama walau job nonaktif
+	var snapshotStorage service.BackupStorage
+	if cfg.Snapshot.Enabled || cfg.Snapshot.ServeFallback {
+		snapshotStorage, err = service.NewLocalBackupStorage(cfg.Snapshot.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init snapshot storage: %w", err)
+		}
+	}
+
+	mediaReplicationService, err := service.NewMediaReplicationService(cfg.MediaReplication)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init media replication: %w", err)
+	}
+
+	mediaArchiveService, err := service.NewMediaArchiveService(cfg.MediaArchive, cfg.Upload.Cloudinary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init media archive: %w", err)
+	}
+
 	// Repositories
 	userRepository := userRepo.NewUserRepository(db)
 	businessRepository := businessRepo.NewBusinessRepository(db)
-	// catalogRepository := catalogRepo.NewCatalogRepository(db)
-	// masterRepository := masterRepo.NewMasterRepository(db)
+	catalogRepository := catalogRepo.NewCatalogRepository(db)
+	masterRepository := masterRepo.NewMasterRepository(db)
 	auditRepository := auditRepo.NewAuditRepository(db)
+	integrationRepository := integrationRepo.NewIntegrationRepository(db)
+	notificationRepository := notificationRepo.NewNotificationRepository(db)
+	commentRepository := commentRepo.NewCommentRepository(db)
+	backupRepository := backupRepo.NewBackupRepository(db)
+	analyticsRepository := analyticsRepo.NewAnalyticsRepository(db)
+	reviewRepository := reviewRepo.NewReviewRepository(db)
+	inquiryRepository := inquiryRepo.NewInquiryRepository(db)
+	orderRepository := orderRepo.NewOrderRepository(db)
+	paymentRepository := paymentRepo.NewPaymentRepository(db)
+	statusRepository := statusRepo.NewStatusRepository(db)
+	authRepository := authRepo.NewAuthRepository(db)
+
+	// Seed master data default untuk instalasi baru
+	if cfg.Database.SeedOnBoot {
+		seedService := service.NewSeedService(db, masterRepository, log)
+		if err := seedService.Seed(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to seed master data: %w", err)
+		}
+	}
 
-	// Start audit service
-	auditService := service.NewAuditService(auditRepository, log)
+	// Start audit service, sink kosong berarti audit log tidak dikirim ke SIEM
+	auditSink, err := service.NewAuditSink(cfg.Audit.Sink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init audit sink: %w", err)
+	}
+	auditService := service.NewAuditService(auditRepository, log, mailService, auditSink, cfg.Audit)
 	auditService.Start()
 
+	// Start notification service
+	notificationHTTPClient := service.NewHTTPClient(cfg.Notification.HTTPTimeout)
+	telegramSender := service.NewTelegramSender(cfg.Notification.Telegram, notificationHTTPClient)
+	notificationService := service.NewNotificationService(
+		notificationRepository,
+		vaultService,
+		log,
+		service.NewWhatsAppSender(cfg.Notification.WhatsApp, notificationHTTPClient),
+		telegramSender,
+	)
+	notificationService.Start()
+
+	// Start cache invalidation service (purge CDN)
+	cdnPurger, err := service.NewCDNPurger(cfg.CDN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init cdn purger: %w", err)
+	}
+	cacheService := service.NewCacheInvalidationService(cdnPurger, cfg.CDN.PurgeURLs, cfg.CDN.PurgeCardURLs, log)
+	cacheService.Start()
+	cachePurgeLimiter := service.NewRateLimiter(redisClient, cfg.CDN.ManualPurgeWindow)
+
+	// Search engine kosong berarti pencarian memakai Postgres full-text search
+	searchEngine, err := service.NewSearchEngine(cfg.Search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init search engine: %w", err)
+	}
+	searchIndexer := service.NewSearchIndexer(searchEngine, catalogRepository, cfg.Search.MaxHits, log)
+	qrService := service.NewQRService(uploadService, cfg.QR, log)
+	searchIndexer.Start()
+
+	// Pemakaian API service account, agregat harian ditulis berkala
+	apiUsageService := service.NewAPIUsageService(analyticsRepository, cfg.APIUsage, log)
+	apiUsageService.Start()
+
+	botFilter := service.NewBotFilter(cfg.Analytics)
+
+	// Proof-of-work untuk endpoint tulis publik, pengganti captcha
+	proofOfWork, err := service.NewProofOfWork(cfg.ProofOfWork, redisClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init proof-of-work: %w", err)
+	}
+
+	// Clock use case dan scheduler, time-travel hanya untuk QA sandbox dan tidak pernah aktif di mode release
+	var clock service.Clock = service.NewSystemClock()
+	var offsetClock *service.OffsetClock
+	if cfg.Clock.TimeTravelEnabled && cfg.Server.Mode != "release" {
+		offsetClock = service.NewOffsetClock(clock, cfg.Clock.Offset)
+		clock = offsetClock
+	}
+
+	// Canary deployment, feature flag eksperimen aktif hanya di variant canary
+	canaryService := service.NewCanaryService(cfg.Canary)
+	loadShedder := service.NewLoadShedder(cfg.LoadShed, db, auditService)
+
+	// Health check status page, default cek endpoint publik API ini sendiri
+	statusChecker := service.NewStatusChecker(db, cfg.Status, fmt.Sprintf("http://127.0.0.1:%s%s/discover", cfg.Server.Port, cfg.API.Prefix))
+
+	// Batas features plan aktif (katalog, produk, user, akses API)
+	planEnforcement := service.NewPlanEnforcementService(businessRepository, catalogRepository, clock)
+
 	// Use Cases
-	businessUseCase := usecase.NewBusinessUseCase(db, businessRepository, userRepository, slugService, uploadService)
-	// catalogUseCase := catalogUC.NewCatalogUseCase(db, catalogRepository, businessRepository, slugService)
-	// masterUseCase := masterUC.NewMasterUseCase(db, masterRepository)
+	businessUseCase := usecase.NewBusinessUseCase(db, businessRepository, userRepository, slugService, uploadService, cfg.IPAllowlist, clock, planEnforcement, mailService, cfg.Invite)
+	backupUseCase := backupUC.NewBackupUseCase(db, backupRepository, catalogRepository, businessRepository, slugService, backupStorage, cacheService, searchIndexer, cfg.Backup.Interval, cfg.Backup.RetentionCount, planEnforcement)
+	catalogUseCase := catalogUC.NewCatalogUseCase(db, catalogRepository, businessRepository, slugService, paymentService, notificationService, presenceService, auditService, mediaReplicationService, mediaArchiveService, cacheService, botFilter, backupUseCase, searchIndexer, qrService, cachePurgeLimiter, cfg.CDN.ManualPurgeLimit, cfg.API.PublicCatalogURL, cfg.API.PublicCardURL, cfg.API.PublicRedirectURL, snapshotStorage, cfg.Snapshot.ServeFallback, clock, planEnforcement)
+	integrationUseCase := integrationUC.NewIntegrationUseCase(db, integrationRepository, catalogRepository, businessRepository, marketplaceService, vaultService, planEnforcement)
+	paymentUseCase := paymentUC.NewPaymentUseCase(db, paymentRepository, businessRepository, masterRepository, paymentService, clock)
+	notificationUseCase := notificationUC.NewNotificationUseCase(db, notificationRepository, businessRepository, vaultService, telegramSender, notificationService, cfg.Notification.Telegram.LinkTTL, clock)
+	commentUseCase := commentUC.NewCommentUseCase(db, commentRepository, catalogRepository, businessRepository, notificationService)
+	analyticsUseCase := analyticsUC.NewAnalyticsUseCase(db, analyticsRepository, catalogRepository, businessRepository, botFilter, catalogUseCase)
+	masterUseCase := masterUC.NewMasterUseCase(db, masterRepository)
+	reviewUseCase := reviewUC.NewReviewUseCase(db, reviewRepository, catalogRepository, businessRepository, botFilter, notificationService, cacheService, cfg.Review)
+	inquiryUseCase := inquiryUC.NewInquiryUseCase(db, inquiryRepository, catalogRepository, businessRepository, botFilter, notificationService, cfg.Inquiry, clock)
+	orderUseCase := orderUC.NewOrderUseCase(db, orderRepository, catalogRepository, businessRepository, slugService, botFilter, notificationService, cfg.Order, clock)
+	statusUseCase := statusUC.NewStatusUseCase(db, statusRepository, statusChecker, cfg.Status)
+	authUseCase := authUC.NewAuthUseCase(authRepository, vaultService, mailService, cfg.StepUp, cfg.Auth.SessionIdleTimeout)
 	// userUseCase := userUC.NewUserUseCase(db, userRepository)
 
 	// Handlers
-	healthHandler := handler.NewHealthHandler(db)
-	businessHandler := handler.NewBusinessHandler(businessUseCase, uploadService, validator)
-	// catalogHandler := handler.NewCatalogHandler(catalogUseCase, uploadService, validator)
-	// masterHandler := handler.NewMasterHandler(masterUseCase, validator)
+	healthHandler := handler.NewHealthHandler(db, auditService, canaryService, loadShedder, uploadService, cfg.Readiness)
+	robotsHandler := handler.NewRobotsHandler(cfg.API.Prefix, cfg.API.RobotsDisallowAll)
+	embedHandler := handler.NewEmbedHandler(cfg.API.Prefix)
+	sitemapHandler := handler.NewSitemapHandler(catalogUseCase, cfg.API.Prefix)
+	proofOfWorkHandler := handler.NewProofOfWorkHandler(proofOfWork)
+	businessHandler := handler.NewBusinessHandler(businessUseCase, uploadService, cfg.API.HideInaccessible, validator)
+	catalogHandler := handler.NewCatalogHandler(catalogUseCase, uploadService, cfg.MediaReplication.GeoHeader, cfg.API.HideInaccessible, validator)
+	integrationHandler := handler.NewIntegrationHandler(integrationUseCase, cfg.API.HideInaccessible, validator)
+	paymentHandler := handler.NewPaymentHandler(paymentUseCase, cfg.API.HideInaccessible, validator)
+	notificationHandler := handler.NewNotificationHandler(notificationUseCase, cfg.Notification.WhatsApp, cfg.API.HideInaccessible, validator)
+	commentHandler := handler.NewCommentHandler(commentUseCase, cfg.API.HideInaccessible, validator)
+	backupHandler := handler.NewBackupHandler(backupUseCase, cfg.API.HideInaccessible, validator)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsUseCase, validator, cfg.Analytics, cfg.API.HideInaccessible)
+	masterHandler := handler.NewMasterHandler(masterUseCase, validator)
+	reviewHandler := handler.NewReviewHandler(reviewUseCase, cfg.API.HideInaccessible, validator)
+	inquiryHandler := handler.NewInquiryHandler(inquiryUseCase, cfg.API.HideInaccessible, validator)
+	orderHandler := handler.NewOrderHandler(orderUseCase, cfg.API.HideInaccessible, validator)
+	authHandler := handler.NewAuthHandler(authUseCase, validator)
+	statusHandler := handler.NewStatusHandler(statusUseCase, validator)
+	profilingHandler := handler.NewProfilingHandler(profilerService)
+	clockHandler := handler.NewClockHandler(offsetClock)
 	// userHandler := handler.NewUserHandler(userUseCase, validator)
 
+	// Background jobs
+	scheduler := NewScheduler(log)
+	if cfg.Integration.SyncEnabled {
+		scheduler.AddJob("marketplace_sync", cfg.Integration.SyncCheckInterval, func() error {
+			return integrationUseCase.SyncDue(context.Background())
+		})
+	}
+	if cfg.Notification.SubscriptionReminderEnabled {
+		scheduler.AddJob("subscription_reminder", 24*time.Hour, func() error {
+			return notificationUseCase.NotifyExpiringSubscriptions(context.Background(), cfg.Notification.SubscriptionReminderDays)
+		})
+	}
+	if cfg.Publish.ScheduleEnabled {
+		scheduler.AddJob("scheduled_publish", cfg.Publish.ScheduleCheckInterval, func() error {
+			return catalogUseCase.PublishDue(context.Background(), cfg.Publish.ScheduleBatchSize)
+		})
+	}
+	if cfg.PriceSchedule.Enabled {
+		scheduler.AddJob("price_schedule", cfg.PriceSchedule.CheckInterval, func() error {
+			return catalogUseCase.ApplyDuePriceSchedules(context.Background(), cfg.PriceSchedule.BatchSize)
+		})
+	}
+	if cfg.VisibilitySchedule.Enabled {
+		scheduler.AddJob("visibility_schedule", cfg.VisibilitySchedule.CheckInterval, func() error {
+			return catalogUseCase.ApplyDueVisibilitySchedules(context.Background(), cfg.VisibilitySchedule.BatchSize)
+		})
+	}
+	if cfg.OwnerAlert.Enabled {
+		scheduler.AddJob("owner_alerts", cfg.OwnerAlert.CheckInterval, func() error {
+			return catalogUseCase.AlertEndingDiscounts(context.Background(), cfg.OwnerAlert.DiscountEndingWithin, cfg.OwnerAlert.BatchSize)
+		})
+	}
+	if cfg.DraftReminder.Enabled {
+		scheduler.AddJob("draft_reminder", cfg.DraftReminder.CheckInterval, func() error {
+			return catalogUseCase.RemindAbandonedDrafts(context.Background(), cfg.DraftReminder.AfterDays, cfg.DraftReminder.BatchSize, cfg.DraftReminder.ResumeURL)
+		})
+	}
+	if cfg.Invite.SweepEnabled {
+		scheduler.AddJob("invite_expiry_sweep", cfg.Invite.SweepCheckInterval, func() error {
+			return businessUseCase.ExpireStaleInvites(context.Background(), cfg.Invite.SweepBatchSize)
+		})
+	}
+	if cfg.Backup.Enabled {
+		scheduler.AddJob("catalog_backup", cfg.Backup.CheckInterval, func() error {
+			return backupUseCase.RunDue(context.Background(), cfg.Backup.BatchSize)
+		})
+	}
+	if cfg.Archive.Enabled {
+		scheduler.AddJob("catalog_archive", cfg.Archive.CheckInterval, func() error {
+			return backupUseCase.ArchiveDue(context.Background(), cfg.Archive.InactiveMonths, cfg.Archive.BatchSize)
+		})
+	}
+	if cfg.Snapshot.Enabled {
+		scheduler.AddJob("catalog_snapshot", cfg.Snapshot.Interval, func() error {
+			return catalogUseCase.GenerateSnapshots(context.Background())
+		})
+	}
+	scheduler.AddJob("analytics_visitor_purge", time.Hour, analyticsUseCase.PurgeVisitorData)
+	if cfg.APIUsage.Enabled && cfg.APIUsage.RetentionDays > 0 {
+		scheduler.AddJob("api_usage_purge", 24*time.Hour, apiUsageService.PurgeExpired)
+	}
+	if cfg.Status.CheckEnabled {
+		scheduler.AddJob("status_check", cfg.Status.CheckInterval, statusUseCase.RunChecks)
+		if cfg.Status.RetentionDays > 0 {
+			scheduler.AddJob("status_check_purge", 24*time.Hour, statusUseCase.PurgeChecks)
+		}
+	}
+	if cfg.Partition.Enabled {
+		partitionService := service.NewPartitionService(db, cfg.Partition, log)
+		scheduler.AddJob("partition_maintenance", cfg.Partition.CheckInterval, partitionService.Maintain)
+	}
+	if mediaReplicationService.Enabled() {
+		scheduler.AddJob("media_replication", cfg.MediaReplication.CheckInterval, func() error {
+			return catalogUseCase.ReplicateMedia(context.Background(), cfg.MediaReplication.BatchSize, cfg.MediaReplication.MaxAttempts)
+		})
+	}
+	if mediaArchiveService.Enabled() {
+		scheduler.AddJob("media_archive", cfg.MediaArchive.CheckInterval, func() error {
+			return catalogUseCase.ArchiveColdMedia(context.Background(), cfg.MediaArchive.ColdAfterDays, cfg.MediaArchive.BatchSize, cfg.MediaArchive.MaxAttempts)
+		})
+	}
+	scheduler.Start()
+
 	// Inisialisasi router Gin
 	if cfg.Server.Mode == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	router := gin.New()
+	// Tanpa ini gin mempercayai X-Forwarded-For dari semua IP, sehingga client
+	// bisa memalsukan IP untuk lolos IP allowlist dan rate limit
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid SERVER_TRUSTED_PROXIES: %w", err)
+	}
 
 	// Pasang middleware global
 	router.Use(gin.Recovery())
 	router.Use(middleware.Logger(log))
-	router.Use(middleware.CORS(cfg.CORS))
+	router.Use(middleware.Canary(canaryService, cfg.Canary))
+	router.Use(middleware.TrackInFlight(loadShedder))
+	// Widget embed dan konten katalog publik dibaca dari website merchant mana pun
+	router.Use(middleware.CORS(cfg.CORS, "/embed.js", cfg.API.Prefix+"/embed/", cfg.API.Prefix+"/c/", cfg.API.Prefix+"/public/"))
 
 	// Setup Swagger untuk development
 	setupSwagger(router, cfg)
 
 	// Daftarkan semua rute
-	// setupRoutes(router, cfg, auditService, healthHandler, businessHandler, catalogHandler, masterHandler, userHandler)
-	setupRoutes(router, cfg, auditService, healthHandler, businessHandler, nil, nil, nil)
+	// setupRoutes(router, cfg, auditService, businessRepository, businessRepository, planEnforcement, rateLimiter, idempotencyStore, apiUsageService, loadShedder, authRepository, authUseCase, proofOfWork, healthHandler, robotsHandler, sitemapHandler, embedHandler, proofOfWorkHandler, authHandler, businessHandler, catalogHandler, integrationHandler, paymentHandler, notificationHandler, commentHandler, backupHandler, analyticsHandler, masterHandler, reviewHandler, inquiryHandler, orderHandler, statusHandler, profilingHandler, clockHandler, userHandler)
+	setupRoutes(router, cfg, auditService, businessRepository, businessRepository, planEnforcement, rateLimiter, idempotencyStore, apiUsageService, loadShedder, authRepository, authUseCase, proofOfWork, healthHandler, robotsHandler, sitemapHandler, embedHandler, proofOfWorkHandler, authHandler, businessHandler, catalogHandler, integrationHandler, paymentHandler, notificationHandler, commentHandler, backupHandler, analyticsHandler, masterHandler, reviewHandler, inquiryHandler, orderHandler, statusHandler, profilingHandler, clockHandler, nil)
 
 	// Konfigurasi server HTTP
 	srv := &http.Server{
@@ -121,7 +419,14 @@ func New() (*App, error) {
 		Server: srv,
 		Log:    log,
 		DB:     db,
+		Redis:  redisClient,
 		AuditService: auditService,
+		Scheduler:    scheduler,
+		NotificationService: notificationService,
+		CacheService: cacheService,
+		SearchIndexer: searchIndexer,
+		APIUsageService: apiUsageService,
+		ProfilerService: profilerService,
 	}, nil
 }
 
@@ -158,7 +463,13 @@ func (a *App) Run() {
 	<-quit
 	a.Log.Info("Shutting down server...")
 
-	// Stop audit service  
+	// Stop background jobs dan audit service
+	a.Scheduler.Stop()
+	a.NotificationService.Stop()
+	a.CacheService.Stop()
+	a.SearchIndexer.Stop()
+	a.APIUsageService.Stop()
+	a.ProfilerService.Stop()
 	a.AuditService.Stop()
 
 	// Beri waktu 5 detik untuk menyelesaikan request yang sedang berjalan
@@ -168,6 +479,9 @@ func (a *App) Run() {
 	// Matikan koneksi database dan logger dengan rapi
 	defer a.Log.Sync()
 	defer a.DB.Close()
+	if a.Redis != nil {
+		defer a.Redis.Close()
+	}
 
 	if err := a.Server.Shutdown(ctx); err != nil {
 		a.Log.Fatal("Server forced to shutdown", logger.Error(err))
@@ -181,15 +495,52 @@ func setupRoutes(
 	router *gin.Engine,
 	cfg *config.Config,
 	auditService service.AuditService,
+	memberRepo middleware.MemberRepository,
+	serviceAccountRepo middleware.ServiceAccountRepository,
+	planChecker middleware.PlanFeatureChecker,
+	rateLimiter service.RateLimiter,
+	idempotencyStore service.IdempotencyStore,
+	apiUsageService service.APIUsageService,
+	loadShedder service.LoadShedder,
+	sessionStore middleware.SessionStore,
+	stepUpVerifier middleware.StepUpVerifier,
+	proofOfWork service.ProofOfWork,
 	healthHandler *handler.HealthHandler,
+	robotsHandler *handler.RobotsHandler,
+	sitemapHandler *handler.SitemapHandler,
+	embedHandler *handler.EmbedHandler,
+	proofOfWorkHandler *handler.ProofOfWorkHandler,
+	authHandler *handler.AuthHandler,
 	businessHandler *handler.BusinessHandler,
 	catalogHandler *handler.CatalogHandler,
+	integrationHandler *handler.IntegrationHandler,
+	paymentHandler *handler.PaymentHandler,
+	notificationHandler *handler.NotificationHandler,
+	commentHandler *handler.CommentHandler,
+	backupHandler *handler.BackupHandler,
+	analyticsHandler *handler.AnalyticsHandler,
 	masterHandler *handler.MasterHandler,
+	reviewHandler *handler.ReviewHandler,
+	inquiryHandler *handler.InquiryHandler,
+	orderHandler *handler.OrderHandler,
+	statusHandler *handler.StatusHandler,
+	profilingHandler *handler.ProfilingHandler,
+	clockHandler *handler.ClockHandler,
 	userHandler *handler.UserHandler,
 ) {
 	// Rute Health check (tidak perlu otentikasi)
 	router.GET("/health", healthHandler.Check)
 	router.GET("/health/db", healthHandler.CheckDB)
+	router.GET("/healthz", healthHandler.Healthz)
+	router.GET("/readyz", healthHandler.Readyz)
+	// Metrics memuat traffic dan error per rute, hanya untuk operator
+	router.GET("/metrics", middleware.AdminToken(cfg.API.AdminToken), healthHandler.Metrics)
+	router.GET("/robots.txt", robotsHandler.RobotsTxt)
+	router.GET("/sitemap.xml", sitemapHandler.Sitemap)
+	router.GET("/embed.js", embedHandler.Script)
+
+	// Redirect link publik dengan pencatatan klik
+	router.GET("/r/:token", analyticsHandler.Redirect)
 
 	// Rute untuk file statis (uploads)
 	router.Static("/uploads", "./uploads")
@@ -200,16 +551,115 @@ func setupRoutes(
 		api.GET("/health", healthHandler.Check)
 		api.GET("/health/db", healthHandler.CheckDB)
 
-		// Terapkan middleware otentikasi
+		// Callback payment gateway (diverifikasi dengan callback token, bukan auth user)
+		api.POST("/payments/callback", paymentHandler.CallbackRouter(catalogHandler.PaymentCallback))
+		api.POST("/payments/subscriptions/callback", paymentHandler.Callback)
+
+		// Webhook WhatsApp Cloud API (diverifikasi dengan verify token / signature)
+		api.GET("/webhooks/whatsapp", notificationHandler.VerifyWhatsAppWebhook)
+		api.POST("/webhooks/whatsapp", notificationHandler.WhatsAppWebhook)
+		api.POST("/webhooks/telegram", notificationHandler.TelegramWebhook)
+
+		// Endpoint admin (diverifikasi dengan admin token, bukan auth user)
+		admin := api.Group("/admin")
+		admin.Use(middleware.AdminToken(cfg.API.AdminToken))
+		{
+			admin.POST("/search/reindex", catalogHandler.ReindexSearch)
+			admin.GET("/audit/health", healthHandler.AuditHealth)
+			admin.POST("/media/access-logs", catalogHandler.IngestMediaAccess)
+
+			// Kategori master
+			admin.POST("/masters/categories", masterHandler.CreateCategory)
+			admin.GET("/masters/categories", masterHandler.ListCategories)
+			admin.GET("/masters/categories/:id", masterHandler.GetCategoryByID)
+			admin.PUT("/masters/categories/:id", masterHandler.UpdateCategory)
+			admin.DELETE("/masters/categories/:id", masterHandler.DeleteCategory)
+
+			// Insiden status page
+			admin.POST("/status/incidents", statusHandler.CreateIncident)
+			admin.GET("/status/incidents", statusHandler.ListIncidents)
+			admin.GET("/status/incidents/:id", statusHandler.GetIncidentByID)
+			admin.PUT("/status/incidents/:id", statusHandler.UpdateIncident)
+			admin.DELETE("/status/incidents/:id", statusHandler.DeleteIncident)
+
+			// Profiling
+			if profilingHandler.Enabled() {
+				admin.GET("/debug/pprof/*name", profilingHandler.Pprof)
+				admin.POST("/debug/pprof/*name", profilingHandler.Pprof)
+				admin.POST("/profiles", profilingHandler.CaptureProfile)
+				admin.GET("/profiles/:name", profilingHandler.DownloadProfile)
+			}
+
+			// Time-travel clock untuk QA sandbox
+			if clockHandler.Enabled() {
+				admin.GET("/clock", clockHandler.GetClock)
+				admin.PUT("/clock", clockHandler.SetClockOffset)
+				admin.DELETE("/clock", clockHandler.ResetClock)
+				admin.GET("/clock/businesses/:id", clockHandler.GetBusinessClock)
+				admin.PUT("/clock/businesses/:id", clockHandler.SetBusinessClockOffset)
+				admin.DELETE("/clock/businesses/:id", clockHandler.ResetBusinessClock)
+			}
+		}
+
+		// Status page publik
+		api.GET("/status", statusHandler.GetStatus)
+
+		// Challenge proof-of-work untuk endpoint tulis publik
+		api.GET("/pow/:action", proofOfWorkHandler.Challenge)
+
+		// Katalog publik (tanpa otentikasi)
+		api.GET("/discover", catalogHandler.Discover)
+		api.GET("/categories", masterHandler.ListActiveCategories)
+		api.GET("/public/catalogs", catalogHandler.GetPublicCatalogs)
+		api.GET("/businesses/:id/sitemap.xml", sitemapHandler.BusinessSitemap)
+		api.GET("/c/:slug", catalogHandler.GetPublicCatalog)
+		api.GET("/c/:slug/cards/:card_slug", catalogHandler.GetPublicCard)
+		api.GET("/c/:slug/search", catalogHandler.SearchPublicCards)
+		api.GET("/embed/:slug", catalogHandler.GetEmbedConfig)
+		// Rute prioritas rendah, ditolak lebih dulu saat beban tinggi
+		shed := middleware.LoadShed(loadShedder, cfg.LoadShed.RetryAfter)
+		api.POST("/c/:slug/events", shed, analyticsHandler.RecordEvent)
+		api.POST("/c/:slug/cards/:card_id/save", shed, analyticsHandler.SaveCard)
+		api.DELETE("/c/:slug/cards/:card_id/save", shed, analyticsHandler.UnsaveCard)
+		api.GET("/c/:slug/saves", analyticsHandler.ListVisitorSaves)
+		api.POST("/c/:slug/compare", shed, analyticsHandler.RecordCompare)
+		api.POST("/c/:slug/reviews", middleware.RequireProofOfWork(proofOfWork, constant.PoWActionReview), reviewHandler.Submit)
+		api.GET("/c/:slug/reviews", reviewHandler.ListPublic)
+		api.POST("/c/:slug/inquiries", middleware.RequireProofOfWork(proofOfWork, constant.PoWActionInquiry), inquiryHandler.Submit)
+		api.POST("/c/:slug/orders", middleware.RequireProofOfWork(proofOfWork, constant.PoWActionOrder), orderHandler.Submit)
+
+		// Terapkan middleware otentikasi, token service account dicek lebih dulu
+		api.Use(middleware.ServiceAccountAuth(serviceAccountRepo, planChecker))
+		api.Use(middleware.APIUsage(apiUsageService))
 		if cfg.Auth.Bypass {
 			api.Use(middleware.AuthBypass(cfg.Auth.BypassUserID, cfg.Auth.BypassProfileID))
 		} else {
 			api.Use(middleware.Auth())
 		}
+		api.Use(middleware.TrackSession(sessionStore))
+
+		// Rate limit per user / service account
+		api.Use(middleware.RateLimit(rateLimiter, cfg.RateLimit))
+
+		// Replay response POST yang diulang dengan Idempotency-Key yang sama
+		api.Use(middleware.Idempotency(idempotencyStore))
 
 		// Audit middleware
 		api.Use(middleware.Audit(auditService, nil))
 
+		// Permission member di-cache per request untuk semua rute terautentikasi,
+		// business di param :id rute /businesses langsung di-load
+		api.Use(middleware.PreloadPermissions(memberRepo, cfg.API.Prefix+"/businesses/:id"))
+
+		// Verifikasi tambahan sebelum operasi destruktif
+		auth := api.Group("/auth")
+		{
+			auth.POST("/step-up", authHandler.StepUp)
+			// Selalu wajib step-up email, terlepas dari STEP_UP_ENABLED
+			auth.POST("/totp", middleware.RequireStepUp(stepUpVerifier, true, constant.StepUpActionTOTPEnroll), authHandler.EnrollTOTP)
+			auth.POST("/totp/confirm", authHandler.ConfirmTOTP)
+		}
+
 		// Rute untuk modul Business
 		businesses := api.Group("/businesses")
 		{
@@ -217,20 +667,141 @@ func setupRoutes(
 			businesses.GET("", businessHandler.List)
 			businesses.GET("/:id", businessHandler.GetByID)
 			businesses.PUT("/:id", businessHandler.Update)
-			businesses.DELETE("/:id", businessHandler.Delete)
+			businesses.DELETE("/:id", middleware.RequireStepUp(stepUpVerifier, cfg.StepUp.Enabled, constant.StepUpActionBusinessDelete), businessHandler.Delete)
+			businesses.PUT("/:id/media-replication", businessHandler.UpdateMediaReplication)
+			businesses.GET("/:id/brand", businessHandler.GetBrand)
+			businesses.PUT("/:id/brand", businessHandler.UpdateBrand)
+			businesses.GET("/:id/onboarding", businessHandler.GetOnboarding)
+			businesses.POST("/:id/invites", businessHandler.CreateInvite)
+			businesses.GET("/:id/invites", businessHandler.ListInvites)
+			businesses.POST("/invites/accept", businessHandler.AcceptInvite)
+			businesses.GET("/:id/api-usage", analyticsHandler.GetAPIUsage)
+			businesses.POST("/:id/brand/apply", catalogHandler.ApplyBrand)
+			businesses.POST("/:id/integrations", integrationHandler.Connect)
+			businesses.GET("/:id/integrations", integrationHandler.List)
+			businesses.POST("/:id/subscription-payments", paymentHandler.CreateSubscriptionPayment)
+			businesses.GET("/:id/subscription-payments", paymentHandler.List)
+			businesses.GET("/:id/subscription-payments/:payment_id", paymentHandler.GetByID)
+			businesses.GET("/:id/notifications", notificationHandler.ListChannels)
+			businesses.GET("/:id/notifications/logs", notificationHandler.ListLogs)
+			businesses.PUT("/:id/notifications/whatsapp", notificationHandler.UpsertWhatsApp)
+			businesses.POST("/:id/notifications/telegram/link", notificationHandler.CreateTelegramLink)
+			businesses.DELETE("/:id/notifications/:channel", notificationHandler.DeleteChannel)
+			businesses.GET("/:id/backups", backupHandler.List)
+			businesses.POST("/:id/backups", backupHandler.Create)
+			businesses.POST("/:id/backups/:backup_id/restore", backupHandler.Restore)
+			businesses.POST("/:id/clone", backupHandler.Clone)
+			businesses.GET("/:id/export.xlsx", shed, backupHandler.ExportWorkbook)
+			businesses.POST("/:id/service-accounts", businessHandler.CreateServiceAccount)
+			businesses.GET("/:id/service-accounts", businessHandler.ListServiceAccounts)
+			businesses.DELETE("/:id/service-accounts/:account_id", businessHandler.RevokeServiceAccount)
+			businesses.GET("/:id/ip-allowlist", businessHandler.GetIPAllowlist)
+			businesses.PUT("/:id/ip-allowlist", businessHandler.UpdateIPAllowlist)
+			businesses.POST("/:id/ip-allowlist/break-glass", middleware.RequireStepUp(stepUpVerifier, cfg.StepUp.Enabled, constant.StepUpActionIPAllowlistBreakGlass), businessHandler.BreakGlassIPAllowlist)
 			// TODO: Tambahkan rute untuk user management di dalam business
 		}
 
+		// Rute untuk invite business, ID di path adalah ID invite
+		invites := api.Group("/invites")
+		{
+			invites.POST("/:id/resend", businessHandler.ResendInvite)
+			invites.DELETE("/:id", businessHandler.RevokeInvite)
+		}
+
 		// Rute untuk modul Catalog
-		// catalogs := api.Group("/catalogs")
-		// {
-		// 	catalogs.POST("", catalogHandler.Create)
-		// 	catalogs.GET("", catalogHandler.List)
-		// 	catalogs.GET("/:id", catalogHandler.GetByID)
-		// 	catalogs.PUT("/:id", catalogHandler.Update)
-		// 	catalogs.DELETE("/:id", catalogHandler.Delete)
-		// 	// TODO: Tambahkan rute untuk section dan card management
-		// }
+		catalogs := api.Group("/catalogs")
+		{
+			catalogs.POST("", catalogHandler.Create)
+			catalogs.GET("", catalogHandler.List)
+			catalogs.GET("/search", catalogHandler.Search)
+			catalogs.GET("/trash", catalogHandler.Trash)
+			catalogs.GET("/:id", catalogHandler.GetByID)
+			catalogs.PUT("/:id", catalogHandler.Update)
+			catalogs.DELETE("/:id", catalogHandler.Delete)
+			catalogs.POST("/:id/restore", catalogHandler.Restore)
+			catalogs.DELETE("/:id/purge", catalogHandler.Purge)
+			catalogs.GET("/:id/affiliate-earnings", catalogHandler.GetAffiliateEarnings)
+			catalogs.GET("/:id/analytics", analyticsHandler.GetCatalogAnalytics)
+			catalogs.GET("/:id/analytics/clicks", analyticsHandler.GetClickHeatmap)
+			catalogs.GET("/:id/analytics/goals", analyticsHandler.GetGoalConversions)
+			catalogs.GET("/:id/analytics/saves", analyticsHandler.GetCardSaves)
+			catalogs.POST("/:id/goals", analyticsHandler.CreateGoal)
+			catalogs.GET("/:id/goals", analyticsHandler.ListGoals)
+			catalogs.DELETE("/goals/:goal_id", analyticsHandler.DeleteGoal)
+			catalogs.GET("/:id/reviews", reviewHandler.List)
+			catalogs.PUT("/reviews/:review_id/moderate", reviewHandler.Moderate)
+			catalogs.PUT("/reviews/:review_id/reply", reviewHandler.Reply)
+			catalogs.GET("/:id/inquiries", inquiryHandler.List)
+			catalogs.GET("/inquiries/:inquiry_id", inquiryHandler.GetByID)
+			catalogs.PUT("/inquiries/:inquiry_id/read", inquiryHandler.MarkRead)
+			catalogs.PUT("/inquiries/:inquiry_id/archive", inquiryHandler.Archive)
+			catalogs.GET("/:id/orders", orderHandler.List)
+			catalogs.GET("/orders/:order_id", orderHandler.GetByID)
+			catalogs.PUT("/orders/:order_id/status", orderHandler.UpdateStatus)
+			catalogs.POST("/:id/presence", catalogHandler.Heartbeat)
+			catalogs.GET("/:id/presence", catalogHandler.ListPresence)
+			catalogs.POST("/:id/publish-requests", catalogHandler.SubmitPublishRequest)
+			catalogs.GET("/:id/publish-requests", catalogHandler.ListPublishRequests)
+			catalogs.POST("/:id/qr", catalogHandler.GenerateQR)
+			catalogs.POST("/:id/cache/purge", catalogHandler.PurgeCache)
+			catalogs.PUT("/:id/publish-schedule", catalogHandler.SchedulePublish)
+			catalogs.DELETE("/:id/publish-schedule", catalogHandler.CancelPublishSchedule)
+			catalogs.PUT("/:id/visibility-schedule", catalogHandler.ScheduleCatalogVisibility)
+			catalogs.DELETE("/:id/visibility-schedule", catalogHandler.CancelCatalogVisibilitySchedule)
+			catalogs.POST("/publish-requests/:request_id/approve", catalogHandler.ApprovePublishRequest)
+			catalogs.POST("/publish-requests/:request_id/request-changes", catalogHandler.RequestPublishChanges)
+			catalogs.POST("/cards/:card_id/checkout-link", catalogHandler.CreateCheckoutLink)
+			catalogs.POST("/cards/:card_id/price-schedules", catalogHandler.SchedulePrice)
+			catalogs.GET("/cards/:card_id/price-schedules", catalogHandler.ListPriceSchedules)
+			catalogs.DELETE("/cards/:card_id/price-schedules/:schedule_id", catalogHandler.CancelPriceSchedule)
+			catalogs.PUT("/cards/:card_id/visibility-schedule", catalogHandler.ScheduleCardVisibility)
+			catalogs.DELETE("/cards/:card_id/visibility-schedule", catalogHandler.CancelCardVisibilitySchedule)
+			catalogs.PUT("/cards/:card_id/stock", catalogHandler.UpdateCardStock)
+			catalogs.POST("/cards/:card_id/stock/adjust", catalogHandler.AdjustCardStock)
+			catalogs.PUT("/cards/:card_id/tags", catalogHandler.SetCardTags)
+			catalogs.GET("/:id/cards", catalogHandler.ListCards)
+			catalogs.GET("/:id/tags", catalogHandler.ListTags)
+			catalogs.POST("/:id/tags", catalogHandler.CreateTag)
+			catalogs.PUT("/tags/:tag_id", catalogHandler.UpdateTag)
+			catalogs.DELETE("/tags/:tag_id", catalogHandler.DeleteTag)
+			catalogs.GET("/cards/:card_id", catalogHandler.GetCard)
+			catalogs.PUT("/cards/:card_id", catalogHandler.UpdateCard)
+			catalogs.GET("/cards/:card_id/links", catalogHandler.ListCardLinks)
+			catalogs.POST("/cards/:card_id/links", catalogHandler.CreateCardLink)
+			catalogs.PUT("/cards/:card_id/links/:link_id", catalogHandler.UpdateCardLink)
+			catalogs.DELETE("/cards/:card_id/links/:link_id", catalogHandler.DeleteCardLink)
+			catalogs.POST("/cards/:card_id/comments", commentHandler.CreateOnCard)
+			catalogs.GET("/cards/:card_id/comments", commentHandler.ListByCard)
+			catalogs.POST("/sections/:section_id/comments", commentHandler.CreateOnSection)
+			catalogs.GET("/sections/:section_id/comments", commentHandler.ListBySection)
+			catalogs.POST("/sections/:section_id/faqs", catalogHandler.CreateFAQs)
+			catalogs.PUT("/sections/:section_id/faqs", catalogHandler.ReplaceFAQs)
+			catalogs.DELETE("/sections/:section_id/faqs", catalogHandler.DeleteFAQs)
+			catalogs.PUT("/sections/:section_id/faqs/reorder", catalogHandler.ReorderFAQs)
+			catalogs.PUT("/faqs/:faq_id", catalogHandler.UpdateFAQ)
+			catalogs.DELETE("/faqs/:faq_id", catalogHandler.DeleteFAQ)
+			catalogs.POST("/sections/:section_id/legal", catalogHandler.CreateLegalVersion)
+			catalogs.GET("/sections/:section_id/legal", catalogHandler.ListLegalVersions)
+			catalogs.POST("/sections/:section_id/cards/import", catalogHandler.ImportCards)
+			catalogs.PUT("/sections/:section_id/position", catalogHandler.MoveSection)
+			catalogs.PUT("/:id/sections/reorder", catalogHandler.ReorderSections)
+			catalogs.PUT("/cards/:card_id/position", catalogHandler.MoveCard)
+			// TODO: Tambahkan rute untuk section dan card management
+		}
+
+		// Rute untuk komentar internal
+		comments := api.Group("/comments")
+		{
+			comments.PUT("/:comment_id/resolve", commentHandler.Resolve)
+		}
+
+		// Rute untuk integrasi marketplace
+		integrations := api.Group("/integrations")
+		{
+			integrations.DELETE("/:integration_id", integrationHandler.Disconnect)
+			integrations.POST("/:integration_id/sync", integrationHandler.Sync)
+			integrations.GET("/:integration_id/mappings", integrationHandler.ListMappings)
+		}
 
 		// // Rute untuk modul Master Data
 		// masters := api.Group("/masters")
@@ -250,6 +821,14 @@ func setupRoutes(
 		// 	masters.DELETE("/themes/:id", masterHandler.DeleteTheme)
 		// }
 
+		// Sesi login (perangkat) milik user
+		sessions := api.Group("/profile/sessions")
+		{
+			sessions.GET("", authHandler.ListSessions)
+			sessions.DELETE("", authHandler.RevokeOtherSessions)
+			sessions.DELETE("/:session_id", authHandler.RevokeSession)
+		}
+
 		// profile := api.Group("/profile")
 		// {
 		// 	profile.GET("", userHandler.GetProfile)
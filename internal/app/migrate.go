@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/joho/godotenv"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/database/migrations"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+const migrateUsage = `usage: catalogd migrate <command>
+
+commands:
+  up             jalankan semua migrasi yang belum dijalankan
+  down [n]       rollback n migrasi terakhir (default 1)
+  status         tampilkan versi skema dan migrasi tertunda
+  force <ver>    set versi skema tanpa menjalankan SQL (0 = kosong)`
+
+// RunMigrate subcommand `catalogd migrate` untuk menjalankan migrasi tanpa start server
+func RunMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	_ = godotenv.Load()
+	cfg := config.Load()
+	log := logger.New(cfg.Log.Level, cfg.Log.Format)
+
+	db, err := database.NewPostgresDB(cfg.Database, log)
+	if err != nil {
+		return fmt.Errorf("failed to init database: %w", err)
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db, migrations.FS, log)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) applied\n", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migration(s) rolled back\n", reverted)
+
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("version: %d (dirty: %t)\n", status.Version, status.Dirty)
+		for _, migration := range status.Pending {
+			fmt.Printf("pending: %03d_%s\n", migration.Version, migration.Name)
+		}
+
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+		fmt.Printf("schema version set to %d\n", version)
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// Scheduler menjalankan background job secara periodik
+type Scheduler struct {
+	log  logger.Logger
+	jobs []schedulerJob
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+type schedulerJob struct {
+	name     string
+	interval time.Duration
+	fn       func() error
+}
+
+// NewScheduler membuat instance scheduler baru
+func NewScheduler(log logger.Logger) *Scheduler {
+	return &Scheduler{
+		log:  log,
+		stop: make(chan struct{}),
+	}
+}
+
+// AddJob daftarkan job, harus dipanggil sebelum Start
+func (s *Scheduler) AddJob(name string, interval time.Duration, fn func() error) {
+	s.jobs = append(s.jobs, schedulerJob{name: name, interval: interval, fn: fn})
+}
+
+// Start jalankan semua job di goroutine masing-masing
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.run(job)
+	}
+}
+
+// Stop hentikan semua job dan tunggu job yang sedang berjalan
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(job schedulerJob) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	s.log.Info("Scheduler job started",
+		logger.String("job", job.name),
+		logger.Duration("interval", job.interval),
+	)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.execute(job)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// execute jalankan satu iterasi job, panic tidak menghentikan scheduler
+func (s *Scheduler) execute(job schedulerJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error("Scheduler job panicked",
+				logger.String("job", job.name),
+				logger.Any("panic", r),
+			)
+		}
+	}()
+
+	start := time.Now()
+	if err := job.fn(); err != nil {
+		s.log.Error("Scheduler job failed",
+			logger.String("job", job.name),
+			logger.Error(err),
+		)
+		return
+	}
+
+	s.log.Debug("Scheduler job finished",
+		logger.String("job", job.name),
+		logger.Duration("duration", time.Since(start)),
+	)
+}
@@ -16,14 +16,53 @@ type Config struct {
 	Upload   UploadConfig
 	API      APIConfig
 	Auth     AuthConfig
+	Integration IntegrationConfig
+	Payment     PaymentConfig
+	Notification NotificationConfig
+	Redis        RedisConfig
+	Presence     PresenceConfig
+	Publish      PublishConfig
+	PriceSchedule PriceScheduleConfig
+	VisibilitySchedule VisibilityScheduleConfig
+	OwnerAlert   OwnerAlertConfig
+	RateLimit    RateLimitConfig
+	Idempotency  IdempotencyConfig
+	StepUp       StepUpConfig
+	Mail         MailConfig
+	IPAllowlist  IPAllowlistConfig
+	DraftReminder DraftReminderConfig
+	Invite        InviteConfig
+	Backup       BackupConfig
+	Archive      ArchiveConfig
+	Snapshot     SnapshotConfig
+	MediaReplication MediaReplicationConfig
+	MediaArchive MediaArchiveConfig
+	CDN          CDNConfig
+	Search       SearchConfig
+	Analytics    AnalyticsConfig
+	Review       ReviewConfig
+	Inquiry      InquiryConfig
+	Order        OrderConfig
+	Partition    PartitionConfig
+	Audit        AuditConfig
+	QR           QRConfig
+	APIUsage     APIUsageConfig
+	Status       StatusConfig
+	Canary       CanaryConfig
+	LoadShed     LoadShedConfig
+	Readiness    ReadinessConfig
+	Profiling    ProfilingConfig
+	ProofOfWork  ProofOfWorkConfig
+	Clock        ClockConfig
 }
 
 // ServerConfig konfigurasi server HTTP
 type ServerConfig struct {
-	Port         string
-	Mode         string        // debug, release, test
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
+	Port           string
+	Mode           string // debug, release, test
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+	TrustedProxies []string // IP/CIDR reverse proxy yang X-Forwarded-For-nya dipercaya, kosong = pakai IP koneksi
 }
 
 // DatabaseConfig konfigurasi koneksi database
@@ -37,6 +76,11 @@ type DatabaseConfig struct {
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
+	SeedOnBoot      bool // isi master data default saat tabel kosong
+	SlowQueryThreshold      time.Duration // 0 = log query lambat nonaktif
+	SlowQueryAnalyzePercent int           // sampling EXPLAIN ANALYZE untuk SELECT lambat
+	StatementTimeout        time.Duration // 0 = tanpa batas waktu per statement
+	MigrateOnStartup        bool          // jalankan migrasi yang tertunda sebelum server start
 }
 
 // LogConfig konfigurasi logging
@@ -73,6 +117,24 @@ type CloudinaryConfig struct {
 type APIConfig struct {
 	Prefix  string
 	Timeout time.Duration
+
+	// Larang semua crawler (staging/development)
+	RobotsDisallowAll bool
+
+	// Balas 404 (bukan 403) ke non-anggota agar keberadaan resource tidak bocor
+	HideInaccessible bool
+
+	// Token header X-Admin-Token untuk endpoint admin, kosong = endpoint admin nonaktif
+	AdminToken string
+
+	// Template canonical URL halaman publik, kosong = canonical_url tidak dikirim.
+	// {slug} diganti slug katalog, {card_slug} diganti slug detail card
+	PublicCatalogURL string
+	PublicCardURL    string
+
+	// Template URL redirect link dengan pencatatan klik, {token} diganti token link.
+	// Kosong = katalog publik mengirim URL asli link
+	PublicRedirectURL string
 }
 
 // AuthConfig konfigurasi autentikasi
@@ -80,16 +142,419 @@ type AuthConfig struct {
 	Bypass          bool
 	BypassUserID    string
 	BypassProfileID int64
+
+	// Sesi tanpa aktivitas lebih lama dari ini tidak ditampilkan sebagai aktif
+	SessionIdleTimeout time.Duration
+}
+
+// IntegrationConfig konfigurasi integrasi marketplace
+type IntegrationConfig struct {
+	SyncEnabled       bool
+	SyncCheckInterval time.Duration
+	HTTPTimeout       time.Duration
+	Shopee            ShopeeConfig
+	Tokopedia         TokopediaConfig
+}
+
+// ShopeeConfig konfigurasi Shopee Open Platform
+type ShopeeConfig struct {
+	BaseURL    string
+	PartnerID  int64
+	PartnerKey string
+}
+
+// TokopediaConfig konfigurasi Tokopedia Open API
+type TokopediaConfig struct {
+	BaseURL      string
+	AuthURL      string
+	FSID         string
+	ClientID     string
+	ClientSecret string
+}
+
+// PaymentConfig konfigurasi payment gateway (Xendit invoice)
+type PaymentConfig struct {
+	BaseURL       string
+	SecretKey     string
+	CallbackToken string
+	RedirectURL   string
+	LinkExpiry    time.Duration
+	HTTPTimeout   time.Duration
+}
+
+// NotificationConfig konfigurasi notifikasi ke owner business
+type NotificationConfig struct {
+	VaultKey    string // kunci enkripsi kredensial channel
+	HTTPTimeout time.Duration
+	WhatsApp    WhatsAppConfig
+	Telegram    TelegramConfig
+
+	// Reminder subscription yang akan berakhir
+	SubscriptionReminderEnabled bool
+	SubscriptionReminderDays    int
+}
+
+// WhatsAppConfig konfigurasi WhatsApp Business Cloud API
+type WhatsAppConfig struct {
+	BaseURL     string
+	APIVersion  string
+	VerifyToken string // token verifikasi webhook
+	AppSecret   string // untuk validasi X-Hub-Signature-256
+}
+
+// RedisConfig konfigurasi koneksi Redis
+type RedisConfig struct {
+	Addr        string
+	Password    string
+	DB          int
+	PoolSize    int
+	DialTimeout time.Duration
+	ReadTimeout time.Duration
+}
+
+// PresenceConfig konfigurasi presence editor katalog
+type PresenceConfig struct {
+	TTL time.Duration // editor dianggap pergi jika tidak heartbeat selama TTL
+}
+
+// PublishConfig konfigurasi publish terjadwal katalog
+type PublishConfig struct {
+	ScheduleEnabled       bool
+	ScheduleCheckInterval time.Duration
+	ScheduleBatchSize     int
+}
+
+// PriceScheduleConfig konfigurasi perubahan harga card terjadwal
+type PriceScheduleConfig struct {
+	Enabled       bool
+	CheckInterval time.Duration
+	BatchSize     int
+}
+
+// VisibilityScheduleConfig konfigurasi tampil/sembunyi katalog dan card terjadwal
+type VisibilityScheduleConfig struct {
+	Enabled       bool
+	CheckInterval time.Duration
+	BatchSize     int
+}
+
+// OwnerAlertConfig konfigurasi evaluator notifikasi ambang batas untuk owner
+type OwnerAlertConfig struct {
+	Enabled              bool
+	CheckInterval        time.Duration
+	DiscountEndingWithin time.Duration // diskon yang berakhir dalam rentang ini dinotifikasi
+	BatchSize            int
+}
+
+// RateLimitConfig konfigurasi rate limit API terautentikasi
+type RateLimitConfig struct {
+	Enabled     bool
+	Requests    int           // limit dasar per principal dalam Window
+	Window      time.Duration
+	Multipliers map[string]float64 // pengali limit per role principal (user, service_account)
+}
+
+// IdempotencyConfig konfigurasi header Idempotency-Key pada request POST terautentikasi
+type IdempotencyConfig struct {
+	Enabled bool
+	TTL     time.Duration // lama response disimpan untuk di-replay
+	LockTTL time.Duration // lama key terkunci selama request pertama diproses
+}
+
+// StepUpConfig konfigurasi verifikasi tambahan (kode email/TOTP) sebelum operasi destruktif
+type StepUpConfig struct {
+	Enabled     bool
+	CodeTTL     time.Duration // masa berlaku kode email
+	TokenTTL    time.Duration // masa berlaku token step-up setelah verifikasi
+	MaxAttempts int           // maksimal percobaan kode per challenge, juga per profile untuk authenticator
+	TOTPIssuer  string        // nama issuer di aplikasi authenticator
+	TOTPLockout time.Duration // lama authenticator dikunci setelah MaxAttempts kode salah
+}
+
+// MailConfig konfigurasi pengiriman email lewat SMTP
+type MailConfig struct {
+	SMTPHost string
+	SMTPPort string
+	Username string
+	Password string
+	From     string
+}
+
+// IPAllowlistConfig konfigurasi IP allowlist business untuk operasi tulis
+type IPAllowlistConfig struct {
+	MaxEntries         int           // maksimal entri IP/CIDR per business
+	BreakGlassDuration time.Duration // lama allowlist dibuka oleh break-glass owner
+}
+
+// DraftReminderConfig konfigurasi pengingat katalog draft yang belum pernah dipublish
+type DraftReminderConfig struct {
+	Enabled       bool
+	AfterDays     int    // draft lebih tua dari N hari dikirimi pengingat
+	ResumeURL     string // template link lanjut edit, {id} diganti ID katalog
+	CheckInterval time.Duration
+	BatchSize     int
+}
+
+// InviteConfig konfigurasi invite business dan job penanda invite yang kadaluarsa
+type InviteConfig struct {
+	AcceptURL          string // template link terima invite, {token} diganti token invite
+	SweepEnabled       bool
+	SweepCheckInterval time.Duration
+	SweepBatchSize     int
+}
+
+// BackupConfig konfigurasi backup otomatis katalog per business
+type BackupConfig struct {
+	Enabled        bool
+	Path           string        // direktori backup storage
+	Interval       time.Duration // jarak minimal antar backup terjadwal per business
+	CheckInterval  time.Duration
+	BatchSize      int
+	RetentionCount int // jumlah backup berhasil yang disimpan per business
+}
+
+// SnapshotConfig konfigurasi snapshot JSON katalog publik, cadangan baca saat database bermasalah
+type SnapshotConfig struct {
+	Enabled       bool
+	Path          string        // direktori snapshot, satu file per slug
+	Interval      time.Duration // jarak antar pembuatan snapshot
+	ServeFallback bool          // layani GET katalog publik dari snapshot saat query database gagal
+}
+
+// ArchiveConfig konfigurasi arsip katalog tidak aktif ke cold storage (backup storage)
+type ArchiveConfig struct {
+	Enabled        bool
+	InactiveMonths int // katalog tanpa perubahan dan kunjungan selama N bulan diarsipkan
+	CheckInterval  time.Duration
+	BatchSize      int
+}
+
+// MediaReplicationConfig konfigurasi replikasi media ke region/CDN kedua
+type MediaReplicationConfig struct {
+	Enabled          bool
+	CloudName        string
+	APIKey           string
+	APISecret        string
+	Folder           string
+	GeoHeader        string   // header negara pengunjung dari CDN/proxy, mis. CF-IPCountry
+	PrimaryCountries []string // negara yang dilayani region utama
+	CheckInterval    time.Duration
+	BatchSize        int
+	MaxAttempts      int
+}
+
+// MediaArchiveConfig konfigurasi pemindahan original media yang jarang diakses
+// ke akun Cloudinary infrequent-access, thumbnail tetap di storage utama
+type MediaArchiveConfig struct {
+	Enabled       bool
+	CloudName     string
+	APIKey        string
+	APISecret     string
+	Folder        string
+	Quality       string // kualitas kompresi saat dipindah, mis. auto:eco
+	ColdAfterDays int    // media tanpa akses selama N hari dianggap dingin
+	CheckInterval time.Duration
+	BatchSize     int
+	MaxAttempts   int
+}
+
+// AnalyticsConfig konfigurasi pencatatan analytics katalog publik
+type AnalyticsConfig struct {
+	BotFilterEnabled bool
+	ChallengeSecret  string        // kosong = verifikasi token JS-challenge nonaktif
+	ChallengeMinAge  time.Duration // event lebih cepat dari ini setelah halaman dimuat dianggap bot
+	ChallengeTTL     time.Duration
+	VisitorCookie    string        // cookie id pengunjung anonim untuk wishlist dan compare
+	VisitorCookieTTL time.Duration
+}
+
+// ReviewConfig konfigurasi ulasan (rating bintang) katalog publik
+type ReviewConfig struct {
+	RateLimit  int           // maksimal ulasan per pengunjung dalam RateWindow
+	RateWindow time.Duration
+	HashSecret string // secret HMAC identitas pengunjung, IP tidak disimpan
+}
+
+// InquiryConfig konfigurasi form inquiry / kontak katalog publik
+type InquiryConfig struct {
+	RateLimit  int           // maksimal inquiry per pengunjung dalam RateWindow
+	RateWindow time.Duration
+	HashSecret string // secret HMAC identitas pengunjung, IP tidak disimpan
+}
+
+// OrderConfig konfigurasi order capture (lead) katalog publik
+type OrderConfig struct {
+	RateLimit  int           // maksimal order per pengunjung dalam RateWindow
+	RateWindow time.Duration
+	HashSecret string // secret HMAC identitas pengunjung, IP tidak disimpan
+}
+
+// PartitionConfig konfigurasi partisi bulanan tabel audit dan analytics
+type PartitionConfig struct {
+	Enabled                  bool
+	PremakeMonths            int // jumlah partisi bulan depan yang disiapkan
+	AuditRetentionMonths     int // 0 = simpan selamanya
+	AnalyticsRetentionMonths int // 0 = simpan selamanya
+	CheckInterval            time.Duration
+}
+
+// AuditConfig konfigurasi alert pipeline audit log
+type AuditConfig struct {
+	AlertEmail    string        // penerima alert saat entry audit hilang, kosong = alert nonaktif
+	AlertCooldown time.Duration // jeda minimal antar email alert
+	Sink          AuditSinkConfig
+	Redaction     AuditRedactionConfig
+}
+
+// AuditRedactionConfig konfigurasi penyamaran field sensitif di old/new data audit log
+type AuditRedactionConfig struct {
+	Enabled     bool
+	Fields      []string            // nama key JSON untuk semua tabel
+	TableFields map[string][]string // tambahan per tabel audit
+}
+
+// AuditSinkConfig konfigurasi pengiriman salinan audit log ke SIEM
+type AuditSinkConfig struct {
+	Provider      string // http, syslog, kosong = nonaktif
+	URL           string // endpoint bulk HTTP (NDJSON)
+	AuthHeader    string // nilai header Authorization, mis. "Bearer xxx" atau "Splunk xxx"
+	SyslogNetwork string // udp, tcp
+	SyslogAddress string // host:port
+	SyslogTag     string
+	HTTPTimeout   time.Duration
+	QueueSize     int // jumlah batch yang boleh antri sebelum batch baru dibuang
+	MaxAttempts   int
+}
+
+// CDNConfig konfigurasi purge cache CDN saat konten katalog publik berubah
+type CDNConfig struct {
+	Provider    string   // cloudflare, fastly, kosong = nonaktif
+	PurgeURLs   []string // template URL publik, {slug} diganti slug katalog
+	PurgeCardURLs []string // template URL detail card, {slug} dan {card_slug}
+	HTTPTimeout time.Duration
+
+	// Batas purge manual oleh pemilik per katalog
+	ManualPurgeLimit  int
+	ManualPurgeWindow time.Duration
+	Cloudflare  CloudflareConfig
+	Fastly      FastlyConfig
+}
+
+// SearchConfig konfigurasi search engine katalog
+type SearchConfig struct {
+	Provider    string // meilisearch, elasticsearch, kosong = Postgres full-text search
+	URL         string
+	APIKey      string
+	Index       string
+	HTTPTimeout time.Duration
+	MaxHits     int // batas hasil dari search engine sebelum difilter & dipaginasi database
+}
+
+// APIUsageConfig konfigurasi pencatatan pemakaian API service account
+type APIUsageConfig struct {
+	Enabled       bool
+	FlushInterval time.Duration // agregat di memori ditulis ke database tiap interval
+	RetentionDays int           // 0 = simpan selamanya
+}
+
+// StatusConfig konfigurasi health check untuk status page publik
+type StatusConfig struct {
+	CheckEnabled    bool
+	CheckInterval   time.Duration
+	CheckTimeout    time.Duration
+	RetentionDays   int    // riwayat health check, 0 = simpan selamanya
+	IncidentDays    int    // insiden selesai yang masih ditampilkan
+	UploadsCheckURL string // kosong = komponen uploads tidak dicek
+	PublicCheckURL  string // kosong = endpoint /discover API ini sendiri
+}
+
+// CanaryConfig konfigurasi canary deployment berbasis feature flag
+type CanaryConfig struct {
+	Enabled   bool
+	Percent   int      // persentase pengunjung baru yang masuk variant canary
+	Header    string   // header internal untuk memaksa variant (stable, canary)
+	Cookie    string   // cookie variant supaya pengunjung tetap di variant yang sama
+	CookieTTL time.Duration
+	Flags     []string // feature flag yang aktif di variant canary
+}
+
+// LoadShedConfig ambang load shedding, request prioritas rendah ditolak 503
+// jika salah satu ambang terlewati. Nilai 0 = ambang tidak dicek
+type LoadShedConfig struct {
+	Enabled           bool
+	MaxInFlight       int // jumlah request yang sedang diproses
+	DBPoolPercent     int // persentase koneksi database yang sedang dipakai dari DB_MAX_OPEN_CONNS
+	AuditQueuePercent int // persentase isi queue audit
+	RetryAfter        time.Duration
+}
+
+// ReadinessConfig konfigurasi probe /readyz
+type ReadinessConfig struct {
+	Timeout           time.Duration // batas waktu setiap pengecekan dependency
+	RequireCloudinary bool          // false = Cloudinary tidak terjangkau hanya dilaporkan, instance tetap ready
+	CloudinaryTTL     time.Duration // hasil cek Cloudinary di-cache agar probe tidak selalu keluar jaringan
+}
+
+// ProfilingConfig konfigurasi pprof dan capture profile on-demand (admin)
+type ProfilingConfig struct {
+	Enabled         bool
+	Path            string        // direktori penyimpanan hasil capture
+	CaptureDuration time.Duration // durasi default capture CPU profile
+	MaxDuration     time.Duration
+}
+
+// ProofOfWorkConfig konfigurasi proof-of-work endpoint tulis publik, pengganti captcha
+type ProofOfWorkConfig struct {
+	Enabled    bool
+	Secret     string        // kosong = secret acak per proses, hanya untuk satu instance
+	Difficulty int           // jumlah bit nol di awal hash sha256
+	TTL        time.Duration // umur challenge
+}
+
+// ClockConfig konfigurasi time-travel untuk QA alur kedaluwarsa di lingkungan sandbox
+type ClockConfig struct {
+	TimeTravelEnabled bool          // offset bisa diubah admin lewat /admin/clock, diabaikan di mode release
+	Offset            time.Duration // offset awal terhadap waktu sistem
+}
+
+// QRConfig konfigurasi QR code katalog
+type QRConfig struct {
+	Format string // png, svg
+	Size   int    // lebar/tinggi PNG dalam pixel
+	Folder string // folder Cloudinary
+}
+
+// CloudflareConfig konfigurasi Cloudflare API
+type CloudflareConfig struct {
+	BaseURL  string
+	ZoneID   string
+	APIToken string
+}
+
+// FastlyConfig konfigurasi Fastly API
+type FastlyConfig struct {
+	BaseURL  string
+	APIToken string
+}
+
+// TelegramConfig konfigurasi Telegram Bot API
+type TelegramConfig struct {
+	BaseURL       string
+	BotToken      string
+	BotUsername   string
+	WebhookSecret string // dicocokkan dengan header X-Telegram-Bot-Api-Secret-Token
+	LinkTTL       time.Duration
 }
 
 // Load membaca konfigurasi dari environment variables
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", ""),
-			Mode:         getEnv("SERVER_MODE", ""),
-			ReadTimeout:  getDuration("SERVER_READ_TIMEOUT", ""),
-			WriteTimeout: getDuration("SERVER_WRITE_TIMEOUT", ""),
+			Port:           getEnv("SERVER_PORT", ""),
+			Mode:           getEnv("SERVER_MODE", ""),
+			ReadTimeout:    getDuration("SERVER_READ_TIMEOUT", ""),
+			WriteTimeout:   getDuration("SERVER_WRITE_TIMEOUT", ""),
+			TrustedProxies: getEnvAsSlice("SERVER_TRUSTED_PROXIES", []string{}),
 		},
 		Database: DatabaseConfig{
 			Host:            getEnv("DB_HOST", ""),
@@ -101,6 +566,11 @@ func Load() *Config {
 			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 0),
 			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 0),
 			ConnMaxLifetime: getDuration("DB_CONN_MAX_LIFETIME", ""),
+			SeedOnBoot:      getEnvAsBool("DB_SEED_ON_BOOT", false),
+			SlowQueryThreshold:      getDuration("DB_SLOW_QUERY_THRESHOLD", ""),
+			SlowQueryAnalyzePercent: getEnvAsInt("DB_SLOW_QUERY_ANALYZE_PERCENT", 10),
+			StatementTimeout:        getDuration("DB_STATEMENT_TIMEOUT", "30s"),
+			MigrateOnStartup:        getEnvAsBool("DB_MIGRATE_ON_STARTUP", false),
 		},
 		Log: LogConfig{
 			Level:  getEnv("LOG_LEVEL", ""),
@@ -109,7 +579,7 @@ func Load() *Config {
 		CORS: CORSConfig{
 			AllowedOrigins:   getEnvAsSlice("CORS_ALLOWED_ORIGINS", []string{"http://localhost:3000"}),
 			AllowedMethods:   getEnvAsSlice("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-			AllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization"}),
+			AllowedHeaders:   getEnvAsSlice("CORS_ALLOWED_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization", "If-Match", "If-None-Match"}),
 			AllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", true),
 		},
 		Upload: UploadConfig{
@@ -126,11 +596,318 @@ func Load() *Config {
 		API: APIConfig{
 			Prefix:  getEnv("API_PREFIX", ""),
 			Timeout: getDuration("API_TIMEOUT", ""),
+
+			RobotsDisallowAll: getEnvAsBool("ROBOTS_DISALLOW_ALL", false),
+			HideInaccessible:  getEnvAsBool("API_HIDE_INACCESSIBLE", true),
+			AdminToken:        getEnv("API_ADMIN_TOKEN", ""),
+
+			PublicCatalogURL: getEnv("PUBLIC_CATALOG_URL", ""),
+			PublicCardURL:    getEnv("PUBLIC_CARD_URL", ""),
+			PublicRedirectURL: getEnv("PUBLIC_REDIRECT_URL", ""),
 		},
 		Auth: AuthConfig{
 			Bypass:          getEnvAsBool("AUTH_BYPASS", false),
 			BypassUserID:    getEnv("AUTH_BYPASS_USER_ID", ""),
 			BypassProfileID: getEnvAsInt64("AUTH_BYPASS_PROFILE_ID", 0),
+
+			SessionIdleTimeout: getDuration("AUTH_SESSION_IDLE_TIMEOUT", "720h"),
+		},
+		Integration: IntegrationConfig{
+			SyncEnabled:       getEnvAsBool("INTEGRATION_SYNC_ENABLED", false),
+			SyncCheckInterval: getDuration("INTEGRATION_SYNC_CHECK_INTERVAL", "5m"),
+			HTTPTimeout:       getDuration("INTEGRATION_HTTP_TIMEOUT", "30s"),
+			Shopee: ShopeeConfig{
+				BaseURL:    getEnv("SHOPEE_BASE_URL", "https://partner.shopeemobile.com"),
+				PartnerID:  getEnvAsInt64("SHOPEE_PARTNER_ID", 0),
+				PartnerKey: getEnv("SHOPEE_PARTNER_KEY", ""),
+			},
+			Tokopedia: TokopediaConfig{
+				BaseURL:      getEnv("TOKOPEDIA_BASE_URL", "https://fs.tokopedia.net"),
+				AuthURL:      getEnv("TOKOPEDIA_AUTH_URL", "https://accounts.tokopedia.com/token"),
+				FSID:         getEnv("TOKOPEDIA_FS_ID", ""),
+				ClientID:     getEnv("TOKOPEDIA_CLIENT_ID", ""),
+				ClientSecret: getEnv("TOKOPEDIA_CLIENT_SECRET", ""),
+			},
+		},
+		Payment: PaymentConfig{
+			BaseURL:       getEnv("PAYMENT_BASE_URL", "https://api.xendit.co"),
+			SecretKey:     getEnv("PAYMENT_SECRET_KEY", ""),
+			CallbackToken: getEnv("PAYMENT_CALLBACK_TOKEN", ""),
+			RedirectURL:   getEnv("PAYMENT_REDIRECT_URL", ""),
+			LinkExpiry:    getDuration("PAYMENT_LINK_EXPIRY", "24h"),
+			HTTPTimeout:   getDuration("PAYMENT_HTTP_TIMEOUT", "30s"),
+		},
+		Notification: NotificationConfig{
+			VaultKey:    getEnv("VAULT_ENCRYPTION_KEY", ""),
+			HTTPTimeout: getDuration("NOTIFICATION_HTTP_TIMEOUT", "15s"),
+			WhatsApp: WhatsAppConfig{
+				BaseURL:     getEnv("WHATSAPP_BASE_URL", "https://graph.facebook.com"),
+				APIVersion:  getEnv("WHATSAPP_API_VERSION", "v19.0"),
+				VerifyToken: getEnv("WHATSAPP_VERIFY_TOKEN", ""),
+				AppSecret:   getEnv("WHATSAPP_APP_SECRET", ""),
+			},
+			Telegram: TelegramConfig{
+				BaseURL:       getEnv("TELEGRAM_BASE_URL", "https://api.telegram.org"),
+				BotToken:      getEnv("TELEGRAM_BOT_TOKEN", ""),
+				BotUsername:   getEnv("TELEGRAM_BOT_USERNAME", ""),
+				WebhookSecret: getEnv("TELEGRAM_WEBHOOK_SECRET", ""),
+				LinkTTL:       getDuration("TELEGRAM_LINK_TTL", "30m"),
+			},
+			SubscriptionReminderEnabled: getEnvAsBool("NOTIFICATION_SUBSCRIPTION_REMINDER_ENABLED", false),
+			SubscriptionReminderDays:    getEnvAsInt("NOTIFICATION_SUBSCRIPTION_REMINDER_DAYS", 3),
+		},
+		Redis: RedisConfig{
+			Addr:        getEnv("REDIS_ADDR", ""),
+			Password:    getEnv("REDIS_PASSWORD", ""),
+			DB:          getEnvAsInt("REDIS_DB", 0),
+			PoolSize:    getEnvAsInt("REDIS_POOL_SIZE", 10),
+			DialTimeout: getDuration("REDIS_DIAL_TIMEOUT", "5s"),
+			ReadTimeout: getDuration("REDIS_READ_TIMEOUT", "3s"),
+		},
+		Presence: PresenceConfig{
+			TTL: getDuration("PRESENCE_TTL", "45s"),
+		},
+		Publish: PublishConfig{
+			ScheduleEnabled:       getEnvAsBool("PUBLISH_SCHEDULE_ENABLED", true),
+			ScheduleCheckInterval: getDuration("PUBLISH_SCHEDULE_CHECK_INTERVAL", "1m"),
+			ScheduleBatchSize:     getEnvAsInt("PUBLISH_SCHEDULE_BATCH_SIZE", 50),
+		},
+		PriceSchedule: PriceScheduleConfig{
+			Enabled:       getEnvAsBool("PRICE_SCHEDULE_ENABLED", true),
+			CheckInterval: getDuration("PRICE_SCHEDULE_CHECK_INTERVAL", "1m"),
+			BatchSize:     getEnvAsInt("PRICE_SCHEDULE_BATCH_SIZE", 100),
+		},
+		VisibilitySchedule: VisibilityScheduleConfig{
+			Enabled:       getEnvAsBool("VISIBILITY_SCHEDULE_ENABLED", true),
+			CheckInterval: getDuration("VISIBILITY_SCHEDULE_CHECK_INTERVAL", "1m"),
+			BatchSize:     getEnvAsInt("VISIBILITY_SCHEDULE_BATCH_SIZE", 100),
+		},
+		OwnerAlert: OwnerAlertConfig{
+			Enabled:              getEnvAsBool("OWNER_ALERT_ENABLED", true),
+			CheckInterval:        getDuration("OWNER_ALERT_CHECK_INTERVAL", "15m"),
+			DiscountEndingWithin: getDuration("OWNER_ALERT_DISCOUNT_ENDING_WITHIN", "24h"),
+			BatchSize:            getEnvAsInt("OWNER_ALERT_BATCH_SIZE", 100),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:     getEnvAsBool("RATE_LIMIT_ENABLED", true),
+			Requests:    getEnvAsInt("RATE_LIMIT_REQUESTS", 120),
+			Window:      getDuration("RATE_LIMIT_WINDOW", "1m"),
+			Multipliers: getEnvAsFloatMap("RATE_LIMIT_MULTIPLIERS", map[string]float64{"service_account": 10}),
+		},
+		Idempotency: IdempotencyConfig{
+			Enabled: getEnvAsBool("IDEMPOTENCY_ENABLED", true),
+			TTL:     getDuration("IDEMPOTENCY_TTL", "24h"),
+			LockTTL: getDuration("IDEMPOTENCY_LOCK_TTL", "1m"),
+		},
+		StepUp: StepUpConfig{
+			Enabled:     getEnvAsBool("STEP_UP_ENABLED", false),
+			CodeTTL:     getDuration("STEP_UP_CODE_TTL", "10m"),
+			TokenTTL:    getDuration("STEP_UP_TOKEN_TTL", "5m"),
+			MaxAttempts: getEnvAsInt("STEP_UP_MAX_ATTEMPTS", 5),
+			TOTPIssuer:  getEnv("STEP_UP_TOTP_ISSUER", "AtamLink"),
+			TOTPLockout: getDuration("STEP_UP_TOTP_LOCKOUT", "15m"),
+		},
+		Mail: MailConfig{
+			SMTPHost: getEnv("SMTP_HOST", ""),
+			SMTPPort: getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", ""),
+		},
+		IPAllowlist: IPAllowlistConfig{
+			MaxEntries:         getEnvAsInt("IP_ALLOWLIST_MAX_ENTRIES", 50),
+			BreakGlassDuration: getDuration("IP_ALLOWLIST_BREAK_GLASS_DURATION", "30m"),
+		},
+		DraftReminder: DraftReminderConfig{
+			Enabled:       getEnvAsBool("DRAFT_REMINDER_ENABLED", false),
+			AfterDays:     getEnvAsInt("DRAFT_REMINDER_AFTER_DAYS", 3),
+			ResumeURL:     getEnv("DRAFT_REMINDER_RESUME_URL", "https://atamlink.id/dashboard/catalogs/{id}"),
+			CheckInterval: getDuration("DRAFT_REMINDER_CHECK_INTERVAL", "1h"),
+			BatchSize:     getEnvAsInt("DRAFT_REMINDER_BATCH_SIZE", 50),
+		},
+		Invite: InviteConfig{
+			AcceptURL:          getEnv("INVITE_ACCEPT_URL", "https://atamlink.id/invite/{token}"),
+			SweepEnabled:       getEnvAsBool("INVITE_SWEEP_ENABLED", true),
+			SweepCheckInterval: getDuration("INVITE_SWEEP_CHECK_INTERVAL", "1h"),
+			SweepBatchSize:     getEnvAsInt("INVITE_SWEEP_BATCH_SIZE", 500),
+		},
+		Snapshot: SnapshotConfig{
+			Enabled:       getEnvAsBool("SNAPSHOT_ENABLED", false),
+			Path:          getEnv("SNAPSHOT_PATH", "./snapshots"),
+			Interval:      getDuration("SNAPSHOT_INTERVAL", "24h"),
+			ServeFallback: getEnvAsBool("SNAPSHOT_SERVE_FALLBACK", false),
+		},
+		Backup: BackupConfig{
+			Enabled:        getEnvAsBool("BACKUP_ENABLED", false),
+			Path:           getEnv("BACKUP_PATH", "./backups"),
+			Interval:       getDuration("BACKUP_INTERVAL", "168h"),
+			CheckInterval:  getDuration("BACKUP_CHECK_INTERVAL", "1h"),
+			BatchSize:      getEnvAsInt("BACKUP_BATCH_SIZE", 20),
+			RetentionCount: getEnvAsInt("BACKUP_RETENTION_COUNT", 4),
+		},
+		Archive: ArchiveConfig{
+			Enabled:        getEnvAsBool("ARCHIVE_ENABLED", false),
+			InactiveMonths: getEnvAsInt("ARCHIVE_INACTIVE_MONTHS", 6),
+			CheckInterval:  getDuration("ARCHIVE_CHECK_INTERVAL", "6h"),
+			BatchSize:      getEnvAsInt("ARCHIVE_BATCH_SIZE", 20),
+		},
+		Analytics: AnalyticsConfig{
+			BotFilterEnabled: getEnvAsBool("ANALYTICS_BOT_FILTER_ENABLED", true),
+			ChallengeSecret:  getEnv("ANALYTICS_CHALLENGE_SECRET", ""),
+			ChallengeMinAge:  getDuration("ANALYTICS_CHALLENGE_MIN_AGE", "1s"),
+			ChallengeTTL:     getDuration("ANALYTICS_CHALLENGE_TTL", "30m"),
+			VisitorCookie:    getEnv("ANALYTICS_VISITOR_COOKIE", "atamlink_vid"),
+			VisitorCookieTTL: getDuration("ANALYTICS_VISITOR_COOKIE_TTL", "8760h"),
+		},
+		Review: ReviewConfig{
+			RateLimit:  getEnvAsInt("REVIEW_RATE_LIMIT", 3),
+			RateWindow: getDuration("REVIEW_RATE_WINDOW", "24h"),
+			HashSecret: getEnv("REVIEW_HASH_SECRET", ""),
+		},
+		Inquiry: InquiryConfig{
+			RateLimit:  getEnvAsInt("INQUIRY_RATE_LIMIT", 5),
+			RateWindow: getDuration("INQUIRY_RATE_WINDOW", "1h"),
+			HashSecret: getEnv("INQUIRY_HASH_SECRET", ""),
+		},
+		Order: OrderConfig{
+			RateLimit:  getEnvAsInt("ORDER_RATE_LIMIT", 5),
+			RateWindow: getDuration("ORDER_RATE_WINDOW", "1h"),
+			HashSecret: getEnv("ORDER_HASH_SECRET", ""),
+		},
+		Partition: PartitionConfig{
+			Enabled:                  getEnvAsBool("PARTITION_MAINTENANCE_ENABLED", true),
+			PremakeMonths:            getEnvAsInt("PARTITION_PREMAKE_MONTHS", 3),
+			AuditRetentionMonths:     getEnvAsInt("AUDIT_RETENTION_MONTHS", 0),
+			AnalyticsRetentionMonths: getEnvAsInt("ANALYTICS_RETENTION_MONTHS", 0),
+			CheckInterval:            getDuration("PARTITION_CHECK_INTERVAL", "24h"),
+		},
+		Audit: AuditConfig{
+			AlertEmail:    getEnv("AUDIT_ALERT_EMAIL", ""),
+			AlertCooldown: getDuration("AUDIT_ALERT_COOLDOWN", "15m"),
+			Sink: AuditSinkConfig{
+				Provider:      getEnv("AUDIT_SINK_PROVIDER", ""),
+				URL:           getEnv("AUDIT_SINK_URL", ""),
+				AuthHeader:    getEnv("AUDIT_SINK_AUTH_HEADER", ""),
+				SyslogNetwork: getEnv("AUDIT_SINK_SYSLOG_NETWORK", "udp"),
+				SyslogAddress: getEnv("AUDIT_SINK_SYSLOG_ADDRESS", ""),
+				SyslogTag:     getEnv("AUDIT_SINK_SYSLOG_TAG", "atamlink-audit"),
+				HTTPTimeout:   getDuration("AUDIT_SINK_HTTP_TIMEOUT", "10s"),
+				QueueSize:     getEnvAsInt("AUDIT_SINK_QUEUE_SIZE", 100),
+				MaxAttempts:   getEnvAsInt("AUDIT_SINK_MAX_ATTEMPTS", 3),
+			},
+			Redaction: AuditRedactionConfig{
+				Enabled: getEnvAsBool("AUDIT_REDACT_ENABLED", true),
+				Fields: getEnvAsSlice("AUDIT_REDACT_FIELDS", []string{
+					"email", "phone", "password", "password_hash", "credentials",
+					"credential_ref", "api_key", "access_token", "refresh_token", "bot_token",
+				}),
+				TableFields: getEnvAsSliceMap("AUDIT_REDACT_TABLE_FIELDS", map[string][]string{}),
+			},
+		},
+		CDN: CDNConfig{
+			Provider:    getEnv("CDN_PROVIDER", ""),
+			PurgeURLs:   getEnvAsSlice("CDN_PURGE_URLS", []string{}),
+			PurgeCardURLs: getEnvAsSlice("CDN_PURGE_CARD_URLS", []string{}),
+			HTTPTimeout: getDuration("CDN_HTTP_TIMEOUT", "10s"),
+
+			ManualPurgeLimit:  getEnvAsInt("CDN_MANUAL_PURGE_LIMIT", 5),
+			ManualPurgeWindow: getDuration("CDN_MANUAL_PURGE_WINDOW", "1h"),
+			Cloudflare: CloudflareConfig{
+				BaseURL:  getEnv("CLOUDFLARE_BASE_URL", "https://api.cloudflare.com/client/v4"),
+				ZoneID:   getEnv("CLOUDFLARE_ZONE_ID", ""),
+				APIToken: getEnv("CLOUDFLARE_API_TOKEN", ""),
+			},
+			Fastly: FastlyConfig{
+				BaseURL:  getEnv("FASTLY_BASE_URL", "https://api.fastly.com"),
+				APIToken: getEnv("FASTLY_API_TOKEN", ""),
+			},
+		},
+		Search: SearchConfig{
+			Provider:    getEnv("SEARCH_PROVIDER", ""),
+			URL:         getEnv("SEARCH_URL", ""),
+			APIKey:      getEnv("SEARCH_API_KEY", ""),
+			Index:       getEnv("SEARCH_INDEX", "catalogs"),
+			HTTPTimeout: getDuration("SEARCH_HTTP_TIMEOUT", "5s"),
+			MaxHits:     getEnvAsInt("SEARCH_MAX_HITS", 1000),
+		},
+		APIUsage: APIUsageConfig{
+			Enabled:       getEnvAsBool("API_USAGE_ENABLED", true),
+			FlushInterval: getDuration("API_USAGE_FLUSH_INTERVAL", "1m"),
+			RetentionDays: getEnvAsInt("API_USAGE_RETENTION_DAYS", 90),
+		},
+		Status: StatusConfig{
+			CheckEnabled:    getEnvAsBool("STATUS_CHECK_ENABLED", true),
+			CheckInterval:   getDuration("STATUS_CHECK_INTERVAL", "1m"),
+			CheckTimeout:    getDuration("STATUS_CHECK_TIMEOUT", "5s"),
+			RetentionDays:   getEnvAsInt("STATUS_RETENTION_DAYS", 90),
+			IncidentDays:    getEnvAsInt("STATUS_INCIDENT_DAYS", 14),
+			UploadsCheckURL: getEnv("STATUS_UPLOADS_CHECK_URL", "https://api.cloudinary.com"),
+			PublicCheckURL:  getEnv("STATUS_PUBLIC_CHECK_URL", ""),
+		},
+		Canary: CanaryConfig{
+			Enabled:   getEnvAsBool("CANARY_ENABLED", false),
+			Percent:   getEnvAsInt("CANARY_PERCENT", 0),
+			Header:    getEnv("CANARY_HEADER", "X-Atamlink-Canary"),
+			Cookie:    getEnv("CANARY_COOKIE", "atamlink_variant"),
+			CookieTTL: getDuration("CANARY_COOKIE_TTL", "168h"),
+			Flags:     getEnvAsSlice("CANARY_FLAGS", []string{}),
+		},
+		LoadShed: LoadShedConfig{
+			Enabled:           getEnvAsBool("LOAD_SHED_ENABLED", true),
+			MaxInFlight:       getEnvAsInt("LOAD_SHED_MAX_IN_FLIGHT", 500),
+			DBPoolPercent:     getEnvAsInt("LOAD_SHED_DB_POOL_PERCENT", 90),
+			AuditQueuePercent: getEnvAsInt("LOAD_SHED_AUDIT_QUEUE_PERCENT", 80),
+			RetryAfter:        getDuration("LOAD_SHED_RETRY_AFTER", "30s"),
+		},
+		Readiness: ReadinessConfig{
+			Timeout:           getDuration("READYZ_TIMEOUT", "2s"),
+			RequireCloudinary: getEnvAsBool("READYZ_REQUIRE_CLOUDINARY", false),
+			CloudinaryTTL:     getDuration("READYZ_CLOUDINARY_TTL", "30s"),
+		},
+		Profiling: ProfilingConfig{
+			Enabled:         getEnvAsBool("PROFILING_ENABLED", false),
+			Path:            getEnv("PROFILING_PATH", "./profiles"),
+			CaptureDuration: getDuration("PROFILING_CAPTURE_DURATION", "30s"),
+			MaxDuration:     getDuration("PROFILING_MAX_DURATION", "2m"),
+		},
+		ProofOfWork: ProofOfWorkConfig{
+			Enabled:    getEnvAsBool("POW_ENABLED", false),
+			Secret:     getEnv("POW_SECRET", ""),
+			Difficulty: getEnvAsInt("POW_DIFFICULTY", 18),
+			TTL:        getDuration("POW_TTL", "5m"),
+		},
+		Clock: ClockConfig{
+			TimeTravelEnabled: getEnvAsBool("CLOCK_TIME_TRAVEL_ENABLED", false),
+			Offset:            getDuration("CLOCK_OFFSET", "0s"),
+		},
+		QR: QRConfig{
+			Format: getEnv("QR_FORMAT", "png"),
+			Size:   getEnvAsInt("QR_SIZE", 512),
+			Folder: getEnv("QR_FOLDER", "atamlink-qr"),
+		},
+		MediaReplication: MediaReplicationConfig{
+			Enabled:          getEnvAsBool("MEDIA_REPLICATION_ENABLED", false),
+			CloudName:        getEnv("MEDIA_REPLICA_CLOUDINARY_CLOUD_NAME", ""),
+			APIKey:           getEnv("MEDIA_REPLICA_CLOUDINARY_API_KEY", ""),
+			APISecret:        getEnv("MEDIA_REPLICA_CLOUDINARY_API_SECRET", ""),
+			Folder:           getEnv("MEDIA_REPLICA_FOLDER", "atamlink-replica"),
+			GeoHeader:        getEnv("MEDIA_REPLICATION_GEO_HEADER", "CF-IPCountry"),
+			PrimaryCountries: getEnvAsSlice("MEDIA_REPLICATION_PRIMARY_COUNTRIES", []string{"ID"}),
+			CheckInterval:    getDuration("MEDIA_REPLICATION_CHECK_INTERVAL", "5m"),
+			BatchSize:        getEnvAsInt("MEDIA_REPLICATION_BATCH_SIZE", 100),
+			MaxAttempts:      getEnvAsInt("MEDIA_REPLICATION_MAX_ATTEMPTS", 5),
+		},
+		MediaArchive: MediaArchiveConfig{
+			Enabled:       getEnvAsBool("MEDIA_ARCHIVE_ENABLED", false),
+			CloudName:     getEnv("MEDIA_ARCHIVE_CLOUDINARY_CLOUD_NAME", ""),
+			APIKey:        getEnv("MEDIA_ARCHIVE_CLOUDINARY_API_KEY", ""),
+			APISecret:     getEnv("MEDIA_ARCHIVE_CLOUDINARY_API_SECRET", ""),
+			Folder:        getEnv("MEDIA_ARCHIVE_FOLDER", "atamlink-archive"),
+			Quality:       getEnv("MEDIA_ARCHIVE_QUALITY", "auto:eco"),
+			ColdAfterDays: getEnvAsInt("MEDIA_ARCHIVE_COLD_AFTER_DAYS", 90),
+			CheckInterval: getDuration("MEDIA_ARCHIVE_CHECK_INTERVAL", "6h"),
+			BatchSize:     getEnvAsInt("MEDIA_ARCHIVE_BATCH_SIZE", 50),
+			MaxAttempts:   getEnvAsInt("MEDIA_ARCHIVE_MAX_ATTEMPTS", 3),
 		},
 	}
 }
@@ -176,6 +953,44 @@ func getEnvAsSlice(key string, defaultValue []string) []string {
 	return strings.Split(strValue, ",")
 }
 
+// getEnvAsFloatMap parse format "key:value,key:value", entri tidak valid dilewati
+func getEnvAsFloatMap(key string, defaultValue map[string]float64) map[string]float64 {
+	strValue := getEnv(key, "")
+	if strValue == "" {
+		return defaultValue
+	}
+
+	result := make(map[string]float64)
+	for _, pair := range strings.Split(strValue, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if value, err := strconv.ParseFloat(parts[1], 64); err == nil {
+			result[parts[0]] = value
+		}
+	}
+	return result
+}
+
+// getEnvAsSliceMap parse format "key:a|b,key:c", entri tidak valid dilewati
+func getEnvAsSliceMap(key string, defaultValue map[string][]string) map[string][]string {
+	strValue := getEnv(key, "")
+	if strValue == "" {
+		return defaultValue
+	}
+
+	result := make(map[string][]string)
+	for _, pair := range strings.Split(strValue, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			continue
+		}
+		result[parts[0]] = append(result[parts[0]], strings.Split(parts[1], "|")...)
+	}
+	return result
+}
+
 func getDuration(key, defaultValue string) time.Duration {
 	strValue := getEnv(key, defaultValue)
 	if duration, err := time.ParseDuration(strValue); err == nil {
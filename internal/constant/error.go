@@ -9,6 +9,9 @@ const (
 	ErrMsgForbidden      = "Akses ditolak"
 	ErrMsgNotFound       = "Data tidak ditemukan"
 	ErrMsgValidation     = "Data tidak valid"
+	ErrMsgPreconditionFailed = "Data sudah diubah, muat ulang lalu coba lagi"
+	ErrMsgCursorInvalid      = "Cursor tidak valid, mulai ulang dari halaman pertama"
+	ErrMsgCursorSortMismatch = "Cursor dibuat untuk urutan lain, gunakan sort dan order yang sama atau mulai ulang dari halaman pertama"
 
 	// Auth errors
 	ErrMsgTokenNotFound   = "Token tidak ditemukan"
@@ -17,6 +20,50 @@ const (
 	ErrMsgInvalidLogin    = "Email atau password salah"
 	ErrMsgAccountLocked   = "Akun Anda terkunci"
 	ErrMsgAccountInactive = "Akun Anda tidak aktif"
+	ErrMsgRateLimited     = "Terlalu banyak request, coba lagi nanti"
+	ErrMsgServiceOverloaded = "Layanan sedang sibuk, coba lagi nanti"
+
+	// Service account errors
+	ErrMsgServiceAccountNotFound    = "Service account tidak ditemukan"
+	ErrMsgServiceAccountNameExists  = "Nama service account sudah digunakan"
+	ErrMsgServiceAccountRoleInvalid = "Role service account tidak valid"
+
+	// Invite errors
+	ErrMsgInviteNotFound      = "Invite tidak ditemukan"
+	ErrMsgInviteInvalid       = "Invite tidak valid atau sudah kadaluarsa"
+	ErrMsgInviteNotPending    = "Invite sudah digunakan atau dibatalkan"
+	ErrMsgInviteStatusInvalid = "Status invite tidak valid"
+	ErrMsgInviteEmailMissing  = "Invite tanpa email tidak bisa dikirim ulang"
+
+	// Step-up errors
+	ErrMsgStepUpRequired        = "Verifikasi tambahan diperlukan untuk aksi ini"
+	ErrMsgStepUpCodeInvalid     = "Kode verifikasi salah"
+	ErrMsgStepUpExpired         = "Kode verifikasi tidak valid atau sudah kadaluarsa"
+	ErrMsgStepUpTOTPNotEnrolled = "Authenticator belum diaktifkan"
+	ErrMsgStepUpTOTPEnrolled    = "Authenticator sudah aktif"
+	ErrMsgStepUpTOTPLocked      = "Terlalu banyak kode authenticator salah, coba lagi nanti"
+	ErrMsgTOTPReenrollCode      = "Authenticator sudah aktif, masukkan kode authenticator saat ini untuk menggantinya"
+	ErrMsgStepUpEmailOnly       = "Aksi ini hanya bisa diverifikasi lewat email"
+	ErrMsgMailNotConfigured     = "Pengiriman email belum dikonfigurasi"
+
+	// Idempotency errors
+	ErrMsgIdempotencyKeyInvalid = "Idempotency-Key maksimal 255 karakter"
+	ErrMsgIdempotencyKeyReused  = "Idempotency-Key sudah dipakai untuk request yang berbeda"
+	ErrMsgIdempotencyInProgress = "Request dengan Idempotency-Key yang sama sedang diproses"
+
+	// Proof-of-work errors
+	ErrMsgProofOfWorkRequired      = "Selesaikan challenge proof-of-work terlebih dahulu"
+	ErrMsgProofOfWorkActionInvalid = "Action proof-of-work tidak dikenal"
+
+	// Session errors
+	ErrMsgSessionNotFound = "Sesi tidak ditemukan"
+	ErrMsgSessionRevoked  = "Sesi sudah dicabut, silakan login kembali"
+
+	// IP allowlist errors
+	ErrMsgIPNotAllowed            = "IP Anda tidak diizinkan untuk mengubah data bisnis ini"
+	ErrMsgIPAllowlistEntryInvalid = "Entri IP allowlist harus berupa IP atau CIDR yang valid"
+	ErrMsgIPAllowlistTooMany      = "Jumlah entri IP allowlist melebihi batas"
+	ErrMsgIPAllowlistSelfLockout  = "IP Anda saat ini harus termasuk dalam allowlist"
 
 	// Business errors
 	ErrMsgBusinessNotFound      = "Bisnis tidak ditemukan"
@@ -30,22 +77,169 @@ const (
 
 	// Catalog errors
 	ErrMsgCatalogNotFound     = "Katalog tidak ditemukan"
+	ErrMsgCatalogModified     = "Katalog sudah diubah oleh pengguna lain, muat ulang lalu coba lagi"
+	ErrMsgCatalogNotInTrash   = "Katalog tidak ada di trash"
+	ErrMsgBatchCatalogIDsInvalid   = "Parameter ids harus berisi 1-%d ID katalog dipisah koma"
+	ErrMsgBatchCatalogSlugsInvalid = "Parameter slugs harus berisi 1-%d slug katalog dipisah koma"
 	ErrMsgCatalogTitleRequired = "Judul katalog wajib diisi"
 	ErrMsgCatalogSlugExists   = "Slug katalog sudah digunakan"
 	ErrMsgCatalogInactive     = "Katalog tidak aktif"
+	ErrMsgCatalogArchived     = "Katalog sudah diarsipkan"
+	ErrMsgBrandEmpty          = "Brand bisnis belum diatur"
 	ErrMsgThemeNotFound       = "Tema tidak ditemukan"
 	ErrMsgThemeInactive       = "Tema tidak tersedia"
+	ErrMsgCategoryNotFound    = "Kategori tidak ditemukan"
+	ErrMsgCategoryInactive    = "Kategori tidak tersedia"
+	ErrMsgCategorySlugExists  = "Slug kategori sudah digunakan"
+	ErrMsgIncidentNotFound    = "Insiden tidak ditemukan"
+
+	// Cache errors
+	ErrMsgCachePurgeDisabled    = "Purge cache CDN tidak dikonfigurasi"
+	ErrMsgCachePurgeRateLimited = "Batas purge cache katalog tercapai, coba lagi nanti"
+	ErrMsgCachePurgeFailed      = "Gagal purge cache CDN"
+
+	// Search errors
+	ErrMsgSearchEngineDisabled = "Search engine tidak dikonfigurasi"
+	ErrMsgSearchReindexRunning = "Reindex search masih berjalan"
+	ErrMsgSearchQueryRequired  = "Kata kunci pencarian wajib diisi"
+	ErrMsgSearchQueryTooLong   = "Kata kunci pencarian maksimal %d karakter"
+
+	// Profiling errors
+	ErrMsgProfilingDisabled        = "Profiling tidak diaktifkan"
+	ErrMsgProfilingTypeInvalid     = "Tipe profile tidak valid"
+	ErrMsgProfilingDurationInvalid = "Durasi profile tidak valid"
+	ErrMsgProfilingRunning         = "Capture CPU profile masih berjalan"
+	ErrMsgProfilingNotFound        = "Hasil capture profile tidak ditemukan"
+
+	// Clock errors
+	ErrMsgClockOffsetInvalid = "Offset waktu tidak valid, gunakan format durasi seperti 72h atau -30m"
 
 	// Section errors
 	ErrMsgSectionNotFound  = "Section tidak ditemukan"
 	ErrMsgSectionTypeInvalid = "Tipe section tidak valid"
 	ErrMsgSectionRequired  = "Section wajib diisi"
+	ErrMsgSectionNotFAQ    = "Section bukan tipe FAQ"
+	ErrMsgSectionNotLegal  = "Section bukan tipe legal"
+	ErrMsgLegalVersionNotFound = "Versi legal tidak ditemukan"
+	ErrMsgLegalAckRequired = "Syarat & ketentuan versi terbaru wajib disetujui"
+	ErrMsgSectionLimitReached = "Katalog sudah mencapai batas %d section"
+	ErrMsgPositionAfterInvalid = "after_id harus item lain di katalog/section yang sama"
+	ErrMsgSectionReorderMismatch = "section_ids harus berisi semua section katalog tepat satu kali"
+	ErrMsgCardLayoutInvalid      = "config.layout tidak valid"
+	ErrMsgCardLayoutType         = "config.layout.type harus grid, list atau carousel"
+	ErrMsgCardLayoutColumns      = "config.layout.columns harus antara 1 dan %d"
+	ErrMsgCardLayoutAspectRatio  = "config.layout.image_aspect_ratio harus 1:1, 4:3, 3:4, 3:2 atau 16:9"
+
+	// FAQ errors
+	ErrMsgFAQNotFound  = "FAQ tidak ditemukan"
+	ErrMsgFAQDuplicate = "FAQ yang sama muncul lebih dari sekali"
+	ErrMsgFAQReorderMismatch = "faq_ids harus berisi semua FAQ section tepat satu kali"
 
 	// Card errors
 	ErrMsgCardNotFound      = "Card tidak ditemukan"
+	ErrMsgCardModified      = "Card sudah diubah oleh pengguna lain, muat ulang lalu coba lagi"
 	ErrMsgCardTitleRequired = "Judul card wajib diisi"
 	ErrMsgCardTypeInvalid   = "Tipe card tidak valid"
 	ErrMsgCardPriceInvalid  = "Harga tidak valid"
+	ErrMsgCardLimitReached  = "Section sudah mencapai batas %d card"
+	ErrMsgMediaLimitReached = "Card maksimal memiliki %d media"
+	ErrMsgCardLinkNotFound  = "Link card tidak ditemukan"
+	ErrMsgCardDetailMissing = "Card belum memiliki halaman detail"
+	ErrMsgCardSlugExists    = "Slug card sudah digunakan di katalog ini"
+	ErrMsgCardImportFormat     = "File import harus berformat CSV atau XLSX"
+	ErrMsgCardImportUnreadable = "File import tidak bisa dibaca"
+	ErrMsgCardImportEmpty      = "File import tidak berisi baris produk"
+	ErrMsgCardImportHeader     = "Baris pertama file import harus header dengan kolom title"
+	ErrMsgCardImportTooMany    = "File import maksimal berisi %d baris produk"
+	ErrMsgCardImportTitleLong  = "Judul card maksimal 200 karakter"
+	ErrMsgCardImportDiscount   = "Diskon harus angka 0-100"
+	ErrMsgCardImportURL        = "URL tidak valid"
+	ErrMsgPriceScheduleInPast   = "Jadwal harga harus di masa depan"
+	ErrMsgPriceScheduleNotFound = "Jadwal harga tidak ditemukan"
+	ErrMsgPriceScheduleClosed   = "Jadwal harga sudah diterapkan atau dibatalkan"
+	ErrMsgCardStockNotTracked   = "Stok card tidak dilacak, atur stok terlebih dahulu"
+	ErrMsgCardStockNegative     = "Stok tidak boleh kurang dari 0"
+	ErrMsgTagNotFound           = "Tag tidak ditemukan"
+	ErrMsgTagSlugExists         = "Slug tag sudah digunakan di katalog ini"
+	ErrMsgTagSlugInvalid        = "Slug tag tidak valid"
+	ErrMsgTagLimitReached       = "Katalog sudah mencapai batas %d tag"
+	ErrMsgCardTagLimitReached   = "Card maksimal memiliki %d tag"
+	ErrMsgCardTagInvalid        = "Tag %d bukan milik katalog card ini"
+
+	// Checkout errors
+	ErrMsgCheckoutNotFound      = "Checkout link tidak ditemukan"
+	ErrMsgCheckoutPriceRequired = "Card belum memiliki harga"
+	ErrMsgPaymentUnavailable    = "Layanan pembayaran tidak tersedia"
+	ErrMsgPaymentCallbackInvalid = "Callback pembayaran tidak valid"
+
+	// Publish approval errors
+	ErrMsgPublishRequestNotFound  = "Pengajuan publish tidak ditemukan"
+	ErrMsgPublishRequestPending   = "Katalog sedang menunggu review"
+	ErrMsgPublishRequestReviewed  = "Pengajuan publish sudah direview"
+	ErrMsgCatalogAlreadyPublished = "Katalog sudah dipublish"
+	ErrMsgCatalogNotPublished     = "Katalog belum dipublish"
+	ErrMsgPublishScheduleInPast   = "Jadwal publish harus di masa depan"
+	ErrMsgPublishScheduleNotFound = "Katalog tidak memiliki jadwal publish"
+	ErrMsgVisibilityScheduleEmpty    = "Isi publish_at atau unpublish_at"
+	ErrMsgVisibilityScheduleInPast   = "Jadwal tampil/sembunyi harus di masa depan"
+	ErrMsgVisibilityScheduleOrder    = "unpublish_at harus setelah publish_at"
+	ErrMsgVisibilityScheduleNotFound = "Tidak ada jadwal tampil/sembunyi"
+
+	// Comment errors
+	ErrMsgCommentNotFound      = "Komentar tidak ditemukan"
+	ErrMsgCommentParentInvalid = "Balasan hanya bisa untuk thread pada target yang sama"
+	ErrMsgCommentMentionInvalid = "Mention hanya untuk anggota business"
+	ErrMsgCommentResolveReply  = "Hanya thread yang bisa di-resolve"
+
+	// Review errors
+	ErrMsgReviewNotFound      = "Ulasan tidak ditemukan"
+	ErrMsgReviewDuplicate     = "Anda sudah memberikan ulasan untuk katalog ini"
+	ErrMsgReviewRateLimited   = "Terlalu banyak ulasan, coba lagi nanti"
+	ErrMsgReviewRejected      = "Ulasan tidak dapat diterima"
+	ErrMsgReviewNotApproved   = "Hanya ulasan yang sudah disetujui yang bisa dibalas"
+	ErrMsgReviewStatusInvalid = "Status ulasan tidak valid"
+
+	// Inquiry errors
+	ErrMsgInquiryNotFound        = "Pesan tidak ditemukan"
+	ErrMsgInquiryRateLimited     = "Terlalu banyak pesan, coba lagi nanti"
+	ErrMsgInquiryRejected        = "Pesan tidak dapat diterima"
+	ErrMsgInquiryContactRequired = "Email atau nomor telepon wajib diisi"
+	ErrMsgInquiryCardInvalid     = "Card tidak ditemukan di katalog ini"
+	ErrMsgInquiryStatusInvalid   = "Status pesan tidak valid"
+
+	// Order errors
+	ErrMsgOrderNotFound          = "Pesanan tidak ditemukan"
+	ErrMsgOrderRateLimited       = "Terlalu banyak pesanan, coba lagi nanti"
+	ErrMsgOrderRejected          = "Pesanan tidak dapat diterima"
+	ErrMsgOrderContactRequired   = "Email atau nomor telepon wajib diisi"
+	ErrMsgOrderCardInvalid       = "Card %d tidak bisa dipesan di katalog ini"
+	ErrMsgOrderCurrencyMismatch  = "Semua item pesanan harus dalam mata uang yang sama"
+	ErrMsgOrderCardSoldOut       = "%s sudah habis"
+	ErrMsgOrderStockInsufficient = "Stok %s tidak cukup, tersisa %d"
+	ErrMsgOrderStatusInvalid     = "Status pesanan tidak valid"
+	ErrMsgOrderStatusTransition  = "Status pesanan tidak bisa diubah dari %s ke %s"
+	ErrMsgOrderStatusChanged     = "Status pesanan sudah diubah, muat ulang lalu coba lagi"
+
+	// Backup errors
+	ErrMsgBackupNotFound      = "Backup tidak ditemukan"
+	ErrMsgBackupNotRestorable = "File backup tidak tersedia untuk restore"
+	ErrMsgBackupCorrupted     = "File backup rusak atau tidak valid"
+	ErrMsgBackupUnavailable   = "Layanan backup tidak tersedia"
+	ErrMsgArchiveCorrupted    = "File arsip katalog rusak atau tidak valid"
+
+	// Analytics errors
+	ErrMsgAnalyticsRangeInvalid = "Rentang tanggal analytics tidak valid (maksimal 366 hari)"
+	ErrMsgAnalyticsTargetInvalid = "Section atau card tidak ditemukan di katalog ini"
+	ErrMsgGoalNotFound           = "Goal tidak ditemukan"
+	ErrMsgGoalLimitReached       = "Jumlah goal katalog sudah maksimal"
+	ErrMsgCompareCardsInvalid    = "Compare membutuhkan 2 sampai 4 card berbeda"
+	ErrMsgRedirectLinkNotFound   = "Link tidak ditemukan"
+
+	// QR code errors
+	ErrMsgQRUnavailable = "QR code tidak tersedia, URL publik katalog belum dikonfigurasi"
+
+	// Presence errors
+	ErrMsgPresenceUnavailable = "Layanan presence tidak tersedia"
 
 	// File upload errors
 	ErrMsgFileRequired    = "File wajib diupload"
@@ -58,6 +252,25 @@ const (
 	ErrMsgSubscriptionRequired = "Fitur ini memerlukan subscription"
 	ErrMsgPlanNotFound        = "Plan tidak ditemukan"
 	ErrMsgPlanInactive        = "Plan tidak tersedia"
+	ErrMsgPlanFree            = "Plan gratis tidak memerlukan pembayaran"
+	ErrMsgSubscriptionPaymentNotFound = "Pembayaran subscription tidak ditemukan"
+	ErrMsgPaymentAmountMismatch       = "Nominal pembayaran tidak sesuai"
+	ErrMsgPlanCatalogLimit    = "Plan %s maksimal %d katalog, upgrade plan untuk menambah katalog"
+	ErrMsgPlanProductLimit    = "Plan %s maksimal %d produk, upgrade plan untuk menambah produk"
+	ErrMsgPlanUserLimit       = "Plan %s maksimal %d user, upgrade plan untuk menambah user"
+	ErrMsgPlanFeatureMissing  = "Fitur %s tidak tersedia di plan %s, upgrade plan untuk menggunakannya"
+
+	// Integration errors
+	ErrMsgIntegrationNotFound = "Integrasi tidak ditemukan"
+	ErrMsgIntegrationExists   = "Toko marketplace sudah terhubung"
+	ErrMsgMarketplaceInvalid  = "Marketplace tidak didukung"
+	ErrMsgSyncFailed          = "Sinkronisasi produk gagal"
+
+	// Notification errors
+	ErrMsgNotificationChannelNotFound = "Channel notifikasi tidak ditemukan"
+	ErrMsgVaultNotConfigured          = "Penyimpanan kredensial belum dikonfigurasi"
+	ErrMsgTelegramNotConfigured       = "Bot Telegram belum dikonfigurasi"
+	ErrMsgTelegramAlreadyLinked       = "Chat Telegram sudah terhubung, putuskan dulu sebelum menghubungkan chat lain"
 
 	// User errors
 	ErrMsgUserNotFound     = "User tidak ditemukan"
@@ -3,17 +3,33 @@ package constant
 // Business roles
 const (
 	RoleOwner  = "owner"
-	RoleAdmin  = "admin"
-	RoleEditor = "editor"
-	RoleViewer = "viewer"
+	RoleAdmin    = "admin"
+	RoleReviewer = "reviewer"
+	RoleEditor   = "editor"
+	RoleViewer   = "viewer"
 )
 
+// Principal API untuk rate limit, service account adalah machine user tanpa profile
+const (
+	PrincipalUser           = "user"
+	PrincipalServiceAccount = "service_account"
+)
+
+// ServiceAccountTokenPrefix awalan token service account, membedakannya dari token user
+const ServiceAccountTokenPrefix = "sa_"
+
+// IsValidServiceAccountRole service account tidak boleh menjadi owner
+func IsValidServiceAccountRole(role string) bool {
+	return role != RoleOwner && IsValidRole(role)
+}
+
 // Role hierarchy untuk permission checking
 var RoleHierarchy = map[string]int{
-	RoleOwner:  4,
-	RoleAdmin:  3,
-	RoleEditor: 2,
-	RoleViewer: 1,
+	RoleOwner:    5,
+	RoleAdmin:    4,
+	RoleReviewer: 3,
+	RoleEditor:   2,
+	RoleViewer:   1,
 }
 
 // HasMinimumRole check apakah user role >= minimum role yang dibutuhkan
@@ -39,7 +55,7 @@ func IsValidRole(role string) bool {
 
 // GetAllRoles mendapatkan semua roles
 func GetAllRoles() []string {
-	return []string{RoleOwner, RoleAdmin, RoleEditor, RoleViewer}
+	return []string{RoleOwner, RoleAdmin, RoleReviewer, RoleEditor, RoleViewer}
 }
 
 // Permission constants
@@ -49,12 +65,16 @@ const (
 	PermBusinessCreate = "business:create"
 	PermBusinessUpdate = "business:update"
 	PermBusinessDelete = "business:delete"
+	PermBusinessBackup = "business:backup" // lihat, buat dan restore backup katalog
 
 	// Catalog permissions
 	PermCatalogView   = "catalog:view"
 	PermCatalogCreate = "catalog:create"
 	PermCatalogUpdate = "catalog:update"
 	PermCatalogDelete = "catalog:delete"
+	PermCatalogReview = "catalog:review" // approve / request changes pengajuan publish
+	PermCatalogRestore = "catalog:restore" // pulihkan katalog dari trash
+	PermCatalogPurge   = "catalog:purge"   // hapus permanen katalog di trash
 
 	// User management permissions
 	PermUserView   = "user:view"
@@ -70,17 +90,25 @@ const (
 // RolePermissions mapping role ke permissions
 var RolePermissions = map[string][]string{
 	RoleOwner: {
-		PermBusinessView, PermBusinessCreate, PermBusinessUpdate, PermBusinessDelete,
-		PermCatalogView, PermCatalogCreate, PermCatalogUpdate, PermCatalogDelete,
+		PermBusinessView, PermBusinessCreate, PermBusinessUpdate, PermBusinessDelete, PermBusinessBackup,
+		PermCatalogView, PermCatalogCreate, PermCatalogUpdate, PermCatalogDelete, PermCatalogReview,
+		PermCatalogRestore, PermCatalogPurge,
 		PermUserView, PermUserInvite, PermUserUpdate, PermUserRemove,
 		PermSubscriptionView, PermSubscriptionUpdate,
 	},
 	RoleAdmin: {
-		PermBusinessView, PermBusinessUpdate,
-		PermCatalogView, PermCatalogCreate, PermCatalogUpdate, PermCatalogDelete,
+		PermBusinessView, PermBusinessUpdate, PermBusinessBackup,
+		PermCatalogView, PermCatalogCreate, PermCatalogUpdate, PermCatalogDelete, PermCatalogReview,
+		PermCatalogRestore,
 		PermUserView, PermUserInvite, PermUserUpdate,
 		PermSubscriptionView,
 	},
+	RoleReviewer: {
+		PermBusinessView,
+		PermCatalogView, PermCatalogCreate, PermCatalogUpdate, PermCatalogReview,
+		PermUserView,
+		PermSubscriptionView,
+	},
 	RoleEditor: {
 		PermBusinessView,
 		PermCatalogView, PermCatalogCreate, PermCatalogUpdate,
@@ -95,6 +123,23 @@ var RolePermissions = map[string][]string{
 	},
 }
 
+// PermissionSet kumpulan permission milik satu member
+type PermissionSet map[string]bool
+
+// Has check apakah permission ada di set
+func (ps PermissionSet) Has(permission string) bool {
+	return ps[permission]
+}
+
+// PermissionsForRole permission set untuk role, kosong jika role tidak dikenal
+func PermissionsForRole(role string) PermissionSet {
+	set := make(PermissionSet, len(RolePermissions[role]))
+	for _, perm := range RolePermissions[role] {
+		set[perm] = true
+	}
+	return set
+}
+
 // HasPermission check apakah role memiliki permission tertentu
 func HasPermission(role, permission string) bool {
 	permissions, exists := RolePermissions[role]
@@ -109,4 +154,4 @@ func HasPermission(role, permission string) bool {
 	}
 
 	return false
-}
\ No newline at end of file
+}
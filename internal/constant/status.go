@@ -21,6 +21,58 @@ const (
 	SubscriptionStatusSuspended = "suspended"
 )
 
+// Catalog publish status
+const (
+	CatalogStatusDraft     = "draft"
+	CatalogStatusInReview  = "in_review"
+	CatalogStatusPublished = "published"
+)
+
+// Publish request status
+const (
+	PublishRequestStatusPending          = "pending"
+	PublishRequestStatusApproved         = "approved"
+	PublishRequestStatusChangesRequested = "changes_requested"
+)
+
+// Card price schedule status
+const (
+	PriceScheduleStatusPending   = "pending"
+	PriceScheduleStatusApplied   = "applied"
+	PriceScheduleStatusCancelled = "cancelled"
+)
+
+// Onboarding steps
+const (
+	OnboardingStepLogoUploaded   = "logo_uploaded"
+	OnboardingStepCatalogCreated = "catalog_created"
+	OnboardingStepCardAdded      = "card_added"
+	OnboardingStepPublished      = "catalog_published"
+	OnboardingStepLinkVisited    = "link_visited"
+)
+
+// Review status, hanya ulasan approved yang tampil dan dihitung di rating
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// Inquiry status, inquiry archived disembunyikan dari daftar default pemilik
+const (
+	InquiryStatusNew      = "new"
+	InquiryStatusRead     = "read"
+	InquiryStatusArchived = "archived"
+)
+
+// Order status, completed dan cancelled adalah status akhir
+const (
+	OrderStatusNew       = "new"
+	OrderStatusContacted = "contacted"
+	OrderStatusCompleted = "completed"
+	OrderStatusCancelled = "cancelled"
+)
+
 // Section types
 const (
 	SectionTypeHero         = "hero"
@@ -33,6 +85,7 @@ const (
 	SectionTypeCTA          = "cta"
 	SectionTypeText         = "text"
 	SectionTypeVideo        = "video"
+	SectionTypeLegal        = "legal"
 )
 
 // Card types
@@ -95,6 +148,295 @@ const (
 	ThemeCreative     = "creative"
 )
 
+// Card layout section cards (config.layout)
+const (
+	CardLayoutGrid     = "grid"
+	CardLayoutList     = "list"
+	CardLayoutCarousel = "carousel"
+
+	MaxCardLayoutColumns = 6
+)
+
+// Marketplace providers
+const (
+	MarketplaceShopee    = "shopee"
+	MarketplaceTokopedia = "tokopedia"
+)
+
+// Analytics event types
+const (
+	AnalyticsEventView      = "view"
+	AnalyticsEventClick     = "click"
+	AnalyticsEventLinkClick = "link_click"
+
+	// Event konversi, bisa dijadikan goal
+	AnalyticsEventWhatsAppClick  = "whatsapp_click"
+	AnalyticsEventCheckoutClick  = "checkout_click"
+	AnalyticsEventOrderSubmitted = "order_submitted"
+
+	// Event wishlist dan compare card, dicatat di hitungan konversi harian
+	AnalyticsEventCardSave    = "card_save"
+	AnalyticsEventCardCompare = "card_compare"
+)
+
+// Batas card per permintaan compare
+const (
+	MinCompareCards = 2
+	MaxCompareCards = 4
+)
+
+// Batas goal konversi per katalog
+const MaxGoalsPerCatalog = 20
+
+// Batas tag per katalog dan per card
+const (
+	MaxTagsPerCatalog = 50
+	MaxTagsPerCard    = 10
+	MaxTagSlugLength  = 60
+)
+
+// Batas katalog per permintaan batch GET
+const MaxBatchCatalogs = 50
+
+// Panjang maksimal kata kunci pencarian card publik dan dashboard
+const MaxSearchQueryLength = 100
+
+// Jumlah card cocok yang ditampilkan per katalog di pencarian dashboard
+const MaxSearchMatchedCards = 3
+
+// Panjang bagian acak nomor referensi order (ORD-XXXXXXXXXX)
+const OrderRefLength = 10
+
+// Batas URL per sitemap.xml sesuai protokol sitemap
+const SitemapMaxURLs = 50000
+
+// Jarak posisi section/card, posisi baru diambil dari tengah dua tetangga
+// sampai jaraknya habis lalu seluruh posisi dinomori ulang
+const PositionGap = 100
+
+// Batas jumlah konten katalog, bisa di-override lewat features plan
+const (
+	DefaultMaxSectionsPerCatalog = 30
+	DefaultMaxCardsPerSection    = 100
+	DefaultMaxMediaPerCard       = 10
+
+	PlanFeatureMaxSectionsPerCatalog = "max_sections_per_catalog"
+	PlanFeatureMaxCardsPerSection    = "max_cards_per_section"
+	PlanFeatureMaxMediaPerCard       = "max_media_per_card"
+)
+
+// Batas resource business per plan, default = plan Free untuk business tanpa subscription aktif.
+// Nilai -1 di features plan berarti tanpa batas
+const (
+	DefaultPlanName    = "Free"
+	DefaultMaxCatalogs = 1
+	DefaultMaxProducts = 50
+	DefaultMaxUsers    = 1
+
+	PlanFeatureMaxCatalogs = "max_catalogs"
+	PlanFeatureMaxProducts = "max_products"
+	PlanFeatureMaxUsers    = "max_users"
+	PlanFeatureAPIAccess   = "api_access"
+)
+
+// Batas import card dari file CSV/XLSX
+const (
+	CardImportMaxFileSize = 5 << 20
+	CardImportMaxRows     = 1000
+	CardImportBatchSize   = 50 // card per transaksi
+)
+
+// EmbedScriptVersion versi script widget embed, naikkan setiap embed.js berubah
+// supaya URL versioned (?v=) tidak terkena cache lama
+const EmbedScriptVersion = "1.0.0"
+
+// Hint pemakaian dikirim saat pemakaian mencapai persentase batas plan ini
+const UsageHintThresholdPercent = 80
+
+// Usage hint codes
+const (
+	HintCodeLimitApproaching = "limit_approaching"
+	HintCodeLimitReached     = "limit_reached"
+
+	HintMsgSectionUsage = "Anda sudah memakai %d/%d section di katalog ini pada paket %s"
+	HintMsgCardUsage    = "Anda sudah memakai %d/%d card di section ini pada paket %s"
+)
+
+// CDN providers
+const (
+	CDNProviderCloudflare = "cloudflare"
+	CDNProviderFastly     = "fastly"
+)
+
+// Audit sink providers (SIEM)
+const (
+	AuditSinkHTTP   = "http"
+	AuditSinkSyslog = "syslog"
+)
+
+// Format QR code katalog
+const (
+	QRFormatPNG = "png"
+	QRFormatSVG = "svg"
+)
+
+// Komponen dan status status page publik
+const (
+	StatusComponentAPI         = "api"
+	StatusComponentUploads     = "uploads"
+	StatusComponentPublicPages = "public_pages"
+
+	ComponentStatusOperational = "operational"
+	ComponentStatusDegraded    = "degraded"
+	ComponentStatusOutage      = "outage"
+	ComponentStatusUnknown     = "unknown" // belum ada health check
+)
+
+// Status dan dampak insiden status page
+const (
+	IncidentStatusInvestigating = "investigating"
+	IncidentStatusIdentified    = "identified"
+	IncidentStatusMonitoring    = "monitoring"
+	IncidentStatusResolved      = "resolved"
+
+	IncidentImpactMinor    = "minor"
+	IncidentImpactMajor    = "major"
+	IncidentImpactCritical = "critical"
+)
+
+// Variant canary deployment dan feature flag yang bisa diaktifkan di canary
+const (
+	VariantStable = "stable"
+	VariantCanary = "canary"
+
+	HeaderVariant = "X-Atamlink-Variant"
+
+	FeaturePublicRendererV2 = "public_renderer_v2"
+)
+
+// Search engine providers
+const (
+	SearchProviderMeilisearch   = "meilisearch"
+	SearchProviderElasticsearch = "elasticsearch"
+)
+
+// Search index events
+const (
+	SearchEventCatalogCreated = "catalog.created"
+	SearchEventCatalogUpdated = "catalog.updated" // termasuk konten section, card & FAQ
+)
+
+// Sync conflict policies
+const (
+	SyncConflictRemoteWins = "remote_wins" // data marketplace menimpa perubahan lokal
+	SyncConflictLocalWins  = "local_wins"  // card yang diubah lokal sejak sync terakhir dilewati
+	SyncConflictCreateOnly = "create_only" // hanya import produk baru
+)
+
+// Sync status
+const (
+	SyncStatusSuccess = "success"
+	SyncStatusFailed  = "failed"
+)
+
+// Checkout link status
+const (
+	CheckoutStatusPending = "pending"
+	CheckoutStatusPaid    = "paid"
+	CheckoutStatusExpired = "expired"
+	CheckoutStatusFailed  = "failed"
+)
+
+// Subscription payment status
+const (
+	PaymentStatusPending = "pending"
+	PaymentStatusPaid    = "paid"
+	PaymentStatusExpired = "expired"
+	PaymentStatusFailed  = "failed"
+
+	PaymentGatewayXendit = "xendit"
+
+	// SubscriptionPaymentPrefix awalan external ID invoice subscription
+	SubscriptionPaymentPrefix = "sub"
+)
+
+// Business invite status
+const (
+	InviteStatusPending = "pending"
+	InviteStatusUsed    = "used"
+	InviteStatusRevoked = "revoked"
+	InviteStatusExpired = "expired"
+)
+
+// Notification channels
+const (
+	NotificationChannelWhatsApp = "whatsapp"
+	NotificationChannelTelegram = "telegram"
+)
+
+// Backup status dan trigger
+const (
+	BackupStatusCompleted = "completed"
+	BackupStatusFailed    = "failed"
+	BackupStatusExpired   = "expired"
+
+	BackupTriggerScheduled = "scheduled"
+	BackupTriggerManual    = "manual"
+)
+
+// Media replica status
+const (
+	MediaReplicaStatusCompleted = "completed"
+	MediaReplicaStatusFailed    = "failed"
+)
+
+// Config section legal: checkout wajib menyertakan versi legal yang disetujui
+const SectionConfigRequireAcknowledgment = "require_acknowledgment"
+
+// Storage class media, original yang jarang diakses dipindah ke infrequent
+const (
+	MediaStorageClassStandard   = "standard"
+	MediaStorageClassInfrequent = "infrequent"
+)
+
+// Notification events
+const (
+	NotificationEventNewOrder            = "new_order"
+	NotificationEventNewTestimonial      = "new_testimonial"
+	NotificationEventSubscriptionExpiring = "subscription_expiring"
+	NotificationEventCommentMention      = "comment_mention"
+	NotificationEventCatalogPublished    = "catalog_published"
+	NotificationEventNewReview           = "new_review"
+	NotificationEventDraftReminder       = "draft_reminder"
+	NotificationEventDiscountEnding      = "discount_ending"
+	NotificationEventNewInquiry          = "new_inquiry"
+)
+
+// Notification delivery status
+const (
+	NotificationStatusSent      = "sent"
+	NotificationStatusFailed    = "failed"
+	NotificationStatusDelivered = "delivered"
+	NotificationStatusRead      = "read"
+)
+
+// Step-up verification
+const (
+	StepUpMethodEmail = "email"
+	StepUpMethodTOTP  = "totp"
+
+	StepUpActionBusinessDelete        = "business_delete"
+	StepUpActionIPAllowlistBreakGlass = "ip_allowlist_break_glass"
+	StepUpActionTOTPEnroll            = "totp_enroll"
+)
+
+// Proof-of-work, action endpoint tulis publik yang bisa diminta challenge
+const (
+	PoWActionReview  = "review"
+	PoWActionInquiry = "inquiry"
+	PoWActionOrder   = "order"
+)
+
 // Currency types
 const (
 	CurrencyIDR = "IDR"
@@ -132,13 +474,38 @@ func IsValidSubscriptionStatus(s string) bool {
 	return contains(validStatuses, s)
 }
 
+// IsValidInviteStatus check apakah status invite valid
+func IsValidInviteStatus(s string) bool {
+	validStatuses := []string{
+		InviteStatusPending, InviteStatusUsed,
+		InviteStatusRevoked, InviteStatusExpired,
+	}
+	return contains(validStatuses, s)
+}
+
+// IsConversionEvent check apakah event termasuk event konversi
+func IsConversionEvent(event string) bool {
+	conversionEvents := []string{
+		AnalyticsEventWhatsAppClick, AnalyticsEventCheckoutClick, AnalyticsEventOrderSubmitted,
+	}
+	return contains(conversionEvents, event)
+}
+
+// IsValidPoWAction check apakah action proof-of-work valid
+func IsValidPoWAction(action string) bool {
+	validActions := []string{
+		PoWActionReview, PoWActionInquiry, PoWActionOrder,
+	}
+	return contains(validActions, action)
+}
+
 // IsValidSectionType check apakah section type valid
 func IsValidSectionType(t string) bool {
 	validTypes := []string{
 		SectionTypeHero, SectionTypeCards, SectionTypeCarousel,
 		SectionTypeFAQs, SectionTypeLinks, SectionTypeSocials,
 		SectionTypeTestimonials, SectionTypeCTA, SectionTypeText,
-		SectionTypeVideo,
+		SectionTypeVideo, SectionTypeLegal,
 	}
 	return contains(validTypes, t)
 }
@@ -193,6 +560,50 @@ func IsValidThemeType(t string) bool {
 	return contains(validTypes, t)
 }
 
+// IsValidCardLayout check apakah tipe layout section cards valid
+func IsValidCardLayout(t string) bool {
+	return contains([]string{CardLayoutGrid, CardLayoutList, CardLayoutCarousel}, t)
+}
+
+// IsValidImageAspectRatio check apakah rasio gambar card valid
+func IsValidImageAspectRatio(r string) bool {
+	return contains([]string{"1:1", "4:3", "3:4", "3:2", "16:9"}, r)
+}
+
+// IsValidReviewStatus check apakah status ulasan valid
+func IsValidReviewStatus(s string) bool {
+	validStatuses := []string{ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected}
+	return contains(validStatuses, s)
+}
+
+// IsValidInquiryStatus check apakah status inquiry valid
+func IsValidInquiryStatus(s string) bool {
+	validStatuses := []string{InquiryStatusNew, InquiryStatusRead, InquiryStatusArchived}
+	return contains(validStatuses, s)
+}
+
+// IsValidOrderStatus check apakah status order valid
+func IsValidOrderStatus(s string) bool {
+	validStatuses := []string{OrderStatusNew, OrderStatusContacted, OrderStatusCompleted, OrderStatusCancelled}
+	return contains(validStatuses, s)
+}
+
+// IsValidMarketplace check apakah marketplace provider valid
+func IsValidMarketplace(p string) bool {
+	validProviders := []string{
+		MarketplaceShopee, MarketplaceTokopedia,
+	}
+	return contains(validProviders, p)
+}
+
+// IsValidSyncConflictPolicy check apakah conflict policy valid
+func IsValidSyncConflictPolicy(p string) bool {
+	validPolicies := []string{
+		SyncConflictRemoteWins, SyncConflictLocalWins, SyncConflictCreateOnly,
+	}
+	return contains(validPolicies, p)
+}
+
 // Helper function
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS atamlink.idx_affiliate_clicks_card;
+DROP TABLE IF EXISTS atamlink.catalog_affiliate_clicks;
+
+ALTER TABLE atamlink.catalog_cards
+    DROP COLUMN IF EXISTS cc_affiliate_commission_rate,
+    DROP COLUMN IF EXISTS cc_affiliate_partner_id;
@@ -0,0 +1,16 @@
+-- Affiliate metadata pada card
+ALTER TABLE atamlink.catalog_cards
+    ADD COLUMN cc_affiliate_partner_id VARCHAR(100),
+    ADD COLUMN cc_affiliate_commission_rate NUMERIC(5,2);
+
+-- Atribusi klik affiliate dari redirect click-tracking
+CREATE TABLE atamlink.catalog_affiliate_clicks (
+    cac_id BIGSERIAL PRIMARY KEY,
+    cac_cc_id BIGINT NOT NULL REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    cac_partner_id VARCHAR(100) NOT NULL,
+    cac_commission_rate NUMERIC(5,2) NOT NULL,
+    cac_amount INTEGER NOT NULL DEFAULT 0,
+    cac_clicked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_affiliate_clicks_card ON atamlink.catalog_affiliate_clicks(cac_cc_id, cac_clicked_at);
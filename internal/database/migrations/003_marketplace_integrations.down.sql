@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS atamlink.idx_integration_mappings_card;
+DROP INDEX IF EXISTS atamlink.idx_business_integrations_due;
+DROP INDEX IF EXISTS atamlink.idx_business_integrations_business;
+
+DROP TABLE IF EXISTS atamlink.integration_product_mappings;
+DROP TABLE IF EXISTS atamlink.business_integrations;
@@ -0,0 +1,34 @@
+-- Koneksi akun marketplace per business
+CREATE TABLE atamlink.business_integrations (
+    bint_id BIGSERIAL PRIMARY KEY,
+    bint_b_id BIGINT NOT NULL REFERENCES atamlink.businesses(b_id) ON DELETE CASCADE,
+    bint_provider VARCHAR(30) NOT NULL,
+    bint_shop_id VARCHAR(100) NOT NULL,
+    bint_credentials JSONB NOT NULL DEFAULT '{}',
+    bint_cs_id BIGINT NOT NULL REFERENCES atamlink.catalog_sections(cs_id) ON DELETE CASCADE,
+    bint_conflict_policy VARCHAR(20) NOT NULL DEFAULT 'remote_wins',
+    bint_sync_interval_minutes INTEGER NOT NULL DEFAULT 360,
+    bint_is_active BOOLEAN NOT NULL DEFAULT true,
+    bint_last_synced_at TIMESTAMP,
+    bint_last_sync_status VARCHAR(20),
+    bint_last_sync_error TEXT,
+    bint_created_by BIGINT NOT NULL,
+    bint_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    bint_updated_at TIMESTAMP,
+    UNIQUE (bint_b_id, bint_provider, bint_shop_id)
+);
+
+-- Mapping produk marketplace ke card
+CREATE TABLE atamlink.integration_product_mappings (
+    ipm_id BIGSERIAL PRIMARY KEY,
+    ipm_bint_id BIGINT NOT NULL REFERENCES atamlink.business_integrations(bint_id) ON DELETE CASCADE,
+    ipm_external_id VARCHAR(100) NOT NULL,
+    ipm_cc_id BIGINT NOT NULL REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    ipm_checksum VARCHAR(64) NOT NULL,
+    ipm_synced_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (ipm_bint_id, ipm_external_id)
+);
+
+CREATE INDEX idx_business_integrations_business ON atamlink.business_integrations(bint_b_id);
+CREATE INDEX idx_business_integrations_due ON atamlink.business_integrations(bint_last_synced_at) WHERE bint_is_active = true;
+CREATE INDEX idx_integration_mappings_card ON atamlink.integration_product_mappings(ipm_cc_id);
@@ -0,0 +1,19 @@
+-- Checkout link pembayaran per card
+CREATE TABLE atamlink.catalog_checkout_links (
+    ccl_id BIGSERIAL PRIMARY KEY,
+    ccl_cc_id BIGINT NOT NULL REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    ccl_external_id VARCHAR(100) NOT NULL UNIQUE,
+    ccl_gateway_id VARCHAR(100),
+    ccl_quantity INTEGER NOT NULL DEFAULT 1,
+    ccl_amount BIGINT NOT NULL,
+    ccl_currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    ccl_payment_url TEXT NOT NULL,
+    ccl_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    ccl_expires_at TIMESTAMP,
+    ccl_paid_at TIMESTAMP,
+    ccl_created_by BIGINT NOT NULL REFERENCES atamlink.user_profiles(up_id),
+    ccl_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ccl_updated_at TIMESTAMP
+);
+
+CREATE INDEX idx_checkout_links_card ON atamlink.catalog_checkout_links(ccl_cc_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS atamlink.notification_logs;
+DROP TABLE IF EXISTS atamlink.business_notification_channels;
@@ -0,0 +1,30 @@
+-- Channel notifikasi per business (opt-in)
+CREATE TABLE atamlink.business_notification_channels (
+    bnc_id BIGSERIAL PRIMARY KEY,
+    bnc_b_id BIGINT NOT NULL REFERENCES atamlink.businesses(b_id) ON DELETE CASCADE,
+    bnc_channel VARCHAR(20) NOT NULL,
+    bnc_recipient VARCHAR(100) NOT NULL,
+    bnc_credentials TEXT NOT NULL, -- terenkripsi oleh vault service
+    bnc_config JSONB NOT NULL DEFAULT '{}',
+    bnc_is_enabled BOOLEAN NOT NULL DEFAULT true,
+    bnc_created_by BIGINT NOT NULL REFERENCES atamlink.user_profiles(up_id),
+    bnc_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    bnc_updated_by BIGINT REFERENCES atamlink.user_profiles(up_id),
+    bnc_updated_at TIMESTAMP,
+    UNIQUE (bnc_b_id, bnc_channel)
+);
+
+-- Log pengiriman notifikasi dan status delivery
+CREATE TABLE atamlink.notification_logs (
+    nl_id BIGSERIAL PRIMARY KEY,
+    nl_bnc_id BIGINT NOT NULL REFERENCES atamlink.business_notification_channels(bnc_id) ON DELETE CASCADE,
+    nl_event VARCHAR(50) NOT NULL,
+    nl_status VARCHAR(20) NOT NULL,
+    nl_provider_message_id VARCHAR(255),
+    nl_error TEXT,
+    nl_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    nl_updated_at TIMESTAMP
+);
+
+CREATE INDEX idx_notification_logs_channel ON atamlink.notification_logs(nl_bnc_id, nl_created_at DESC);
+CREATE INDEX idx_notification_logs_message ON atamlink.notification_logs(nl_provider_message_id);
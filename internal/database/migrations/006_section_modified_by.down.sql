@@ -0,0 +1,3 @@
+ALTER TABLE atamlink.catalog_sections
+    DROP COLUMN IF EXISTS cs_updated_by,
+    DROP COLUMN IF EXISTS cs_created_by;
@@ -0,0 +1,15 @@
+-- Pencatatan editor pada section (card sudah punya cc_created_by/cc_updated_by)
+ALTER TABLE atamlink.catalog_sections
+    ADD COLUMN cs_created_by BIGINT,
+    ADD COLUMN cs_updated_by BIGINT;
+
+-- Backfill dari audit log terakhir per section bila ada
+UPDATE atamlink.catalog_sections cs
+SET cs_updated_by = al.al_user_profile_id
+FROM (
+    SELECT DISTINCT ON (al_record_id) al_record_id, al_user_profile_id
+    FROM atamlink.audit_logs
+    WHERE al_table_name = 'sections' AND al_user_profile_id IS NOT NULL
+    ORDER BY al_record_id, al_timestamp DESC
+) al
+WHERE al.al_record_id = cs.cs_id::TEXT;
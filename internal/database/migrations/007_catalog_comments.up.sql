@@ -0,0 +1,20 @@
+-- Komentar internal (non-publik) pada section dan card untuk review tim
+CREATE TABLE atamlink.catalog_comments (
+    cmt_id BIGSERIAL PRIMARY KEY,
+    cmt_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cmt_cs_id BIGINT REFERENCES atamlink.catalog_sections(cs_id) ON DELETE CASCADE,
+    cmt_cc_id BIGINT REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    cmt_parent_id BIGINT REFERENCES atamlink.catalog_comments(cmt_id) ON DELETE CASCADE,
+    cmt_body TEXT NOT NULL,
+    cmt_mentions BIGINT[] NOT NULL DEFAULT '{}',
+    cmt_is_resolved BOOLEAN NOT NULL DEFAULT false,
+    cmt_resolved_by BIGINT,
+    cmt_resolved_at TIMESTAMP,
+    cmt_created_by BIGINT NOT NULL,
+    cmt_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (cmt_cs_id IS NOT NULL OR cmt_cc_id IS NOT NULL)
+);
+
+CREATE INDEX idx_catalog_comments_section ON atamlink.catalog_comments(cmt_cs_id);
+CREATE INDEX idx_catalog_comments_card ON atamlink.catalog_comments(cmt_cc_id);
+CREATE INDEX idx_catalog_comments_parent ON atamlink.catalog_comments(cmt_parent_id);
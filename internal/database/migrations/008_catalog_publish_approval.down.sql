@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS atamlink.catalog_publish_requests;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_published_by,
+    DROP COLUMN IF EXISTS c_published_at,
+    DROP COLUMN IF EXISTS c_status;
+
+-- Nilai enum 'reviewer' dan aksi audit PUBLISH_* tidak bisa dihapus tanpa membuat ulang type
@@ -0,0 +1,32 @@
+-- Role reviewer dan aksi audit untuk alur approval publish
+-- (ALTER TYPE ... ADD VALUE tidak bisa dijalankan di dalam transaksi pada PostgreSQL < 12)
+ALTER TYPE business_role ADD VALUE IF NOT EXISTS 'reviewer';
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'PUBLISH_REQUESTED';
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'PUBLISH_APPROVED';
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'PUBLISH_CHANGES_REQUESTED';
+
+-- Status publish katalog, katalog lama dianggap sudah published
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_status VARCHAR(20) NOT NULL DEFAULT 'published'
+        CHECK (c_status IN ('draft', 'in_review', 'published')),
+    ADD COLUMN c_published_at TIMESTAMP,
+    ADD COLUMN c_published_by BIGINT;
+
+ALTER TABLE atamlink.catalogs ALTER COLUMN c_status SET DEFAULT 'draft';
+
+-- Pengajuan publish oleh editor, direview oleh reviewer
+CREATE TABLE atamlink.catalog_publish_requests (
+    cpr_id BIGSERIAL PRIMARY KEY,
+    cpr_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cpr_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (cpr_status IN ('pending', 'approved', 'changes_requested')),
+    cpr_note TEXT,
+    cpr_review_comment TEXT,
+    cpr_requested_by BIGINT NOT NULL,
+    cpr_requested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cpr_reviewed_by BIGINT,
+    cpr_reviewed_at TIMESTAMP
+);
+
+CREATE INDEX idx_publish_requests_catalog ON atamlink.catalog_publish_requests(cpr_c_id, cpr_requested_at DESC);
+CREATE UNIQUE INDEX idx_publish_requests_pending ON atamlink.catalog_publish_requests(cpr_c_id) WHERE cpr_status = 'pending';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS atamlink.idx_catalogs_publish_scheduled;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_publish_scheduled_by,
+    DROP COLUMN IF EXISTS c_publish_scheduled_at;
+
+-- Nilai enum audit_action_type tidak bisa dihapus, 'SCHEDULED_PUBLISH' dibiarkan
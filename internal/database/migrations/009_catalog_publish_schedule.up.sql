@@ -0,0 +1,10 @@
+-- Aksi audit untuk publish otomatis oleh scheduler
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'SCHEDULED_PUBLISH';
+
+-- Jadwal publish katalog draft
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_publish_scheduled_at TIMESTAMP,
+    ADD COLUMN c_publish_scheduled_by BIGINT;
+
+CREATE INDEX idx_catalogs_publish_scheduled ON atamlink.catalogs(c_publish_scheduled_at)
+    WHERE c_publish_scheduled_at IS NOT NULL;
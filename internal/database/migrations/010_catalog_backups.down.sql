@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS atamlink.catalog_backups;
+
+-- Nilai enum audit_action_type 'BACKUP_RESTORED' tidak bisa dihapus
@@ -0,0 +1,21 @@
+-- Aksi audit untuk restore katalog dari backup
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'BACKUP_RESTORED';
+
+-- Riwayat backup katalog per business, file JSON disimpan di backup storage
+CREATE TABLE atamlink.catalog_backups (
+    cb_id BIGSERIAL PRIMARY KEY,
+    cb_b_id BIGINT NOT NULL REFERENCES atamlink.businesses(b_id) ON DELETE CASCADE,
+    cb_trigger VARCHAR(20) NOT NULL CHECK (cb_trigger IN ('scheduled', 'manual')),
+    cb_status VARCHAR(20) NOT NULL CHECK (cb_status IN ('completed', 'failed', 'expired')),
+    cb_storage_key TEXT,
+    cb_catalog_count INT NOT NULL DEFAULT 0,
+    cb_size_bytes BIGINT NOT NULL DEFAULT 0,
+    cb_checksum VARCHAR(64),
+    cb_error TEXT,
+    cb_created_by BIGINT,
+    cb_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cb_restored_by BIGINT,
+    cb_restored_at TIMESTAMP
+);
+
+CREATE INDEX idx_catalog_backups_business ON atamlink.catalog_backups(cb_b_id, cb_created_at DESC);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS atamlink.catalog_media_replicas;
+
+ALTER TABLE atamlink.businesses
+    DROP COLUMN IF EXISTS b_media_replication;
@@ -0,0 +1,15 @@
+-- Opsi replikasi media ke region/CDN kedua per business
+ALTER TABLE atamlink.businesses
+    ADD COLUMN b_media_replication BOOLEAN NOT NULL DEFAULT false;
+
+-- Salinan media di storage region kedua, dikunci per URL sumber
+CREATE TABLE atamlink.catalog_media_replicas (
+    cmr_id BIGSERIAL PRIMARY KEY,
+    cmr_source_url TEXT NOT NULL UNIQUE,
+    cmr_replica_url TEXT,
+    cmr_status VARCHAR(20) NOT NULL CHECK (cmr_status IN ('completed', 'failed')),
+    cmr_attempts INT NOT NULL DEFAULT 0,
+    cmr_error TEXT,
+    cmr_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cmr_updated_at TIMESTAMP
+);
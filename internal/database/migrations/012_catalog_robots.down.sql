@@ -0,0 +1 @@
+ALTER TABLE atamlink.catalogs DROP COLUMN IF EXISTS c_allow_indexing;
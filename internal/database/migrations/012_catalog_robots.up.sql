@@ -0,0 +1,3 @@
+-- Kontrol indexing mesin pencari per katalog (private-but-linkable)
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_allow_indexing BOOLEAN NOT NULL DEFAULT true;
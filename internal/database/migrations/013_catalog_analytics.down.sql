@@ -0,0 +1 @@
+DROP TABLE IF EXISTS atamlink.catalog_daily_stats;
@@ -0,0 +1,9 @@
+-- Statistik harian katalog publik, view mentah (termasuk bot) dan view manusia dipisah
+CREATE TABLE atamlink.catalog_daily_stats (
+    cds_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cds_date DATE NOT NULL,
+    cds_raw_views BIGINT NOT NULL DEFAULT 0,
+    cds_views BIGINT NOT NULL DEFAULT 0,
+    cds_updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cds_c_id, cds_date)
+);
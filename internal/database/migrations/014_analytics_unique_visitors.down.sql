@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS atamlink.catalog_daily_visitors;
+DROP TABLE IF EXISTS atamlink.analytics_daily_salts;
+ALTER TABLE atamlink.catalog_daily_stats DROP COLUMN IF EXISTS cds_unique_visitors;
@@ -0,0 +1,20 @@
+-- Estimasi pengunjung unik harian tanpa menyimpan IP mentah
+ALTER TABLE atamlink.catalog_daily_stats
+    ADD COLUMN cds_unique_visitors BIGINT NOT NULL DEFAULT 0;
+
+-- Salt acak per hari, dihapus setelah lewat sehingga hash lama tidak bisa dicocokkan ulang
+CREATE TABLE atamlink.analytics_daily_salts (
+    ads_date DATE PRIMARY KEY,
+    ads_salt BYTEA NOT NULL,
+    ads_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Hash sha256(salt harian + katalog + IP + user agent), hanya disimpan selama hari berjalan
+CREATE TABLE atamlink.catalog_daily_visitors (
+    cdv_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cdv_date DATE NOT NULL,
+    cdv_visitor_hash CHAR(64) NOT NULL,
+    PRIMARY KEY (cdv_c_id, cdv_date, cdv_visitor_hash)
+);
+
+CREATE INDEX idx_catalog_daily_visitors_date ON atamlink.catalog_daily_visitors(cdv_date);
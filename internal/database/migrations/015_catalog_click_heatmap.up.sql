@@ -0,0 +1,11 @@
+-- Agregat posisi klik per section/card (grid 10x10 relatif terhadap section), tanpa data pengunjung
+CREATE TABLE atamlink.catalog_click_stats (
+    ccs_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    ccs_cs_id BIGINT NOT NULL REFERENCES atamlink.catalog_sections(cs_id) ON DELETE CASCADE,
+    ccs_cc_id BIGINT NOT NULL DEFAULT 0, -- 0 = klik di luar card
+    ccs_date DATE NOT NULL,
+    ccs_bucket_x SMALLINT NOT NULL CHECK (ccs_bucket_x BETWEEN 0 AND 9),
+    ccs_bucket_y SMALLINT NOT NULL CHECK (ccs_bucket_y BETWEEN 0 AND 9),
+    ccs_clicks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (ccs_c_id, ccs_date, ccs_cs_id, ccs_cc_id, ccs_bucket_x, ccs_bucket_y)
+);
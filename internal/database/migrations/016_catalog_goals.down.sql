@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS atamlink.catalog_daily_conversions;
+DROP TABLE IF EXISTS atamlink.catalog_goals;
@@ -0,0 +1,22 @@
+-- Goal konversi per katalog, dihitung terhadap view manusia
+CREATE TABLE atamlink.catalog_goals (
+    cg_id BIGSERIAL PRIMARY KEY,
+    cg_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cg_name VARCHAR(100) NOT NULL,
+    cg_event VARCHAR(30) NOT NULL CHECK (cg_event IN ('whatsapp_click', 'checkout_click', 'order_submitted')),
+    cg_cc_id BIGINT, -- goal khusus satu card, NULL = semua card
+    cg_created_by BIGINT NOT NULL REFERENCES atamlink.user_profiles(up_id),
+    cg_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_catalog_goals_catalog ON atamlink.catalog_goals(cg_c_id);
+
+-- Jumlah event konversi harian per card (0 = tanpa card)
+CREATE TABLE atamlink.catalog_daily_conversions (
+    cdc_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cdc_date DATE NOT NULL,
+    cdc_event VARCHAR(30) NOT NULL,
+    cdc_cc_id BIGINT NOT NULL DEFAULT 0,
+    cdc_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cdc_c_id, cdc_date, cdc_event, cdc_cc_id)
+);
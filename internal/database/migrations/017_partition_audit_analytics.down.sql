@@ -0,0 +1,85 @@
+-- Kembalikan tabel partisi ke tabel biasa, data semua partisi dipindahkan
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_user_profile_id;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_business_id;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_timestamp;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_record;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_action;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_context_gin;
+
+ALTER TABLE atamlink.audit_logs RENAME TO audit_logs_partitioned;
+ALTER TABLE atamlink.audit_logs_partitioned RENAME CONSTRAINT audit_logs_pkey TO audit_logs_partitioned_pkey;
+ALTER SEQUENCE atamlink.audit_logs_al_id_seq OWNED BY NONE;
+
+CREATE TABLE atamlink.audit_logs (
+    al_id BIGINT PRIMARY KEY DEFAULT nextval('atamlink.audit_logs_al_id_seq'),
+    al_timestamp TIMESTAMPTZ NOT NULL DEFAULT now(),
+    al_user_profile_id BIGINT REFERENCES atamlink.user_profiles(up_id) ON DELETE SET NULL,
+    al_business_id BIGINT REFERENCES atamlink.businesses(b_id) ON DELETE SET NULL,
+    al_action audit_action_type NOT NULL,
+    al_table_name TEXT,
+    al_record_id TEXT,
+    al_old_data JSONB,
+    al_new_data JSONB,
+    al_context JSONB,
+    al_reason TEXT
+);
+
+ALTER SEQUENCE atamlink.audit_logs_al_id_seq OWNED BY atamlink.audit_logs.al_id;
+
+INSERT INTO atamlink.audit_logs SELECT * FROM atamlink.audit_logs_partitioned;
+DROP TABLE atamlink.audit_logs_partitioned;
+
+CREATE INDEX idx_audit_logs_user_profile_id ON atamlink.audit_logs(al_user_profile_id);
+CREATE INDEX idx_audit_logs_business_id ON atamlink.audit_logs(al_business_id);
+CREATE INDEX idx_audit_logs_timestamp ON atamlink.audit_logs(al_timestamp);
+CREATE INDEX idx_audit_logs_record ON atamlink.audit_logs(al_table_name, al_record_id);
+CREATE INDEX idx_audit_logs_action ON atamlink.audit_logs(al_action);
+CREATE INDEX idx_audit_logs_context_gin ON atamlink.audit_logs USING GIN(al_context);
+
+ALTER TABLE atamlink.catalog_daily_stats RENAME TO catalog_daily_stats_partitioned;
+ALTER TABLE atamlink.catalog_daily_stats_partitioned RENAME CONSTRAINT catalog_daily_stats_pkey TO catalog_daily_stats_partitioned_pkey;
+
+CREATE TABLE atamlink.catalog_daily_stats (
+    cds_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cds_date DATE NOT NULL,
+    cds_raw_views BIGINT NOT NULL DEFAULT 0,
+    cds_views BIGINT NOT NULL DEFAULT 0,
+    cds_updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cds_unique_visitors BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cds_c_id, cds_date)
+);
+
+INSERT INTO atamlink.catalog_daily_stats SELECT * FROM atamlink.catalog_daily_stats_partitioned;
+DROP TABLE atamlink.catalog_daily_stats_partitioned;
+
+ALTER TABLE atamlink.catalog_click_stats RENAME TO catalog_click_stats_partitioned;
+ALTER TABLE atamlink.catalog_click_stats_partitioned RENAME CONSTRAINT catalog_click_stats_pkey TO catalog_click_stats_partitioned_pkey;
+
+CREATE TABLE atamlink.catalog_click_stats (
+    ccs_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    ccs_cs_id BIGINT NOT NULL REFERENCES atamlink.catalog_sections(cs_id) ON DELETE CASCADE,
+    ccs_cc_id BIGINT NOT NULL DEFAULT 0, -- 0 = klik di luar card
+    ccs_date DATE NOT NULL,
+    ccs_bucket_x SMALLINT NOT NULL CHECK (ccs_bucket_x BETWEEN 0 AND 9),
+    ccs_bucket_y SMALLINT NOT NULL CHECK (ccs_bucket_y BETWEEN 0 AND 9),
+    ccs_clicks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (ccs_c_id, ccs_date, ccs_cs_id, ccs_cc_id, ccs_bucket_x, ccs_bucket_y)
+);
+
+INSERT INTO atamlink.catalog_click_stats SELECT * FROM atamlink.catalog_click_stats_partitioned;
+DROP TABLE atamlink.catalog_click_stats_partitioned;
+
+ALTER TABLE atamlink.catalog_daily_conversions RENAME TO catalog_daily_conversions_partitioned;
+ALTER TABLE atamlink.catalog_daily_conversions_partitioned RENAME CONSTRAINT catalog_daily_conversions_pkey TO catalog_daily_conversions_partitioned_pkey;
+
+CREATE TABLE atamlink.catalog_daily_conversions (
+    cdc_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cdc_date DATE NOT NULL,
+    cdc_event VARCHAR(30) NOT NULL,
+    cdc_cc_id BIGINT NOT NULL DEFAULT 0,
+    cdc_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cdc_c_id, cdc_date, cdc_event, cdc_cc_id)
+);
+
+INSERT INTO atamlink.catalog_daily_conversions SELECT * FROM atamlink.catalog_daily_conversions_partitioned;
+DROP TABLE atamlink.catalog_daily_conversions_partitioned;
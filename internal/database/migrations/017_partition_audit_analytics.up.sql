@@ -0,0 +1,106 @@
+-- Partisi bulanan (RANGE) untuk audit log dan agregat analytics.
+-- Partisi bulan depan dibuat dan partisi kadaluarsa dihapus oleh job partition_maintenance.
+-- Butuh PostgreSQL 12+ (foreign key dari tabel partisi).
+
+-- Audit log: PK harus menyertakan kolom partisi
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_user_profile_id;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_business_id;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_timestamp;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_record;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_action;
+DROP INDEX IF EXISTS atamlink.idx_audit_logs_context_gin;
+
+ALTER TABLE atamlink.audit_logs RENAME TO audit_logs_legacy;
+ALTER TABLE atamlink.audit_logs_legacy RENAME CONSTRAINT audit_logs_pkey TO audit_logs_legacy_pkey;
+ALTER SEQUENCE atamlink.audit_logs_al_id_seq OWNED BY NONE;
+
+CREATE TABLE atamlink.audit_logs (
+    al_id BIGINT NOT NULL DEFAULT nextval('atamlink.audit_logs_al_id_seq'),
+    al_timestamp TIMESTAMPTZ NOT NULL DEFAULT now(),
+    al_user_profile_id BIGINT REFERENCES atamlink.user_profiles(up_id) ON DELETE SET NULL,
+    al_business_id BIGINT REFERENCES atamlink.businesses(b_id) ON DELETE SET NULL,
+    al_action audit_action_type NOT NULL,
+    al_table_name TEXT,
+    al_record_id TEXT,
+    al_old_data JSONB,
+    al_new_data JSONB,
+    al_context JSONB,
+    al_reason TEXT,
+    PRIMARY KEY (al_id, al_timestamp)
+) PARTITION BY RANGE (al_timestamp);
+
+ALTER SEQUENCE atamlink.audit_logs_al_id_seq OWNED BY atamlink.audit_logs.al_id;
+
+CREATE INDEX idx_audit_logs_user_profile_id ON atamlink.audit_logs(al_user_profile_id);
+CREATE INDEX idx_audit_logs_business_id ON atamlink.audit_logs(al_business_id);
+CREATE INDEX idx_audit_logs_timestamp ON atamlink.audit_logs(al_timestamp);
+CREATE INDEX idx_audit_logs_record ON atamlink.audit_logs(al_table_name, al_record_id);
+CREATE INDEX idx_audit_logs_action ON atamlink.audit_logs(al_action);
+CREATE INDEX idx_audit_logs_context_gin ON atamlink.audit_logs USING GIN(al_context);
+
+-- Agregat analytics, kolom tanggal sudah bagian dari PK
+ALTER TABLE atamlink.catalog_daily_stats RENAME TO catalog_daily_stats_legacy;
+ALTER TABLE atamlink.catalog_daily_stats_legacy RENAME CONSTRAINT catalog_daily_stats_pkey TO catalog_daily_stats_legacy_pkey;
+
+CREATE TABLE atamlink.catalog_daily_stats (
+    cds_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cds_date DATE NOT NULL,
+    cds_raw_views BIGINT NOT NULL DEFAULT 0,
+    cds_views BIGINT NOT NULL DEFAULT 0,
+    cds_updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    cds_unique_visitors BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cds_c_id, cds_date)
+) PARTITION BY RANGE (cds_date);
+
+ALTER TABLE atamlink.catalog_click_stats RENAME TO catalog_click_stats_legacy;
+ALTER TABLE atamlink.catalog_click_stats_legacy RENAME CONSTRAINT catalog_click_stats_pkey TO catalog_click_stats_legacy_pkey;
+
+CREATE TABLE atamlink.catalog_click_stats (
+    ccs_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    ccs_cs_id BIGINT NOT NULL REFERENCES atamlink.catalog_sections(cs_id) ON DELETE CASCADE,
+    ccs_cc_id BIGINT NOT NULL DEFAULT 0, -- 0 = klik di luar card
+    ccs_date DATE NOT NULL,
+    ccs_bucket_x SMALLINT NOT NULL CHECK (ccs_bucket_x BETWEEN 0 AND 9),
+    ccs_bucket_y SMALLINT NOT NULL CHECK (ccs_bucket_y BETWEEN 0 AND 9),
+    ccs_clicks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (ccs_c_id, ccs_date, ccs_cs_id, ccs_cc_id, ccs_bucket_x, ccs_bucket_y)
+) PARTITION BY RANGE (ccs_date);
+
+ALTER TABLE atamlink.catalog_daily_conversions RENAME TO catalog_daily_conversions_legacy;
+ALTER TABLE atamlink.catalog_daily_conversions_legacy RENAME CONSTRAINT catalog_daily_conversions_pkey TO catalog_daily_conversions_legacy_pkey;
+
+CREATE TABLE atamlink.catalog_daily_conversions (
+    cdc_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cdc_date DATE NOT NULL,
+    cdc_event VARCHAR(30) NOT NULL,
+    cdc_cc_id BIGINT NOT NULL DEFAULT 0,
+    cdc_count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cdc_c_id, cdc_date, cdc_event, cdc_cc_id)
+) PARTITION BY RANGE (cdc_date);
+
+-- Partisi bulanan untuk data lama sampai 2 bulan ke depan, sisanya ke partisi default
+DO $$
+DECLARE
+    t RECORD;
+    m DATE;
+BEGIN
+    FOR t IN SELECT * FROM (VALUES
+        ('audit_logs', 'al_timestamp'),
+        ('catalog_daily_stats', 'cds_date'),
+        ('catalog_click_stats', 'ccs_date'),
+        ('catalog_daily_conversions', 'cdc_date')
+    ) AS v(tbl, col) LOOP
+        EXECUTE format('SELECT date_trunc(''month'', COALESCE(MIN(%I), now()))::date FROM atamlink.%I',
+            t.col, t.tbl || '_legacy') INTO m;
+
+        WHILE m <= (date_trunc('month', now()) + interval '2 months')::date LOOP
+            EXECUTE format('CREATE TABLE atamlink.%I PARTITION OF atamlink.%I FOR VALUES FROM (%L) TO (%L)',
+                t.tbl || '_p' || to_char(m, 'YYYYMM'), t.tbl, m, (m + interval '1 month')::date);
+            m := (m + interval '1 month')::date;
+        END LOOP;
+
+        EXECUTE format('CREATE TABLE atamlink.%I PARTITION OF atamlink.%I DEFAULT', t.tbl || '_default', t.tbl);
+        EXECUTE format('INSERT INTO atamlink.%I SELECT * FROM atamlink.%I', t.tbl, t.tbl || '_legacy');
+        EXECUTE format('DROP TABLE atamlink.%I', t.tbl || '_legacy');
+    END LOOP;
+END $$;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS atamlink.idx_faqs_section_order;
+
+ALTER TABLE atamlink.catalog_faqs
+    DROP COLUMN IF EXISTS cf_display_order;
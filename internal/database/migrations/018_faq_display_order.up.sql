@@ -0,0 +1,13 @@
+-- Urutan tampil FAQ dalam section, FAQ lama diurutkan sesuai urutan dibuat
+ALTER TABLE atamlink.catalog_faqs
+    ADD COLUMN cf_display_order INT NOT NULL DEFAULT 0;
+
+UPDATE atamlink.catalog_faqs f
+SET cf_display_order = o.rn
+FROM (
+    SELECT cf_id, ROW_NUMBER() OVER (PARTITION BY cf_cs_id ORDER BY cf_id) AS rn
+    FROM atamlink.catalog_faqs
+) o
+WHERE f.cf_id = o.cf_id;
+
+CREATE INDEX idx_faqs_section_order ON atamlink.catalog_faqs(cf_cs_id, cf_display_order);
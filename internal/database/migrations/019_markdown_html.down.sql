@@ -0,0 +1,5 @@
+ALTER TABLE atamlink.catalog_faqs
+    DROP COLUMN IF EXISTS cf_answer_html;
+
+ALTER TABLE atamlink.catalog_card_details
+    DROP COLUMN IF EXISTS ccd_description_html;
@@ -0,0 +1,6 @@
+-- Hasil render Markdown yang sudah disanitasi, NULL = data lama, dirender saat dibaca
+ALTER TABLE atamlink.catalog_card_details
+    ADD COLUMN ccd_description_html TEXT;
+
+ALTER TABLE atamlink.catalog_faqs
+    ADD COLUMN cf_answer_html TEXT;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS atamlink.idx_cards_section_position;
+DROP INDEX IF EXISTS atamlink.idx_sections_catalog_position;
+
+ALTER TABLE atamlink.catalog_cards
+    DROP COLUMN IF EXISTS cc_position;
+
+ALTER TABLE atamlink.catalog_sections
+    DROP COLUMN IF EXISTS cs_position;
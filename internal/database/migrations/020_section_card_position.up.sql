@@ -0,0 +1,26 @@
+-- Posisi section dan card dengan jarak (100, 200, ...) supaya pindah satu item
+-- cukup update satu baris. Data lama diurutkan sesuai urutan dibuat.
+ALTER TABLE atamlink.catalog_sections
+    ADD COLUMN cs_position INT NOT NULL DEFAULT 0;
+
+ALTER TABLE atamlink.catalog_cards
+    ADD COLUMN cc_position INT NOT NULL DEFAULT 0;
+
+UPDATE atamlink.catalog_sections s
+SET cs_position = o.rn * 100
+FROM (
+    SELECT cs_id, ROW_NUMBER() OVER (PARTITION BY cs_c_id ORDER BY cs_id) AS rn
+    FROM atamlink.catalog_sections
+) o
+WHERE s.cs_id = o.cs_id;
+
+UPDATE atamlink.catalog_cards c
+SET cc_position = o.rn * 100
+FROM (
+    SELECT cc_id, ROW_NUMBER() OVER (PARTITION BY cc_cs_id ORDER BY cc_id) AS rn
+    FROM atamlink.catalog_cards
+) o
+WHERE c.cc_id = o.cc_id;
+
+CREATE INDEX idx_sections_catalog_position ON atamlink.catalog_sections(cs_c_id, cs_position);
+CREATE INDEX idx_cards_section_position ON atamlink.catalog_cards(cc_cs_id, cc_position);
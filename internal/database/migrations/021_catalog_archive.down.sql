@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS atamlink.idx_catalogs_archived;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_archive_key,
+    DROP COLUMN IF EXISTS c_archived_at;
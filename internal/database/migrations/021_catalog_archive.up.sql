@@ -0,0 +1,7 @@
+-- Katalog yang lama tidak aktif diarsipkan ke cold storage (JSON di backup
+-- storage), konten section dihapus dan dipulihkan saat pemilik membukanya lagi
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_archived_at TIMESTAMP,
+    ADD COLUMN c_archive_key TEXT;
+
+CREATE INDEX idx_catalogs_archived ON atamlink.catalogs(c_archived_at) WHERE c_archived_at IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE atamlink.businesses
+    DROP COLUMN IF EXISTS b_brand;
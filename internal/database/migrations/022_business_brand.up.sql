@@ -0,0 +1,3 @@
+-- Brand business (warna, font, pemakaian logo) jadi default settings katalog baru
+ALTER TABLE atamlink.businesses
+    ADD COLUMN b_brand JSONB NOT NULL DEFAULT '{}'::jsonb;
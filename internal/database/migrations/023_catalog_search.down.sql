@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS atamlink.idx_catalogs_search;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_search;
@@ -0,0 +1,10 @@
+-- Postgres full-text search untuk pencarian katalog saat search engine tidak dikonfigurasi
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_search tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple',
+            coalesce(c_title, '') || ' ' ||
+            coalesce(c_subtitle, '') || ' ' ||
+            replace(c_slug, '-', ' '))
+    ) STORED;
+
+CREATE INDEX idx_catalogs_search ON atamlink.catalogs USING GIN (c_search);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS atamlink.idx_catalogs_listed;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_listed;
+
+DROP INDEX IF EXISTS atamlink.idx_businesses_city;
+
+ALTER TABLE atamlink.businesses
+    DROP COLUMN IF EXISTS b_city;
@@ -0,0 +1,12 @@
+-- Kota business untuk filter lokasi di direktori publik
+ALTER TABLE atamlink.businesses
+    ADD COLUMN b_city VARCHAR(100);
+
+CREATE INDEX idx_businesses_city ON atamlink.businesses (LOWER(b_city));
+
+-- Katalog tampil di direktori publik hanya jika pemilik ikut serta (opt-in)
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_listed BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_catalogs_listed ON atamlink.catalogs (c_published_at DESC)
+    WHERE c_listed = true AND c_is_active = true AND c_archived_at IS NULL;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS atamlink.idx_catalogs_category;
+DROP INDEX IF EXISTS atamlink.idx_businesses_category;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_mc_id;
+
+ALTER TABLE atamlink.businesses
+    DROP COLUMN IF EXISTS b_mc_id;
+
+DROP TABLE IF EXISTS atamlink.master_categories;
@@ -0,0 +1,19 @@
+-- Kategori master untuk direktori publik dan segmentasi analytics
+CREATE TABLE atamlink.master_categories (
+    mc_id          BIGSERIAL PRIMARY KEY,
+    mc_slug        VARCHAR(100) NOT NULL UNIQUE,
+    mc_name        VARCHAR(100) NOT NULL,
+    mc_description TEXT,
+    mc_is_active   BOOLEAN NOT NULL DEFAULT true,
+    mc_created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Kategori katalog (jika diisi) menggantikan kategori business
+ALTER TABLE atamlink.businesses
+    ADD COLUMN b_mc_id BIGINT REFERENCES atamlink.master_categories (mc_id);
+
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_mc_id BIGINT REFERENCES atamlink.master_categories (mc_id);
+
+CREATE INDEX idx_businesses_category ON atamlink.businesses (b_mc_id) WHERE b_mc_id IS NOT NULL;
+CREATE INDEX idx_catalogs_category ON atamlink.catalogs (c_mc_id) WHERE c_mc_id IS NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_rating_count,
+    DROP COLUMN IF EXISTS c_rating_avg;
+
+DROP TABLE IF EXISTS atamlink.catalog_reviews;
@@ -0,0 +1,24 @@
+-- Ulasan publik katalog (rating 1-5 bintang), tampil setelah dimoderasi pemilik
+CREATE TABLE atamlink.catalog_reviews (
+    cr_id BIGSERIAL PRIMARY KEY,
+    cr_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cr_rating SMALLINT NOT NULL CHECK (cr_rating BETWEEN 1 AND 5),
+    cr_name VARCHAR(100),
+    cr_body TEXT,
+    cr_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    cr_visitor_hash VARCHAR(64) NOT NULL, -- HMAC IP + user agent, IP tidak disimpan
+    cr_reply TEXT,
+    cr_replied_by BIGINT,
+    cr_replied_at TIMESTAMP,
+    cr_moderated_by BIGINT,
+    cr_moderated_at TIMESTAMP,
+    cr_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_catalog_reviews_catalog ON atamlink.catalog_reviews(cr_c_id, cr_status, cr_created_at DESC);
+CREATE INDEX idx_catalog_reviews_visitor ON atamlink.catalog_reviews(cr_visitor_hash, cr_created_at);
+
+-- Agregat rating dari ulasan approved, dihitung ulang saat moderasi
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_rating_avg NUMERIC(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN c_rating_count INT NOT NULL DEFAULT 0;
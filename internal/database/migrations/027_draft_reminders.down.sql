@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS atamlink.idx_catalogs_abandoned_draft;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_draft_reminded_at;
@@ -0,0 +1,6 @@
+-- Pengingat katalog draft yang belum pernah dipublish, dikirim sekali per katalog
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_draft_reminded_at TIMESTAMP;
+
+CREATE INDEX idx_catalogs_abandoned_draft ON atamlink.catalogs(c_created_at)
+    WHERE c_status = 'draft' AND c_published_at IS NULL AND c_draft_reminded_at IS NULL;
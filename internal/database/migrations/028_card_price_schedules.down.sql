@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS atamlink.catalog_card_price_schedules;
+
+-- Nilai enum audit_action_type tidak bisa dihapus, 'SCHEDULED_PRICE_CHANGE' dibiarkan
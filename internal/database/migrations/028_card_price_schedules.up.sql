@@ -0,0 +1,21 @@
+-- Aksi audit untuk perubahan harga otomatis oleh scheduler
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'SCHEDULED_PRICE_CHANGE';
+
+-- Jadwal perubahan harga card, diterapkan scheduler saat ccps_effective_at tercapai
+CREATE TABLE atamlink.catalog_card_price_schedules (
+    ccps_id BIGSERIAL PRIMARY KEY,
+    ccps_cc_id BIGINT NOT NULL REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    ccps_price BIGINT NOT NULL CHECK (ccps_price >= 0),
+    ccps_discount INT CHECK (ccps_discount BETWEEN 0 AND 100), -- NULL = diskon tidak diubah
+    ccps_effective_at TIMESTAMP NOT NULL,
+    ccps_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    ccps_created_by BIGINT NOT NULL,
+    ccps_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ccps_applied_at TIMESTAMP,
+    ccps_cancelled_by BIGINT,
+    ccps_cancelled_at TIMESTAMP
+);
+
+CREATE INDEX idx_card_price_schedules_card ON atamlink.catalog_card_price_schedules(ccps_cc_id, ccps_effective_at);
+CREATE INDEX idx_card_price_schedules_due ON atamlink.catalog_card_price_schedules(ccps_effective_at)
+    WHERE ccps_status = 'pending';
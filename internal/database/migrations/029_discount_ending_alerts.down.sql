@@ -0,0 +1,2 @@
+ALTER TABLE atamlink.catalog_card_price_schedules
+    DROP COLUMN IF EXISTS ccps_alerted_at;
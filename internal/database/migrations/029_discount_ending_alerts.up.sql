@@ -0,0 +1,3 @@
+-- Penanda notifikasi "diskon segera berakhir" sudah dikirim untuk jadwal harga
+ALTER TABLE atamlink.catalog_card_price_schedules
+    ADD COLUMN ccps_alerted_at TIMESTAMP;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS atamlink.business_service_accounts;
@@ -0,0 +1,20 @@
+-- Service account (machine user) business untuk integrasi seperti sinkronisasi ERP.
+-- Tidak terikat ke user/profile, token hanya disimpan sebagai hash SHA-256
+CREATE TABLE atamlink.business_service_accounts (
+    bsa_id BIGSERIAL PRIMARY KEY,
+    bsa_b_id BIGINT NOT NULL REFERENCES atamlink.businesses(b_id) ON DELETE CASCADE,
+    bsa_name VARCHAR(100) NOT NULL,
+    bsa_role business_role NOT NULL DEFAULT 'editor',
+    bsa_token_hash VARCHAR(64) NOT NULL UNIQUE,
+    bsa_token_prefix VARCHAR(16) NOT NULL, -- awal token untuk identifikasi di dashboard
+    bsa_is_active BOOLEAN NOT NULL DEFAULT true,
+    bsa_last_used_at TIMESTAMP,
+    bsa_created_by BIGINT NOT NULL,
+    bsa_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    bsa_revoked_by BIGINT,
+    bsa_revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_service_accounts_business ON atamlink.business_service_accounts(bsa_b_id);
+CREATE UNIQUE INDEX idx_service_accounts_name ON atamlink.business_service_accounts(bsa_b_id, bsa_name)
+    WHERE bsa_is_active = true;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS atamlink.user_totp_secrets;
+DROP TABLE IF EXISTS atamlink.user_step_up_challenges;
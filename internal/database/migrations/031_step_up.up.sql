@@ -0,0 +1,29 @@
+-- Verifikasi tambahan sebelum operasi destruktif (hapus bisnis, dll).
+-- Challenge email menyimpan hash kode; setelah terverifikasi challenge
+-- menerbitkan token sekali pakai yang dikirim lewat header X-Step-Up-Token
+CREATE TABLE atamlink.user_step_up_challenges (
+    usc_id BIGSERIAL PRIMARY KEY,
+    usc_up_id BIGINT NOT NULL REFERENCES atamlink.user_profiles(up_id) ON DELETE CASCADE,
+    usc_action VARCHAR(50) NOT NULL,
+    usc_method VARCHAR(10) NOT NULL CHECK (usc_method IN ('email', 'totp')),
+    usc_code_hash VARCHAR(64),
+    usc_attempts INTEGER NOT NULL DEFAULT 0,
+    usc_expires_at TIMESTAMP NOT NULL,
+    usc_verified_at TIMESTAMP,
+    usc_token_hash VARCHAR(64) UNIQUE,
+    usc_token_expires_at TIMESTAMP,
+    usc_consumed_at TIMESTAMP,
+    usc_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_step_up_challenges_profile ON atamlink.user_step_up_challenges(usc_up_id, usc_created_at DESC);
+
+-- Secret TOTP per profile, disimpan terenkripsi oleh vault service.
+-- uts_last_step mencegah kode yang sama dipakai dua kali
+CREATE TABLE atamlink.user_totp_secrets (
+    uts_up_id BIGINT PRIMARY KEY REFERENCES atamlink.user_profiles(up_id) ON DELETE CASCADE,
+    uts_secret TEXT NOT NULL,
+    uts_confirmed_at TIMESTAMP,
+    uts_last_step BIGINT NOT NULL DEFAULT 0,
+    uts_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS atamlink.user_sessions;
@@ -0,0 +1,15 @@
+-- Sesi login per token bearer (hash SHA-256), dicatat saat token pertama kali
+-- dipakai. Sesi yang dicabut membuat token ditolak walau belum kadaluarsa
+CREATE TABLE atamlink.user_sessions (
+    us_id BIGSERIAL PRIMARY KEY,
+    us_up_id BIGINT NOT NULL REFERENCES atamlink.user_profiles(up_id) ON DELETE CASCADE,
+    us_token_hash VARCHAR(64) NOT NULL UNIQUE,
+    us_ip_address VARCHAR(45),
+    us_user_agent VARCHAR(500),
+    us_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    us_last_active_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    us_revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_user_sessions_profile ON atamlink.user_sessions(us_up_id, us_last_active_at DESC)
+    WHERE us_revoked_at IS NULL;
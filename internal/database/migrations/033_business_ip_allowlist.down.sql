@@ -0,0 +1,5 @@
+ALTER TABLE atamlink.businesses
+    DROP COLUMN IF EXISTS b_ip_allowlist_bypass_until,
+    DROP COLUMN IF EXISTS b_ip_allowlist;
+
+-- Nilai enum audit_action_type 'IP_ALLOWLIST_BREAK_GLASS' tidak bisa dihapus
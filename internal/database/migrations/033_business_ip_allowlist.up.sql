@@ -0,0 +1,9 @@
+-- Aksi audit untuk pembukaan sementara IP allowlist (break-glass)
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'IP_ALLOWLIST_BREAK_GLASS';
+
+-- IP allowlist business untuk operasi tulis, kosong berarti semua IP diizinkan.
+-- Entri berupa IP atau CIDR. Bypass diisi oleh break-glass owner dan kosong
+-- kembali saat allowlist diperbarui
+ALTER TABLE atamlink.businesses
+    ADD COLUMN b_ip_allowlist TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN b_ip_allowlist_bypass_until TIMESTAMP;
@@ -0,0 +1 @@
+-- Nilai enum audit_action_type 'SECTION_REORDER' tidak bisa dihapus
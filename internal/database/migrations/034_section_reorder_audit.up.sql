@@ -0,0 +1,2 @@
+-- Aksi audit untuk pengurutan ulang seluruh section katalog sekaligus
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'SECTION_REORDER';
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS atamlink.catalog_card_slug_history;
+
+DROP INDEX IF EXISTS atamlink.uq_card_details_catalog_slug;
+
+-- Gagal jika sudah ada slug card yang sama di katalog berbeda
+ALTER TABLE atamlink.catalog_card_details
+    ADD CONSTRAINT catalog_card_details_ccd_slug_key UNIQUE (ccd_slug),
+    DROP COLUMN IF EXISTS ccd_c_id;
@@ -0,0 +1,27 @@
+-- Slug detail card unik per katalog, bukan global, supaya katalog hasil
+-- clone/restore bisa memakai slug card yang sama
+ALTER TABLE atamlink.catalog_card_details
+    ADD COLUMN ccd_c_id BIGINT REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE;
+
+UPDATE atamlink.catalog_card_details d
+SET ccd_c_id = s.cs_c_id
+FROM atamlink.catalog_cards c
+JOIN atamlink.catalog_sections s ON s.cs_id = c.cc_cs_id
+WHERE c.cc_id = d.ccd_cc_id;
+
+ALTER TABLE atamlink.catalog_card_details
+    ALTER COLUMN ccd_c_id SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS catalog_card_details_ccd_slug_key;
+
+CREATE UNIQUE INDEX uq_card_details_catalog_slug ON atamlink.catalog_card_details(ccd_c_id, ccd_slug);
+
+-- Slug lama card setelah rename, dipakai untuk redirect ke slug terbaru
+CREATE TABLE atamlink.catalog_card_slug_history (
+    ccsh_id BIGSERIAL PRIMARY KEY,
+    ccsh_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    ccsh_cc_id BIGINT NOT NULL REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    ccsh_slug VARCHAR(100) NOT NULL,
+    ccsh_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX uq_card_slug_history_catalog_slug ON atamlink.catalog_card_slug_history(ccsh_c_id, ccsh_slug);
@@ -0,0 +1 @@
+-- Nilai enum audit_action_type 'CACHE_PURGE' tidak bisa dihapus
@@ -0,0 +1,2 @@
+-- Aksi audit untuk purge cache CDN katalog secara manual oleh pemilik
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'CACHE_PURGE';
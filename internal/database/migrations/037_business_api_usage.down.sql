@@ -0,0 +1 @@
+DROP TABLE IF EXISTS atamlink.business_api_usage_daily;
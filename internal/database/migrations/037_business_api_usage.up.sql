@@ -0,0 +1,21 @@
+-- Pemakaian API per service account per hari, untuk laporan pemakaian integrator.
+-- Latency disimpan sebagai histogram (batas bucket ms ada di entity APIUsageDaily)
+-- supaya persentil bisa dihitung ulang untuk rentang berapa pun
+CREATE TABLE atamlink.business_api_usage_daily (
+    bau_b_id BIGINT NOT NULL REFERENCES atamlink.businesses(b_id) ON DELETE CASCADE,
+    bau_bsa_id BIGINT NOT NULL REFERENCES atamlink.business_service_accounts(bsa_id) ON DELETE CASCADE,
+    bau_date DATE NOT NULL,
+    bau_method VARCHAR(10) NOT NULL,
+    bau_endpoint VARCHAR(200) NOT NULL, -- route template, mis. /api/v1/catalogs/:id
+    bau_requests BIGINT NOT NULL DEFAULT 0,
+    bau_client_errors BIGINT NOT NULL DEFAULT 0, -- status 4xx
+    bau_server_errors BIGINT NOT NULL DEFAULT 0, -- status 5xx
+    bau_bytes_in BIGINT NOT NULL DEFAULT 0,
+    bau_bytes_out BIGINT NOT NULL DEFAULT 0,
+    bau_latency_total_ms BIGINT NOT NULL DEFAULT 0,
+    bau_latency_buckets BIGINT[] NOT NULL,
+    bau_updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bau_b_id, bau_bsa_id, bau_date, bau_method, bau_endpoint)
+);
+
+CREATE INDEX idx_api_usage_date ON atamlink.business_api_usage_daily(bau_date);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS atamlink.status_incidents;
+DROP TABLE IF EXISTS atamlink.status_checks;
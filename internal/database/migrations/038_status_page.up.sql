@@ -0,0 +1,28 @@
+-- Riwayat health check komponen untuk status page publik (uptime dihitung dari sini)
+CREATE TABLE atamlink.status_checks (
+    sc_id BIGSERIAL PRIMARY KEY,
+    sc_component VARCHAR(50) NOT NULL, -- api, uploads, public_pages
+    sc_is_up BOOLEAN NOT NULL,
+    sc_latency_ms INT NOT NULL DEFAULT 0,
+    sc_error TEXT,
+    sc_checked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_status_checks_component ON atamlink.status_checks(sc_component, sc_checked_at DESC);
+CREATE INDEX idx_status_checks_checked_at ON atamlink.status_checks(sc_checked_at);
+
+-- Insiden yang dikelola admin
+CREATE TABLE atamlink.status_incidents (
+    si_id BIGSERIAL PRIMARY KEY,
+    si_title VARCHAR(200) NOT NULL,
+    si_message TEXT,
+    si_status VARCHAR(20) NOT NULL DEFAULT 'investigating', -- investigating, identified, monitoring, resolved
+    si_impact VARCHAR(20) NOT NULL DEFAULT 'minor', -- minor, major, critical
+    si_components TEXT[] NOT NULL DEFAULT '{}',
+    si_started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    si_resolved_at TIMESTAMP,
+    si_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    si_updated_at TIMESTAMP
+);
+
+CREATE INDEX idx_status_incidents_started_at ON atamlink.status_incidents(si_started_at DESC);
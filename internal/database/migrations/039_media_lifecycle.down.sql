@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS atamlink.idx_card_media_standard;
+DROP INDEX IF EXISTS atamlink.idx_card_media_url;
+
+ALTER TABLE atamlink.catalog_card_media
+    DROP COLUMN IF EXISTS ccm_archived_at,
+    DROP COLUMN IF EXISTS ccm_archive_attempts,
+    DROP COLUMN IF EXISTS ccm_storage_class,
+    DROP COLUMN IF EXISTS ccm_last_accessed_at;
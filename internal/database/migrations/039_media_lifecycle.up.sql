@@ -0,0 +1,12 @@
+-- Waktu akses terakhir media (dari log CDN) dan storage class untuk job lifecycle,
+-- original yang jarang diakses dipindah ke storage infrequent-access
+ALTER TABLE atamlink.catalog_card_media
+    ADD COLUMN ccm_last_accessed_at TIMESTAMP,
+    ADD COLUMN ccm_storage_class VARCHAR(20) NOT NULL DEFAULT 'standard'
+        CHECK (ccm_storage_class IN ('standard', 'infrequent')),
+    ADD COLUMN ccm_archive_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN ccm_archived_at TIMESTAMP;
+
+CREATE INDEX idx_card_media_url ON atamlink.catalog_card_media(ccm_url);
+CREATE INDEX idx_card_media_standard ON atamlink.catalog_card_media(ccm_last_accessed_at)
+    WHERE ccm_storage_class = 'standard';
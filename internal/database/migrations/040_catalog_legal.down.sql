@@ -0,0 +1,6 @@
+ALTER TABLE atamlink.catalog_checkout_links
+    DROP COLUMN IF EXISTS ccl_legal_version;
+
+DROP TABLE IF EXISTS atamlink.catalog_legal_versions;
+
+-- Nilai enum 'legal' tidak bisa dihapus tanpa membuat ulang type
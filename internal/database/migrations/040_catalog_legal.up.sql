@@ -0,0 +1,21 @@
+-- Section legal (syarat & ketentuan, kebijakan privasi)
+ALTER TYPE section_type ADD VALUE IF NOT EXISTS 'legal';
+
+-- Setiap perubahan teks legal menjadi versi baru, versi lama disimpan sebagai
+-- bukti isi yang disetujui pembeli
+CREATE TABLE atamlink.catalog_legal_versions (
+    clv_id BIGSERIAL PRIMARY KEY,
+    clv_cs_id BIGINT NOT NULL REFERENCES atamlink.catalog_sections(cs_id) ON DELETE CASCADE,
+    clv_version INT NOT NULL,
+    clv_terms TEXT NOT NULL DEFAULT '',
+    clv_terms_html TEXT,
+    clv_privacy TEXT NOT NULL DEFAULT '',
+    clv_privacy_html TEXT,
+    clv_created_by BIGINT NOT NULL,
+    clv_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (clv_cs_id, clv_version)
+);
+
+-- Versi legal yang disetujui saat checkout dibuat
+ALTER TABLE atamlink.catalog_checkout_links
+    ADD COLUMN ccl_legal_version INT;
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS atamlink.idx_catalog_cards_unpublish_at;
+DROP INDEX IF EXISTS atamlink.idx_catalog_cards_publish_at;
+DROP INDEX IF EXISTS atamlink.idx_catalogs_unpublish_at;
+DROP INDEX IF EXISTS atamlink.idx_catalogs_publish_at;
+
+ALTER TABLE atamlink.catalog_cards
+    DROP COLUMN IF EXISTS cc_visibility_scheduled_by,
+    DROP COLUMN IF EXISTS cc_unpublish_at,
+    DROP COLUMN IF EXISTS cc_publish_at;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_visibility_scheduled_by,
+    DROP COLUMN IF EXISTS c_unpublish_at,
+    DROP COLUMN IF EXISTS c_publish_at;
+
+-- Nilai enum audit_action_type tidak bisa dihapus, 'SCHEDULED_VISIBILITY' dibiarkan
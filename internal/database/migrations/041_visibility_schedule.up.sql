@@ -0,0 +1,25 @@
+-- Aksi audit untuk tampil/sembunyi otomatis oleh scheduler
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'SCHEDULED_VISIBILITY';
+
+-- Jadwal tampil (publish_at) dan sembunyi (unpublish_at) katalog lewat c_is_active.
+-- Beda dengan c_publish_scheduled_at yang mengubah status draft menjadi published
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_publish_at TIMESTAMP,
+    ADD COLUMN c_unpublish_at TIMESTAMP,
+    ADD COLUMN c_visibility_scheduled_by BIGINT;
+
+CREATE INDEX idx_catalogs_publish_at ON atamlink.catalogs(c_publish_at)
+    WHERE c_publish_at IS NOT NULL;
+CREATE INDEX idx_catalogs_unpublish_at ON atamlink.catalogs(c_unpublish_at)
+    WHERE c_unpublish_at IS NOT NULL;
+
+-- Jadwal tampil dan sembunyi card lewat cc_is_visible
+ALTER TABLE atamlink.catalog_cards
+    ADD COLUMN cc_publish_at TIMESTAMP,
+    ADD COLUMN cc_unpublish_at TIMESTAMP,
+    ADD COLUMN cc_visibility_scheduled_by BIGINT;
+
+CREATE INDEX idx_catalog_cards_publish_at ON atamlink.catalog_cards(cc_publish_at)
+    WHERE cc_publish_at IS NOT NULL;
+CREATE INDEX idx_catalog_cards_unpublish_at ON atamlink.catalog_cards(cc_unpublish_at)
+    WHERE cc_unpublish_at IS NOT NULL;
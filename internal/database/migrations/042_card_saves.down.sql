@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS atamlink.catalog_card_saves;
+
+-- Hitungan harian card_save/card_compare di catalog_daily_conversions tidak dihapus
@@ -0,0 +1,12 @@
+-- Card yang disimpan (wishlist) pengunjung anonim, pengunjung diidentifikasi
+-- dari hash cookie visitor. Jumlah save/compare harian masuk catalog_daily_conversions
+-- dengan event card_save dan card_compare
+CREATE TABLE atamlink.catalog_card_saves (
+    ccsv_cc_id BIGINT NOT NULL REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    ccsv_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    ccsv_visitor_hash CHAR(64) NOT NULL,
+    ccsv_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (ccsv_cc_id, ccsv_visitor_hash)
+);
+
+CREATE INDEX idx_catalog_card_saves_visitor ON atamlink.catalog_card_saves(ccsv_c_id, ccsv_visitor_hash);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS atamlink.catalog_daily_link_clicks;
+DROP TABLE IF EXISTS atamlink.catalog_daily_referrers;
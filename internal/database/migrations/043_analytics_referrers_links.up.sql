@@ -0,0 +1,40 @@
+-- View manusia harian per host referrer ('' = direct / tanpa referrer)
+CREATE TABLE atamlink.catalog_daily_referrers (
+    cdr_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cdr_date DATE NOT NULL,
+    cdr_host VARCHAR(255) NOT NULL DEFAULT '',
+    cdr_views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cdr_c_id, cdr_date, cdr_host)
+) PARTITION BY RANGE (cdr_date);
+
+-- Klik link harian: link section links (cdlc_cc_id = 0) atau link detail card
+CREATE TABLE atamlink.catalog_daily_link_clicks (
+    cdlc_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    cdlc_date DATE NOT NULL,
+    cdlc_cc_id BIGINT NOT NULL DEFAULT 0,
+    cdlc_link_id BIGINT NOT NULL,
+    cdlc_clicks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (cdlc_c_id, cdlc_date, cdlc_cc_id, cdlc_link_id)
+) PARTITION BY RANGE (cdlc_date);
+
+-- Partisi bulan berjalan sampai 2 bulan ke depan, selanjutnya dibuat partition service
+DO $$
+DECLARE
+    t RECORD;
+    m DATE;
+BEGIN
+    FOR t IN SELECT * FROM (VALUES
+        ('catalog_daily_referrers'),
+        ('catalog_daily_link_clicks')
+    ) AS v(tbl) LOOP
+        m := date_trunc('month', now())::date;
+
+        WHILE m <= (date_trunc('month', now()) + interval '2 months')::date LOOP
+            EXECUTE format('CREATE TABLE atamlink.%I PARTITION OF atamlink.%I FOR VALUES FROM (%L) TO (%L)',
+                t.tbl || '_p' || to_char(m, 'YYYYMM'), t.tbl, m, (m + interval '1 month')::date);
+            m := (m + interval '1 month')::date;
+        END LOOP;
+
+        EXECUTE format('CREATE TABLE atamlink.%I PARTITION OF atamlink.%I DEFAULT', t.tbl || '_default', t.tbl);
+    END LOOP;
+END $$;
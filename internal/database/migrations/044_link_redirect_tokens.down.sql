@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS atamlink.idx_catalog_links_token;
+ALTER TABLE atamlink.catalog_links DROP COLUMN IF EXISTS cl_token;
+
+DROP INDEX IF EXISTS atamlink.idx_catalog_card_links_token;
+ALTER TABLE atamlink.catalog_card_links DROP COLUMN IF EXISTS ccl_token;
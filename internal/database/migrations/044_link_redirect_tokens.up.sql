@@ -0,0 +1,10 @@
+-- Token publik link untuk redirect /r/{token}, baris lama otomatis mendapat token
+ALTER TABLE atamlink.catalog_card_links
+    ADD COLUMN ccl_token VARCHAR(16) NOT NULL DEFAULT substr(md5(random()::text || clock_timestamp()::text), 1, 16);
+
+CREATE UNIQUE INDEX idx_catalog_card_links_token ON atamlink.catalog_card_links(ccl_token);
+
+ALTER TABLE atamlink.catalog_links
+    ADD COLUMN cl_token VARCHAR(16) NOT NULL DEFAULT substr(md5(random()::text || clock_timestamp()::text), 1, 16);
+
+CREATE UNIQUE INDEX idx_catalog_links_token ON atamlink.catalog_links(cl_token);
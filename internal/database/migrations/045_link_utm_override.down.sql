@@ -0,0 +1 @@
+ALTER TABLE atamlink.catalog_card_links DROP COLUMN IF EXISTS ccl_utm;
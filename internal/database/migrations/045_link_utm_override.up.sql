@@ -0,0 +1,3 @@
+-- Override UTM per link detail card, NULL = ikut settings utm_* katalog
+ALTER TABLE atamlink.catalog_card_links
+    ADD COLUMN ccl_utm JSONB;
@@ -0,0 +1,4 @@
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_og_image,
+    DROP COLUMN IF EXISTS c_meta_description,
+    DROP COLUMN IF EXISTS c_meta_title;
@@ -0,0 +1,5 @@
+-- Metadata SEO katalog untuk meta tag, OpenGraph dan Twitter card
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_meta_title VARCHAR(200),
+    ADD COLUMN c_meta_description VARCHAR(500),
+    ADD COLUMN c_og_image VARCHAR(500);
@@ -0,0 +1,20 @@
+-- Pesan inquiry / form kontak dari pengunjung katalog publik, opsional untuk card tertentu
+CREATE TABLE atamlink.catalog_inquiries (
+    ci_id BIGSERIAL PRIMARY KEY,
+    ci_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    ci_cc_id BIGINT REFERENCES atamlink.catalog_cards(cc_id) ON DELETE SET NULL,
+    ci_name VARCHAR(100) NOT NULL,
+    ci_email VARCHAR(255),
+    ci_phone VARCHAR(30),
+    ci_message TEXT NOT NULL,
+    ci_status VARCHAR(20) NOT NULL DEFAULT 'new', -- new, read, archived
+    ci_visitor_hash VARCHAR(64) NOT NULL, -- HMAC IP + user agent, IP tidak disimpan
+    ci_read_by BIGINT,
+    ci_read_at TIMESTAMP,
+    ci_archived_by BIGINT,
+    ci_archived_at TIMESTAMP,
+    ci_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_catalog_inquiries_catalog ON atamlink.catalog_inquiries(ci_c_id, ci_status, ci_created_at DESC);
+CREATE INDEX idx_catalog_inquiries_visitor ON atamlink.catalog_inquiries(ci_visitor_hash, ci_created_at);
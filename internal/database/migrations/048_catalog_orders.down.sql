@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS atamlink.catalog_order_items;
+DROP TABLE IF EXISTS atamlink.catalog_orders;
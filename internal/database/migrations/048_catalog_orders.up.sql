@@ -0,0 +1,32 @@
+-- Order capture (lead) dari pengunjung katalog publik, tanpa pembayaran.
+-- Harga dan judul card disalin saat order dibuat
+CREATE TABLE atamlink.catalog_orders (
+    co_id BIGSERIAL PRIMARY KEY,
+    co_ref VARCHAR(20) NOT NULL UNIQUE, -- nomor referensi yang ditampilkan ke pengunjung
+    co_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    co_name VARCHAR(100) NOT NULL,
+    co_email VARCHAR(255),
+    co_phone VARCHAR(30),
+    co_note TEXT,
+    co_status VARCHAR(20) NOT NULL DEFAULT 'new', -- new, contacted, completed, cancelled
+    co_total BIGINT NOT NULL DEFAULT 0,
+    co_currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    co_visitor_hash VARCHAR(64) NOT NULL, -- HMAC IP + user agent, IP tidak disimpan
+    co_status_updated_by BIGINT,
+    co_status_updated_at TIMESTAMP,
+    co_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_catalog_orders_catalog ON atamlink.catalog_orders(co_c_id, co_status, co_created_at DESC);
+CREATE INDEX idx_catalog_orders_visitor ON atamlink.catalog_orders(co_visitor_hash, co_created_at);
+
+CREATE TABLE atamlink.catalog_order_items (
+    coi_id BIGSERIAL PRIMARY KEY,
+    coi_co_id BIGINT NOT NULL REFERENCES atamlink.catalog_orders(co_id) ON DELETE CASCADE,
+    coi_cc_id BIGINT REFERENCES atamlink.catalog_cards(cc_id) ON DELETE SET NULL,
+    coi_title VARCHAR(200) NOT NULL,
+    coi_quantity INT NOT NULL CHECK (coi_quantity > 0),
+    coi_unit_price BIGINT -- NULL jika card tidak punya harga
+);
+
+CREATE INDEX idx_catalog_order_items_order ON atamlink.catalog_order_items(coi_co_id);
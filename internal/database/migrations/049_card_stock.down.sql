@@ -0,0 +1,4 @@
+ALTER TABLE atamlink.catalog_cards
+    DROP COLUMN IF EXISTS cc_hide_when_sold_out,
+    DROP COLUMN IF EXISTS cc_sold_out,
+    DROP COLUMN IF EXISTS cc_stock;
@@ -0,0 +1,6 @@
+-- Stok card (opsional). cc_stock NULL = stok tidak dilacak, cc_sold_out
+-- menandai habis secara manual terlepas dari jumlah stok
+ALTER TABLE atamlink.catalog_cards
+    ADD COLUMN cc_stock INT CHECK (cc_stock >= 0),
+    ADD COLUMN cc_sold_out BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN cc_hide_when_sold_out BOOLEAN NOT NULL DEFAULT false; -- false = tetap tampil dengan badge habis
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS atamlink.catalog_card_tags;
+DROP TABLE IF EXISTS atamlink.catalog_tags;
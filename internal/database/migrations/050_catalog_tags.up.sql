@@ -0,0 +1,20 @@
+-- Tag / kategori produk per katalog untuk mengelompokkan dan memfilter card
+CREATE TABLE atamlink.catalog_tags (
+    ct_id BIGSERIAL PRIMARY KEY,
+    ct_c_id BIGINT NOT NULL REFERENCES atamlink.catalogs(c_id) ON DELETE CASCADE,
+    ct_name VARCHAR(50) NOT NULL,
+    ct_slug VARCHAR(60) NOT NULL, -- dipakai di filter publik ?tag=
+    ct_created_by BIGINT NOT NULL,
+    ct_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ct_updated_by BIGINT,
+    ct_updated_at TIMESTAMP,
+    UNIQUE (ct_c_id, ct_slug)
+);
+
+CREATE TABLE atamlink.catalog_card_tags (
+    cct_cc_id BIGINT NOT NULL REFERENCES atamlink.catalog_cards(cc_id) ON DELETE CASCADE,
+    cct_ct_id BIGINT NOT NULL REFERENCES atamlink.catalog_tags(ct_id) ON DELETE CASCADE,
+    PRIMARY KEY (cct_cc_id, cct_ct_id)
+);
+
+CREATE INDEX idx_catalog_card_tags_tag ON atamlink.catalog_card_tags(cct_ct_id);
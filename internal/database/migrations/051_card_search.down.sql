@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS atamlink.idx_catalog_card_details_search;
+DROP INDEX IF EXISTS atamlink.idx_catalog_cards_search;
+
+ALTER TABLE atamlink.catalog_card_details
+    DROP COLUMN IF EXISTS ccd_search;
+
+ALTER TABLE atamlink.catalog_cards
+    DROP COLUMN IF EXISTS cc_search;
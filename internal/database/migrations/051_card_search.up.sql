@@ -0,0 +1,16 @@
+-- Postgres full-text search card di dalam katalog publik. Judul berbobot paling
+-- tinggi, lalu subtitle, lalu deskripsi detail
+ALTER TABLE atamlink.catalog_cards
+    ADD COLUMN cc_search tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(cc_title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(cc_subtitle, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_catalog_cards_search ON atamlink.catalog_cards USING GIN (cc_search);
+
+ALTER TABLE atamlink.catalog_card_details
+    ADD COLUMN ccd_search tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(ccd_description, '')), 'C')
+    ) STORED;
+
+CREATE INDEX idx_catalog_card_details_search ON atamlink.catalog_card_details USING GIN (ccd_search);
@@ -0,0 +1,5 @@
+ALTER TABLE atamlink.catalog_cards
+    DROP COLUMN IF EXISTS cc_version;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_version;
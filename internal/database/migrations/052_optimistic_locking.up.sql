@@ -0,0 +1,6 @@
+-- Versi baris untuk optimistic locking edit katalog dan card, naik setiap update
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE atamlink.catalog_cards
+    ADD COLUMN cc_version INTEGER NOT NULL DEFAULT 1;
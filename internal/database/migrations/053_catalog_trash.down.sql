@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS atamlink.idx_catalogs_deleted_at;
+
+ALTER TABLE atamlink.catalogs
+    DROP COLUMN IF EXISTS c_deleted_by,
+    DROP COLUMN IF EXISTS c_deleted_at;
+
+-- Nilai enum audit_action_type 'CATALOG_RESTORED' dan 'CATALOG_PURGED' tidak bisa dihapus
@@ -0,0 +1,12 @@
+-- Aksi audit untuk pulihkan katalog dari trash dan hapus permanen
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'CATALOG_RESTORED';
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'CATALOG_PURGED';
+
+-- Katalog yang dihapus masuk trash, bisa dipulihkan atau dihapus permanen
+-- (child row ikut terhapus lewat ON DELETE CASCADE)
+ALTER TABLE atamlink.catalogs
+    ADD COLUMN c_deleted_at TIMESTAMP,
+    ADD COLUMN c_deleted_by BIGINT;
+
+CREATE INDEX idx_catalogs_deleted_at ON atamlink.catalogs(c_b_id, c_deleted_at)
+    WHERE c_deleted_at IS NOT NULL;
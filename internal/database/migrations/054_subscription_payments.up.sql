@@ -0,0 +1,21 @@
+-- Pembayaran subscription lewat payment gateway, subscription aktif setelah callback lunas
+CREATE TABLE atamlink.subscription_payments (
+    sp_id BIGSERIAL PRIMARY KEY,
+    sp_b_id BIGINT NOT NULL REFERENCES atamlink.businesses(b_id) ON DELETE CASCADE,
+    sp_mp_id BIGINT NOT NULL REFERENCES atamlink.master_plans(mp_id),
+    sp_bs_id BIGINT REFERENCES atamlink.business_subscriptions(bs_id) ON DELETE SET NULL,
+    sp_external_id VARCHAR(100) NOT NULL UNIQUE,
+    sp_gateway VARCHAR(20) NOT NULL,
+    sp_gateway_id VARCHAR(100),
+    sp_amount BIGINT NOT NULL,
+    sp_currency VARCHAR(3) NOT NULL DEFAULT 'IDR',
+    sp_payment_url TEXT NOT NULL,
+    sp_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    sp_expires_at TIMESTAMP,
+    sp_paid_at TIMESTAMP,
+    sp_created_by BIGINT NOT NULL REFERENCES atamlink.user_profiles(up_id),
+    sp_created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sp_updated_at TIMESTAMP
+);
+
+CREATE INDEX idx_subscription_payments_business ON atamlink.subscription_payments(sp_b_id, sp_created_at DESC);
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS atamlink.idx_business_invites_pending_expiry;
+DROP INDEX IF EXISTS atamlink.idx_business_invites_b_id;
+
+ALTER TABLE atamlink.business_invites
+    DROP COLUMN IF EXISTS bi_resent_at,
+    DROP COLUMN IF EXISTS bi_revoked_at,
+    DROP COLUMN IF EXISTS bi_revoked_by,
+    DROP COLUMN IF EXISTS bi_status;
+
+-- Nilai enum audit_action_type 'INVITE_CANCELLED' tidak bisa dihapus
@@ -0,0 +1,21 @@
+-- Aksi audit untuk pembatalan invite
+ALTER TYPE audit_action_type ADD VALUE IF NOT EXISTS 'INVITE_CANCELLED';
+
+-- Status invite: pending, used, revoked, expired. bi_is_used dipertahankan
+-- untuk kompatibilitas dan tetap diisi saat invite diterima
+ALTER TABLE atamlink.business_invites
+    ADD COLUMN bi_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (bi_status IN ('pending', 'used', 'revoked', 'expired')),
+    ADD COLUMN bi_revoked_by BIGINT,
+    ADD COLUMN bi_revoked_at TIMESTAMP,
+    ADD COLUMN bi_resent_at TIMESTAMP;
+
+UPDATE atamlink.business_invites SET bi_status = 'used' WHERE bi_is_used = true;
+UPDATE atamlink.business_invites SET bi_status = 'expired'
+    WHERE bi_is_used = false AND bi_expires_at <= CURRENT_TIMESTAMP;
+
+CREATE INDEX idx_business_invites_b_id ON atamlink.business_invites(bi_b_id, bi_created_at DESC);
+
+-- Sweep invite kadaluarsa hanya memindai invite yang masih pending
+CREATE INDEX idx_business_invites_pending_expiry ON atamlink.business_invites(bi_expires_at)
+    WHERE bi_status = 'pending';
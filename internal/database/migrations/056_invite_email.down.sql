@@ -0,0 +1,2 @@
+ALTER TABLE atamlink.business_invites
+    DROP COLUMN IF EXISTS bi_email;
@@ -0,0 +1,3 @@
+-- Email penerima invite, link invite dikirim (ulang) ke alamat ini
+ALTER TABLE atamlink.business_invites
+    ADD COLUMN bi_email VARCHAR(255);
@@ -0,0 +1,3 @@
+ALTER TABLE atamlink.user_totp_secrets
+    DROP COLUMN IF EXISTS uts_locked_until,
+    DROP COLUMN IF EXISTS uts_failed_attempts;
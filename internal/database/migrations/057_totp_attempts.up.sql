@@ -0,0 +1,5 @@
+-- Batas percobaan kode authenticator per profile, kode 6 digit tidak boleh
+-- bisa ditebak dengan brute force
+ALTER TABLE atamlink.user_totp_secrets
+    ADD COLUMN uts_failed_attempts INT NOT NULL DEFAULT 0,
+    ADD COLUMN uts_locked_until TIMESTAMP;
@@ -0,0 +1,7 @@
+-- Kredensial yang sudah terenkripsi tidak bisa dikembalikan ke JSON,
+-- integration tersebut perlu dihubungkan ulang
+ALTER TABLE atamlink.business_integrations
+    ALTER COLUMN bint_credentials TYPE JSONB USING (
+        CASE WHEN bint_credentials LIKE '{%' THEN bint_credentials::jsonb ELSE '{}'::jsonb END
+    ),
+    ALTER COLUMN bint_credentials SET DEFAULT '{}';
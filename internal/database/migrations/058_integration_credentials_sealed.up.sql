@@ -0,0 +1,5 @@
+-- Kredensial marketplace disimpan terenkripsi oleh vault service. Baris lama
+-- masih berisi JSON polos dan di-seal ulang saat sync berikutnya
+ALTER TABLE atamlink.business_integrations
+    ALTER COLUMN bint_credentials DROP DEFAULT,
+    ALTER COLUMN bint_credentials TYPE TEXT USING bint_credentials::text;
@@ -0,0 +1,9 @@
+// Package migrations berisi file migrasi SQL yang ikut ter-embed di binary catalogd
+package migrations
+
+import "embed"
+
+// FS file NNN_nama.up.sql / NNN_nama.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,712 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_analytics/dto"
+	"github.com/atam/atamlink/internal/mod_analytics/usecase"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// visitorIDPattern format id cookie pengunjung (16 byte hex)
+var visitorIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// AnalyticsHandler handler untuk analytics katalog
+type AnalyticsHandler struct {
+	analyticsUC      usecase.AnalyticsUseCase
+	validator        *utils.Validator
+	config           config.AnalyticsConfig
+	hideInaccessible bool
+}
+
+// NewAnalyticsHandler membuat instance analytics handler baru
+func NewAnalyticsHandler(analyticsUC usecase.AnalyticsUseCase, validator *utils.Validator, cfg config.AnalyticsConfig, hideInaccessible bool) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUC:      analyticsUC,
+		validator:        validator,
+		config:           cfg,
+		hideInaccessible: hideInaccessible,
+	}
+}
+
+// RecordEvent handler untuk beacon event dari katalog publik
+// @Summary Record public catalog event
+// @Description Catat event analytics dari script tema (tanpa otentikasi). view: event dari bot tetap masuk hitungan mentah tapi tidak dihitung sebagai view. view: referrer opsional (document.referrer), hanya host yang disimpan. click: posisi klik relatif terhadap section (x, y 0..1), klik dari bot diabaikan. link_click: link_id dari section links, atau link detail card jika card_id diisi. whatsapp_click, checkout_click, order_submitted: event konversi untuk goal, card_id opsional
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param body body dto.RecordEventRequest true "Event data"
+// @Success 204
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /c/{slug}/events [post]
+func (h *AnalyticsHandler) RecordEvent(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	// navigator.sendBeacon mengirim text/plain, body tetap di-decode sebagai JSON
+	var req dto.RecordEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	if err := h.analyticsUC.RecordEvent(slug, visitorInfo(c), &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// Redirect handler untuk redirect link publik dengan pencatatan klik
+// @Summary Redirect public link
+// @Description Redirect 302 ke URL asli link detail card atau section links berdasarkan token, klik dicatat ke analytics (crawler diabaikan)
+// @Tags analytics
+// @Param token path string true "Link token"
+// @Success 302
+// @Failure 404 {object} utils.Response
+// @Router /r/{token} [get]
+func (h *AnalyticsHandler) Redirect(c *gin.Context) {
+	token := c.Param("token")
+	if token == "" {
+		utils.NotFound(c, constant.ErrMsgRedirectLinkNotFound)
+		return
+	}
+
+	target, err := h.analyticsUC.ResolveRedirect(token, visitorInfo(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Jangan di-cache agar setiap klik sampai ke server
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer-when-downgrade")
+	c.Redirect(http.StatusFound, target)
+}
+
+// SaveCard handler untuk menyimpan card ke wishlist pengunjung
+// @Summary Save card to visitor wishlist
+// @Description Simpan card ke wishlist pengunjung anonim (tanpa otentikasi). Pengunjung dikenali dari cookie visitor, cookie diterbitkan jika belum ada. Save dari bot diabaikan
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param card_id path int true "Card ID"
+// @Param body body dto.CardSaveRequest true "Token analytics"
+// @Success 200 {object} utils.Response{data=dto.VisitorSavesResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /c/{slug}/cards/{card_id}/save [post]
+func (h *AnalyticsHandler) SaveCard(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	var req dto.CardSaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	visitorID, err := h.ensureVisitorID(c)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	saves, err := h.analyticsUC.SaveCard(slug, cardID, visitorID, visitorInfo(c), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Card berhasil disimpan", saves)
+}
+
+// UnsaveCard handler untuk menghapus card dari wishlist pengunjung
+// @Summary Remove card from visitor wishlist
+// @Description Hapus card dari wishlist pengunjung anonim berdasarkan cookie visitor
+// @Tags analytics
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param card_id path int true "Card ID"
+// @Success 200 {object} utils.Response{data=dto.VisitorSavesResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /c/{slug}/cards/{card_id}/save [delete]
+func (h *AnalyticsHandler) UnsaveCard(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	visitorID, ok := h.visitorID(c)
+	if !ok {
+		utils.OK(c, "Card berhasil dihapus dari wishlist", &dto.VisitorSavesResponse{CardIDs: []int64{}})
+		return
+	}
+
+	saves, err := h.analyticsUC.UnsaveCard(slug, cardID, visitorID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Card berhasil dihapus dari wishlist", saves)
+}
+
+// ListVisitorSaves handler untuk wishlist pengunjung
+// @Summary Get visitor wishlist
+// @Description Daftar id card yang disimpan pengunjung anonim di katalog, kosong jika belum punya cookie visitor
+// @Tags analytics
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Success 200 {object} utils.Response{data=dto.VisitorSavesResponse}
+// @Failure 404 {object} utils.Response
+// @Router /c/{slug}/saves [get]
+func (h *AnalyticsHandler) ListVisitorSaves(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	visitorID, ok := h.visitorID(c)
+	if !ok {
+		utils.OK(c, "Wishlist berhasil diambil", &dto.VisitorSavesResponse{CardIDs: []int64{}})
+		return
+	}
+
+	saves, err := h.analyticsUC.ListVisitorSaves(slug, visitorID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Wishlist berhasil diambil", saves)
+}
+
+// RecordCompare handler untuk beacon compare card
+// @Summary Record card comparison
+// @Description Catat 2-4 card yang dibandingkan pengunjung anonim (tanpa otentikasi). Tiap card dihitung sekali per pengunjung per hari, compare dari bot diabaikan
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param body body dto.CompareCardsRequest true "Card yang dibandingkan"
+// @Success 204
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /c/{slug}/compare [post]
+func (h *AnalyticsHandler) RecordCompare(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	// navigator.sendBeacon mengirim text/plain, body tetap di-decode sebagai JSON
+	var req dto.CompareCardsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	visitorID, err := h.ensureVisitorID(c)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if err := h.analyticsUC.RecordCompare(slug, visitorID, visitorInfo(c), &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// GetCatalogAnalytics handler untuk laporan view katalog
+// @Summary Get catalog analytics
+// @Description View harian katalog: views (setelah filter bot), raw_views (semua event) dan unique_visitors (hash anonim harian, tanpa menyimpan IP). Termasuk top card (klik), top link dan host referrer teratas
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param from query string false "Tanggal awal (YYYY-MM-DD), default 29 hari lalu"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD), default hari ini"
+// @Success 200 {object} utils.Response{data=dto.CatalogAnalyticsResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/analytics [get]
+func (h *AnalyticsHandler) GetCatalogAnalytics(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.analyticsUC.GetCatalogAnalytics(c, catalogID, profileID, from, to)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data analytics berhasil diambil", analytics)
+}
+
+// GetClickHeatmap handler untuk distribusi klik per section
+// @Summary Get catalog click heatmap
+// @Description Agregat posisi klik per section (grid[y][x] relatif terhadap kotak section) dan klik per card
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param from query string false "Tanggal awal (YYYY-MM-DD), default 29 hari lalu"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD), default hari ini"
+// @Success 200 {object} utils.Response{data=dto.ClickHeatmapResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/analytics/clicks [get]
+func (h *AnalyticsHandler) GetClickHeatmap(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	heatmap, err := h.analyticsUC.GetClickHeatmap(c, catalogID, profileID, from, to)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data klik berhasil diambil", heatmap)
+}
+
+// CreateGoal handler untuk membuat goal konversi
+// @Summary Create conversion goal
+// @Description Definisikan goal konversi katalog dari event whatsapp_click, checkout_click atau order_submitted, opsional dibatasi ke satu card
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param body body dto.CreateGoalRequest true "Goal data"
+// @Success 201 {object} utils.Response{data=dto.GoalResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/{id}/goals [post]
+func (h *AnalyticsHandler) CreateGoal(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	var req dto.CreateGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	goal, err := h.analyticsUC.CreateGoal(c, catalogID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Goal berhasil dibuat", goal)
+}
+
+// ListGoals handler untuk daftar goal konversi
+// @Summary List conversion goals
+// @Description Daftar goal konversi katalog
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=[]dto.GoalResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/goals [get]
+func (h *AnalyticsHandler) ListGoals(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	goals, err := h.analyticsUC.ListGoals(c, catalogID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data goal berhasil diambil", goals)
+}
+
+// DeleteGoal handler untuk menghapus goal konversi
+// @Summary Delete conversion goal
+// @Description Hapus goal konversi, data event konversi harian tetap disimpan
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param goal_id path int true "Goal ID"
+// @Success 204
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/goals/{goal_id} [delete]
+func (h *AnalyticsHandler) DeleteGoal(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	goalID, err := strconv.ParseInt(c.Param("goal_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID goal tidak valid")
+		return
+	}
+
+	if err := h.analyticsUC.DeleteGoal(c, goalID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// GetGoalConversions handler untuk laporan konversi per goal
+// @Summary Get goal conversions
+// @Description Konversi per goal dan rasio terhadap view (setelah filter bot), total dan per hari
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param from query string false "Tanggal awal (YYYY-MM-DD), default 29 hari lalu"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD), default hari ini"
+// @Success 200 {object} utils.Response{data=dto.GoalConversionsResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/analytics/goals [get]
+func (h *AnalyticsHandler) GetGoalConversions(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsUC.GetGoalConversions(c, catalogID, profileID, from, to)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data konversi berhasil diambil", report)
+}
+
+// GetCardSaves handler untuk laporan card paling banyak disimpan
+// @Summary Get most saved cards
+// @Description Card paling banyak disimpan pengunjung (saved = penyimpan saat ini) beserta jumlah save dan compare dalam rentang, maksimal 20 card
+// @Tags analytics
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param from query string false "Tanggal awal (YYYY-MM-DD), default 29 hari lalu"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD), default hari ini"
+// @Success 200 {object} utils.Response{data=dto.CardSavesResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/analytics/saves [get]
+func (h *AnalyticsHandler) GetCardSaves(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsUC.GetCardSaves(c, catalogID, profileID, from, to)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data wishlist berhasil diambil", report)
+}
+
+// visitorInfo data pengunjung untuk filter bot, IP tidak disimpan
+func visitorInfo(c *gin.Context) *service.VisitorInfo {
+	return &service.VisitorInfo{
+		UserAgent:      c.GetHeader("User-Agent"),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		IP:             c.ClientIP(),
+	}
+}
+
+// visitorID id pengunjung anonim dari cookie visitor
+func (h *AnalyticsHandler) visitorID(c *gin.Context) (string, bool) {
+	id, err := c.Cookie(h.config.VisitorCookie)
+	if err != nil || !visitorIDPattern.MatchString(id) {
+		return "", false
+	}
+	return id, true
+}
+
+// ensureVisitorID id pengunjung dari cookie, cookie baru diterbitkan jika belum ada
+func (h *AnalyticsHandler) ensureVisitorID(c *gin.Context) (string, error) {
+	if id, ok := h.visitorID(c); ok {
+		return id, nil
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Wrap(err, "failed to generate visitor id")
+	}
+	id := hex.EncodeToString(buf)
+
+	// Katalog publik bisa beda domain dengan API, lewat HTTPS cookie dikirim lintas situs
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	if secure {
+		c.SetSameSite(http.SameSiteNoneMode)
+	} else {
+		c.SetSameSite(http.SameSiteLaxMode)
+	}
+	c.SetCookie(h.config.VisitorCookie, id, int(h.config.VisitorCookieTTL/time.Second), "/", "", secure, true)
+
+	return id, nil
+}
+
+// parseAnalyticsRange parse periode (hari) dari query, default 30 hari terakhir
+func parseAnalyticsRange(c *gin.Context) (time.Time, time.Time, bool) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := to.AddDate(0, 0, -29)
+
+	var err error
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.ParseInLocation("2006-01-02", fromStr, time.Local)
+		if err != nil {
+			utils.BadRequest(c, "Format tanggal awal tidak valid (YYYY-MM-DD)")
+			return from, to, false
+		}
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.ParseInLocation("2006-01-02", toStr, time.Local)
+		if err != nil {
+			utils.BadRequest(c, "Format tanggal akhir tidak valid (YYYY-MM-DD)")
+			return from, to, false
+		}
+	}
+
+	return from, to, true
+}
+
+// GetAPIUsage handler untuk laporan pemakaian API service account business
+// @Summary Get business API usage
+// @Description Pemakaian API oleh service account business per hari dan per endpoint: jumlah request, error 4xx/5xx, bytes dan persentil latency (perkiraan dari histogram). Request dengan login user tidak dihitung
+// @Tags analytics
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param service_account_id query int false "Filter satu service account"
+// @Param from query string false "Tanggal awal (YYYY-MM-DD), default 29 hari lalu"
+// @Param to query string false "Tanggal akhir (YYYY-MM-DD), default hari ini"
+// @Success 200 {object} utils.Response{data=dto.APIUsageResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /businesses/{id}/api-usage [get]
+func (h *AnalyticsHandler) GetAPIUsage(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var serviceAccountID int64
+	if value := c.Query("service_account_id"); value != "" {
+		serviceAccountID, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || serviceAccountID <= 0 {
+			utils.BadRequest(c, "ID service account tidak valid")
+			return
+		}
+	}
+
+	from, to, ok := parseAnalyticsRange(c)
+	if !ok {
+		return
+	}
+
+	usage, err := h.analyticsUC.GetAPIUsage(c, businessID, profileID, serviceAccountID, from, to)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data pemakaian API berhasil diambil", usage)
+}
+
+// handleError menangani error dari use case
+func (h *AnalyticsHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, analyticsNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// analyticsNotFoundMessage pesan 404 sesuai resource yang diminta route
+func analyticsNotFoundMessage(c *gin.Context) string {
+	switch {
+	case c.Param("goal_id") != "":
+		return constant.ErrMsgGoalNotFound
+	case strings.Contains(c.FullPath(), "/businesses/"):
+		return constant.ErrMsgBusinessNotFound
+	default:
+		return constant.ErrMsgCatalogNotFound
+	}
+}
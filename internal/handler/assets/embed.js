@@ -0,0 +1,172 @@
+/*! atamlink embed widget v__VERSION__ */
+(function () {
+  'use strict';
+
+  var script = document.currentScript;
+  if (!script) {
+    return;
+  }
+
+  var slug = script.getAttribute('data-catalog');
+  if (!slug) {
+    console.error('[atamlink] atribut data-catalog wajib diisi');
+    return;
+  }
+
+  var apiBase = new URL(script.src).origin + '__API_PREFIX__';
+
+  // Container widget: elemen data-target atau div tepat setelah tag script
+  var host = null;
+  var target = script.getAttribute('data-target');
+  if (target) {
+    host = document.querySelector(target);
+  }
+  if (!host) {
+    host = document.createElement('div');
+    script.parentNode.insertBefore(host, script.nextSibling);
+  }
+  var root = host.attachShadow ? host.attachShadow({ mode: 'open' }) : host;
+
+  function fetchData(path) {
+    return fetch(apiBase + path, { credentials: 'omit' }).then(function (res) {
+      if (!res.ok) {
+        throw new Error('HTTP ' + res.status);
+      }
+      return res.json();
+    }).then(function (body) {
+      return body.data;
+    });
+  }
+
+  function el(tag, className, text) {
+    var node = document.createElement(tag);
+    if (className) {
+      node.className = className;
+    }
+    if (text) {
+      node.textContent = text;
+    }
+    return node;
+  }
+
+  function formatPrice(amount, currency) {
+    try {
+      return new Intl.NumberFormat('id-ID', {
+        style: 'currency',
+        currency: currency || 'IDR',
+        maximumFractionDigits: 0
+      }).format(amount);
+    } catch (e) {
+      return (currency || 'IDR') + ' ' + amount;
+    }
+  }
+
+  function columnsFor(layout, fallback) {
+    var columns = (layout && layout.columns) || {};
+    var width = host.clientWidth || window.innerWidth;
+    if (width < 640) {
+      return columns.mobile || 1;
+    }
+    if (width < 1024) {
+      return columns.tablet || columns.mobile || 2;
+    }
+    return columns.desktop || fallback || 3;
+  }
+
+  function aspectRatio(value) {
+    var parts = (value || '1:1').split(':');
+    return parts[0] + ' / ' + (parts[1] || 1);
+  }
+
+  function style(config) {
+    var colors = config.colors || {};
+    return ':host{all:initial;display:block}' +
+      '.al{font-family:' + (config.font_family ? JSON.stringify(config.font_family) + ',' : '') + 'sans-serif;' +
+      'background:' + (colors.background || '#fff') + ';color:' + (colors.text || '#111') + ';padding:16px}' +
+      '.al-title{font-size:1.25em;font-weight:600;margin:0 0 12px}' +
+      '.al-grid{display:grid;gap:12px;margin-bottom:16px}' +
+      '.al-card{display:block;text-decoration:none;color:inherit;overflow:hidden;' +
+      'border:1px solid rgba(0,0,0,.08);border-radius:' + (config.border_radius || '8px') + '}' +
+      '.al-img{width:100%;object-fit:cover;display:block;background:rgba(0,0,0,.04)}' +
+      '.al-body{padding:8px 10px}' +
+      '.al-name{font-weight:600;margin:0 0 4px}' +
+      '.al-sub{font-size:.85em;opacity:.7;margin:0 0 4px}' +
+      '.al-price{color:' + (colors.primary || 'inherit') + ';font-weight:600}' +
+      '.al-old{text-decoration:line-through;opacity:.6;font-size:.85em;margin-left:6px}' +
+      '.al-more{display:inline-block;color:' + (colors.primary || 'inherit') + '}';
+  }
+
+  function renderCard(card, ratio) {
+    var node = el(card.url ? 'a' : 'div', 'al-card');
+    if (card.url) {
+      node.href = card.url;
+      node.target = '_blank';
+      node.rel = 'noopener';
+    }
+
+    var image = (card.media || [])[0];
+    if (image) {
+      var img = el('img', 'al-img');
+      img.src = image.url;
+      img.alt = card.title;
+      img.loading = 'lazy';
+      img.style.aspectRatio = aspectRatio(ratio);
+      node.appendChild(img);
+    }
+
+    var body = el('div', 'al-body');
+    body.appendChild(el('p', 'al-name', card.title));
+    if (card.subtitle) {
+      body.appendChild(el('p', 'al-sub', card.subtitle));
+    }
+    if (card.price) {
+      var price = el('div', 'al-price', formatPrice(card.discounted_price || card.price, card.currency));
+      if (card.discount) {
+        price.appendChild(el('span', 'al-old', formatPrice(card.price, card.currency)));
+      }
+      body.appendChild(price);
+    }
+    node.appendChild(body);
+    return node;
+  }
+
+  function render(config, catalog) {
+    var css = el('style');
+    css.textContent = style(config);
+    root.appendChild(css);
+
+    var container = el('div', 'al');
+    container.appendChild(el('p', 'al-title', catalog.title));
+
+    (catalog.sections || []).forEach(function (section) {
+      if (section.type !== 'cards' || !section.content || !section.content.length) {
+        return;
+      }
+      var layout = (section.config && section.config.layout) || {};
+      var grid = el('div', 'al-grid');
+      var columns = layout.type === 'list' ? 1 : columnsFor(layout, config.layout.columns);
+      grid.style.gridTemplateColumns = 'repeat(' + columns + ', minmax(0, 1fr))';
+      section.content.forEach(function (card) {
+        grid.appendChild(renderCard(card, layout.image_aspect_ratio || config.layout.image_aspect_ratio));
+      });
+      container.appendChild(grid);
+    });
+
+    if (config.catalog_url) {
+      var more = el('a', 'al-more', 'Lihat katalog lengkap');
+      more.href = config.catalog_url;
+      more.target = '_blank';
+      more.rel = 'noopener';
+      container.appendChild(more);
+    }
+
+    root.appendChild(container);
+  }
+
+  var path = '/' + encodeURIComponent(slug);
+  Promise.all([fetchData('/embed' + path), fetchData('/c' + path)]).then(function (data) {
+    render(data[0], data[1]);
+  }).catch(function (err) {
+    console.error('[atamlink] gagal memuat katalog ' + slug + ': ' + err.message);
+  });
+})();
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_auth/dto"
+	"github.com/atam/atamlink/internal/mod_auth/usecase"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// AuthHandler handler untuk verifikasi tambahan (step-up) dan sesi user
+type AuthHandler struct {
+	authUC    usecase.AuthUseCase
+	validator *utils.Validator
+}
+
+// NewAuthHandler membuat instance auth handler baru
+func NewAuthHandler(authUC usecase.AuthUseCase, validator *utils.Validator) *AuthHandler {
+	return &AuthHandler{
+		authUC:    authUC,
+		validator: validator,
+	}
+}
+
+// StepUp handler untuk verifikasi tambahan sebelum operasi destruktif
+// @Summary Step-up verification
+// @Description Metode email: kirim tanpa code untuk menerima kode lewat email, lalu kirim ulang dengan challenge_id dan code. Metode totp: kirim code dari authenticator. Token yang diterima dikirim lewat header X-Step-Up-Token dan hanya berlaku sekali
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.StepUpRequest true "Step-up data"
+// @Success 200 {object} utils.Response{data=dto.StepUpResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /auth/step-up [post]
+func (h *AuthHandler) StepUp(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Bind request
+	var req dto.StepUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	result, err := h.authUC.StepUp(profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if result.Token == "" {
+		utils.OK(c, "Kode verifikasi telah dikirim", result)
+		return
+	}
+	utils.OK(c, "Verifikasi berhasil", result)
+}
+
+// EnrollTOTP handler untuk mendaftarkan authenticator
+// @Summary Enroll TOTP authenticator
+// @Description Buat secret authenticator baru, aktif setelah dikonfirmasi dengan kode pertama. Wajib step-up email (header X-Step-Up-Token, action totp_enroll). Mengganti authenticator aktif wajib menyertakan kode authenticator saat ini
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param X-Step-Up-Token header string true "Token step-up email untuk action totp_enroll"
+// @Param body body dto.EnrollTOTPRequest false "Kode authenticator saat ini (wajib jika sudah aktif)"
+// @Success 201 {object} utils.Response{data=dto.TOTPEnrollResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /auth/totp [post]
+func (h *AuthHandler) EnrollTOTP(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Body opsional, hanya untuk mengganti authenticator aktif
+	var req dto.EnrollTOTPRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, constant.ErrMsgBadRequest)
+			return
+		}
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	result, err := h.authUC.EnrollTOTP(profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Scan secret dengan aplikasi authenticator lalu konfirmasi", result)
+}
+
+// ConfirmTOTP handler untuk mengaktifkan authenticator
+// @Summary Confirm TOTP authenticator
+// @Description Aktifkan authenticator dengan kode 6 digit pertama
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.ConfirmTOTPRequest true "TOTP code"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /auth/totp/confirm [post]
+func (h *AuthHandler) ConfirmTOTP(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Bind request
+	var req dto.ConfirmTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	if err := h.authUC.ConfirmTOTP(profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Authenticator berhasil diaktifkan", nil)
+}
+
+// ListSessions handler untuk daftar sesi aktif
+// @Summary List active sessions
+// @Description Daftar token/perangkat yang masih aktif beserta IP dan aktivitas terakhir, sesi saat ini ditandai current
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]dto.SessionResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /profile/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	currentSessionID, _ := middleware.GetSessionID(c)
+	sessions, err := h.authUC.ListSessions(profileID, currentSessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Sesi berhasil diambil", sessions)
+}
+
+// RevokeSession handler untuk mencabut satu sesi
+// @Summary Revoke session
+// @Description Cabut satu sesi, token sesi tersebut langsung ditolak
+// @Tags auth
+// @Produce json
+// @Param session_id path int true "Session ID"
+// @Success 204
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /profile/sessions/{session_id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID sesi tidak valid")
+		return
+	}
+
+	if err := h.authUC.RevokeSession(profileID, sessionID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// RevokeOtherSessions handler untuk mencabut semua sesi lain
+// @Summary Revoke other sessions
+// @Description Cabut semua sesi kecuali sesi yang dipakai request ini
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.Response{data=dto.RevokeSessionsResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /profile/sessions [delete]
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	currentSessionID, _ := middleware.GetSessionID(c)
+	result, err := h.authUC.RevokeOtherSessions(profileID, currentSessionID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Sesi lain berhasil dicabut", result)
+}
+
+// handleError menangani error dari use case
+func (h *AuthHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
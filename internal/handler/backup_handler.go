@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_backup/dto"
+	"github.com/atam/atamlink/internal/mod_backup/usecase"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// BackupHandler handler untuk backup dan restore katalog business
+type BackupHandler struct {
+	backupUC         usecase.BackupUseCase
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewBackupHandler membuat instance backup handler baru
+func NewBackupHandler(backupUC usecase.BackupUseCase, hideInaccessible bool, validator *utils.Validator) *BackupHandler {
+	return &BackupHandler{
+		backupUC:         backupUC,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// List handler untuk riwayat backup
+// @Summary List catalog backups
+// @Description Riwayat backup katalog business (terjadwal dan manual)
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=[]dto.BackupResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /businesses/{id}/backups [get]
+func (h *BackupHandler) List(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID business tidak valid")
+		return
+	}
+
+	backups, err := h.backupUC.List(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Riwayat backup berhasil diambil", backups)
+}
+
+// Create handler untuk backup manual
+// @Summary Create catalog backup
+// @Description Export seluruh katalog aktif business ke backup storage sekarang juga
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 201 {object} utils.Response{data=dto.BackupResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /businesses/{id}/backups [post]
+func (h *BackupHandler) Create(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID business tidak valid")
+		return
+	}
+
+	backup, err := h.backupUC.Create(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Backup berhasil dibuat", backup)
+}
+
+// Restore handler untuk restore katalog dari backup
+// @Summary Restore catalogs from backup
+// @Description Pulihkan katalog dari backup. Katalog yang masih ada diganti seluruh section-nya, katalog yang sudah hilang dibuat ulang
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param backup_id path int true "Backup ID"
+// @Param body body dto.RestoreBackupRequest false "Katalog yang dipulihkan (kosong = semua)"
+// @Success 200 {object} utils.Response{data=dto.RestoreResultResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /businesses/{id}/backups/{backup_id}/restore [post]
+func (h *BackupHandler) Restore(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID business tidak valid")
+		return
+	}
+
+	backupID, err := strconv.ParseInt(c.Param("backup_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID backup tidak valid")
+		return
+	}
+
+	var req dto.RestoreBackupRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, "Format request tidak valid")
+			return
+		}
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	result, err := h.backupUC.Restore(c, businessID, backupID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Katalog berhasil dipulihkan dari backup", result)
+}
+
+// Clone handler untuk clone business beserta katalognya
+// @Summary Clone business
+// @Description Buat business baru berisi salinan katalog terpilih sebagai draft. Member dan subscription tidak ikut disalin
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.CloneBusinessRequest true "Data business baru"
+// @Success 201 {object} utils.Response{data=dto.CloneResultResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 422 {object} utils.Response
+// @Router /businesses/{id}/clone [post]
+func (h *BackupHandler) Clone(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID business tidak valid")
+		return
+	}
+
+	var req dto.CloneBusinessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	result, err := h.backupUC.CloneBusiness(c, businessID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Business berhasil di-clone", result)
+}
+
+// ExportWorkbook handler untuk export data business ke Excel
+// @Summary Export business data to Excel
+// @Description Workbook berisi sheet katalog, card beserta harga, member dan riwayat subscription. File di-stream selama workbook dibuat
+// @Tags backups
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path int true "Business ID"
+// @Success 200 {file} file
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /businesses/{id}/export.xlsx [get]
+func (h *BackupHandler) ExportWorkbook(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID business tidak valid")
+		return
+	}
+
+	filename, reader, err := h.backupUC.ExportWorkbook(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	defer reader.Close()
+
+	// Panjang konten belum diketahui, response dikirim chunked
+	c.DataFromReader(http.StatusOK, -1, utils.XLSXContentType, reader, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, filename),
+	})
+}
+
+// handleError menangani error dari use case
+func (h *BackupHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, backupNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// backupNotFoundMessage pesan 404 sesuai resource yang diminta route
+func backupNotFoundMessage(c *gin.Context) string {
+	if c.Param("backup_id") != "" {
+		return constant.ErrMsgBackupNotFound
+	}
+	return constant.ErrMsgBusinessNotFound
+}
@@ -19,6 +19,7 @@ import (
 type BusinessHandler struct {
 	businessUC usecase.BusinessUseCase
 	uploadService service.UploadService
+	hideInaccessible bool
 	validator  *utils.Validator
 }
 
@@ -26,11 +27,13 @@ type BusinessHandler struct {
 func NewBusinessHandler(
 	businessUC usecase.BusinessUseCase,
 	uploadService service.UploadService,
+	hideInaccessible bool,
 	validator *utils.Validator,
 ) *BusinessHandler {
 	return &BusinessHandler{
 		businessUC: businessUC,
 		uploadService: uploadService,
+		hideInaccessible: hideInaccessible,
 		validator:  validator,
 	}
 }
@@ -44,6 +47,8 @@ func NewBusinessHandler(
 // @Param name formData string true "Business name"
 // @Param slug formData string false "Business slug"
 // @Param type formData string true "Business type"
+// @Param city formData string false "Business city, shown in the public directory"
+// @Param category_id formData int false "Master category ID"
 // @Param logo formData file false "Logo image file (max 10MB, JPG/PNG)"
 // @Success 201 {object} utils.Response{data=dto.BusinessResponse}
 // @Failure 400 {object} utils.Response
@@ -72,6 +77,15 @@ func (h *BusinessHandler) Create(c *gin.Context) {
 	req.Name = c.PostForm("name")
 	req.Slug = c.PostForm("slug")
 	req.Type = c.PostForm("type")
+	req.City = c.PostForm("city")
+	if categoryIDStr := c.PostForm("category_id"); categoryIDStr != "" {
+		categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+		if err != nil {
+			utils.BadRequest(c, "Field 'category_id' harus berupa angka")
+			return
+		}
+		req.CategoryID = categoryID
+	}
 	
 	// Handle file upload jika ada
 	file, err := c.FormFile("logo")
@@ -108,7 +122,7 @@ func (h *BusinessHandler) Create(c *gin.Context) {
 
 // List handler untuk list businesses
 // @Summary List businesses
-// @Description Get list of businesses
+// @Description Get list of businesses. Kirim cursor/limit untuk cursor pagination (tanpa total, meta.next_cursor untuk halaman berikutnya)
 // @Tags businesses
 // @Accept json
 // @Produce json
@@ -117,9 +131,12 @@ func (h *BusinessHandler) Create(c *gin.Context) {
 // @Param search query string false "Search keyword"
 // @Param type query string false "Business type filter"
 // @Param is_active query bool false "Active status filter"
+// @Param cursor query string false "Cursor pagination (kosong = halaman pertama), menggantikan page"
+// @Param limit query int false "Items per page untuk cursor pagination" default(20)
 // @Param sort query string false "Sort field" default(created_at)
 // @Param order query string false "Sort order" default(desc)
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.BusinessListResponse}
+// @Success 200 {object} utils.CursorResponse{data=[]dto.BusinessListResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 500 {object} utils.Response
@@ -156,6 +173,12 @@ func (h *BusinessHandler) List(c *gin.Context) {
 		}
 	}
 
+	// Cursor pagination menggantikan page/per_page
+	if paginationParams.CursorMode {
+		h.listByCursor(c, profileID, filter, paginationParams)
+		return
+	}
+
 	// Build order by
 	allowedSorts := map[string]string{
 		"created_at": "b_created_at",
@@ -166,7 +189,7 @@ func (h *BusinessHandler) List(c *gin.Context) {
 	orderBy := utils.BuildOrderBy(paginationParams.Sort, paginationParams.Order, allowedSorts)
 
 	// Get businesses
-	businesses, total, err := h.businessUC.List(
+	businesses, total, err := h.businessUC.List(c.Request.Context(), 
 		profileID,
 		filter,
 		paginationParams.Page,
@@ -183,6 +206,38 @@ func (h *BusinessHandler) List(c *gin.Context) {
 	utils.SuccessPaginated(c, 200, "Data bisnis berhasil diambil", businesses, meta)
 }
 
+// listByCursor list business dengan cursor pagination (?cursor= & ?limit=)
+func (h *BusinessHandler) listByCursor(c *gin.Context, profileID int64, filter *dto.BusinessFilter, paginationParams *utils.PaginationParams) {
+	// Kolom urutan harus NOT NULL agar keyset konsisten
+	allowedSorts := map[string]string{
+		"created_at": "b_created_at",
+		"updated_at": "COALESCE(b_updated_at, b_created_at)",
+		"name":       "b_name",
+		"type":       "b_type",
+	}
+	keyset, err := utils.BuildKeyset(paginationParams, allowedSorts, "b_id")
+	if err == utils.ErrCursorSortMismatch {
+		utils.BadRequest(c, constant.ErrMsgCursorSortMismatch)
+		return
+	}
+	if err != nil {
+		utils.BadRequest(c, constant.ErrMsgCursorInvalid)
+		return
+	}
+
+	businesses, nextCursor, err := h.businessUC.ListByCursor(c.Request.Context(), profileID, filter, keyset, paginationParams.PerPage)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.SuccessCursor(c, 200, "Data bisnis berhasil diambil", businesses, utils.CursorMeta{
+		Limit:      paginationParams.PerPage,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+	})
+}
+
 // GetByID handler untuk get business by ID
 // @Summary Get business by ID
 // @Description Get business details by ID
@@ -191,6 +246,7 @@ func (h *BusinessHandler) List(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Business ID"
 // @Success 200 {object} utils.Response{data=dto.BusinessResponse}
+// @Success 304
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
@@ -209,13 +265,13 @@ func (h *BusinessHandler) GetByID(c *gin.Context) {
 	}
 
 	// Get business
-	business, err := h.businessUC.GetByID(id, profileID)
+	business, err := h.businessUC.GetByID(c.Request.Context(), id, profileID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.OK(c, "Data bisnis berhasil diambil", business)
+	utils.OKWithETag(c, "Data bisnis berhasil diambil", business)
 }
 
 // Update handler untuk update business
@@ -225,8 +281,11 @@ func (h *BusinessHandler) GetByID(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce json
 // @Param id path int true "Business ID"
+// @Param If-Match header string false "ETag dari GET /businesses/{id}, update ditolak jika bisnis sudah berubah"
 // @Param name formData string false "Business name"
 // @Param type formData string false "Business type"
+// @Param city formData string false "Business city"
+// @Param category_id formData int false "Master category ID, 0 to clear"
 // @Param is_active formData bool false "Active status"
 // @Param logo formData file false "Logo image file (max 10MB, JPG/PNG)"
 // @Success 200 {object} utils.Response{data=dto.BusinessResponse}
@@ -234,6 +293,7 @@ func (h *BusinessHandler) GetByID(c *gin.Context) {
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
+// @Failure 412 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /businesses/{id} [put]
 func (h *BusinessHandler) Update(c *gin.Context) {
@@ -266,6 +326,17 @@ func (h *BusinessHandler) Update(c *gin.Context) {
 		// Manual binding untuk multipart form
 		req.Name = c.PostForm("name")
 		req.Type = c.PostForm("type")
+		if city, ok := c.GetPostForm("city"); ok {
+			req.City = &city
+		}
+		if categoryIDStr := c.PostForm("category_id"); categoryIDStr != "" {
+			categoryID, err := strconv.ParseInt(categoryIDStr, 10, 64)
+			if err != nil {
+				utils.BadRequest(c, "Field 'category_id' harus berupa angka")
+				return
+			}
+			req.CategoryID = &categoryID
+		}
 		
 		// Handle is_active boolean
 		if isActiveStr := c.PostForm("is_active"); isActiveStr != "" {
@@ -291,13 +362,26 @@ func (h *BusinessHandler) Update(c *gin.Context) {
 	}
 
 	// Validate request jika ada field yang diisi
-	if req.Name != "" || req.Type != "" || req.LogoFile != nil {
+	if req.Name != "" || req.Type != "" || req.City != nil || req.CategoryID != nil || req.LogoFile != nil {
 		if errors := h.validator.Validate(req); len(errors) > 0 {
 			utils.ValidationError(c, errors)
 			return
 		}
 	}
 
+	// Tolak update jika bisnis sudah berubah sejak dibaca klien
+	if utils.HasIfMatch(c) {
+		current, err := h.businessUC.GetByID(c.Request.Context(), id, profileID)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		if !utils.IfMatch(c, current) {
+			utils.PreconditionFailed(c, constant.ErrMsgPreconditionFailed)
+			return
+		}
+	}
+
 	// Update business
 	business, err := h.businessUC.Update(c, id, profileID, &req)
 	if err != nil {
@@ -305,6 +389,7 @@ func (h *BusinessHandler) Update(c *gin.Context) {
 		return
 	}
 
+	c.Header("ETag", utils.ETag(business))
 	utils.OK(c, "Bisnis berhasil diperbarui", business)
 }
 
@@ -346,6 +431,182 @@ func (h *BusinessHandler) Delete(c *gin.Context) {
 	utils.NoContent(c)
 }
 
+// UpdateMediaReplication handler untuk opsi replikasi media
+// @Summary Update media replication
+// @Description Aktifkan/nonaktifkan replikasi media katalog ke region/CDN kedua untuk pengunjung luar negeri
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.UpdateMediaReplicationRequest true "Media replication setting"
+// @Success 200 {object} utils.Response{data=dto.BusinessResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/media-replication [put]
+func (h *BusinessHandler) UpdateMediaReplication(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var req dto.UpdateMediaReplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	business, err := h.businessUC.UpdateMediaReplication(c, id, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Pengaturan replikasi media berhasil diperbarui", business)
+}
+
+// GetBrand handler untuk brand business
+// @Summary Get business brand
+// @Description Brand business (warna, font, pemakaian logo) yang jadi default settings katalog baru
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=dto.BrandResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/brand [get]
+func (h *BusinessHandler) GetBrand(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	brand, err := h.businessUC.GetBrand(c, id, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Brand bisnis berhasil diambil", brand)
+}
+
+// GetOnboarding handler untuk checklist onboarding business
+// @Summary Get business onboarding progress
+// @Description Progres langkah onboarding (logo, katalog pertama, card pertama, publish, link dikunjungi) yang dihitung dari data katalog dan analytics
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=dto.OnboardingResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/onboarding [get]
+func (h *BusinessHandler) GetOnboarding(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	onboarding, err := h.businessUC.GetOnboarding(c, id, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Progres onboarding berhasil diambil", onboarding)
+}
+
+// UpdateBrand handler untuk update brand business
+// @Summary Update business brand
+// @Description Ganti brand business. Katalog yang sudah ada baru berubah setelah apply brand
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.UpdateBrandRequest true "Brand settings"
+// @Success 200 {object} utils.Response{data=dto.BrandResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/brand [put]
+func (h *BusinessHandler) UpdateBrand(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var req dto.UpdateBrandRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	brand, err := h.businessUC.UpdateBrand(c, id, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Brand bisnis berhasil diperbarui", brand)
+}
+
 // AddUser handler untuk add user to business
 // @Summary Add user to business
 // @Description Add user as member of business
@@ -391,7 +652,7 @@ func (h *BusinessHandler) AddUser(c *gin.Context) {
 	}
 
 	// Add user
-	if err := h.businessUC.AddUser(businessID, profileID, &req); err != nil {
+	if err := h.businessUC.AddUser(c, businessID, profileID, &req); err != nil {
 		h.handleError(c, err)
 		return
 	}
@@ -451,7 +712,7 @@ func (h *BusinessHandler) UpdateUserRole(c *gin.Context) {
 	}
 
 	// Update user role
-	if err := h.businessUC.UpdateUserRole(businessID, profileID, targetProfileID, req.Role); err != nil {
+	if err := h.businessUC.UpdateUserRole(c, businessID, profileID, targetProfileID, req.Role); err != nil {
 		h.handleError(c, err)
 		return
 	}
@@ -497,7 +758,7 @@ func (h *BusinessHandler) RemoveUser(c *gin.Context) {
 	}
 
 	// Remove user
-	if err := h.businessUC.RemoveUser(businessID, profileID, targetProfileID); err != nil {
+	if err := h.businessUC.RemoveUser(c, businessID, profileID, targetProfileID); err != nil {
 		h.handleError(c, err)
 		return
 	}
@@ -519,6 +780,7 @@ func (h *BusinessHandler) RemoveUser(c *gin.Context) {
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
 // @Failure 500 {object} utils.Response
+// @Failure 503 {object} utils.Response
 // @Router /businesses/{id}/invites [post]
 func (h *BusinessHandler) CreateInvite(c *gin.Context) {
 	// Get profile ID from context
@@ -549,7 +811,7 @@ func (h *BusinessHandler) CreateInvite(c *gin.Context) {
 	}
 
 	// Create invite
-	invite, err := h.businessUC.CreateInvite(businessID, profileID, &req)
+	invite, err := h.businessUC.CreateInvite(c, businessID, profileID, &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -596,7 +858,7 @@ func (h *BusinessHandler) AcceptInvite(c *gin.Context) {
 	}
 
 	// Accept invite
-	if err := h.businessUC.AcceptInvite(&req); err != nil {
+	if err := h.businessUC.AcceptInvite(c.Request.Context(), &req); err != nil {
 		h.handleError(c, err)
 		return
 	}
@@ -604,8 +866,377 @@ func (h *BusinessHandler) AcceptInvite(c *gin.Context) {
 	utils.OK(c, "Berhasil bergabung ke bisnis", nil)
 }
 
+// ListInvites handler untuk daftar invite business
+// @Summary List business invites
+// @Description List invites of business, newest first, optionally filtered by status
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param status query string false "Invite status" Enums(pending, used, revoked, expired)
+// @Success 200 {object} utils.Response{data=[]dto.InviteResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/invites [get]
+func (h *BusinessHandler) ListInvites(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	invites, err := h.businessUC.ListInvites(c, businessID, profileID, c.Query("status"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Invite berhasil diambil", invites)
+}
+
+// ResendInvite handler untuk mengirim ulang invite
+// @Summary Resend business invite
+// @Description Email the invite link again and renew expiry of pending or expired invite, the invite link stays the same
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Invite ID"
+// @Success 200 {object} utils.Response{data=dto.InviteResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /invites/{id}/resend [post]
+func (h *BusinessHandler) ResendInvite(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	inviteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID invite tidak valid")
+		return
+	}
+
+	invite, err := h.businessUC.ResendInvite(c, inviteID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Invite berhasil dikirim ulang", invite)
+}
+
+// RevokeInvite handler untuk mencabut invite
+// @Summary Revoke business invite
+// @Description Cancel pending invite, its link stops working immediately
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Invite ID"
+// @Success 204
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /invites/{id} [delete]
+func (h *BusinessHandler) RevokeInvite(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	inviteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID invite tidak valid")
+		return
+	}
+
+	if err := h.businessUC.RevokeInvite(c, inviteID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// CreateServiceAccount handler untuk membuat service account
+// @Summary Create service account
+// @Description Create machine user for integrations, token is only shown once
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.CreateServiceAccountRequest true "Service account data"
+// @Success 201 {object} utils.Response{data=dto.ServiceAccountCreatedResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/service-accounts [post]
+func (h *BusinessHandler) CreateServiceAccount(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	account, err := h.businessUC.CreateServiceAccount(c, businessID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Service account berhasil dibuat", account)
+}
+
+// ListServiceAccounts handler untuk daftar service account
+// @Summary List service accounts
+// @Description List machine users of business
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=[]dto.ServiceAccountResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/service-accounts [get]
+func (h *BusinessHandler) ListServiceAccounts(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	accounts, err := h.businessUC.ListServiceAccounts(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Service account berhasil diambil", accounts)
+}
+
+// RevokeServiceAccount handler untuk mencabut service account
+// @Summary Revoke service account
+// @Description Revoke machine user, its token stops working immediately
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param account_id path int true "Service account ID"
+// @Success 204
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/service-accounts/{account_id} [delete]
+func (h *BusinessHandler) RevokeServiceAccount(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	accountID, err := strconv.ParseInt(c.Param("account_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID service account tidak valid")
+		return
+	}
+
+	if err := h.businessUC.RevokeServiceAccount(c, businessID, accountID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// GetIPAllowlist handler untuk IP allowlist business
+// @Summary Get IP allowlist
+// @Description IP/CIDR yang boleh melakukan operasi tulis pada business, beserta IP pemanggil saat ini
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=dto.IPAllowlistResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/ip-allowlist [get]
+func (h *BusinessHandler) GetIPAllowlist(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	allowlist, err := h.businessUC.GetIPAllowlist(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "IP allowlist berhasil diambil", allowlist)
+}
+
+// UpdateIPAllowlist handler untuk mengganti IP allowlist business
+// @Summary Update IP allowlist
+// @Description Ganti daftar IP/CIDR yang boleh melakukan operasi tulis, daftar kosong menonaktifkan allowlist. IP pemanggil harus termasuk dalam daftar baru
+// @Tags businesses
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.UpdateIPAllowlistRequest true "IP allowlist"
+// @Success 200 {object} utils.Response{data=dto.IPAllowlistResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/ip-allowlist [put]
+func (h *BusinessHandler) UpdateIPAllowlist(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var req dto.UpdateIPAllowlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	allowlist, err := h.businessUC.UpdateIPAllowlist(c, businessID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "IP allowlist berhasil diperbarui", allowlist)
+}
+
+// BreakGlassIPAllowlist handler untuk membuka IP allowlist sementara
+// @Summary Break-glass IP allowlist
+// @Description Owner yang terkunci di luar allowlist dapat membuka allowlist sementara setelah step-up (header X-Step-Up-Token, action ip_allowlist_break_glass). Allowlist tertutup kembali saat waktu habis atau saat allowlist diperbarui
+// @Tags businesses
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=dto.IPAllowlistResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/ip-allowlist/break-glass [post]
+func (h *BusinessHandler) BreakGlassIPAllowlist(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	allowlist, err := h.businessUC.BreakGlassIPAllowlist(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "IP allowlist dibuka sementara", allowlist)
+}
+
 // handleError menangani error dari use case
 func (h *BusinessHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota tidak boleh tahu bisnis ini ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, constant.ErrMsgBusinessNotFound)
+		return
+	}
+
 	// Check if AppError
 	if appErr, ok := err.(*errors.AppError); ok {
 		utils.Error(c, appErr.StatusCode, appErr.Message)
@@ -2,7 +2,12 @@ package handler
 
 import (
 	"fmt"
+	"io"
+	"net/http"
+	"path"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -19,6 +24,8 @@ import (
 type CatalogHandler struct {
 	catalogUC     usecase.CatalogUseCase
 	uploadService service.UploadService
+	geoHeader     string
+	hideInaccessible bool
 	validator     *utils.Validator
 }
 
@@ -26,11 +33,15 @@ type CatalogHandler struct {
 func NewCatalogHandler(
 	catalogUC usecase.CatalogUseCase,
 	uploadService service.UploadService,
+	geoHeader string,
+	hideInaccessible bool,
 	validator *utils.Validator,
 ) *CatalogHandler {
 	return &CatalogHandler{
 		catalogUC:     catalogUC,
 		uploadService: uploadService,
+		geoHeader:     geoHeader,
+		hideInaccessible: hideInaccessible,
 		validator:     validator,
 	}
 }
@@ -71,7 +82,7 @@ func (h *CatalogHandler) Create(c *gin.Context) {
 	}
 
 	// Create catalog
-	catalog, err := h.catalogUC.Create(profileID, &req)
+	catalog, err := h.catalogUC.Create(c, profileID, &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -82,7 +93,7 @@ func (h *CatalogHandler) Create(c *gin.Context) {
 
 // List handler untuk list catalogs
 // @Summary List catalogs
-// @Description Get list of catalogs
+// @Description Get list of catalogs. Kirim cursor/limit untuk cursor pagination (tanpa total, meta.next_cursor untuk halaman berikutnya). Jika parameter ids diisi, response berisi ringkasan katalog per ID sesuai urutan ids (tanpa paginasi) dengan error per item untuk ID yang tidak ditemukan atau tanpa akses
 // @Tags catalogs
 // @Accept json
 // @Produce json
@@ -92,9 +103,14 @@ func (h *CatalogHandler) Create(c *gin.Context) {
 // @Param business_id query int false "Business ID filter"
 // @Param theme_id query int false "Theme ID filter"
 // @Param is_active query bool false "Active status filter"
+// @Param ids query string false "Batch ID katalog dipisah koma, maksimal 50"
+// @Param cursor query string false "Cursor pagination (kosong = halaman pertama), menggantikan page"
+// @Param limit query int false "Items per page untuk cursor pagination" default(20)
 // @Param sort query string false "Sort field" default(created_at)
 // @Param order query string false "Sort order" default(desc)
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.CatalogListResponse}
+// @Success 200 {object} utils.CursorResponse{data=[]dto.CatalogListResponse}
+// @Success 200 {object} utils.Response{data=[]dto.CatalogBatchItem}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 500 {object} utils.Response
@@ -103,6 +119,12 @@ func (h *CatalogHandler) List(c *gin.Context) {
 	// Get profile ID from context
 	profileID, _ := middleware.GetProfileID(c)
 
+	// Batch per ID menggantikan list biasa
+	if rawIDs := c.Query("ids"); rawIDs != "" {
+		h.listByIDs(c, profileID, rawIDs)
+		return
+	}
+
 	// Get pagination params
 	paginationParams := utils.GetPaginationParams(c)
 
@@ -138,6 +160,12 @@ func (h *CatalogHandler) List(c *gin.Context) {
 		}
 	}
 
+	// Cursor pagination menggantikan page/per_page
+	if paginationParams.CursorMode {
+		h.listByCursor(c, profileID, filter, paginationParams)
+		return
+	}
+
 	// Build order by
 	allowedSorts := map[string]string{
 		"created_at": "c_created_at",
@@ -147,7 +175,7 @@ func (h *CatalogHandler) List(c *gin.Context) {
 	orderBy := utils.BuildOrderBy(paginationParams.Sort, paginationParams.Order, allowedSorts)
 
 	// Get catalogs
-	catalogs, total, err := h.catalogUC.List(
+	catalogs, total, err := h.catalogUC.List(c.Request.Context(), 
 		profileID,
 		filter,
 		paginationParams.Page,
@@ -164,14 +192,223 @@ func (h *CatalogHandler) List(c *gin.Context) {
 	utils.SuccessPaginated(c, 200, "Data katalog berhasil diambil", catalogs, meta)
 }
 
+// listByCursor list katalog dengan cursor pagination (?cursor= & ?limit=)
+func (h *CatalogHandler) listByCursor(c *gin.Context, profileID int64, filter *dto.CatalogFilter, paginationParams *utils.PaginationParams) {
+	// Kolom urutan harus NOT NULL agar keyset konsisten
+	allowedSorts := map[string]string{
+		"created_at": "c.c_created_at",
+		"updated_at": "COALESCE(c.c_updated_at, c.c_created_at)",
+		"title":      "c.c_title",
+	}
+	keyset, err := utils.BuildKeyset(paginationParams, allowedSorts, "c.c_id")
+	if err == utils.ErrCursorSortMismatch {
+		utils.BadRequest(c, constant.ErrMsgCursorSortMismatch)
+		return
+	}
+	if err != nil {
+		utils.BadRequest(c, constant.ErrMsgCursorInvalid)
+		return
+	}
+
+	catalogs, nextCursor, err := h.catalogUC.ListByCursor(c.Request.Context(), profileID, filter, keyset, paginationParams.PerPage)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.SuccessCursor(c, 200, "Data katalog berhasil diambil", catalogs, utils.CursorMeta{
+		Limit:      paginationParams.PerPage,
+		NextCursor: nextCursor,
+		HasMore:    nextCursor != "",
+	})
+}
+
+// listByIDs ringkasan katalog untuk GET /catalogs?ids=
+func (h *CatalogHandler) listByIDs(c *gin.Context, profileID int64, rawIDs string) {
+	ids := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for _, part := range strings.Split(rawIDs, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || id <= 0 {
+			utils.BadRequest(c, fmt.Sprintf(constant.ErrMsgBatchCatalogIDsInvalid, constant.MaxBatchCatalogs))
+			return
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > constant.MaxBatchCatalogs {
+		utils.BadRequest(c, fmt.Sprintf(constant.ErrMsgBatchCatalogIDsInvalid, constant.MaxBatchCatalogs))
+		return
+	}
+
+	items, err := h.catalogUC.GetByIDs(c, profileID, ids)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	for _, item := range items {
+		item.Error = h.batchItemError(item.Err)
+	}
+
+	utils.OK(c, "Data katalog berhasil diambil", items)
+}
+
+// GetPublicCatalogs handler untuk batch katalog publik by slugs
+// @Summary Batch get public catalogs
+// @Description Ringkasan beberapa katalog publik sekaligus untuk aplikasi agregator, urut sesuai slugs. Slug yang tidak ditemukan atau tidak tampil publik mendapat error per item
+// @Tags catalogs
+// @Produce json
+// @Param slugs query string true "Slug katalog dipisah koma, maksimal 50"
+// @Success 200 {object} utils.Response{data=[]dto.PublicCatalogBatchItem}
+// @Failure 400 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /public/catalogs [get]
+func (h *CatalogHandler) GetPublicCatalogs(c *gin.Context) {
+	slugs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(c.Query("slugs"), ",") {
+		slug := strings.TrimSpace(part)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+	if len(slugs) == 0 || len(slugs) > constant.MaxBatchCatalogs {
+		utils.BadRequest(c, fmt.Sprintf(constant.ErrMsgBatchCatalogSlugsInvalid, constant.MaxBatchCatalogs))
+		return
+	}
+
+	items, err := h.catalogUC.GetPublicBySlugs(c.Request.Context(), slugs)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	for _, item := range items {
+		item.Error = h.batchItemError(item.Err)
+	}
+
+	utils.OK(c, "Data katalog berhasil diambil", items)
+}
+
+// batchItemError error per item batch, mengikuti status dan pesan endpoint tunggal
+func (h *CatalogHandler) batchItemError(err error) *dto.BatchItemError {
+	if err == nil {
+		return nil
+	}
+	// Non-anggota dapat 404 yang sama dengan katalog yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		return &dto.BatchItemError{Status: 404, Message: constant.ErrMsgCatalogNotFound}
+	}
+	if appErr, ok := err.(*errors.AppError); ok {
+		return &dto.BatchItemError{Status: appErr.StatusCode, Message: appErr.Message}
+	}
+	return &dto.BatchItemError{Status: 500, Message: constant.ErrMsgInternalServer}
+}
+
+// Search handler untuk pencarian katalog di dashboard
+// @Summary Search catalogs
+// @Description Cari katalog (judul, subtitle, slug) dan judul card di semua business milik user dengan full-text search, diurutkan dari yang paling relevan. Field *_highlight berisi teks yang sudah di-escape HTML dengan kata cocok dibungkus <mark>
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param q query string true "Kata kunci pencarian"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.CatalogSearchResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/search [get]
+func (h *CatalogHandler) Search(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+
+	results, total, err := h.catalogUC.SearchDashboard(c.Request.Context(), 
+		profileID,
+		c.Query("q"),
+		paginationParams.Page,
+		paginationParams.PerPage,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Hasil pencarian katalog berhasil diambil", results, meta)
+}
+
+// Discover handler untuk direktori publik katalog
+// @Summary Discover catalogs
+// @Description Direktori publik katalog published yang ikut serta (opt-in lewat field listed), dapat difilter kategori master, tipe business dan kota
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param search query string false "Search keyword"
+// @Param category query string false "Category slug"
+// @Param type query string false "Business type"
+// @Param city query string false "Business city"
+// @Param sort query string false "Sort field (popular, newest, title, rating)" default(popular)
+// @Param order query string false "Sort order" default(desc)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.DirectoryCatalogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /discover [get]
+func (h *CatalogHandler) Discover(c *gin.Context) {
+	paginationParams := utils.GetPaginationParams(c)
+	filterParams := utils.GetFilterParams(c)
+
+	filter := &dto.DirectoryFilter{
+		Search:   filterParams.Search,
+		Category: c.Query("category"),
+		Type:     c.Query("type"),
+		City:     c.Query("city"),
+	}
+
+	// Default popular (sort created_at bawaan pagination jatuh ke default)
+	allowedSorts := map[string]string{
+		"created_at": "views",
+		"popular":    "views",
+		"newest":     "c.c_published_at",
+		"title":      "c.c_title",
+		"rating":     "c.c_rating_avg",
+	}
+	orderBy := utils.BuildOrderBy(paginationParams.Sort, paginationParams.Order, allowedSorts)
+
+	catalogs, total, err := h.catalogUC.Discover(c.Request.Context(), 
+		filter,
+		paginationParams.Page,
+		paginationParams.PerPage,
+		orderBy,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Direktori katalog berhasil diambil", catalogs, meta)
+}
+
 // GetByID handler untuk get catalog by ID
 // @Summary Get catalog by ID
-// @Description Get catalog details by ID
+// @Description Get catalog details by ID. meta.hints berisi pemakaian section/card yang mendekati batas plan (jika ada)
 // @Tags catalogs
 // @Accept json
 // @Produce json
 // @Param id path int true "Catalog ID"
 // @Success 200 {object} utils.Response{data=dto.CatalogResponse}
+// @Success 304
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
@@ -190,13 +427,18 @@ func (h *CatalogHandler) GetByID(c *gin.Context) {
 	}
 
 	// Get catalog
-	catalog, err := h.catalogUC.GetByID(id, profileID)
+	catalog, err := h.catalogUC.GetByID(c, id, profileID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.OK(c, "Data katalog berhasil diambil", catalog)
+	// Hint batas plan opsional, kegagalan tidak menggagalkan response
+	if hints, err := h.catalogUC.UsageHints(c.Request.Context(), id); err == nil {
+		utils.SetHints(c, hints)
+	}
+
+	utils.OKWithETag(c, "Data katalog berhasil diambil", catalog)
 }
 
 // Update handler untuk update catalog
@@ -206,12 +448,16 @@ func (h *CatalogHandler) GetByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Catalog ID"
+// @Param If-Match header string false "ETag dari GET /catalogs/{id}, update ditolak jika katalog sudah berubah"
+// @Param If-Unmodified-Since header string false "HTTP date updated_at saat dibaca, 409 jika katalog sudah berubah setelahnya"
 // @Param body body dto.UpdateCatalogRequest true "Update data"
 // @Success 200 {object} utils.Response{data=dto.CatalogResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 412 {object} utils.Response
 // @Failure 500 {object} utils.Response
 // @Router /catalogs/{id} [put]
 func (h *CatalogHandler) Update(c *gin.Context) {
@@ -236,37 +482,2465 @@ func (h *CatalogHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Validate request
-	if errors := h.validator.Validate(req); len(errors) > 0 {
-		utils.ValidationError(c, errors)
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	req.UnmodifiedSince = utils.IfUnmodifiedSince(c)
+
+	// Tolak update jika katalog sudah berubah sejak dibaca klien
+	if utils.HasIfMatch(c) {
+		current, err := h.catalogUC.GetByID(c, id, profileID)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		if !utils.IfMatch(c, current) {
+			utils.PreconditionFailed(c, constant.ErrMsgPreconditionFailed)
+			return
+		}
+	}
+
+	// Update catalog
+	catalog, err := h.catalogUC.Update(c, id, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("ETag", utils.ETag(catalog))
+	utils.OK(c, "Katalog berhasil diperbarui", catalog)
+}
+
+// Delete handler untuk delete catalog
+// @Summary Delete catalog
+// @Description Soft delete catalog: katalog dinonaktifkan dan dipindah ke trash (GET /catalogs/trash)
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/{id} [delete]
+func (h *CatalogHandler) Delete(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	// Delete catalog
+	if err := h.catalogUC.Delete(c, id, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// Trash handler untuk list katalog yang sudah dihapus
+// @Summary List deleted catalogs
+// @Description Katalog yang sudah dihapus (trash) pada business milik user, bisa dipulihkan atau dihapus permanen
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param search query string false "Search keyword"
+// @Param business_id query int false "Business ID filter"
+// @Param sort query string false "Sort field" default(deleted_at)
+// @Param order query string false "Sort order" default(desc)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.CatalogListResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/trash [get]
+func (h *CatalogHandler) Trash(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+	filterParams := utils.GetFilterParams(c)
+
+	filter := &dto.CatalogFilter{
+		Search: filterParams.Search,
+	}
+	if businessIDStr := c.Query("business_id"); businessIDStr != "" {
+		businessID, err := strconv.ParseInt(businessIDStr, 10, 64)
+		if err == nil {
+			filter.BusinessID = businessID
+		}
+	}
+
+	// Default urutan: yang terakhir dihapus lebih dulu
+	sort := paginationParams.Sort
+	if c.Query("sort") == "" {
+		sort = "deleted_at"
+	}
+	allowedSorts := map[string]string{
+		"deleted_at": "c_deleted_at",
+		"created_at": "c_created_at",
+		"title":      "c_title",
+	}
+	orderBy := utils.BuildOrderBy(sort, paginationParams.Order, allowedSorts)
+
+	catalogs, total, err := h.catalogUC.ListTrash(
+		c,
+		profileID,
+		filter,
+		paginationParams.Page,
+		paginationParams.PerPage,
+		orderBy,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Data trash katalog berhasil diambil", catalogs, meta)
+}
+
+// Restore handler untuk memulihkan katalog dari trash
+// @Summary Restore deleted catalog
+// @Description Pulihkan katalog dari trash. Katalog tetap nonaktif sampai diaktifkan lewat update
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=dto.CatalogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/{id}/restore [post]
+func (h *CatalogHandler) Restore(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	catalog, err := h.catalogUC.Restore(c, id, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Katalog berhasil dipulihkan", catalog)
+}
+
+// Purge handler untuk hapus permanen katalog di trash
+// @Summary Permanently delete catalog
+// @Description Hapus permanen katalog yang sudah ada di trash beserta section, card dan data turunannya. Tidak bisa dibatalkan
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/{id}/purge [delete]
+func (h *CatalogHandler) Purge(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	if err := h.catalogUC.Purge(c, id, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// GetPublicCatalog handler untuk get public catalog by slug
+// @Summary Get public catalog
+// @Description Get public catalog by slug. Field robots dan header X-Robots-Tag mengikuti opsi allow_indexing katalog. URL media mengikuti region pengunjung jika business mengaktifkan replikasi media. Dengan query tag hanya card dengan tag tersebut yang ditampilkan, field tags selalu berisi semua tag card yang tampil. Header ETag berubah setiap konten katalog (termasuk section, card dan child lain) berubah
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param tag query string false "Slug tag untuk filter card"
+// @Param If-None-Match header string false "ETag response sebelumnya, 304 jika konten katalog belum berubah"
+// @Success 200 {object} utils.Response{data=dto.PublicCatalogResponse}
+// @Success 304
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /c/{slug} [get]
+func (h *CatalogHandler) GetPublicCatalog(c *gin.Context) {
+	// Get slug from param
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	// Negara pengunjung dari header CDN/proxy untuk memilih region media
+	country := ""
+	if h.geoHeader != "" {
+		country = c.GetHeader(h.geoHeader)
+	}
+
+	tag := c.Query("tag")
+
+	// Versi konten dicek sebelum katalog di-load, klien yang polling dapat 304.
+	// Gagal hitung ETag (mis. database bermasalah) lanjut ke alur biasa
+	if etag, err := h.catalogUC.PublicCatalogETag(c.Request.Context(), slug, country, tag); err == nil && utils.NotModified(c, etag) {
+		return
+	}
+
+	// Get public catalog
+	catalog, err := h.catalogUC.GetBySlug(c.Request.Context(), slug, country, tag)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Katalog private-but-linkable tetap bisa dibuka tapi tidak diindex
+	c.Header("X-Robots-Tag", catalog.Robots)
+
+	utils.OK(c, "Data katalog berhasil diambil", catalog)
+}
+
+// GetPublicCard handler untuk get detail card publik by slug
+// @Summary Get public card
+// @Description Get card publik (deskripsi, media, links, harga) untuk halaman detail produk berdasarkan slug detail yang unik per katalog. Katalog, section, card dan detail harus visible. Slug lama setelah rename diarahkan (301) ke slug terbaru
+// @Tags catalogs
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param card_slug path string true "Card detail slug"
+// @Success 200 {object} utils.Response{data=dto.CardResponse}
+// @Success 301
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /c/{slug}/cards/{card_slug} [get]
+func (h *CatalogHandler) GetPublicCard(c *gin.Context) {
+	slug := c.Param("slug")
+	cardSlug := c.Param("card_slug")
+	if slug == "" || cardSlug == "" {
+		utils.BadRequest(c, "Slug tidak valid")
+		return
+	}
+
+	country := ""
+	if h.geoHeader != "" {
+		country = c.GetHeader(h.geoHeader)
+	}
+
+	card, redirect, err := h.catalogUC.GetPublicCard(c.Request.Context(), slug, cardSlug, country)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Slug lama, arahkan ke URL dengan slug terbaru
+	if redirect != "" {
+		c.Redirect(http.StatusMovedPermanently, path.Dir(c.Request.URL.Path)+"/"+redirect)
+		return
+	}
+
+	utils.OK(c, "Data card berhasil diambil", card)
+}
+
+// SearchPublicCards handler untuk pencarian card di katalog publik
+// @Summary Search public cards
+// @Description Cari card yang tampil di katalog publik berdasarkan judul, subtitle dan deskripsi detail (full-text search), diurutkan dari yang paling relevan
+// @Tags catalogs
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param q query string true "Kata kunci pencarian"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.CardResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /c/{slug}/search [get]
+func (h *CatalogHandler) SearchPublicCards(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	country := ""
+	if h.geoHeader != "" {
+		country = c.GetHeader(h.geoHeader)
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+
+	cards, total, err := h.catalogUC.SearchPublicCards(c.Request.Context(), 
+		slug,
+		c.Query("q"),
+		country,
+		paginationParams.Page,
+		paginationParams.PerPage,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Hasil pencarian card berhasil diambil", cards, meta)
+}
+
+// GetEmbedConfig handler untuk pengaturan widget embed katalog
+// @Summary Get embed config
+// @Description Pengaturan widget embed (warna, font, layout) katalog publik, dipanggil oleh embed.js dari website merchant
+// @Tags embed
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Success 200 {object} utils.Response{data=dto.EmbedConfigResponse}
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /embed/{slug} [get]
+func (h *CatalogHandler) GetEmbedConfig(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	config, err := h.catalogUC.GetEmbedConfig(c.Request.Context(), slug)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=60")
+	utils.OK(c, "Config embed berhasil diambil", config)
+}
+
+// CreateSection handler untuk create section
+// @Summary Create catalog section
+// @Description Create new section in catalog
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param body body dto.CreateSectionRequest true "Section data"
+// @Success 201 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/{id}/sections [post]
+func (h *CatalogHandler) CreateSection(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.CreateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	// Create section
+	if err := h.catalogUC.CreateSection(c, catalogID, profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Section berhasil dibuat", nil)
+}
+
+// UpdateSection handler untuk update section
+// @Summary Update catalog section
+// @Description Update section data
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.UpdateSectionRequest true "Update data"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id} [put]
+func (h *CatalogHandler) UpdateSection(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.UpdateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	// Update section
+	if err := h.catalogUC.UpdateSection(c, sectionID, profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Section berhasil diperbarui", nil)
+}
+
+// DeleteSection handler untuk delete section
+// @Summary Delete catalog section
+// @Description Delete section from catalog
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id} [delete]
+func (h *CatalogHandler) DeleteSection(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Delete section
+	if err := h.catalogUC.DeleteSection(c, sectionID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// MoveSection handler untuk pindah posisi section
+// @Summary Move catalog section
+// @Description Pindahkan section tepat setelah section after_id, after_id 0 = paling atas
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.MovePositionRequest true "Posisi tujuan"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/position [put]
+func (h *CatalogHandler) MoveSection(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.MovePositionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	if err := h.catalogUC.MoveSection(c, sectionID, profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Posisi section berhasil diubah", nil)
+}
+
+// ReorderSections handler untuk mengurutkan ulang section katalog
+// @Summary Reorder catalog sections
+// @Description Urutkan ulang semua section katalog sekaligus, section_ids harus berisi semua section katalog sesuai urutan tampil
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param body body dto.ReorderSectionsRequest true "Urutan section"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/{id}/sections/reorder [put]
+func (h *CatalogHandler) ReorderSections(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.ReorderSectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	if err := h.catalogUC.ReorderSections(c, catalogID, profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Urutan section berhasil diubah", nil)
+}
+
+// GenerateQR handler untuk generate ulang QR code katalog
+// @Summary Generate catalog QR code
+// @Description Generate QR code berisi URL publik katalog, upload ke storage dan simpan sebagai qr_url. File lama ditimpa
+// @Tags catalogs
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=dto.CatalogQRResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /catalogs/{id}/qr [post]
+func (h *CatalogHandler) GenerateQR(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	result, err := h.catalogUC.GenerateQR(c, catalogID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "QR code katalog berhasil dibuat", result)
+}
+
+// PurgeCache handler untuk purge cache CDN katalog secara manual
+// @Summary Purge catalog cache
+// @Description Purge cache CDN halaman publik katalog dan halaman detail card sekarang juga. Kirim section_id untuk hanya ikut purge detail card di section tersebut. Dibatasi per katalog
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param body body dto.PurgeCacheRequest false "Section opsional"
+// @Success 200 {object} utils.Response{data=dto.PurgeCacheResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 429 {object} utils.Response
+// @Failure 502 {object} utils.Response
+// @Router /catalogs/{id}/cache/purge [post]
+func (h *CatalogHandler) PurgeCache(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	// Body opsional, default seluruh katalog
+	var req dto.PurgeCacheRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, constant.ErrMsgBadRequest)
+			return
+		}
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	result, err := h.catalogUC.PurgeCache(c, catalogID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Cache katalog berhasil di-purge", result)
+}
+
+// ApplyBrand handler untuk menerapkan brand business ke semua katalog
+// @Summary Apply business brand to catalogs
+// @Description Timpa warna, font dan pemakaian logo di settings semua katalog business dengan brand business
+// @Tags catalogs
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=dto.ApplyBrandResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/brand/apply [post]
+func (h *CatalogHandler) ApplyBrand(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get business ID from param
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	result, err := h.catalogUC.ApplyBrand(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Brand berhasil diterapkan ke katalog", result)
+}
+
+// ReindexSearch handler untuk reindex penuh search engine
+// @Summary Reindex catalog search
+// @Description Kosongkan index search engine lalu isi ulang semua katalog di background
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /admin/search/reindex [post]
+func (h *CatalogHandler) ReindexSearch(c *gin.Context) {
+	if err := h.catalogUC.ReindexSearch(c.Request.Context()); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Reindex search dimulai", nil)
+}
+
+// IngestMediaAccess handler untuk ingest log akses media dari CDN
+// @Summary Ingest media access logs
+// @Description Catat waktu akses terakhir media dari log CDN, dipakai job lifecycle untuk memindah original yang jarang diakses ke storage arsip
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param body body dto.MediaAccessLogRequest true "Access log entries"
+// @Success 200 {object} utils.Response{data=dto.MediaAccessLogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /admin/media/access-logs [post]
+func (h *CatalogHandler) IngestMediaAccess(c *gin.Context) {
+	// Bind request
+	var req dto.MediaAccessLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	result, err := h.catalogUC.IngestMediaAccess(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Log akses media berhasil dicatat", result)
+}
+
+// CreateFAQs handler untuk menambah FAQ ke section
+// @Summary Add section FAQs
+// @Description Tambah satu atau beberapa FAQ di akhir section bertipe faqs
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.CreateFAQsRequest true "FAQ data"
+// @Success 201 {object} utils.Response{data=[]dto.FAQResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/faqs [post]
+func (h *CatalogHandler) CreateFAQs(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.CreateFAQsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	faqs, err := h.catalogUC.CreateFAQs(c, sectionID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "FAQ berhasil ditambahkan", faqs)
+}
+
+// CreateLegalVersion handler untuk menyimpan teks legal versi baru
+// @Summary Create legal version
+// @Description Simpan syarat & ketentuan dan kebijakan privasi section legal sebagai versi baru, versi lama tetap disimpan
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.CreateLegalVersionRequest true "Legal text"
+// @Success 201 {object} utils.Response{data=dto.LegalVersionResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/legal [post]
+func (h *CatalogHandler) CreateLegalVersion(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.CreateLegalVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	legal, err := h.catalogUC.CreateLegalVersion(c, sectionID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Versi legal berhasil disimpan", legal)
+}
+
+// ListLegalVersions handler untuk riwayat versi teks legal
+// @Summary List legal versions
+// @Description Riwayat versi syarat & ketentuan dan kebijakan privasi section legal, terbaru lebih dulu
+// @Tags catalogs
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Success 200 {object} utils.Response{data=[]dto.LegalVersionResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/legal [get]
+func (h *CatalogHandler) ListLegalVersions(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	versions, err := h.catalogUC.ListLegalVersions(c, sectionID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Versi legal berhasil diambil", versions)
+}
+
+// ReplaceFAQs handler untuk mengganti seluruh FAQ section
+// @Summary Replace section FAQs
+// @Description Ganti seluruh FAQ section secara atomik. FAQ dengan id diupdate, tanpa id dibuat baru, yang tidak dikirim dihapus. Urutan array menjadi urutan tampil
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.ReplaceFAQsRequest true "Daftar FAQ"
+// @Success 200 {object} utils.Response{data=[]dto.FAQResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/faqs [put]
+func (h *CatalogHandler) ReplaceFAQs(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.ReplaceFAQsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	faqs, err := h.catalogUC.ReplaceFAQs(c, sectionID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "FAQ berhasil diperbarui", faqs)
+}
+
+// DeleteFAQs handler untuk menghapus semua FAQ section
+// @Summary Delete section FAQs
+// @Description Hapus semua FAQ di section
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/faqs [delete]
+func (h *CatalogHandler) DeleteFAQs(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	if err := h.catalogUC.DeleteFAQs(c, sectionID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// ReorderFAQs handler untuk mengurutkan ulang FAQ section
+// @Summary Reorder section FAQs
+// @Description Urutkan ulang semua FAQ section sekaligus, faq_ids harus berisi semua FAQ section sesuai urutan tampil
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.ReorderFAQsRequest true "Urutan FAQ"
+// @Success 200 {object} utils.Response{data=[]dto.FAQResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/faqs/reorder [put]
+func (h *CatalogHandler) ReorderFAQs(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.ReorderFAQsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	faqs, err := h.catalogUC.ReorderFAQs(c, sectionID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Urutan FAQ berhasil diubah", faqs)
+}
+
+// UpdateFAQ handler untuk update satu FAQ
+// @Summary Update FAQ
+// @Description Update pertanyaan, jawaban (Markdown) atau visibilitas satu FAQ, field kosong tidak diubah
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param faq_id path int true "FAQ ID"
+// @Param body body dto.UpdateFAQRequest true "Data FAQ"
+// @Success 200 {object} utils.Response{data=dto.FAQResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/faqs/{faq_id} [put]
+func (h *CatalogHandler) UpdateFAQ(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get FAQ ID from param
+	faqID, err := strconv.ParseInt(c.Param("faq_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID FAQ tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.UpdateFAQRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	faq, err := h.catalogUC.UpdateFAQ(c, faqID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "FAQ berhasil diperbarui", faq)
+}
+
+// DeleteFAQ handler untuk menghapus satu FAQ
+// @Summary Delete FAQ
+// @Description Hapus satu FAQ, urutan FAQ lain tidak berubah
+// @Tags catalogs
+// @Param faq_id path int true "FAQ ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/faqs/{faq_id} [delete]
+func (h *CatalogHandler) DeleteFAQ(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get FAQ ID from param
+	faqID, err := strconv.ParseInt(c.Param("faq_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID FAQ tidak valid")
+		return
+	}
+
+	if err := h.catalogUC.DeleteFAQ(c, faqID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// CreateCard handler untuk create card
+// @Summary Create catalog card
+// @Description Create new card in section
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.CreateCardRequest true "Card data"
+// @Success 201 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/cards [post]
+func (h *CatalogHandler) CreateCard(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.CreateCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	// Create card
+	if err := h.catalogUC.CreateCard(c, sectionID, profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Card berhasil dibuat", nil)
+}
+
+// ImportCards handler untuk import card produk dari file CSV/XLSX
+// @Summary Import catalog cards
+// @Description Import card produk dari file CSV atau XLSX dengan kolom title, price, discount, url, image_url (subtitle opsional). Baris yang gagal validasi dilaporkan per baris
+// @Tags catalogs
+// @Accept multipart/form-data
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param file formData file true "File CSV/XLSX"
+// @Success 201 {object} utils.Response{data=dto.CardImportResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 413 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/cards/import [post]
+func (h *CatalogHandler) ImportCards(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get section ID from param
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	// Get file from form
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequest(c, constant.ErrMsgFileRequired)
+		return
+	}
+	if file.Size > constant.CardImportMaxFileSize {
+		utils.Error(c, http.StatusRequestEntityTooLarge, constant.ErrMsgFileTooLarge)
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		utils.BadRequest(c, constant.ErrMsgCardImportUnreadable)
+		return
+	}
+	defer src.Close()
+
+	result, err := h.catalogUC.ImportCards(c, sectionID, profileID, file.Filename, io.LimitReader(src, constant.CardImportMaxFileSize))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Import card selesai", result)
+}
+
+// GetCard handler untuk get card by ID
+// @Summary Get catalog card
+// @Description Get card data untuk editor, header ETag dipakai sebagai If-Match saat update
+// @Tags catalogs
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 200 {object} utils.Response{data=dto.CardResponse}
+// @Success 304
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id} [get]
+func (h *CatalogHandler) GetCard(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	card, err := h.catalogUC.GetCard(c, cardID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OKWithETag(c, "Data card berhasil diambil", card)
+}
+
+// UpdateCard handler untuk update card
+// @Summary Update catalog card
+// @Description Update card data
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param If-Match header string false "ETag dari GET /catalogs/cards/{card_id}, update ditolak jika card sudah berubah"
+// @Param If-Unmodified-Since header string false "HTTP date updated_at saat dibaca, 409 jika card sudah berubah setelahnya"
+// @Param body body dto.UpdateCardRequest true "Update data"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 412 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id} [put]
+func (h *CatalogHandler) UpdateCard(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.UpdateCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	req.UnmodifiedSince = utils.IfUnmodifiedSince(c)
+
+	// Tolak update jika card sudah berubah sejak dibaca klien
+	if utils.HasIfMatch(c) {
+		current, err := h.catalogUC.GetCard(c, cardID, profileID)
+		if err != nil {
+			h.handleError(c, err)
+			return
+		}
+		if !utils.IfMatch(c, current) {
+			utils.PreconditionFailed(c, constant.ErrMsgPreconditionFailed)
+			return
+		}
+	}
+
+	// Update card
+	if err := h.catalogUC.UpdateCard(c, cardID, profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Card berhasil diperbarui", nil)
+}
+
+// DeleteCard handler untuk delete card
+// @Summary Delete catalog card
+// @Description Delete card from section
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id} [delete]
+func (h *CatalogHandler) DeleteCard(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	// Delete card
+	if err := h.catalogUC.DeleteCard(c, cardID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// MoveCard handler untuk pindah posisi card
+// @Summary Move catalog card
+// @Description Pindahkan card tepat setelah card after_id dalam section yang sama, after_id 0 = paling atas
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param body body dto.MovePositionRequest true "Posisi tujuan"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/position [put]
+func (h *CatalogHandler) MoveCard(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.MovePositionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	if err := h.catalogUC.MoveCard(c, cardID, profileID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Posisi card berhasil diubah", nil)
+}
+
+// UploadCardImage handler untuk upload card image
+// @Summary Upload card image
+// @Description Upload image for catalog card
+// @Tags catalogs
+// @Accept multipart/form-data
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param file formData file true "Image file"
+// @Success 200 {object} utils.Response{data=service.FileInfo}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 413 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/images [post]
+func (h *CatalogHandler) UploadCardImage(c *gin.Context) {
+	// Get profile ID from context
+	_, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	// Get file from form
+	file, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequest(c, constant.ErrMsgFileRequired)
+		return
+	}
+
+	// Validate file
+	if err := h.uploadService.ValidateFile(file); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Upload file
+	folder := fmt.Sprintf("catalogs/cards/%d", cardID)
+	fileInfo, err := service.ProcessUpload(
+		file,
+		folder,
+		h.uploadService,
+		c.Request.Host,
+	)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// TODO: Save media URL to database
+
+	utils.OK(c, "Gambar berhasil diupload", fileInfo)
+}
+
+// GetAffiliateEarnings handler untuk laporan estimasi komisi affiliate
+// @Summary Get affiliate earnings
+// @Description Get estimated affiliate commissions per card per month
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param from query string false "Start month (YYYY-MM), default 11 bulan lalu"
+// @Param to query string false "End month (YYYY-MM), default bulan ini"
+// @Success 200 {object} utils.Response{data=[]dto.AffiliateEarningResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/{id}/affiliate-earnings [get]
+func (h *CatalogHandler) GetAffiliateEarnings(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	// Parse periode (bulan), default 12 bulan terakhir
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	from := to.AddDate(0, -11, 0)
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.ParseInLocation("2006-01", fromStr, time.Local)
+		if err != nil {
+			utils.BadRequest(c, "Format bulan awal tidak valid (YYYY-MM)")
+			return
+		}
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.ParseInLocation("2006-01", toStr, time.Local)
+		if err != nil {
+			utils.BadRequest(c, "Format bulan akhir tidak valid (YYYY-MM)")
+			return
+		}
+	}
+
+	// Get earnings (to bersifat inklusif sampai akhir bulan)
+	earnings, err := h.catalogUC.GetAffiliateEarnings(c, catalogID, profileID, from, to.AddDate(0, 1, 0))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data komisi affiliate berhasil diambil", earnings)
+}
+
+// CreateCheckoutLink handler untuk generate checkout link card
+// @Summary Create checkout link
+// @Description Generate payment link yang bisa dibagikan, nominal diambil dari harga card setelah diskon
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param body body dto.CreateCheckoutLinkRequest false "Checkout data"
+// @Success 201 {object} utils.Response{data=dto.CheckoutLinkResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/checkout-link [post]
+func (h *CatalogHandler) CreateCheckoutLink(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	// Body opsional, default quantity 1
+	var req dto.CreateCheckoutLinkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, "Format request tidak valid")
+			return
+		}
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	// Create checkout link
+	link, err := h.catalogUC.CreateCheckoutLink(c, cardID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Checkout link berhasil dibuat", link)
+}
+
+// ListCardLinks handler untuk daftar link detail card
+// @Summary List card links
+// @Description Link (marketplace, WhatsApp, website, dll) pada halaman detail card
+// @Tags cards
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 200 {object} utils.Response{data=[]dto.LinkResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/links [get]
+func (h *CatalogHandler) ListCardLinks(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	links, err := h.catalogUC.ListCardLinks(c, cardID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Link card berhasil diambil", links)
+}
+
+// CreateCardLink handler untuk menambah link detail card
+// @Summary Create card link
+// @Description Tambah link pada halaman detail card, card harus sudah punya detail
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param body body dto.LinkRequest true "Link data"
+// @Success 201 {object} utils.Response{data=dto.LinkResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/links [post]
+func (h *CatalogHandler) CreateCardLink(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	var req dto.LinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	link, err := h.catalogUC.CreateCardLink(c, cardID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Link card berhasil ditambahkan", link)
+}
+
+// UpdateCardLink handler untuk update link detail card
+// @Summary Update card link
+// @Description Update tipe, URL atau visibilitas link card
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param link_id path int true "Link ID"
+// @Param body body dto.UpdateLinkRequest true "Link data"
+// @Success 200 {object} utils.Response{data=dto.LinkResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/links/{link_id} [put]
+func (h *CatalogHandler) UpdateCardLink(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	linkID, err := strconv.ParseInt(c.Param("link_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID link tidak valid")
+		return
+	}
+
+	var req dto.UpdateLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	link, err := h.catalogUC.UpdateCardLink(c, cardID, linkID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Link card berhasil diperbarui", link)
+}
+
+// DeleteCardLink handler untuk menghapus link detail card
+// @Summary Delete card link
+// @Description Hapus link dari halaman detail card
+// @Tags cards
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param link_id path int true "Link ID"
+// @Success 204
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/links/{link_id} [delete]
+func (h *CatalogHandler) DeleteCardLink(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	linkID, err := strconv.ParseInt(c.Param("link_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID link tidak valid")
+		return
+	}
+
+	if err := h.catalogUC.DeleteCardLink(c, cardID, linkID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// SchedulePrice handler untuk menjadwalkan perubahan harga card
+// @Summary Schedule card price change
+// @Description Jadwalkan harga (dan diskon) baru card yang diterapkan otomatis pada effective_at
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param body body dto.SchedulePriceRequest true "Harga baru dan waktu berlaku (RFC3339)"
+// @Success 201 {object} utils.Response{data=dto.PriceScheduleResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/price-schedules [post]
+func (h *CatalogHandler) SchedulePrice(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	var req dto.SchedulePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	schedule, err := h.catalogUC.SchedulePrice(c, cardID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Jadwal harga berhasil disimpan", schedule)
+}
+
+// ListPriceSchedules handler untuk daftar jadwal harga card
+// @Summary List card price schedules
+// @Description Jadwal harga card yang pending, sudah diterapkan dan dibatalkan
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Success 200 {object} utils.Response{data=[]dto.PriceScheduleResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/price-schedules [get]
+func (h *CatalogHandler) ListPriceSchedules(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	schedules, err := h.catalogUC.ListPriceSchedules(c, cardID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Jadwal harga berhasil diambil", schedules)
+}
+
+// CancelPriceSchedule handler untuk membatalkan jadwal harga card
+// @Summary Cancel card price schedule
+// @Description Batalkan jadwal harga yang belum diterapkan
+// @Tags cards
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param schedule_id path int true "Schedule ID"
+// @Success 204
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/price-schedules/{schedule_id} [delete]
+func (h *CatalogHandler) CancelPriceSchedule(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	scheduleID, err := strconv.ParseInt(c.Param("schedule_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID jadwal tidak valid")
+		return
+	}
+
+	if err := h.catalogUC.CancelPriceSchedule(c, cardID, scheduleID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// PaymentCallback handler untuk callback status pembayaran dari gateway
+// @Summary Payment callback
+// @Description Callback invoice dari payment gateway, diverifikasi dengan header X-Callback-Token
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param X-Callback-Token header string true "Callback token"
+// @Param body body dto.PaymentCallbackRequest true "Callback payload"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /payments/callback [post]
+func (h *CatalogHandler) PaymentCallback(c *gin.Context) {
+	var req dto.PaymentCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	if err := h.catalogUC.HandlePaymentCallback(c, c.GetHeader("X-Callback-Token"), &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Callback pembayaran diterima", nil)
+}
+
+// Heartbeat handler untuk heartbeat presence editor
+// @Summary Presence heartbeat
+// @Description Tandai editor sedang membuka katalog (kirim berkala sebelum TTL habis), mengembalikan editor yang aktif
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=[]dto.PresenceResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /catalogs/{id}/presence [post]
+func (h *CatalogHandler) Heartbeat(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	editors, err := h.catalogUC.Heartbeat(c, catalogID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Presence berhasil diperbarui", editors)
+}
+
+// ListPresence handler untuk daftar editor yang sedang aktif
+// @Summary List active editors
+// @Description Daftar editor yang sedang membuka katalog
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=[]dto.PresenceResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /catalogs/{id}/presence [get]
+func (h *CatalogHandler) ListPresence(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	editors, err := h.catalogUC.ListPresence(c, catalogID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Daftar editor aktif berhasil diambil", editors)
+}
+
+// SubmitPublishRequest handler untuk mengajukan publish katalog
+// @Summary Submit publish request
+// @Description Ajukan katalog draft untuk direview, status katalog menjadi in_review
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param body body dto.SubmitPublishRequest false "Catatan untuk reviewer"
+// @Success 201 {object} utils.Response{data=dto.PublishRequestResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/{id}/publish-requests [post]
+func (h *CatalogHandler) SubmitPublishRequest(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	// Body opsional
+	var req dto.SubmitPublishRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, "Format request tidak valid")
+			return
+		}
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	request, err := h.catalogUC.SubmitPublishRequest(c, catalogID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Katalog berhasil diajukan untuk review", request)
+}
+
+// ListPublishRequests handler untuk riwayat pengajuan publish
+// @Summary List publish requests
+// @Description Riwayat pengajuan publish katalog, terbaru dulu
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=[]dto.PublishRequestResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/publish-requests [get]
+func (h *CatalogHandler) ListPublishRequests(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	requests, err := h.catalogUC.ListPublishRequests(c, catalogID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Riwayat pengajuan publish berhasil diambil", requests)
+}
+
+// ApprovePublishRequest handler untuk menyetujui pengajuan publish, katalog langsung published
+// @Summary Approve publish request
+// @Description Menyetujui pengajuan publish, katalog langsung published
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param request_id path int true "Publish request ID"
+// @Param body body dto.ReviewPublishRequest false "Komentar review"
+// @Success 200 {object} utils.Response{data=dto.PublishRequestResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/publish-requests/{request_id}/approve [post]
+func (h *CatalogHandler) ApprovePublishRequest(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get request ID from param
+	requestID, err := strconv.ParseInt(c.Param("request_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pengajuan tidak valid")
+		return
+	}
+
+	var req dto.ReviewPublishRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, "Format request tidak valid")
+			return
+		}
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	request, err := h.catalogUC.ApprovePublishRequest(c, requestID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Katalog berhasil dipublish", request)
+}
+
+// RequestPublishChanges handler untuk meminta perubahan pada pengajuan publish, katalog kembali ke draft
+// @Summary Request changes on publish request
+// @Description Meminta perubahan pada pengajuan publish, katalog kembali ke draft
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param request_id path int true "Publish request ID"
+// @Param body body dto.ReviewPublishRequest true "Komentar review"
+// @Success 200 {object} utils.Response{data=dto.PublishRequestResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/publish-requests/{request_id}/request-changes [post]
+func (h *CatalogHandler) RequestPublishChanges(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get request ID from param
+	requestID, err := strconv.ParseInt(c.Param("request_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pengajuan tidak valid")
+		return
+	}
+
+	var req dto.ReviewPublishRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.BadRequest(c, "Format request tidak valid")
+			return
+		}
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	request, err := h.catalogUC.RequestPublishChanges(c, requestID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Permintaan perubahan berhasil dikirim", request)
+}
+
+// SchedulePublish handler untuk menjadwalkan publish katalog
+// @Summary Schedule catalog publish
+// @Description Jadwalkan katalog draft untuk dipublish otomatis pada waktu tertentu
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param body body dto.SchedulePublishRequest true "Waktu publish (RFC3339)"
+// @Success 200 {object} utils.Response{data=dto.CatalogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/{id}/publish-schedule [put]
+func (h *CatalogHandler) SchedulePublish(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	var req dto.SchedulePublishRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	catalog, err := h.catalogUC.SchedulePublish(c, catalogID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Jadwal publish berhasil disimpan", catalog)
+}
+
+// CancelPublishSchedule handler untuk membatalkan jadwal publish
+// @Summary Cancel scheduled publish
+// @Description Batalkan jadwal publish katalog
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=dto.CatalogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/publish-schedule [delete]
+func (h *CatalogHandler) CancelPublishSchedule(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	catalog, err := h.catalogUC.CancelPublishSchedule(c, catalogID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Jadwal publish berhasil dibatalkan", catalog)
+}
+
+// ScheduleCatalogVisibility handler untuk menjadwalkan tampil/sembunyi katalog
+// @Summary Schedule catalog visibility
+// @Description Jadwalkan katalog tampil (publish_at) dan/atau disembunyikan (unpublish_at) otomatis lewat is_active. Dengan publish_at katalog langsung disembunyikan sampai waktunya tiba. Jadwal lama diganti
+// @Tags catalogs
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param body body dto.VisibilityScheduleRequest true "Jadwal (RFC3339)"
+// @Success 200 {object} utils.Response{data=dto.CatalogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/visibility-schedule [put]
+func (h *CatalogHandler) ScheduleCatalogVisibility(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	var req dto.VisibilityScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	catalog, err := h.catalogUC.ScheduleCatalogVisibility(c, catalogID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Jadwal tampil katalog berhasil disimpan", catalog)
+}
+
+// CancelCatalogVisibilitySchedule handler untuk membatalkan jadwal tampil/sembunyi katalog
+// @Summary Cancel catalog visibility schedule
+// @Description Hapus jadwal tampil/sembunyi katalog, is_active tidak diubah
+// @Tags catalogs
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=dto.CatalogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/visibility-schedule [delete]
+func (h *CatalogHandler) CancelCatalogVisibilitySchedule(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
 		return
 	}
 
-	// Update catalog
-	catalog, err := h.catalogUC.Update(c, id, profileID, &req)
+	catalog, err := h.catalogUC.CancelCatalogVisibilitySchedule(c, catalogID, profileID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.OK(c, "Katalog berhasil diperbarui", catalog)
+	utils.OK(c, "Jadwal tampil katalog berhasil dibatalkan", catalog)
 }
 
-// Delete handler untuk delete catalog
-// @Summary Delete catalog
-// @Description Soft delete catalog
+// ScheduleCardVisibility handler untuk menjadwalkan tampil/sembunyi card
+// @Summary Schedule card visibility
+// @Description Jadwalkan card tampil (publish_at) dan/atau disembunyikan (unpublish_at) otomatis lewat is_visible, mis. untuk penawaran terbatas. Dengan publish_at card langsung disembunyikan sampai waktunya tiba. Jadwal lama diganti
 // @Tags catalogs
 // @Accept json
 // @Produce json
-// @Param id path int true "Catalog ID"
-// @Success 204 {object} nil
+// @Param card_id path int true "Card ID"
+// @Param body body dto.VisibilityScheduleRequest true "Jadwal (RFC3339)"
+// @Success 200 {object} utils.Response{data=dto.CardResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/{id} [delete]
-func (h *CatalogHandler) Delete(c *gin.Context) {
+// @Router /catalogs/cards/{card_id}/visibility-schedule [put]
+func (h *CatalogHandler) ScheduleCardVisibility(c *gin.Context) {
 	// Get profile ID from context
 	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
@@ -274,67 +2948,79 @@ func (h *CatalogHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	// Get catalog ID from param
-	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
 	if err != nil {
-		utils.BadRequest(c, "ID katalog tidak valid")
+		utils.BadRequest(c, "ID card tidak valid")
 		return
 	}
 
-	// Delete catalog
-	if err := h.catalogUC.Delete(c, id, profileID); err != nil {
+	var req dto.VisibilityScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	card, err := h.catalogUC.ScheduleCardVisibility(c, cardID, profileID, &req)
+	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.NoContent(c)
+	utils.OK(c, "Jadwal tampil card berhasil disimpan", card)
 }
 
-// GetPublicCatalog handler untuk get public catalog by slug
-// @Summary Get public catalog
-// @Description Get public catalog by slug
+// CancelCardVisibilitySchedule handler untuk membatalkan jadwal tampil/sembunyi card
+// @Summary Cancel card visibility schedule
+// @Description Hapus jadwal tampil/sembunyi card, is_visible tidak diubah
 // @Tags catalogs
-// @Accept json
 // @Produce json
-// @Param slug path string true "Catalog slug"
-// @Success 200 {object} utils.Response{data=dto.PublicCatalogResponse}
+// @Param card_id path int true "Card ID"
+// @Success 200 {object} utils.Response{data=dto.CardResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /c/{slug} [get]
-func (h *CatalogHandler) GetPublicCatalog(c *gin.Context) {
-	// Get slug from param
-	slug := c.Param("slug")
-	if slug == "" {
-		utils.BadRequest(c, "Slug katalog tidak valid")
+// @Router /catalogs/cards/{card_id}/visibility-schedule [delete]
+func (h *CatalogHandler) CancelCardVisibilitySchedule(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
 		return
 	}
 
-	// Get public catalog
-	catalog, err := h.catalogUC.GetBySlug(slug)
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	card, err := h.catalogUC.CancelCardVisibilitySchedule(c, cardID, profileID)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.OK(c, "Data katalog berhasil diambil", catalog)
+	utils.OK(c, "Jadwal tampil card berhasil dibatalkan", card)
 }
 
-// CreateSection handler untuk create section
-// @Summary Create catalog section
-// @Description Create new section in catalog
+// UpdateCardStock handler untuk mengatur stok card
+// @Summary Update card stock
+// @Description Atur stok card. stock null = stok tidak dilacak, sold_out menandai habis manual. Card dengan stok 0 atau sold_out tampil dengan badge habis, atau disembunyikan dari publik jika hide_when_sold_out aktif
 // @Tags catalogs
 // @Accept json
 // @Produce json
-// @Param id path int true "Catalog ID"
-// @Param body body dto.CreateSectionRequest true "Section data"
-// @Success 201 {object} utils.Response
+// @Param card_id path int true "Card ID"
+// @Param body body dto.CardStockRequest true "Pengaturan stok"
+// @Success 200 {object} utils.Response{data=dto.CardResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/{id}/sections [post]
-func (h *CatalogHandler) CreateSection(c *gin.Context) {
+// @Router /catalogs/cards/{card_id}/stock [put]
+func (h *CatalogHandler) UpdateCardStock(c *gin.Context) {
 	// Get profile ID from context
 	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
@@ -342,51 +3028,48 @@ func (h *CatalogHandler) CreateSection(c *gin.Context) {
 		return
 	}
 
-	// Get catalog ID from param
-	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
 	if err != nil {
-		utils.BadRequest(c, "ID katalog tidak valid")
+		utils.BadRequest(c, "ID card tidak valid")
 		return
 	}
 
-	// Bind request
-	var req dto.CreateSectionRequest
+	var req dto.CardStockRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		utils.BadRequest(c, "Format request tidak valid")
 		return
 	}
 
-	// Validate request
 	if errors := h.validator.Validate(req); len(errors) > 0 {
 		utils.ValidationError(c, errors)
 		return
 	}
 
-	// Create section
-	if err := h.catalogUC.CreateSection(catalogID, profileID, &req); err != nil {
+	card, err := h.catalogUC.UpdateCardStock(c, cardID, profileID, &req)
+	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.Created(c, "Section berhasil dibuat", nil)
+	utils.OK(c, "Stok card berhasil disimpan", card)
 }
 
-// UpdateSection handler untuk update section
-// @Summary Update catalog section
-// @Description Update section data
+// AdjustCardStock handler untuk menambah/mengurangi stok card
+// @Summary Adjust card stock
+// @Description Tambah (delta positif) atau kurangi (delta negatif) stok card yang dilacak secara atomik. Pengurangan melebihi sisa stok ditolak
 // @Tags catalogs
 // @Accept json
 // @Produce json
-// @Param section_id path int true "Section ID"
-// @Param body body dto.UpdateSectionRequest true "Update data"
-// @Success 200 {object} utils.Response
+// @Param card_id path int true "Card ID"
+// @Param body body dto.AdjustCardStockRequest true "Perubahan stok"
+// @Success 200 {object} utils.Response{data=dto.CardResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/sections/{section_id} [put]
-func (h *CatalogHandler) UpdateSection(c *gin.Context) {
+// @Router /catalogs/cards/{card_id}/stock/adjust [post]
+func (h *CatalogHandler) AdjustCardStock(c *gin.Context) {
 	// Get profile ID from context
 	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
@@ -394,50 +3077,46 @@ func (h *CatalogHandler) UpdateSection(c *gin.Context) {
 		return
 	}
 
-	// Get section ID from param
-	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	// Get card ID from param
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
 	if err != nil {
-		utils.BadRequest(c, "ID section tidak valid")
+		utils.BadRequest(c, "ID card tidak valid")
 		return
 	}
 
-	// Bind request
-	var req dto.UpdateSectionRequest
+	var req dto.AdjustCardStockRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		utils.BadRequest(c, "Format request tidak valid")
 		return
 	}
 
-	// Validate request
 	if errors := h.validator.Validate(req); len(errors) > 0 {
 		utils.ValidationError(c, errors)
 		return
 	}
 
-	// Update section
-	if err := h.catalogUC.UpdateSection(c, sectionID, profileID, &req); err != nil {
+	card, err := h.catalogUC.AdjustCardStock(c, cardID, profileID, &req)
+	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.OK(c, "Section berhasil diperbarui", nil)
+	utils.OK(c, "Stok card berhasil diubah", card)
 }
 
-// DeleteSection handler untuk delete section
-// @Summary Delete catalog section
-// @Description Delete section from catalog
+// ListTags handler untuk daftar tag katalog
+// @Summary List catalog tags
+// @Description Daftar tag katalog urut nama beserta jumlah card yang memakainya
 // @Tags catalogs
-// @Accept json
 // @Produce json
-// @Param section_id path int true "Section ID"
-// @Success 204 {object} nil
+// @Param id path int true "Catalog ID"
+// @Success 200 {object} utils.Response{data=[]dto.TagResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/sections/{section_id} [delete]
-func (h *CatalogHandler) DeleteSection(c *gin.Context) {
+// @Router /catalogs/{id}/tags [get]
+func (h *CatalogHandler) ListTags(c *gin.Context) {
 	// Get profile ID from context
 	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
@@ -445,38 +3124,38 @@ func (h *CatalogHandler) DeleteSection(c *gin.Context) {
 		return
 	}
 
-	// Get section ID from param
-	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	// Get catalog ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		utils.BadRequest(c, "ID section tidak valid")
+		utils.BadRequest(c, "ID katalog tidak valid")
 		return
 	}
 
-	// Delete section
-	if err := h.catalogUC.DeleteSection(c, sectionID, profileID); err != nil {
+	tags, err := h.catalogUC.ListTags(c, id, profileID)
+	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.NoContent(c)
+	utils.OK(c, "Tag katalog berhasil diambil", tags)
 }
 
-// CreateCard handler untuk create card
-// @Summary Create catalog card
-// @Description Create new card in section
+// CreateTag handler untuk membuat tag katalog
+// @Summary Create catalog tag
+// @Description Buat tag untuk mengelompokkan card. Slug dipakai di filter publik (?tag=), default dari nama
 // @Tags catalogs
 // @Accept json
 // @Produce json
-// @Param section_id path int true "Section ID"
-// @Param body body dto.CreateCardRequest true "Card data"
-// @Success 201 {object} utils.Response
+// @Param id path int true "Catalog ID"
+// @Param body body dto.CreateTagRequest true "Tag data"
+// @Success 201 {object} utils.Response{data=dto.TagResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/sections/{section_id}/cards [post]
-func (h *CatalogHandler) CreateCard(c *gin.Context) {
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/{id}/tags [post]
+func (h *CatalogHandler) CreateTag(c *gin.Context) {
 	// Get profile ID from context
 	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
@@ -484,51 +3163,49 @@ func (h *CatalogHandler) CreateCard(c *gin.Context) {
 		return
 	}
 
-	// Get section ID from param
-	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	// Get catalog ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		utils.BadRequest(c, "ID section tidak valid")
+		utils.BadRequest(c, "ID katalog tidak valid")
 		return
 	}
 
-	// Bind request
-	var req dto.CreateCardRequest
+	var req dto.CreateTagRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		utils.BadRequest(c, "Format request tidak valid")
 		return
 	}
 
-	// Validate request
 	if errors := h.validator.Validate(req); len(errors) > 0 {
 		utils.ValidationError(c, errors)
 		return
 	}
 
-	// Create card
-	if err := h.catalogUC.CreateCard(sectionID, profileID, &req); err != nil {
+	tag, err := h.catalogUC.CreateTag(c, id, profileID, &req)
+	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.Created(c, "Card berhasil dibuat", nil)
+	utils.Created(c, "Tag berhasil dibuat", tag)
 }
 
-// UpdateCard handler untuk update card
-// @Summary Update catalog card
-// @Description Update card data
+// UpdateTag handler untuk update tag katalog
+// @Summary Update catalog tag
+// @Description Ubah nama dan/atau slug tag. Slug tidak ikut berubah saat nama diubah
 // @Tags catalogs
 // @Accept json
 // @Produce json
-// @Param card_id path int true "Card ID"
-// @Param body body dto.UpdateCardRequest true "Update data"
-// @Success 200 {object} utils.Response
+// @Param tag_id path int true "Tag ID"
+// @Param body body dto.UpdateTagRequest true "Tag data"
+// @Success 200 {object} utils.Response{data=dto.TagResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/cards/{card_id} [put]
-func (h *CatalogHandler) UpdateCard(c *gin.Context) {
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/tags/{tag_id} [put]
+func (h *CatalogHandler) UpdateTag(c *gin.Context) {
 	// Get profile ID from context
 	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
@@ -536,50 +3213,46 @@ func (h *CatalogHandler) UpdateCard(c *gin.Context) {
 		return
 	}
 
-	// Get card ID from param
-	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	// Get tag ID from param
+	tagID, err := strconv.ParseInt(c.Param("tag_id"), 10, 64)
 	if err != nil {
-		utils.BadRequest(c, "ID card tidak valid")
+		utils.BadRequest(c, "ID tag tidak valid")
 		return
 	}
 
-	// Bind request
-	var req dto.UpdateCardRequest
+	var req dto.UpdateTagRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		utils.BadRequest(c, "Format request tidak valid")
 		return
 	}
 
-	// Validate request
 	if errors := h.validator.Validate(req); len(errors) > 0 {
 		utils.ValidationError(c, errors)
 		return
 	}
 
-	// Update card
-	if err := h.catalogUC.UpdateCard(c, cardID, profileID, &req); err != nil {
+	tag, err := h.catalogUC.UpdateTag(c, tagID, profileID, &req)
+	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	utils.OK(c, "Card berhasil diperbarui", nil)
+	utils.OK(c, "Tag berhasil diupdate", tag)
 }
 
-// DeleteCard handler untuk delete card
-// @Summary Delete catalog card
-// @Description Delete card from section
+// DeleteTag handler untuk hapus tag katalog
+// @Summary Delete catalog tag
+// @Description Hapus tag, card yang memakainya kehilangan tag tersebut
 // @Tags catalogs
-// @Accept json
 // @Produce json
-// @Param card_id path int true "Card ID"
-// @Success 204 {object} nil
+// @Param tag_id path int true "Tag ID"
+// @Success 204
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/cards/{card_id} [delete]
-func (h *CatalogHandler) DeleteCard(c *gin.Context) {
+// @Router /catalogs/tags/{tag_id} [delete]
+func (h *CatalogHandler) DeleteTag(c *gin.Context) {
 	// Get profile ID from context
 	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
@@ -587,15 +3260,14 @@ func (h *CatalogHandler) DeleteCard(c *gin.Context) {
 		return
 	}
 
-	// Get card ID from param
-	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	// Get tag ID from param
+	tagID, err := strconv.ParseInt(c.Param("tag_id"), 10, 64)
 	if err != nil {
-		utils.BadRequest(c, "ID card tidak valid")
+		utils.BadRequest(c, "ID tag tidak valid")
 		return
 	}
 
-	// Delete card
-	if err := h.catalogUC.DeleteCard(c, cardID, profileID); err != nil {
+	if err := h.catalogUC.DeleteTag(c, tagID, profileID); err != nil {
 		h.handleError(c, err)
 		return
 	}
@@ -603,25 +3275,23 @@ func (h *CatalogHandler) DeleteCard(c *gin.Context) {
 	utils.NoContent(c)
 }
 
-// UploadCardImage handler untuk upload card image
-// @Summary Upload card image
-// @Description Upload image for catalog card
+// SetCardTags handler untuk mengganti tag card
+// @Summary Set card tags
+// @Description Ganti seluruh tag card dengan tag_ids, [] menghapus semua tag. Tag harus milik katalog yang sama dengan card
 // @Tags catalogs
-// @Accept multipart/form-data
+// @Accept json
 // @Produce json
 // @Param card_id path int true "Card ID"
-// @Param file formData file true "Image file"
-// @Success 200 {object} utils.Response{data=service.FileInfo}
+// @Param body body dto.SetCardTagsRequest true "Tag card"
+// @Success 200 {object} utils.Response{data=dto.CardResponse}
 // @Failure 400 {object} utils.Response
 // @Failure 401 {object} utils.Response
 // @Failure 403 {object} utils.Response
 // @Failure 404 {object} utils.Response
-// @Failure 413 {object} utils.Response
-// @Failure 500 {object} utils.Response
-// @Router /catalogs/cards/{card_id}/images [post]
-func (h *CatalogHandler) UploadCardImage(c *gin.Context) {
+// @Router /catalogs/cards/{card_id}/tags [put]
+func (h *CatalogHandler) SetCardTags(c *gin.Context) {
 	// Get profile ID from context
-	_, exists := middleware.GetProfileID(c)
+	profileID, exists := middleware.GetProfileID(c)
 	if !exists {
 		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
 		return
@@ -634,39 +3304,71 @@ func (h *CatalogHandler) UploadCardImage(c *gin.Context) {
 		return
 	}
 
-	// Get file from form
-	file, err := c.FormFile("file")
-	if err != nil {
-		utils.BadRequest(c, constant.ErrMsgFileRequired)
+	var req dto.SetCardTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
 		return
 	}
 
-	// Validate file
-	if err := h.uploadService.ValidateFile(file); err != nil {
-		h.handleError(c, err)
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
 		return
 	}
 
-	// Upload file
-	folder := fmt.Sprintf("catalogs/cards/%d", cardID)
-	fileInfo, err := service.ProcessUpload(
-		file,
-		folder,
-		h.uploadService,
-		c.Request.Host,
-	)
+	card, err := h.catalogUC.SetCardTags(c, cardID, profileID, &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
 	}
 
-	// TODO: Save media URL to database
+	utils.OK(c, "Tag card berhasil disimpan", card)
+}
 
-	utils.OK(c, "Gambar berhasil diupload", fileInfo)
+// ListCards handler untuk daftar card katalog
+// @Summary List catalog cards
+// @Description Semua card katalog sesuai urutan section untuk dashboard, dapat difilter slug tag
+// @Tags catalogs
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param tag query string false "Slug tag"
+// @Success 200 {object} utils.Response{data=[]dto.CardResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/cards [get]
+func (h *CatalogHandler) ListCards(c *gin.Context) {
+	// Get profile ID from context
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	// Get catalog ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	cards, err := h.catalogUC.ListCards(c, id, profileID, c.Query("tag"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data card berhasil diambil", cards)
 }
 
 // handleError menangani error dari use case
 func (h *CatalogHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, catalogNotFoundMessage(c))
+		return
+	}
+
 	// Check if AppError
 	if appErr, ok := err.(*errors.AppError); ok {
 		utils.Error(c, appErr.StatusCode, appErr.Message)
@@ -696,4 +3398,31 @@ func (h *CatalogHandler) handleError(c *gin.Context, err error) {
 	default:
 		utils.InternalServerError(c, constant.ErrMsgInternalServer)
 	}
-}
\ No newline at end of file
+}
+// catalogNotFoundMessage pesan 404 sesuai resource yang diminta route,
+// sama persis dengan pesan ketika resource pertama yang dicari use case
+// memang tidak ada. Setiap param route harus punya case sendiri
+func catalogNotFoundMessage(c *gin.Context) string {
+	switch {
+	case c.Param("schedule_id") != "":
+		// Jadwal dicari lebih dulu dari card
+		return constant.ErrMsgPriceScheduleNotFound
+	case c.Param("link_id") != "":
+		// Card dicari lebih dulu, link baru dicari setelah akses dicek
+		return constant.ErrMsgCardNotFound
+	case c.Param("card_id") != "":
+		return constant.ErrMsgCardNotFound
+	case c.Param("faq_id") != "":
+		return constant.ErrMsgFAQNotFound
+	case c.Param("tag_id") != "":
+		return constant.ErrMsgTagNotFound
+	case c.Param("section_id") != "":
+		return constant.ErrMsgSectionNotFound
+	case c.Param("request_id") != "":
+		return constant.ErrMsgPublishRequestNotFound
+	case strings.Contains(c.FullPath(), "/businesses/"):
+		return constant.ErrMsgBusinessNotFound
+	default:
+		return constant.ErrMsgCatalogNotFound
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// ClockHandler handler time-travel clock untuk QA alur kedaluwarsa di sandbox (admin)
+type ClockHandler struct {
+	clock *service.OffsetClock
+}
+
+// NewClockHandler membuat instance clock handler baru, clock nil berarti time-travel nonaktif
+func NewClockHandler(clock *service.OffsetClock) *ClockHandler {
+	return &ClockHandler{
+		clock: clock,
+	}
+}
+
+// Enabled check apakah rute time-travel perlu didaftarkan
+func (h *ClockHandler) Enabled() bool {
+	return h.clock != nil
+}
+
+// GetClock handler untuk melihat waktu dan offset clock aplikasi
+// @Summary Get clock
+// @Description Waktu yang dipakai use case dan scheduler beserta offset time-travel terhadap waktu sistem
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} utils.Response{data=service.ClockStatus}
+// @Failure 401 {object} utils.Response
+// @Router /admin/clock [get]
+func (h *ClockHandler) GetClock(c *gin.Context) {
+	utils.OK(c, "Clock berhasil diambil", h.clock.Status())
+}
+
+// SetClockOffset handler untuk mengubah offset clock
+// @Summary Set clock offset
+// @Description Majukan atau mundurkan waktu aplikasi untuk QA kedaluwarsa undangan, langganan, jadwal harga dan publish. Hanya tersedia jika CLOCK_TIME_TRAVEL_ENABLED dan server bukan mode release
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param request body service.ClockOffsetRequest true "Offset clock"
+// @Success 200 {object} utils.Response{data=service.ClockStatus}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /admin/clock [put]
+func (h *ClockHandler) SetClockOffset(c *gin.Context) {
+	var req service.ClockOffsetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	offset, err := time.ParseDuration(req.Offset)
+	if err != nil {
+		utils.BadRequest(c, constant.ErrMsgClockOffsetInvalid)
+		return
+	}
+
+	h.clock.SetOffset(offset)
+	utils.OK(c, "Offset clock berhasil diubah", h.clock.Status())
+}
+
+// ResetClock handler untuk mengembalikan clock ke waktu sistem
+// @Summary Reset clock
+// @Description Hapus offset time-travel sehingga clock kembali mengikuti waktu sistem
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} utils.Response{data=service.ClockStatus}
+// @Failure 401 {object} utils.Response
+// @Router /admin/clock [delete]
+func (h *ClockHandler) ResetClock(c *gin.Context) {
+	h.clock.SetOffset(0)
+	utils.OK(c, "Clock berhasil direset", h.clock.Status())
+}
+
+// GetBusinessClock handler untuk melihat waktu yang berlaku untuk satu business
+// @Summary Get business clock
+// @Description Waktu dan offset yang dipakai untuk business, offset global berlaku jika business tidak punya offset sendiri
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=service.ClockStatus}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /admin/clock/businesses/{id} [get]
+func (h *ClockHandler) GetBusinessClock(c *gin.Context) {
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	utils.OK(c, "Clock berhasil diambil", h.clock.BusinessStatus(businessID))
+}
+
+// SetBusinessClockOffset handler untuk mengubah offset clock satu business
+// @Summary Set business clock offset
+// @Description Time-travel untuk satu business sandbox tanpa memengaruhi business lain. Offset business menggantikan offset global
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Business ID"
+// @Param request body service.ClockOffsetRequest true "Offset clock"
+// @Success 200 {object} utils.Response{data=service.ClockStatus}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /admin/clock/businesses/{id} [put]
+func (h *ClockHandler) SetBusinessClockOffset(c *gin.Context) {
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var req service.ClockOffsetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	offset, err := time.ParseDuration(req.Offset)
+	if err != nil {
+		utils.BadRequest(c, constant.ErrMsgClockOffsetInvalid)
+		return
+	}
+
+	h.clock.SetBusinessOffset(businessID, offset)
+	utils.OK(c, "Offset clock berhasil diubah", h.clock.BusinessStatus(businessID))
+}
+
+// ResetBusinessClock handler untuk menghapus offset clock satu business
+// @Summary Reset business clock
+// @Description Hapus offset business sehingga business kembali mengikuti offset global
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=service.ClockStatus}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /admin/clock/businesses/{id} [delete]
+func (h *ClockHandler) ResetBusinessClock(c *gin.Context) {
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	h.clock.ResetBusinessOffset(businessID)
+	utils.OK(c, "Clock berhasil direset", h.clock.BusinessStatus(businessID))
+}
@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_comment/dto"
+	"github.com/atam/atamlink/internal/mod_comment/usecase"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// CommentHandler handler untuk komentar internal pada section dan card
+type CommentHandler struct {
+	commentUC        usecase.CommentUseCase
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewCommentHandler membuat instance comment handler baru
+func NewCommentHandler(commentUC usecase.CommentUseCase, hideInaccessible bool, validator *utils.Validator) *CommentHandler {
+	return &CommentHandler{
+		commentUC:        commentUC,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// CreateOnSection handler untuk komentar pada section
+// @Summary Comment on section
+// @Description Tambah komentar internal atau balasan thread pada section, dengan @mention anggota business
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param body body dto.CreateCommentRequest true "Comment data"
+// @Success 201 {object} utils.Response{data=dto.CommentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/comments [post]
+func (h *CommentHandler) CreateOnSection(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	comment, err := h.commentUC.CreateOnSection(c, sectionID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Komentar berhasil ditambahkan", comment)
+}
+
+// ListBySection handler untuk daftar thread komentar section
+// @Summary List section comments
+// @Description Daftar thread komentar pada section beserta balasannya
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param section_id path int true "Section ID"
+// @Param include_resolved query bool false "Sertakan thread yang sudah resolved"
+// @Success 200 {object} utils.Response{data=[]dto.CommentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/sections/{section_id}/comments [get]
+func (h *CommentHandler) ListBySection(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	sectionID, err := strconv.ParseInt(c.Param("section_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID section tidak valid")
+		return
+	}
+
+	includeResolved, _ := strconv.ParseBool(c.Query("include_resolved"))
+
+	comments, err := h.commentUC.ListBySection(c, sectionID, profileID, includeResolved)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Daftar komentar berhasil diambil", comments)
+}
+
+// CreateOnCard handler untuk komentar pada card
+// @Summary Comment on card
+// @Description Tambah komentar internal atau balasan thread pada card, dengan @mention anggota business
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param body body dto.CreateCommentRequest true "Comment data"
+// @Success 201 {object} utils.Response{data=dto.CommentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/comments [post]
+func (h *CommentHandler) CreateOnCard(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	comment, err := h.commentUC.CreateOnCard(c, cardID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Komentar berhasil ditambahkan", comment)
+}
+
+// ListByCard handler untuk daftar thread komentar card
+// @Summary List card comments
+// @Description Daftar thread komentar pada card beserta balasannya
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param card_id path int true "Card ID"
+// @Param include_resolved query bool false "Sertakan thread yang sudah resolved"
+// @Success 200 {object} utils.Response{data=[]dto.CommentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /catalogs/cards/{card_id}/comments [get]
+func (h *CommentHandler) ListByCard(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	cardID, err := strconv.ParseInt(c.Param("card_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID card tidak valid")
+		return
+	}
+
+	includeResolved, _ := strconv.ParseBool(c.Query("include_resolved"))
+
+	comments, err := h.commentUC.ListByCard(c, cardID, profileID, includeResolved)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Daftar komentar berhasil diambil", comments)
+}
+
+// Resolve handler untuk resolve atau buka kembali thread
+// @Summary Resolve comment thread
+// @Description Tandai thread selesai (atau buka kembali), oleh penulis thread atau editor katalog
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param comment_id path int true "Comment ID"
+// @Param body body dto.ResolveCommentRequest true "Resolve state"
+// @Success 200 {object} utils.Response{data=dto.CommentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /comments/{comment_id}/resolve [put]
+func (h *CommentHandler) Resolve(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	commentID, err := strconv.ParseInt(c.Param("comment_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID komentar tidak valid")
+		return
+	}
+
+	var req dto.ResolveCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	comment, err := h.commentUC.Resolve(c, commentID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Status thread berhasil diperbarui", comment)
+}
+
+// handleError menangani error dari use case
+func (h *CommentHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, commentNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// commentNotFoundMessage pesan 404 sesuai resource yang diminta route
+func commentNotFoundMessage(c *gin.Context) string {
+	switch {
+	case c.Param("comment_id") != "":
+		return constant.ErrMsgCommentNotFound
+	case c.Param("card_id") != "":
+		return constant.ErrMsgCardNotFound
+	default:
+		return constant.ErrMsgSectionNotFound
+	}
+}
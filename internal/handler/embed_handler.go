@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+)
+
+//go:embed assets/embed.js
+var embedScriptSource string
+
+// EmbedHandler handler untuk script widget embed katalog
+type EmbedHandler struct {
+	script []byte
+	etag   string
+}
+
+// NewEmbedHandler membuat instance embed handler baru, prefix API ditanam ke
+// script supaya widget tahu endpoint yang dipanggil
+func NewEmbedHandler(apiPrefix string) *EmbedHandler {
+	script := strings.NewReplacer(
+		"__API_PREFIX__", strings.TrimRight(apiPrefix, "/"),
+		"__VERSION__", constant.EmbedScriptVersion,
+	).Replace(embedScriptSource)
+
+	sum := sha256.Sum256([]byte(script))
+	return &EmbedHandler{
+		script: []byte(script),
+		etag:   `"` + hex.EncodeToString(sum[:8]) + `"`,
+	}
+}
+
+// Script handler untuk embed.js
+// @Summary Embed script
+// @Description Script widget katalog. Pasang <script src=".../embed.js?v=VERSION" data-catalog="slug" async></script> di website mana pun, opsional data-target="#selector" untuk container. URL dengan v sesuai versi terbaru di-cache permanen, tanpa v di-cache singkat
+// @Tags embed
+// @Produce application/javascript
+// @Param v query string false "Versi script"
+// @Success 200 {string} string
+// @Success 304
+// @Router /embed.js [get]
+func (h *EmbedHandler) Script(c *gin.Context) {
+	c.Header("ETag", h.etag)
+	c.Header("X-Embed-Version", constant.EmbedScriptVersion)
+	// Versi lama tetap dilayani script terbaru, tapi tidak di-cache permanen
+	if c.Query("v") == constant.EmbedScriptVersion {
+		c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		c.Header("Cache-Control", "public, max-age=300")
+	}
+
+	if c.GetHeader("If-None-Match") == h.etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/javascript; charset=utf-8", h.script)
+}
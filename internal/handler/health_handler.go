@@ -1,23 +1,44 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/service"
 	"github.com/atam/atamlink/pkg/utils"
 )
 
 // HealthHandler handler untuk health check
 type HealthHandler struct {
-	db *sql.DB
+	db            *sql.DB
+	auditService  service.AuditService
+	canaryService service.CanaryService
+	loadShedder   service.LoadShedder
+	uploadService service.UploadService
+	readiness     config.ReadinessConfig
+
+	// Hasil cek Cloudinary terakhir, di-cache selama readiness.CloudinaryTTL
+	cloudinaryMu        sync.Mutex
+	cloudinaryCheckedAt time.Time
+	cloudinaryCheck     ReadinessCheck
 }
 
 // NewHealthHandler membuat instance health handler baru
-func NewHealthHandler(db *sql.DB) *HealthHandler {
+func NewHealthHandler(db *sql.DB, auditService service.AuditService, canaryService service.CanaryService, loadShedder service.LoadShedder, uploadService service.UploadService, readiness config.ReadinessConfig) *HealthHandler {
 	return &HealthHandler{
-		db: db,
+		db:            db,
+		auditService:  auditService,
+		canaryService: canaryService,
+		loadShedder:   loadShedder,
+		uploadService: uploadService,
+		readiness:     readiness,
 	}
 }
 
@@ -41,6 +62,21 @@ type HealthDBStatus struct {
 	DBError     string    `json:"db_error,omitempty"`
 }
 
+// ReadinessStatus struktur response /readyz
+type ReadinessStatus struct {
+	Status    string                    `json:"status"` // ready, not_ready
+	Timestamp time.Time                 `json:"timestamp"`
+	Checks    map[string]ReadinessCheck `json:"checks"`
+}
+
+// ReadinessCheck hasil pengecekan satu dependency
+type ReadinessCheck struct {
+	Status    string `json:"status"` // ok, fail
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
 var startTime = time.Now()
 
 // Check endpoint untuk basic health check
@@ -61,6 +97,111 @@ func (h *HealthHandler) Check(c *gin.Context) {
 	utils.OK(c, "Service is healthy", health)
 }
 
+// Healthz endpoint liveness probe, hanya memastikan proses masih melayani request
+// @Summary Liveness probe
+// @Description Selalu 200 selama proses berjalan, tanpa cek dependency
+// @Tags health
+// @Produce json
+// @Success 200 {object} utils.Response{data=HealthStatus}
+// @Router /healthz [get]
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	health := HealthStatus{
+		Status:    "healthy",
+		Timestamp: time.Now(),
+		Version:   "1.0.0",
+		Uptime:    time.Since(startTime).String(),
+	}
+
+	utils.OK(c, "Service is alive", health)
+}
+
+// Readyz endpoint readiness probe: database, Cloudinary, dan worker audit.
+// Cloudinary hanya critical jika READYZ_REQUIRE_CLOUDINARY aktif
+// @Summary Readiness probe
+// @Description Cek dependency yang dibutuhkan untuk melayani traffic. 503 jika ada check critical yang gagal
+// @Tags health
+// @Produce json
+// @Success 200 {object} utils.Response{data=ReadinessStatus}
+// @Failure 503 {object} utils.Response{data=ReadinessStatus}
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	checks := map[string]ReadinessCheck{
+		"database":     h.checkDatabase(c.Request.Context()),
+		"cloudinary":   h.checkCloudinary(c.Request.Context()),
+		"audit_worker": h.checkAuditWorker(),
+	}
+
+	result := ReadinessStatus{
+		Status:    "ready",
+		Timestamp: time.Now(),
+		Checks:    checks,
+	}
+	for _, check := range checks {
+		if check.Critical && check.Status != "ok" {
+			result.Status = "not_ready"
+			break
+		}
+	}
+
+	if result.Status != "ready" {
+		utils.Success(c, 503, "Service belum siap menerima traffic", result)
+		return
+	}
+	utils.Success(c, 200, "Service siap menerima traffic", result)
+}
+
+func (h *HealthHandler) checkDatabase(ctx context.Context) ReadinessCheck {
+	ctx, cancel := context.WithTimeout(ctx, h.readiness.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.db.PingContext(ctx)
+	return newReadinessCheck(true, start, err)
+}
+
+// checkCloudinary hasil di-cache agar probe yang sering tidak membebani jaringan keluar
+func (h *HealthHandler) checkCloudinary(ctx context.Context) ReadinessCheck {
+	h.cloudinaryMu.Lock()
+	defer h.cloudinaryMu.Unlock()
+
+	if !h.cloudinaryCheckedAt.IsZero() && time.Since(h.cloudinaryCheckedAt) < h.readiness.CloudinaryTTL {
+		return h.cloudinaryCheck
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.readiness.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := h.uploadService.PingCloudinary(ctx)
+	h.cloudinaryCheck = newReadinessCheck(h.readiness.RequireCloudinary, start, err)
+	h.cloudinaryCheckedAt = time.Now()
+	return h.cloudinaryCheck
+}
+
+// checkAuditWorker worker berhenti saat shutdown, sehingga instance keluar dari
+// load balancer sebelum server berhenti menerima koneksi
+func (h *HealthHandler) checkAuditWorker() ReadinessCheck {
+	check := ReadinessCheck{Status: "ok", Critical: true}
+	if !h.auditService.Health().WorkerRunning {
+		check.Status = "fail"
+		check.Error = "audit worker is not running"
+	}
+	return check
+}
+
+func newReadinessCheck(critical bool, start time.Time, err error) ReadinessCheck {
+	check := ReadinessCheck{
+		Status:    "ok",
+		Critical:  critical,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		check.Status = "fail"
+		check.Error = err.Error()
+	}
+	return check
+}
+
 // CheckDB endpoint untuk database health check
 // @Summary Database health check
 // @Description Check database connection
@@ -97,4 +238,99 @@ func (h *HealthHandler) CheckDB(c *gin.Context) {
 
 	health.DBConnected = true
 	utils.OK(c, "Service dan database healthy", health)
-}
\ No newline at end of file
+}
+
+// AuditHealth endpoint untuk kondisi pipeline audit log
+// @Summary Audit pipeline health
+// @Description Kedalaman queue, jumlah entry yang hilang, waktu flush terakhir, dan batch error rate audit log
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {object} utils.Response{data=service.AuditHealth}
+// @Failure 401 {object} utils.Response
+// @Router /admin/audit/health [get]
+func (h *HealthHandler) AuditHealth(c *gin.Context) {
+	health := h.auditService.Health()
+	if health.Status != "healthy" {
+		utils.OK(c, "Pipeline audit bermasalah", health)
+		return
+	}
+	utils.OK(c, "Pipeline audit healthy", health)
+}
+
+// Metrics endpoint metrics format Prometheus text
+// @Summary Metrics
+// @Description Metrics format Prometheus text exposition, scraper wajib mengirim header X-Admin-Token
+// @Tags health
+// @Produce plain
+// @Param X-Admin-Token header string true "Admin token"
+// @Success 200 {string} string
+// @Failure 401 {object} utils.Response
+// @Router /metrics [get]
+func (h *HealthHandler) Metrics(c *gin.Context) {
+	audit := h.auditService.Health()
+
+	var b strings.Builder
+	writeMetric(&b, "atamlink_uptime_seconds", "gauge", "Lama service berjalan", time.Since(startTime).Seconds())
+	writeMetric(&b, "atamlink_audit_queue_depth", "gauge", "Jumlah entry audit di queue", float64(audit.QueueDepth))
+	writeMetric(&b, "atamlink_audit_queue_capacity", "gauge", "Kapasitas queue audit", float64(audit.QueueCapacity))
+
+	b.WriteString("# HELP atamlink_audit_dropped_total Jumlah entry audit yang hilang\n")
+	b.WriteString("# TYPE atamlink_audit_dropped_total counter\n")
+	fmt.Fprintf(&b, "atamlink_audit_dropped_total{reason=\"queue_full\"} %d\n", audit.DroppedQueueFull)
+	fmt.Fprintf(&b, "atamlink_audit_dropped_total{reason=\"flush_failed\"} %d\n", audit.DroppedFlushFailed)
+
+	writeMetric(&b, "atamlink_audit_batches_total", "counter", "Jumlah batch audit yang di-flush", float64(audit.BatchesTotal))
+	writeMetric(&b, "atamlink_audit_batch_errors_total", "counter", "Jumlah batch audit yang gagal disimpan", float64(audit.BatchErrorsTotal))
+	writeMetric(&b, "atamlink_audit_batch_error_rate", "gauge", "Rasio batch gagal dari 100 batch terakhir", audit.BatchErrorRate)
+
+	var lastFlush float64
+	if audit.LastFlushAt != nil {
+		lastFlush = float64(audit.LastFlushAt.Unix())
+	}
+	writeMetric(&b, "atamlink_audit_last_flush_timestamp_seconds", "gauge", "Waktu flush audit terakhir (unix), 0 = belum pernah", lastFlush)
+
+	if sink := audit.Sink; sink != nil {
+		writeMetric(&b, "atamlink_audit_sink_queue_depth", "gauge", "Jumlah batch audit yang antri ke SIEM", float64(sink.QueueDepth))
+		writeMetric(&b, "atamlink_audit_sink_sent_total", "counter", "Jumlah entry audit terkirim ke SIEM", float64(sink.SentTotal))
+		writeMetric(&b, "atamlink_audit_sink_dropped_total", "counter", "Jumlah entry audit yang tidak terkirim ke SIEM", float64(sink.DroppedTotal))
+		writeMetric(&b, "atamlink_audit_sink_failed_batches_total", "counter", "Jumlah batch audit yang gagal dikirim ke SIEM", float64(sink.FailedBatches))
+	}
+
+	// Metrics per variant untuk membandingkan canary dengan stable
+	if variants := h.canaryService.Stats(); len(variants) > 0 {
+		b.WriteString("# HELP atamlink_http_requests_total Jumlah request per variant canary\n")
+		b.WriteString("# TYPE atamlink_http_requests_total counter\n")
+		for _, v := range variants {
+			fmt.Fprintf(&b, "atamlink_http_requests_total{variant=%q} %d\n", v.Variant, v.Requests)
+		}
+		b.WriteString("# HELP atamlink_http_request_errors_total Jumlah request error per variant canary\n")
+		b.WriteString("# TYPE atamlink_http_request_errors_total counter\n")
+		for _, v := range variants {
+			fmt.Fprintf(&b, "atamlink_http_request_errors_total{variant=%q,class=\"4xx\"} %d\n", v.Variant, v.ClientErrors)
+			fmt.Fprintf(&b, "atamlink_http_request_errors_total{variant=%q,class=\"5xx\"} %d\n", v.Variant, v.ServerErrors)
+		}
+		b.WriteString("# HELP atamlink_http_request_duration_seconds_sum Total latency request per variant canary\n")
+		b.WriteString("# TYPE atamlink_http_request_duration_seconds_sum counter\n")
+		for _, v := range variants {
+			fmt.Fprintf(&b, "atamlink_http_request_duration_seconds_sum{variant=%q} %g\n", v.Variant, v.LatencySeconds)
+		}
+	}
+
+	if h.loadShedder.Enabled() {
+		shed := h.loadShedder.Stats()
+		writeMetric(&b, "atamlink_inflight_requests", "gauge", "Jumlah request yang sedang diproses", float64(shed.InFlight))
+		b.WriteString("# HELP atamlink_load_shed_total Jumlah request prioritas rendah yang ditolak karena beban tinggi\n")
+		b.WriteString("# TYPE atamlink_load_shed_total counter\n")
+		for _, reason := range []string{service.ShedReasonInFlight, service.ShedReasonDBPool, service.ShedReasonAuditQueue} {
+			fmt.Fprintf(&b, "atamlink_load_shed_total{reason=%q} %d\n", reason, shed.Shed[reason])
+		}
+	}
+
+	c.Data(200, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
+
+// writeMetric tulis satu metric tanpa label beserta HELP dan TYPE
+func writeMetric(b *strings.Builder, name, metricType, help string, value float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n%s %g\n", name, help, name, metricType, name, value)
+}
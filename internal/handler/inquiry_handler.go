@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_inquiry/dto"
+	"github.com/atam/atamlink/internal/mod_inquiry/usecase"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// InquiryHandler handler untuk inquiry / form kontak katalog
+type InquiryHandler struct {
+	inquiryUC        usecase.InquiryUseCase
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewInquiryHandler membuat instance inquiry handler baru
+func NewInquiryHandler(inquiryUC usecase.InquiryUseCase, hideInaccessible bool, validator *utils.Validator) *InquiryHandler {
+	return &InquiryHandler{
+		inquiryUC:        inquiryUC,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// Submit handler untuk inquiry publik
+// @Summary Submit catalog inquiry
+// @Description Kirim pesan ke pemilik katalog, opsional untuk card tertentu (card_id). Email atau phone wajib diisi. Field website adalah honeypot dan harus dibiarkan kosong. Dibatasi per pengunjung (429 jika melebihi batas). Jika proof-of-work aktif, wajib header X-PoW-Challenge dan X-PoW-Nonce dari GET /pow/inquiry (428 jika tidak valid)
+// @Tags inquiries
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param body body dto.CreateInquiryRequest true "Inquiry data"
+// @Param X-PoW-Challenge header string false "Challenge proof-of-work"
+// @Param X-PoW-Nonce header string false "Nonce proof-of-work"
+// @Success 201 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 428 {object} utils.Response
+// @Failure 429 {object} utils.Response
+// @Router /c/{slug}/inquiries [post]
+func (h *InquiryHandler) Submit(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	var req dto.CreateInquiryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	visitor := &service.VisitorInfo{
+		UserAgent:      c.GetHeader("User-Agent"),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		IP:             c.ClientIP(),
+	}
+
+	if err := h.inquiryUC.Submit(c, slug, visitor, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Pesan berhasil dikirim", nil)
+}
+
+// List handler untuk daftar inquiry katalog (pemilik)
+// @Summary List catalog inquiries
+// @Description Daftar inquiry katalog, default semua yang belum diarsipkan. Dapat difilter status (new, read, archived) dan card
+// @Tags inquiries
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param status query string false "Status filter"
+// @Param card_id query int false "Card ID filter"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param sort query string false "Sort field (created_at)" default(created_at)
+// @Param order query string false "Sort order" default(desc)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.InquiryResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/inquiries [get]
+func (h *InquiryHandler) List(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	filter := &dto.InquiryFilter{Status: c.Query("status")}
+	if cardIDStr := c.Query("card_id"); cardIDStr != "" {
+		filter.CardID, err = strconv.ParseInt(cardIDStr, 10, 64)
+		if err != nil {
+			utils.BadRequest(c, "ID card tidak valid")
+			return
+		}
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+	orderBy := utils.BuildOrderBy(paginationParams.Sort, paginationParams.Order, inquirySorts)
+
+	inquiries, total, err := h.inquiryUC.List(c, catalogID, profileID, filter, paginationParams.Page, paginationParams.PerPage, orderBy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Daftar pesan berhasil diambil", inquiries, meta)
+}
+
+// GetByID handler untuk detail inquiry
+// @Summary Get inquiry
+// @Description Detail inquiry, tidak mengubah status baca
+// @Tags inquiries
+// @Accept json
+// @Produce json
+// @Param inquiry_id path int true "Inquiry ID"
+// @Success 200 {object} utils.Response{data=dto.InquiryResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/inquiries/{inquiry_id} [get]
+func (h *InquiryHandler) GetByID(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	inquiryID, err := strconv.ParseInt(c.Param("inquiry_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pesan tidak valid")
+		return
+	}
+
+	inquiry, err := h.inquiryUC.GetByID(c, inquiryID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Pesan berhasil diambil", inquiry)
+}
+
+// MarkRead handler untuk menandai inquiry sudah dibaca
+// @Summary Mark inquiry read
+// @Description Tandai inquiry baru sebagai sudah dibaca
+// @Tags inquiries
+// @Accept json
+// @Produce json
+// @Param inquiry_id path int true "Inquiry ID"
+// @Success 200 {object} utils.Response{data=dto.InquiryResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/inquiries/{inquiry_id}/read [put]
+func (h *InquiryHandler) MarkRead(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	inquiryID, err := strconv.ParseInt(c.Param("inquiry_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pesan tidak valid")
+		return
+	}
+
+	inquiry, err := h.inquiryUC.MarkRead(c, inquiryID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Pesan ditandai sudah dibaca", inquiry)
+}
+
+// Archive handler untuk mengarsipkan inquiry
+// @Summary Archive inquiry
+// @Description Arsipkan inquiry sehingga tidak tampil di daftar default
+// @Tags inquiries
+// @Accept json
+// @Produce json
+// @Param inquiry_id path int true "Inquiry ID"
+// @Success 200 {object} utils.Response{data=dto.InquiryResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/inquiries/{inquiry_id}/archive [put]
+func (h *InquiryHandler) Archive(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	inquiryID, err := strconv.ParseInt(c.Param("inquiry_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pesan tidak valid")
+		return
+	}
+
+	inquiry, err := h.inquiryUC.Archive(c, inquiryID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Pesan berhasil diarsipkan", inquiry)
+}
+
+// inquirySorts kolom sort inquiry yang diizinkan
+var inquirySorts = map[string]string{
+	"created_at": "ci.ci_created_at",
+}
+
+// handleError menangani error dari use case
+func (h *InquiryHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, inquiryNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// inquiryNotFoundMessage pesan 404 sesuai resource yang diminta route
+func inquiryNotFoundMessage(c *gin.Context) string {
+	if c.Param("inquiry_id") != "" {
+		return constant.ErrMsgInquiryNotFound
+	}
+	return constant.ErrMsgCatalogNotFound
+}
@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_integration/dto"
+	"github.com/atam/atamlink/internal/mod_integration/usecase"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// IntegrationHandler handler untuk integrasi marketplace
+type IntegrationHandler struct {
+	integrationUC    usecase.IntegrationUseCase
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewIntegrationHandler membuat instance integration handler baru
+func NewIntegrationHandler(integrationUC usecase.IntegrationUseCase, hideInaccessible bool, validator *utils.Validator) *IntegrationHandler {
+	return &IntegrationHandler{
+		integrationUC:    integrationUC,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// Connect handler untuk menghubungkan akun marketplace
+// @Summary Connect marketplace
+// @Description Hubungkan toko Shopee/Tokopedia ke business untuk import produk
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.CreateIntegrationRequest true "Integration data"
+// @Success 201 {object} utils.Response{data=dto.IntegrationResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/integrations [post]
+func (h *IntegrationHandler) Connect(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var req dto.CreateIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	integration, err := h.integrationUC.Connect(c, businessID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Integrasi berhasil dihubungkan", integration)
+}
+
+// List handler untuk daftar integrasi business
+// @Summary List integrations
+// @Description Daftar integrasi marketplace milik business
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=[]dto.IntegrationResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/integrations [get]
+func (h *IntegrationHandler) List(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	integrations, err := h.integrationUC.List(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Daftar integrasi berhasil diambil", integrations)
+}
+
+// Disconnect handler untuk memutus integrasi
+// @Summary Disconnect marketplace
+// @Description Putuskan integrasi marketplace, card yang sudah diimport tidak dihapus
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param integration_id path int true "Integration ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /integrations/{integration_id} [delete]
+func (h *IntegrationHandler) Disconnect(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	integrationID, err := strconv.ParseInt(c.Param("integration_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID integrasi tidak valid")
+		return
+	}
+
+	if err := h.integrationUC.Disconnect(c, integrationID, profileID); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// Sync handler untuk sinkronisasi manual
+// @Summary Sync marketplace products
+// @Description Jalankan sinkronisasi produk marketplace sekarang
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param integration_id path int true "Integration ID"
+// @Success 200 {object} utils.Response{data=dto.SyncResultResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 502 {object} utils.Response
+// @Router /integrations/{integration_id}/sync [post]
+func (h *IntegrationHandler) Sync(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	integrationID, err := strconv.ParseInt(c.Param("integration_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID integrasi tidak valid")
+		return
+	}
+
+	result, err := h.integrationUC.Sync(c, integrationID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Sinkronisasi produk berhasil", result)
+}
+
+// ListMappings handler untuk daftar mapping produk
+// @Summary List product mappings
+// @Description Daftar mapping produk marketplace ke card
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param integration_id path int true "Integration ID"
+// @Success 200 {object} utils.Response{data=[]dto.ProductMappingResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /integrations/{integration_id}/mappings [get]
+func (h *IntegrationHandler) ListMappings(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	integrationID, err := strconv.ParseInt(c.Param("integration_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID integrasi tidak valid")
+		return
+	}
+
+	mappings, err := h.integrationUC.ListMappings(c, integrationID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Daftar mapping produk berhasil diambil", mappings)
+}
+
+// handleError menangani error dari use case
+func (h *IntegrationHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, integrationNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// integrationNotFoundMessage pesan 404 sesuai resource yang diminta route
+func integrationNotFoundMessage(c *gin.Context) string {
+	if c.Param("integration_id") != "" {
+		return constant.ErrMsgIntegrationNotFound
+	}
+	return constant.ErrMsgBusinessNotFound
+}
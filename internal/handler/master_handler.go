@@ -54,7 +54,7 @@ func (h *MasterHandler) CreatePlan(c *gin.Context) {
 	}
 
 	// Create plan
-	plan, err := h.masterUC.CreatePlan(&req)
+	plan, err := h.masterUC.CreatePlan(c.Request.Context(), &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -191,7 +191,7 @@ func (h *MasterHandler) ListPlans(c *gin.Context) {
 	}
 
 	// Get plans
-	plans, err := h.masterUC.ListPlans(filter)
+	plans, err := h.masterUC.ListPlans(c.Request.Context(), filter)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -221,7 +221,7 @@ func (h *MasterHandler) GetPlanByID(c *gin.Context) {
 	}
 
 	// Get plan
-	plan, err := h.masterUC.GetPlanByID(id)
+	plan, err := h.masterUC.GetPlanByID(c.Request.Context(), id)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -258,7 +258,7 @@ func (h *MasterHandler) CreateTheme(c *gin.Context) {
 	}
 
 	// Create theme
-	theme, err := h.masterUC.CreateTheme(&req)
+	theme, err := h.masterUC.CreateTheme(c.Request.Context(), &req)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -388,7 +388,7 @@ func (h *MasterHandler) ListThemes(c *gin.Context) {
 	}
 
 	// Get themes
-	themes, err := h.masterUC.ListThemes(filter)
+	themes, err := h.masterUC.ListThemes(c.Request.Context(), filter)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -418,7 +418,7 @@ func (h *MasterHandler) GetThemeByID(c *gin.Context) {
 	}
 
 	// Get theme
-	theme, err := h.masterUC.GetThemeByID(id)
+	theme, err := h.masterUC.GetThemeByID(c.Request.Context(), id)
 	if err != nil {
 		h.handleError(c, err)
 		return
@@ -427,6 +427,211 @@ func (h *MasterHandler) GetThemeByID(c *gin.Context) {
 	utils.OK(c, "Data theme berhasil diambil", theme)
 }
 
+// CreateCategory handler untuk create category
+// @Summary Create category
+// @Description Create new business/catalog category
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param body body dto.CreateCategoryRequest true "Category data"
+// @Success 201 {object} utils.Response{data=dto.CategoryResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/masters/categories [post]
+func (h *MasterHandler) CreateCategory(c *gin.Context) {
+	// Bind request
+	var req dto.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	// Create category
+	category, err := h.masterUC.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Kategori berhasil dibuat", category)
+}
+
+// UpdateCategory handler untuk update category
+// @Summary Update category
+// @Description Update existing category, slug tidak dapat diubah
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Category ID"
+// @Param body body dto.UpdateCategoryRequest true "Update data"
+// @Success 200 {object} utils.Response{data=dto.CategoryResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/masters/categories/{id} [put]
+func (h *MasterHandler) UpdateCategory(c *gin.Context) {
+	// Get category ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID kategori tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	// Update category
+	category, err := h.masterUC.UpdateCategory(c, id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Kategori berhasil diperbarui", category)
+}
+
+// DeleteCategory handler untuk delete category
+// @Summary Delete category
+// @Description Soft delete category
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Category ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/masters/categories/{id} [delete]
+func (h *MasterHandler) DeleteCategory(c *gin.Context) {
+	// Get category ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID kategori tidak valid")
+		return
+	}
+
+	// Delete category
+	if err := h.masterUC.DeleteCategory(c, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// ListCategories handler untuk list categories (admin, termasuk yang nonaktif)
+// @Summary List categories
+// @Description Get list of categories
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param search query string false "Search keyword"
+// @Param is_active query bool false "Active status filter"
+// @Success 200 {object} utils.Response{data=[]dto.CategoryResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/masters/categories [get]
+func (h *MasterHandler) ListCategories(c *gin.Context) {
+	// Build filter
+	filter := &dto.CategoryFilter{
+		Search: c.Query("search"),
+	}
+
+	// Parse is_active filter
+	if isActiveStr := c.Query("is_active"); isActiveStr != "" {
+		isActive, err := strconv.ParseBool(isActiveStr)
+		if err == nil {
+			filter.IsActive = &isActive
+		}
+	}
+
+	h.listCategories(c, filter)
+}
+
+// ListActiveCategories handler untuk list category aktif (publik)
+// @Summary List active categories
+// @Description Kategori aktif untuk filter direktori dan pilihan kategori business/katalog
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Param search query string false "Search keyword"
+// @Success 200 {object} utils.Response{data=[]dto.CategoryResponse}
+// @Failure 500 {object} utils.Response
+// @Router /categories [get]
+func (h *MasterHandler) ListActiveCategories(c *gin.Context) {
+	isActive := true
+	h.listCategories(c, &dto.CategoryFilter{
+		Search:   c.Query("search"),
+		IsActive: &isActive,
+	})
+}
+
+func (h *MasterHandler) listCategories(c *gin.Context, filter *dto.CategoryFilter) {
+	categories, err := h.masterUC.ListCategories(c.Request.Context(), filter)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data kategori berhasil diambil", categories)
+}
+
+// GetCategoryByID handler untuk get category by ID
+// @Summary Get category by ID
+// @Description Get category details by ID
+// @Tags masters
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Category ID"
+// @Success 200 {object} utils.Response{data=dto.CategoryResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/masters/categories/{id} [get]
+func (h *MasterHandler) GetCategoryByID(c *gin.Context) {
+	// Get category ID from param
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID kategori tidak valid")
+		return
+	}
+
+	// Get category
+	category, err := h.masterUC.GetCategoryByID(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data kategori berhasil diambil", category)
+}
+
 // handleError menangani error dari use case
 func (h *MasterHandler) handleError(c *gin.Context, err error) {
 	// Check if AppError
@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_notification/dto"
+	"github.com/atam/atamlink/internal/mod_notification/usecase"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// NotificationHandler handler untuk notifikasi owner business
+type NotificationHandler struct {
+	notificationUC   usecase.NotificationUseCase
+	whatsAppConfig   config.WhatsAppConfig
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewNotificationHandler membuat instance notification handler baru
+func NewNotificationHandler(
+	notificationUC usecase.NotificationUseCase,
+	whatsAppConfig config.WhatsAppConfig,
+	hideInaccessible bool,
+	validator *utils.Validator,
+) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUC:   notificationUC,
+		whatsAppConfig:   whatsAppConfig,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// ListChannels handler untuk daftar channel notifikasi
+// @Summary List notification channels
+// @Description Daftar channel notifikasi yang diaktifkan business
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=[]dto.NotificationChannelResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/notifications [get]
+func (h *NotificationHandler) ListChannels(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	channels, err := h.notificationUC.ListChannels(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Daftar channel notifikasi berhasil diambil", channels)
+}
+
+// UpsertWhatsApp handler untuk mengaktifkan notifikasi WhatsApp
+// @Summary Configure WhatsApp notification
+// @Description Opt-in notifikasi pesanan baru via WhatsApp Business Cloud API
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.UpsertWhatsAppRequest true "WhatsApp config"
+// @Success 200 {object} utils.Response{data=dto.NotificationChannelResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/notifications/whatsapp [put]
+func (h *NotificationHandler) UpsertWhatsApp(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var req dto.UpsertWhatsAppRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	channel, err := h.notificationUC.UpsertWhatsApp(c, businessID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Notifikasi WhatsApp berhasil disimpan", channel)
+}
+
+// DeleteChannel handler untuk opt-out channel notifikasi
+// @Summary Delete notification channel
+// @Description Nonaktifkan dan hapus kredensial channel notifikasi
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param channel path string true "Channel (whatsapp, telegram)"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/notifications/{channel} [delete]
+func (h *NotificationHandler) DeleteChannel(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	if err := h.notificationUC.DeleteChannel(c, businessID, profileID, c.Param("channel")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// ListLogs handler untuk log pengiriman notifikasi
+// @Summary List notification logs
+// @Description Log pengiriman dan status delivery notifikasi terbaru
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 200 {object} utils.Response{data=[]dto.NotificationLogResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/notifications/logs [get]
+func (h *NotificationHandler) ListLogs(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	logs, err := h.notificationUC.ListLogs(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Log notifikasi berhasil diambil", logs)
+}
+
+// VerifyWhatsAppWebhook handler verifikasi subscription webhook WhatsApp
+// @Summary Verify WhatsApp webhook
+// @Description Endpoint verifikasi webhook (hub.challenge) dari Meta
+// @Tags webhooks
+// @Produce plain
+// @Param hub.mode query string true "subscribe"
+// @Param hub.verify_token query string true "Verify token"
+// @Param hub.challenge query string true "Challenge"
+// @Success 200 {string} string
+// @Failure 403 {object} utils.Response
+// @Router /webhooks/whatsapp [get]
+func (h *NotificationHandler) VerifyWhatsAppWebhook(c *gin.Context) {
+	if h.whatsAppConfig.VerifyToken == "" ||
+		c.Query("hub.mode") != "subscribe" ||
+		subtle.ConstantTimeCompare([]byte(c.Query("hub.verify_token")), []byte(h.whatsAppConfig.VerifyToken)) != 1 {
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+		return
+	}
+
+	c.String(http.StatusOK, c.Query("hub.challenge"))
+}
+
+// WhatsAppWebhook handler status delivery dari WhatsApp
+// @Summary WhatsApp webhook
+// @Description Terima status delivery message, diverifikasi dengan X-Hub-Signature-256
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /webhooks/whatsapp [post]
+func (h *NotificationHandler) WhatsAppWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, 1<<20))
+	if err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if !service.VerifyWhatsAppSignature(h.whatsAppConfig.AppSecret, body, c.GetHeader("X-Hub-Signature-256")) {
+		utils.Unauthorized(c, "Signature webhook tidak valid")
+		return
+	}
+
+	var req dto.WhatsAppWebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if err := h.notificationUC.HandleWhatsAppWebhook(&req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Webhook diterima", nil)
+}
+
+// CreateTelegramLink handler untuk membuat deep link bot Telegram
+// @Summary Create Telegram link
+// @Description Buat deep link t.me untuk menghubungkan chat Telegram owner ke notifikasi business
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Success 201 {object} utils.Response{data=dto.TelegramLinkResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /businesses/{id}/notifications/telegram/link [post]
+func (h *NotificationHandler) CreateTelegramLink(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	link, err := h.notificationUC.CreateTelegramLink(c, businessID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Link Telegram berhasil dibuat", link)
+}
+
+// TelegramWebhook handler update dari bot Telegram
+// @Summary Telegram webhook
+// @Description Terima update bot (perintah /start <token>), diverifikasi dengan X-Telegram-Bot-Api-Secret-Token
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param body body dto.TelegramUpdateRequest true "Telegram update"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Router /webhooks/telegram [post]
+func (h *NotificationHandler) TelegramWebhook(c *gin.Context) {
+	var req dto.TelegramUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if err := h.notificationUC.HandleTelegramWebhook(c, c.GetHeader("X-Telegram-Bot-Api-Secret-Token"), &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Webhook diterima", nil)
+}
+
+// handleError menangani error dari use case
+func (h *NotificationHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan business yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, constant.ErrMsgBusinessNotFound)
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_order/dto"
+	"github.com/atam/atamlink/internal/mod_order/usecase"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// OrderHandler handler untuk order capture card produk
+type OrderHandler struct {
+	orderUC          usecase.OrderUseCase
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewOrderHandler membuat instance order handler baru
+func NewOrderHandler(orderUC usecase.OrderUseCase, hideInaccessible bool, validator *utils.Validator) *OrderHandler {
+	return &OrderHandler{
+		orderUC:          orderUC,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// Submit handler untuk order publik
+// @Summary Submit catalog order
+// @Description Pesan satu atau beberapa card produk beserta jumlah dan kontak pemesan. Bukan checkout, pemilik akan menghubungi pemesan. Harga mengikuti card saat order dibuat. Email atau phone wajib diisi, field website adalah honeypot dan harus kosong. Dibatasi per pengunjung (429 jika melebihi batas). Jika proof-of-work aktif, wajib header X-PoW-Challenge dan X-PoW-Nonce dari GET /pow/order (428 jika tidak valid)
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param body body dto.CreateOrderRequest true "Order data"
+// @Param X-PoW-Challenge header string false "Challenge proof-of-work"
+// @Param X-PoW-Nonce header string false "Nonce proof-of-work"
+// @Success 201 {object} utils.Response{data=dto.PublicOrderResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 428 {object} utils.Response
+// @Failure 429 {object} utils.Response
+// @Router /c/{slug}/orders [post]
+func (h *OrderHandler) Submit(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	var req dto.CreateOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	visitor := &service.VisitorInfo{
+		UserAgent:      c.GetHeader("User-Agent"),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		IP:             c.ClientIP(),
+	}
+
+	order, err := h.orderUC.Submit(c, slug, visitor, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Pesanan berhasil dikirim", order)
+}
+
+// List handler untuk daftar order katalog (pemilik)
+// @Summary List catalog orders
+// @Description Daftar order katalog, dapat difilter status (new, contacted, completed, cancelled)
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param status query string false "Status filter"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param sort query string false "Sort field (created_at, total)" default(created_at)
+// @Param order query string false "Sort order" default(desc)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.OrderResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/orders [get]
+func (h *OrderHandler) List(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+	orderBy := utils.BuildOrderBy(paginationParams.Sort, paginationParams.Order, orderSorts)
+	filter := &dto.OrderFilter{Status: c.Query("status")}
+
+	orders, total, err := h.orderUC.List(c, catalogID, profileID, filter, paginationParams.Page, paginationParams.PerPage, orderBy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Daftar pesanan berhasil diambil", orders, meta)
+}
+
+// GetByID handler untuk detail order
+// @Summary Get order
+// @Description Detail order beserta item dan kontak pemesan
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param order_id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=dto.OrderResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/orders/{order_id} [get]
+func (h *OrderHandler) GetByID(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("order_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pesanan tidak valid")
+		return
+	}
+
+	order, err := h.orderUC.GetByID(c, orderID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Pesanan berhasil diambil", order)
+}
+
+// UpdateStatus handler untuk mengubah status order
+// @Summary Update order status
+// @Description Ubah status order: new -> contacted -> completed, cancelled dari status yang belum selesai
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param order_id path int true "Order ID"
+// @Param body body dto.UpdateOrderStatusRequest true "Status data"
+// @Success 200 {object} utils.Response{data=dto.OrderResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /catalogs/orders/{order_id}/status [put]
+func (h *OrderHandler) UpdateStatus(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	orderID, err := strconv.ParseInt(c.Param("order_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pesanan tidak valid")
+		return
+	}
+
+	var req dto.UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	order, err := h.orderUC.UpdateStatus(c, orderID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Status pesanan berhasil diperbarui", order)
+}
+
+// orderSorts kolom sort order yang diizinkan
+var orderSorts = map[string]string{
+	"created_at": "co.co_created_at",
+	"total":      "co.co_total",
+}
+
+// handleError menangani error dari use case
+func (h *OrderHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, orderNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// orderNotFoundMessage pesan 404 sesuai resource yang diminta route
+func orderNotFoundMessage(c *gin.Context) string {
+	if c.Param("order_id") != "" {
+		return constant.ErrMsgOrderNotFound
+	}
+	return constant.ErrMsgCatalogNotFound
+}
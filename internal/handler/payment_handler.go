@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_payment/dto"
+	"github.com/atam/atamlink/internal/mod_payment/usecase"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// PaymentHandler handler untuk pembayaran subscription
+type PaymentHandler struct {
+	paymentUC        usecase.PaymentUseCase
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewPaymentHandler membuat instance payment handler baru
+func NewPaymentHandler(paymentUC usecase.PaymentUseCase, hideInaccessible bool, validator *utils.Validator) *PaymentHandler {
+	return &PaymentHandler{
+		paymentUC:        paymentUC,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// CreateSubscriptionPayment handler untuk membuat pembayaran subscription
+// @Summary Create subscription payment
+// @Description Buat invoice payment gateway untuk plan, subscription aktif setelah pembayaran lunas
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param body body dto.CreateSubscriptionPaymentRequest true "Plan yang dibeli"
+// @Success 201 {object} utils.Response{data=dto.SubscriptionPaymentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 503 {object} utils.Response
+// @Router /businesses/{id}/subscription-payments [post]
+func (h *PaymentHandler) CreateSubscriptionPayment(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	var req dto.CreateSubscriptionPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	payment, err := h.paymentUC.CreateSubscriptionPayment(c, businessID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Pembayaran subscription berhasil dibuat", payment)
+}
+
+// List handler untuk riwayat pembayaran subscription
+// @Summary List subscription payments
+// @Description Riwayat pembayaran subscription business, terbaru dulu
+// @Tags subscriptions
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.SubscriptionPaymentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Router /businesses/{id}/subscription-payments [get]
+func (h *PaymentHandler) List(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+
+	payments, total, err := h.paymentUC.List(c, businessID, profileID, paginationParams.Page, paginationParams.PerPage)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Riwayat pembayaran berhasil diambil", payments, meta)
+}
+
+// GetByID handler untuk detail pembayaran subscription
+// @Summary Get subscription payment
+// @Description Detail satu pembayaran subscription business
+// @Tags subscriptions
+// @Produce json
+// @Param id path int true "Business ID"
+// @Param payment_id path int true "Payment ID"
+// @Success 200 {object} utils.Response{data=dto.SubscriptionPaymentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /businesses/{id}/subscription-payments/{payment_id} [get]
+func (h *PaymentHandler) GetByID(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	paymentID, err := strconv.ParseInt(c.Param("payment_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID pembayaran tidak valid")
+		return
+	}
+
+	payment, err := h.paymentUC.GetByID(c, businessID, paymentID, profileID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Detail pembayaran berhasil diambil", payment)
+}
+
+// Callback handler untuk callback invoice subscription dari gateway
+// @Summary Subscription payment callback
+// @Description Callback status invoice subscription dari payment gateway, diverifikasi lewat header X-Callback-Token
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param X-Callback-Token header string true "Callback token gateway"
+// @Param body body dto.PaymentCallbackRequest true "Callback payload"
+// @Success 200 {object} utils.Response
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /payments/subscriptions/callback [post]
+func (h *PaymentHandler) Callback(c *gin.Context) {
+	var req dto.PaymentCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	if err := h.paymentUC.HandleCallback(c.Request.Context(), c.GetHeader("X-Callback-Token"), &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Callback pembayaran diterima", nil)
+}
+
+// CallbackRouter gateway mengirim callback invoice ke satu URL per akun, invoice
+// subscription (external ID berawalan sub-) diteruskan ke Callback, sisanya ke next
+func (h *PaymentHandler) CallbackRouter(next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.BadRequest(c, "Format request tidak valid")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var payload struct {
+			ExternalID string `json:"external_id"`
+		}
+		if json.Unmarshal(body, &payload) == nil && strings.HasPrefix(payload.ExternalID, constant.SubscriptionPaymentPrefix+"-") {
+			h.Callback(c)
+			return
+		}
+
+		next(c)
+	}
+}
+
+// handleError menangani error dari use case
+func (h *PaymentHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, paymentNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// paymentNotFoundMessage pesan 404 sesuai resource yang diminta route
+func paymentNotFoundMessage(c *gin.Context) string {
+	if c.Param("payment_id") != "" {
+		return constant.ErrMsgSubscriptionPaymentNotFound
+	}
+	return constant.ErrMsgBusinessNotFound
+}
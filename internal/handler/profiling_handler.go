@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// ProfilingHandler handler untuk pprof dan capture profile on-demand (admin)
+type ProfilingHandler struct {
+	profilerService service.ProfilerService
+}
+
+// NewProfilingHandler membuat instance profiling handler baru
+func NewProfilingHandler(profilerService service.ProfilerService) *ProfilingHandler {
+	return &ProfilingHandler{
+		profilerService: profilerService,
+	}
+}
+
+// Enabled check apakah rute profiling perlu didaftarkan
+func (h *ProfilingHandler) Enabled() bool {
+	return h.profilerService.Enabled()
+}
+
+// Pprof handler untuk endpoint net/http/pprof di bawah /admin/debug/pprof
+// @Summary pprof
+// @Description Endpoint net/http/pprof (index, heap, goroutine, profile, trace, dst), dipakai dengan go tool pprof
+// @Tags admin
+// @Produce plain
+// @Param X-Admin-Token header string true "Admin token"
+// @Param name path string true "Nama profile (kosong = index)"
+// @Success 200 {string} string
+// @Failure 401 {object} utils.Response
+// @Router /admin/debug/pprof/{name} [get]
+func (h *ProfilingHandler) Pprof(c *gin.Context) {
+	// pprof.Index hanya mengenali prefix /debug/pprof/, nama profile diambil dari param
+	switch name := c.Param("name"); name {
+	case "/", "":
+		pprof.Index(c.Writer, c.Request)
+	case "/cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "/profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "/symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "/trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		pprof.Handler(name[1:]).ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// CaptureProfile handler untuk capture CPU/heap profile ke storage
+// @Summary Capture profile
+// @Description Capture CPU profile (di background selama seconds, default 30 detik) atau heap profile lalu simpan ke storage untuk dianalisis belakangan
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param type query string false "Tipe profile (cpu, heap)" default(cpu)
+// @Param seconds query int false "Durasi CPU profile dalam detik"
+// @Success 201 {object} utils.Response{data=service.ProfileCapture}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Router /admin/profiles [post]
+func (h *ProfilingHandler) CaptureProfile(c *gin.Context) {
+	kind := c.DefaultQuery("type", service.ProfileCPU)
+
+	var duration time.Duration
+	if secondsStr := c.Query("seconds"); secondsStr != "" {
+		seconds, err := strconv.Atoi(secondsStr)
+		if err != nil {
+			utils.BadRequest(c, constant.ErrMsgProfilingDurationInvalid)
+			return
+		}
+		duration = time.Duration(seconds) * time.Second
+	}
+
+	capture, err := h.profilerService.Capture(kind, duration)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Capture profile dimulai", capture)
+}
+
+// DownloadProfile handler untuk download hasil capture profile
+// @Summary Download profile
+// @Description Download hasil capture profile, buka dengan go tool pprof
+// @Tags admin
+// @Produce octet-stream
+// @Param X-Admin-Token header string true "Admin token"
+// @Param name path string true "Nama profile"
+// @Success 200 {file} file
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /admin/profiles/{name} [get]
+func (h *ProfilingHandler) DownloadProfile(c *gin.Context) {
+	name := c.Param("name")
+
+	data, err := h.profilerService.Get(name)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	c.Data(http.StatusOK, "application/octet-stream", data)
+}
+
+// handleError menangani error dari profiler service
+func (h *ProfilingHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	utils.InternalServerError(c, constant.ErrMsgInternalServer)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// ProofOfWorkHandler handler untuk challenge proof-of-work endpoint publik
+type ProofOfWorkHandler struct {
+	pow service.ProofOfWork
+}
+
+// NewProofOfWorkHandler membuat instance proof-of-work handler baru
+func NewProofOfWorkHandler(pow service.ProofOfWork) *ProofOfWorkHandler {
+	return &ProofOfWorkHandler{
+		pow: pow,
+	}
+}
+
+// Challenge handler untuk terbitkan challenge proof-of-work
+// @Summary Issue proof-of-work challenge
+// @Description Terbitkan challenge untuk endpoint tulis publik (action: review, inquiry, order). Client mencari nonce sehingga sha256(challenge + nonce) diawali minimal `difficulty` bit nol, lalu mengirim header X-PoW-Challenge dan X-PoW-Nonce. Challenge hanya berlaku sekali sampai expires_at. 404 jika proof-of-work tidak diaktifkan
+// @Tags proof-of-work
+// @Produce json
+// @Param action path string true "Action endpoint" Enums(review, inquiry, order)
+// @Success 200 {object} utils.Response{data=service.PoWChallenge}
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /pow/{action} [get]
+func (h *ProofOfWorkHandler) Challenge(c *gin.Context) {
+	if !h.pow.Enabled() {
+		utils.NotFound(c, constant.ErrMsgNotFound)
+		return
+	}
+
+	action := c.Param("action")
+	if !constant.IsValidPoWAction(action) {
+		utils.BadRequest(c, constant.ErrMsgProofOfWorkActionInvalid)
+		return
+	}
+
+	challenge, err := h.pow.Issue(action)
+	if err != nil {
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	utils.OK(c, "Challenge berhasil dibuat", challenge)
+}
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_review/dto"
+	"github.com/atam/atamlink/internal/mod_review/usecase"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// ReviewHandler handler untuk ulasan (rating bintang) katalog
+type ReviewHandler struct {
+	reviewUC         usecase.ReviewUseCase
+	hideInaccessible bool
+	validator        *utils.Validator
+}
+
+// NewReviewHandler membuat instance review handler baru
+func NewReviewHandler(reviewUC usecase.ReviewUseCase, hideInaccessible bool, validator *utils.Validator) *ReviewHandler {
+	return &ReviewHandler{
+		reviewUC:         reviewUC,
+		hideInaccessible: hideInaccessible,
+		validator:        validator,
+	}
+}
+
+// Submit handler untuk ulasan publik
+// @Summary Submit catalog review
+// @Description Kirim rating 1-5 bintang dengan ulasan opsional. Ulasan tampil setelah disetujui pemilik, dibatasi per pengunjung (429 jika melebihi batas). Jika proof-of-work aktif, wajib header X-PoW-Challenge dan X-PoW-Nonce dari GET /pow/review (428 jika tidak valid)
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param body body dto.CreateReviewRequest true "Review data"
+// @Param X-PoW-Challenge header string false "Challenge proof-of-work"
+// @Param X-PoW-Nonce header string false "Nonce proof-of-work"
+// @Success 201 {object} utils.Response{data=dto.PublicReviewResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 409 {object} utils.Response
+// @Failure 428 {object} utils.Response
+// @Failure 429 {object} utils.Response
+// @Router /c/{slug}/reviews [post]
+func (h *ReviewHandler) Submit(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	visitor := &service.VisitorInfo{
+		UserAgent:      c.GetHeader("User-Agent"),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+		IP:             c.ClientIP(),
+	}
+
+	review, err := h.reviewUC.Submit(c, slug, visitor, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Ulasan berhasil dikirim dan menunggu moderasi", review)
+}
+
+// ListPublic handler untuk daftar ulasan publik
+// @Summary List catalog reviews
+// @Description Daftar ulasan yang sudah disetujui beserta balasan pemilik
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param slug path string true "Catalog slug"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param sort query string false "Sort field (created_at, rating)" default(created_at)
+// @Param order query string false "Sort order" default(desc)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.PublicReviewResponse}
+// @Failure 404 {object} utils.Response
+// @Router /c/{slug}/reviews [get]
+func (h *ReviewHandler) ListPublic(c *gin.Context) {
+	slug := c.Param("slug")
+	if slug == "" {
+		utils.BadRequest(c, "Slug katalog tidak valid")
+		return
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+	orderBy := utils.BuildOrderBy(paginationParams.Sort, paginationParams.Order, reviewSorts)
+
+	reviews, total, err := h.reviewUC.ListPublic(slug, paginationParams.Page, paginationParams.PerPage, orderBy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Daftar ulasan berhasil diambil", reviews, meta)
+}
+
+// List handler untuk daftar ulasan katalog (pemilik)
+// @Summary List catalog reviews for moderation
+// @Description Daftar semua ulasan katalog, dapat difilter status (pending, approved, rejected)
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Catalog ID"
+// @Param status query string false "Status filter"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Param sort query string false "Sort field (created_at, rating)" default(created_at)
+// @Param order query string false "Sort order" default(desc)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.ReviewResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/{id}/reviews [get]
+func (h *ReviewHandler) List(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	catalogID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID katalog tidak valid")
+		return
+	}
+
+	paginationParams := utils.GetPaginationParams(c)
+	orderBy := utils.BuildOrderBy(paginationParams.Sort, paginationParams.Order, reviewSorts)
+	filter := &dto.ReviewFilter{Status: c.Query("status")}
+
+	reviews, total, err := h.reviewUC.List(c, catalogID, profileID, filter, paginationParams.Page, paginationParams.PerPage, orderBy)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Daftar ulasan berhasil diambil", reviews, meta)
+}
+
+// Moderate handler untuk approve / reject ulasan
+// @Summary Moderate review
+// @Description Setujui atau tolak ulasan, rating agregat katalog dihitung ulang dari ulasan yang disetujui
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Param body body dto.ModerateReviewRequest true "Moderation data"
+// @Success 200 {object} utils.Response{data=dto.ReviewResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/reviews/{review_id}/moderate [put]
+func (h *ReviewHandler) Moderate(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	reviewID, err := strconv.ParseInt(c.Param("review_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID ulasan tidak valid")
+		return
+	}
+
+	var req dto.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	review, err := h.reviewUC.Moderate(c, reviewID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Status ulasan berhasil diperbarui", review)
+}
+
+// Reply handler untuk balasan pemilik pada ulasan
+// @Summary Reply to review
+// @Description Balas ulasan yang sudah disetujui, reply kosong menghapus balasan
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param review_id path int true "Review ID"
+// @Param body body dto.ReplyReviewRequest true "Reply data"
+// @Success 200 {object} utils.Response{data=dto.ReviewResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 403 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /catalogs/reviews/{review_id}/reply [put]
+func (h *ReviewHandler) Reply(c *gin.Context) {
+	profileID, exists := middleware.GetProfileID(c)
+	if !exists {
+		utils.Unauthorized(c, constant.ErrMsgUnauthorized)
+		return
+	}
+
+	reviewID, err := strconv.ParseInt(c.Param("review_id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID ulasan tidak valid")
+		return
+	}
+
+	var req dto.ReplyReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Format request tidak valid")
+		return
+	}
+
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	review, err := h.reviewUC.Reply(c, reviewID, profileID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Balasan ulasan berhasil disimpan", review)
+}
+
+// reviewSorts kolom sort ulasan yang diizinkan
+var reviewSorts = map[string]string{
+	"created_at": "cr.cr_created_at",
+	"rating":     "cr.cr_rating",
+}
+
+// handleError menangani error dari use case
+func (h *ReviewHandler) handleError(c *gin.Context, err error) {
+	// Non-anggota dapat 404 yang sama dengan resource yang tidak ada
+	if h.hideInaccessible && errors.Is(err, errors.ErrNotMember) {
+		utils.NotFound(c, reviewNotFoundMessage(c))
+		return
+	}
+
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, err.Error())
+	case errors.Is(err, errors.ErrForbidden):
+		utils.Forbidden(c, constant.ErrMsgForbidden)
+	case errors.Is(err, errors.ErrValidation):
+		utils.BadRequest(c, err.Error())
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
+
+// reviewNotFoundMessage pesan 404 sesuai resource yang diminta route
+func reviewNotFoundMessage(c *gin.Context) string {
+	if c.Param("review_id") != "" {
+		return constant.ErrMsgReviewNotFound
+	}
+	return constant.ErrMsgCatalogNotFound
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RobotsHandler handler untuk robots.txt global
+type RobotsHandler struct {
+	apiPrefix   string
+	disallowAll bool
+}
+
+// NewRobotsHandler membuat instance robots handler baru, disallowAll untuk
+// environment non-produksi yang tidak boleh diindex sama sekali
+func NewRobotsHandler(apiPrefix string, disallowAll bool) *RobotsHandler {
+	return &RobotsHandler{
+		apiPrefix:   strings.TrimRight(apiPrefix, "/"),
+		disallowAll: disallowAll,
+	}
+}
+
+// RobotsTxt handler untuk robots.txt
+// @Summary robots.txt
+// @Description Aturan crawl global. Hanya katalog publik yang boleh di-crawl, kontrol index per katalog lewat meta robots / X-Robots-Tag. Menyertakan lokasi /sitemap.xml
+// @Tags seo
+// @Produce plain
+// @Success 200 {string} string
+// @Router /robots.txt [get]
+func (h *RobotsHandler) RobotsTxt(c *gin.Context) {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+
+	if h.disallowAll {
+		b.WriteString("Disallow: /\n")
+	} else {
+		// Katalog private tetap boleh di-crawl agar crawler membaca noindex-nya
+		fmt.Fprintf(&b, "Allow: %s/c/\n", h.apiPrefix)
+		fmt.Fprintf(&b, "Disallow: %s/\n", h.apiPrefix)
+		b.WriteString("Disallow: /uploads/\n")
+
+		scheme := "http"
+		if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+			scheme = "https"
+		}
+		fmt.Fprintf(&b, "\nSitemap: %s://%s/sitemap.xml\n", scheme, c.Request.Host)
+	}
+
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(b.String()))
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_catalog/dto"
+	"github.com/atam/atamlink/internal/mod_catalog/usecase"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// SitemapHandler handler untuk sitemap.xml katalog publik
+type SitemapHandler struct {
+	catalogUC usecase.CatalogUseCase
+	apiPrefix string
+}
+
+// NewSitemapHandler membuat instance sitemap handler baru
+func NewSitemapHandler(catalogUC usecase.CatalogUseCase, apiPrefix string) *SitemapHandler {
+	return &SitemapHandler{
+		catalogUC: catalogUC,
+		apiPrefix: strings.TrimRight(apiPrefix, "/"),
+	}
+}
+
+// Sitemap handler untuk sitemap.xml global
+// @Summary sitemap.xml
+// @Description URL semua katalog publik yang boleh diindex beserta detail card yang tampil, dengan lastmod. URL mengikuti PUBLIC_CATALOG_URL / PUBLIC_CARD_URL, maksimal 50.000 URL
+// @Tags seo
+// @Produce xml
+// @Success 200 {string} string
+// @Failure 500 {object} utils.Response
+// @Router /sitemap.xml [get]
+func (h *SitemapHandler) Sitemap(c *gin.Context) {
+	h.write(c, 0)
+}
+
+// BusinessSitemap handler untuk sitemap.xml per business
+// @Summary Business sitemap.xml
+// @Description Sama seperti /sitemap.xml tetapi hanya katalog milik satu business
+// @Tags seo
+// @Produce xml
+// @Param id path int true "Business ID"
+// @Success 200 {string} string
+// @Failure 400 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /businesses/{id}/sitemap.xml [get]
+func (h *SitemapHandler) BusinessSitemap(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		utils.BadRequest(c, "ID bisnis tidak valid")
+		return
+	}
+
+	h.write(c, id)
+}
+
+// write stream sitemap ke response. Header baru dikirim saat URL pertama siap
+// supaya error di awal masih bisa dibalas dengan status yang benar
+func (h *SitemapHandler) write(c *gin.Context, businessID int64) {
+	var w *bufio.Writer
+	start := func() {
+		c.Header("Content-Type", "application/xml; charset=utf-8")
+		c.Status(http.StatusOK)
+		w = bufio.NewWriter(c.Writer)
+		io.WriteString(w, xml.Header)
+		io.WriteString(w, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`+"\n")
+	}
+
+	err := h.catalogUC.StreamSitemap(c.Request.Context(), businessID, h.baseURL(c), func(url *dto.SitemapURL) error {
+		if w == nil {
+			start()
+		}
+		io.WriteString(w, "<url><loc>")
+		xml.EscapeText(w, []byte(url.Loc))
+		io.WriteString(w, "</loc><lastmod>")
+		io.WriteString(w, url.LastMod.UTC().Format(time.RFC3339))
+		_, err := io.WriteString(w, "</lastmod></url>\n")
+		return err
+	})
+	if err != nil && w == nil {
+		if appErr, ok := err.(*errors.AppError); ok {
+			utils.Error(c, appErr.StatusCode, appErr.Message)
+			return
+		}
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+		return
+	}
+	if err != nil {
+		// Response sudah terkirim sebagian, XML sengaja dibiarkan tidak lengkap
+		// supaya crawler tidak menganggap daftar terpotong sebagai sitemap utuh
+		c.Error(err)
+		w.Flush()
+		return
+	}
+
+	if w == nil {
+		start()
+	}
+	io.WriteString(w, "</urlset>\n")
+	w.Flush()
+}
+
+// baseURL URL dasar API dari request, dipakai jika template URL publik tidak diset
+func (h *SitemapHandler) baseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + h.apiPrefix
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_status/dto"
+	"github.com/atam/atamlink/internal/mod_status/usecase"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// StatusHandler handler untuk status page publik dan insiden
+type StatusHandler struct {
+	statusUC  usecase.StatusUseCase
+	validator *utils.Validator
+}
+
+// NewStatusHandler membuat instance status handler baru
+func NewStatusHandler(statusUC usecase.StatusUseCase, validator *utils.Validator) *StatusHandler {
+	return &StatusHandler{
+		statusUC:  statusUC,
+		validator: validator,
+	}
+}
+
+// GetStatus handler untuk data status page publik
+// @Summary Get service status
+// @Description Status komponen (API, uploads, halaman publik) dari health check terakhir, uptime 24 jam/7 hari/30 hari dan insiden aktif atau yang baru selesai
+// @Tags status
+// @Produce json
+// @Success 200 {object} utils.Response{data=dto.StatusResponse}
+// @Failure 500 {object} utils.Response
+// @Router /status [get]
+func (h *StatusHandler) GetStatus(c *gin.Context) {
+	status, err := h.statusUC.GetStatus()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	// Dibaca status page marketing, cukup di-cache sebentar di CDN/browser
+	c.Header("Cache-Control", "public, max-age=30")
+
+	utils.OK(c, "Status layanan berhasil diambil", status)
+}
+
+// CreateIncident handler untuk membuat insiden
+// @Summary Create incident
+// @Description Buat insiden yang tampil di status page
+// @Tags status
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param body body dto.CreateIncidentRequest true "Incident data"
+// @Success 201 {object} utils.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/status/incidents [post]
+func (h *StatusHandler) CreateIncident(c *gin.Context) {
+	// Bind request
+	var req dto.CreateIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	incident, err := h.statusUC.CreateIncident(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.Created(c, "Insiden berhasil dibuat", incident)
+}
+
+// ListIncidents handler untuk list insiden (admin)
+// @Summary List incidents
+// @Description Get list insiden, terbaru lebih dulu
+// @Tags status
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param page query int false "Page number" default(1)
+// @Param per_page query int false "Items per page" default(20)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.IncidentResponse}
+// @Failure 401 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/status/incidents [get]
+func (h *StatusHandler) ListIncidents(c *gin.Context) {
+	paginationParams := utils.GetPaginationParams(c)
+
+	incidents, total, err := h.statusUC.ListIncidents(paginationParams.Page, paginationParams.PerPage)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	meta := utils.GetPaginationMeta(paginationParams.Page, paginationParams.PerPage, total)
+	utils.SuccessPaginated(c, 200, "Data insiden berhasil diambil", incidents, meta)
+}
+
+// GetIncidentByID handler untuk get insiden by ID
+// @Summary Get incident by ID
+// @Description Get detail insiden
+// @Tags status
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Incident ID"
+// @Success 200 {object} utils.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Router /admin/status/incidents/{id} [get]
+func (h *StatusHandler) GetIncidentByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID insiden tidak valid")
+		return
+	}
+
+	incident, err := h.statusUC.GetIncidentByID(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Data insiden berhasil diambil", incident)
+}
+
+// UpdateIncident handler untuk update insiden
+// @Summary Update incident
+// @Description Update insiden, status resolved mengisi waktu selesai
+// @Tags status
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Incident ID"
+// @Param body body dto.UpdateIncidentRequest true "Update data"
+// @Success 200 {object} utils.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/status/incidents/{id} [put]
+func (h *StatusHandler) UpdateIncident(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID insiden tidak valid")
+		return
+	}
+
+	// Bind request
+	var req dto.UpdateIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, constant.ErrMsgBadRequest)
+		return
+	}
+
+	// Validate request
+	if errors := h.validator.Validate(req); len(errors) > 0 {
+		utils.ValidationError(c, errors)
+		return
+	}
+
+	incident, err := h.statusUC.UpdateIncident(c, id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.OK(c, "Insiden berhasil diperbarui", incident)
+}
+
+// DeleteIncident handler untuk hapus insiden
+// @Summary Delete incident
+// @Description Hapus insiden
+// @Tags status
+// @Param X-Admin-Token header string true "Admin token"
+// @Param id path int true "Incident ID"
+// @Success 204 {object} nil
+// @Failure 400 {object} utils.Response
+// @Failure 401 {object} utils.Response
+// @Failure 404 {object} utils.Response
+// @Failure 500 {object} utils.Response
+// @Router /admin/status/incidents/{id} [delete]
+func (h *StatusHandler) DeleteIncident(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		utils.BadRequest(c, "ID insiden tidak valid")
+		return
+	}
+
+	if err := h.statusUC.DeleteIncident(c, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// handleError menangani error dari use case
+func (h *StatusHandler) handleError(c *gin.Context, err error) {
+	if appErr, ok := err.(*errors.AppError); ok {
+		utils.Error(c, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		utils.NotFound(c, constant.ErrMsgIncidentNotFound)
+	default:
+		utils.InternalServerError(c, constant.ErrMsgInternalServer)
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/service"
+)
+
+// APIUsage middleware pencatat pemakaian API service account (endpoint, status,
+// latency, bytes). Dipasang setelah ServiceAccountAuth, request user biasa dilewati
+func APIUsage(usageService service.APIUsageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !usageService.Enabled() {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		account, ok := GetServiceAccount(c)
+		if !ok {
+			return
+		}
+
+		usageService.Record(service.APIUsageRecord{
+			BusinessID:       account.BusinessID,
+			ServiceAccountID: account.ID,
+			Method:           c.Request.Method,
+			Endpoint:         c.FullPath(),
+			Status:           c.Writer.Status(),
+			Latency:          time.Since(start),
+			BytesIn:          c.Request.ContentLength,
+			BytesOut:         int64(c.Writer.Size()),
+			At:               start,
+		})
+	}
+}
@@ -10,11 +10,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/atam/atamlink/internal/constant"
 	"github.com/atam/atamlink/internal/service"
 )
 
 const GinKeyAuditOldData = "audit_old_data"
 
+// AuditActionInviteCancel aksi audit invite yang dibatalkan owner/admin
+const AuditActionInviteCancel = "INVITE_CANCELLED"
+
 // AuditConfig konfigurasi untuk audit middleware
 type AuditConfig struct {
 	// Skip audit untuk paths tertentu
@@ -35,6 +39,8 @@ func DefaultAuditConfig() *AuditConfig {
 		SkipPaths: []string{
 			"/health",
 			"/health/db",
+			"/healthz",
+			"/readyz",
 			"/metrics",
 			"/swagger",
 		},
@@ -136,7 +142,17 @@ func Audit(auditService service.AuditService, config *AuditConfig) gin.HandlerFu
 				"duration_ms":  time.Since(start).Milliseconds(),
 				"client_ip":    c.ClientIP(),
 				"user_agent":   c.Request.UserAgent(),
-				"request_id":   c.GetString("requestID"),
+				"request_id":   c.GetString(GinKeyRequestID),
+			}
+
+			// Aksi service account dicatat atas nama service account, bukan profile
+			if account, ok := GetServiceAccount(c); ok {
+				ctx["actor"] = constant.PrincipalServiceAccount
+				ctx["service_account_id"] = account.ID
+				ctx["service_account"] = account.Name
+				if businessID == nil {
+					businessID = &account.BusinessID
+				}
 			}
 
 			// // Add response body if configured
@@ -158,6 +174,7 @@ func Audit(auditService service.AuditService, config *AuditConfig) gin.HandlerFu
 					// responseBody, _ = json.Marshal(blw.body.String())
 					responseBody = json.RawMessage(strconv.Quote(blw.body.String()))
 				}
+				responseBody = redactAuditSecrets(responseBody)
 			}
 
 			// Create audit entry
@@ -192,6 +209,46 @@ func Audit(auditService service.AuditService, config *AuditConfig) gin.HandlerFu
 	}
 }
 
+// auditSecretFields field data response yang tidak boleh tersimpan di audit log
+// (token step-up, token service account, secret authenticator)
+var auditSecretFields = []string{"token", "secret", "uri"}
+
+// redactAuditSecrets ganti nilai field rahasia di data response
+func redactAuditSecrets(body json.RawMessage) json.RawMessage {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return body
+	}
+
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(envelope["data"], &data); err != nil {
+		return body
+	}
+
+	redacted := false
+	for _, field := range auditSecretFields {
+		if _, ok := data[field]; ok {
+			data[field] = json.RawMessage(`"[REDACTED]"`)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return body
+	}
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	envelope["data"] = dataJSON
+
+	result, err := json.Marshal(envelope)
+	if err != nil {
+		return body
+	}
+	return result
+}
+
 // shouldSkipAudit check apakah request harus di-skip dari audit
 func shouldSkipAudit(c *gin.Context, config *AuditConfig) bool {
 	// Skip by path
@@ -222,11 +279,42 @@ func determineAction(c *gin.Context) string {
 			return "INVITE_USED"
 		} else if strings.Contains(path, "/invites") {
 			return "INVITE_SENT"
+		} else if strings.Contains(path, "/backups/") && strings.HasSuffix(path, "/restore") {
+			return "BACKUP_RESTORED"
+		} else if strings.Contains(path, "/catalogs/") && strings.HasSuffix(path, "/restore") {
+			return "CATALOG_RESTORED"
+		} else if strings.HasSuffix(path, "/publish-requests") {
+			return "PUBLISH_REQUESTED"
+		} else if strings.Contains(path, "/publish-requests/") && strings.HasSuffix(path, "/approve") {
+			return "PUBLISH_APPROVED"
+		} else if strings.Contains(path, "/publish-requests/") && strings.HasSuffix(path, "/request-changes") {
+			return "PUBLISH_CHANGES_REQUESTED"
+		} else if strings.HasSuffix(path, "/ip-allowlist/break-glass") {
+			return "IP_ALLOWLIST_BREAK_GLASS"
+		} else if strings.HasSuffix(path, "/cache/purge") {
+			return "CACHE_PURGE"
+		} else if strings.HasSuffix(path, "/qr") {
+			// Generate ulang QR hanya mengganti qr_url katalog
+			return "UPDATE"
 		}
 		return "CREATE"
 	case "PUT", "PATCH":
+		if strings.HasSuffix(path, "/sections/reorder") {
+			return "SECTION_REORDER"
+		}
 		return "UPDATE"
 	case "DELETE":
+		// Membatalkan jadwal publish/harga mengubah status, bukan menghapus data
+		if strings.HasSuffix(path, "/publish-schedule") || strings.Contains(path, "/price-schedules/") {
+			return "UPDATE"
+		}
+		if strings.Contains(path, "/catalogs/") && strings.HasSuffix(path, "/purge") {
+			return "CATALOG_PURGED"
+		}
+		// Invite yang dibatalkan hanya berubah status, baris tidak dihapus
+		if strings.Contains(path, "/invites/") {
+			return AuditActionInviteCancel
+		}
 		return "DELETE"
 	default:
 		return method
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -21,6 +22,12 @@ type AuthUser struct {
 // Auth middleware untuk autentikasi (placeholder untuk integrasi dengan auth service)
 func Auth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Sudah diautentikasi sebagai service account
+		if _, ok := GetServiceAccount(c); ok {
+			c.Next()
+			return
+		}
+
 		// Get token from header
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -50,6 +57,12 @@ func Auth() gin.HandlerFunc {
 // AuthBypass middleware untuk bypass auth di development
 func AuthBypass(userID string, profileID int64) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Sudah diautentikasi sebagai service account
+		if _, ok := GetServiceAccount(c); ok {
+			c.Next()
+			return
+		}
+
 		// Set dummy auth user
 		authUser := AuthUser{
 			UserID:    userID,
@@ -104,6 +117,24 @@ func GetProfileID(c *gin.Context) (int64, bool) {
 	return id, ok
 }
 
+// AdminToken middleware untuk endpoint admin, token dikirim lewat header X-Admin-Token.
+// Token kosong berarti endpoint admin nonaktif (404).
+func AdminToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			utils.Abort(c, 404, "Endpoint tidak ditemukan")
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Admin-Token")), []byte(token)) != 1 {
+			utils.Abort(c, 401, "Admin token tidak valid")
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // RequireRole middleware untuk check role (akan diimplementasi nanti)
 func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
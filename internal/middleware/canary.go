@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+)
+
+const GinKeyVariant = "variant"
+
+// Canary middleware pembagian traffic stable/canary. Header internal lebih
+// diutamakan dari cookie; pengunjung baru diundi lalu diberi cookie supaya
+// tetap di variant yang sama. Metrics request dicatat per variant
+func Canary(canaryService service.CanaryService, cfg config.CanaryConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !canaryService.Enabled() {
+			c.Next()
+			return
+		}
+
+		requested := c.GetHeader(cfg.Header)
+		fromHeader := requested != ""
+		if !fromHeader {
+			requested, _ = c.Cookie(cfg.Cookie)
+		}
+
+		variant := canaryService.Assign(requested)
+		if !fromHeader && requested != variant {
+			c.SetCookie(cfg.Cookie, variant, int(cfg.CookieTTL/time.Second), "/", "", false, true)
+		}
+
+		c.Set(GinKeyVariant, variant)
+		c.Header(constant.HeaderVariant, variant)
+
+		start := time.Now()
+		c.Next()
+
+		canaryService.Observe(variant, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// GetVariant variant request saat ini, stable jika canary tidak aktif
+func GetVariant(c *gin.Context) string {
+	if value, exists := c.Get(GinKeyVariant); exists {
+		if variant, ok := value.(string); ok {
+			return variant
+		}
+	}
+	return constant.VariantStable
+}
+
+// FeatureEnabled check apakah feature flag aktif untuk request saat ini
+func FeatureEnabled(c *gin.Context, canaryService service.CanaryService, flag string) bool {
+	return canaryService.FeatureEnabled(GetVariant(c), flag)
+}
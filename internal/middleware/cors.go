@@ -1,19 +1,25 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 
 	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/service"
 )
 
-// CORS setup CORS middleware
-func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+// CORS setup CORS middleware. Request GET ke publicPaths (prefix path) boleh
+// dari origin mana pun tanpa credentials, mis. widget embed di website merchant.
+// Origin yang terdaftar tetap memakai aturan biasa agar cookie ikut terkirim
+func CORS(cfg config.CORSConfig, publicPaths ...string) gin.HandlerFunc {
 	corsConfig := cors.Config{
 		AllowOrigins:     cfg.AllowedOrigins,
 		AllowMethods:     cfg.AllowedMethods,
 		AllowHeaders:     cfg.AllowedHeaders,
 		AllowCredentials: cfg.AllowCredentials,
+		ExposeHeaders:    []string{service.HeaderRequestID, "ETag"}, // dashboard bisa menampilkan ID untuk laporan error
 		MaxAge:           86400, // 24 hours
 	}
 
@@ -23,5 +29,49 @@ func CORS(cfg config.CORSConfig) gin.HandlerFunc {
 		corsConfig.AllowOrigins = nil
 	}
 
-	return cors.New(corsConfig)
-}
\ No newline at end of file
+	restricted := cors.New(corsConfig)
+	if len(publicPaths) == 0 {
+		return restricted
+	}
+
+	public := cors.New(cors.Config{
+		AllowAllOrigins: true,
+		AllowMethods:    []string{"GET", "HEAD", "OPTIONS"},
+		AllowHeaders:    []string{"Origin", "Accept", "If-None-Match"},
+		ExposeHeaders:   []string{"ETag", "X-Embed-Version"},
+		MaxAge:          86400,
+	})
+
+	allowed := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		allowed[origin] = true
+	}
+
+	return func(c *gin.Context) {
+		if !allowed[c.GetHeader("Origin")] && isPublicCORSRequest(c, publicPaths) {
+			public(c)
+			return
+		}
+		restricted(c)
+	}
+}
+
+// isPublicCORSRequest check request baca (atau preflight-nya) ke path publik
+func isPublicCORSRequest(c *gin.Context, publicPaths []string) bool {
+	method := c.Request.Method
+	if method == "OPTIONS" {
+		method = c.GetHeader("Access-Control-Request-Method")
+	}
+	switch method {
+	case "GET", "HEAD":
+	default:
+		return false
+	}
+
+	for _, prefix := range publicPaths {
+		if strings.HasPrefix(c.Request.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+const (
+	// HeaderIdempotencyKey header yang dikirim client untuk request POST yang aman diulang
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed penanda response berasal dari request sebelumnya
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyResponseWriter custom response writer untuk capture response yang akan di-replay
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Idempotency middleware untuk header Idempotency-Key pada request POST, misal
+// retry dari aplikasi mobile saat koneksi putus. Key di-scope per principal dan route,
+// response 2xx disimpan lalu di-replay untuk request ulang dengan body yang sama.
+// Store error tidak memblokir request.
+func Idempotency(store service.IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if key == "" || c.Request.Method != http.MethodPost || !store.Enabled() {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			utils.Abort(c, 400, constant.ErrMsgIdempotencyKeyInvalid)
+			return
+		}
+
+		var principal string
+		if account, ok := GetServiceAccount(c); ok {
+			principal = fmt.Sprintf("sa:%d", account.ID)
+		} else if profileID, ok := GetProfileID(c); ok {
+			principal = fmt.Sprintf("profile:%d", profileID)
+		} else {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Next()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewBuffer(body))
+
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method))
+		hash.Write([]byte{0})
+		hash.Write([]byte(c.Request.URL.RequestURI()))
+		hash.Write([]byte{0})
+		hash.Write(body)
+		requestHash := hex.EncodeToString(hash.Sum(nil))
+
+		scopedKey := principal + ":" + c.Request.Method + ":" + c.FullPath() + ":" + key
+
+		record, found, err := store.Begin(scopedKey, requestHash)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		if found {
+			switch {
+			case record.RequestHash != requestHash:
+				utils.Abort(c, 422, constant.ErrMsgIdempotencyKeyReused)
+			case record.InProgress():
+				c.Header("Retry-After", "1")
+				utils.Abort(c, 409, constant.ErrMsgIdempotencyInProgress)
+			default:
+				c.Header(HeaderIdempotentReplayed, "true")
+				c.Data(record.Status, record.ContentType, record.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		writer := &idempotencyResponseWriter{
+			ResponseWriter: c.Writer,
+			body:           &bytes.Buffer{},
+		}
+		c.Writer = writer
+
+		c.Next()
+
+		status := writer.Status()
+		if status < 200 || status >= 300 {
+			// Request gagal boleh dicoba ulang dengan key yang sama
+			store.Release(scopedKey)
+			return
+		}
+
+		store.Complete(scopedKey, &service.IdempotencyRecord{
+			RequestHash: requestHash,
+			Status:      status,
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// TrackInFlight middleware penghitung request yang sedang diproses untuk load shedding.
+// Dipasang global supaya semua request ikut dihitung
+func TrackInFlight(shedder service.LoadShedder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !shedder.Enabled() {
+			c.Next()
+			return
+		}
+
+		done := shedder.Begin()
+		defer done()
+		c.Next()
+	}
+}
+
+// LoadShed middleware penolak request prioritas rendah (503) saat resource tertekan,
+// dipasang per rute supaya request penting tetap dilayani
+func LoadShed(shedder service.LoadShedder, retryAfter time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if overloaded, reason := shedder.Overloaded(); overloaded {
+			shedder.Shed(reason)
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			utils.Abort(c, 503, constant.ErrMsgServiceOverloaded)
+			return
+		}
+
+		c.Next()
+	}
+}
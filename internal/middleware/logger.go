@@ -2,14 +2,18 @@ package middleware
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/atam/atamlink/internal/service"
 	"github.com/atam/atamlink/pkg/logger"
 )
 
+const GinKeyRequestID = "requestID"
+
 // bodyLogWriter untuk capture response body
 type bodyLogWriter struct {
 	gin.ResponseWriter
@@ -27,12 +31,14 @@ func Logger(log logger.Logger) gin.HandlerFunc {
 		// Start timer
 		start := time.Now()
 
-		// Request ID
-		requestID := c.GetHeader("X-Request-ID")
+		// Request ID, dikembalikan di response dan diteruskan ke panggilan keluar
+		requestID := c.GetHeader(service.HeaderRequestID)
 		if requestID == "" {
 			requestID = generateRequestID()
 		}
-		c.Set("requestID", requestID)
+		c.Set(GinKeyRequestID, requestID)
+		c.Header(service.HeaderRequestID, requestID)
+		c.Request = c.Request.WithContext(service.WithRequestID(c.Request.Context(), requestID))
 
 		// Log request
 		path := c.Request.URL.Path
@@ -107,6 +113,21 @@ func Logger(log logger.Logger) gin.HandlerFunc {
 	}
 }
 
+// GetRequestID request ID dari context, kosong tanpa gin context (job background)
+func GetRequestID(c *gin.Context) string {
+	if c == nil {
+		return ""
+	}
+	return c.GetString(GinKeyRequestID)
+}
+
+// RequestContext context untuk panggilan ke sistem luar yang membawa request ID.
+// Sengaja tidak mewarisi pembatalan request supaya panggilan keluar yang sudah
+// berjalan (misal pembuatan invoice) tidak terputus saat client menutup koneksi
+func RequestContext(c *gin.Context) context.Context {
+	return service.WithRequestID(context.Background(), GetRequestID(c))
+}
+
 // generateRequestID generate unique request ID
 func generateRequestID() string {
 	return time.Now().Format("20060102150405") + "-" + generateRandomString(8)
@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_business/entity"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// MemberRepository sumber data member business untuk load permission
+type MemberRepository interface {
+	GetUserByBusinessAndProfile(ctx context.Context, businessID, profileID int64) (*entity.BusinessUser, error)
+	GetIPAllowlist(ctx context.Context, businessID int64) (*entity.IPAllowlist, error)
+}
+
+// PreloadPermissions middleware untuk memasang PermissionLoader request di context
+// pada semua route terautentikasi. Untuk route dengan prefix businessPath,
+// permission business di param :id langsung di-load supaya use case tidak
+// lookup role berulang
+func PreloadPermissions(repo MemberRepository, businessPath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		loader := &requestPermissions{
+			c:          c,
+			repo:       repo,
+			perms:      make(map[int64]constant.PermissionSet),
+			allowlists: make(map[int64]*entity.IPAllowlist),
+		}
+		c.Request = c.Request.WithContext(service.WithPermissionLoader(c.Request.Context(), loader))
+
+		if !strings.HasPrefix(c.FullPath(), businessPath) {
+			c.Next()
+			return
+		}
+
+		profileID, ok := GetProfileID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		// Param tidak valid dibiarkan, handler yang akan menolak
+		businessID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		// IP allowlist dicek saat use case memeriksa permission
+		if _, err := loader.load(businessID, profileID); err != nil {
+			utils.Abort(c, 500, constant.ErrMsgInternalServer)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requestPermissions PermissionLoader untuk satu request, permission set dan
+// IP allowlist per business di-cache selama request. Permission nil berarti
+// profile bukan member aktif business tersebut
+type requestPermissions struct {
+	c          *gin.Context
+	repo       MemberRepository
+	perms      map[int64]constant.PermissionSet
+	allowlists map[int64]*entity.IPAllowlist
+}
+
+// LoadPermissions permission set profile di business, nil jika bukan member aktif.
+// Untuk request tulis, IP client harus lolos IP allowlist business
+func (p *requestPermissions) LoadPermissions(businessID, profileID int64) (constant.PermissionSet, error) {
+	perms, err := p.load(businessID, profileID)
+	if err != nil || perms == nil {
+		return perms, err
+	}
+
+	if err := p.checkIPAllowlist(businessID); err != nil {
+		return nil, err
+	}
+
+	return perms, nil
+}
+
+// LoadMemberPermissions permission set profile di business tanpa pengecekan IP allowlist
+func (p *requestPermissions) LoadMemberPermissions(businessID, profileID int64) (constant.PermissionSet, error) {
+	return p.load(businessID, profileID)
+}
+
+// load permission set tanpa pengecekan IP allowlist
+func (p *requestPermissions) load(businessID, profileID int64) (constant.PermissionSet, error) {
+	// Service account hanya punya akses ke business pemiliknya
+	if account, ok := GetServiceAccount(p.c); ok {
+		if account.BusinessID != businessID {
+			return nil, nil
+		}
+		return constant.PermissionsForRole(account.Role), nil
+	}
+
+	if perms, ok := p.perms[businessID]; ok {
+		return perms, nil
+	}
+
+	user, err := p.repo.GetUserByBusinessAndProfile(p.c.Request.Context(), businessID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var perms constant.PermissionSet
+	if user != nil && user.IsActive {
+		perms = constant.PermissionsForRole(user.Role)
+	}
+	p.perms[businessID] = perms
+
+	return perms, nil
+}
+
+// checkIPAllowlist tolak operasi tulis dari IP di luar allowlist business.
+// Request baca tidak dicek.
+func (p *requestPermissions) checkIPAllowlist(businessID int64) error {
+	if p.c.Request == nil || isSafeMethod(p.c.Request.Method) {
+		return nil
+	}
+
+	allowlist, ok := p.allowlists[businessID]
+	if !ok {
+		var err error
+		allowlist, err = p.repo.GetIPAllowlist(p.c.Request.Context(), businessID)
+		if err != nil {
+			return err
+		}
+		p.allowlists[businessID] = allowlist
+	}
+
+	if !allowlist.Allows(p.c.ClientIP(), time.Now()) {
+		return errors.New(errors.ErrForbidden, constant.ErrMsgIPNotAllowed, 403)
+	}
+
+	return nil
+}
+
+// isSafeMethod method HTTP yang tidak mengubah data
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// Header berisi challenge dari GET /pow/{action} dan nonce hasil pencarian client
+const (
+	HeaderPoWChallenge = "X-PoW-Challenge"
+	HeaderPoWNonce     = "X-PoW-Nonce"
+)
+
+// RequireProofOfWork middleware untuk endpoint tulis publik, request harus
+// membawa challenge action yang sama beserta nonce yang valid. Challenge
+// hanya bisa dipakai sekali. Tidak melakukan apa-apa jika proof-of-work
+// tidak diaktifkan.
+func RequireProofOfWork(pow service.ProofOfWork, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !pow.Enabled() {
+			c.Next()
+			return
+		}
+
+		challenge := c.GetHeader(HeaderPoWChallenge)
+		nonce := c.GetHeader(HeaderPoWNonce)
+		if challenge == "" || !pow.Verify(action, challenge, nonce) {
+			utils.Abort(c, 428, constant.ErrMsgProofOfWorkRequired)
+			return
+		}
+
+		c.Next()
+	}
+}
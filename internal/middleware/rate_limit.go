@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// RateLimit middleware pembatas request per principal (profile atau service account).
+// Limit dasar dikali multiplier sesuai role principal, misal service account untuk
+// sync ERP mendapat limit lebih tinggi. Redis error tidak memblokir request.
+func RateLimit(limiter service.RateLimiter, cfg config.RateLimitConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.Enabled || !limiter.Enabled() {
+			c.Next()
+			return
+		}
+
+		var key, role string
+		if account, ok := GetServiceAccount(c); ok {
+			key = fmt.Sprintf("sa:%d", account.ID)
+			role = constant.PrincipalServiceAccount
+		} else if profileID, ok := GetProfileID(c); ok {
+			key = fmt.Sprintf("profile:%d", profileID)
+			role = constant.PrincipalUser
+		} else {
+			c.Next()
+			return
+		}
+
+		limit := cfg.Requests
+		if multiplier, ok := cfg.Multipliers[role]; ok && multiplier > 0 {
+			limit = int(float64(cfg.Requests) * multiplier)
+		}
+
+		allowed, remaining, err := limiter.Allow(key, limit)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(cfg.Window.Seconds())))
+			utils.Abort(c, 429, constant.ErrMsgRateLimited)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_business/entity"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+const GinKeyServiceAccount = "service_account"
+
+// serviceAccountTouchInterval last_used_at cukup diperbarui sesekali, sync ERP bisa
+// mengirim banyak request beruntun
+const serviceAccountTouchInterval = time.Minute
+
+// ServiceAccountRepository sumber data service account untuk autentikasi token
+type ServiceAccountRepository interface {
+	GetServiceAccountByTokenHash(ctx context.Context, tokenHash string) (*entity.ServiceAccount, error)
+	TouchServiceAccount(ctx context.Context, id int64, usedAt time.Time) error
+}
+
+// PlanFeatureChecker cek feature plan aktif business
+type PlanFeatureChecker interface {
+	CheckFeature(ctx context.Context, businessID int64, feature string) error
+}
+
+// ServiceAccountAuth middleware autentikasi service account lewat header
+// "Authorization: Bearer sa_...". Request dengan token lain diteruskan ke Auth.
+// Service account tidak punya profile, profile_id diisi 0 dan permission
+// diambil dari role service account (lihat PreloadPermissions). Business pemiliknya
+// disimpan di context request supaya use case membatasi list dan akses ke
+// business tersebut (lihat service.ServiceAccountScope). Plan business harus
+// tetap punya api_access, service account lama berhenti bekerja setelah downgrade.
+func ServiceAccountAuth(repo ServiceAccountRepository, plans PlanFeatureChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !strings.HasPrefix(token, constant.ServiceAccountTokenPrefix) {
+			c.Next()
+			return
+		}
+
+		account, err := repo.GetServiceAccountByTokenHash(c.Request.Context(), HashServiceAccountToken(token))
+		if err != nil {
+			utils.Abort(c, 500, constant.ErrMsgInternalServer)
+			return
+		}
+		if account == nil || !account.IsActive {
+			utils.Abort(c, 401, constant.ErrMsgTokenInvalid)
+			return
+		}
+
+		if err := plans.CheckFeature(c.Request.Context(), account.BusinessID, constant.PlanFeatureAPIAccess); err != nil {
+			if appErr, ok := err.(*errors.AppError); ok {
+				utils.Abort(c, appErr.StatusCode, appErr.Message)
+				return
+			}
+			utils.Abort(c, 500, constant.ErrMsgInternalServer)
+			return
+		}
+
+		now := time.Now()
+		if account.LastUsedAt == nil || now.Sub(*account.LastUsedAt) > serviceAccountTouchInterval {
+			_ = repo.TouchServiceAccount(c.Request.Context(), account.ID, now)
+		}
+
+		c.Set(GinKeyServiceAccount, account)
+		c.Set("profile_id", int64(0))
+		c.Request = c.Request.WithContext(service.WithServiceAccountScope(c.Request.Context(), account.BusinessID))
+
+		c.Next()
+	}
+}
+
+// GetServiceAccount service account yang mengautentikasi request
+func GetServiceAccount(c *gin.Context) (*entity.ServiceAccount, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	value, exists := c.Get(GinKeyServiceAccount)
+	if !exists {
+		return nil, false
+	}
+
+	account, ok := value.(*entity.ServiceAccount)
+	return account, ok
+}
+
+// HashServiceAccountToken hash token yang disimpan di database
+func HashServiceAccountToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
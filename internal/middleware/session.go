@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_auth/entity"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+const GinKeySessionID = "session_id"
+
+// sessionTouchInterval aktivitas terakhir sesi cukup diperbarui sesekali
+const sessionTouchInterval = time.Minute
+
+// maxUserAgentLength batas panjang user agent yang disimpan
+const maxUserAgentLength = 500
+
+// SessionStore penyimpanan sesi token bearer
+type SessionStore interface {
+	GetSessionByTokenHash(tokenHash string) (*entity.Session, error)
+	CreateSession(session *entity.Session) error
+	TouchSession(id int64, ipAddress string, at time.Time) error
+}
+
+// TrackSession middleware pencatat sesi per token bearer (perangkat) setelah
+// Auth. Token dari sesi yang sudah dicabut ditolak. Request service account
+// dan request tanpa token (AUTH_BYPASS) tidak dicatat.
+func TrackSession(store SessionStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		profileID, _ := GetProfileID(c)
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if _, ok := GetServiceAccount(c); ok || profileID <= 0 || token == "" {
+			c.Next()
+			return
+		}
+
+		sum := sha256.Sum256([]byte(token))
+		tokenHash := hex.EncodeToString(sum[:])
+
+		session, err := store.GetSessionByTokenHash(tokenHash)
+		if err != nil {
+			utils.Abort(c, 500, constant.ErrMsgInternalServer)
+			return
+		}
+
+		now := time.Now()
+		if session == nil {
+			userAgent := c.Request.UserAgent()
+			if len(userAgent) > maxUserAgentLength {
+				userAgent = userAgent[:maxUserAgentLength]
+			}
+
+			session = &entity.Session{
+				ProfileID:    profileID,
+				TokenHash:    tokenHash,
+				IPAddress:    sql.NullString{String: c.ClientIP(), Valid: c.ClientIP() != ""},
+				UserAgent:    sql.NullString{String: userAgent, Valid: userAgent != ""},
+				CreatedAt:    now,
+				LastActiveAt: now,
+			}
+			if err := store.CreateSession(session); err != nil {
+				utils.Abort(c, 500, constant.ErrMsgInternalServer)
+				return
+			}
+		} else if !session.IsRevoked() && now.Sub(session.LastActiveAt) > sessionTouchInterval {
+			_ = store.TouchSession(session.ID, c.ClientIP(), now)
+		}
+
+		if session.IsRevoked() || session.ProfileID != profileID {
+			utils.Abort(c, 401, constant.ErrMsgSessionRevoked)
+			return
+		}
+
+		c.Set(GinKeySessionID, session.ID)
+		c.Next()
+	}
+}
+
+// GetSessionID ID sesi request saat ini
+func GetSessionID(c *gin.Context) (int64, bool) {
+	value, exists := c.Get(GinKeySessionID)
+	if !exists {
+		return 0, false
+	}
+
+	id, ok := value.(int64)
+	return id, ok
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// HeaderStepUpToken header berisi token dari POST /auth/step-up
+const HeaderStepUpToken = "X-Step-Up-Token"
+
+// StepUpVerifier pemakai token step-up
+type StepUpVerifier interface {
+	ConsumeStepUpToken(profileID int64, action, token string) (bool, error)
+}
+
+// RequireStepUp middleware untuk operasi destruktif, request harus membawa
+// token step-up untuk action yang sama. Token langsung hangus saat dipakai.
+// Tidak melakukan apa-apa jika step-up tidak diaktifkan.
+func RequireStepUp(verifier StepUpVerifier, enabled bool, action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled {
+			c.Next()
+			return
+		}
+
+		profileID, _ := GetProfileID(c)
+		token := c.GetHeader(HeaderStepUpToken)
+		if token == "" {
+			utils.Abort(c, 403, constant.ErrMsgStepUpRequired)
+			return
+		}
+
+		ok, err := verifier.ConsumeStepUpToken(profileID, action, token)
+		if err != nil {
+			utils.Abort(c, 500, constant.ErrMsgInternalServer)
+			return
+		}
+		if !ok {
+			utils.Abort(c, 403, constant.ErrMsgStepUpRequired)
+			return
+		}
+
+		c.Next()
+	}
+}
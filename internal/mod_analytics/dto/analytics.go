@@ -0,0 +1,220 @@
+package dto
+
+import "time"
+
+// RecordEventRequest event dari script tema katalog publik
+type RecordEventRequest struct {
+	Type  string `json:"type" validate:"required,oneof=view click link_click whatsapp_click checkout_click order_submitted"`
+	Token string `json:"token,omitempty" validate:"max=200"` // analytics_token dari response katalog publik
+
+	// Khusus view: document.referrer, hanya host yang disimpan
+	Referrer string `json:"referrer,omitempty" validate:"max=2000"`
+
+	// Khusus click: posisi relatif terhadap kotak section (0..1)
+	SectionID int64    `json:"section_id,omitempty" validate:"required_if=Type click,omitempty,gt=0"`
+	CardID    int64    `json:"card_id,omitempty" validate:"omitempty,gt=0"` // juga untuk event konversi
+	X         *float64 `json:"x,omitempty" validate:"required_if=Type click,omitempty,min=0,max=1"`
+	Y         *float64 `json:"y,omitempty" validate:"required_if=Type click,omitempty,min=0,max=1"`
+
+	// Khusus link_click: id link section links, atau id link detail card jika card_id diisi
+	LinkID int64 `json:"link_id,omitempty" validate:"required_if=Type link_click,omitempty,gt=0"`
+}
+
+// CatalogAnalyticsResponse ringkasan analytics katalog
+type CatalogAnalyticsResponse struct {
+	CatalogID      int64                      `json:"catalog_id"`
+	From           time.Time                  `json:"from"`
+	To             time.Time                  `json:"to"`
+	Views          int64                      `json:"views"`
+	RawViews       int64                      `json:"raw_views"`
+	BotViews       int64                      `json:"bot_views"`
+	UniqueVisitors int64                      `json:"unique_visitors"` // jumlah unique harian, pengunjung yang sama di hari berbeda dihitung lagi
+	Daily          []DailyViewsResponse       `json:"daily"`
+	Category       *CategoryBenchmarkResponse `json:"category,omitempty"` // kosong jika katalog belum berkategori
+	TopCards       []TopCardResponse          `json:"top_cards"`
+	TopLinks       []TopLinkResponse          `json:"top_links"`
+	Referrers      []ReferrerResponse         `json:"referrers"` // view manusia per host referrer
+}
+
+// TopCardResponse card dengan klik terbanyak
+type TopCardResponse struct {
+	CardID int64  `json:"card_id"`
+	Title  string `json:"title"`
+	Clicks int64  `json:"clicks"`
+}
+
+// TopLinkResponse link dengan klik terbanyak
+type TopLinkResponse struct {
+	LinkID    int64  `json:"link_id"`
+	CardID    int64  `json:"card_id,omitempty"` // diisi untuk link detail card
+	CardTitle string `json:"card_title,omitempty"`
+	Label     string `json:"label"` // display name link section, atau tipe link card
+	URL       string `json:"url"`
+	Clicks    int64  `json:"clicks"`
+}
+
+// ReferrerResponse view manusia dari satu host referrer
+type ReferrerResponse struct {
+	Host  string  `json:"host"` // kosong = direct / tanpa referrer
+	Views int64   `json:"views"`
+	Share float64 `json:"share"` // porsi dari total view manusia (0..1)
+}
+
+// CategoryBenchmarkResponse rata-rata katalog published dalam kategori yang sama
+type CategoryBenchmarkResponse struct {
+	ID                int64   `json:"id"`
+	Slug              string  `json:"slug"`
+	Name              string  `json:"name"`
+	Catalogs          int64   `json:"catalogs"`
+	AvgViews          float64 `json:"avg_views"`
+	AvgUniqueVisitors float64 `json:"avg_unique_visitors"`
+}
+
+// DailyViewsResponse view per hari
+type DailyViewsResponse struct {
+	Date           string `json:"date"` // YYYY-MM-DD
+	Views          int64  `json:"views"`
+	RawViews       int64  `json:"raw_views"`
+	UniqueVisitors int64  `json:"unique_visitors"`
+}
+
+// ClickHeatmapResponse distribusi klik per section
+type ClickHeatmapResponse struct {
+	CatalogID   int64                   `json:"catalog_id"`
+	From        time.Time               `json:"from"`
+	To          time.Time               `json:"to"`
+	GridSize    int                     `json:"grid_size"`
+	TotalClicks int64                   `json:"total_clicks"`
+	Sections    []SectionClicksResponse `json:"sections"`
+}
+
+// SectionClicksResponse klik pada satu section
+type SectionClicksResponse struct {
+	SectionID int64                `json:"section_id"`
+	Type      string               `json:"type"`
+	Clicks    int64                `json:"clicks"`
+	Share     float64              `json:"share"` // porsi dari total klik katalog (0..1)
+	Grid      [][]int64            `json:"grid"`  // grid[y][x]
+	Cards     []CardClicksResponse `json:"cards"`
+}
+
+// CardClicksResponse klik pada satu card
+type CardClicksResponse struct {
+	CardID int64  `json:"card_id"`
+	Title  string `json:"title"`
+	Clicks int64  `json:"clicks"`
+}
+
+// CreateGoalRequest request untuk membuat goal konversi
+type CreateGoalRequest struct {
+	Name   string `json:"name" validate:"required,min=1,max=100"`
+	Event  string `json:"event" validate:"required,oneof=whatsapp_click checkout_click order_submitted"`
+	CardID int64  `json:"card_id,omitempty" validate:"omitempty,gt=0"` // kosong = semua card
+}
+
+// GoalResponse response goal konversi
+type GoalResponse struct {
+	ID        int64     `json:"id"`
+	CatalogID int64     `json:"catalog_id"`
+	Name      string    `json:"name"`
+	Event     string    `json:"event"`
+	CardID    *int64    `json:"card_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GoalConversionsResponse laporan konversi semua goal katalog
+type GoalConversionsResponse struct {
+	CatalogID int64                `json:"catalog_id"`
+	From      time.Time            `json:"from"`
+	To        time.Time            `json:"to"`
+	Views     int64                `json:"views"`
+	Goals     []GoalReportResponse `json:"goals"`
+}
+
+// GoalReportResponse konversi satu goal
+type GoalReportResponse struct {
+	Goal        GoalResponse              `json:"goal"`
+	Conversions int64                     `json:"conversions"`
+	Rate        float64                   `json:"rate"` // conversions / views
+	Daily       []DailyConversionResponse `json:"daily"`
+}
+
+// DailyConversionResponse konversi goal per hari
+type DailyConversionResponse struct {
+	Date        string  `json:"date"` // YYYY-MM-DD
+	Views       int64   `json:"views"`
+	Conversions int64   `json:"conversions"`
+	Rate        float64 `json:"rate"`
+}
+
+// CardSaveRequest simpan card ke wishlist pengunjung
+type CardSaveRequest struct {
+	Token string `json:"token,omitempty" validate:"max=200"` // analytics_token dari response katalog publik
+}
+
+// CompareCardsRequest card yang sedang dibandingkan pengunjung
+type CompareCardsRequest struct {
+	CardIDs []int64 `json:"card_ids" validate:"required,min=2,max=4,dive,gt=0"`
+	Token   string  `json:"token,omitempty" validate:"max=200"`
+}
+
+// VisitorSavesResponse card yang disimpan pengunjung di katalog
+type VisitorSavesResponse struct {
+	CardIDs []int64 `json:"card_ids"` // terbaru dulu
+}
+
+// CardSavesResponse card paling banyak disimpan di katalog
+type CardSavesResponse struct {
+	CatalogID int64              `json:"catalog_id"`
+	From      time.Time          `json:"from"`
+	To        time.Time          `json:"to"`
+	Cards     []CardSaveResponse `json:"cards"`
+}
+
+// CardSaveResponse wishlist dan compare satu card
+type CardSaveResponse struct {
+	CardID   int64  `json:"card_id"`
+	Title    string `json:"title"`
+	Saved    int64  `json:"saved"`    // pengunjung yang saat ini menyimpan card
+	Saves    int64  `json:"saves"`    // event save dalam rentang
+	Compares int64  `json:"compares"` // pengunjung yang membandingkan card dalam rentang (unik per hari)
+}
+
+// APIUsageResponse pemakaian API service account business
+type APIUsageResponse struct {
+	BusinessID       int64                      `json:"business_id"`
+	ServiceAccountID int64                      `json:"service_account_id,omitempty"` // kosong = semua service account
+	From             time.Time                  `json:"from"`
+	To               time.Time                  `json:"to"`
+	Total            APIUsageStats              `json:"total"`
+	Daily            []APIUsageDailyResponse    `json:"daily"`
+	Endpoints        []APIUsageEndpointResponse `json:"endpoints"` // urut dari request terbanyak
+}
+
+// APIUsageStats agregat pemakaian API. Persentil latency berupa perkiraan
+// dari histogram (batas atas bucket)
+type APIUsageStats struct {
+	Requests     int64   `json:"requests"`
+	ClientErrors int64   `json:"client_errors"` // status 4xx
+	ServerErrors int64   `json:"server_errors"` // status 5xx
+	ErrorRate    float64 `json:"error_rate"`
+	BytesIn      int64   `json:"bytes_in"`
+	BytesOut     int64   `json:"bytes_out"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	P50LatencyMs int64   `json:"p50_latency_ms"`
+	P95LatencyMs int64   `json:"p95_latency_ms"`
+	P99LatencyMs int64   `json:"p99_latency_ms"`
+}
+
+// APIUsageDailyResponse pemakaian API per hari
+type APIUsageDailyResponse struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	APIUsageStats
+}
+
+// APIUsageEndpointResponse pemakaian API per endpoint dalam rentang
+type APIUsageEndpointResponse struct {
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+	APIUsageStats
+}
@@ -0,0 +1,197 @@
+package entity
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// CatalogDailyStat entity untuk tabel catalog_daily_stats
+type CatalogDailyStat struct {
+	CatalogID      int64     `json:"catalog_id" db:"cds_c_id"`
+	Date           time.Time `json:"date" db:"cds_date"`
+	RawViews       int64     `json:"raw_views" db:"cds_raw_views"` // semua event termasuk bot
+	Views          int64     `json:"views" db:"cds_views"`         // setelah filter bot
+	UniqueVisitors int64     `json:"unique_visitors" db:"cds_unique_visitors"`
+	UpdatedAt      time.Time `json:"updated_at" db:"cds_updated_at"`
+}
+
+// CategoryBenchmark total view katalog published dalam kategori yang sama
+type CategoryBenchmark struct {
+	CategoryID     int64
+	Slug           string
+	Name           string
+	Catalogs       int64
+	Views          int64
+	UniqueVisitors int64
+}
+
+// CatalogClickStat entity untuk tabel catalog_click_stats
+type CatalogClickStat struct {
+	CatalogID int64     `json:"catalog_id" db:"ccs_c_id"`
+	SectionID int64     `json:"section_id" db:"ccs_cs_id"`
+	CardID    int64     `json:"card_id" db:"ccs_cc_id"` // 0 = klik di luar card
+	Date      time.Time `json:"date" db:"ccs_date"`
+	BucketX   int       `json:"bucket_x" db:"ccs_bucket_x"`
+	BucketY   int       `json:"bucket_y" db:"ccs_bucket_y"`
+	Clicks    int64     `json:"clicks" db:"ccs_clicks"`
+}
+
+// CatalogGoal entity untuk tabel catalog_goals
+type CatalogGoal struct {
+	ID        int64         `json:"id" db:"cg_id"`
+	CatalogID int64         `json:"catalog_id" db:"cg_c_id"`
+	Name      string        `json:"name" db:"cg_name"`
+	Event     string        `json:"event" db:"cg_event"`
+	CardID    sql.NullInt64 `json:"card_id" db:"cg_cc_id"`
+	CreatedBy int64         `json:"created_by" db:"cg_created_by"`
+	CreatedAt time.Time     `json:"created_at" db:"cg_created_at"`
+}
+
+// DailyConversion jumlah konversi goal per hari
+type DailyConversion struct {
+	Date  time.Time
+	Count int64
+}
+
+// CardClickTotal total klik satu card dalam rentang
+type CardClickTotal struct {
+	CardID int64
+	Title  string
+	Clicks int64
+}
+
+// LinkClickTotal total klik satu link dalam rentang
+type LinkClickTotal struct {
+	LinkID    int64
+	CardID    int64 // 0 = link section links
+	CardTitle string
+	Label     string
+	URL       string
+	Clicks    int64
+}
+
+// ReferrerTotal total view manusia dari satu host referrer
+type ReferrerTotal struct {
+	Host  string
+	Views int64
+}
+
+// RedirectLink link tujuan redirect /r/{token}
+type RedirectLink struct {
+	CatalogID int64
+	CardID    int64 // 0 = link section links
+	LinkID    int64
+	URL       string
+	Public    bool // link beserta card/section, katalog dan business tampil publik
+
+	CatalogUTM utils.UTMParams    // settings utm_* katalog
+	LinkUTM    *utils.UTMOverride // nil = ikut UTM katalog
+}
+
+// CardSaveStat jumlah save dan compare satu card
+type CardSaveStat struct {
+	CardID   int64
+	Title    string
+	Saved    int64 // pengunjung yang saat ini menyimpan card
+	Saves    int64 // event save dalam rentang
+	Compares int64 // event compare dalam rentang
+}
+
+// PublicCatalog katalog target event analytics publik
+type PublicCatalog struct {
+	ID               int64
+	BusinessID       int64
+	IsActive         bool
+	Status           string
+	BusinessIsActive bool
+}
+
+// IsTrackable hanya katalog yang tampil publik yang dicatat
+func (c *PublicCatalog) IsTrackable() bool {
+	return c.IsActive && c.Status == "published" && c.BusinessIsActive
+}
+
+// APILatencyBucketsMs batas atas bucket histogram latency API (ms),
+// bucket terakhir menampung semua request yang lebih lambat
+var APILatencyBucketsMs = []int64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// APIUsageDaily entity untuk tabel business_api_usage_daily
+type APIUsageDaily struct {
+	BusinessID       int64     `json:"business_id" db:"bau_b_id"`
+	ServiceAccountID int64     `json:"service_account_id" db:"bau_bsa_id"`
+	Date             time.Time `json:"date" db:"bau_date"`
+	Method           string    `json:"method" db:"bau_method"`
+	Endpoint         string    `json:"endpoint" db:"bau_endpoint"`
+	Requests         int64     `json:"requests" db:"bau_requests"`
+	ClientErrors     int64     `json:"client_errors" db:"bau_client_errors"`
+	ServerErrors     int64     `json:"server_errors" db:"bau_server_errors"`
+	BytesIn          int64     `json:"bytes_in" db:"bau_bytes_in"`
+	BytesOut         int64     `json:"bytes_out" db:"bau_bytes_out"`
+	LatencyTotalMs   int64     `json:"latency_total_ms" db:"bau_latency_total_ms"`
+	LatencyBuckets   []int64   `json:"latency_buckets" db:"bau_latency_buckets"`
+	UpdatedAt        time.Time `json:"updated_at" db:"bau_updated_at"`
+}
+
+// NewAPIUsageBuckets histogram latency kosong
+func NewAPIUsageBuckets() []int64 {
+	return make([]int64, len(APILatencyBucketsMs)+1)
+}
+
+// ObserveLatency masukkan satu request ke histogram latency
+func (u *APIUsageDaily) ObserveLatency(latencyMs int64) {
+	if len(u.LatencyBuckets) != len(APILatencyBucketsMs)+1 {
+		u.LatencyBuckets = NewAPIUsageBuckets()
+	}
+	u.LatencyTotalMs += latencyMs
+
+	for i, bound := range APILatencyBucketsMs {
+		if latencyMs <= bound {
+			u.LatencyBuckets[i]++
+			return
+		}
+	}
+	u.LatencyBuckets[len(APILatencyBucketsMs)]++
+}
+
+// Merge tambahkan hitungan usage lain ke usage ini
+func (u *APIUsageDaily) Merge(other *APIUsageDaily) {
+	if len(u.LatencyBuckets) != len(APILatencyBucketsMs)+1 {
+		u.LatencyBuckets = NewAPIUsageBuckets()
+	}
+	u.Requests += other.Requests
+	u.ClientErrors += other.ClientErrors
+	u.ServerErrors += other.ServerErrors
+	u.BytesIn += other.BytesIn
+	u.BytesOut += other.BytesOut
+	u.LatencyTotalMs += other.LatencyTotalMs
+	for i := range u.LatencyBuckets {
+		if i < len(other.LatencyBuckets) {
+			u.LatencyBuckets[i] += other.LatencyBuckets[i]
+		}
+	}
+}
+
+// LatencyPercentile perkiraan persentil latency (ms) dari histogram, berupa batas
+// atas bucket. Request di bucket terakhir dilaporkan sebagai batas bucket terbesar
+func (u *APIUsageDaily) LatencyPercentile(p float64) int64 {
+	var total int64
+	for _, count := range u.LatencyBuckets {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	target := int64(math.Ceil(float64(total) * p))
+	var cumulative int64
+	for i, count := range u.LatencyBuckets {
+		cumulative += count
+		if cumulative >= target && i < len(APILatencyBucketsMs) {
+			return APILatencyBucketsMs[i]
+		}
+	}
+	return APILatencyBucketsMs[len(APILatencyBucketsMs)-1]
+}
@@ -0,0 +1,978 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_analytics/entity"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// AnalyticsRepository interface untuk analytics repository
+type AnalyticsRepository interface {
+	GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error)
+	IncrementViews(catalogID int64, date time.Time, human, unique bool) error
+	ListDailyStats(catalogID int64, from, to time.Time) ([]*entity.CatalogDailyStat, error)
+	GetCategoryBenchmark(catalogID int64, from, to time.Time) (*entity.CategoryBenchmark, error)
+
+	// Unique visitor methods
+	GetOrCreateDailySalt(date time.Time, candidate []byte) ([]byte, error)
+	AddDailyVisitor(catalogID int64, date time.Time, visitorHash string) (bool, error)
+	DeleteVisitorDataBefore(date time.Time) error
+
+	// Click heatmap methods
+	IsClickTargetValid(catalogID, sectionID, cardID int64) (bool, error)
+	IncrementClick(stat *entity.CatalogClickStat) error
+	ListClickStats(catalogID int64, from, to time.Time) ([]*entity.CatalogClickStat, error)
+	ListTopCards(catalogID int64, from, to time.Time, limit int) ([]*entity.CardClickTotal, error)
+
+	// Link click dan referrer methods
+	IsLinkInCatalog(catalogID, cardID, linkID int64) (bool, error)
+	GetRedirectLink(token string) (*entity.RedirectLink, error)
+	IncrementLinkClick(catalogID int64, date time.Time, cardID, linkID int64) error
+	ListTopLinks(catalogID int64, from, to time.Time, limit int) ([]*entity.LinkClickTotal, error)
+	IncrementReferrer(catalogID int64, date time.Time, host string) error
+	ListTopReferrers(catalogID int64, from, to time.Time, limit int) ([]*entity.ReferrerTotal, error)
+
+	// Conversion goal methods
+	IsCardInCatalog(catalogID, cardID int64) (bool, error)
+	IncrementConversion(catalogID int64, date time.Time, event string, cardID int64) error
+	CreateGoal(tx *sql.Tx, goal *entity.CatalogGoal) error
+	GetGoalByID(id int64) (*entity.CatalogGoal, error)
+	ListGoals(catalogID int64) ([]*entity.CatalogGoal, error)
+	CountGoals(catalogID int64) (int, error)
+	DeleteGoal(tx *sql.Tx, id int64) error
+	ListGoalConversions(goal *entity.CatalogGoal, from, to time.Time) ([]*entity.DailyConversion, error)
+
+	// Wishlist dan compare methods
+	AddCardSave(catalogID, cardID int64, visitorHash string, now time.Time) (bool, error)
+	RemoveCardSave(cardID int64, visitorHash string) (bool, error)
+	ListVisitorSaves(catalogID int64, visitorHash string) ([]int64, error)
+	ListCardSaveStats(catalogID int64, from, to time.Time, limit int) ([]*entity.CardSaveStat, error)
+
+	// API usage methods
+	UpsertAPIUsage(usages []*entity.APIUsageDaily) error
+	ListAPIUsage(businessID, serviceAccountID int64, from, to time.Time) ([]*entity.APIUsageDaily, error)
+	DeleteAPIUsageBefore(date time.Time) (int64, error)
+}
+
+type analyticsRepository struct {
+	db *sql.DB
+}
+
+// NewAnalyticsRepository membuat instance analytics repository baru
+func NewAnalyticsRepository(db *sql.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// GetPublicCatalogBySlug status katalog dan business untuk validasi event publik
+func (r *analyticsRepository) GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error) {
+	query := `
+		SELECT c.c_id, c.c_b_id, c.c_is_active, c.c_status, b.b_is_active
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_slug = $1`
+
+	catalog := &entity.PublicCatalog{}
+	err := r.db.QueryRow(query, slug).Scan(
+		&catalog.ID,
+		&catalog.BusinessID,
+		&catalog.IsActive,
+		&catalog.Status,
+		&catalog.BusinessIsActive,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get catalog by slug")
+	}
+
+	return catalog, nil
+}
+
+// IncrementViews tambah view mentah, view manusia hanya jika lolos filter bot,
+// unique jika pengunjung belum tercatat hari ini
+func (r *analyticsRepository) IncrementViews(catalogID int64, date time.Time, human, unique bool) error {
+	query := `
+		INSERT INTO atamlink.catalog_daily_stats (
+			cds_c_id, cds_date, cds_raw_views, cds_views, cds_unique_visitors, cds_updated_at
+		) VALUES ($1, $2, 1, $3, $4, $5)
+		ON CONFLICT (cds_c_id, cds_date) DO UPDATE SET
+			cds_raw_views = atamlink.catalog_daily_stats.cds_raw_views + 1,
+			cds_views = atamlink.catalog_daily_stats.cds_views + EXCLUDED.cds_views,
+			cds_unique_visitors = atamlink.catalog_daily_stats.cds_unique_visitors + EXCLUDED.cds_unique_visitors,
+			cds_updated_at = EXCLUDED.cds_updated_at`
+
+	views, uniques := 0, 0
+	if human {
+		views = 1
+	}
+	if unique {
+		uniques = 1
+	}
+
+	_, err := r.db.Exec(query, catalogID, date.Format("2006-01-02"), views, uniques, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to increment catalog views")
+	}
+
+	return nil
+}
+
+// ListDailyStats statistik harian katalog dalam rentang [from, to]
+func (r *analyticsRepository) ListDailyStats(catalogID int64, from, to time.Time) ([]*entity.CatalogDailyStat, error) {
+	query := `
+		SELECT cds_c_id, cds_date, cds_raw_views, cds_views, cds_unique_visitors, cds_updated_at
+		FROM atamlink.catalog_daily_stats
+		WHERE cds_c_id = $1 AND cds_date BETWEEN $2 AND $3
+		ORDER BY cds_date ASC`
+
+	rows, err := r.db.Query(query, catalogID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list catalog daily stats")
+	}
+	defer rows.Close()
+
+	stats := make([]*entity.CatalogDailyStat, 0)
+	for rows.Next() {
+		stat := &entity.CatalogDailyStat{}
+		if err := rows.Scan(
+			&stat.CatalogID,
+			&stat.Date,
+			&stat.RawViews,
+			&stat.Views,
+			&stat.UniqueVisitors,
+			&stat.UpdatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan catalog daily stat")
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// GetCategoryBenchmark total view katalog published sekategori dalam rentang [from, to].
+// Kategori katalog menggantikan kategori business, nil jika katalog tidak berkategori
+func (r *analyticsRepository) GetCategoryBenchmark(catalogID int64, from, to time.Time) (*entity.CategoryBenchmark, error) {
+	query := `
+		WITH target AS (
+			SELECT mc.mc_id, mc.mc_slug, mc.mc_name
+			FROM atamlink.catalogs c
+			INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+			INNER JOIN atamlink.master_categories mc ON mc.mc_id = COALESCE(c.c_mc_id, b.b_mc_id)
+			WHERE c.c_id = $1 AND mc.mc_is_active = true
+		), peers AS (
+			SELECT c.c_id, COALESCE(c.c_mc_id, b.b_mc_id) AS mc_id
+			FROM atamlink.catalogs c
+			INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+			WHERE c.c_status = $4 AND c.c_is_active = true
+		)
+		SELECT t.mc_id, t.mc_slug, t.mc_name,
+			COUNT(DISTINCT p.c_id),
+			COALESCE(SUM(s.cds_views), 0),
+			COALESCE(SUM(s.cds_unique_visitors), 0)
+		FROM target t
+		LEFT JOIN peers p ON p.mc_id = t.mc_id
+		LEFT JOIN atamlink.catalog_daily_stats s
+			ON s.cds_c_id = p.c_id AND s.cds_date BETWEEN $2 AND $3
+		GROUP BY t.mc_id, t.mc_slug, t.mc_name`
+
+	benchmark := &entity.CategoryBenchmark{}
+	err := r.db.QueryRow(
+		query,
+		catalogID,
+		from.Format("2006-01-02"),
+		to.Format("2006-01-02"),
+		constant.CatalogStatusPublished,
+	).Scan(
+		&benchmark.CategoryID,
+		&benchmark.Slug,
+		&benchmark.Name,
+		&benchmark.Catalogs,
+		&benchmark.Views,
+		&benchmark.UniqueVisitors,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get category benchmark")
+	}
+
+	return benchmark, nil
+}
+
+// GetOrCreateDailySalt salt hari tersebut, candidate dipakai jika belum ada.
+// Semua instance mendapat salt yang sama
+func (r *analyticsRepository) GetOrCreateDailySalt(date time.Time, candidate []byte) ([]byte, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO atamlink.analytics_daily_salts (ads_date, ads_salt)
+			VALUES ($1, $2)
+			ON CONFLICT (ads_date) DO NOTHING
+			RETURNING ads_salt
+		)
+		SELECT ads_salt FROM inserted
+		UNION ALL
+		SELECT ads_salt FROM atamlink.analytics_daily_salts WHERE ads_date = $1
+		LIMIT 1`
+
+	var salt []byte
+	if err := r.db.QueryRow(query, date.Format("2006-01-02"), candidate).Scan(&salt); err != nil {
+		return nil, errors.Wrap(err, "failed to get daily salt")
+	}
+
+	return salt, nil
+}
+
+// AddDailyVisitor catat hash pengunjung, true jika pengunjung baru hari ini
+func (r *analyticsRepository) AddDailyVisitor(catalogID int64, date time.Time, visitorHash string) (bool, error) {
+	query := `
+		INSERT INTO atamlink.catalog_daily_visitors (cdv_c_id, cdv_date, cdv_visitor_hash)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+
+	result, err := r.db.Exec(query, catalogID, date.Format("2006-01-02"), visitorHash)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to add daily visitor")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// DeleteVisitorDataBefore hapus hash pengunjung dan salt sebelum tanggal,
+// jumlah unique harian sudah tersimpan di catalog_daily_stats
+func (r *analyticsRepository) DeleteVisitorDataBefore(date time.Time) error {
+	day := date.Format("2006-01-02")
+
+	if _, err := r.db.Exec(`DELETE FROM atamlink.catalog_daily_visitors WHERE cdv_date < $1`, day); err != nil {
+		return errors.Wrap(err, "failed to delete daily visitors")
+	}
+
+	if _, err := r.db.Exec(`DELETE FROM atamlink.analytics_daily_salts WHERE ads_date < $1`, day); err != nil {
+		return errors.Wrap(err, "failed to delete daily salts")
+	}
+
+	return nil
+}
+
+// IsClickTargetValid section milik katalog dan card (jika ada) milik section
+func (r *analyticsRepository) IsClickTargetValid(catalogID, sectionID, cardID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM atamlink.catalog_sections cs
+			WHERE cs.cs_id = $2 AND cs.cs_c_id = $1 AND cs.cs_is_visible = true
+			AND ($3 = 0 OR EXISTS (
+				SELECT 1 FROM atamlink.catalog_cards cc
+				WHERE cc.cc_id = $3 AND cc.cc_cs_id = cs.cs_id
+			))
+		)`
+
+	var valid bool
+	if err := r.db.QueryRow(query, catalogID, sectionID, cardID).Scan(&valid); err != nil {
+		return false, errors.Wrap(err, "failed to check click target")
+	}
+
+	return valid, nil
+}
+
+// IncrementClick tambah satu klik pada bucket posisi
+func (r *analyticsRepository) IncrementClick(stat *entity.CatalogClickStat) error {
+	query := `
+		INSERT INTO atamlink.catalog_click_stats (
+			ccs_c_id, ccs_cs_id, ccs_cc_id, ccs_date, ccs_bucket_x, ccs_bucket_y, ccs_clicks
+		) VALUES ($1, $2, $3, $4, $5, $6, 1)
+		ON CONFLICT (ccs_c_id, ccs_date, ccs_cs_id, ccs_cc_id, ccs_bucket_x, ccs_bucket_y)
+		DO UPDATE SET ccs_clicks = atamlink.catalog_click_stats.ccs_clicks + 1`
+
+	_, err := r.db.Exec(
+		query,
+		stat.CatalogID,
+		stat.SectionID,
+		stat.CardID,
+		stat.Date.Format("2006-01-02"),
+		stat.BucketX,
+		stat.BucketY,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to increment click")
+	}
+
+	return nil
+}
+
+// ListClickStats total klik per section, card dan bucket dalam rentang [from, to]
+func (r *analyticsRepository) ListClickStats(catalogID int64, from, to time.Time) ([]*entity.CatalogClickStat, error) {
+	query := `
+		SELECT ccs_cs_id, ccs_cc_id, ccs_bucket_x, ccs_bucket_y, SUM(ccs_clicks)
+		FROM atamlink.catalog_click_stats
+		WHERE ccs_c_id = $1 AND ccs_date BETWEEN $2 AND $3
+		GROUP BY ccs_cs_id, ccs_cc_id, ccs_bucket_x, ccs_bucket_y`
+
+	rows, err := r.db.Query(query, catalogID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list click stats")
+	}
+	defer rows.Close()
+
+	stats := make([]*entity.CatalogClickStat, 0)
+	for rows.Next() {
+		stat := &entity.CatalogClickStat{CatalogID: catalogID}
+		if err := rows.Scan(
+			&stat.SectionID,
+			&stat.CardID,
+			&stat.BucketX,
+			&stat.BucketY,
+			&stat.Clicks,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan click stat")
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// ListTopCards card dengan klik terbanyak dalam rentang [from, to]
+func (r *analyticsRepository) ListTopCards(catalogID int64, from, to time.Time, limit int) ([]*entity.CardClickTotal, error) {
+	query := `
+		SELECT cc.cc_id, cc.cc_title, t.clicks
+		FROM (
+			SELECT ccs_cc_id, SUM(ccs_clicks) AS clicks
+			FROM atamlink.catalog_click_stats
+			WHERE ccs_c_id = $1 AND ccs_date BETWEEN $2 AND $3 AND ccs_cc_id > 0
+			GROUP BY ccs_cc_id
+		) t
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = t.ccs_cc_id
+		ORDER BY t.clicks DESC, cc.cc_id
+		LIMIT $4`
+
+	rows, err := r.db.Query(query, catalogID, from.Format("2006-01-02"), to.Format("2006-01-02"), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list top cards")
+	}
+	defer rows.Close()
+
+	totals := make([]*entity.CardClickTotal, 0)
+	for rows.Next() {
+		total := &entity.CardClickTotal{}
+		if err := rows.Scan(&total.CardID, &total.Title, &total.Clicks); err != nil {
+			return nil, errors.Wrap(err, "failed to scan top card")
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, nil
+}
+
+// IsLinkInCatalog link milik katalog: link detail card jika cardID diisi,
+// selain itu link section links
+func (r *analyticsRepository) IsLinkInCatalog(catalogID, cardID, linkID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM atamlink.catalog_links cl
+			INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cl.cl_cs_id
+			WHERE $2 = 0 AND cl.cl_id = $3 AND cs.cs_c_id = $1
+		) OR EXISTS (
+			SELECT 1 FROM atamlink.catalog_card_links ccl
+			INNER JOIN atamlink.catalog_card_details ccd ON ccd.ccd_id = ccl.ccl_ccd_id
+			INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = ccd.ccd_cc_id
+			INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+			WHERE $2 > 0 AND ccl.ccl_id = $3 AND cc.cc_id = $2 AND cs.cs_c_id = $1
+		)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, catalogID, cardID, linkID).Scan(&exists); err != nil {
+		return false, errors.Wrap(err, "failed to check link")
+	}
+
+	return exists, nil
+}
+
+// GetRedirectLink link detail card atau section links berdasarkan token publik
+func (r *analyticsRepository) GetRedirectLink(token string) (*entity.RedirectLink, error) {
+	query := `
+		SELECT cs.cs_c_id, cc.cc_id, ccl.ccl_id, ccl.ccl_url,
+			COALESCE(c.c_settings->>'utm_source', ''), COALESCE(c.c_settings->>'utm_medium', ''),
+			COALESCE(c.c_settings->>'utm_campaign', ''), ccl.ccl_utm,
+			ccl.ccl_is_visible AND ccd.ccd_is_visible AND cc.cc_is_visible AND cs.cs_is_visible
+				AND c.c_is_active AND c.c_status = $2 AND b.b_is_active
+		FROM atamlink.catalog_card_links ccl
+		INNER JOIN atamlink.catalog_card_details ccd ON ccd.ccd_id = ccl.ccl_ccd_id
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = ccd.ccd_cc_id
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE ccl.ccl_token = $1
+		UNION ALL
+		SELECT cs.cs_c_id, 0, cl.cl_id, cl.cl_url,
+			COALESCE(c.c_settings->>'utm_source', ''), COALESCE(c.c_settings->>'utm_medium', ''),
+			COALESCE(c.c_settings->>'utm_campaign', ''), NULL::jsonb,
+			cl.cl_is_visible AND cs.cs_is_visible
+				AND c.c_is_active AND c.c_status = $2 AND b.b_is_active
+		FROM atamlink.catalog_links cl
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cl.cl_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE cl.cl_token = $1
+		LIMIT 1`
+
+	link := &entity.RedirectLink{}
+	var utmJSON []byte
+	err := r.db.QueryRow(query, token, constant.CatalogStatusPublished).Scan(
+		&link.CatalogID,
+		&link.CardID,
+		&link.LinkID,
+		&link.URL,
+		&link.CatalogUTM.Source,
+		&link.CatalogUTM.Medium,
+		&link.CatalogUTM.Campaign,
+		&utmJSON,
+		&link.Public,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgRedirectLinkNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get redirect link")
+	}
+	if len(utmJSON) > 0 {
+		link.LinkUTM = &utils.UTMOverride{}
+		if err := json.Unmarshal(utmJSON, link.LinkUTM); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal redirect link utm")
+		}
+	}
+
+	return link, nil
+}
+
+// IncrementLinkClick tambah satu klik link harian
+func (r *analyticsRepository) IncrementLinkClick(catalogID int64, date time.Time, cardID, linkID int64) error {
+	query := `
+		INSERT INTO atamlink.catalog_daily_link_clicks (
+			cdlc_c_id, cdlc_date, cdlc_cc_id, cdlc_link_id, cdlc_clicks
+		) VALUES ($1, $2, $3, $4, 1)
+		ON CONFLICT (cdlc_c_id, cdlc_date, cdlc_cc_id, cdlc_link_id)
+		DO UPDATE SET cdlc_clicks = atamlink.catalog_daily_link_clicks.cdlc_clicks + 1`
+
+	if _, err := r.db.Exec(query, catalogID, date.Format("2006-01-02"), cardID, linkID); err != nil {
+		return errors.Wrap(err, "failed to increment link click")
+	}
+
+	return nil
+}
+
+// ListTopLinks link dengan klik terbanyak dalam rentang, link yang sudah dihapus tidak ikut
+func (r *analyticsRepository) ListTopLinks(catalogID int64, from, to time.Time, limit int) ([]*entity.LinkClickTotal, error) {
+	query := `
+		SELECT t.cdlc_link_id, t.cdlc_cc_id, COALESCE(cc.cc_title, ''),
+			COALESCE(cl.cl_display_name, ccl.ccl_type::text), COALESCE(cl.cl_url, ccl.ccl_url), t.clicks
+		FROM (
+			SELECT cdlc_cc_id, cdlc_link_id, SUM(cdlc_clicks) AS clicks
+			FROM atamlink.catalog_daily_link_clicks
+			WHERE cdlc_c_id = $1 AND cdlc_date BETWEEN $2 AND $3
+			GROUP BY cdlc_cc_id, cdlc_link_id
+		) t
+		LEFT JOIN atamlink.catalog_links cl ON t.cdlc_cc_id = 0 AND cl.cl_id = t.cdlc_link_id
+		LEFT JOIN atamlink.catalog_card_links ccl ON t.cdlc_cc_id > 0 AND ccl.ccl_id = t.cdlc_link_id
+		LEFT JOIN atamlink.catalog_cards cc ON cc.cc_id = t.cdlc_cc_id
+		WHERE cl.cl_id IS NOT NULL OR ccl.ccl_id IS NOT NULL
+		ORDER BY t.clicks DESC, t.cdlc_link_id
+		LIMIT $4`
+
+	rows, err := r.db.Query(query, catalogID, from.Format("2006-01-02"), to.Format("2006-01-02"), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list top links")
+	}
+	defer rows.Close()
+
+	totals := make([]*entity.LinkClickTotal, 0)
+	for rows.Next() {
+		total := &entity.LinkClickTotal{}
+		if err := rows.Scan(
+			&total.LinkID,
+			&total.CardID,
+			&total.CardTitle,
+			&total.Label,
+			&total.URL,
+			&total.Clicks,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan top link")
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, nil
+}
+
+// IncrementReferrer tambah satu view manusia untuk host referrer
+func (r *analyticsRepository) IncrementReferrer(catalogID int64, date time.Time, host string) error {
+	query := `
+		INSERT INTO atamlink.catalog_daily_referrers (cdr_c_id, cdr_date, cdr_host, cdr_views)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (cdr_c_id, cdr_date, cdr_host)
+		DO UPDATE SET cdr_views = atamlink.catalog_daily_referrers.cdr_views + 1`
+
+	if _, err := r.db.Exec(query, catalogID, date.Format("2006-01-02"), host); err != nil {
+		return errors.Wrap(err, "failed to increment referrer")
+	}
+
+	return nil
+}
+
+// ListTopReferrers host referrer dengan view terbanyak dalam rentang
+func (r *analyticsRepository) ListTopReferrers(catalogID int64, from, to time.Time, limit int) ([]*entity.ReferrerTotal, error) {
+	query := `
+		SELECT cdr_host, SUM(cdr_views) AS views
+		FROM atamlink.catalog_daily_referrers
+		WHERE cdr_c_id = $1 AND cdr_date BETWEEN $2 AND $3
+		GROUP BY cdr_host
+		ORDER BY views DESC, cdr_host
+		LIMIT $4`
+
+	rows, err := r.db.Query(query, catalogID, from.Format("2006-01-02"), to.Format("2006-01-02"), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list top referrers")
+	}
+	defer rows.Close()
+
+	totals := make([]*entity.ReferrerTotal, 0)
+	for rows.Next() {
+		total := &entity.ReferrerTotal{}
+		if err := rows.Scan(&total.Host, &total.Views); err != nil {
+			return nil, errors.Wrap(err, "failed to scan referrer")
+		}
+		totals = append(totals, total)
+	}
+
+	return totals, nil
+}
+
+// IsCardInCatalog check card milik katalog
+func (r *analyticsRepository) IsCardInCatalog(catalogID, cardID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM atamlink.catalog_cards cc
+			INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+			WHERE cc.cc_id = $2 AND cs.cs_c_id = $1
+		)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, catalogID, cardID).Scan(&exists); err != nil {
+		return false, errors.Wrap(err, "failed to check card")
+	}
+
+	return exists, nil
+}
+
+// IncrementConversion tambah satu event konversi harian
+func (r *analyticsRepository) IncrementConversion(catalogID int64, date time.Time, event string, cardID int64) error {
+	query := `
+		INSERT INTO atamlink.catalog_daily_conversions (
+			cdc_c_id, cdc_date, cdc_event, cdc_cc_id, cdc_count
+		) VALUES ($1, $2, $3, $4, 1)
+		ON CONFLICT (cdc_c_id, cdc_date, cdc_event, cdc_cc_id)
+		DO UPDATE SET cdc_count = atamlink.catalog_daily_conversions.cdc_count + 1`
+
+	if _, err := r.db.Exec(query, catalogID, date.Format("2006-01-02"), event, cardID); err != nil {
+		return errors.Wrap(err, "failed to increment conversion")
+	}
+
+	return nil
+}
+
+// AddCardSave simpan card ke wishlist pengunjung, false jika sudah tersimpan
+func (r *analyticsRepository) AddCardSave(catalogID, cardID int64, visitorHash string, now time.Time) (bool, error) {
+	query := `
+		INSERT INTO atamlink.catalog_card_saves (
+			ccsv_cc_id, ccsv_c_id, ccsv_visitor_hash, ccsv_created_at
+		) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (ccsv_cc_id, ccsv_visitor_hash) DO NOTHING`
+
+	result, err := r.db.Exec(query, cardID, catalogID, visitorHash, now)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to add card save")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get affected rows")
+	}
+
+	return rows > 0, nil
+}
+
+// RemoveCardSave hapus card dari wishlist pengunjung, false jika memang tidak tersimpan
+func (r *analyticsRepository) RemoveCardSave(cardID int64, visitorHash string) (bool, error) {
+	query := `
+		DELETE FROM atamlink.catalog_card_saves
+		WHERE ccsv_cc_id = $1 AND ccsv_visitor_hash = $2`
+
+	result, err := r.db.Exec(query, cardID, visitorHash)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to remove card save")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get affected rows")
+	}
+
+	return rows > 0, nil
+}
+
+// ListVisitorSaves id card yang disimpan pengunjung di katalog, terbaru dulu
+func (r *analyticsRepository) ListVisitorSaves(catalogID int64, visitorHash string) ([]int64, error) {
+	query := `
+		SELECT ccsv_cc_id
+		FROM atamlink.catalog_card_saves
+		WHERE ccsv_c_id = $1 AND ccsv_visitor_hash = $2
+		ORDER BY ccsv_created_at DESC`
+
+	rows, err := r.db.Query(query, catalogID, visitorHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list card saves")
+	}
+	defer rows.Close()
+
+	cardIDs := make([]int64, 0)
+	for rows.Next() {
+		var cardID int64
+		if err := rows.Scan(&cardID); err != nil {
+			return nil, errors.Wrap(err, "failed to scan card save")
+		}
+		cardIDs = append(cardIDs, cardID)
+	}
+
+	return cardIDs, nil
+}
+
+// ListCardSaveStats card paling banyak disimpan: jumlah penyimpan saat ini,
+// lalu event save dan compare dalam rentang. Card tanpa aktivitas tidak ikut
+func (r *analyticsRepository) ListCardSaveStats(catalogID int64, from, to time.Time, limit int) ([]*entity.CardSaveStat, error) {
+	query := `
+		SELECT cc.cc_id, cc.cc_title,
+			COALESCE(s.saved, 0), COALESCE(e.saves, 0), COALESCE(e.compares, 0)
+		FROM atamlink.catalog_cards cc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		LEFT JOIN (
+			SELECT ccsv_cc_id, COUNT(*) AS saved
+			FROM atamlink.catalog_card_saves
+			WHERE ccsv_c_id = $1
+			GROUP BY ccsv_cc_id
+		) s ON s.ccsv_cc_id = cc.cc_id
+		LEFT JOIN (
+			SELECT cdc_cc_id,
+				SUM(cdc_count) FILTER (WHERE cdc_event = $4) AS saves,
+				SUM(cdc_count) FILTER (WHERE cdc_event = $5) AS compares
+			FROM atamlink.catalog_daily_conversions
+			WHERE cdc_c_id = $1 AND cdc_date BETWEEN $2 AND $3 AND cdc_event IN ($4, $5)
+			GROUP BY cdc_cc_id
+		) e ON e.cdc_cc_id = cc.cc_id
+		WHERE cs.cs_c_id = $1 AND (s.saved > 0 OR e.saves > 0 OR e.compares > 0)
+		ORDER BY COALESCE(s.saved, 0) DESC, COALESCE(e.saves, 0) DESC, COALESCE(e.compares, 0) DESC, cc.cc_id
+		LIMIT $6`
+
+	rows, err := r.db.Query(query, catalogID, from.Format("2006-01-02"), to.Format("2006-01-02"),
+		constant.AnalyticsEventCardSave, constant.AnalyticsEventCardCompare, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list card save stats")
+	}
+	defer rows.Close()
+
+	stats := make([]*entity.CardSaveStat, 0)
+	for rows.Next() {
+		stat := &entity.CardSaveStat{}
+		if err := rows.Scan(&stat.CardID, &stat.Title, &stat.Saved, &stat.Saves, &stat.Compares); err != nil {
+			return nil, errors.Wrap(err, "failed to scan card save stat")
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+const goalColumns = `
+	cg_id, cg_c_id, cg_name, cg_event, cg_cc_id, cg_created_by, cg_created_at`
+
+// rowScanner abstraksi *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanGoal(row rowScanner) (*entity.CatalogGoal, error) {
+	goal := &entity.CatalogGoal{}
+	err := row.Scan(
+		&goal.ID,
+		&goal.CatalogID,
+		&goal.Name,
+		&goal.Event,
+		&goal.CardID,
+		&goal.CreatedBy,
+		&goal.CreatedAt,
+	)
+	return goal, err
+}
+
+// CreateGoal create goal konversi
+func (r *analyticsRepository) CreateGoal(tx *sql.Tx, goal *entity.CatalogGoal) error {
+	query := `
+		INSERT INTO atamlink.catalog_goals (
+			cg_c_id, cg_name, cg_event, cg_cc_id, cg_created_by, cg_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING cg_id`
+
+	err := tx.QueryRow(
+		query,
+		goal.CatalogID,
+		goal.Name,
+		goal.Event,
+		goal.CardID,
+		goal.CreatedBy,
+		goal.CreatedAt,
+	).Scan(&goal.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to create goal")
+	}
+
+	return nil
+}
+
+// GetGoalByID get goal by ID
+func (r *analyticsRepository) GetGoalByID(id int64) (*entity.CatalogGoal, error) {
+	query := `SELECT` + goalColumns + `
+		FROM atamlink.catalog_goals
+		WHERE cg_id = $1`
+
+	goal, err := scanGoal(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgGoalNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get goal")
+	}
+
+	return goal, nil
+}
+
+// ListGoals daftar goal katalog
+func (r *analyticsRepository) ListGoals(catalogID int64) ([]*entity.CatalogGoal, error) {
+	query := `SELECT` + goalColumns + `
+		FROM atamlink.catalog_goals
+		WHERE cg_c_id = $1
+		ORDER BY cg_id ASC`
+
+	rows, err := r.db.Query(query, catalogID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list goals")
+	}
+	defer rows.Close()
+
+	goals := make([]*entity.CatalogGoal, 0)
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan goal")
+		}
+		goals = append(goals, goal)
+	}
+
+	return goals, nil
+}
+
+// CountGoals jumlah goal katalog
+func (r *analyticsRepository) CountGoals(catalogID int64) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM atamlink.catalog_goals WHERE cg_c_id = $1`, catalogID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count goals")
+	}
+	return count, nil
+}
+
+// DeleteGoal hapus goal, data konversi harian tetap disimpan
+func (r *analyticsRepository) DeleteGoal(tx *sql.Tx, id int64) error {
+	result, err := tx.Exec(`DELETE FROM atamlink.catalog_goals WHERE cg_id = $1`, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete goal")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgGoalNotFound, 404)
+	}
+
+	return nil
+}
+
+// ListGoalConversions konversi harian sesuai event (dan card) goal
+func (r *analyticsRepository) ListGoalConversions(goal *entity.CatalogGoal, from, to time.Time) ([]*entity.DailyConversion, error) {
+	query := `
+		SELECT cdc_date, SUM(cdc_count)
+		FROM atamlink.catalog_daily_conversions
+		WHERE cdc_c_id = $1 AND cdc_event = $2
+		AND ($3::BIGINT IS NULL OR cdc_cc_id = $3)
+		AND cdc_date BETWEEN $4 AND $5
+		GROUP BY cdc_date
+		ORDER BY cdc_date ASC`
+
+	rows, err := r.db.Query(
+		query,
+		goal.CatalogID,
+		goal.Event,
+		goal.CardID,
+		from.Format("2006-01-02"),
+		to.Format("2006-01-02"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list goal conversions")
+	}
+	defer rows.Close()
+
+	conversions := make([]*entity.DailyConversion, 0)
+	for rows.Next() {
+		conversion := &entity.DailyConversion{}
+		if err := rows.Scan(&conversion.Date, &conversion.Count); err != nil {
+			return nil, errors.Wrap(err, "failed to scan goal conversion")
+		}
+		conversions = append(conversions, conversion)
+	}
+
+	return conversions, nil
+}
+
+// UpsertAPIUsage tambahkan agregat pemakaian API ke baris harian, histogram
+// latency dijumlahkan per bucket
+func (r *analyticsRepository) UpsertAPIUsage(usages []*entity.APIUsageDaily) error {
+	if len(usages) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO atamlink.business_api_usage_daily (
+			bau_b_id, bau_bsa_id, bau_date, bau_method, bau_endpoint,
+			bau_requests, bau_client_errors, bau_server_errors, bau_bytes_in, bau_bytes_out,
+			bau_latency_total_ms, bau_latency_buckets, bau_updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (bau_b_id, bau_bsa_id, bau_date, bau_method, bau_endpoint) DO UPDATE SET
+			bau_requests = atamlink.business_api_usage_daily.bau_requests + EXCLUDED.bau_requests,
+			bau_client_errors = atamlink.business_api_usage_daily.bau_client_errors + EXCLUDED.bau_client_errors,
+			bau_server_errors = atamlink.business_api_usage_daily.bau_server_errors + EXCLUDED.bau_server_errors,
+			bau_bytes_in = atamlink.business_api_usage_daily.bau_bytes_in + EXCLUDED.bau_bytes_in,
+			bau_bytes_out = atamlink.business_api_usage_daily.bau_bytes_out + EXCLUDED.bau_bytes_out,
+			bau_latency_total_ms = atamlink.business_api_usage_daily.bau_latency_total_ms + EXCLUDED.bau_latency_total_ms,
+			bau_latency_buckets = ARRAY(
+				SELECT COALESCE(a, 0) + COALESCE(b, 0)
+				FROM unnest(atamlink.business_api_usage_daily.bau_latency_buckets, EXCLUDED.bau_latency_buckets) AS t(a, b)
+			),
+			bau_updated_at = EXCLUDED.bau_updated_at`
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return errors.Wrap(err, "failed to prepare api usage upsert")
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, usage := range usages {
+		if _, err := stmt.Exec(
+			usage.BusinessID,
+			usage.ServiceAccountID,
+			usage.Date.Format("2006-01-02"),
+			usage.Method,
+			usage.Endpoint,
+			usage.Requests,
+			usage.ClientErrors,
+			usage.ServerErrors,
+			usage.BytesIn,
+			usage.BytesOut,
+			usage.LatencyTotalMs,
+			pq.Array(usage.LatencyBuckets),
+			now,
+		); err != nil {
+			return errors.Wrap(err, "failed to upsert api usage")
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListAPIUsage pemakaian API business dalam rentang [from, to],
+// serviceAccountID 0 berarti semua service account
+func (r *analyticsRepository) ListAPIUsage(businessID, serviceAccountID int64, from, to time.Time) ([]*entity.APIUsageDaily, error) {
+	query := `
+		SELECT
+			bau_b_id, bau_bsa_id, bau_date, bau_method, bau_endpoint,
+			bau_requests, bau_client_errors, bau_server_errors, bau_bytes_in, bau_bytes_out,
+			bau_latency_total_ms, bau_latency_buckets, bau_updated_at
+		FROM atamlink.business_api_usage_daily
+		WHERE bau_b_id = $1 AND ($2 = 0 OR bau_bsa_id = $2) AND bau_date BETWEEN $3 AND $4
+		ORDER BY bau_date ASC, bau_method ASC, bau_endpoint ASC`
+
+	rows, err := r.db.Query(query, businessID, serviceAccountID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list api usage")
+	}
+	defer rows.Close()
+
+	usages := make([]*entity.APIUsageDaily, 0)
+	for rows.Next() {
+		usage := &entity.APIUsageDaily{}
+		var buckets pq.Int64Array
+		if err := rows.Scan(
+			&usage.BusinessID,
+			&usage.ServiceAccountID,
+			&usage.Date,
+			&usage.Method,
+			&usage.Endpoint,
+			&usage.Requests,
+			&usage.ClientErrors,
+			&usage.ServerErrors,
+			&usage.BytesIn,
+			&usage.BytesOut,
+			&usage.LatencyTotalMs,
+			&buckets,
+			&usage.UpdatedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan api usage")
+		}
+		usage.LatencyBuckets = buckets
+		usages = append(usages, usage)
+	}
+
+	return usages, nil
+}
+
+// DeleteAPIUsageBefore hapus pemakaian API yang lebih lama dari date
+func (r *analyticsRepository) DeleteAPIUsageBefore(date time.Time) (int64, error) {
+	query := `DELETE FROM atamlink.business_api_usage_daily WHERE bau_date < $1`
+
+	result, err := r.db.Exec(query, date.Format("2006-01-02"))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete api usage")
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,1020 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_analytics/dto"
+	"github.com/atam/atamlink/internal/mod_analytics/entity"
+	"github.com/atam/atamlink/internal/mod_analytics/repository"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+const (
+	// Rentang laporan analytics maksimal
+	maxAnalyticsRangeDays = 366
+
+	// Jumlah bucket per sumbu heatmap klik
+	heatmapGridSize = 10
+
+	// Jumlah card di laporan most saved
+	topSavedCardsLimit = 20
+
+	// Jumlah top card, top link dan referrer di laporan analytics
+	topAnalyticsLimit = 10
+
+	// Panjang maksimal host referrer (kolom cdr_host)
+	maxReferrerHostLength = 255
+)
+
+// AnalyticsUseCase interface untuk analytics use case
+type AnalyticsUseCase interface {
+	RecordEvent(slug string, visitor *service.VisitorInfo, req *dto.RecordEventRequest) error
+	ResolveRedirect(token string, visitor *service.VisitorInfo) (string, error)
+	GetCatalogAnalytics(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.CatalogAnalyticsResponse, error)
+	GetClickHeatmap(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.ClickHeatmapResponse, error)
+
+	// Wishlist dan compare card (pengunjung anonim via cookie)
+	SaveCard(slug string, cardID int64, visitorID string, visitor *service.VisitorInfo, req *dto.CardSaveRequest) (*dto.VisitorSavesResponse, error)
+	UnsaveCard(slug string, cardID int64, visitorID string) (*dto.VisitorSavesResponse, error)
+	ListVisitorSaves(slug, visitorID string) (*dto.VisitorSavesResponse, error)
+	RecordCompare(slug, visitorID string, visitor *service.VisitorInfo, req *dto.CompareCardsRequest) error
+	GetCardSaves(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.CardSavesResponse, error)
+
+	// Conversion goals
+	CreateGoal(ctx *gin.Context, catalogID, profileID int64, req *dto.CreateGoalRequest) (*dto.GoalResponse, error)
+	ListGoals(ctx *gin.Context, catalogID, profileID int64) ([]*dto.GoalResponse, error)
+	DeleteGoal(ctx *gin.Context, goalID, profileID int64) error
+	GetGoalConversions(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.GoalConversionsResponse, error)
+	PurgeVisitorData() error
+
+	// Pemakaian API service account
+	GetAPIUsage(ctx *gin.Context, businessID, profileID, serviceAccountID int64, from, to time.Time) (*dto.APIUsageResponse, error)
+}
+
+// AffiliateTracker pencatat atribusi affiliate klik card, diimplementasi catalog use case
+type AffiliateTracker interface {
+	TrackAffiliateClick(ctx context.Context, cardID int64) error
+}
+
+type analyticsUseCase struct {
+	db               *sql.DB
+	analyticsRepo    repository.AnalyticsRepository
+	catalogRepo      catalogRepo.CatalogRepository
+	businessRepo     businessRepo.BusinessRepository
+	botFilter        service.BotFilter
+	affiliateTracker AffiliateTracker
+
+	// Cache salt harian, salt hanya berlaku untuk satu tanggal
+	saltMu   sync.Mutex
+	saltDate string
+	salt     []byte
+}
+
+// NewAnalyticsUseCase membuat instance analytics use case baru
+func NewAnalyticsUseCase(
+	db *sql.DB,
+	analyticsRepo repository.AnalyticsRepository,
+	catalogRepo catalogRepo.CatalogRepository,
+	businessRepo businessRepo.BusinessRepository,
+	botFilter service.BotFilter,
+	affiliateTracker AffiliateTracker,
+) AnalyticsUseCase {
+	return &analyticsUseCase{
+		db:               db,
+		analyticsRepo:    analyticsRepo,
+		catalogRepo:      catalogRepo,
+		businessRepo:     businessRepo,
+		botFilter:        botFilter,
+		affiliateTracker: affiliateTracker,
+	}
+}
+
+// RecordEvent catat event katalog publik. Semua event masuk hitungan mentah,
+// hanya yang lolos filter bot masuk hitungan view
+func (uc *analyticsUseCase) RecordEvent(slug string, visitor *service.VisitorInfo, req *dto.RecordEventRequest) error {
+	catalog, err := uc.analyticsRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return err
+	}
+	if !catalog.IsTrackable() {
+		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	now := time.Now()
+	isBot := uc.botFilter.IsBot(visitor, req.Token, catalog.ID)
+
+	if req.Type == constant.AnalyticsEventClick {
+		return uc.recordClick(catalog.ID, isBot, req, now)
+	}
+	if req.Type == constant.AnalyticsEventLinkClick {
+		return uc.recordLinkClick(catalog.ID, isBot, req, now)
+	}
+	if constant.IsConversionEvent(req.Type) {
+		return uc.recordConversion(catalog.ID, isBot, req, now)
+	}
+
+	if isBot {
+		return uc.analyticsRepo.IncrementViews(catalog.ID, now, false, false)
+	}
+
+	visitorHash, err := uc.visitorHash(catalog.ID, visitor, now)
+	if err != nil {
+		return err
+	}
+
+	unique, err := uc.analyticsRepo.AddDailyVisitor(catalog.ID, now, visitorHash)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.analyticsRepo.IncrementViews(catalog.ID, now, true, unique); err != nil {
+		return err
+	}
+
+	return uc.analyticsRepo.IncrementReferrer(catalog.ID, now, referrerHost(req.Referrer))
+}
+
+// recordLinkClick catat klik link section links atau link detail card, klik dari bot diabaikan
+func (uc *analyticsUseCase) recordLinkClick(catalogID int64, isBot bool, req *dto.RecordEventRequest, now time.Time) error {
+	valid, err := uc.analyticsRepo.IsLinkInCatalog(catalogID, req.CardID, req.LinkID)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New(errors.ErrValidation, constant.ErrMsgAnalyticsTargetInvalid, 400)
+	}
+
+	if isBot {
+		return nil
+	}
+
+	return uc.analyticsRepo.IncrementLinkClick(catalogID, now, req.CardID, req.LinkID)
+}
+
+// ResolveRedirect URL tujuan link publik berdasarkan token. Klik dan atribusi
+// affiliate card dicatat di background agar redirect tidak menunggu database,
+// klik dari crawler diabaikan
+func (uc *analyticsUseCase) ResolveRedirect(token string, visitor *service.VisitorInfo) (string, error) {
+	link, err := uc.analyticsRepo.GetRedirectLink(token)
+	if err != nil {
+		return "", err
+	}
+	if !link.Public {
+		return "", errors.New(errors.ErrNotFound, constant.ErrMsgRedirectLinkNotFound, 404)
+	}
+
+	if !uc.botFilter.IsCrawler(visitor) {
+		now := time.Now()
+		go func() {
+			// Best effort, redirect tetap jalan walau pencatatan gagal
+			_ = uc.analyticsRepo.IncrementLinkClick(link.CatalogID, now, link.CardID, link.LinkID)
+			if link.CardID > 0 {
+				_ = uc.affiliateTracker.TrackAffiliateClick(context.Background(), link.CardID)
+			}
+		}()
+	}
+
+	return utils.AppendUTM(link.URL, link.CatalogUTM.WithOverride(link.LinkUTM)), nil
+}
+
+// referrerHost host referrer tanpa www dan port, kosong untuk direct atau referrer tidak valid
+func referrerHost(referrer string) string {
+	u, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if len(host) > maxReferrerHostLength {
+		return ""
+	}
+	return host
+}
+
+// recordClick catat posisi klik ke bucket heatmap, klik dari bot diabaikan
+func (uc *analyticsUseCase) recordClick(catalogID int64, isBot bool, req *dto.RecordEventRequest, now time.Time) error {
+	valid, err := uc.analyticsRepo.IsClickTargetValid(catalogID, req.SectionID, req.CardID)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New(errors.ErrValidation, constant.ErrMsgAnalyticsTargetInvalid, 400)
+	}
+
+	if isBot {
+		return nil
+	}
+
+	return uc.analyticsRepo.IncrementClick(&entity.CatalogClickStat{
+		CatalogID: catalogID,
+		SectionID: req.SectionID,
+		CardID:    req.CardID,
+		Date:      now,
+		BucketX:   heatmapBucket(*req.X),
+		BucketY:   heatmapBucket(*req.Y),
+	})
+}
+
+// recordConversion catat event konversi harian, event dari bot diabaikan
+func (uc *analyticsUseCase) recordConversion(catalogID int64, isBot bool, req *dto.RecordEventRequest, now time.Time) error {
+	if req.CardID > 0 {
+		valid, err := uc.analyticsRepo.IsCardInCatalog(catalogID, req.CardID)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errors.New(errors.ErrValidation, constant.ErrMsgAnalyticsTargetInvalid, 400)
+		}
+	}
+
+	if isBot {
+		return nil
+	}
+
+	return uc.analyticsRepo.IncrementConversion(catalogID, now, req.Type, req.CardID)
+}
+
+// heatmapBucket posisi 0..1 ke indeks bucket, posisi 1 masuk bucket terakhir
+func heatmapBucket(pos float64) int {
+	bucket := int(pos * heatmapGridSize)
+	if bucket >= heatmapGridSize {
+		bucket = heatmapGridSize - 1
+	}
+	return bucket
+}
+
+// PurgeVisitorData hapus hash pengunjung dan salt hari-hari sebelumnya,
+// dipanggil scheduler. Data kemarin disimpan agar event yang terlambat tetap dedup
+func (uc *analyticsUseCase) PurgeVisitorData() error {
+	return uc.analyticsRepo.DeleteVisitorDataBefore(time.Now().AddDate(0, 0, -1))
+}
+
+// visitorHash hash anonim pengunjung: sha256(salt harian, katalog, IP, user agent).
+// IP tidak pernah disimpan, dan setelah salt dihapus hash tidak bisa dicocokkan ulang
+func (uc *analyticsUseCase) visitorHash(catalogID int64, visitor *service.VisitorInfo, now time.Time) (string, error) {
+	salt, err := uc.dailySalt(now)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(strconv.FormatInt(catalogID, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(visitor.IP))
+	h.Write([]byte{0})
+	h.Write([]byte(visitor.UserAgent))
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (uc *analyticsUseCase) dailySalt(now time.Time) ([]byte, error) {
+	date := now.Format("2006-01-02")
+
+	uc.saltMu.Lock()
+	defer uc.saltMu.Unlock()
+
+	if uc.saltDate == date {
+		return uc.salt, nil
+	}
+
+	candidate := make([]byte, 32)
+	if _, err := rand.Read(candidate); err != nil {
+		return nil, errors.Wrap(err, "failed to generate daily salt")
+	}
+
+	salt, err := uc.analyticsRepo.GetOrCreateDailySalt(now, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.saltDate = date
+	uc.salt = salt
+
+	return salt, nil
+}
+
+// GetCatalogAnalytics view harian katalog, hari tanpa data diisi nol
+func (uc *analyticsUseCase) GetCatalogAnalytics(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.CatalogAnalyticsResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.analyticsRepo.ListDailyStats(catalogID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]*dto.DailyViewsResponse, len(stats))
+	for _, stat := range stats {
+		byDate[stat.Date.Format("2006-01-02")] = &dto.DailyViewsResponse{
+			Views:          stat.Views,
+			RawViews:       stat.RawViews,
+			UniqueVisitors: stat.UniqueVisitors,
+		}
+	}
+
+	resp := &dto.CatalogAnalyticsResponse{
+		CatalogID: catalogID,
+		From:      from,
+		To:        to,
+		Daily:     make([]dto.DailyViewsResponse, 0),
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		daily := dto.DailyViewsResponse{Date: date}
+		if stat, ok := byDate[date]; ok {
+			daily.Views = stat.Views
+			daily.RawViews = stat.RawViews
+			daily.UniqueVisitors = stat.UniqueVisitors
+		}
+
+		resp.Views += daily.Views
+		resp.RawViews += daily.RawViews
+		resp.UniqueVisitors += daily.UniqueVisitors
+		resp.Daily = append(resp.Daily, daily)
+	}
+	resp.BotViews = resp.RawViews - resp.Views
+
+	benchmark, err := uc.analyticsRepo.GetCategoryBenchmark(catalogID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if benchmark != nil {
+		resp.Category = &dto.CategoryBenchmarkResponse{
+			ID:       benchmark.CategoryID,
+			Slug:     benchmark.Slug,
+			Name:     benchmark.Name,
+			Catalogs: benchmark.Catalogs,
+		}
+		if benchmark.Catalogs > 0 {
+			resp.Category.AvgViews = float64(benchmark.Views) / float64(benchmark.Catalogs)
+			resp.Category.AvgUniqueVisitors = float64(benchmark.UniqueVisitors) / float64(benchmark.Catalogs)
+		}
+	}
+
+	if err := uc.fillTopLists(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// fillTopLists top card, top link dan referrer untuk laporan analytics
+func (uc *analyticsUseCase) fillTopLists(resp *dto.CatalogAnalyticsResponse) error {
+	cards, err := uc.analyticsRepo.ListTopCards(resp.CatalogID, resp.From, resp.To, topAnalyticsLimit)
+	if err != nil {
+		return err
+	}
+	resp.TopCards = make([]dto.TopCardResponse, 0, len(cards))
+	for _, card := range cards {
+		resp.TopCards = append(resp.TopCards, dto.TopCardResponse{
+			CardID: card.CardID,
+			Title:  card.Title,
+			Clicks: card.Clicks,
+		})
+	}
+
+	links, err := uc.analyticsRepo.ListTopLinks(resp.CatalogID, resp.From, resp.To, topAnalyticsLimit)
+	if err != nil {
+		return err
+	}
+	resp.TopLinks = make([]dto.TopLinkResponse, 0, len(links))
+	for _, link := range links {
+		resp.TopLinks = append(resp.TopLinks, dto.TopLinkResponse{
+			LinkID:    link.LinkID,
+			CardID:    link.CardID,
+			CardTitle: link.CardTitle,
+			Label:     link.Label,
+			URL:       link.URL,
+			Clicks:    link.Clicks,
+		})
+	}
+
+	referrers, err := uc.analyticsRepo.ListTopReferrers(resp.CatalogID, resp.From, resp.To, topAnalyticsLimit)
+	if err != nil {
+		return err
+	}
+	resp.Referrers = make([]dto.ReferrerResponse, 0, len(referrers))
+	for _, referrer := range referrers {
+		resp.Referrers = append(resp.Referrers, dto.ReferrerResponse{
+			Host:  referrer.Host,
+			Views: referrer.Views,
+			Share: conversionRate(referrer.Views, resp.Views),
+		})
+	}
+
+	return nil
+}
+
+// GetClickHeatmap distribusi klik per section dan card dalam rentang tanggal
+func (uc *analyticsUseCase) GetClickHeatmap(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.ClickHeatmapResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.analyticsRepo.ListClickStats(catalogID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ClickHeatmapResponse{
+		CatalogID: catalogID,
+		From:      from,
+		To:        to,
+		GridSize:  heatmapGridSize,
+		Sections:  make([]dto.SectionClicksResponse, 0, len(sections)),
+	}
+
+	// Kelompokkan per section, section yang sudah dihapus otomatis hilang (cascade)
+	bySection := make(map[int64][]*entity.CatalogClickStat)
+	for _, stat := range stats {
+		bySection[stat.SectionID] = append(bySection[stat.SectionID], stat)
+		resp.TotalClicks += stat.Clicks
+	}
+
+	for _, section := range sections {
+		sectionResp := dto.SectionClicksResponse{
+			SectionID: section.ID,
+			Type:      section.Type,
+			Grid:      make([][]int64, heatmapGridSize),
+			Cards:     make([]dto.CardClicksResponse, 0),
+		}
+		for y := range sectionResp.Grid {
+			sectionResp.Grid[y] = make([]int64, heatmapGridSize)
+		}
+
+		cardClicks := make(map[int64]int64)
+		for _, stat := range bySection[section.ID] {
+			sectionResp.Clicks += stat.Clicks
+			sectionResp.Grid[stat.BucketY][stat.BucketX] += stat.Clicks
+			if stat.CardID > 0 {
+				cardClicks[stat.CardID] += stat.Clicks
+			}
+		}
+
+		if len(cardClicks) > 0 {
+			cards, err := uc.catalogRepo.GetCardsBySectionID(utils.ContextFrom(ctx), section.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, card := range cards {
+				if clicks, ok := cardClicks[card.ID]; ok {
+					sectionResp.Cards = append(sectionResp.Cards, dto.CardClicksResponse{
+						CardID: card.ID,
+						Title:  card.Title,
+						Clicks: clicks,
+					})
+				}
+			}
+		}
+
+		if resp.TotalClicks > 0 {
+			sectionResp.Share = float64(sectionResp.Clicks) / float64(resp.TotalClicks)
+		}
+
+		resp.Sections = append(resp.Sections, sectionResp)
+	}
+
+	return resp, nil
+}
+
+// SaveCard simpan card ke wishlist pengunjung. Save dari bot diabaikan,
+// save ulang card yang sudah tersimpan tidak dihitung lagi
+func (uc *analyticsUseCase) SaveCard(slug string, cardID int64, visitorID string, visitor *service.VisitorInfo, req *dto.CardSaveRequest) (*dto.VisitorSavesResponse, error) {
+	catalog, err := uc.trackableCatalogCard(slug, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	visitorHash := cookieVisitorHash(visitorID)
+
+	if !uc.botFilter.IsBot(visitor, req.Token, catalog.ID) {
+		now := time.Now()
+		added, err := uc.analyticsRepo.AddCardSave(catalog.ID, cardID, visitorHash, now)
+		if err != nil {
+			return nil, err
+		}
+		if added {
+			if err := uc.analyticsRepo.IncrementConversion(catalog.ID, now, constant.AnalyticsEventCardSave, cardID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return uc.visitorSaves(catalog.ID, visitorHash)
+}
+
+// UnsaveCard hapus card dari wishlist pengunjung, hitungan save harian tetap
+func (uc *analyticsUseCase) UnsaveCard(slug string, cardID int64, visitorID string) (*dto.VisitorSavesResponse, error) {
+	catalog, err := uc.trackableCatalogCard(slug, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	visitorHash := cookieVisitorHash(visitorID)
+	if _, err := uc.analyticsRepo.RemoveCardSave(cardID, visitorHash); err != nil {
+		return nil, err
+	}
+
+	return uc.visitorSaves(catalog.ID, visitorHash)
+}
+
+// ListVisitorSaves card yang disimpan pengunjung di katalog
+func (uc *analyticsUseCase) ListVisitorSaves(slug, visitorID string) (*dto.VisitorSavesResponse, error) {
+	catalog, err := uc.analyticsRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if !catalog.IsTrackable() {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	return uc.visitorSaves(catalog.ID, cookieVisitorHash(visitorID))
+}
+
+// RecordCompare catat card yang dibandingkan pengunjung, tiap card dihitung
+// sekali per pengunjung per hari. Compare dari bot diabaikan
+func (uc *analyticsUseCase) RecordCompare(slug, visitorID string, visitor *service.VisitorInfo, req *dto.CompareCardsRequest) error {
+	catalog, err := uc.analyticsRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return err
+	}
+	if !catalog.IsTrackable() {
+		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	cardIDs := make([]int64, 0, len(req.CardIDs))
+	seen := make(map[int64]bool, len(req.CardIDs))
+	for _, cardID := range req.CardIDs {
+		if !seen[cardID] {
+			seen[cardID] = true
+			cardIDs = append(cardIDs, cardID)
+		}
+	}
+	if len(cardIDs) < constant.MinCompareCards || len(cardIDs) > constant.MaxCompareCards {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCompareCardsInvalid, 400)
+	}
+
+	for _, cardID := range cardIDs {
+		valid, err := uc.analyticsRepo.IsCardInCatalog(catalog.ID, cardID)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return errors.New(errors.ErrValidation, constant.ErrMsgAnalyticsTargetInvalid, 400)
+		}
+	}
+
+	if uc.botFilter.IsBot(visitor, req.Token, catalog.ID) {
+		return nil
+	}
+
+	now := time.Now()
+	for _, cardID := range cardIDs {
+		// Dedup harian memakai tabel pengunjung harian, ikut terhapus oleh PurgeVisitorData
+		first, err := uc.analyticsRepo.AddDailyVisitor(catalog.ID, now, cookieVisitorHash(visitorID, "compare", strconv.FormatInt(cardID, 10)))
+		if err != nil {
+			return err
+		}
+		if !first {
+			continue
+		}
+		if err := uc.analyticsRepo.IncrementConversion(catalog.ID, now, constant.AnalyticsEventCardCompare, cardID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// trackableCatalogCard katalog publik yang bisa di-track dan card miliknya
+func (uc *analyticsUseCase) trackableCatalogCard(slug string, cardID int64) (*entity.PublicCatalog, error) {
+	catalog, err := uc.analyticsRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if !catalog.IsTrackable() {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	valid, err := uc.analyticsRepo.IsCardInCatalog(catalog.ID, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgAnalyticsTargetInvalid, 400)
+	}
+
+	return catalog, nil
+}
+
+func (uc *analyticsUseCase) visitorSaves(catalogID int64, visitorHash string) (*dto.VisitorSavesResponse, error) {
+	cardIDs, err := uc.analyticsRepo.ListVisitorSaves(catalogID, visitorHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.VisitorSavesResponse{CardIDs: cardIDs}, nil
+}
+
+// cookieVisitorHash hash id cookie pengunjung, id asli tidak disimpan
+func cookieVisitorHash(visitorID string, parts ...string) string {
+	h := sha256.New()
+	h.Write([]byte(visitorID))
+	for _, part := range parts {
+		h.Write([]byte{0})
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// GetCardSaves card paling banyak disimpan beserta jumlah compare dalam rentang
+func (uc *analyticsUseCase) GetCardSaves(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.CardSavesResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.analyticsRepo.ListCardSaveStats(catalogID, from, to, topSavedCardsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.CardSavesResponse{
+		CatalogID: catalogID,
+		From:      from,
+		To:        to,
+		Cards:     make([]dto.CardSaveResponse, 0, len(stats)),
+	}
+	for _, stat := range stats {
+		resp.Cards = append(resp.Cards, dto.CardSaveResponse{
+			CardID:   stat.CardID,
+			Title:    stat.Title,
+			Saved:    stat.Saved,
+			Saves:    stat.Saves,
+			Compares: stat.Compares,
+		})
+	}
+
+	return resp, nil
+}
+
+// CreateGoal buat goal konversi katalog
+func (uc *analyticsUseCase) CreateGoal(ctx *gin.Context, catalogID, profileID int64, req *dto.CreateGoalRequest) (*dto.GoalResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	count, err := uc.analyticsRepo.CountGoals(catalogID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= constant.MaxGoalsPerCatalog {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgGoalLimitReached, 409)
+	}
+
+	goal := &entity.CatalogGoal{
+		CatalogID: catalogID,
+		Name:      req.Name,
+		Event:     req.Event,
+		CreatedBy: profileID,
+		CreatedAt: time.Now(),
+	}
+
+	if req.CardID > 0 {
+		valid, err := uc.analyticsRepo.IsCardInCatalog(catalogID, req.CardID)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgAnalyticsTargetInvalid, 400)
+		}
+		goal.CardID = sql.NullInt64{Int64: req.CardID, Valid: true}
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.analyticsRepo.CreateGoal(tx, goal); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toGoalResponse(goal), nil
+}
+
+// ListGoals daftar goal konversi katalog
+func (uc *analyticsUseCase) ListGoals(ctx *gin.Context, catalogID, profileID int64) ([]*dto.GoalResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	goals, err := uc.analyticsRepo.ListGoals(catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.GoalResponse, 0, len(goals))
+	for _, goal := range goals {
+		resp = append(resp, toGoalResponse(goal))
+	}
+
+	return resp, nil
+}
+
+// DeleteGoal hapus goal konversi
+func (uc *analyticsUseCase) DeleteGoal(ctx *gin.Context, goalID, profileID int64) error {
+	goal, err := uc.analyticsRepo.GetGoalByID(goalID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, goal)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), goal.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.analyticsRepo.DeleteGoal(tx, goalID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// GetGoalConversions konversi tiap goal terhadap view manusia per hari
+func (uc *analyticsUseCase) GetGoalConversions(ctx *gin.Context, catalogID, profileID int64, from, to time.Time) (*dto.GoalConversionsResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	stats, err := uc.analyticsRepo.ListDailyStats(catalogID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make(map[string]int64, len(stats))
+	resp := &dto.GoalConversionsResponse{
+		CatalogID: catalogID,
+		From:      from,
+		To:        to,
+		Goals:     make([]dto.GoalReportResponse, 0),
+	}
+	for _, stat := range stats {
+		views[stat.Date.Format("2006-01-02")] = stat.Views
+		resp.Views += stat.Views
+	}
+
+	goals, err := uc.analyticsRepo.ListGoals(catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, goal := range goals {
+		conversions, err := uc.analyticsRepo.ListGoalConversions(goal, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		byDate := make(map[string]int64, len(conversions))
+		for _, conversion := range conversions {
+			byDate[conversion.Date.Format("2006-01-02")] = conversion.Count
+		}
+
+		report := dto.GoalReportResponse{
+			Goal:  *toGoalResponse(goal),
+			Daily: make([]dto.DailyConversionResponse, 0),
+		}
+
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			date := day.Format("2006-01-02")
+			daily := dto.DailyConversionResponse{
+				Date:        date,
+				Views:       views[date],
+				Conversions: byDate[date],
+				Rate:        conversionRate(byDate[date], views[date]),
+			}
+
+			report.Conversions += daily.Conversions
+			report.Daily = append(report.Daily, daily)
+		}
+		report.Rate = conversionRate(report.Conversions, resp.Views)
+
+		resp.Goals = append(resp.Goals, report)
+	}
+
+	return resp, nil
+}
+
+// GetAPIUsage laporan pemakaian API service account business per hari dan per endpoint
+func (uc *analyticsUseCase) GetAPIUsage(ctx *gin.Context, businessID, profileID, serviceAccountID int64, from, to time.Time) (*dto.APIUsageResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessView); err != nil {
+		return nil, err
+	}
+
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	usages, err := uc.analyticsRepo.ListAPIUsage(businessID, serviceAccountID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	total := &entity.APIUsageDaily{}
+	byDate := make(map[string]*entity.APIUsageDaily)
+	byEndpoint := make(map[string]*entity.APIUsageDaily)
+	endpointKeys := make([]string, 0)
+	for _, usage := range usages {
+		total.Merge(usage)
+
+		date := usage.Date.Format("2006-01-02")
+		if _, ok := byDate[date]; !ok {
+			byDate[date] = &entity.APIUsageDaily{}
+		}
+		byDate[date].Merge(usage)
+
+		key := usage.Method + " " + usage.Endpoint
+		if _, ok := byEndpoint[key]; !ok {
+			byEndpoint[key] = &entity.APIUsageDaily{Method: usage.Method, Endpoint: usage.Endpoint}
+			endpointKeys = append(endpointKeys, key)
+		}
+		byEndpoint[key].Merge(usage)
+	}
+
+	resp := &dto.APIUsageResponse{
+		BusinessID:       businessID,
+		ServiceAccountID: serviceAccountID,
+		From:             from,
+		To:               to,
+		Total:            toAPIUsageStats(total),
+		Daily:            make([]dto.APIUsageDailyResponse, 0),
+		Endpoints:        make([]dto.APIUsageEndpointResponse, 0, len(endpointKeys)),
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		daily := dto.APIUsageDailyResponse{Date: date}
+		if usage, ok := byDate[date]; ok {
+			daily.APIUsageStats = toAPIUsageStats(usage)
+		}
+		resp.Daily = append(resp.Daily, daily)
+	}
+
+	for _, key := range endpointKeys {
+		usage := byEndpoint[key]
+		resp.Endpoints = append(resp.Endpoints, dto.APIUsageEndpointResponse{
+			Method:        usage.Method,
+			Endpoint:      usage.Endpoint,
+			APIUsageStats: toAPIUsageStats(usage),
+		})
+	}
+	sort.SliceStable(resp.Endpoints, func(i, j int) bool {
+		return resp.Endpoints[i].Requests > resp.Endpoints[j].Requests
+	})
+
+	return resp, nil
+}
+
+// toAPIUsageStats ringkas agregat pemakaian API untuk response
+func toAPIUsageStats(usage *entity.APIUsageDaily) dto.APIUsageStats {
+	stats := dto.APIUsageStats{
+		Requests:     usage.Requests,
+		ClientErrors: usage.ClientErrors,
+		ServerErrors: usage.ServerErrors,
+		BytesIn:      usage.BytesIn,
+		BytesOut:     usage.BytesOut,
+		P50LatencyMs: usage.LatencyPercentile(0.50),
+		P95LatencyMs: usage.LatencyPercentile(0.95),
+		P99LatencyMs: usage.LatencyPercentile(0.99),
+	}
+	if usage.Requests > 0 {
+		stats.ErrorRate = float64(usage.ClientErrors+usage.ServerErrors) / float64(usage.Requests)
+		stats.AvgLatencyMs = float64(usage.LatencyTotalMs) / float64(usage.Requests)
+	}
+	return stats
+}
+
+// conversionRate rasio konversi terhadap view, 0 jika belum ada view
+func conversionRate(conversions, views int64) float64 {
+	if views == 0 {
+		return 0
+	}
+	return float64(conversions) / float64(views)
+}
+
+// validateRange rentang tanggal laporan valid dan tidak melebihi batas
+func validateRange(from, to time.Time) error {
+	if to.Before(from) || to.Sub(from) > maxAnalyticsRangeDays*24*time.Hour {
+		return errors.New(errors.ErrValidation, constant.ErrMsgAnalyticsRangeInvalid, 400)
+	}
+	return nil
+}
+
+func toGoalResponse(goal *entity.CatalogGoal) *dto.GoalResponse {
+	resp := &dto.GoalResponse{
+		ID:        goal.ID,
+		CatalogID: goal.CatalogID,
+		Name:      goal.Name,
+		Event:     goal.Event,
+		CreatedAt: goal.CreatedAt,
+	}
+	if goal.CardID.Valid {
+		resp.CardID = &goal.CardID.Int64
+	}
+	return resp
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// StepUpRequest request verifikasi tambahan sebelum operasi destruktif.
+// Email: kirim tanpa code untuk menerima kode, lalu kirim ulang dengan
+// challenge_id dan code. TOTP: langsung kirim code dari authenticator.
+type StepUpRequest struct {
+	Action      string `json:"action" validate:"required,oneof=business_delete ip_allowlist_break_glass totp_enroll"`
+	Method      string `json:"method" validate:"required,oneof=email totp"`
+	ChallengeID int64  `json:"challenge_id,omitempty" validate:"omitempty,gt=0"`
+	Code        string `json:"code,omitempty" validate:"omitempty,len=6,numeric"`
+}
+
+// StepUpResponse response step-up, token diisi setelah kode terverifikasi
+type StepUpResponse struct {
+	ChallengeID    int64      `json:"challenge_id"`
+	Action         string     `json:"action"`
+	Method         string     `json:"method"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Token          string     `json:"token,omitempty"`
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+}
+
+// EnrollTOTPRequest request enroll authenticator, kode authenticator saat ini
+// wajib jika sudah ada authenticator aktif yang akan diganti
+type EnrollTOTPRequest struct {
+	Code string `json:"code,omitempty" validate:"omitempty,len=6,numeric"`
+}
+
+// TOTPEnrollResponse secret authenticator yang harus dikonfirmasi
+type TOTPEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"` // otpauth:// untuk QR code
+}
+
+// ConfirmTOTPRequest request aktivasi authenticator
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// SessionResponse sesi login (perangkat) aktif
+type SessionResponse struct {
+	ID           int64     `json:"id"`
+	IPAddress    string    `json:"ip_address,omitempty"`
+	UserAgent    string    `json:"user_agent,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	Current      bool      `json:"current"`
+}
+
+// RevokeSessionsResponse jumlah sesi yang dicabut
+type RevokeSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
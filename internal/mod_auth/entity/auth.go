@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// StepUpChallenge entity untuk tabel user_step_up_challenges
+type StepUpChallenge struct {
+	ID             int64          `json:"id" db:"usc_id"`
+	ProfileID      int64          `json:"profile_id" db:"usc_up_id"`
+	Action         string         `json:"action" db:"usc_action"`
+	Method         string         `json:"method" db:"usc_method"`
+	CodeHash       sql.NullString `json:"-" db:"usc_code_hash"`
+	Attempts       int            `json:"attempts" db:"usc_attempts"`
+	ExpiresAt      time.Time      `json:"expires_at" db:"usc_expires_at"`
+	VerifiedAt     *time.Time     `json:"verified_at" db:"usc_verified_at"`
+	TokenHash      sql.NullString `json:"-" db:"usc_token_hash"`
+	TokenExpiresAt *time.Time     `json:"token_expires_at" db:"usc_token_expires_at"`
+	ConsumedAt     *time.Time     `json:"consumed_at" db:"usc_consumed_at"`
+	CreatedAt      time.Time      `json:"created_at" db:"usc_created_at"`
+}
+
+// TableName mendapatkan nama tabel
+func (StepUpChallenge) TableName() string { return "atamlink.user_step_up_challenges" }
+
+// IsOpen check apakah kode challenge masih bisa diverifikasi
+func (c *StepUpChallenge) IsOpen(now time.Time, maxAttempts int) bool {
+	return c.VerifiedAt == nil && now.Before(c.ExpiresAt) && c.Attempts < maxAttempts
+}
+
+// TOTPSecret entity untuk tabel user_totp_secrets
+type TOTPSecret struct {
+	ProfileID      int64      `json:"profile_id" db:"uts_up_id"`
+	Secret         string     `json:"-" db:"uts_secret"` // sealed oleh vault service
+	ConfirmedAt    *time.Time `json:"confirmed_at" db:"uts_confirmed_at"`
+	LastStep       int64      `json:"-" db:"uts_last_step"`
+	FailedAttempts int        `json:"-" db:"uts_failed_attempts"`
+	LockedUntil    *time.Time `json:"-" db:"uts_locked_until"`
+	CreatedAt      time.Time  `json:"created_at" db:"uts_created_at"`
+}
+
+// TableName mendapatkan nama tabel
+func (TOTPSecret) TableName() string { return "atamlink.user_totp_secrets" }
+
+// IsConfirmed check apakah authenticator sudah diaktifkan
+func (s *TOTPSecret) IsConfirmed() bool {
+	return s.ConfirmedAt != nil
+}
+
+// IsLocked check apakah authenticator sedang dikunci karena terlalu banyak kode salah
+func (s *TOTPSecret) IsLocked(now time.Time) bool {
+	return s.LockedUntil != nil && now.Before(*s.LockedUntil)
+}
+
+// Session entity untuk tabel user_sessions
+type Session struct {
+	ID           int64          `json:"id" db:"us_id"`
+	ProfileID    int64          `json:"profile_id" db:"us_up_id"`
+	TokenHash    string         `json:"-" db:"us_token_hash"`
+	IPAddress    sql.NullString `json:"ip_address" db:"us_ip_address"`
+	UserAgent    sql.NullString `json:"user_agent" db:"us_user_agent"`
+	CreatedAt    time.Time      `json:"created_at" db:"us_created_at"`
+	LastActiveAt time.Time      `json:"last_active_at" db:"us_last_active_at"`
+	RevokedAt    *time.Time     `json:"revoked_at" db:"us_revoked_at"`
+}
+
+// TableName mendapatkan nama tabel
+func (Session) TableName() string { return "atamlink.user_sessions" }
+
+// IsRevoked check apakah sesi sudah dicabut
+func (s *Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
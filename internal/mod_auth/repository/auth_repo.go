@@ -0,0 +1,482 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_auth/entity"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// AuthRepository interface untuk auth repository (step-up dan sesi)
+type AuthRepository interface {
+	GetProfileEmail(profileID int64) (string, error)
+
+	// Step-up challenge
+	CreateChallenge(challenge *entity.StepUpChallenge) error
+	GetChallenge(id, profileID int64) (*entity.StepUpChallenge, error)
+	IncrementChallengeAttempts(id int64) error
+	IssueChallengeToken(id int64, tokenHash string, verifiedAt, tokenExpiresAt time.Time) (bool, error)
+	ConsumeToken(tokenHash string, profileID int64, action string, now time.Time) (bool, error)
+
+	// TOTP
+	GetTOTPSecret(profileID int64) (*entity.TOTPSecret, error)
+	UpsertTOTPSecret(secret *entity.TOTPSecret) error
+	ReplaceTOTPSecret(secret *entity.TOTPSecret, step int64) error
+	ConfirmTOTPSecret(profileID int64, step int64, confirmedAt time.Time) error
+	UseTOTPStep(profileID int64, step int64) (bool, error)
+	RecordTOTPFailure(profileID int64, maxAttempts int, lockedUntil time.Time) error
+
+	// Session
+	GetSessionByTokenHash(tokenHash string) (*entity.Session, error)
+	CreateSession(session *entity.Session) error
+	TouchSession(id int64, ipAddress string, at time.Time) error
+	ListActiveSessions(profileID int64, activeSince time.Time) ([]*entity.Session, error)
+	RevokeSession(profileID, sessionID int64, at time.Time) error
+	RevokeOtherSessions(profileID, exceptSessionID int64, at time.Time) (int64, error)
+}
+
+type authRepository struct {
+	db *sql.DB
+}
+
+// NewAuthRepository membuat instance auth repository baru
+func NewAuthRepository(db *sql.DB) AuthRepository {
+	return &authRepository{db: db}
+}
+
+// GetProfileEmail email user pemilik profile
+func (r *authRepository) GetProfileEmail(profileID int64) (string, error) {
+	query := `
+		SELECT u.u_email
+		FROM atamlink.user_profiles up
+		INNER JOIN atamlink.users u ON u.u_id = up.up_u_id
+		WHERE up.up_id = $1`
+
+	var email string
+	err := r.db.QueryRow(query, profileID).Scan(&email)
+	if err == sql.ErrNoRows {
+		return "", errors.New(errors.ErrNotFound, "Profile tidak ditemukan", 404)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get profile email")
+	}
+
+	return email, nil
+}
+
+// CreateChallenge simpan challenge step-up baru
+func (r *authRepository) CreateChallenge(challenge *entity.StepUpChallenge) error {
+	query := `
+		INSERT INTO atamlink.user_step_up_challenges (
+			usc_up_id, usc_action, usc_method, usc_code_hash, usc_expires_at,
+			usc_verified_at, usc_token_hash, usc_token_expires_at, usc_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING usc_id`
+
+	err := r.db.QueryRow(
+		query,
+		challenge.ProfileID,
+		challenge.Action,
+		challenge.Method,
+		challenge.CodeHash,
+		challenge.ExpiresAt,
+		challenge.VerifiedAt,
+		challenge.TokenHash,
+		challenge.TokenExpiresAt,
+		challenge.CreatedAt,
+	).Scan(&challenge.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create step-up challenge")
+	}
+
+	return nil
+}
+
+// GetChallenge challenge milik profile
+func (r *authRepository) GetChallenge(id, profileID int64) (*entity.StepUpChallenge, error) {
+	query := `
+		SELECT
+			usc_id, usc_up_id, usc_action, usc_method, usc_code_hash, usc_attempts,
+			usc_expires_at, usc_verified_at, usc_token_expires_at, usc_consumed_at, usc_created_at
+		FROM atamlink.user_step_up_challenges
+		WHERE usc_id = $1 AND usc_up_id = $2`
+
+	challenge := &entity.StepUpChallenge{}
+	err := r.db.QueryRow(query, id, profileID).Scan(
+		&challenge.ID,
+		&challenge.ProfileID,
+		&challenge.Action,
+		&challenge.Method,
+		&challenge.CodeHash,
+		&challenge.Attempts,
+		&challenge.ExpiresAt,
+		&challenge.VerifiedAt,
+		&challenge.TokenExpiresAt,
+		&challenge.ConsumedAt,
+		&challenge.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgStepUpExpired, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get step-up challenge")
+	}
+
+	return challenge, nil
+}
+
+// IncrementChallengeAttempts catat percobaan kode yang salah
+func (r *authRepository) IncrementChallengeAttempts(id int64) error {
+	query := `UPDATE atamlink.user_step_up_challenges SET usc_attempts = usc_attempts + 1 WHERE usc_id = $1`
+
+	if _, err := r.db.Exec(query, id); err != nil {
+		return errors.Wrap(err, "failed to update step-up attempts")
+	}
+
+	return nil
+}
+
+// IssueChallengeToken tandai challenge terverifikasi dan simpan hash token,
+// false jika challenge sudah diverifikasi oleh request lain
+func (r *authRepository) IssueChallengeToken(id int64, tokenHash string, verifiedAt, tokenExpiresAt time.Time) (bool, error) {
+	query := `
+		UPDATE atamlink.user_step_up_challenges SET
+			usc_verified_at = $3,
+			usc_token_hash = $2,
+			usc_token_expires_at = $4
+		WHERE usc_id = $1 AND usc_verified_at IS NULL`
+
+	result, err := r.db.Exec(query, id, tokenHash, verifiedAt, tokenExpiresAt)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to issue step-up token")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ConsumeToken pakai token step-up sekali, false jika token tidak cocok,
+// kadaluarsa, atau sudah dipakai
+func (r *authRepository) ConsumeToken(tokenHash string, profileID int64, action string, now time.Time) (bool, error) {
+	query := `
+		UPDATE atamlink.user_step_up_challenges SET usc_consumed_at = $4
+		WHERE usc_token_hash = $1
+			AND usc_up_id = $2
+			AND usc_action = $3
+			AND usc_consumed_at IS NULL
+			AND usc_token_expires_at > $4`
+
+	result, err := r.db.Exec(query, tokenHash, profileID, action, now)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to consume step-up token")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetTOTPSecret secret TOTP profile, nil jika belum pernah enroll
+func (r *authRepository) GetTOTPSecret(profileID int64) (*entity.TOTPSecret, error) {
+	query := `
+		SELECT
+			uts_up_id, uts_secret, uts_confirmed_at, uts_last_step,
+			uts_failed_attempts, uts_locked_until, uts_created_at
+		FROM atamlink.user_totp_secrets
+		WHERE uts_up_id = $1`
+
+	secret := &entity.TOTPSecret{}
+	err := r.db.QueryRow(query, profileID).Scan(
+		&secret.ProfileID,
+		&secret.Secret,
+		&secret.ConfirmedAt,
+		&secret.LastStep,
+		&secret.FailedAttempts,
+		&secret.LockedUntil,
+		&secret.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get totp secret")
+	}
+
+	return secret, nil
+}
+
+// UpsertTOTPSecret simpan secret baru yang belum dikonfirmasi, secret yang
+// sudah aktif tidak ditimpa
+func (r *authRepository) UpsertTOTPSecret(secret *entity.TOTPSecret) error {
+	query := `
+		INSERT INTO atamlink.user_totp_secrets (uts_up_id, uts_secret, uts_created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (uts_up_id) DO UPDATE SET
+			uts_secret = EXCLUDED.uts_secret,
+			uts_created_at = EXCLUDED.uts_created_at,
+			uts_last_step = 0
+		WHERE atamlink.user_totp_secrets.uts_confirmed_at IS NULL`
+
+	result, err := r.db.Exec(query, secret.ProfileID, secret.Secret, secret.CreatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to save totp secret")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrConflict, constant.ErrMsgStepUpTOTPEnrolled, 409)
+	}
+
+	return nil
+}
+
+// ReplaceTOTPSecret ganti authenticator aktif dengan secret baru yang belum
+// dikonfirmasi. Hanya berhasil jika langkah terakhir yang dipakai sama dengan
+// step challenge TOTP yang baru saja lolos
+func (r *authRepository) ReplaceTOTPSecret(secret *entity.TOTPSecret, step int64) error {
+	query := `
+		UPDATE atamlink.user_totp_secrets SET
+			uts_secret = $2,
+			uts_created_at = $3,
+			uts_confirmed_at = NULL,
+			uts_last_step = 0,
+			uts_failed_attempts = 0
+		WHERE uts_up_id = $1 AND uts_confirmed_at IS NOT NULL AND uts_last_step = $4`
+
+	result, err := r.db.Exec(query, secret.ProfileID, secret.Secret, secret.CreatedAt, step)
+	if err != nil {
+		return errors.Wrap(err, "failed to replace totp secret")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrValidation, constant.ErrMsgStepUpCodeInvalid, 400)
+	}
+
+	return nil
+}
+
+// ConfirmTOTPSecret aktifkan authenticator setelah kode pertama valid
+func (r *authRepository) ConfirmTOTPSecret(profileID int64, step int64, confirmedAt time.Time) error {
+	query := `
+		UPDATE atamlink.user_totp_secrets SET
+			uts_confirmed_at = $3,
+			uts_last_step = $2,
+			uts_failed_attempts = 0
+		WHERE uts_up_id = $1 AND uts_confirmed_at IS NULL`
+
+	result, err := r.db.Exec(query, profileID, step, confirmedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to confirm totp secret")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrConflict, constant.ErrMsgStepUpTOTPEnrolled, 409)
+	}
+
+	return nil
+}
+
+// UseTOTPStep catat langkah TOTP yang dipakai, false jika kode langkah
+// tersebut (atau yang lebih baru) sudah pernah dipakai
+func (r *authRepository) UseTOTPStep(profileID int64, step int64) (bool, error) {
+	query := `
+		UPDATE atamlink.user_totp_secrets SET uts_last_step = $2, uts_failed_attempts = 0
+		WHERE uts_up_id = $1 AND uts_last_step < $2`
+
+	result, err := r.db.Exec(query, profileID, step)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to update totp step")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RecordTOTPFailure catat kode authenticator yang salah. Percobaan ke-maxAttempts
+// mengunci authenticator sampai lockedUntil dan mereset hitungan
+func (r *authRepository) RecordTOTPFailure(profileID int64, maxAttempts int, lockedUntil time.Time) error {
+	query := `
+		UPDATE atamlink.user_totp_secrets SET
+			uts_failed_attempts = CASE WHEN uts_failed_attempts + 1 >= $2 THEN 0 ELSE uts_failed_attempts + 1 END,
+			uts_locked_until = CASE WHEN uts_failed_attempts + 1 >= $2 THEN $3 ELSE uts_locked_until END
+		WHERE uts_up_id = $1`
+
+	if _, err := r.db.Exec(query, profileID, maxAttempts, lockedUntil); err != nil {
+		return errors.Wrap(err, "failed to record totp failure")
+	}
+
+	return nil
+}
+
+// GetSessionByTokenHash sesi untuk token bearer, nil jika token belum pernah dipakai
+func (r *authRepository) GetSessionByTokenHash(tokenHash string) (*entity.Session, error) {
+	query := `
+		SELECT
+			us_id, us_up_id, us_ip_address, us_user_agent,
+			us_created_at, us_last_active_at, us_revoked_at
+		FROM atamlink.user_sessions
+		WHERE us_token_hash = $1`
+
+	session := &entity.Session{TokenHash: tokenHash}
+	err := r.db.QueryRow(query, tokenHash).Scan(
+		&session.ID,
+		&session.ProfileID,
+		&session.IPAddress,
+		&session.UserAgent,
+		&session.CreatedAt,
+		&session.LastActiveAt,
+		&session.RevokedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get session")
+	}
+
+	return session, nil
+}
+
+// CreateSession catat sesi baru, request paralel dengan token yang sama
+// memakai sesi yang sudah tercatat
+func (r *authRepository) CreateSession(session *entity.Session) error {
+	query := `
+		INSERT INTO atamlink.user_sessions (
+			us_up_id, us_token_hash, us_ip_address, us_user_agent,
+			us_created_at, us_last_active_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (us_token_hash) DO UPDATE SET
+			us_last_active_at = EXCLUDED.us_last_active_at
+		RETURNING us_id, us_revoked_at`
+
+	err := r.db.QueryRow(
+		query,
+		session.ProfileID,
+		session.TokenHash,
+		session.IPAddress,
+		session.UserAgent,
+		session.CreatedAt,
+		session.LastActiveAt,
+	).Scan(&session.ID, &session.RevokedAt)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create session")
+	}
+
+	return nil
+}
+
+// TouchSession catat aktivitas terakhir dan IP terakhir sesi
+func (r *authRepository) TouchSession(id int64, ipAddress string, at time.Time) error {
+	query := `UPDATE atamlink.user_sessions SET us_last_active_at = $2, us_ip_address = $3 WHERE us_id = $1`
+
+	if _, err := r.db.Exec(query, id, at, ipAddress); err != nil {
+		return errors.Wrap(err, "failed to update session activity")
+	}
+
+	return nil
+}
+
+// ListActiveSessions sesi profile yang belum dicabut dan masih aktif sejak activeSince
+func (r *authRepository) ListActiveSessions(profileID int64, activeSince time.Time) ([]*entity.Session, error) {
+	query := `
+		SELECT
+			us_id, us_up_id, us_ip_address, us_user_agent,
+			us_created_at, us_last_active_at, us_revoked_at
+		FROM atamlink.user_sessions
+		WHERE us_up_id = $1 AND us_revoked_at IS NULL AND us_last_active_at >= $2
+		ORDER BY us_last_active_at DESC`
+
+	rows, err := r.db.Query(query, profileID, activeSince)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list sessions")
+	}
+	defer rows.Close()
+
+	var sessions []*entity.Session
+	for rows.Next() {
+		session := &entity.Session{}
+		if err := rows.Scan(
+			&session.ID,
+			&session.ProfileID,
+			&session.IPAddress,
+			&session.UserAgent,
+			&session.CreatedAt,
+			&session.LastActiveAt,
+			&session.RevokedAt,
+		); err != nil {
+			return nil, errors.Wrap(err, "failed to scan session")
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// RevokeSession cabut satu sesi milik profile
+func (r *authRepository) RevokeSession(profileID, sessionID int64, at time.Time) error {
+	query := `
+		UPDATE atamlink.user_sessions SET us_revoked_at = $3
+		WHERE us_id = $1 AND us_up_id = $2 AND us_revoked_at IS NULL`
+
+	result, err := r.db.Exec(query, sessionID, profileID, at)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke session")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgSessionNotFound, 404)
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions cabut semua sesi profile kecuali exceptSessionID
+func (r *authRepository) RevokeOtherSessions(profileID, exceptSessionID int64, at time.Time) (int64, error) {
+	query := `
+		UPDATE atamlink.user_sessions SET us_revoked_at = $3
+		WHERE us_up_id = $1 AND us_id <> $2 AND us_revoked_at IS NULL`
+
+	result, err := r.db.Exec(query, profileID, exceptSessionID, at)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to revoke sessions")
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_auth/dto"
+	"github.com/atam/atamlink/internal/mod_auth/entity"
+	"github.com/atam/atamlink/internal/mod_auth/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// AuthUseCase interface untuk auth use case (step-up dan sesi)
+type AuthUseCase interface {
+	StepUp(profileID int64, req *dto.StepUpRequest) (*dto.StepUpResponse, error)
+	EnrollTOTP(profileID int64, req *dto.EnrollTOTPRequest) (*dto.TOTPEnrollResponse, error)
+	ConfirmTOTP(profileID int64, req *dto.ConfirmTOTPRequest) error
+	// ConsumeStepUpToken pakai token step-up untuk aksi, false jika token tidak berlaku
+	ConsumeStepUpToken(profileID int64, action, token string) (bool, error)
+
+	// Session
+	ListSessions(profileID, currentSessionID int64) ([]*dto.SessionResponse, error)
+	RevokeSession(profileID, sessionID int64) error
+	RevokeOtherSessions(profileID, currentSessionID int64) (*dto.RevokeSessionsResponse, error)
+}
+
+type authUseCase struct {
+	authRepo     repository.AuthRepository
+	vaultService service.VaultService
+	mailService  service.MailService
+	config       config.StepUpConfig
+	sessionIdle  time.Duration
+}
+
+// NewAuthUseCase membuat instance auth use case baru
+func NewAuthUseCase(
+	authRepo repository.AuthRepository,
+	vaultService service.VaultService,
+	mailService service.MailService,
+	cfg config.StepUpConfig,
+	sessionIdle time.Duration,
+) AuthUseCase {
+	return &authUseCase{
+		authRepo:     authRepo,
+		vaultService: vaultService,
+		mailService:  mailService,
+		config:       cfg,
+		sessionIdle:  sessionIdle,
+	}
+}
+
+// StepUp terbitkan challenge email atau verifikasi kode lalu terbitkan token step-up
+func (uc *authUseCase) StepUp(profileID int64, req *dto.StepUpRequest) (*dto.StepUpResponse, error) {
+	// Service account tidak punya faktor verifikasi
+	if profileID <= 0 {
+		return nil, errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	if req.Method == constant.StepUpMethodTOTP {
+		return uc.stepUpTOTP(profileID, req)
+	}
+
+	if req.Code == "" {
+		return uc.sendEmailChallenge(profileID, req.Action)
+	}
+	if req.ChallengeID == 0 {
+		return nil, errors.New(errors.ErrValidation, "challenge_id wajib diisi", 400)
+	}
+	return uc.verifyEmailChallenge(profileID, req)
+}
+
+// EnrollTOTP buat secret authenticator baru, aktif setelah dikonfirmasi dengan kode pertama.
+// Route-nya wajib membawa token step-up email untuk action totp_enroll, sehingga
+// sesi yang dicuri saja tidak cukup untuk mendaftarkan authenticator penyerang.
+// Authenticator aktif hanya bisa diganti dengan kode authenticator saat ini
+func (uc *authUseCase) EnrollTOTP(profileID int64, req *dto.EnrollTOTPRequest) (*dto.TOTPEnrollResponse, error) {
+	if profileID <= 0 {
+		return nil, errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	existing, err := uc.authRepo.GetTOTPSecret(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authenticator aktif: challenge TOTP baru sebelum secret diganti
+	var replaceStep int64
+	if existing != nil && existing.IsConfirmed() {
+		if req.Code == "" {
+			return nil, errors.New(errors.ErrConflict, constant.ErrMsgTOTPReenrollCode, 409)
+		}
+
+		totp, current, err := uc.openTOTPSecret(profileID)
+		if err != nil {
+			return nil, err
+		}
+
+		step, err := uc.validateTOTP(totp, current, req.Code, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		// Kode yang sama tidak boleh dipakai dua kali
+		fresh, err := uc.authRepo.UseTOTPStep(profileID, step)
+		if err != nil {
+			return nil, err
+		}
+		if !fresh {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgStepUpCodeInvalid, 400)
+		}
+		replaceStep = step
+	}
+
+	email, err := uc.authRepo.GetProfileEmail(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate totp secret")
+	}
+
+	sealed, err := uc.vaultService.Seal(map[string]string{"secret": secret})
+	if err != nil {
+		return nil, err
+	}
+
+	totp := &entity.TOTPSecret{
+		ProfileID: profileID,
+		Secret:    sealed,
+		CreatedAt: time.Now(),
+	}
+	if replaceStep > 0 {
+		err = uc.authRepo.ReplaceTOTPSecret(totp, replaceStep)
+	} else {
+		err = uc.authRepo.UpsertTOTPSecret(totp)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.TOTPEnrollResponse{
+		Secret: secret,
+		URI:    utils.TOTPURI(uc.config.TOTPIssuer, email, secret),
+	}, nil
+}
+
+// ConfirmTOTP aktifkan authenticator dengan kode yang valid
+func (uc *authUseCase) ConfirmTOTP(profileID int64, req *dto.ConfirmTOTPRequest) error {
+	totp, secret, err := uc.openTOTPSecret(profileID)
+	if err != nil {
+		return err
+	}
+	if totp.IsConfirmed() {
+		return errors.New(errors.ErrConflict, constant.ErrMsgStepUpTOTPEnrolled, 409)
+	}
+
+	step, err := uc.validateTOTP(totp, secret, req.Code, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return uc.authRepo.ConfirmTOTPSecret(profileID, step, time.Now())
+}
+
+// ConsumeStepUpToken pakai token step-up sekali untuk aksi tertentu
+func (uc *authUseCase) ConsumeStepUpToken(profileID int64, action, token string) (bool, error) {
+	if profileID <= 0 || token == "" {
+		return false, nil
+	}
+	return uc.authRepo.ConsumeToken(hashSecret(token), profileID, action, time.Now())
+}
+
+// ListSessions sesi (perangkat) aktif milik profile, sesi saat ini ditandai
+func (uc *authUseCase) ListSessions(profileID, currentSessionID int64) ([]*dto.SessionResponse, error) {
+	if profileID <= 0 {
+		return nil, errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	sessions, err := uc.authRepo.ListActiveSessions(profileID, time.Now().Add(-uc.sessionIdle))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = &dto.SessionResponse{
+			ID:           session.ID,
+			IPAddress:    session.IPAddress.String,
+			UserAgent:    session.UserAgent.String,
+			CreatedAt:    session.CreatedAt,
+			LastActiveAt: session.LastActiveAt,
+			Current:      session.ID == currentSessionID,
+		}
+	}
+	return responses, nil
+}
+
+// RevokeSession cabut satu sesi, token sesi tersebut langsung ditolak.
+// Mencabut sesi saat ini sama dengan logout.
+func (uc *authUseCase) RevokeSession(profileID, sessionID int64) error {
+	if profileID <= 0 {
+		return errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	return uc.authRepo.RevokeSession(profileID, sessionID, time.Now())
+}
+
+// RevokeOtherSessions cabut semua sesi selain sesi saat ini
+func (uc *authUseCase) RevokeOtherSessions(profileID, currentSessionID int64) (*dto.RevokeSessionsResponse, error) {
+	if profileID <= 0 {
+		return nil, errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	revoked, err := uc.authRepo.RevokeOtherSessions(profileID, currentSessionID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RevokeSessionsResponse{Revoked: revoked}, nil
+}
+
+func (uc *authUseCase) sendEmailChallenge(profileID int64, action string) (*dto.StepUpResponse, error) {
+	if !uc.mailService.IsConfigured() {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgMailNotConfigured, 503)
+	}
+
+	email, err := uc.authRepo.GetProfileEmail(profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate step-up code")
+	}
+
+	now := time.Now()
+	challenge := &entity.StepUpChallenge{
+		ProfileID: profileID,
+		Action:    action,
+		Method:    constant.StepUpMethodEmail,
+		CodeHash:  sql.NullString{String: hashSecret(code), Valid: true},
+		ExpiresAt: now.Add(uc.config.CodeTTL),
+		CreatedAt: now,
+	}
+	if err := uc.authRepo.CreateChallenge(challenge); err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf(
+		"Kode verifikasi Anda: %s\n\nKode berlaku %d menit. Abaikan email ini jika Anda tidak sedang melakukan aksi tersebut.",
+		code, int(uc.config.CodeTTL.Minutes()),
+	)
+	if err := uc.mailService.Send(email, "Kode verifikasi AtamLink", body); err != nil {
+		return nil, errors.Wrap(err, "failed to send step-up code")
+	}
+
+	return &dto.StepUpResponse{
+		ChallengeID: challenge.ID,
+		Action:      challenge.Action,
+		Method:      challenge.Method,
+		ExpiresAt:   &challenge.ExpiresAt,
+	}, nil
+}
+
+func (uc *authUseCase) verifyEmailChallenge(profileID int64, req *dto.StepUpRequest) (*dto.StepUpResponse, error) {
+	challenge, err := uc.authRepo.GetChallenge(req.ChallengeID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if challenge.Method != constant.StepUpMethodEmail || challenge.Action != req.Action ||
+		!challenge.IsOpen(now, uc.config.MaxAttempts) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgStepUpExpired, 400)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(req.Code)), []byte(challenge.CodeHash.String)) != 1 {
+		if err := uc.authRepo.IncrementChallengeAttempts(challenge.ID); err != nil {
+			return nil, err
+		}
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgStepUpCodeInvalid, 400)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate step-up token")
+	}
+
+	tokenExpiresAt := now.Add(uc.config.TokenTTL)
+	issued, err := uc.authRepo.IssueChallengeToken(challenge.ID, hashSecret(token), now, tokenExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if !issued {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgStepUpExpired, 400)
+	}
+
+	return &dto.StepUpResponse{
+		ChallengeID:    challenge.ID,
+		Action:         challenge.Action,
+		Method:         challenge.Method,
+		Token:          token,
+		TokenExpiresAt: &tokenExpiresAt,
+	}, nil
+}
+
+func (uc *authUseCase) stepUpTOTP(profileID int64, req *dto.StepUpRequest) (*dto.StepUpResponse, error) {
+	if req.Code == "" {
+		return nil, errors.New(errors.ErrValidation, "Kode authenticator wajib diisi", 400)
+	}
+
+	// Authenticator tidak bisa dipakai untuk mendaftarkan authenticator
+	if req.Action == constant.StepUpActionTOTPEnroll {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgStepUpEmailOnly, 400)
+	}
+
+	totp, secret, err := uc.openTOTPSecret(profileID)
+	if err != nil {
+		return nil, err
+	}
+	if !totp.IsConfirmed() {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgStepUpTOTPNotEnrolled, 400)
+	}
+
+	now := time.Now()
+	step, err := uc.validateTOTP(totp, secret, req.Code, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Kode yang sama tidak boleh dipakai dua kali
+	fresh, err := uc.authRepo.UseTOTPStep(profileID, step)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgStepUpCodeInvalid, 400)
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate step-up token")
+	}
+
+	tokenExpiresAt := now.Add(uc.config.TokenTTL)
+	challenge := &entity.StepUpChallenge{
+		ProfileID:      profileID,
+		Action:         req.Action,
+		Method:         constant.StepUpMethodTOTP,
+		ExpiresAt:      now,
+		VerifiedAt:     &now,
+		TokenHash:      sql.NullString{String: hashSecret(token), Valid: true},
+		TokenExpiresAt: &tokenExpiresAt,
+		CreatedAt:      now,
+	}
+	if err := uc.authRepo.CreateChallenge(challenge); err != nil {
+		return nil, err
+	}
+
+	return &dto.StepUpResponse{
+		ChallengeID:    challenge.ID,
+		Action:         challenge.Action,
+		Method:         challenge.Method,
+		Token:          token,
+		TokenExpiresAt: &tokenExpiresAt,
+	}, nil
+}
+
+// validateTOTP cek kode authenticator dengan batas percobaan per profile,
+// MaxAttempts kode salah mengunci authenticator selama TOTPLockout
+func (uc *authUseCase) validateTOTP(totp *entity.TOTPSecret, secret, code string, now time.Time) (int64, error) {
+	if totp.IsLocked(now) {
+		return 0, errors.New(errors.ErrRateLimited, constant.ErrMsgStepUpTOTPLocked, 429)
+	}
+
+	step, ok := utils.ValidateTOTP(secret, code, now)
+	if !ok {
+		if err := uc.authRepo.RecordTOTPFailure(totp.ProfileID, uc.config.MaxAttempts, now.Add(uc.config.TOTPLockout)); err != nil {
+			return 0, err
+		}
+		return 0, errors.New(errors.ErrValidation, constant.ErrMsgStepUpCodeInvalid, 400)
+	}
+
+	return step, nil
+}
+
+// openTOTPSecret secret TOTP profile dalam bentuk base32
+func (uc *authUseCase) openTOTPSecret(profileID int64) (*entity.TOTPSecret, string, error) {
+	totp, err := uc.authRepo.GetTOTPSecret(profileID)
+	if err != nil {
+		return nil, "", err
+	}
+	if totp == nil {
+		return nil, "", errors.New(errors.ErrValidation, constant.ErrMsgStepUpTOTPNotEnrolled, 400)
+	}
+
+	opened, err := uc.vaultService.Open(totp.Secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return totp, opened["secret"], nil
+}
+
+// generateCode kode numerik 6 digit untuk email
+func generateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// generateToken token step-up acak, yang disimpan hanya hash-nya
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashSecret(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
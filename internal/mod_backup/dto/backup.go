@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+)
+
+// RestoreBackupRequest request restore katalog dari backup
+type RestoreBackupRequest struct {
+	// Kosong berarti semua katalog dalam backup
+	CatalogIDs []int64 `json:"catalog_ids,omitempty" validate:"omitempty,dive,gt=0"`
+}
+
+// CloneBusinessRequest request clone business beserta katalognya
+type CloneBusinessRequest struct {
+	Name string `json:"name" validate:"required,min=3,max=200"`
+	Slug string `json:"slug,omitempty" validate:"omitempty,slug,min=3,max=100"`
+	// Kosong berarti semua katalog aktif
+	CatalogIDs []int64 `json:"catalog_ids,omitempty" validate:"omitempty,dive,gt=0"`
+}
+
+// BackupResponse response riwayat backup
+type BackupResponse struct {
+	ID           int64      `json:"id"`
+	BusinessID   int64      `json:"business_id"`
+	Trigger      string     `json:"trigger"`
+	Status       string     `json:"status"`
+	CatalogCount int        `json:"catalog_count"`
+	SizeBytes    int64      `json:"size_bytes"`
+	Checksum     string     `json:"checksum,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	RestoredAt   *time.Time `json:"restored_at,omitempty"`
+}
+
+// RestoreResultResponse ringkasan hasil restore per katalog
+type RestoreResultResponse struct {
+	BackupID int64                   `json:"backup_id"`
+	Catalogs []RestoredCatalogResult `json:"catalogs"`
+}
+
+// RestoredCatalogResult hasil restore satu katalog
+type RestoredCatalogResult struct {
+	SourceID  int64  `json:"source_id"`
+	CatalogID int64  `json:"catalog_id"`
+	Slug      string `json:"slug"`
+	Action    string `json:"action"` // replaced, recreated atau cloned
+	Sections  int    `json:"sections"`
+}
+
+// CloneResultResponse hasil clone business
+type CloneResultResponse struct {
+	SourceBusinessID int64                   `json:"source_business_id"`
+	BusinessID       int64                   `json:"business_id"`
+	Slug             string                  `json:"slug"`
+	Catalogs         []RestoredCatalogResult `json:"catalogs"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// CatalogBackup entity untuk tabel catalog_backups
+type CatalogBackup struct {
+	ID           int64          `json:"id" db:"cb_id"`
+	BusinessID   int64          `json:"business_id" db:"cb_b_id"`
+	Trigger      string         `json:"trigger" db:"cb_trigger"`
+	Status       string         `json:"status" db:"cb_status"`
+	StorageKey   sql.NullString `json:"storage_key" db:"cb_storage_key"`
+	CatalogCount int            `json:"catalog_count" db:"cb_catalog_count"`
+	SizeBytes    int64          `json:"size_bytes" db:"cb_size_bytes"`
+	Checksum     sql.NullString `json:"checksum" db:"cb_checksum"`
+	Error        sql.NullString `json:"error" db:"cb_error"`
+	CreatedBy    sql.NullInt64  `json:"created_by" db:"cb_created_by"`
+	CreatedAt    time.Time      `json:"created_at" db:"cb_created_at"`
+	RestoredBy   sql.NullInt64  `json:"restored_by" db:"cb_restored_by"`
+	RestoredAt   *time.Time     `json:"restored_at" db:"cb_restored_at"`
+}
+
+// TableName mendapatkan nama tabel
+func (CatalogBackup) TableName() string { return "atamlink.catalog_backups" }
+
+// IsRestorable check apakah file backup tersedia untuk restore
+func (b *CatalogBackup) IsRestorable() bool {
+	return b.Status == "completed" && b.StorageKey.Valid
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_backup/entity"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// BackupRepository interface untuk backup repository
+type BackupRepository interface {
+	Create(tx *sql.Tx, backup *entity.CatalogBackup) error
+	GetByID(id int64) (*entity.CatalogBackup, error)
+	ListByBusinessID(businessID int64, limit int) ([]*entity.CatalogBackup, error)
+	ListBusinessesDue(before time.Time, limit int) ([]int64, error)
+	ListBeyondRetention(businessID int64, keep int) ([]*entity.CatalogBackup, error)
+	MarkExpired(tx *sql.Tx, id int64) error
+	MarkRestored(tx *sql.Tx, id int64, profileID int64) error
+}
+
+type backupRepository struct {
+	db *sql.DB
+}
+
+// NewBackupRepository membuat instance backup repository baru
+func NewBackupRepository(db *sql.DB) BackupRepository {
+	return &backupRepository{db: db}
+}
+
+const backupColumns = `
+	cb_id, cb_b_id, cb_trigger, cb_status, cb_storage_key, cb_catalog_count,
+	cb_size_bytes, cb_checksum, cb_error, cb_created_by, cb_created_at,
+	cb_restored_by, cb_restored_at`
+
+// Create menyimpan riwayat backup
+func (r *backupRepository) Create(tx *sql.Tx, backup *entity.CatalogBackup) error {
+	query := `
+		INSERT INTO atamlink.catalog_backups (
+			cb_b_id, cb_trigger, cb_status, cb_storage_key, cb_catalog_count,
+			cb_size_bytes, cb_checksum, cb_error, cb_created_by, cb_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING cb_id`
+
+	err := tx.QueryRow(
+		query,
+		backup.BusinessID,
+		backup.Trigger,
+		backup.Status,
+		backup.StorageKey,
+		backup.CatalogCount,
+		backup.SizeBytes,
+		backup.Checksum,
+		backup.Error,
+		backup.CreatedBy,
+		backup.CreatedAt,
+	).Scan(&backup.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create backup")
+	}
+
+	return nil
+}
+
+// GetByID get backup by ID
+func (r *backupRepository) GetByID(id int64) (*entity.CatalogBackup, error) {
+	query := `SELECT ` + backupColumns + `
+		FROM atamlink.catalog_backups
+		WHERE cb_id = $1`
+
+	backup, err := scanBackup(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgBackupNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get backup")
+	}
+
+	return backup, nil
+}
+
+// ListByBusinessID riwayat backup business, terbaru dulu
+func (r *backupRepository) ListByBusinessID(businessID int64, limit int) ([]*entity.CatalogBackup, error) {
+	query := `SELECT ` + backupColumns + `
+		FROM atamlink.catalog_backups
+		WHERE cb_b_id = $1
+		ORDER BY cb_created_at DESC, cb_id DESC
+		LIMIT $2`
+
+	return r.queryBackups(query, businessID, limit)
+}
+
+// ListBusinessesDue business aktif yang belum punya backup berhasil sejak before
+func (r *backupRepository) ListBusinessesDue(before time.Time, limit int) ([]int64, error) {
+	query := `
+		SELECT b.b_id
+		FROM atamlink.businesses b
+		WHERE b.b_is_active = true
+			AND EXISTS (
+				SELECT 1 FROM atamlink.catalogs c
+				WHERE c.c_b_id = b.b_id AND c.c_is_active = true
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM atamlink.catalog_backups cb
+				WHERE cb.cb_b_id = b.b_id
+					AND cb.cb_status = 'completed'
+					AND cb.cb_created_at > $1
+			)
+		ORDER BY b.b_id
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, before, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get businesses due for backup")
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, "failed to scan business id")
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// ListBeyondRetention backup berhasil di luar keep backup terbaru
+func (r *backupRepository) ListBeyondRetention(businessID int64, keep int) ([]*entity.CatalogBackup, error) {
+	query := `SELECT ` + backupColumns + `
+		FROM atamlink.catalog_backups
+		WHERE cb_b_id = $1 AND cb_status = 'completed'
+		ORDER BY cb_created_at DESC, cb_id DESC
+		OFFSET $2`
+
+	return r.queryBackups(query, businessID, keep)
+}
+
+// MarkExpired tandai backup sudah dihapus dari storage
+func (r *backupRepository) MarkExpired(tx *sql.Tx, id int64) error {
+	query := `
+		UPDATE atamlink.catalog_backups
+		SET cb_status = 'expired', cb_storage_key = NULL
+		WHERE cb_id = $1`
+
+	if _, err := tx.Exec(query, id); err != nil {
+		return errors.Wrap(err, "failed to expire backup")
+	}
+
+	return nil
+}
+
+// MarkRestored catat restore terakhir dari backup
+func (r *backupRepository) MarkRestored(tx *sql.Tx, id int64, profileID int64) error {
+	query := `
+		UPDATE atamlink.catalog_backups
+		SET cb_restored_by = $2, cb_restored_at = $3
+		WHERE cb_id = $1`
+
+	result, err := tx.Exec(query, id, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to mark backup restored")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgBackupNotFound, 404)
+	}
+
+	return nil
+}
+
+func (r *backupRepository) queryBackups(query string, args ...interface{}) ([]*entity.CatalogBackup, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get backups")
+	}
+	defer rows.Close()
+
+	backups := make([]*entity.CatalogBackup, 0)
+	for rows.Next() {
+		backup, err := scanBackup(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan backup")
+		}
+		backups = append(backups, backup)
+	}
+
+	return backups, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBackup(row rowScanner) (*entity.CatalogBackup, error) {
+	backup := &entity.CatalogBackup{}
+	err := row.Scan(
+		&backup.ID,
+		&backup.BusinessID,
+		&backup.Trigger,
+		&backup.Status,
+		&backup.StorageKey,
+		&backup.CatalogCount,
+		&backup.SizeBytes,
+		&backup.Checksum,
+		&backup.Error,
+		&backup.CreatedBy,
+		&backup.CreatedAt,
+		&backup.RestoredBy,
+		&backup.RestoredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
@@ -0,0 +1,1316 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_backup/dto"
+	"github.com/atam/atamlink/internal/mod_backup/entity"
+	"github.com/atam/atamlink/internal/mod_backup/repository"
+	businessEntity "github.com/atam/atamlink/internal/mod_business/entity"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogDto "github.com/atam/atamlink/internal/mod_catalog/dto"
+	catalogEntity "github.com/atam/atamlink/internal/mod_catalog/entity"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// maxBackupCatalogs batas katalog yang diikutkan dalam satu backup
+const maxBackupCatalogs = 1000
+
+// BackupUseCase interface untuk backup use case
+type BackupUseCase interface {
+	List(ctx *gin.Context, businessID, profileID int64) ([]*dto.BackupResponse, error)
+	Create(ctx *gin.Context, businessID, profileID int64) (*dto.BackupResponse, error)
+	Restore(ctx *gin.Context, businessID, backupID, profileID int64, req *dto.RestoreBackupRequest) (*dto.RestoreResultResponse, error)
+	CloneBusiness(ctx *gin.Context, businessID, profileID int64, req *dto.CloneBusinessRequest) (*dto.CloneResultResponse, error)
+	ExportWorkbook(ctx *gin.Context, businessID, profileID int64) (string, io.ReadCloser, error)
+	RunDue(ctx context.Context, batchSize int) error
+
+	// Arsip katalog tidak aktif
+	ArchiveDue(ctx context.Context, inactiveMonths, batchSize int) error
+	Rehydrate(ctx context.Context, catalog *catalogEntity.Catalog) error
+}
+
+type backupUseCase struct {
+	db              *sql.DB
+	backupRepo      repository.BackupRepository
+	catalogRepo     catalogRepo.CatalogRepository
+	businessRepo    businessRepo.BusinessRepository
+	slugService     service.SlugService
+	storage         service.BackupStorage
+	cacheService    service.CacheInvalidationService
+	searchIndexer   service.SearchIndexer
+	interval        time.Duration
+	retentionCount  int
+	planEnforcement service.PlanEnforcementService
+}
+
+// NewBackupUseCase membuat instance backup use case baru,
+// storage nil berarti backup dinonaktifkan
+func NewBackupUseCase(
+	db *sql.DB,
+	backupRepo repository.BackupRepository,
+	catalogRepo catalogRepo.CatalogRepository,
+	businessRepo businessRepo.BusinessRepository,
+	slugService service.SlugService,
+	storage service.BackupStorage,
+	cacheService service.CacheInvalidationService,
+	searchIndexer service.SearchIndexer,
+	interval time.Duration,
+	retentionCount int,
+	planEnforcement service.PlanEnforcementService,
+) BackupUseCase {
+	return &backupUseCase{
+		db:              db,
+		backupRepo:      backupRepo,
+		catalogRepo:     catalogRepo,
+		businessRepo:    businessRepo,
+		slugService:     slugService,
+		storage:         storage,
+		cacheService:    cacheService,
+		searchIndexer:   searchIndexer,
+		interval:        interval,
+		retentionCount:  retentionCount,
+		planEnforcement: planEnforcement,
+	}
+}
+
+// List riwayat backup business
+func (uc *backupUseCase) List(ctx *gin.Context, businessID, profileID int64) ([]*dto.BackupResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessBackup); err != nil {
+		return nil, err
+	}
+
+	backups, err := uc.backupRepo.ListByBusinessID(businessID, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.BackupResponse, 0, len(backups))
+	for _, backup := range backups {
+		responses = append(responses, toBackupResponse(backup))
+	}
+
+	return responses, nil
+}
+
+// Create buat backup manual di luar jadwal
+func (uc *backupUseCase) Create(ctx *gin.Context, businessID, profileID int64) (*dto.BackupResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessBackup); err != nil {
+		return nil, err
+	}
+
+	if uc.storage == nil {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgBackupUnavailable, 503)
+	}
+
+	backup, err := uc.backup(utils.ContextFrom(ctx), businessID, constant.BackupTriggerManual, database.NullInt64(profileID))
+	if err != nil {
+		return nil, err
+	}
+
+	return toBackupResponse(backup), nil
+}
+
+// RunDue backup business yang backup terakhirnya lebih lama dari interval, dipanggil scheduler
+func (uc *backupUseCase) RunDue(ctx context.Context, batchSize int) error {
+	if uc.storage == nil {
+		return nil
+	}
+
+	businessIDs, err := uc.backupRepo.ListBusinessesDue(time.Now().Add(-uc.interval), batchSize)
+	if err != nil {
+		return err
+	}
+
+	// Satu business gagal tidak menghentikan backup business lain
+	var firstErr error
+	for _, businessID := range businessIDs {
+		if _, err := uc.backup(ctx, businessID, constant.BackupTriggerScheduled, sql.NullInt64{}); err != nil && firstErr == nil {
+			firstErr = errors.Wrap(err, fmt.Sprintf("failed to backup business %d", businessID))
+		}
+	}
+
+	return firstErr
+}
+
+// backup export katalog business ke storage dan catat hasilnya, termasuk saat gagal
+func (uc *backupUseCase) backup(ctx context.Context, businessID int64, trigger string, createdBy sql.NullInt64) (*entity.CatalogBackup, error) {
+	backup := &entity.CatalogBackup{
+		BusinessID: businessID,
+		Trigger:    trigger,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}
+
+	export, data, err := uc.export(ctx, businessID, backup.CreatedAt)
+	if err == nil {
+		key := fmt.Sprintf("business-%d/%s.json", businessID, backup.CreatedAt.UTC().Format("20060102T150405Z"))
+		err = uc.storage.Put(key, data)
+		if err == nil {
+			sum := sha256.Sum256(data)
+			backup.Status = constant.BackupStatusCompleted
+			backup.StorageKey = database.NullString(key)
+			backup.CatalogCount = len(export.Catalogs)
+			backup.SizeBytes = int64(len(data))
+			backup.Checksum = database.NullString(hex.EncodeToString(sum[:]))
+		}
+	}
+	if err != nil {
+		backup.Status = constant.BackupStatusFailed
+		backup.Error = database.NullString(err.Error())
+	}
+
+	tx, txErr := uc.db.Begin()
+	if txErr != nil {
+		return nil, errors.Wrap(txErr, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if txErr := uc.backupRepo.Create(tx, backup); txErr != nil {
+		return nil, txErr
+	}
+
+	if txErr := tx.Commit(); txErr != nil {
+		return nil, errors.Wrap(txErr, "failed to commit transaction")
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to backup catalogs")
+	}
+
+	if err := uc.applyRetention(ctx, businessID); err != nil {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+// export susun JSON export seluruh katalog aktif milik business
+func (uc *backupUseCase) export(ctx context.Context, businessID int64, exportedAt time.Time) (*catalogDto.BusinessExport, []byte, error) {
+	business, err := uc.businessRepo.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	isActive := true
+	catalogs, _, err := uc.catalogRepo.List(ctx, catalogRepo.ListFilter{
+		BusinessID: businessID,
+		IsActive:   &isActive,
+		Limit:      maxBackupCatalogs,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	export := &catalogDto.BusinessExport{
+		FormatVersion: catalogDto.ExportFormatVersion,
+		ExportedAt:    exportedAt.UTC(),
+		BusinessID:    business.ID,
+		BusinessName:  business.Name,
+		Catalogs:      make([]catalogDto.CatalogExport, 0, len(catalogs)),
+	}
+
+	for _, item := range catalogs {
+		catalog, err := uc.catalogRepo.GetByID(ctx, item.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		catalogExport, err := uc.exportCatalog(ctx, catalog)
+		if err != nil {
+			return nil, nil, err
+		}
+		export.Catalogs = append(export.Catalogs, *catalogExport)
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal export")
+	}
+
+	return export, data, nil
+}
+
+func (uc *backupUseCase) exportCatalog(ctx context.Context, catalog *catalogEntity.Catalog) (*catalogDto.CatalogExport, error) {
+	// Konten katalog yang diarsipkan hanya ada di file arsip
+	if catalog.IsArchived() {
+		return uc.loadArchive(ctx, catalog)
+	}
+
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, catalog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &catalogDto.CatalogExport{
+		ID:              catalog.ID,
+		ThemeID:         catalog.ThemeID,
+		Slug:            catalog.Slug,
+		Title:           catalog.Title,
+		Subtitle:        catalog.GetSubtitle(),
+		IsActive:        catalog.IsActive,
+		Status:          catalog.Status,
+		Settings:        catalog.Settings,
+		MetaTitle:       catalog.MetaTitle.String,
+		MetaDescription: catalog.MetaDescription.String,
+		OGImage:         catalog.OGImage.String,
+		AllowIndexing:   &catalog.AllowIndexing,
+		Listed:          catalog.Listed,
+		CategoryID:      catalog.CategoryID.Int64,
+		Sections:        make([]catalogDto.SectionExport, 0, len(sections)),
+	}
+
+	for _, section := range sections {
+		sectionExport := catalogDto.SectionExport{
+			Type:      section.Type,
+			IsVisible: section.IsVisible,
+			Config:    section.Config,
+		}
+
+		switch section.Type {
+		case constant.SectionTypeCards:
+			cards, err := uc.catalogRepo.GetCardsBySectionID(ctx, section.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, card := range cards {
+				cardExport, err := uc.exportCard(ctx, card)
+				if err != nil {
+					return nil, err
+				}
+				sectionExport.Cards = append(sectionExport.Cards, *cardExport)
+			}
+
+		case constant.SectionTypeFAQs:
+			faqs, err := uc.catalogRepo.GetFAQsBySectionID(ctx, section.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, faq := range faqs {
+				sectionExport.FAQs = append(sectionExport.FAQs, catalogDto.FAQExport{
+					Question:  faq.Question,
+					Answer:    faq.Answer,
+					IsVisible: faq.IsVisible,
+				})
+			}
+
+		case constant.SectionTypeLegal:
+			legal, err := uc.catalogRepo.GetCurrentLegalVersion(ctx, section.ID)
+			if err != nil && !errors.Is(err, errors.ErrNotFound) {
+				return nil, err
+			}
+			if legal != nil {
+				sectionExport.Legal = &catalogDto.LegalExport{
+					Terms:   legal.Terms,
+					Privacy: legal.Privacy,
+				}
+			}
+		}
+
+		export.Sections = append(export.Sections, sectionExport)
+	}
+
+	return export, nil
+}
+
+func (uc *backupUseCase) exportCard(ctx context.Context, card *catalogEntity.CatalogCard) (*catalogDto.CardExport, error) {
+	export := &catalogDto.CardExport{
+		Title:              card.Title,
+		Subtitle:           card.Subtitle.String,
+		Type:               card.Type,
+		URL:                card.URL.String,
+		IsVisible:          card.IsVisible,
+		Discount:           card.Discount,
+		Currency:           card.Currency,
+		AffiliatePartnerID: card.AffiliatePartnerID.String,
+	}
+	if card.Price.Valid {
+		export.Price = &card.Price.Int64
+	}
+	if card.AffiliateCommissionRate.Valid {
+		export.AffiliateCommissionRate = &card.AffiliateCommissionRate.Float64
+	}
+
+	if card.HasDetail {
+		detail, err := uc.catalogRepo.GetCardDetailByCardID(ctx, card.ID)
+		if err != nil {
+			return nil, err
+		}
+		if detail != nil {
+			export.Detail = &catalogDto.CardDetailExport{
+				Slug:        detail.Slug,
+				Description: detail.Description.String,
+				IsVisible:   detail.IsVisible,
+			}
+
+			links, err := uc.catalogRepo.GetCardLinksByDetailID(ctx, detail.ID)
+			if err != nil {
+				return nil, err
+			}
+			for _, link := range links {
+				export.Detail.Links = append(export.Detail.Links, catalogDto.CardLinkExport{
+					Type:      link.Type,
+					URL:       link.URL,
+					UTM:       catalogDto.NewLinkUTM(link.UTM),
+					IsVisible: link.IsVisible,
+				})
+			}
+		}
+	}
+
+	media, err := uc.catalogRepo.GetCardMediaByCardID(ctx, card.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range media {
+		export.Media = append(export.Media, catalogDto.CardMediaExport{Type: m.Type, URL: m.URL})
+	}
+
+	return export, nil
+}
+
+// ArchiveDue arsipkan katalog yang tidak diubah maupun dikunjungi selama
+// inactiveMonths ke storage lalu hapus kontennya, dipanggil scheduler
+func (uc *backupUseCase) ArchiveDue(ctx context.Context, inactiveMonths, batchSize int) error {
+	if uc.storage == nil || inactiveMonths <= 0 {
+		return nil
+	}
+
+	catalogIDs, err := uc.catalogRepo.ListInactiveCatalogs(ctx, time.Now().AddDate(0, -inactiveMonths, 0), batchSize)
+	if err != nil {
+		return err
+	}
+
+	// Satu katalog gagal tidak menghentikan arsip katalog lain
+	var firstErr error
+	for _, catalogID := range catalogIDs {
+		if err := uc.archive(ctx, catalogID); err != nil && firstErr == nil {
+			firstErr = errors.Wrap(err, fmt.Sprintf("failed to archive catalog %d", catalogID))
+		}
+	}
+
+	return firstErr
+}
+
+// archive simpan export katalog ke storage lalu hapus section beserta isinya.
+// Baris katalog tetap ada supaya slug tidak dipakai katalog lain.
+func (uc *backupUseCase) archive(ctx context.Context, catalogID int64) error {
+	catalog, err := uc.catalogRepo.GetByID(ctx, catalogID)
+	if err != nil {
+		return err
+	}
+	if catalog.IsArchived() {
+		return nil
+	}
+
+	catalogExport, err := uc.exportCatalog(ctx, catalog)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	data, err := json.Marshal(&catalogDto.BusinessExport{
+		FormatVersion: catalogDto.ExportFormatVersion,
+		ExportedAt:    now.UTC(),
+		BusinessID:    catalog.BusinessID,
+		BusinessName:  catalog.Business.Name,
+		Catalogs:      []catalogDto.CatalogExport{*catalogExport},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal archive")
+	}
+
+	key := fmt.Sprintf("archive/business-%d/catalog-%d.json", catalog.BusinessID, catalog.ID)
+	if err := uc.storage.Put(key, data); err != nil {
+		return errors.Wrap(err, "failed to write archive")
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	marked, err := uc.catalogRepo.MarkArchived(ctx, tx, catalog.ID, key, now)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return nil
+	}
+
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, catalog.ID)
+	if err != nil {
+		return err
+	}
+	for _, section := range sections {
+		if err := uc.catalogRepo.DeleteSection(ctx, tx, section.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.cacheService.InvalidateCatalog(catalog.Slug)
+	return nil
+}
+
+// Rehydrate pulihkan konten katalog yang diarsipkan, dipanggil saat pemilik membuka katalog
+func (uc *backupUseCase) Rehydrate(ctx context.Context, catalog *catalogEntity.Catalog) error {
+	if !catalog.IsArchived() {
+		return nil
+	}
+
+	if uc.storage == nil {
+		return errors.New(errors.ErrInternalServer, constant.ErrMsgBackupUnavailable, 503)
+	}
+
+	source, err := uc.loadArchive(ctx, catalog)
+	if err != nil {
+		return err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	// Kunci baris katalog, request lain yang memulihkan bersamaan cukup menunggu
+	cleared, err := uc.catalogRepo.ClearArchived(ctx, tx, catalog.ID)
+	if err != nil {
+		return err
+	}
+
+	if cleared {
+		for _, section := range source.Sections {
+			if err := uc.restoreSection(ctx, tx, catalog.ID, catalog.CreatedBy, &section); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	if cleared {
+		// File arsip yang gagal dihapus tidak dipakai lagi, cukup diabaikan
+		_ = uc.storage.Delete(catalog.ArchiveKey.String)
+		uc.cacheService.InvalidateCatalog(catalog.Slug)
+	}
+
+	catalog.ArchivedAt = nil
+	catalog.ArchiveKey = sql.NullString{}
+	return nil
+}
+
+// loadArchive baca export katalog dari file arsip
+func (uc *backupUseCase) loadArchive(ctx context.Context, catalog *catalogEntity.Catalog) (*catalogDto.CatalogExport, error) {
+	data, err := uc.storage.Get(catalog.ArchiveKey.String)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read archive")
+	}
+
+	export := &catalogDto.BusinessExport{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgArchiveCorrupted, 422)
+	}
+
+	if export.FormatVersion != catalogDto.ExportFormatVersion || len(export.Catalogs) != 1 || export.Catalogs[0].ID != catalog.ID {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgArchiveCorrupted, 422)
+	}
+
+	return &export.Catalogs[0], nil
+}
+
+// applyRetention hapus file backup di luar jumlah retensi
+func (uc *backupUseCase) applyRetention(ctx context.Context, businessID int64) error {
+	if uc.retentionCount <= 0 {
+		return nil
+	}
+
+	expired, err := uc.backupRepo.ListBeyondRetention(businessID, uc.retentionCount)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range expired {
+		if err := uc.storage.Delete(backup.StorageKey.String); err != nil {
+			return errors.Wrap(err, "failed to delete expired backup")
+		}
+
+		tx, err := uc.db.Begin()
+		if err != nil {
+			return errors.Wrap(err, "failed to begin transaction")
+		}
+		if err := uc.backupRepo.MarkExpired(tx, backup.ID); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return errors.Wrap(err, "failed to commit transaction")
+		}
+	}
+
+	return nil
+}
+
+// Restore pulihkan katalog dari backup. Katalog yang masih ada diganti kontennya,
+// katalog yang sudah tidak ada dibuat ulang
+func (uc *backupUseCase) Restore(ctx *gin.Context, businessID, backupID, profileID int64, req *dto.RestoreBackupRequest) (*dto.RestoreResultResponse, error) {
+	backup, err := uc.backupRepo.GetByID(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if backup.BusinessID != businessID {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgBackupNotFound, 404)
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, backup.BusinessID, profileID, constant.PermBusinessBackup); err != nil {
+		return nil, err
+	}
+
+	if uc.storage == nil {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgBackupUnavailable, 503)
+	}
+
+	if !backup.IsRestorable() {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgBackupNotRestorable, 409)
+	}
+
+	export, err := uc.load(utils.ContextFrom(ctx), backup)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs, err := selectCatalogs(export, req.CatalogIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Katalog dan card hasil restore tetap dibatasi plan business
+	if err := uc.checkRestoreQuota(utils.ContextFrom(ctx), backup.BusinessID, catalogs); err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	result := &dto.RestoreResultResponse{
+		BackupID: backup.ID,
+		Catalogs: make([]dto.RestoredCatalogResult, 0, len(catalogs)),
+	}
+
+	for _, catalog := range catalogs {
+		restored, err := uc.restoreCatalog(utils.ContextFrom(ctx), tx, backup.BusinessID, profileID, catalog)
+		if err != nil {
+			return nil, err
+		}
+		result.Catalogs = append(result.Catalogs, *restored)
+	}
+
+	if err := uc.backupRepo.MarkRestored(tx, backup.ID, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	for _, restored := range result.Catalogs {
+		uc.cacheService.InvalidateCatalog(restored.Slug)
+		uc.searchIndexer.Publish(service.SearchEvent{Type: constant.SearchEventCatalogUpdated, CatalogID: restored.CatalogID})
+	}
+
+	return result, nil
+}
+
+// load baca dan validasi file backup
+func (uc *backupUseCase) load(ctx context.Context, backup *entity.CatalogBackup) (*catalogDto.BusinessExport, error) {
+	data, err := uc.storage.Get(backup.StorageKey.String)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read backup")
+	}
+
+	sum := sha256.Sum256(data)
+	if backup.Checksum.Valid && hex.EncodeToString(sum[:]) != backup.Checksum.String {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgBackupCorrupted, 422)
+	}
+
+	export := &catalogDto.BusinessExport{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgBackupCorrupted, 422)
+	}
+
+	if export.FormatVersion != catalogDto.ExportFormatVersion || export.BusinessID != backup.BusinessID {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgBackupCorrupted, 422)
+	}
+
+	return export, nil
+}
+
+// checkRestoreQuota cek batas plan untuk katalog yang dibuat ulang atau keluar dari
+// trash, dan selisih card setelah konten katalog yang masih ada diganti isi backup
+func (uc *backupUseCase) checkRestoreQuota(ctx context.Context, businessID int64, catalogs []*catalogDto.CatalogExport) error {
+	addingCatalogs, addingCards := 0, 0
+	for _, source := range catalogs {
+		addingCards += countExportCards(source)
+
+		existing, err := uc.catalogRepo.GetByID(ctx, source.ID)
+		if err != nil {
+			if appErr, ok := err.(*errors.AppError); !ok || appErr.StatusCode != 404 {
+				return err
+			}
+			existing = nil
+		}
+
+		// Sama dengan restoreCatalog: katalog business lain tidak ditimpa
+		if existing == nil || existing.BusinessID != businessID || existing.IsDeleted() {
+			addingCatalogs++
+			continue
+		}
+
+		// Card lama katalog ini ikut terhapus saat kontennya diganti
+		current, err := uc.catalogRepo.CountCardsByCatalog(ctx, existing.ID)
+		if err != nil {
+			return err
+		}
+		addingCards -= current
+	}
+
+	return uc.checkQuota(ctx, businessID, addingCatalogs, addingCards)
+}
+
+// checkQuota cek batas katalog dan produk plan sebelum menambah sejumlah resource
+func (uc *backupUseCase) checkQuota(ctx context.Context, businessID int64, addingCatalogs, addingCards int) error {
+	if addingCatalogs > 0 {
+		quota, err := uc.planEnforcement.CatalogQuota(ctx, businessID)
+		if err != nil {
+			return err
+		}
+		if err := quota.Check(addingCatalogs); err != nil {
+			return err
+		}
+	}
+
+	if addingCards > 0 {
+		if err := uc.planEnforcement.CheckProductLimit(ctx, businessID, addingCards); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countExportCards jumlah card di semua section katalog export
+func countExportCards(source *catalogDto.CatalogExport) int {
+	count := 0
+	for _, section := range source.Sections {
+		count += len(section.Cards)
+	}
+	return count
+}
+
+func selectCatalogs(export *catalogDto.BusinessExport, ids []int64) ([]*catalogDto.CatalogExport, error) {
+	byID := make(map[int64]*catalogDto.CatalogExport, len(export.Catalogs))
+	for i := range export.Catalogs {
+		byID[export.Catalogs[i].ID] = &export.Catalogs[i]
+	}
+
+	if len(ids) == 0 {
+		catalogs := make([]*catalogDto.CatalogExport, 0, len(export.Catalogs))
+		for i := range export.Catalogs {
+			catalogs = append(catalogs, &export.Catalogs[i])
+		}
+		return catalogs, nil
+	}
+
+	catalogs := make([]*catalogDto.CatalogExport, 0, len(ids))
+	for _, id := range ids {
+		catalog, ok := byID[id]
+		if !ok {
+			return nil, errors.New(errors.ErrValidation, fmt.Sprintf("Katalog %d tidak ada di backup", id), 400)
+		}
+		catalogs = append(catalogs, catalog)
+	}
+	return catalogs, nil
+}
+
+func (uc *backupUseCase) restoreCatalog(ctx context.Context, tx *sql.Tx, businessID, profileID int64, source *catalogDto.CatalogExport) (*dto.RestoredCatalogResult, error) {
+	result := &dto.RestoredCatalogResult{SourceID: source.ID}
+
+	existing, err := uc.catalogRepo.GetByID(ctx, source.ID)
+	if err != nil {
+		if appErr, ok := err.(*errors.AppError); !ok || appErr.StatusCode != 404 {
+			return nil, err
+		}
+		existing = nil
+	}
+
+	now := time.Now()
+	if existing != nil && existing.BusinessID == businessID {
+		// Ganti metadata dan seluruh section katalog yang masih ada
+		existing.ThemeID = source.ThemeID
+		existing.Title = source.Title
+		existing.Subtitle = database.NullString(source.Subtitle)
+		existing.IsActive = true
+		existing.Settings = source.Settings
+		existing.MetaTitle = database.NullString(source.MetaTitle)
+		existing.MetaDescription = database.NullString(source.MetaDescription)
+		existing.OGImage = database.NullString(source.OGImage)
+		existing.AllowIndexing = source.IndexingAllowed()
+		existing.Listed = source.Listed
+		existing.CategoryID = database.NullInt64(source.CategoryID)
+		existing.UpdatedBy = database.NullInt64(profileID)
+		existing.UpdatedAt = &now
+		if err := uc.catalogRepo.Update(ctx, tx, existing); err != nil {
+			return nil, err
+		}
+		// Katalog yang dipulihkan dari backup ikut keluar dari trash
+		if existing.IsDeleted() {
+			if err := uc.catalogRepo.Restore(ctx, tx, existing.ID, profileID); err != nil {
+				return nil, err
+			}
+		}
+
+		sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, existing.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, section := range sections {
+			if err := uc.catalogRepo.DeleteSection(ctx, tx, section.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		// Konten dari backup menggantikan arsip, file arsip lama dibiarkan
+		if existing.IsArchived() {
+			if _, err := uc.catalogRepo.ClearArchived(ctx, tx, existing.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		result.CatalogID = existing.ID
+		result.Slug = existing.Slug
+		result.Action = "replaced"
+	} else {
+		status := source.Status
+		if status == "" {
+			status = constant.CatalogStatusDraft
+		}
+
+		catalog, err := uc.createCatalog(ctx, tx, businessID, profileID, source, status)
+		if err != nil {
+			return nil, err
+		}
+
+		result.CatalogID = catalog.ID
+		result.Slug = catalog.Slug
+		result.Action = "recreated"
+	}
+
+	for _, section := range source.Sections {
+		if err := uc.restoreSection(ctx, tx, result.CatalogID, profileID, &section); err != nil {
+			return nil, err
+		}
+	}
+	result.Sections = len(source.Sections)
+
+	return result, nil
+}
+
+func (uc *backupUseCase) restoreSection(ctx context.Context, tx *sql.Tx, catalogID, profileID int64, source *catalogDto.SectionExport) error {
+	now := time.Now()
+
+	config := source.Config
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+
+	section := &catalogEntity.CatalogSection{
+		CatalogID: catalogID,
+		Type:      source.Type,
+		IsVisible: source.IsVisible,
+		Config:    config,
+		CreatedBy: database.NullInt64(profileID),
+		CreatedAt: now,
+	}
+	if err := uc.catalogRepo.CreateSection(ctx, tx, section); err != nil {
+		return err
+	}
+
+	for _, cardSource := range source.Cards {
+		card := &catalogEntity.CatalogCard{
+			SectionID:          section.ID,
+			Title:              cardSource.Title,
+			Subtitle:           database.NullString(cardSource.Subtitle),
+			Type:               cardSource.Type,
+			URL:                database.NullString(cardSource.URL),
+			IsVisible:          cardSource.IsVisible,
+			HasDetail:          cardSource.Detail != nil,
+			Discount:           cardSource.Discount,
+			Currency:           cardSource.Currency,
+			AffiliatePartnerID: database.NullString(cardSource.AffiliatePartnerID),
+			CreatedBy:          profileID,
+			CreatedAt:          now,
+		}
+		if cardSource.Price != nil {
+			card.Price = sql.NullInt64{Int64: *cardSource.Price, Valid: true}
+		}
+		if cardSource.AffiliateCommissionRate != nil {
+			card.AffiliateCommissionRate = sql.NullFloat64{Float64: *cardSource.AffiliateCommissionRate, Valid: true}
+		}
+		if err := uc.catalogRepo.CreateCard(ctx, tx, card); err != nil {
+			return err
+		}
+
+		if cardSource.Detail != nil {
+			detail := &catalogEntity.CatalogCardDetail{
+				CardID:          card.ID,
+				CatalogID:       catalogID,
+				Slug:            cardSource.Detail.Slug,
+				Description:     database.NullString(cardSource.Detail.Description),
+				DescriptionHTML: database.NullString(utils.RenderMarkdown(cardSource.Detail.Description)),
+				IsVisible:       cardSource.Detail.IsVisible,
+				CreatedBy:       profileID,
+				CreatedAt:       now,
+			}
+			if err := uc.catalogRepo.CreateCardDetail(ctx, tx, detail); err != nil {
+				return err
+			}
+
+			for _, linkSource := range cardSource.Detail.Links {
+				link := &catalogEntity.CatalogCardLink{
+					DetailID:  detail.ID,
+					Type:      linkSource.Type,
+					URL:       linkSource.URL,
+					UTM:       linkSource.UTM.Override(),
+					IsVisible: linkSource.IsVisible,
+					CreatedBy: profileID,
+					CreatedAt: now,
+				}
+				if err := uc.catalogRepo.CreateCardLink(ctx, tx, link); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, mediaSource := range cardSource.Media {
+			media := &catalogEntity.CatalogCardMedia{
+				CardID:    card.ID,
+				Type:      mediaSource.Type,
+				URL:       mediaSource.URL,
+				CreatedBy: profileID,
+				CreatedAt: now,
+			}
+			if err := uc.catalogRepo.CreateCardMedia(ctx, tx, media); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, faqSource := range source.FAQs {
+		faq := &catalogEntity.CatalogFAQ{
+			SectionID:    section.ID,
+			Question:     faqSource.Question,
+			Answer:       faqSource.Answer,
+			AnswerHTML:   database.NullString(utils.RenderMarkdown(faqSource.Answer)),
+			IsVisible:    faqSource.IsVisible,
+			DisplayOrder: i + 1,
+			CreatedBy:    profileID,
+			CreatedAt:    now,
+		}
+		if err := uc.catalogRepo.CreateFAQ(ctx, tx, faq); err != nil {
+			return err
+		}
+	}
+
+	// Teks legal dipulihkan sebagai versi 1 di section baru
+	if source.Legal != nil {
+		legal := &catalogEntity.CatalogLegalVersion{
+			SectionID:   section.ID,
+			Terms:       source.Legal.Terms,
+			TermsHTML:   database.NullString(utils.RenderMarkdown(source.Legal.Terms)),
+			Privacy:     source.Legal.Privacy,
+			PrivacyHTML: database.NullString(utils.RenderMarkdown(source.Legal.Privacy)),
+			CreatedBy:   profileID,
+			CreatedAt:   now,
+		}
+		if err := uc.catalogRepo.CreateLegalVersion(ctx, tx, legal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// availableSlug pakai slug lama jika masih kosong, jika tidak generate slug baru
+func (uc *backupUseCase) availableSlug(ctx context.Context, slug string) (string, error) {
+	exists, err := uc.catalogRepo.IsSlugExists(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return slug, nil
+	}
+
+	return service.GenerateUniqueSlug(
+		slug,
+		uc.slugService,
+		func(s string) (bool, error) {
+			return uc.catalogRepo.IsSlugExists(ctx, s)
+		},
+		5,
+	)
+}
+
+func toBackupResponse(backup *entity.CatalogBackup) *dto.BackupResponse {
+	return &dto.BackupResponse{
+		ID:           backup.ID,
+		BusinessID:   backup.BusinessID,
+		Trigger:      backup.Trigger,
+		Status:       backup.Status,
+		CatalogCount: backup.CatalogCount,
+		SizeBytes:    backup.SizeBytes,
+		Checksum:     backup.Checksum.String,
+		Error:        backup.Error.String,
+		CreatedAt:    backup.CreatedAt,
+		RestoredAt:   backup.RestoredAt,
+	}
+}
+
+// createCatalog buat katalog baru dari export, slug lama dipakai jika masih kosong
+func (uc *backupUseCase) createCatalog(ctx context.Context, tx *sql.Tx, businessID, profileID int64, source *catalogDto.CatalogExport, status string) (*catalogEntity.Catalog, error) {
+	slug, err := uc.availableSlug(ctx, source.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &catalogEntity.Catalog{
+		BusinessID:      businessID,
+		ThemeID:         source.ThemeID,
+		Slug:            slug,
+		Title:           source.Title,
+		Subtitle:        database.NullString(source.Subtitle),
+		IsActive:        true,
+		Status:          status,
+		Settings:        source.Settings,
+		MetaTitle:       database.NullString(source.MetaTitle),
+		MetaDescription: database.NullString(source.MetaDescription),
+		OGImage:         database.NullString(source.OGImage),
+		AllowIndexing:   source.IndexingAllowed(),
+		Listed:          source.Listed,
+		CategoryID:      database.NullInt64(source.CategoryID),
+		CreatedBy:       profileID,
+		CreatedAt:       time.Now(),
+	}
+	if catalog.Settings == nil {
+		catalog.Settings = make(map[string]interface{})
+	}
+	if err := uc.catalogRepo.Create(ctx, tx, catalog); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+// CloneBusiness buat business baru berisi salinan katalog terpilih. Member lain dan
+// subscription tidak ikut disalin, pembuat clone menjadi owner business baru
+func (uc *backupUseCase) CloneBusiness(ctx *gin.Context, businessID, profileID int64, req *dto.CloneBusinessRequest) (*dto.CloneResultResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessBackup); err != nil {
+		return nil, err
+	}
+
+	source, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs, err := uc.cloneSources(utils.ContextFrom(ctx), businessID, req.CatalogIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Export di luar transaksi, katalog arsip dibaca dari file arsip
+	exports := make([]*catalogDto.CatalogExport, 0, len(catalogs))
+	for _, catalog := range catalogs {
+		catalogExport, err := uc.exportCatalog(utils.ContextFrom(ctx), catalog)
+		if err != nil {
+			return nil, err
+		}
+		exports = append(exports, catalogExport)
+	}
+
+	slug, err := uc.businessSlug(utils.ContextFrom(ctx), req.Name, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	business := &businessEntity.Business{
+		Slug:       slug,
+		Name:       req.Name,
+		LogoURL:    source.LogoURL,
+		Type:       source.Type,
+		City:       source.City,
+		CategoryID: source.CategoryID,
+		IsActive:   true,
+		CreatedBy:  profileID,
+		CreatedAt:  time.Now(),
+	}
+	if err := uc.businessRepo.Create(utils.ContextFrom(ctx), tx, business); err != nil {
+		return nil, err
+	}
+
+	if err := uc.businessRepo.AddUser(utils.ContextFrom(ctx), tx, &businessEntity.BusinessUser{
+		BusinessID: business.ID,
+		ProfileID:  profileID,
+		Role:       constant.RoleOwner,
+		IsOwner:    true,
+		IsActive:   true,
+		CreatedAt:  time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := uc.businessRepo.UpdateBrand(utils.ContextFrom(ctx), tx, business.ID, &source.Brand, profileID); err != nil {
+		return nil, err
+	}
+
+	// Business baru belum punya subscription, salinan dibatasi plan Free
+	cards := 0
+	for _, catalogExport := range exports {
+		cards += countExportCards(catalogExport)
+	}
+	if err := uc.checkQuota(utils.ContextFrom(ctx), business.ID, len(exports), cards); err != nil {
+		return nil, err
+	}
+
+	result := &dto.CloneResultResponse{
+		SourceBusinessID: source.ID,
+		BusinessID:       business.ID,
+		Slug:             business.Slug,
+		Catalogs:         make([]dto.RestoredCatalogResult, 0, len(exports)),
+	}
+
+	// Salinan selalu draft, dipublish setelah disesuaikan untuk business baru
+	for _, catalogExport := range exports {
+		catalog, err := uc.createCatalog(utils.ContextFrom(ctx), tx, business.ID, profileID, catalogExport, constant.CatalogStatusDraft)
+		if err != nil {
+			return nil, err
+		}
+		for _, section := range catalogExport.Sections {
+			if err := uc.restoreSection(utils.ContextFrom(ctx), tx, catalog.ID, profileID, &section); err != nil {
+				return nil, err
+			}
+		}
+
+		result.Catalogs = append(result.Catalogs, dto.RestoredCatalogResult{
+			SourceID:  catalogExport.ID,
+			CatalogID: catalog.ID,
+			Slug:      catalog.Slug,
+			Action:    "cloned",
+			Sections:  len(catalogExport.Sections),
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	for _, cloned := range result.Catalogs {
+		uc.searchIndexer.Publish(service.SearchEvent{Type: constant.SearchEventCatalogCreated, CatalogID: cloned.CatalogID})
+	}
+
+	return result, nil
+}
+
+// cloneSources katalog yang akan di-clone, ids kosong berarti semua katalog aktif
+func (uc *backupUseCase) cloneSources(ctx context.Context, businessID int64, ids []int64) ([]*catalogEntity.Catalog, error) {
+	if len(ids) == 0 {
+		isActive := true
+		items, _, err := uc.catalogRepo.List(ctx, catalogRepo.ListFilter{
+			BusinessID: businessID,
+			IsActive:   &isActive,
+			Limit:      maxBackupCatalogs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+	}
+
+	catalogs := make([]*catalogEntity.Catalog, 0, len(ids))
+	for _, id := range ids {
+		catalog, err := uc.catalogRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if catalog.BusinessID != businessID {
+			return nil, errors.New(errors.ErrValidation, fmt.Sprintf("Katalog %d bukan milik business ini", id), 400)
+		}
+		catalogs = append(catalogs, catalog)
+	}
+
+	return catalogs, nil
+}
+
+// businessSlug validasi slug yang diminta atau generate dari nama business
+func (uc *backupUseCase) businessSlug(ctx context.Context, name, slug string) (string, error) {
+	if slug == "" {
+		return service.GenerateUniqueSlug(
+			name,
+			uc.slugService,
+			func(s string) (bool, error) {
+				return uc.businessRepo.IsSlugExists(ctx, s)
+			},
+			5,
+		)
+	}
+
+	if !uc.slugService.IsValid(slug) {
+		return "", errors.New(errors.ErrValidation, "Slug tidak valid", 400)
+	}
+
+	exists, err := uc.businessRepo.IsSlugExists(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", errors.New(errors.ErrConflict, constant.ErrMsgBusinessSlugExists, 409)
+	}
+
+	return slug, nil
+}
+
+// ExportWorkbook export data business ke workbook Excel (katalog, card beserta harga,
+// member, riwayat subscription). Workbook ditulis di background dan dibaca
+// bertahap dari reader yang dikembalikan, reader wajib di-Close pemanggil.
+func (uc *backupUseCase) ExportWorkbook(ctx *gin.Context, businessID, profileID int64) (string, io.ReadCloser, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessBackup); err != nil {
+		return "", nil, err
+	}
+
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	filename := fmt.Sprintf("%s-%s.xlsx", business.Slug, time.Now().Format("20060102"))
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(uc.writeWorkbook(utils.ContextFrom(ctx), pw, business.ID))
+	}()
+
+	return filename, pr, nil
+}
+
+func (uc *backupUseCase) writeWorkbook(ctx context.Context, w io.Writer, businessID int64) error {
+	xlsx := utils.NewXLSXWriter(w)
+
+	catalogs, _, err := uc.catalogRepo.List(ctx, catalogRepo.ListFilter{
+		BusinessID: businessID,
+		Limit:      maxBackupCatalogs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := xlsx.AddSheet("Katalog", "ID", "Slug", "Judul", "Status", "Aktif", "Dibuat", "Dipublish"); err != nil {
+		return err
+	}
+	for _, catalog := range catalogs {
+		var publishedAt interface{}
+		if catalog.PublishedAt != nil {
+			publishedAt = *catalog.PublishedAt
+		}
+		if err := xlsx.WriteRow(catalog.ID, catalog.Slug, catalog.Title, catalog.Status, catalog.IsActive, catalog.CreatedAt, publishedAt); err != nil {
+			return err
+		}
+	}
+
+	// Katalog arsip tidak punya section di database sehingga tidak muncul di sheet card
+	if err := xlsx.AddSheet("Card", "Katalog", "Section", "Card", "Tipe", "Harga", "Diskon (%)", "Mata Uang", "Tampil"); err != nil {
+		return err
+	}
+	for _, catalog := range catalogs {
+		sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, catalog.ID)
+		if err != nil {
+			return err
+		}
+		for _, section := range sections {
+			cards, err := uc.catalogRepo.GetCardsBySectionID(ctx, section.ID)
+			if err != nil {
+				return err
+			}
+			for _, card := range cards {
+				var price interface{}
+				if card.Price.Valid {
+					price = card.Price.Int64
+				}
+				if err := xlsx.WriteRow(catalog.Slug, section.Type, card.Title, card.Type, price, card.Discount, card.Currency, card.IsVisible); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	users, err := uc.businessRepo.GetUsersByBusinessID(ctx, businessID)
+	if err != nil {
+		return err
+	}
+
+	if err := xlsx.AddSheet("Member", "Nama", "Telepon", "Role", "Owner", "Bergabung"); err != nil {
+		return err
+	}
+	for _, user := range users {
+		if err := xlsx.WriteRow(user.Profile.GetDisplayName(), user.Profile.Phone.String, user.Role, user.IsOwner, user.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	subscriptions, err := uc.businessRepo.ListSubscriptions(ctx, businessID)
+	if err != nil {
+		return err
+	}
+
+	if err := xlsx.AddSheet("Subscription", "Paket", "Harga", "Durasi", "Status", "Mulai", "Berakhir"); err != nil {
+		return err
+	}
+	for _, sub := range subscriptions {
+		if err := xlsx.WriteRow(sub.Plan.Name, sub.Plan.Price, sub.Plan.Duration, sub.Status, sub.StartsAt, sub.ExpiresAt); err != nil {
+			return err
+		}
+	}
+
+	return xlsx.Close()
+}
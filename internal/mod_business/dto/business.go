@@ -10,6 +10,8 @@ type CreateBusinessRequest struct {
 	Name string `json:"name" validate:"required,min=3,max=200"`
 	Slug string `json:"slug,omitempty" validate:"omitempty,slug,min=3,max=100"`
 	Type string `json:"type" validate:"required,oneof=retail service manufacturing technology hospitality healthcare education other"`
+	City string `json:"city,omitempty" validate:"omitempty,max=100"`
+	CategoryID int64 `json:"category_id,omitempty" validate:"omitempty,gt=0"`
 	LogoFile *multipart.FileHeader `form:"logo"`
 }
 
@@ -17,10 +19,54 @@ type CreateBusinessRequest struct {
 type UpdateBusinessRequest struct {
 	Name     string `json:"name,omitempty" validate:"omitempty,min=3,max=200"`
 	Type     string `json:"type,omitempty" validate:"omitempty,oneof=retail service manufacturing technology hospitality healthcare education other"`
+	City     *string `json:"city,omitempty" validate:"omitempty,max=100"`
+	CategoryID *int64 `json:"category_id,omitempty" validate:"omitempty,gte=0"` // 0 = hapus kategori
 	IsActive *bool  `json:"is_active,omitempty"`
 	LogoFile *multipart.FileHeader `form:"logo"`
 }
 
+// UpdateMediaReplicationRequest request opsi replikasi media ke region kedua
+type UpdateMediaReplicationRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+// UpdateBrandRequest request brand business, menggantikan brand sebelumnya
+type UpdateBrandRequest struct {
+	PrimaryColor   string `json:"primary_color,omitempty" validate:"omitempty,hexcolor"`
+	SecondaryColor string `json:"secondary_color,omitempty" validate:"omitempty,hexcolor"`
+	FontFamily     string `json:"font_family,omitempty" validate:"omitempty,max=100"`
+	HeadingFont    string `json:"heading_font,omitempty" validate:"omitempty,max=100"`
+	ShowLogo       *bool  `json:"show_logo,omitempty"`
+}
+
+// BrandResponse response brand business
+type BrandResponse struct {
+	BusinessID     int64   `json:"business_id"`
+	PrimaryColor   string  `json:"primary_color,omitempty"`
+	SecondaryColor string  `json:"secondary_color,omitempty"`
+	FontFamily     string  `json:"font_family,omitempty"`
+	HeadingFont    string  `json:"heading_font,omitempty"`
+	ShowLogo       *bool   `json:"show_logo,omitempty"`
+	LogoURL        *string `json:"logo_url,omitempty"`
+}
+
+// OnboardingStepResponse satu langkah checklist onboarding
+type OnboardingStepResponse struct {
+	Key       string `json:"key"`
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
+
+// OnboardingResponse progres checklist onboarding business
+type OnboardingResponse struct {
+	BusinessID     int64                    `json:"business_id"`
+	Steps          []OnboardingStepResponse `json:"steps"`
+	CompletedSteps int                      `json:"completed_steps"`
+	TotalSteps     int                      `json:"total_steps"`
+	Percent        int                      `json:"percent"`
+	IsCompleted    bool                     `json:"is_completed"`
+}
+
 // BusinessResponse response untuk business
 type BusinessResponse struct {
 	ID               int64                  `json:"id"`
@@ -28,9 +74,12 @@ type BusinessResponse struct {
 	Name             string                 `json:"name"`
 	LogoURL          *string                `json:"logo_url,omitempty"`
 	Type             string                 `json:"type"`
+	City             *string                `json:"city,omitempty"`
+	CategoryID       *int64                 `json:"category_id,omitempty"`
 	IsActive         bool                   `json:"is_active"`
 	IsSuspended      bool                   `json:"is_suspended"`
 	SuspensionReason string                 `json:"suspension_reason,omitempty"`
+	MediaReplication bool                   `json:"media_replication"`
 	CreatedBy        int64                  `json:"created_by"`
 	CreatedAt        time.Time              `json:"created_at"`
 	UpdatedAt        *time.Time             `json:"updated_at,omitempty"`
@@ -94,30 +143,75 @@ type PlanResponse struct {
 // AddUserRequest request untuk add user to business
 type AddUserRequest struct {
 	ProfileID int64  `json:"profile_id" validate:"required,gt=0"`
-	Role      string `json:"role" validate:"required,oneof=owner admin editor viewer"`
+	Role      string `json:"role" validate:"required,oneof=owner admin reviewer editor viewer"`
 }
 
 // UpdateUserRoleRequest request untuk update user role
 type UpdateUserRoleRequest struct {
-	Role string `json:"role" validate:"required,oneof=owner admin editor viewer"`
+	Role string `json:"role" validate:"required,oneof=owner admin reviewer editor viewer"`
 }
 
 // CreateInviteRequest request untuk create invite
 type CreateInviteRequest struct {
-	Role      string    `json:"role" validate:"required,oneof=admin editor viewer"`
+	Role      string    `json:"role" validate:"required,oneof=admin reviewer editor viewer"`
+	Email     string    `json:"email,omitempty" validate:"omitempty,email,max=255"` // jika diisi, link invite dikirim ke email ini
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
 }
 
 // InviteResponse response untuk invite
 type InviteResponse struct {
-	ID        int64     `json:"id"`
-	Token     string    `json:"token"`
-	Role      string    `json:"role"`
-	InvitedBy int64     `json:"invited_by"`
-	IsUsed    bool      `json:"is_used"`
-	ExpiresAt time.Time `json:"expires_at"`
-	CreatedAt time.Time `json:"created_at"`
-	InviteURL string    `json:"invite_url,omitempty"`
+	ID        int64      `json:"id"`
+	Token     string     `json:"token"`
+	Role      string     `json:"role"`
+	InvitedBy int64      `json:"invited_by"`
+	Email     string     `json:"email,omitempty"`
+	IsUsed    bool       `json:"is_used"`
+	Status    string     `json:"status"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	ResentAt  *time.Time `json:"resent_at,omitempty"`
+	InviteURL string     `json:"invite_url,omitempty"`
+}
+
+// CreateServiceAccountRequest request untuk membuat service account
+type CreateServiceAccountRequest struct {
+	Name string `json:"name" validate:"required,min=3,max=100"`
+	Role string `json:"role,omitempty" validate:"omitempty,oneof=admin reviewer editor viewer"`
+}
+
+// ServiceAccountResponse response untuk service account
+type ServiceAccountResponse struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	Role        string     `json:"role"`
+	TokenPrefix string     `json:"token_prefix"`
+	IsActive    bool       `json:"is_active"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	CreatedBy   int64      `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ServiceAccountCreatedResponse response setelah service account dibuat,
+// token hanya ditampilkan sekali
+type ServiceAccountCreatedResponse struct {
+	ServiceAccountResponse
+	Token string `json:"token"`
+}
+
+// UpdateIPAllowlistRequest request IP allowlist, menggantikan daftar sebelumnya.
+// Daftar kosong menonaktifkan allowlist
+type UpdateIPAllowlistRequest struct {
+	Entries []string `json:"entries" validate:"omitempty,dive,required,max=50"`
+}
+
+// IPAllowlistResponse response IP allowlist business
+type IPAllowlistResponse struct {
+	Entries     []string   `json:"entries"`
+	Enabled     bool       `json:"enabled"`
+	BypassUntil *time.Time `json:"bypass_until,omitempty"` // diisi selama break-glass aktif
+	ClientIP    string     `json:"client_ip"`              // IP pemanggil, untuk membantu menyusun allowlist
 }
 
 // AcceptInviteRequest request untuk accept invite
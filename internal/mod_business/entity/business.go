@@ -2,6 +2,8 @@ package entity
 
 import (
 	"database/sql"
+	"net"
+	"strings"
 	"time"
 )
 
@@ -12,11 +14,15 @@ type Business struct {
 	Name             string         `json:"name" db:"b_name"`
 	LogoURL          sql.NullString `json:"logo_url" db:"b_logo_url"`
 	Type             string         `json:"type" db:"b_type"`
+	City             sql.NullString `json:"city" db:"b_city"`
+	CategoryID       sql.NullInt64  `json:"category_id" db:"b_mc_id"`
 	IsActive         bool           `json:"is_active" db:"b_is_active"`
 	IsSuspended      bool           `json:"is_suspended" db:"b_is_suspended"`
 	SuspensionReason sql.NullString `json:"suspension_reason" db:"b_suspension_reason"`
 	SuspendedBy      sql.NullInt64  `json:"suspended_by" db:"b_suspended_by"`
 	SuspendedAt      *time.Time     `json:"suspended_at" db:"b_suspended_at"`
+	MediaReplication bool           `json:"media_replication" db:"b_media_replication"`
+	Brand            BrandSettings  `json:"brand" db:"b_brand"`
 	CreatedBy        int64          `json:"created_by" db:"b_created_by"`
 	CreatedAt        time.Time      `json:"created_at" db:"b_created_at"`
 	UpdatedBy        sql.NullInt64  `json:"updated_by" db:"b_updated_by"`
@@ -28,6 +34,67 @@ type Business struct {
 	ActivePlan    *BusinessSubscription  `json:"active_plan,omitempty"`
 }
 
+// BrandSettings brand business yang diwariskan ke settings katalog
+type BrandSettings struct {
+	PrimaryColor   string `json:"primary_color,omitempty"`
+	SecondaryColor string `json:"secondary_color,omitempty"`
+	FontFamily     string `json:"font_family,omitempty"`
+	HeadingFont    string `json:"heading_font,omitempty"`
+	ShowLogo       *bool  `json:"show_logo,omitempty"`
+}
+
+// OnboardingProgress status langkah onboarding, dihitung dari data yang sudah ada
+type OnboardingProgress struct {
+	BusinessID       int64 `db:"b_id"`
+	LogoUploaded     bool  `db:"logo_uploaded"`
+	CatalogCreated   bool  `db:"catalog_created"`
+	CardAdded        bool  `db:"card_added"`
+	CatalogPublished bool  `db:"catalog_published"`
+	LinkVisited      bool  `db:"link_visited"` // katalog pernah dikunjungi pengunjung (bukan bot)
+}
+
+// IPAllowlist daftar IP/CIDR yang boleh melakukan operasi tulis pada business
+type IPAllowlist struct {
+	BusinessID  int64      `json:"business_id" db:"b_id"`
+	Entries     []string   `json:"entries" db:"b_ip_allowlist"`
+	BypassUntil *time.Time `json:"bypass_until" db:"b_ip_allowlist_bypass_until"`
+}
+
+// IsBypassed check apakah allowlist sedang dibuka sementara lewat break-glass
+func (a *IPAllowlist) IsBypassed(now time.Time) bool {
+	return a.BypassUntil != nil && now.Before(*a.BypassUntil)
+}
+
+// Allows check apakah IP boleh melakukan operasi tulis. Allowlist kosong
+// atau sedang di-bypass mengizinkan semua IP
+func (a *IPAllowlist) Allows(ip string, now time.Time) bool {
+	if len(a.Entries) == 0 || a.IsBypassed(now) {
+		return true
+	}
+	return MatchIPEntries(a.Entries, ip)
+}
+
+// MatchIPEntries check apakah IP termasuk salah satu entri IP/CIDR
+func MatchIPEntries(entries []string, ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	for _, entry := range entries {
+		if strings.Contains(entry, "/") {
+			if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 // BusinessUser entity untuk tabel business_users
 type BusinessUser struct {
 	ID        int64     `json:"id" db:"bu_id"`
@@ -45,20 +112,41 @@ type BusinessUser struct {
 
 // BusinessInvite entity untuk tabel business_invites
 type BusinessInvite struct {
-	ID        int64     `json:"id" db:"bi_id"`
-	BusinessID int64    `json:"business_id" db:"bi_b_id"`
-	Token     string    `json:"token" db:"bi_token"`
-	Role      string    `json:"role" db:"bi_role"`
-	InvitedBy int64     `json:"invited_by" db:"bi_invited_by"`
-	IsUsed    bool      `json:"is_used" db:"bi_is_used"`
-	ExpiresAt time.Time `json:"expires_at" db:"bi_expires_at"`
-	CreatedAt time.Time `json:"created_at" db:"bi_created_at"`
+	ID         int64          `json:"id" db:"bi_id"`
+	BusinessID int64          `json:"business_id" db:"bi_b_id"`
+	Token      string         `json:"token" db:"bi_token"`
+	Role       string         `json:"role" db:"bi_role"`
+	InvitedBy  int64          `json:"invited_by" db:"bi_invited_by"`
+	Email      sql.NullString `json:"email" db:"bi_email"`
+	IsUsed     bool           `json:"is_used" db:"bi_is_used"`
+	Status     string         `json:"status" db:"bi_status"`
+	ExpiresAt  time.Time      `json:"expires_at" db:"bi_expires_at"`
+	CreatedAt  time.Time      `json:"created_at" db:"bi_created_at"`
+	RevokedBy  sql.NullInt64  `json:"revoked_by" db:"bi_revoked_by"`
+	RevokedAt  *time.Time     `json:"revoked_at" db:"bi_revoked_at"`
+	ResentAt   *time.Time     `json:"resent_at" db:"bi_resent_at"`
 
 	// Relations
 	Business   *Business    `json:"business,omitempty"`
 	InvitedUser *UserProfile `json:"invited_user,omitempty"`
 }
 
+// ServiceAccount entity untuk tabel business_service_accounts
+type ServiceAccount struct {
+	ID          int64         `json:"id" db:"bsa_id"`
+	BusinessID  int64         `json:"business_id" db:"bsa_b_id"`
+	Name        string        `json:"name" db:"bsa_name"`
+	Role        string        `json:"role" db:"bsa_role"`
+	TokenHash   string        `json:"-" db:"bsa_token_hash"`
+	TokenPrefix string        `json:"token_prefix" db:"bsa_token_prefix"`
+	IsActive    bool          `json:"is_active" db:"bsa_is_active"`
+	LastUsedAt  *time.Time    `json:"last_used_at" db:"bsa_last_used_at"`
+	CreatedBy   int64         `json:"created_by" db:"bsa_created_by"`
+	CreatedAt   time.Time     `json:"created_at" db:"bsa_created_at"`
+	RevokedBy   sql.NullInt64 `json:"revoked_by" db:"bsa_revoked_by"`
+	RevokedAt   *time.Time    `json:"revoked_at" db:"bsa_revoked_at"`
+}
+
 // BusinessSubscription entity untuk tabel business_subscriptions
 type BusinessSubscription struct {
 	ID        int64      `json:"id" db:"bs_id"`
@@ -142,14 +230,64 @@ func (b *Business) HasActiveSubscription() bool {
 	       b.ActivePlan.ExpiresAt.After(time.Now())
 }
 
-// IsExpired check apakah invite sudah expired
-func (bi *BusinessInvite) IsExpired() bool {
-	return bi.ExpiresAt.Before(time.Now())
+// CatalogSettings brand dalam bentuk settings katalog, hanya field yang diisi
+func (bs *BrandSettings) CatalogSettings() map[string]interface{} {
+	settings := make(map[string]interface{})
+	if bs.PrimaryColor != "" {
+		settings["primary_color"] = bs.PrimaryColor
+	}
+	if bs.SecondaryColor != "" {
+		settings["secondary_color"] = bs.SecondaryColor
+	}
+	if bs.FontFamily != "" {
+		settings["font_family"] = bs.FontFamily
+	}
+	if bs.HeadingFont != "" {
+		settings["heading_font"] = bs.HeadingFont
+	}
+	if bs.ShowLogo != nil {
+		settings["show_logo"] = *bs.ShowLogo
+	}
+	return settings
+}
+
+// IsExpired check apakah invite sudah expired pada waktu now
+func (bi *BusinessInvite) IsExpired(now time.Time) bool {
+	return bi.ExpiresAt.Before(now)
+}
+
+// IsPending check apakah invite belum digunakan, dibatalkan atau ditandai kadaluarsa
+func (bi *BusinessInvite) IsPending() bool {
+	return bi.Status == "pending" && !bi.IsUsed
+}
+
+// IsValid check apakah invite masih valid pada waktu now
+func (bi *BusinessInvite) IsValid(now time.Time) bool {
+	return bi.IsPending() && !bi.IsExpired(now)
+}
+
+// FeatureInt nilai feature plan bertipe angka, fallback jika tidak diisi atau <= 0
+func (p *MasterPlan) FeatureInt(key string, fallback int) int {
+	// Angka dari JSONB ter-decode sebagai float64
+	if value, ok := p.Features[key].(float64); ok && value > 0 {
+		return int(value)
+	}
+	return fallback
+}
+
+// FeatureLimit batas feature plan bertipe angka, 0 = tanpa batas (-1 di features).
+// Fallback jika tidak diisi
+func (p *MasterPlan) FeatureLimit(key string, fallback int) int {
+	if value, ok := p.Features[key].(float64); ok && value < 0 {
+		return 0
+	}
+	return p.FeatureInt(key, fallback)
 }
 
-// IsValid check apakah invite masih valid
-func (bi *BusinessInvite) IsValid() bool {
-	return !bi.IsUsed && !bi.IsExpired()
+// FeatureEnabled check apakah feature plan bertipe boolean aktif
+func (p *MasterPlan) FeatureEnabled(key string) bool {
+	enabled, _ := p.Features[key].(bool)
+	return enabled
 }
 
 // IsActive check apakah subscription aktif
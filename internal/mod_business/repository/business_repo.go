@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/atam/atamlink/internal/constant"
 	"github.com/atam/atamlink/internal/mod_business/entity"
 	"github.com/atam/atamlink/pkg/database"
@@ -13,37 +16,63 @@ import (
 
 // BusinessRepository interface untuk business repository
 type BusinessRepository interface {
-	Create(tx *sql.Tx, business *entity.Business) error
-	GetByID(id int64) (*entity.Business, error)
-	GetBySlug(slug string) (*entity.Business, error)
-	List(filter ListFilter) ([]*entity.Business, int64, error)
-	Update(tx *sql.Tx, business *entity.Business) error
-	Delete(tx *sql.Tx, id int64) error
+	Create(ctx context.Context, tx *sql.Tx, business *entity.Business) error
+	GetByID(ctx context.Context, id int64) (*entity.Business, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.Business, error)
+	List(ctx context.Context, filter ListFilter) ([]*entity.Business, int64, error)
+	Update(ctx context.Context, tx *sql.Tx, business *entity.Business) error
+	Delete(ctx context.Context, tx *sql.Tx, id int64) error
+	SetMediaReplication(ctx context.Context, tx *sql.Tx, id int64, enabled bool, profileID int64) error
+	UpdateBrand(ctx context.Context, tx *sql.Tx, id int64, brand *entity.BrandSettings, profileID int64) error
+	GetOnboardingProgress(ctx context.Context, id int64) (*entity.OnboardingProgress, error)
 
 	// Business User methods
-	AddUser(tx *sql.Tx, businessUser *entity.BusinessUser) error
-	GetUsersByBusinessID(businessID int64) ([]*entity.BusinessUser, error)
-	GetUserByBusinessAndProfile(businessID, profileID int64) (*entity.BusinessUser, error)
-	UpdateUserRole(tx *sql.Tx, businessID, profileID int64, role string) error
-	RemoveUser(tx *sql.Tx, businessID, profileID int64) error
+	AddUser(ctx context.Context, tx *sql.Tx, businessUser *entity.BusinessUser) error
+	GetUsersByBusinessID(ctx context.Context, businessID int64) ([]*entity.BusinessUser, error)
+	GetUserByBusinessAndProfile(ctx context.Context, businessID, profileID int64) (*entity.BusinessUser, error)
+	UpdateUserRole(ctx context.Context, tx *sql.Tx, businessID, profileID int64, role string) error
+	RemoveUser(ctx context.Context, tx *sql.Tx, businessID, profileID int64) error
 
 	// Business Invite methods
-	CreateInvite(tx *sql.Tx, invite *entity.BusinessInvite) error
-	GetInviteByToken(token string) (*entity.BusinessInvite, error)
-	UseInvite(tx *sql.Tx, token string) error
+	CreateInvite(ctx context.Context, tx *sql.Tx, invite *entity.BusinessInvite) error
+	GetInviteByToken(ctx context.Context, token string) (*entity.BusinessInvite, error)
+	GetInviteByID(ctx context.Context, id int64) (*entity.BusinessInvite, error)
+	ListInvites(ctx context.Context, businessID int64, status string) ([]*entity.BusinessInvite, error)
+	UseInvite(ctx context.Context, tx *sql.Tx, token string) error
+	RevokeInvite(ctx context.Context, tx *sql.Tx, id, profileID int64) error
+	RenewInvite(ctx context.Context, tx *sql.Tx, id int64, expiresAt, resentAt time.Time) error
+	ExpireStaleInvites(ctx context.Context, now time.Time, excludeBusinessIDs []int64, limit int) (int64, error)
+	ExpireStaleBusinessInvites(ctx context.Context, businessID int64, now time.Time, limit int) (int64, error)
+
+	// Service account methods
+	CreateServiceAccount(ctx context.Context, tx *sql.Tx, account *entity.ServiceAccount) error
+	ListServiceAccounts(ctx context.Context, businessID int64) ([]*entity.ServiceAccount, error)
+	GetServiceAccountByTokenHash(ctx context.Context, tokenHash string) (*entity.ServiceAccount, error)
+	IsServiceAccountNameExists(ctx context.Context, businessID int64, name string) (bool, error)
+	RevokeServiceAccount(ctx context.Context, tx *sql.Tx, businessID, accountID, profileID int64) error
+	TouchServiceAccount(ctx context.Context, id int64, usedAt time.Time) error
+
+	// IP allowlist methods
+	GetIPAllowlist(ctx context.Context, businessID int64) (*entity.IPAllowlist, error)
+	UpdateIPAllowlist(ctx context.Context, tx *sql.Tx, businessID int64, entries []string, profileID int64) error
+	SetIPAllowlistBypass(ctx context.Context, tx *sql.Tx, businessID int64, until time.Time) error
 
 	// Business Subscription methods
-	GetActiveSubscription(businessID int64) (*entity.BusinessSubscription, error)
-	CreateSubscription(tx *sql.Tx, subscription *entity.BusinessSubscription) error
-	UpdateSubscription(tx *sql.Tx, subscription *entity.BusinessSubscription) error
+	GetActiveSubscription(ctx context.Context, businessID int64, now time.Time) (*entity.BusinessSubscription, error)
+	ListExpiringSubscriptions(ctx context.Context, from, to time.Time) ([]*entity.BusinessSubscription, error)
+	ListSubscriptions(ctx context.Context, businessID int64) ([]*entity.BusinessSubscription, error)
+	CreateSubscription(ctx context.Context, tx *sql.Tx, subscription *entity.BusinessSubscription) error
+	UpdateSubscription(ctx context.Context, tx *sql.Tx, subscription *entity.BusinessSubscription) error
 
 	// Helper methods
-	IsSlugExists(slug string) (bool, error)
-	CountUserBusinesses(profileID int64) (int, error)
+	IsSlugExists(ctx context.Context, slug string) (bool, error)
+	IsCategoryActive(ctx context.Context, categoryID int64) (bool, error)
+	CountUserBusinesses(ctx context.Context, profileID int64) (int, error)
+	CountActiveUsers(ctx context.Context, businessID int64) (int, error)
 }
 
 type businessRepository struct {
-	db *sql.DB
+	db  *sql.DB
 }
 
 // NewBusinessRepository membuat instance business repository baru
@@ -58,26 +87,30 @@ type ListFilter struct {
 	IsActive    *bool
 	IsSuspended *bool
 	ProfileID   int64
+	BusinessID  int64 // dibatasi ke satu business, dipakai untuk service account
 	Limit       int
 	Offset      int
 	OrderBy     string
+	Keyset      *database.Keyset // cursor pagination, menggantikan Offset/OrderBy dan total tidak dihitung
 }
 
 // Create membuat business baru
-func (r *businessRepository) Create(tx *sql.Tx, business *entity.Business) error {
+func (r *businessRepository) Create(ctx context.Context, tx *sql.Tx, business *entity.Business) error {
 	query := `
 		INSERT INTO atamlink.businesses (
-			b_slug, b_name, b_logo_url, b_type, b_is_active, b_is_suspended,
+			b_slug, b_name, b_logo_url, b_type, b_city, b_mc_id, b_is_active, b_is_suspended,
 			b_created_by, b_created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING b_id`
 
-	err := tx.QueryRow(
+	err := tx.QueryRowContext(ctx,
 		query,
 		business.Slug,
 		business.Name,
 		business.LogoURL,
 		business.Type,
+		business.City,
+		business.CategoryID,
 		business.IsActive,
 		business.IsSuspended,
 		business.CreatedBy,
@@ -92,33 +125,16 @@ func (r *businessRepository) Create(tx *sql.Tx, business *entity.Business) error
 }
 
 // GetByID mendapatkan business berdasarkan ID
-func (r *businessRepository) GetByID(id int64) (*entity.Business, error) {
+func (r *businessRepository) GetByID(ctx context.Context, id int64) (*entity.Business, error) {
 	query := `
 		SELECT 
-			b_id, b_slug, b_name, b_logo_url, b_type, b_is_active, b_is_suspended,
-			b_suspension_reason, b_suspended_by, b_suspended_at,
+			b_id, b_slug, b_name, b_logo_url, b_type, b_city, b_mc_id, b_is_active, b_is_suspended,
+			b_suspension_reason, b_suspended_by, b_suspended_at, b_media_replication, b_brand,
 			b_created_by, b_created_at, b_updated_by, b_updated_at
 		FROM atamlink.businesses
 		WHERE b_id = $1`
 
-	business := &entity.Business{}
-	err := r.db.QueryRow(query, id).Scan(
-		&business.ID,
-		&business.Slug,
-		&business.Name,
-		&business.LogoURL,
-		&business.Type,
-		&business.IsActive,
-		&business.IsSuspended,
-		&business.SuspensionReason,
-		&business.SuspendedBy,
-		&business.SuspendedAt,
-		&business.CreatedBy,
-		&business.CreatedAt,
-		&business.UpdatedBy,
-		&business.UpdatedAt,
-	)
-
+	business, err := database.Get[entity.Business](ctx, r.db, query, id)
 	if err == sql.ErrNoRows {
 		return nil, errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
 	}
@@ -130,7 +146,7 @@ func (r *businessRepository) GetByID(id int64) (*entity.Business, error) {
 }
 
 // GetBySlug mendapatkan business berdasarkan slug
-func (r *businessRepository) GetBySlug(slug string) (*entity.Business, error) {
+func (r *businessRepository) GetBySlug(ctx context.Context, slug string) (*entity.Business, error) {
 	query := `
 		SELECT 
 			b_id, b_slug, b_name, b_logo_url, b_type, b_is_active, b_is_suspended,
@@ -139,24 +155,7 @@ func (r *businessRepository) GetBySlug(slug string) (*entity.Business, error) {
 		FROM atamlink.businesses
 		WHERE b_slug = $1`
 
-	business := &entity.Business{}
-	err := r.db.QueryRow(query, slug).Scan(
-		&business.ID,
-		&business.Slug,
-		&business.Name,
-		&business.LogoURL,
-		&business.Type,
-		&business.IsActive,
-		&business.IsSuspended,
-		&business.SuspensionReason,
-		&business.SuspendedBy,
-		&business.SuspendedAt,
-		&business.CreatedBy,
-		&business.CreatedAt,
-		&business.UpdatedBy,
-		&business.UpdatedAt,
-	)
-
+	business, err := database.Get[entity.Business](ctx, r.db, query, slug)
 	if err == sql.ErrNoRows {
 		return nil, errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
 	}
@@ -168,7 +167,7 @@ func (r *businessRepository) GetBySlug(slug string) (*entity.Business, error) {
 }
 
 // List mendapatkan list businesses dengan filter
-func (r *businessRepository) List(filter ListFilter) ([]*entity.Business, int64, error) {
+func (r *businessRepository) List(ctx context.Context, filter ListFilter) ([]*entity.Business, int64, error) {
 	// Build query dengan filter
 	qb := database.NewQueryBuilder()
 	qb.Select(
@@ -194,81 +193,68 @@ func (r *businessRepository) List(filter ListFilter) ([]*entity.Business, int64,
 		qb.Where("b_is_suspended = ?", *filter.IsSuspended)
 	}
 
+	if filter.BusinessID > 0 {
+		qb.Where("b_id = ?", filter.BusinessID)
+	}
+
 	if filter.ProfileID > 0 {
 		qb.InnerJoin("atamlink.business_users", "bu_b_id = b_id")
 		qb.Where("bu_up_id = ? AND bu_is_active = true", filter.ProfileID)
 	}
 
-	// Count total
-	countQuery, countArgs := qb.BuildCount()
 	var total int64
-	err := r.db.QueryRow(countQuery, countArgs...).Scan(&total)
-	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to count businesses")
+	if filter.Keyset != nil {
+		qb.ApplyKeyset(filter.Keyset)
+	} else {
+		// Count total
+		countQuery, countArgs := qb.BuildCount()
+		err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to count businesses")
+		}
+
+		qb.OrderBy(filter.OrderBy)
+		qb.Offset(filter.Offset)
 	}
 
 	// Get data
-	qb.OrderBy(filter.OrderBy)
 	qb.Limit(filter.Limit)
-	qb.Offset(filter.Offset)
 
 	query, args := qb.Build()
-	rows, err := r.db.Query(query, args...)
+	businesses, err := database.Select[entity.Business](ctx, r.db, query, args...)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to query businesses")
 	}
-	defer rows.Close()
-
-	businesses := make([]*entity.Business, 0)
-	for rows.Next() {
-		business := &entity.Business{}
-		err := rows.Scan(
-			&business.ID,
-			&business.Slug,
-			&business.Name,
-			&business.LogoURL,
-			&business.Type,
-			&business.IsActive,
-			&business.IsSuspended,
-			&business.SuspensionReason,
-			&business.SuspendedBy,
-			&business.SuspendedAt,
-			&business.CreatedBy,
-			&business.CreatedAt,
-			&business.UpdatedBy,
-			&business.UpdatedAt,
-		)
-		if err != nil {
-			return nil, 0, errors.Wrap(err, "failed to scan business row")
-		}
-		businesses = append(businesses, business)
-	}
 
 	return businesses, total, nil
 }
 
 // Update update business
-func (r *businessRepository) Update(tx *sql.Tx, business *entity.Business) error {
+func (r *businessRepository) Update(ctx context.Context, tx *sql.Tx, business *entity.Business) error {
 	query := `
 		UPDATE atamlink.businesses SET
 			b_name = $2,
 			b_logo_url = $3,
 			b_type = $4,
-			b_is_active = $5,
-			b_is_suspended = $6,
-			b_suspension_reason = $7,
-			b_suspended_by = $8,
-			b_suspended_at = $9,
-			b_updated_by = $10,
-			b_updated_at = $11
+			b_city = $5,
+			b_mc_id = $6,
+			b_is_active = $7,
+			b_is_suspended = $8,
+			b_suspension_reason = $9,
+			b_suspended_by = $10,
+			b_suspended_at = $11,
+			b_updated_by = $12,
+			b_updated_at = $13
 		WHERE b_id = $1`
 
-	result, err := tx.Exec(
+	result, err := tx.ExecContext(ctx,
 		query,
 		business.ID,
 		business.Name,
 		business.LogoURL,
 		business.Type,
+		business.City,
+		business.CategoryID,
 		business.IsActive,
 		business.IsSuspended,
 		business.SuspensionReason,
@@ -295,13 +281,13 @@ func (r *businessRepository) Update(tx *sql.Tx, business *entity.Business) error
 }
 
 // Delete soft delete business
-func (r *businessRepository) Delete(tx *sql.Tx, id int64) error {
+func (r *businessRepository) Delete(ctx context.Context, tx *sql.Tx, id int64) error {
 	query := `
 		UPDATE atamlink.businesses 
 		SET b_is_active = false, b_updated_at = $2
 		WHERE b_id = $1`
 
-	result, err := tx.Exec(query, id, time.Now())
+	result, err := tx.ExecContext(ctx, query, id, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "failed to delete business")
 	}
@@ -318,15 +304,72 @@ func (r *businessRepository) Delete(tx *sql.Tx, id int64) error {
 	return nil
 }
 
+// SetMediaReplication aktifkan/nonaktifkan replikasi media business
+func (r *businessRepository) SetMediaReplication(ctx context.Context, tx *sql.Tx, id int64, enabled bool, profileID int64) error {
+	query := `
+		UPDATE atamlink.businesses SET
+			b_media_replication = $2,
+			b_updated_by = $3,
+			b_updated_at = $4
+		WHERE b_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, enabled, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update media replication")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
+	}
+
+	return nil
+}
+
+// UpdateBrand simpan brand settings business
+func (r *businessRepository) UpdateBrand(ctx context.Context, tx *sql.Tx, id int64, brand *entity.BrandSettings, profileID int64) error {
+	brandJSON, err := json.Marshal(brand)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal brand")
+	}
+
+	query := `
+		UPDATE atamlink.businesses SET
+			b_brand = $2,
+			b_updated_by = $3,
+			b_updated_at = $4
+		WHERE b_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, brandJSON, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update brand")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
+	}
+
+	return nil
+}
+
 // AddUser menambahkan user ke business
-func (r *businessRepository) AddUser(tx *sql.Tx, businessUser *entity.BusinessUser) error {
+func (r *businessRepository) AddUser(ctx context.Context, tx *sql.Tx, businessUser *entity.BusinessUser) error {
 	query := `
 		INSERT INTO atamlink.business_users (
 			bu_b_id, bu_up_id, bu_role, bu_is_owner, bu_is_active, bu_created_at
 		) VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING bu_id`
 
-	err := tx.QueryRow(
+	err := tx.QueryRowContext(ctx,
 		query,
 		businessUser.BusinessID,
 		businessUser.ProfileID,
@@ -344,7 +387,7 @@ func (r *businessRepository) AddUser(tx *sql.Tx, businessUser *entity.BusinessUs
 }
 
 // GetUsersByBusinessID mendapatkan users by business ID
-func (r *businessRepository) GetUsersByBusinessID(businessID int64) ([]*entity.BusinessUser, error) {
+func (r *businessRepository) GetUsersByBusinessID(ctx context.Context, businessID int64) ([]*entity.BusinessUser, error) {
 	query := `
 		SELECT 
 			bu.bu_id, bu.bu_b_id, bu.bu_up_id, bu.bu_role, 
@@ -355,56 +398,25 @@ func (r *businessRepository) GetUsersByBusinessID(businessID int64) ([]*entity.B
 		WHERE bu.bu_b_id = $1 AND bu.bu_is_active = true
 		ORDER BY bu.bu_created_at ASC`
 
-	rows, err := r.db.Query(query, businessID)
+	users, err := database.Select[entity.BusinessUser](ctx, r.db, query, businessID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get business users")
 	}
-	defer rows.Close()
-
-	users := make([]*entity.BusinessUser, 0)
-	for rows.Next() {
-		user := &entity.BusinessUser{
-			Profile: &entity.UserProfile{},
-		}
-		err := rows.Scan(
-			&user.ID,
-			&user.BusinessID,
-			&user.ProfileID,
-			&user.Role,
-			&user.IsOwner,
-			&user.IsActive,
-			&user.CreatedAt,
-			&user.Profile.Phone,
-			&user.Profile.DisplayName,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan business user")
-		}
+	for _, user := range users {
 		user.Profile.ID = user.ProfileID
-		users = append(users, user)
 	}
 
 	return users, nil
 }
 
 // GetUserByBusinessAndProfile mendapatkan user by business dan profile ID
-func (r *businessRepository) GetUserByBusinessAndProfile(businessID, profileID int64) (*entity.BusinessUser, error) {
+func (r *businessRepository) GetUserByBusinessAndProfile(ctx context.Context, businessID, profileID int64) (*entity.BusinessUser, error) {
 	query := `
 		SELECT bu_id, bu_b_id, bu_up_id, bu_role, bu_is_owner, bu_is_active, bu_created_at
 		FROM atamlink.business_users
 		WHERE bu_b_id = $1 AND bu_up_id = $2`
 
-	user := &entity.BusinessUser{}
-	err := r.db.QueryRow(query, businessID, profileID).Scan(
-		&user.ID,
-		&user.BusinessID,
-		&user.ProfileID,
-		&user.Role,
-		&user.IsOwner,
-		&user.IsActive,
-		&user.CreatedAt,
-	)
-
+	user, err := database.Get[entity.BusinessUser](ctx, r.db, query, businessID, profileID)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -416,13 +428,13 @@ func (r *businessRepository) GetUserByBusinessAndProfile(businessID, profileID i
 }
 
 // UpdateUserRole update user role
-func (r *businessRepository) UpdateUserRole(tx *sql.Tx, businessID, profileID int64, role string) error {
+func (r *businessRepository) UpdateUserRole(ctx context.Context, tx *sql.Tx, businessID, profileID int64, role string) error {
 	query := `
 		UPDATE atamlink.business_users 
 		SET bu_role = $3
 		WHERE bu_b_id = $1 AND bu_up_id = $2`
 
-	result, err := tx.Exec(query, businessID, profileID, role)
+	result, err := tx.ExecContext(ctx, query, businessID, profileID, role)
 	if err != nil {
 		return errors.Wrap(err, "failed to update user role")
 	}
@@ -440,13 +452,13 @@ func (r *businessRepository) UpdateUserRole(tx *sql.Tx, businessID, profileID in
 }
 
 // RemoveUser remove user dari business
-func (r *businessRepository) RemoveUser(tx *sql.Tx, businessID, profileID int64) error {
+func (r *businessRepository) RemoveUser(ctx context.Context, tx *sql.Tx, businessID, profileID int64) error {
 	query := `
 		UPDATE atamlink.business_users 
 		SET bu_is_active = false
 		WHERE bu_b_id = $1 AND bu_up_id = $2`
 
-	result, err := tx.Exec(query, businessID, profileID)
+	result, err := tx.ExecContext(ctx, query, businessID, profileID)
 	if err != nil {
 		return errors.Wrap(err, "failed to remove user")
 	}
@@ -464,21 +476,23 @@ func (r *businessRepository) RemoveUser(tx *sql.Tx, businessID, profileID int64)
 }
 
 // CreateInvite membuat invite baru
-func (r *businessRepository) CreateInvite(tx *sql.Tx, invite *entity.BusinessInvite) error {
+func (r *businessRepository) CreateInvite(ctx context.Context, tx *sql.Tx, invite *entity.BusinessInvite) error {
 	query := `
 		INSERT INTO atamlink.business_invites (
-			bi_b_id, bi_token, bi_role, bi_invited_by, 
-			bi_is_used, bi_expires_at, bi_created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+			bi_b_id, bi_token, bi_role, bi_invited_by, bi_email,
+			bi_is_used, bi_status, bi_expires_at, bi_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING bi_id`
 
-	err := tx.QueryRow(
+	err := tx.QueryRowContext(ctx,
 		query,
 		invite.BusinessID,
 		invite.Token,
 		invite.Role,
 		invite.InvitedBy,
+		invite.Email,
 		invite.IsUsed,
+		invite.Status,
 		invite.ExpiresAt,
 		invite.CreatedAt,
 	).Scan(&invite.ID)
@@ -490,29 +504,37 @@ func (r *businessRepository) CreateInvite(tx *sql.Tx, invite *entity.BusinessInv
 	return nil
 }
 
+const inviteColumns = `
+	bi_id, bi_b_id, bi_token, bi_role, bi_invited_by, bi_email,
+	bi_is_used, bi_status, bi_expires_at, bi_created_at,
+	bi_revoked_by, bi_revoked_at, bi_resent_at`
+
 // GetInviteByToken mendapatkan invite by token
-func (r *businessRepository) GetInviteByToken(token string) (*entity.BusinessInvite, error) {
-	query := `
-		SELECT 
-			bi_id, bi_b_id, bi_token, bi_role, bi_invited_by,
-			bi_is_used, bi_expires_at, bi_created_at
+func (r *businessRepository) GetInviteByToken(ctx context.Context, token string) (*entity.BusinessInvite, error) {
+	query := `SELECT ` + inviteColumns + `
 		FROM atamlink.business_invites
 		WHERE bi_token = $1`
 
-	invite := &entity.BusinessInvite{}
-	err := r.db.QueryRow(query, token).Scan(
-		&invite.ID,
-		&invite.BusinessID,
-		&invite.Token,
-		&invite.Role,
-		&invite.InvitedBy,
-		&invite.IsUsed,
-		&invite.ExpiresAt,
-		&invite.CreatedAt,
-	)
+	invite, err := database.Get[entity.BusinessInvite](ctx, r.db, query, token)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgInviteNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get invite")
+	}
+
+	return invite, nil
+}
 
+// GetInviteByID mendapatkan invite by ID
+func (r *businessRepository) GetInviteByID(ctx context.Context, id int64) (*entity.BusinessInvite, error) {
+	query := `SELECT ` + inviteColumns + `
+		FROM atamlink.business_invites
+		WHERE bi_id = $1`
+
+	invite, err := database.Get[entity.BusinessInvite](ctx, r.db, query, id)
 	if err == sql.ErrNoRows {
-		return nil, errors.New(errors.ErrNotFound, "Invite tidak ditemukan", 404)
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgInviteNotFound, 404)
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get invite")
@@ -521,14 +543,29 @@ func (r *businessRepository) GetInviteByToken(token string) (*entity.BusinessInv
 	return invite, nil
 }
 
+// ListInvites invite business terbaru dulu, status kosong = semua status
+func (r *businessRepository) ListInvites(ctx context.Context, businessID int64, status string) ([]*entity.BusinessInvite, error) {
+	query := `SELECT ` + inviteColumns + `
+		FROM atamlink.business_invites
+		WHERE bi_b_id = $1 AND ($2::text = '' OR bi_status = $2)
+		ORDER BY bi_created_at DESC, bi_id DESC`
+
+	invites, err := database.Select[entity.BusinessInvite](ctx, r.db, query, businessID, status)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list invites")
+	}
+
+	return invites, nil
+}
+
 // UseInvite mark invite as used
-func (r *businessRepository) UseInvite(tx *sql.Tx, token string) error {
+func (r *businessRepository) UseInvite(ctx context.Context, tx *sql.Tx, token string) error {
 	query := `
 		UPDATE atamlink.business_invites 
-		SET bi_is_used = true
-		WHERE bi_token = $1 AND bi_is_used = false`
+		SET bi_is_used = true, bi_status = 'used'
+		WHERE bi_token = $1 AND bi_is_used = false AND bi_status = 'pending'`
 
-	result, err := tx.Exec(query, token)
+	result, err := tx.ExecContext(ctx, query, token)
 	if err != nil {
 		return errors.Wrap(err, "failed to use invite")
 	}
@@ -545,8 +582,230 @@ func (r *businessRepository) UseInvite(tx *sql.Tx, token string) error {
 	return nil
 }
 
+// RevokeInvite batalkan invite yang masih pending, token langsung tidak berlaku
+func (r *businessRepository) RevokeInvite(ctx context.Context, tx *sql.Tx, id, profileID int64) error {
+	query := `
+		UPDATE atamlink.business_invites
+		SET bi_status = 'revoked', bi_revoked_by = $2, bi_revoked_at = $3
+		WHERE bi_id = $1 AND bi_is_used = false AND bi_status = 'pending'`
+
+	result, err := tx.ExecContext(ctx, query, id, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke invite")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrConflict, constant.ErrMsgInviteNotPending, 409)
+	}
+
+	return nil
+}
+
+// RenewInvite perpanjang invite pending atau yang sudah kadaluarsa, token tetap sama
+func (r *businessRepository) RenewInvite(ctx context.Context, tx *sql.Tx, id int64, expiresAt, resentAt time.Time) error {
+	query := `
+		UPDATE atamlink.business_invites
+		SET bi_status = 'pending', bi_expires_at = $2, bi_resent_at = $3
+		WHERE bi_id = $1 AND bi_is_used = false AND bi_status IN ('pending', 'expired')`
+
+	result, err := tx.ExecContext(ctx, query, id, expiresAt, resentAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to renew invite")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrConflict, constant.ErrMsgInviteNotPending, 409)
+	}
+
+	return nil
+}
+
+// ExpireStaleInvites tandai maksimal limit invite pending yang lewat masa berlaku
+// sebagai expired, kecuali invite milik excludeBusinessIDs. Return jumlah invite yang ditandai
+func (r *businessRepository) ExpireStaleInvites(ctx context.Context, now time.Time, excludeBusinessIDs []int64, limit int) (int64, error) {
+	query := `
+		UPDATE atamlink.business_invites
+		SET bi_status = 'expired'
+		WHERE bi_id IN (
+			SELECT bi_id FROM atamlink.business_invites
+			WHERE bi_status = 'pending' AND bi_expires_at <= $1
+				AND NOT (bi_b_id = ANY($2))
+			ORDER BY bi_expires_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.db.ExecContext(ctx, query, now, pq.Array(excludeBusinessIDs), limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to expire stale invites")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected, nil
+}
+
+// ExpireStaleBusinessInvites sama dengan ExpireStaleInvites untuk satu business,
+// dipakai untuk business yang waktunya digeser time-travel
+func (r *businessRepository) ExpireStaleBusinessInvites(ctx context.Context, businessID int64, now time.Time, limit int) (int64, error) {
+	query := `
+		UPDATE atamlink.business_invites
+		SET bi_status = 'expired'
+		WHERE bi_id IN (
+			SELECT bi_id FROM atamlink.business_invites
+			WHERE bi_b_id = $1 AND bi_status = 'pending' AND bi_expires_at <= $2
+			ORDER BY bi_expires_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)`
+
+	result, err := r.db.ExecContext(ctx, query, businessID, now, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to expire stale business invites")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected, nil
+}
+
+// CreateServiceAccount simpan service account baru
+func (r *businessRepository) CreateServiceAccount(ctx context.Context, tx *sql.Tx, account *entity.ServiceAccount) error {
+	query := `
+		INSERT INTO atamlink.business_service_accounts (
+			bsa_b_id, bsa_name, bsa_role, bsa_token_hash, bsa_token_prefix,
+			bsa_is_active, bsa_created_by, bsa_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING bsa_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		account.BusinessID,
+		account.Name,
+		account.Role,
+		account.TokenHash,
+		account.TokenPrefix,
+		account.IsActive,
+		account.CreatedBy,
+		account.CreatedAt,
+	).Scan(&account.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create service account")
+	}
+
+	return nil
+}
+
+// ListServiceAccounts service account business, yang aktif lebih dulu
+func (r *businessRepository) ListServiceAccounts(ctx context.Context, businessID int64) ([]*entity.ServiceAccount, error) {
+	query := `
+		SELECT
+			bsa_id, bsa_b_id, bsa_name, bsa_role, bsa_token_prefix, bsa_is_active,
+			bsa_last_used_at, bsa_created_by, bsa_created_at, bsa_revoked_by, bsa_revoked_at
+		FROM atamlink.business_service_accounts
+		WHERE bsa_b_id = $1
+		ORDER BY bsa_is_active DESC, bsa_created_at DESC`
+
+	accounts, err := database.Select[entity.ServiceAccount](ctx, r.db, query, businessID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list service accounts")
+	}
+
+	return accounts, nil
+}
+
+// GetServiceAccountByTokenHash service account pemilik token, nil jika tidak ada
+func (r *businessRepository) GetServiceAccountByTokenHash(ctx context.Context, tokenHash string) (*entity.ServiceAccount, error) {
+	query := `
+		SELECT
+			bsa_id, bsa_b_id, bsa_name, bsa_role, bsa_token_prefix, bsa_is_active,
+			bsa_last_used_at, bsa_created_by, bsa_created_at
+		FROM atamlink.business_service_accounts
+		WHERE bsa_token_hash = $1`
+
+	account, err := database.Get[entity.ServiceAccount](ctx, r.db, query, tokenHash)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get service account")
+	}
+
+	return account, nil
+}
+
+// IsServiceAccountNameExists check nama service account aktif di business
+func (r *businessRepository) IsServiceAccountNameExists(ctx context.Context, businessID int64, name string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM atamlink.business_service_accounts
+			WHERE bsa_b_id = $1 AND bsa_name = $2 AND bsa_is_active = true
+		)`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, businessID, name).Scan(&exists); err != nil {
+		return false, errors.Wrap(err, "failed to check service account name")
+	}
+
+	return exists, nil
+}
+
+// RevokeServiceAccount nonaktifkan service account, token langsung tidak berlaku
+func (r *businessRepository) RevokeServiceAccount(ctx context.Context, tx *sql.Tx, businessID, accountID, profileID int64) error {
+	query := `
+		UPDATE atamlink.business_service_accounts SET
+			bsa_is_active = false,
+			bsa_revoked_by = $3,
+			bsa_revoked_at = $4
+		WHERE bsa_id = $1 AND bsa_b_id = $2 AND bsa_is_active = true`
+
+	result, err := tx.ExecContext(ctx, query, accountID, businessID, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke service account")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgServiceAccountNotFound, 404)
+	}
+
+	return nil
+}
+
+// TouchServiceAccount catat waktu terakhir token dipakai
+func (r *businessRepository) TouchServiceAccount(ctx context.Context, id int64, usedAt time.Time) error {
+	query := `UPDATE atamlink.business_service_accounts SET bsa_last_used_at = $2 WHERE bsa_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, usedAt); err != nil {
+		return errors.Wrap(err, "failed to update service account usage")
+	}
+
+	return nil
+}
+
 // GetActiveSubscription mendapatkan active subscription
-func (r *businessRepository) GetActiveSubscription(businessID int64) (*entity.BusinessSubscription, error) {
+func (r *businessRepository) GetActiveSubscription(ctx context.Context, businessID int64, now time.Time) (*entity.BusinessSubscription, error) {
 	query := `
 		SELECT 
 			bs.bs_id, bs.bs_b_id, bs.bs_mp_id, bs.bs_status,
@@ -556,30 +815,11 @@ func (r *businessRepository) GetActiveSubscription(businessID int64) (*entity.Bu
 		INNER JOIN atamlink.master_plans mp ON mp.mp_id = bs.bs_mp_id
 		WHERE bs.bs_b_id = $1 
 			AND bs.bs_status = 'active' 
-			AND bs.bs_expires_at > NOW()
+			AND bs.bs_expires_at > $2
 		ORDER BY bs.bs_created_at DESC
 		LIMIT 1`
 
-	sub := &entity.BusinessSubscription{
-		Plan: &entity.MasterPlan{},
-	}
-
-	var featuresJSON []byte
-	err := r.db.QueryRow(query, businessID).Scan(
-		&sub.ID,
-		&sub.BusinessID,
-		&sub.PlanID,
-		&sub.Status,
-		&sub.StartsAt,
-		&sub.ExpiresAt,
-		&sub.CreatedAt,
-		&sub.UpdatedAt,
-		&sub.Plan.ID,
-		&sub.Plan.Name,
-		&sub.Plan.Price,
-		&featuresJSON,
-	)
-
+	sub, err := database.Get[entity.BusinessSubscription](ctx, r.db, query, businessID, now)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -587,16 +827,53 @@ func (r *businessRepository) GetActiveSubscription(businessID int64) (*entity.Bu
 		return nil, errors.Wrap(err, "failed to get active subscription")
 	}
 
-	// Parse JSON features
-	if err := json.Unmarshal(featuresJSON, &sub.Plan.Features); err != nil {
-		return nil, errors.Wrap(err, "failed to parse plan features")
+	return sub, nil
+}
+
+// ListExpiringSubscriptions mendapatkan subscription aktif yang berakhir dalam rentang waktu
+func (r *businessRepository) ListExpiringSubscriptions(ctx context.Context, from, to time.Time) ([]*entity.BusinessSubscription, error) {
+	query := `
+		SELECT 
+			bs.bs_id, bs.bs_b_id, bs.bs_mp_id, bs.bs_status,
+			bs.bs_starts_at, bs.bs_expires_at, bs.bs_created_at, bs.bs_updated_at,
+			mp.mp_id, mp.mp_name
+		FROM atamlink.business_subscriptions bs
+		INNER JOIN atamlink.master_plans mp ON mp.mp_id = bs.bs_mp_id
+		WHERE bs.bs_status = 'active'
+			AND bs.bs_expires_at > $1
+			AND bs.bs_expires_at <= $2
+		ORDER BY bs.bs_expires_at ASC`
+
+	subs, err := database.Select[entity.BusinessSubscription](ctx, r.db, query, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list expiring subscriptions")
 	}
 
-	return sub, nil
+	return subs, nil
+}
+
+// ListSubscriptions riwayat seluruh subscription business, terbaru dulu
+func (r *businessRepository) ListSubscriptions(ctx context.Context, businessID int64) ([]*entity.BusinessSubscription, error) {
+	query := `
+		SELECT 
+			bs.bs_id, bs.bs_b_id, bs.bs_mp_id, bs.bs_status,
+			bs.bs_starts_at, bs.bs_expires_at, bs.bs_created_at, bs.bs_updated_at,
+			mp.mp_id, mp.mp_name, mp.mp_price, mp.mp_duration
+		FROM atamlink.business_subscriptions bs
+		INNER JOIN atamlink.master_plans mp ON mp.mp_id = bs.bs_mp_id
+		WHERE bs.bs_b_id = $1
+		ORDER BY bs.bs_created_at DESC`
+
+	subs, err := database.Select[entity.BusinessSubscription](ctx, r.db, query, businessID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list subscriptions")
+	}
+
+	return subs, nil
 }
 
 // CreateSubscription create subscription
-func (r *businessRepository) CreateSubscription(tx *sql.Tx, subscription *entity.BusinessSubscription) error {
+func (r *businessRepository) CreateSubscription(ctx context.Context, tx *sql.Tx, subscription *entity.BusinessSubscription) error {
 	query := `
 		INSERT INTO atamlink.business_subscriptions (
 			bs_b_id, bs_mp_id, bs_status, bs_starts_at, 
@@ -604,7 +881,7 @@ func (r *businessRepository) CreateSubscription(tx *sql.Tx, subscription *entity
 		) VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING bs_id`
 
-	err := tx.QueryRow(
+	err := tx.QueryRowContext(ctx,
 		query,
 		subscription.BusinessID,
 		subscription.PlanID,
@@ -622,7 +899,7 @@ func (r *businessRepository) CreateSubscription(tx *sql.Tx, subscription *entity
 }
 
 // UpdateSubscription update subscription
-func (r *businessRepository) UpdateSubscription(tx *sql.Tx, subscription *entity.BusinessSubscription) error {
+func (r *businessRepository) UpdateSubscription(ctx context.Context, tx *sql.Tx, subscription *entity.BusinessSubscription) error {
 	query := `
 		UPDATE atamlink.business_subscriptions SET
 			bs_status = $2,
@@ -630,7 +907,7 @@ func (r *businessRepository) UpdateSubscription(tx *sql.Tx, subscription *entity
 			bs_updated_at = $4
 		WHERE bs_id = $1`
 
-	result, err := tx.Exec(
+	result, err := tx.ExecContext(ctx,
 		query,
 		subscription.ID,
 		subscription.Status,
@@ -655,11 +932,11 @@ func (r *businessRepository) UpdateSubscription(tx *sql.Tx, subscription *entity
 }
 
 // IsSlugExists check apakah slug sudah ada
-func (r *businessRepository) IsSlugExists(slug string) (bool, error) {
+func (r *businessRepository) IsSlugExists(ctx context.Context, slug string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM atamlink.businesses WHERE b_slug = $1)`
 
 	var exists bool
-	err := r.db.QueryRow(query, slug).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(&exists)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check slug exists")
 	}
@@ -667,15 +944,146 @@ func (r *businessRepository) IsSlugExists(slug string) (bool, error) {
 	return exists, nil
 }
 
+// GetOnboardingProgress hitung status langkah onboarding business
+func (r *businessRepository) GetOnboardingProgress(ctx context.Context, id int64) (*entity.OnboardingProgress, error) {
+	query := `
+		SELECT
+			b.b_id,
+			b.b_logo_url IS NOT NULL AND b.b_logo_url <> '' AS logo_uploaded,
+			EXISTS(SELECT 1 FROM atamlink.catalogs c WHERE c.c_b_id = b.b_id) AS catalog_created,
+			EXISTS(
+				SELECT 1 FROM atamlink.catalog_cards cc
+				INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+				INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+				WHERE c.c_b_id = b.b_id
+			) AS card_added,
+			EXISTS(SELECT 1 FROM atamlink.catalogs c WHERE c.c_b_id = b.b_id AND c.c_published_at IS NOT NULL) AS catalog_published,
+			EXISTS(
+				SELECT 1 FROM atamlink.catalog_daily_stats cds
+				INNER JOIN atamlink.catalogs c ON c.c_id = cds.cds_c_id
+				WHERE c.c_b_id = b.b_id AND cds.cds_views > 0
+			) AS link_visited
+		FROM atamlink.businesses b
+		WHERE b.b_id = $1`
+
+	progress, err := database.Get[entity.OnboardingProgress](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get onboarding progress")
+	}
+
+	return progress, nil
+}
+
+// GetIPAllowlist IP allowlist business
+func (r *businessRepository) GetIPAllowlist(ctx context.Context, businessID int64) (*entity.IPAllowlist, error) {
+	query := `
+		SELECT b_id, b_ip_allowlist, b_ip_allowlist_bypass_until
+		FROM atamlink.businesses
+		WHERE b_id = $1`
+
+	allowlist, err := database.Get[entity.IPAllowlist](ctx, r.db, query, businessID)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ip allowlist")
+	}
+
+	return allowlist, nil
+}
+
+// UpdateIPAllowlist ganti IP allowlist business, bypass break-glass ikut ditutup
+func (r *businessRepository) UpdateIPAllowlist(ctx context.Context, tx *sql.Tx, businessID int64, entries []string, profileID int64) error {
+	query := `
+		UPDATE atamlink.businesses SET
+			b_ip_allowlist = $2,
+			b_ip_allowlist_bypass_until = NULL,
+			b_updated_by = $3,
+			b_updated_at = $4
+		WHERE b_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, businessID, pq.Array(entries), profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update ip allowlist")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
+	}
+
+	return nil
+}
+
+// SetIPAllowlistBypass buka IP allowlist sementara sampai waktu tertentu
+func (r *businessRepository) SetIPAllowlistBypass(ctx context.Context, tx *sql.Tx, businessID int64, until time.Time) error {
+	query := `
+		UPDATE atamlink.businesses
+		SET b_ip_allowlist_bypass_until = $2
+		WHERE b_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, businessID, until)
+	if err != nil {
+		return errors.Wrap(err, "failed to set ip allowlist bypass")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
+	}
+
+	return nil
+}
+
+// IsCategoryActive check apakah kategori master ada dan aktif
+func (r *businessRepository) IsCategoryActive(ctx context.Context, categoryID int64) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM atamlink.master_categories WHERE mc_id = $1 AND mc_is_active = true)`
+
+	var active bool
+	err := r.db.QueryRowContext(ctx, query, categoryID).Scan(&active)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check category")
+	}
+
+	return active, nil
+}
+
+// CountActiveUsers jumlah user aktif business, termasuk owner
+func (r *businessRepository) CountActiveUsers(ctx context.Context, businessID int64) (int, error) {
+	query := `
+		SELECT COUNT(*) 
+		FROM atamlink.business_users 
+		WHERE bu_b_id = $1 AND bu_is_active = true`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, businessID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count business users")
+	}
+
+	return count, nil
+}
+
 // CountUserBusinesses count business yang dimiliki user
-func (r *businessRepository) CountUserBusinesses(profileID int64) (int, error) {
+func (r *businessRepository) CountUserBusinesses(ctx context.Context, profileID int64) (int, error) {
 	query := `
 		SELECT COUNT(*) 
 		FROM atamlink.business_users 
 		WHERE bu_up_id = $1 AND bu_is_active = true`
 
 	var count int
-	err := r.db.QueryRow(query, profileID).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, profileID).Scan(&count)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to count user businesses")
 	}
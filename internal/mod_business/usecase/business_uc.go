@@ -1,12 +1,18 @@
 package usecase
 
 import (
+	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/hex"
 	"fmt"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/atam/atamlink/internal/config"
 	"github.com/atam/atamlink/internal/constant"
 	"github.com/atam/atamlink/internal/middleware"
 	"github.com/atam/atamlink/internal/mod_business/dto"
@@ -14,27 +20,50 @@ import (
 	"github.com/atam/atamlink/internal/mod_business/repository"
 	userRepo "github.com/atam/atamlink/internal/mod_user/repository"
 	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
 	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
 	"github.com/google/uuid"
 )
 
 // BusinessUseCase interface untuk business use case
 type BusinessUseCase interface {
 	Create(ctx *gin.Context, profileID int64, req *dto.CreateBusinessRequest) (*dto.BusinessResponse, error)
-	GetByID(id int64, profileID int64) (*dto.BusinessResponse, error)
-	GetBySlug(slug string) (*dto.BusinessResponse, error)
-	List(profileID int64, filter *dto.BusinessFilter, page, perPage int, orderBy string) ([]*dto.BusinessListResponse, int64, error)
+	GetByID(ctx context.Context, id int64, profileID int64) (*dto.BusinessResponse, error)
+	GetBySlug(ctx context.Context, slug string) (*dto.BusinessResponse, error)
+	List(ctx context.Context, profileID int64, filter *dto.BusinessFilter, page, perPage int, orderBy string) ([]*dto.BusinessListResponse, int64, error)
+	ListByCursor(ctx context.Context, profileID int64, filter *dto.BusinessFilter, keyset *database.Keyset, limit int) ([]*dto.BusinessListResponse, string, error)
 	Update(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateBusinessRequest) (*dto.BusinessResponse, error)
 	Delete(ctx *gin.Context, id int64, profileID int64) error
+	UpdateMediaReplication(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateMediaReplicationRequest) (*dto.BusinessResponse, error)
+
+	// Brand
+	GetBrand(ctx *gin.Context, id int64, profileID int64) (*dto.BrandResponse, error)
+	UpdateBrand(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateBrandRequest) (*dto.BrandResponse, error)
+	GetOnboarding(ctx *gin.Context, id int64, profileID int64) (*dto.OnboardingResponse, error)
 
 	// User management
-	AddUser(businessID int64, profileID int64, req *dto.AddUserRequest) error
-	UpdateUserRole(businessID int64, profileID int64, targetProfileID int64, role string) error
-	RemoveUser(businessID int64, profileID int64, targetProfileID int64) error
+	AddUser(ctx *gin.Context, businessID int64, profileID int64, req *dto.AddUserRequest) error
+	UpdateUserRole(ctx *gin.Context, businessID int64, profileID int64, targetProfileID int64, role string) error
+	RemoveUser(ctx *gin.Context, businessID int64, profileID int64, targetProfileID int64) error
 
 	// Invite management
-	CreateInvite(businessID int64, profileID int64, req *dto.CreateInviteRequest) (*dto.InviteResponse, error)
-	AcceptInvite(req *dto.AcceptInviteRequest) error
+	CreateInvite(ctx *gin.Context, businessID int64, profileID int64, req *dto.CreateInviteRequest) (*dto.InviteResponse, error)
+	AcceptInvite(ctx context.Context, req *dto.AcceptInviteRequest) error
+	ListInvites(ctx *gin.Context, businessID int64, profileID int64, status string) ([]*dto.InviteResponse, error)
+	ResendInvite(ctx *gin.Context, inviteID int64, profileID int64) (*dto.InviteResponse, error)
+	RevokeInvite(ctx *gin.Context, inviteID int64, profileID int64) error
+	ExpireStaleInvites(ctx context.Context, batchSize int) error
+
+	// Service account
+	CreateServiceAccount(ctx *gin.Context, businessID int64, profileID int64, req *dto.CreateServiceAccountRequest) (*dto.ServiceAccountCreatedResponse, error)
+	ListServiceAccounts(ctx *gin.Context, businessID int64, profileID int64) ([]*dto.ServiceAccountResponse, error)
+	RevokeServiceAccount(ctx *gin.Context, businessID int64, accountID int64, profileID int64) error
+
+	// IP allowlist
+	GetIPAllowlist(ctx *gin.Context, businessID int64, profileID int64) (*dto.IPAllowlistResponse, error)
+	UpdateIPAllowlist(ctx *gin.Context, businessID int64, profileID int64, req *dto.UpdateIPAllowlistRequest) (*dto.IPAllowlistResponse, error)
+	BreakGlassIPAllowlist(ctx *gin.Context, businessID int64, profileID int64) (*dto.IPAllowlistResponse, error)
 }
 
 type businessUseCase struct {
@@ -43,6 +72,11 @@ type businessUseCase struct {
 	userRepo     userRepo.UserRepository
 	slugService  service.SlugService
 	uploadService service.UploadService
+	ipAllowlistConfig config.IPAllowlistConfig
+	clock        service.Clock
+	planEnforcement service.PlanEnforcementService
+	mailService  service.MailService
+	inviteConfig config.InviteConfig
 }
 
 // NewBusinessUseCase membuat instance business use case baru
@@ -52,6 +86,11 @@ func NewBusinessUseCase(
 	userRepo userRepo.UserRepository,
 	slugService service.SlugService,
 	uploadService service.UploadService,
+	ipAllowlistConfig config.IPAllowlistConfig,
+	clock service.Clock,
+	planEnforcement service.PlanEnforcementService,
+	mailService service.MailService,
+	inviteConfig config.InviteConfig,
 ) BusinessUseCase {
 	return &businessUseCase{
 		db:           db,
@@ -59,6 +98,11 @@ func NewBusinessUseCase(
 		userRepo:     userRepo,
 		slugService:  slugService,
 		uploadService: uploadService,
+		ipAllowlistConfig: ipAllowlistConfig,
+		clock:        clock,
+		planEnforcement: planEnforcement,
+		mailService:  mailService,
+		inviteConfig: inviteConfig,
 	}
 }
 
@@ -68,6 +112,11 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 	if !constant.IsValidBusinessType(req.Type) {
 		return nil, errors.New(errors.ErrValidation, constant.ErrMsgBusinessTypeInvalid, 400)
 	}
+	if req.CategoryID > 0 {
+		if err := uc.checkCategory(utils.ContextFrom(ctx), req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Generate atau validate slug
 	var slug string
@@ -77,7 +126,7 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 		}
 
 		// Check if slug exists
-		exists, err := uc.businessRepo.IsSlugExists(req.Slug)
+		exists, err := uc.businessRepo.IsSlugExists(utils.ContextFrom(ctx), req.Slug)
 		if err != nil {
 			return nil, err
 		}
@@ -91,7 +140,7 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 			req.Name,
 			uc.slugService,
 			func(s string) (bool, error) {
-				return uc.businessRepo.IsSlugExists(s)
+				return uc.businessRepo.IsSlugExists(utils.ContextFrom(ctx), s)
 			},
 			5,
 		)
@@ -102,17 +151,20 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 	}
 
 	// Start transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
 	// Create business
+	city := strings.TrimSpace(req.City)
 	business := &entity.Business{
 		Slug:      slug,
 		Name:      req.Name,
 		Type:      req.Type,
+		City:      sql.NullString{String: city, Valid: city != ""},
+		CategoryID: sql.NullInt64{Int64: req.CategoryID, Valid: req.CategoryID > 0},
 		IsActive:  true,
 		CreatedBy: profileID,
 		CreatedAt: time.Now(),
@@ -122,7 +174,7 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 	var uploadedLogoURL string
 	if req.LogoFile != nil {
 		// Upload logo ke Cloudinary sebagai thumbnail
-		logoURL, err := uc.uploadService.UploadImageToCloudinary(req.LogoFile, "thumbnail")
+		logoURL, err := uc.uploadService.UploadImageToCloudinary(middleware.RequestContext(ctx), req.LogoFile, "thumbnail")
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to upload logo")
 		}
@@ -134,14 +186,14 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 	}
 
 	// Create business
-	if err := uc.businessRepo.Create(tx, business); err != nil {
+	if err := uc.businessRepo.Create(utils.ContextFrom(ctx), tx, business); err != nil {
 		// Jika create gagal dan logo sudah diupload, hapus dari Cloudinary
 		if uploadedLogoURL != "" {
 			// Extract public ID dari URL untuk delete
 			// Note: Dalam implementasi real, kita perlu extract public ID dari URL
 			go func() {
 				// Best effort delete, tidak perlu handle error
-				_ = uc.uploadService.DeleteFromCloudinary(uploadedLogoURL)
+				_ = uc.uploadService.DeleteFromCloudinary(middleware.RequestContext(ctx), uploadedLogoURL)
 			}()
 		}
 		return nil, err
@@ -157,11 +209,11 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 		CreatedAt:  time.Now(),
 	}
 
-	if err := uc.businessRepo.AddUser(tx, businessUser); err != nil {
+	if err := uc.businessRepo.AddUser(utils.ContextFrom(ctx), tx, businessUser); err != nil {
 		// Rollback upload jika add user gagal
 		if uploadedLogoURL != "" {
 			go func() {
-				_ = uc.uploadService.DeleteFromCloudinary(uploadedLogoURL)
+				_ = uc.uploadService.DeleteFromCloudinary(middleware.RequestContext(ctx), uploadedLogoURL)
 			}()
 		}
 		return nil, err
@@ -172,43 +224,48 @@ func (uc *businessUseCase) Create(ctx *gin.Context, profileID int64, req *dto.Cr
 		// Rollback upload jika commit gagal
 		if uploadedLogoURL != "" {
 			go func() {
-				_ = uc.uploadService.DeleteFromCloudinary(uploadedLogoURL)
+				_ = uc.uploadService.DeleteFromCloudinary(middleware.RequestContext(ctx), uploadedLogoURL)
 			}()
 		}
 		return nil, errors.Wrap(err, "failed to commit transaction")
 	}
 
 	// Get complete business data
-	return uc.GetByID(business.ID, profileID)
+	return uc.GetByID(utils.ContextFrom(ctx), business.ID, profileID)
 }
 
 // GetByID mendapatkan business by ID
-func (uc *businessUseCase) GetByID(id int64, profileID int64) (*dto.BusinessResponse, error) {
+func (uc *businessUseCase) GetByID(ctx context.Context, id int64, profileID int64) (*dto.BusinessResponse, error) {
 	// Get business
-	business, err := uc.businessRepo.GetByID(id)
+	business, err := uc.businessRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check access jika profileID > 0
-	if profileID > 0 {
-		user, err := uc.businessRepo.GetUserByBusinessAndProfile(id, profileID)
+	// Service account hanya boleh membaca business pemiliknya, selain itu
+	// profile harus member aktif (profile 0 bukan berarti tanpa batasan)
+	if scopeID, ok := service.ServiceAccountScope(ctx); ok {
+		if scopeID != id {
+			return nil, errors.New(errors.ErrNotMember, constant.ErrMsgBusinessAccessDenied, 403)
+		}
+	} else {
+		user, err := uc.businessRepo.GetUserByBusinessAndProfile(ctx, id, profileID)
 		if err != nil {
 			return nil, err
 		}
 		if user == nil || !user.IsActive {
-			return nil, errors.New(errors.ErrForbidden, constant.ErrMsgBusinessAccessDenied, 403)
+			return nil, errors.New(errors.ErrNotMember, constant.ErrMsgBusinessAccessDenied, 403)
 		}
 	}
 
 	// Get users
-	users, err := uc.businessRepo.GetUsersByBusinessID(id)
+	users, err := uc.businessRepo.GetUsersByBusinessID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get active subscription
-	subscription, err := uc.businessRepo.GetActiveSubscription(id)
+	subscription, err := uc.businessRepo.GetActiveSubscription(ctx, id, uc.clock.NowFor(id))
 	if err != nil {
 		return nil, err
 	}
@@ -218,8 +275,8 @@ func (uc *businessUseCase) GetByID(id int64, profileID int64) (*dto.BusinessResp
 }
 
 // GetBySlug mendapatkan business by slug
-func (uc *businessUseCase) GetBySlug(slug string) (*dto.BusinessResponse, error) {
-	business, err := uc.businessRepo.GetBySlug(slug)
+func (uc *businessUseCase) GetBySlug(ctx context.Context, slug string) (*dto.BusinessResponse, error) {
+	business, err := uc.businessRepo.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, err
 	}
@@ -229,56 +286,124 @@ func (uc *businessUseCase) GetBySlug(slug string) (*dto.BusinessResponse, error)
 }
 
 // List mendapatkan list businesses
-func (uc *businessUseCase) List(profileID int64, filter *dto.BusinessFilter, page, perPage int, orderBy string) ([]*dto.BusinessListResponse, int64, error) {
+func (uc *businessUseCase) List(ctx context.Context, profileID int64, filter *dto.BusinessFilter, page, perPage int, orderBy string) ([]*dto.BusinessListResponse, int64, error) {
 	// Build filter
-	repoFilter := repository.ListFilter{
-		Limit:   perPage,
-		Offset:  (page - 1) * perPage,
-		OrderBy: orderBy,
+	repoFilter, err := uc.listFilter(ctx, profileID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	repoFilter.Limit = perPage
+	repoFilter.Offset = (page - 1) * perPage
+	repoFilter.OrderBy = orderBy
+
+	// Get businesses
+	businesses, total, err := uc.businessRepo.List(ctx, repoFilter)
+	if err != nil {
+		return nil, 0, err
 	}
 
+	// Convert to response
+	responses := make([]*dto.BusinessListResponse, len(businesses))
+	for i, business := range businesses {
+		responses[i] = toBusinessListResponse(business)
+	}
+
+	return responses, total, nil
+}
+
+// ListByCursor list business dengan cursor pagination, next cursor kosong berarti halaman terakhir
+func (uc *businessUseCase) ListByCursor(ctx context.Context, profileID int64, filter *dto.BusinessFilter, keyset *database.Keyset, limit int) ([]*dto.BusinessListResponse, string, error) {
+	repoFilter, err := uc.listFilter(ctx, profileID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	// Ambil satu baris lebih untuk tahu masih ada halaman berikutnya
+	repoFilter.Limit = limit + 1
+	repoFilter.Keyset = keyset
+
+	businesses, _, err := uc.businessRepo.List(ctx, repoFilter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(businesses) > limit {
+		businesses = businesses[:limit]
+		last := businesses[limit-1]
+		nextCursor = utils.EncodeCursor(keyset, businessCursorValue(last, keyset.Sort), last.ID)
+	}
+
+	responses := make([]*dto.BusinessListResponse, len(businesses))
+	for i, business := range businesses {
+		responses[i] = toBusinessListResponse(business)
+	}
+
+	return responses, nextCursor, nil
+}
+
+// listFilter filter repository untuk list business, default dibatasi ke business milik user.
+// Service account hanya melihat business pemiliknya
+func (uc *businessUseCase) listFilter(ctx context.Context, profileID int64, filter *dto.BusinessFilter) (repository.ListFilter, error) {
+	repoFilter := repository.ListFilter{}
+
 	if filter != nil {
 		repoFilter.Search = filter.Search
 		repoFilter.Type = filter.Type
 		repoFilter.IsActive = filter.IsActive
 		repoFilter.IsSuspended = filter.IsSuspended
-		if filter.ProfileID > 0 {
-			repoFilter.ProfileID = filter.ProfileID
-		} else if profileID > 0 {
-			repoFilter.ProfileID = profileID
-		}
-	} else if profileID > 0 {
-		repoFilter.ProfileID = profileID
 	}
 
-	// Get businesses
-	businesses, total, err := uc.businessRepo.List(repoFilter)
-	if err != nil {
-		return nil, 0, err
+	if scopeID, ok := service.ServiceAccountScope(ctx); ok {
+		repoFilter.BusinessID = scopeID
+		return repoFilter, nil
 	}
 
-	// Convert to response
-	responses := make([]*dto.BusinessListResponse, len(businesses))
-	for i, business := range businesses {
-		responses[i] = &dto.BusinessListResponse{
-			ID:          business.ID,
-			Slug:        business.Slug,
-			Name:        business.Name,
-			Type:        business.Type,
-			IsActive:    business.IsActive,
-			IsSuspended: business.IsSuspended,
-			CreatedAt:   business.CreatedAt,
-			UpdatedAt:   business.UpdatedAt,
+	repoFilter.ProfileID = profileID
+	if filter != nil && filter.ProfileID > 0 {
+		repoFilter.ProfileID = filter.ProfileID
+	}
+	if repoFilter.ProfileID <= 0 {
+		return repository.ListFilter{}, errors.New(errors.ErrUnauthorized, constant.ErrMsgUnauthorized, 401)
+	}
+
+	return repoFilter, nil
+}
+
+// businessCursorValue nilai kolom urutan business untuk cursor berikutnya,
+// harus sama dengan kolom sort cursor di handler
+func businessCursorValue(business *entity.Business, sort string) string {
+	switch sort {
+	case "updated_at":
+		if business.UpdatedAt != nil {
+			return business.UpdatedAt.Format(time.RFC3339Nano)
 		}
+		return business.CreatedAt.Format(time.RFC3339Nano)
+	case "name":
+		return business.Name
+	case "type":
+		return business.Type
+	default:
+		return business.CreatedAt.Format(time.RFC3339Nano)
 	}
+}
 
-	return responses, total, nil
+func toBusinessListResponse(business *entity.Business) *dto.BusinessListResponse {
+	return &dto.BusinessListResponse{
+		ID:          business.ID,
+		Slug:        business.Slug,
+		Name:        business.Name,
+		Type:        business.Type,
+		IsActive:    business.IsActive,
+		IsSuspended: business.IsSuspended,
+		CreatedAt:   business.CreatedAt,
+		UpdatedAt:   business.UpdatedAt,
+	}
 }
 
 // GetByID mendapatkan business by ID
 func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateBusinessRequest) (*dto.BusinessResponse, error) {
 	// Get existing business
-	business, err := uc.businessRepo.GetByID(id)
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +414,7 @@ func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, r
 	}
 
 	// Check permission
-	if err := uc.checkBusinessPermission(id, profileID, constant.PermBusinessUpdate); err != nil {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, id, profileID, constant.PermBusinessUpdate); err != nil {
 		return nil, err
 	}
 
@@ -297,9 +422,14 @@ func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, r
 	if req.Type != "" && !constant.IsValidBusinessType(req.Type) {
 		return nil, errors.New(errors.ErrValidation, constant.ErrMsgBusinessTypeInvalid, 400)
 	}
+	if req.CategoryID != nil && *req.CategoryID > 0 {
+		if err := uc.checkCategory(utils.ContextFrom(ctx), *req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Start transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -318,6 +448,13 @@ func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, r
 	if req.Type != "" {
 		business.Type = req.Type
 	}
+	if req.City != nil {
+		city := strings.TrimSpace(*req.City)
+		business.City = sql.NullString{String: city, Valid: city != ""}
+	}
+	if req.CategoryID != nil {
+		business.CategoryID = sql.NullInt64{Int64: *req.CategoryID, Valid: *req.CategoryID > 0}
+	}
 	if req.IsActive != nil {
 		business.IsActive = *req.IsActive
 	}
@@ -326,7 +463,7 @@ func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, r
 	var uploadedLogoURL string
 	if req.LogoFile != nil {
 		// Upload logo baru ke Cloudinary
-		logoURL, err := uc.uploadService.UploadImageToCloudinary(req.LogoFile, "thumbnail")
+		logoURL, err := uc.uploadService.UploadImageToCloudinary(middleware.RequestContext(ctx), req.LogoFile, "thumbnail")
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to upload logo")
 		}
@@ -346,11 +483,11 @@ func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, r
 	*business.UpdatedAt = time.Now()
 
 	// Execute update
-	if err := uc.businessRepo.Update(tx, business); err != nil {
+	if err := uc.businessRepo.Update(utils.ContextFrom(ctx), tx, business); err != nil {
 		// Rollback upload jika update gagal
 		if uploadedLogoURL != "" {
 			go func() {
-				_ = uc.uploadService.DeleteFromCloudinary(uploadedLogoURL)
+				_ = uc.uploadService.DeleteFromCloudinary(middleware.RequestContext(ctx), uploadedLogoURL)
 			}()
 		}
 		return nil, err
@@ -361,7 +498,7 @@ func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, r
 		// Rollback upload jika commit gagal
 		if uploadedLogoURL != "" {
 			go func() {
-				_ = uc.uploadService.DeleteFromCloudinary(uploadedLogoURL)
+				_ = uc.uploadService.DeleteFromCloudinary(middleware.RequestContext(ctx), uploadedLogoURL)
 			}()
 		}
 		return nil, errors.Wrap(err, "failed to commit transaction")
@@ -371,18 +508,18 @@ func (uc *businessUseCase) Update(ctx *gin.Context, id int64, profileID int64, r
 	if oldLogoURL != "" && uploadedLogoURL != "" {
 		go func() {
 			// Best effort delete old logo
-			_ = uc.uploadService.DeleteFromCloudinary(oldLogoURL)
+			_ = uc.uploadService.DeleteFromCloudinary(middleware.RequestContext(ctx), oldLogoURL)
 		}()
 	}
 
 	// Get updated business
-	return uc.GetByID(id, profileID)
+	return uc.GetByID(utils.ContextFrom(ctx), id, profileID)
 }
 
 // Delete soft delete business
 func (uc *businessUseCase) Delete(ctx *gin.Context, id int64, profileID int64) error {
 	// Get existing business
-	business, err := uc.businessRepo.GetByID(id)
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -393,18 +530,18 @@ func (uc *businessUseCase) Delete(ctx *gin.Context, id int64, profileID int64) e
 	}
 	
 	// Check permission
-	if err := uc.checkBusinessPermission(id, profileID, constant.PermBusinessDelete); err != nil {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, id, profileID, constant.PermBusinessDelete); err != nil {
 		return err
 	}
 
 	// Delete in transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.businessRepo.Delete(tx, id); err != nil {
+	if err := uc.businessRepo.Delete(utils.ContextFrom(ctx), tx, id); err != nil {
 		return err
 	}
 
@@ -415,10 +552,116 @@ func (uc *businessUseCase) Delete(ctx *gin.Context, id int64, profileID int64) e
 	return nil
 }
 
+// UpdateMediaReplication aktifkan replikasi media ke region kedua untuk pengunjung luar negeri
+func (uc *businessUseCase) UpdateMediaReplication(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateMediaReplicationRequest) (*dto.BusinessResponse, error) {
+	// Get existing business
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, id, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, business)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.businessRepo.SetMediaReplication(utils.ContextFrom(ctx), tx, id, *req.Enabled, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return uc.GetByID(utils.ContextFrom(ctx), id, profileID)
+}
+
+// GetBrand mendapatkan brand business
+func (uc *businessUseCase) GetBrand(ctx *gin.Context, id int64, profileID int64) (*dto.BrandResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, id, profileID, constant.PermBusinessView); err != nil {
+		return nil, err
+	}
+
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toBrandResponse(business), nil
+}
+
+// GetOnboarding progres checklist onboarding business
+func (uc *businessUseCase) GetOnboarding(ctx *gin.Context, id int64, profileID int64) (*dto.OnboardingResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, id, profileID, constant.PermBusinessView); err != nil {
+		return nil, err
+	}
+
+	progress, err := uc.businessRepo.GetOnboardingProgress(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOnboardingResponse(progress), nil
+}
+
+// UpdateBrand ganti brand business. Katalog yang sudah ada tidak ikut berubah
+// sampai brand diterapkan lewat apply brand.
+func (uc *businessUseCase) UpdateBrand(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateBrandRequest) (*dto.BrandResponse, error) {
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, id, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, business.Brand)
+	}
+
+	brand := &entity.BrandSettings{
+		PrimaryColor:   req.PrimaryColor,
+		SecondaryColor: req.SecondaryColor,
+		FontFamily:     req.FontFamily,
+		HeadingFont:    req.HeadingFont,
+		ShowLogo:       req.ShowLogo,
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.businessRepo.UpdateBrand(utils.ContextFrom(ctx), tx, id, brand, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	business.Brand = *brand
+	return toBrandResponse(business), nil
+}
+
 // AddUser menambahkan user ke business
-func (uc *businessUseCase) AddUser(businessID int64, profileID int64, req *dto.AddUserRequest) error {
+func (uc *businessUseCase) AddUser(ctx *gin.Context, businessID int64, profileID int64, req *dto.AddUserRequest) error {
 	// Check permission
-	if err := uc.checkBusinessPermission(businessID, profileID, constant.PermUserInvite); err != nil {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserInvite); err != nil {
 		return err
 	}
 
@@ -437,24 +680,30 @@ func (uc *businessUseCase) AddUser(businessID int64, profileID int64, req *dto.A
 	}
 
 	// Check if already member
-	existingUser, err := uc.businessRepo.GetUserByBusinessAndProfile(businessID, req.ProfileID)
+	existingUser, err := uc.businessRepo.GetUserByBusinessAndProfile(utils.ContextFrom(ctx), businessID, req.ProfileID)
 	if err != nil {
 		return err
 	}
+	if existingUser != nil && existingUser.IsActive {
+		return errors.New(errors.ErrConflict, "User sudah menjadi member", 409)
+	}
+
+	// Cek batas user sesuai plan, termasuk reaktivasi member lama
+	if err := uc.planEnforcement.CheckUserLimit(utils.ContextFrom(ctx), businessID); err != nil {
+		return err
+	}
+
 	if existingUser != nil {
-		if existingUser.IsActive {
-			return errors.New(errors.ErrConflict, "User sudah menjadi member", 409)
-		}
 		// Reactivate user
 		existingUser.IsActive = true
 		existingUser.Role = req.Role
-		tx, err := uc.db.Begin()
+		tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 		if err != nil {
 			return errors.Wrap(err, "failed to begin transaction")
 		}
 		defer tx.Rollback()
 
-		if err := uc.businessRepo.UpdateUserRole(tx, businessID, req.ProfileID, req.Role); err != nil {
+		if err := uc.businessRepo.UpdateUserRole(utils.ContextFrom(ctx), tx, businessID, req.ProfileID, req.Role); err != nil {
 			return err
 		}
 
@@ -462,7 +711,7 @@ func (uc *businessUseCase) AddUser(businessID int64, profileID int64, req *dto.A
 	}
 
 	// Add user
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
@@ -477,7 +726,7 @@ func (uc *businessUseCase) AddUser(businessID int64, profileID int64, req *dto.A
 		CreatedAt:  time.Now(),
 	}
 
-	if err := uc.businessRepo.AddUser(tx, businessUser); err != nil {
+	if err := uc.businessRepo.AddUser(utils.ContextFrom(ctx), tx, businessUser); err != nil {
 		return err
 	}
 
@@ -485,9 +734,9 @@ func (uc *businessUseCase) AddUser(businessID int64, profileID int64, req *dto.A
 }
 
 // UpdateUserRole update role user
-func (uc *businessUseCase) UpdateUserRole(businessID int64, profileID int64, targetProfileID int64, role string) error {
+func (uc *businessUseCase) UpdateUserRole(ctx *gin.Context, businessID int64, profileID int64, targetProfileID int64, role string) error {
 	// Check permission
-	if err := uc.checkBusinessPermission(businessID, profileID, constant.PermUserUpdate); err != nil {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserUpdate); err != nil {
 		return err
 	}
 
@@ -502,7 +751,7 @@ func (uc *businessUseCase) UpdateUserRole(businessID int64, profileID int64, tar
 	}
 
 	// Check target user exists
-	targetUser, err := uc.businessRepo.GetUserByBusinessAndProfile(businessID, targetProfileID)
+	targetUser, err := uc.businessRepo.GetUserByBusinessAndProfile(utils.ContextFrom(ctx), businessID, targetProfileID)
 	if err != nil {
 		return err
 	}
@@ -511,13 +760,13 @@ func (uc *businessUseCase) UpdateUserRole(businessID int64, profileID int64, tar
 	}
 
 	// Update role
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.businessRepo.UpdateUserRole(tx, businessID, targetProfileID, role); err != nil {
+	if err := uc.businessRepo.UpdateUserRole(utils.ContextFrom(ctx), tx, businessID, targetProfileID, role); err != nil {
 		return err
 	}
 
@@ -525,9 +774,9 @@ func (uc *businessUseCase) UpdateUserRole(businessID int64, profileID int64, tar
 }
 
 // RemoveUser hapus user dari business
-func (uc *businessUseCase) RemoveUser(businessID int64, profileID int64, targetProfileID int64) error {
+func (uc *businessUseCase) RemoveUser(ctx *gin.Context, businessID int64, profileID int64, targetProfileID int64) error {
 	// Check permission
-	if err := uc.checkBusinessPermission(businessID, profileID, constant.PermUserRemove); err != nil {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserRemove); err != nil {
 		return err
 	}
 
@@ -537,7 +786,7 @@ func (uc *businessUseCase) RemoveUser(businessID int64, profileID int64, targetP
 	}
 
 	// Check if target is the only owner
-	users, err := uc.businessRepo.GetUsersByBusinessID(businessID)
+	users, err := uc.businessRepo.GetUsersByBusinessID(utils.ContextFrom(ctx), businessID)
 	if err != nil {
 		return err
 	}
@@ -562,23 +811,26 @@ func (uc *businessUseCase) RemoveUser(businessID int64, profileID int64, targetP
 	}
 
 	// Remove user
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.businessRepo.RemoveUser(tx, businessID, targetProfileID); err != nil {
+	if err := uc.businessRepo.RemoveUser(utils.ContextFrom(ctx), tx, businessID, targetProfileID); err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
+// inviteValidity masa berlaku default invite baru dan invite yang dikirim ulang
+const inviteValidity = 7 * 24 * time.Hour
+
 // CreateInvite membuat invite link
-func (uc *businessUseCase) CreateInvite(businessID int64, profileID int64, req *dto.CreateInviteRequest) (*dto.InviteResponse, error) {
+func (uc *businessUseCase) CreateInvite(ctx *gin.Context, businessID int64, profileID int64, req *dto.CreateInviteRequest) (*dto.InviteResponse, error) {
 	// Check permission
-	if err := uc.checkBusinessPermission(businessID, profileID, constant.PermUserInvite); err != nil {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserInvite); err != nil {
 		return nil, err
 	}
 
@@ -592,17 +844,22 @@ func (uc *businessUseCase) CreateInvite(businessID int64, profileID int64, req *
 		return nil, errors.New(errors.ErrValidation, "Owner harus ditambahkan langsung", 400)
 	}
 
+	email := strings.TrimSpace(req.Email)
+	if email != "" && !uc.mailService.IsConfigured() {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgMailNotConfigured, 503)
+	}
+
 	// Set expiry
 	expiresAt := req.ExpiresAt
 	if expiresAt.IsZero() {
-		expiresAt = time.Now().Add(7 * 24 * time.Hour) // 7 days
+		expiresAt = uc.clock.NowFor(businessID).Add(inviteValidity)
 	}
 
 	// Generate token
 	token := uuid.New().String()
 
 	// Create invite
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -613,45 +870,46 @@ func (uc *businessUseCase) CreateInvite(businessID int64, profileID int64, req *
 		Token:      token,
 		Role:       req.Role,
 		InvitedBy:  profileID,
+		Email:      sql.NullString{String: email, Valid: email != ""},
+		Status:     constant.InviteStatusPending,
 		ExpiresAt:  expiresAt,
 		CreatedAt:  time.Now(),
 	}
 
-	if err := uc.businessRepo.CreateInvite(tx, invite); err != nil {
+	if err := uc.businessRepo.CreateInvite(utils.ContextFrom(ctx), tx, invite); err != nil {
 		return nil, err
 	}
 
+	// Email dikirim sebelum commit, invite batal jika pengiriman gagal
+	if invite.Email.Valid {
+		if err := uc.sendInviteEmail(utils.ContextFrom(ctx), invite); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, errors.Wrap(err, "failed to commit transaction")
 	}
 
 	// Return response
-	return &dto.InviteResponse{
-		ID:        invite.ID,
-		Token:     invite.Token,
-		Role:      invite.Role,
-		InvitedBy: invite.InvitedBy,
-		ExpiresAt: invite.ExpiresAt,
-		CreatedAt: invite.CreatedAt,
-		InviteURL: fmt.Sprintf("/invite/%s", invite.Token), // TODO: Use proper base URL
-	}, nil
+	return uc.toInviteResponse(invite), nil
 }
 
 // AcceptInvite accept invite
-func (uc *businessUseCase) AcceptInvite(req *dto.AcceptInviteRequest) error {
+func (uc *businessUseCase) AcceptInvite(ctx context.Context, req *dto.AcceptInviteRequest) error {
 	// Get invite
-	invite, err := uc.businessRepo.GetInviteByToken(req.Token)
+	invite, err := uc.businessRepo.GetInviteByToken(ctx, req.Token)
 	if err != nil {
 		return err
 	}
 
 	// Validate invite
-	if !invite.IsValid() {
-		return errors.New(errors.ErrValidation, "Invite tidak valid atau sudah kadaluarsa", 400)
+	if !invite.IsValid(uc.clock.NowFor(invite.BusinessID)) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgInviteInvalid, 400)
 	}
 
 	// Check if user already member
-	existingUser, err := uc.businessRepo.GetUserByBusinessAndProfile(invite.BusinessID, req.ProfileID)
+	existingUser, err := uc.businessRepo.GetUserByBusinessAndProfile(ctx, invite.BusinessID, req.ProfileID)
 	if err != nil {
 		return err
 	}
@@ -659,15 +917,20 @@ func (uc *businessUseCase) AcceptInvite(req *dto.AcceptInviteRequest) error {
 		return errors.New(errors.ErrConflict, "Anda sudah menjadi member", 409)
 	}
 
+	// Batas user dicek saat invite diterima, bukan saat dibuat
+	if err := uc.planEnforcement.CheckUserLimit(ctx, invite.BusinessID); err != nil {
+		return err
+	}
+
 	// Accept invite
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
 	// Mark invite as used
-	if err := uc.businessRepo.UseInvite(tx, req.Token); err != nil {
+	if err := uc.businessRepo.UseInvite(ctx, tx, req.Token); err != nil {
 		return err
 	}
 
@@ -680,31 +943,376 @@ func (uc *businessUseCase) AcceptInvite(req *dto.AcceptInviteRequest) error {
 		CreatedAt:  time.Now(),
 	}
 
-	if err := uc.businessRepo.AddUser(tx, businessUser); err != nil {
+	if err := uc.businessRepo.AddUser(ctx, tx, businessUser); err != nil {
 		return err
 	}
 
 	return tx.Commit()
 }
 
-// Helper methods
+// ListInvites daftar invite business, status kosong = semua status
+func (uc *businessUseCase) ListInvites(ctx *gin.Context, businessID int64, profileID int64, status string) ([]*dto.InviteResponse, error) {
+	// Token invite ikut dikembalikan, jadi hanya untuk yang boleh mengundang
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserInvite); err != nil {
+		return nil, err
+	}
+
+	if status != "" && !constant.IsValidInviteStatus(status) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgInviteStatusInvalid, 400)
+	}
+
+	invites, err := uc.businessRepo.ListInvites(utils.ContextFrom(ctx), businessID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.InviteResponse, len(invites))
+	for i, invite := range invites {
+		responses[i] = uc.toInviteResponse(invite)
+	}
+	return responses, nil
+}
+
+// ResendInvite kirim ulang email invite pending atau yang sudah kadaluarsa,
+// masa berlaku diperpanjang dengan link yang sama
+func (uc *businessUseCase) ResendInvite(ctx *gin.Context, inviteID int64, profileID int64) (*dto.InviteResponse, error) {
+	invite, err := uc.businessRepo.GetInviteByID(utils.ContextFrom(ctx), inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, invite.BusinessID, profileID, constant.PermUserInvite); err != nil {
+		return nil, err
+	}
+
+	if invite.IsUsed || (invite.Status != constant.InviteStatusPending && invite.Status != constant.InviteStatusExpired) {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgInviteNotPending, 409)
+	}
+
+	if !invite.Email.Valid {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgInviteEmailMissing, 400)
+	}
+	if !uc.mailService.IsConfigured() {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgMailNotConfigured, 503)
+	}
+
+	now := uc.clock.NowFor(invite.BusinessID)
+	expiresAt := now.Add(inviteValidity)
 
-func (uc *businessUseCase) checkBusinessPermission(businessID, profileID int64, permission string) error {
-	// Get user role in business
-	user, err := uc.businessRepo.GetUserByBusinessAndProfile(businessID, profileID)
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.businessRepo.RenewInvite(utils.ContextFrom(ctx), tx, invite.ID, expiresAt, now); err != nil {
+		return nil, err
+	}
+
+	invite.Status = constant.InviteStatusPending
+	invite.ExpiresAt = expiresAt
+	invite.ResentAt = &now
+
+	// Masa berlaku tidak diperpanjang jika email gagal terkirim
+	if err := uc.sendInviteEmail(utils.ContextFrom(ctx), invite); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return uc.toInviteResponse(invite), nil
+}
+
+// RevokeInvite cabut invite pending, link invite langsung tidak berlaku
+func (uc *businessUseCase) RevokeInvite(ctx *gin.Context, inviteID int64, profileID int64) error {
+	invite, err := uc.businessRepo.GetInviteByID(utils.ContextFrom(ctx), inviteID)
 	if err != nil {
 		return err
 	}
 
-	if user == nil || !user.IsActive {
-		return errors.New(errors.ErrForbidden, constant.ErrMsgBusinessAccessDenied, 403)
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, invite.BusinessID, profileID, constant.PermUserInvite); err != nil {
+		return err
 	}
 
-	// Check permission
-	if !constant.HasPermission(user.Role, permission) {
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.businessRepo.RevokeInvite(utils.ContextFrom(ctx), tx, invite.ID, profileID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// sendInviteEmail kirim link invite ke email penerima
+func (uc *businessUseCase) sendInviteEmail(ctx context.Context, invite *entity.BusinessInvite) error {
+	business, err := uc.businessRepo.GetByID(ctx, invite.BusinessID)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Anda diundang bergabung ke %s di AtamLink sebagai %s.\n\nBuka link berikut untuk menerima undangan:\n%s\n\nUndangan berlaku sampai %s.",
+		business.Name, invite.Role, uc.inviteURL(invite.Token), invite.ExpiresAt.Format("02 Jan 2006 15:04"),
+	)
+	if err := uc.mailService.Send(invite.Email.String, "Undangan bergabung ke "+business.Name+" di AtamLink", body); err != nil {
+		return errors.Wrap(err, "failed to send invite email")
+	}
+
+	return nil
+}
+
+// inviteURL link terima invite dari template INVITE_ACCEPT_URL
+func (uc *businessUseCase) inviteURL(token string) string {
+	return strings.ReplaceAll(uc.inviteConfig.AcceptURL, "{token}", token)
+}
+
+// ExpireStaleInvites job background, tandai invite pending yang lewat masa
+// berlaku sebagai expired
+func (uc *businessUseCase) ExpireStaleInvites(ctx context.Context, batchSize int) error {
+	// Business dengan offset time-travel sendiri disapu dengan waktunya masing-masing
+	traveling := uc.clock.TravelingBusinesses()
+	if _, err := uc.businessRepo.ExpireStaleInvites(ctx, uc.clock.Now(), traveling, batchSize); err != nil {
+		return err
+	}
+
+	for _, businessID := range traveling {
+		if _, err := uc.businessRepo.ExpireStaleBusinessInvites(ctx, businessID, uc.clock.NowFor(businessID), batchSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serviceAccountTokenPrefixLen panjang awal token yang disimpan untuk identifikasi
+const serviceAccountTokenPrefixLen = 10
+
+// CreateServiceAccount membuat service account untuk integrasi mesin (ERP, dll)
+func (uc *businessUseCase) CreateServiceAccount(ctx *gin.Context, businessID int64, profileID int64, req *dto.CreateServiceAccountRequest) (*dto.ServiceAccountCreatedResponse, error) {
+	// Service account tidak boleh membuat service account lain
+	if _, ok := middleware.GetServiceAccount(ctx); ok {
+		return nil, errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserInvite); err != nil {
+		return nil, err
+	}
+
+	// Akses API mesin hanya untuk plan dengan api_access
+	if err := uc.planEnforcement.CheckFeature(utils.ContextFrom(ctx), businessID, constant.PlanFeatureAPIAccess); err != nil {
+		return nil, err
+	}
+
+	role := req.Role
+	if role == "" {
+		role = constant.RoleEditor
+	}
+	if !constant.IsValidServiceAccountRole(role) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgServiceAccountRoleInvalid, 400)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	exists, err := uc.businessRepo.IsServiceAccountNameExists(utils.ContextFrom(ctx), businessID, name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgServiceAccountNameExists, 409)
+	}
+
+	// Generate token, yang disimpan hanya hash-nya
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to generate service account token")
+	}
+	token := constant.ServiceAccountTokenPrefix + hex.EncodeToString(tokenBytes)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	account := &entity.ServiceAccount{
+		BusinessID:  businessID,
+		Name:        name,
+		Role:        role,
+		TokenHash:   middleware.HashServiceAccountToken(token),
+		TokenPrefix: token[:serviceAccountTokenPrefixLen],
+		IsActive:    true,
+		CreatedBy:   profileID,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := uc.businessRepo.CreateServiceAccount(utils.ContextFrom(ctx), tx, account); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &dto.ServiceAccountCreatedResponse{
+		ServiceAccountResponse: *uc.toServiceAccountResponse(account),
+		Token:                  token,
+	}, nil
+}
+
+// ListServiceAccounts daftar service account business
+func (uc *businessUseCase) ListServiceAccounts(ctx *gin.Context, businessID int64, profileID int64) ([]*dto.ServiceAccountResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserView); err != nil {
+		return nil, err
+	}
+
+	accounts, err := uc.businessRepo.ListServiceAccounts(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ServiceAccountResponse, len(accounts))
+	for i, account := range accounts {
+		responses[i] = uc.toServiceAccountResponse(account)
+	}
+	return responses, nil
+}
+
+// RevokeServiceAccount cabut service account, token langsung tidak berlaku
+func (uc *businessUseCase) RevokeServiceAccount(ctx *gin.Context, businessID int64, accountID int64, profileID int64) error {
+	if _, ok := middleware.GetServiceAccount(ctx); ok {
 		return errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
 	}
 
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermUserInvite); err != nil {
+		return err
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.businessRepo.RevokeServiceAccount(utils.ContextFrom(ctx), tx, businessID, accountID, profileID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetIPAllowlist IP allowlist business untuk operasi tulis
+func (uc *businessUseCase) GetIPAllowlist(ctx *gin.Context, businessID int64, profileID int64) (*dto.IPAllowlistResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	allowlist, err := uc.businessRepo.GetIPAllowlist(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toIPAllowlistResponse(allowlist, ctx.ClientIP()), nil
+}
+
+// UpdateIPAllowlist ganti IP allowlist business. IP pemanggil harus termasuk
+// dalam allowlist baru supaya owner tidak mengunci dirinya sendiri
+func (uc *businessUseCase) UpdateIPAllowlist(ctx *gin.Context, businessID int64, profileID int64, req *dto.UpdateIPAllowlistRequest) (*dto.IPAllowlistResponse, error) {
+	if _, ok := middleware.GetServiceAccount(ctx); ok {
+		return nil, errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	// Perubahan allowlist juga harus berasal dari IP yang diizinkan
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	entries, err := normalizeIPAllowlist(req.Entries)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > uc.ipAllowlistConfig.MaxEntries {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgIPAllowlistTooMany, 400)
+	}
+	if len(entries) > 0 && !entity.MatchIPEntries(entries, ctx.ClientIP()) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgIPAllowlistSelfLockout, 400)
+	}
+
+	existing, err := uc.businessRepo.GetIPAllowlist(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	ctx.Set(middleware.GinKeyAuditOldData, existing)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.businessRepo.UpdateIPAllowlist(utils.ContextFrom(ctx), tx, businessID, entries, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toIPAllowlistResponse(&entity.IPAllowlist{BusinessID: businessID, Entries: entries}, ctx.ClientIP()), nil
+}
+
+// BreakGlassIPAllowlist buka IP allowlist sementara untuk owner yang terkunci
+// di luar allowlist. Route wajib dilindungi step-up, allowlist tidak dicek di sini
+func (uc *businessUseCase) BreakGlassIPAllowlist(ctx *gin.Context, businessID int64, profileID int64) (*dto.IPAllowlistResponse, error) {
+	if _, ok := middleware.GetServiceAccount(ctx); ok {
+		return nil, errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+
+	// Allowlist tidak diterapkan, business:delete hanya dimiliki owner
+	if err := service.CheckBusinessAccessWithoutAllowlist(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessDelete); err != nil {
+		return nil, err
+	}
+
+	allowlist, err := uc.businessRepo.GetIPAllowlist(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	until := time.Now().Add(uc.ipAllowlistConfig.BreakGlassDuration)
+	if err := uc.businessRepo.SetIPAllowlistBypass(utils.ContextFrom(ctx), tx, businessID, until); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	allowlist.BypassUntil = &until
+	return toIPAllowlistResponse(allowlist, ctx.ClientIP()), nil
+}
+
+// Helper methods
+
+// checkCategory pastikan kategori master ada dan aktif
+func (uc *businessUseCase) checkCategory(ctx context.Context, categoryID int64) error {
+	active, err := uc.businessRepo.IsCategoryActive(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCategoryInactive, 400)
+	}
 	return nil
 }
 
@@ -717,6 +1325,7 @@ func (uc *businessUseCase) toBusinessResponse(business *entity.Business, users [
 		IsActive:         business.IsActive,
 		IsSuspended:      business.IsSuspended,
 		SuspensionReason: business.GetSuspensionReason(),
+		MediaReplication: business.MediaReplication,
 		CreatedBy:        business.CreatedBy,
 		CreatedAt:        business.CreatedAt,
 		UpdatedAt:        business.UpdatedAt,
@@ -726,6 +1335,12 @@ func (uc *businessUseCase) toBusinessResponse(business *entity.Business, users [
 	if business.LogoURL.Valid {
 		resp.LogoURL = &business.LogoURL.String
 	}
+	if business.City.Valid {
+		resp.City = &business.City.String
+	}
+	if business.CategoryID.Valid {
+		resp.CategoryID = &business.CategoryID.Int64
+	}
 
 	// Add users
 	if users != nil {
@@ -772,4 +1387,119 @@ func (uc *businessUseCase) toBusinessResponse(business *entity.Business, users [
 	}
 
 	return resp
-}
\ No newline at end of file
+}
+
+func toOnboardingResponse(progress *entity.OnboardingProgress) *dto.OnboardingResponse {
+	steps := []dto.OnboardingStepResponse{
+		{Key: constant.OnboardingStepLogoUploaded, Title: "Upload logo bisnis", Completed: progress.LogoUploaded},
+		{Key: constant.OnboardingStepCatalogCreated, Title: "Buat katalog pertama", Completed: progress.CatalogCreated},
+		{Key: constant.OnboardingStepCardAdded, Title: "Tambah card pertama", Completed: progress.CardAdded},
+		{Key: constant.OnboardingStepPublished, Title: "Publish katalog", Completed: progress.CatalogPublished},
+		{Key: constant.OnboardingStepLinkVisited, Title: "Bagikan link katalog", Completed: progress.LinkVisited},
+	}
+
+	completed := 0
+	for _, step := range steps {
+		if step.Completed {
+			completed++
+		}
+	}
+
+	return &dto.OnboardingResponse{
+		BusinessID:     progress.BusinessID,
+		Steps:          steps,
+		CompletedSteps: completed,
+		TotalSteps:     len(steps),
+		Percent:        completed * 100 / len(steps),
+		IsCompleted:    completed == len(steps),
+	}
+}
+
+func toBrandResponse(business *entity.Business) *dto.BrandResponse {
+	resp := &dto.BrandResponse{
+		BusinessID:     business.ID,
+		PrimaryColor:   business.Brand.PrimaryColor,
+		SecondaryColor: business.Brand.SecondaryColor,
+		FontFamily:     business.Brand.FontFamily,
+		HeadingFont:    business.Brand.HeadingFont,
+		ShowLogo:       business.Brand.ShowLogo,
+	}
+	if business.LogoURL.Valid {
+		resp.LogoURL = &business.LogoURL.String
+	}
+	return resp
+}
+
+func (uc *businessUseCase) toServiceAccountResponse(account *entity.ServiceAccount) *dto.ServiceAccountResponse {
+	return &dto.ServiceAccountResponse{
+		ID:          account.ID,
+		Name:        account.Name,
+		Role:        account.Role,
+		TokenPrefix: account.TokenPrefix,
+		IsActive:    account.IsActive,
+		LastUsedAt:  account.LastUsedAt,
+		CreatedBy:   account.CreatedBy,
+		CreatedAt:   account.CreatedAt,
+		RevokedAt:   account.RevokedAt,
+	}
+}
+
+func (uc *businessUseCase) toInviteResponse(invite *entity.BusinessInvite) *dto.InviteResponse {
+	return &dto.InviteResponse{
+		ID:        invite.ID,
+		Token:     invite.Token,
+		Role:      invite.Role,
+		InvitedBy: invite.InvitedBy,
+		Email:     invite.Email.String,
+		IsUsed:    invite.IsUsed,
+		Status:    invite.Status,
+		ExpiresAt: invite.ExpiresAt,
+		CreatedAt: invite.CreatedAt,
+		RevokedAt: invite.RevokedAt,
+		ResentAt:  invite.ResentAt,
+		InviteURL: uc.inviteURL(invite.Token),
+	}
+}
+
+// normalizeIPAllowlist validasi entri IP/CIDR lalu ubah ke bentuk kanonik tanpa duplikat
+func normalizeIPAllowlist(entries []string) ([]string, error) {
+	normalized := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, raw := range entries {
+		entry := strings.TrimSpace(raw)
+		if strings.Contains(entry, "/") {
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, errors.New(errors.ErrValidation, constant.ErrMsgIPAllowlistEntryInvalid, 400)
+			}
+			entry = network.String()
+		} else {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, errors.New(errors.ErrValidation, constant.ErrMsgIPAllowlistEntryInvalid, 400)
+			}
+			entry = ip.String()
+		}
+
+		if !seen[entry] {
+			seen[entry] = true
+			normalized = append(normalized, entry)
+		}
+	}
+	return normalized, nil
+}
+
+func toIPAllowlistResponse(allowlist *entity.IPAllowlist, clientIP string) *dto.IPAllowlistResponse {
+	resp := &dto.IPAllowlistResponse{
+		Entries:  allowlist.Entries,
+		Enabled:  len(allowlist.Entries) > 0,
+		ClientIP: clientIP,
+	}
+	if resp.Entries == nil {
+		resp.Entries = []string{}
+	}
+	if allowlist.IsBypassed(time.Now()) {
+		resp.BypassUntil = allowlist.BypassUntil
+	}
+	return resp
+}
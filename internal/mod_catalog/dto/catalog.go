@@ -1,7 +1,10 @@
 package dto
 
 import (
+	"strings"
 	"time"
+
+	"github.com/atam/atamlink/pkg/utils"
 )
 
 // CreateCatalogRequest request untuk create catalog
@@ -12,6 +15,12 @@ type CreateCatalogRequest struct {
 	Title      string                 `json:"title" validate:"required,min=3,max=200"`
 	Subtitle   string                 `json:"subtitle,omitempty" validate:"max=300"`
 	Settings   map[string]interface{} `json:"settings,omitempty"`
+	MetaTitle  string                 `json:"meta_title,omitempty" validate:"omitempty,max=200"`
+	MetaDescription string            `json:"meta_description,omitempty" validate:"omitempty,max=500"`
+	OGImage    string                 `json:"og_image,omitempty" validate:"omitempty,url,max=500"`
+	AllowIndexing *bool               `json:"allow_indexing,omitempty"` // default true
+	Listed     *bool                  `json:"listed,omitempty"`         // tampil di direktori publik, default false
+	CategoryID int64                  `json:"category_id,omitempty" validate:"omitempty,gt=0"` // kosong = ikut kategori business
 	Sections   []CreateSectionRequest `json:"sections,omitempty"`
 }
 
@@ -22,6 +31,14 @@ type UpdateCatalogRequest struct {
 	Subtitle string                 `json:"subtitle,omitempty" validate:"max=300"`
 	IsActive *bool                  `json:"is_active,omitempty"`
 	Settings map[string]interface{} `json:"settings,omitempty"`
+	MetaTitle *string               `json:"meta_title,omitempty" validate:"omitempty,max=200"`             // "" = hapus
+	MetaDescription *string         `json:"meta_description,omitempty" validate:"omitempty,max=500"`
+	OGImage  *string                `json:"og_image,omitempty" validate:"omitempty,url,max=500"`
+	AllowIndexing *bool             `json:"allow_indexing,omitempty"`
+	Listed   *bool                  `json:"listed,omitempty"`
+	CategoryID *int64               `json:"category_id,omitempty" validate:"omitempty,gte=0"` // 0 = ikut kategori business
+	Version  *int                   `json:"version,omitempty" validate:"omitempty,gt=0"`       // versi saat dibaca, 409 jika sudah berubah
+	UnmodifiedSince *time.Time      `json:"-"`                                                  // dari header If-Unmodified-Since
 }
 
 // CatalogResponse response untuk catalog
@@ -34,10 +51,23 @@ type CatalogResponse struct {
 	Title      string                 `json:"title"`
 	Subtitle   string                 `json:"subtitle,omitempty"`
 	IsActive   bool                   `json:"is_active"`
+	Status     string                 `json:"status"`
+	PublishedAt *time.Time            `json:"published_at,omitempty"`
+	PublishScheduledAt *time.Time     `json:"publish_scheduled_at,omitempty"`
+	PublishAt   *time.Time            `json:"publish_at,omitempty"`
+	UnpublishAt *time.Time            `json:"unpublish_at,omitempty"`
 	Settings   map[string]interface{} `json:"settings"`
+	MetaTitle  string                 `json:"meta_title,omitempty"`
+	MetaDescription string            `json:"meta_description,omitempty"`
+	OGImage    string                 `json:"og_image,omitempty"`
+	AllowIndexing bool                `json:"allow_indexing"`
+	Listed     bool                   `json:"listed"`
+	CategoryID *int64                 `json:"category_id,omitempty"`
 	CreatedBy  int64                  `json:"created_by"`
 	CreatedAt  time.Time              `json:"created_at"`
 	UpdatedAt  *time.Time             `json:"updated_at,omitempty"`
+	DeletedAt  *time.Time             `json:"deleted_at,omitempty"` // katalog ada di trash
+	Version    int                    `json:"version"`
 	PublicURL  string                 `json:"public_url"`
 	Business   *BusinessResponse      `json:"business,omitempty"`
 	Theme      *ThemeResponse         `json:"theme,omitempty"`
@@ -53,12 +83,107 @@ type CatalogListResponse struct {
 	Title        string     `json:"title"`
 	Subtitle     string     `json:"subtitle,omitempty"`
 	IsActive     bool       `json:"is_active"`
+	Status       string     `json:"status"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+	ArchivedAt   *time.Time `json:"archived_at,omitempty"` // konten dipulihkan otomatis saat katalog dibuka
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"`  // hanya di list trash
 	ThemeName    string     `json:"theme_name"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
 	PublicURL    string     `json:"public_url"`
 }
 
+// CatalogSearchResponse hasil pencarian katalog di dashboard. Field *_highlight sudah
+// di-escape HTML dengan kata yang cocok dibungkus <mark>
+type CatalogSearchResponse struct {
+	ID             int64                       `json:"id"`
+	BusinessID     int64                       `json:"business_id"`
+	BusinessName   string                      `json:"business_name"`
+	Slug           string                      `json:"slug"`
+	SlugHighlight  string                      `json:"slug_highlight"`
+	Title          string                      `json:"title"`
+	TitleHighlight string                      `json:"title_highlight"`
+	Subtitle       string                      `json:"subtitle,omitempty"`
+	IsActive       bool                        `json:"is_active"`
+	Status         string                      `json:"status"`
+	ArchivedAt     *time.Time                  `json:"archived_at,omitempty"`
+	UpdatedAt      *time.Time                  `json:"updated_at,omitempty"`
+	Rank           float64                     `json:"rank"`
+	MatchedCards   []CatalogSearchCardResponse `json:"matched_cards"` // card dengan judul cocok, maksimal 3
+	PublicURL      string                      `json:"public_url"`
+}
+
+// CatalogSearchCardResponse card yang judulnya cocok di pencarian dashboard
+type CatalogSearchCardResponse struct {
+	ID             int64  `json:"id"`
+	SectionID      int64  `json:"section_id"`
+	Title          string `json:"title"`
+	TitleHighlight string `json:"title_highlight"`
+	IsVisible      bool   `json:"is_visible"`
+}
+
+// CatalogBatchItem hasil satu ID di batch GET /catalogs?ids=, urut sesuai request
+type CatalogBatchItem struct {
+	ID      int64                `json:"id"`
+	Catalog *CatalogListResponse `json:"catalog,omitempty"`
+	Error   *BatchItemError      `json:"error,omitempty"`
+	Err     error                `json:"-"` // diubah handler menjadi Error
+}
+
+// PublicCatalogBatchItem hasil satu slug di batch GET /public/catalogs?slugs=
+type PublicCatalogBatchItem struct {
+	Slug    string                `json:"slug"`
+	Catalog *PublicCatalogSummary `json:"catalog,omitempty"`
+	Error   *BatchItemError       `json:"error,omitempty"`
+	Err     error                 `json:"-"` // diubah handler menjadi Error
+}
+
+// PublicCatalogSummary ringkasan katalog publik
+type PublicCatalogSummary struct {
+	Slug            string        `json:"slug"`
+	Title           string        `json:"title"`
+	Subtitle        string        `json:"subtitle,omitempty"`
+	MetaDescription string        `json:"meta_description,omitempty"`
+	OGImage         string        `json:"og_image,omitempty"`
+	BusinessName    string        `json:"business_name"`
+	BusinessSlug    string        `json:"business_slug"`
+	BusinessLogo    *string       `json:"business_logo,omitempty"`
+	Rating          RatingSummary `json:"rating"`
+	CanonicalURL    string        `json:"canonical_url,omitempty"`
+	PublicURL       string        `json:"public_url"`
+}
+
+// SitemapURL satu entri <url> sitemap.xml
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// BatchItemError error per item batch, status mengikuti status HTTP endpoint tunggal
+type BatchItemError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// DirectoryCatalogResponse katalog di direktori publik
+type DirectoryCatalogResponse struct {
+	Slug         string     `json:"slug"`
+	Title        string     `json:"title"`
+	Subtitle     string     `json:"subtitle,omitempty"`
+	BusinessName string     `json:"business_name"`
+	BusinessSlug string     `json:"business_slug"`
+	BusinessLogo *string    `json:"business_logo,omitempty"`
+	Category     string     `json:"category,omitempty"` // slug kategori master
+	CategoryName string     `json:"category_name,omitempty"`
+	BusinessType string     `json:"business_type"`
+	City         string     `json:"city,omitempty"`
+	Views        int64      `json:"views"` // 30 hari terakhir
+	RatingAvg    float64    `json:"rating_avg"`
+	RatingCount  int        `json:"rating_count"`
+	PublishedAt  *time.Time `json:"published_at,omitempty"`
+	PublicURL    string     `json:"public_url"`
+}
+
 // BusinessResponse simple business response
 type BusinessResponse struct {
 	ID   int64  `json:"id"`
@@ -77,7 +202,7 @@ type ThemeResponse struct {
 type CreateSectionRequest struct {
 	Type      string                 `json:"type" validate:"required,oneof=hero cards carousel faqs links socials testimonials cta text video"`
 	IsVisible bool                   `json:"is_visible"`
-	Config    map[string]interface{} `json:"config,omitempty"`
+	Config    map[string]interface{} `json:"config,omitempty"` // section cards: layout {type, columns{mobile,tablet,desktop}, image_aspect_ratio}
 	Content   interface{}            `json:"content,omitempty"` // Specific content based on type
 }
 
@@ -95,8 +220,11 @@ type SectionResponse struct {
 	Type      string                 `json:"type"`
 	IsVisible bool                   `json:"is_visible"`
 	Config    map[string]interface{} `json:"config"`
+	Position  int                    `json:"position"`
 	CreatedAt time.Time              `json:"created_at"`
 	UpdatedAt *time.Time             `json:"updated_at,omitempty"`
+	LastModifiedBy string            `json:"last_modified_by,omitempty"`
+	LastModifiedAt *time.Time        `json:"last_modified_at,omitempty"`
 	Content   interface{}            `json:"content,omitempty"` // Based on type
 }
 
@@ -113,6 +241,23 @@ type CreateCardRequest struct {
 	Currency  string   `json:"currency,omitempty" validate:"omitempty,oneof=IDR"`
 	Detail    *CardDetailRequest `json:"detail,omitempty"`
 	MediaURLs []string `json:"media_urls,omitempty"`
+	Affiliate *AffiliateRequest  `json:"affiliate,omitempty"`
+}
+
+// CardImportResponse hasil import card dari file, baris yang gagal tidak dibuat
+type CardImportResponse struct {
+	TotalRows int                  `json:"total_rows"`
+	Created   int                  `json:"created"`
+	Failed    int                  `json:"failed"`
+	Errors    []CardImportRowError `json:"errors"`
+}
+
+// CardImportRowError error satu baris file import, row mengikuti nomor baris di
+// file (header = baris 1)
+type CardImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
 }
 
 // UpdateCardRequest request untuk update card
@@ -126,6 +271,172 @@ type UpdateCardRequest struct {
 	Price     *int64   `json:"price,omitempty" validate:"omitempty,gte=0"`
 	Discount  *int     `json:"discount,omitempty" validate:"omitempty,gte=0,lte=100"`
 	Currency  string   `json:"currency,omitempty" validate:"omitempty,oneof=IDR"`
+	Affiliate *AffiliateRequest `json:"affiliate,omitempty"` // partner_id kosong untuk menghapus
+	Detail    *CardDetailRequest `json:"detail,omitempty"` // links null = tidak diubah, [] = hapus semua
+	Version   *int     `json:"version,omitempty" validate:"omitempty,gt=0"` // versi saat dibaca, 409 jika sudah berubah
+	UnmodifiedSince *time.Time `json:"-"`                               // dari header If-Unmodified-Since
+}
+
+// AffiliateRequest request untuk affiliate metadata card
+type AffiliateRequest struct {
+	PartnerID      string  `json:"partner_id" validate:"omitempty,max=100"`
+	CommissionRate float64 `json:"commission_rate" validate:"gte=0,lte=100"`
+}
+
+// AffiliateResponse response untuk affiliate metadata card
+type AffiliateResponse struct {
+	PartnerID      string  `json:"partner_id"`
+	CommissionRate float64 `json:"commission_rate"`
+}
+
+// AffiliateEarningResponse response untuk laporan estimasi komisi per card per bulan
+type AffiliateEarningResponse struct {
+	CardID              int64   `json:"card_id"`
+	CardTitle           string  `json:"card_title"`
+	PartnerID           string  `json:"partner_id"`
+	Month               string  `json:"month"` // format YYYY-MM
+	Clicks              int64   `json:"clicks"`
+	EstimatedCommission float64 `json:"estimated_commission"`
+}
+
+// CreateCheckoutLinkRequest request untuk generate checkout link
+type CreateCheckoutLinkRequest struct {
+	Quantity int `json:"quantity,omitempty" validate:"omitempty,gte=1,lte=1000"`
+	// Versi legal katalog yang disetujui pembeli, wajib jika section legal
+	// mengaktifkan require_acknowledgment
+	LegalVersion int `json:"legal_version,omitempty" validate:"omitempty,gte=1"`
+}
+
+// CheckoutLinkResponse response untuk checkout link
+type CheckoutLinkResponse struct {
+	ID           int64      `json:"id"`
+	CardID       int64      `json:"card_id"`
+	ExternalID   string     `json:"external_id"`
+	Quantity     int        `json:"quantity"`
+	Amount       int64      `json:"amount"`
+	Currency     string     `json:"currency"`
+	PaymentURL   string     `json:"payment_url"`
+	Status       string     `json:"status"`
+	LegalVersion *int       `json:"legal_version,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// SchedulePriceRequest request jadwal perubahan harga card
+type SchedulePriceRequest struct {
+	Price       *int64    `json:"price" validate:"required,gte=0"`
+	Discount    *int      `json:"discount,omitempty" validate:"omitempty,gte=0,lte=100"` // kosong = diskon tidak diubah
+	EffectiveAt time.Time `json:"effective_at" validate:"required"`
+}
+
+// PriceScheduleResponse response jadwal perubahan harga card
+type PriceScheduleResponse struct {
+	ID          int64      `json:"id"`
+	CardID      int64      `json:"card_id"`
+	Price       int64      `json:"price"`
+	Discount    *int       `json:"discount,omitempty"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	Status      string     `json:"status"`
+	CreatedBy   int64      `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
+
+// SubmitPublishRequest request pengajuan publish katalog
+type SubmitPublishRequest struct {
+	Note string `json:"note,omitempty" validate:"max=1000"`
+}
+
+// ReviewPublishRequest request hasil review pengajuan publish
+type ReviewPublishRequest struct {
+	Comment string `json:"comment,omitempty" validate:"max=2000"`
+}
+
+// SchedulePublishRequest request jadwal publish katalog draft
+type SchedulePublishRequest struct {
+	PublishAt time.Time `json:"publish_at" validate:"required"`
+}
+
+// VisibilityScheduleRequest request jadwal tampil/sembunyi katalog atau card,
+// minimal salah satu diisi. Jadwal lama diganti seluruhnya
+type VisibilityScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at,omitempty"`   // sembunyikan sekarang, tampilkan pada waktu ini
+	UnpublishAt *time.Time `json:"unpublish_at,omitempty"` // sembunyikan pada waktu ini
+}
+
+// CardStockRequest request atur stok card, seluruh pengaturan stok diganti
+type CardStockRequest struct {
+	Stock           *int64 `json:"stock" validate:"omitempty,gte=0"` // null = stok tidak dilacak
+	SoldOut         bool   `json:"sold_out"`                          // tandai habis manual
+	HideWhenSoldOut bool   `json:"hide_when_sold_out"`                // false = tetap tampil dengan badge habis
+}
+
+// AdjustCardStockRequest request tambah/kurangi stok card yang dilacak
+type AdjustCardStockRequest struct {
+	Delta int64 `json:"delta" validate:"required"` // positif = restock, negatif = terjual
+}
+
+// CreateTagRequest request untuk membuat tag katalog
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+	Slug string `json:"slug,omitempty" validate:"omitempty,slug,max=60"` // default dari nama
+}
+
+// UpdateTagRequest request untuk update tag katalog
+type UpdateTagRequest struct {
+	Name string `json:"name,omitempty" validate:"omitempty,min=1,max=50"`
+	Slug string `json:"slug,omitempty" validate:"omitempty,slug,max=60"`
+}
+
+// SetCardTagsRequest request ganti seluruh tag card, [] = hapus semua tag
+type SetCardTagsRequest struct {
+	TagIDs []int64 `json:"tag_ids" validate:"required"`
+}
+
+// TagResponse response untuk tag katalog
+type TagResponse struct {
+	ID        int64      `json:"id"`
+	CatalogID int64      `json:"catalog_id"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	CardCount int        `json:"card_count"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CardTagResponse tag ringkas pada card dan filter katalog publik
+type CardTagResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// PublishRequestResponse response pengajuan publish
+type PublishRequestResponse struct {
+	ID            int64                `json:"id"`
+	CatalogID     int64                `json:"catalog_id"`
+	Status        string               `json:"status"`
+	Note          string               `json:"note,omitempty"`
+	ReviewComment string               `json:"review_comment,omitempty"`
+	RequestedBy   PublishActorResponse `json:"requested_by"`
+	RequestedAt   time.Time            `json:"requested_at"`
+	ReviewedBy    *PublishActorResponse `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time           `json:"reviewed_at,omitempty"`
+}
+
+// PublishActorResponse pengaju atau reviewer
+type PublishActorResponse struct {
+	ProfileID   int64  `json:"profile_id"`
+	DisplayName string `json:"display_name"`
+}
+
+// PaymentCallbackRequest payload callback invoice dari payment gateway
+type PaymentCallbackRequest struct {
+	ID         string     `json:"id"`
+	ExternalID string     `json:"external_id" validate:"required"`
+	Status     string     `json:"status" validate:"required"`
+	PaidAt     *time.Time `json:"paid_at,omitempty"`
 }
 
 // CardResponse response untuk card
@@ -142,18 +453,29 @@ type CardResponse struct {
 	Discount        int                `json:"discount,omitempty"`
 	DiscountedPrice int64              `json:"discounted_price,omitempty"`
 	Currency        string             `json:"currency,omitempty"`
+	Position        int                `json:"position,omitempty"` // hanya untuk response admin
+	PublishAt       *time.Time         `json:"publish_at,omitempty"`   // hanya untuk response admin
+	UnpublishAt     *time.Time         `json:"unpublish_at,omitempty"` // hanya untuk response admin
+	Stock           *int64             `json:"stock,omitempty"`
+	SoldOut         bool               `json:"sold_out"`
+	HideWhenSoldOut bool               `json:"hide_when_sold_out,omitempty"` // hanya untuk response admin
 	CreatedAt       time.Time          `json:"created_at"`
 	UpdatedAt       *time.Time         `json:"updated_at,omitempty"`
+	Version         int                `json:"version,omitempty"` // hanya untuk response admin
 	Detail          *CardDetailResponse `json:"detail,omitempty"`
 	Media           []MediaResponse     `json:"media,omitempty"`
+	Tags            []CardTagResponse   `json:"tags,omitempty"`
+	Affiliate       *AffiliateResponse  `json:"affiliate,omitempty"` // hanya untuk response admin
+	LastModifiedBy  string              `json:"last_modified_by,omitempty"` // hanya untuk response admin
+	LastModifiedAt  *time.Time          `json:"last_modified_at,omitempty"` // hanya untuk response admin
 }
 
 // CardDetailRequest request untuk card detail
 type CardDetailRequest struct {
-	Slug        string      `json:"slug,omitempty" validate:"omitempty,slug"`
-	Description string      `json:"description,omitempty"`
+	Slug        string      `json:"slug,omitempty" validate:"omitempty,slug,min=3,max=100"` // unik per katalog
+	Description string      `json:"description,omitempty"` // Markdown
 	IsVisible   bool        `json:"is_visible"`
-	Links       []LinkRequest `json:"links,omitempty"`
+	Links       []LinkRequest `json:"links,omitempty" validate:"omitempty,dive"`
 }
 
 // CardDetailResponse response untuk card detail
@@ -161,11 +483,13 @@ type CardDetailResponse struct {
 	ID          int64           `json:"id"`
 	CardID      int64           `json:"card_id"`
 	Slug        string          `json:"slug"`
-	Description string          `json:"description,omitempty"`
+	Description string          `json:"description,omitempty"` // Markdown, hanya untuk response admin
+	DescriptionHTML string      `json:"description_html,omitempty"`
 	IsVisible   bool            `json:"is_visible"`
 	CreatedAt   time.Time       `json:"created_at"`
 	UpdatedAt   *time.Time      `json:"updated_at,omitempty"`
 	Links       []LinkResponse  `json:"links,omitempty"`
+	CanonicalURL string         `json:"canonical_url,omitempty"` // hanya untuk response publik
 }
 
 // LinkRequest request untuk links
@@ -173,16 +497,68 @@ type LinkRequest struct {
 	Type      string `json:"type" validate:"required,oneof=whatsapp shopee tokopedia website tiktokshop facebook instagram telegram email phone custom"`
 	URL       string `json:"url" validate:"required,max=500"`
 	IsVisible bool   `json:"is_visible"`
+	UTM       *LinkUTM `json:"utm,omitempty"` // override UTM katalog
+}
+
+// UpdateLinkRequest request untuk update link card
+type UpdateLinkRequest struct {
+	Type      string `json:"type,omitempty" validate:"omitempty,oneof=whatsapp shopee tokopedia website tiktokshop facebook instagram telegram email phone custom"`
+	URL       string `json:"url,omitempty" validate:"omitempty,max=500"`
+	IsVisible *bool  `json:"is_visible,omitempty"`
+	UTM       *LinkUTM `json:"utm,omitempty"` // {} = hapus override, ikut UTM katalog
+}
+
+// LinkUTM override UTM per link. Field kosong mengikuti settings utm_* katalog,
+// disabled mematikan UTM untuk link ini
+type LinkUTM struct {
+	Disabled bool   `json:"disabled,omitempty"`
+	Source   string `json:"source,omitempty" validate:"omitempty,max=100"`
+	Medium   string `json:"medium,omitempty" validate:"omitempty,max=100"`
+	Campaign string `json:"campaign,omitempty" validate:"omitempty,max=100"`
+}
+
+// NewLinkUTM convert override UTM link ke DTO, nil jika tidak ada override
+func NewLinkUTM(o *utils.UTMOverride) *LinkUTM {
+	if o == nil {
+		return nil
+	}
+	return &LinkUTM{
+		Disabled: o.Disabled,
+		Source:   o.Source,
+		Medium:   o.Medium,
+		Campaign: o.Campaign,
+	}
+}
+
+// Override convert ke override UTM link, nil jika kosong (ikut UTM katalog)
+func (u *LinkUTM) Override() *utils.UTMOverride {
+	if u == nil {
+		return nil
+	}
+	params := utils.UTMParams{
+		Source:   strings.TrimSpace(u.Source),
+		Medium:   strings.TrimSpace(u.Medium),
+		Campaign: strings.TrimSpace(u.Campaign),
+	}
+	if !u.Disabled && params.IsZero() {
+		return nil
+	}
+	if u.Disabled {
+		params = utils.UTMParams{}
+	}
+	return &utils.UTMOverride{Disabled: u.Disabled, UTMParams: params}
 }
 
 // LinkResponse response untuk links
 type LinkResponse struct {
-	ID        int64      `json:"id"`
-	Type      string     `json:"type"`
-	URL       string     `json:"url"`
-	IsVisible bool       `json:"is_visible"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	ID          int64      `json:"id"`
+	Type        string     `json:"type"`
+	URL         string     `json:"url"`
+	RedirectURL string     `json:"redirect_url,omitempty"` // URL redirect dengan pencatatan klik
+	UTM         *LinkUTM   `json:"utm,omitempty"`          // override UTM, hanya di response admin
+	IsVisible   bool       `json:"is_visible"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
 }
 
 // MediaResponse response untuk media
@@ -211,10 +587,91 @@ type CreateCarouselItemRequest struct {
 // FAQRequest request untuk FAQ
 type FAQRequest struct {
 	Question  string `json:"question" validate:"required"`
-	Answer    string `json:"answer" validate:"required"`
+	Answer    string `json:"answer" validate:"required"` // Markdown
 	IsVisible bool   `json:"is_visible"`
 }
 
+// CreateFAQsRequest request untuk menambah FAQ di akhir section
+type CreateFAQsRequest struct {
+	FAQs []FAQRequest `json:"faqs" validate:"required,min=1,max=100,dive"`
+}
+
+// ApplyBrandResponse hasil penerapan brand business ke katalog
+type ApplyBrandResponse struct {
+	BusinessID   int64                  `json:"business_id"`
+	UpdatedCount int                    `json:"updated_count"`
+	Settings     map[string]interface{} `json:"settings"`
+}
+
+// MovePositionRequest request pindah posisi section/card, after_id 0 = paling atas
+type MovePositionRequest struct {
+	AfterID int64 `json:"after_id" validate:"min=0"`
+}
+
+// ReorderSectionsRequest request urutkan ulang section katalog, urutan array = urutan tampil.
+// Harus berisi semua section katalog tepat satu kali
+type ReorderSectionsRequest struct {
+	SectionIDs []int64 `json:"section_ids" validate:"required,min=1,dive,gt=0"`
+}
+
+// ReplaceFAQsRequest request untuk mengganti seluruh FAQ section, urutan array = urutan tampil
+type ReplaceFAQsRequest struct {
+	FAQs []UpsertFAQRequest `json:"faqs" validate:"max=100,dive"`
+}
+
+// UpdateFAQRequest request untuk update satu FAQ, field kosong tidak diubah
+type UpdateFAQRequest struct {
+	Question  string `json:"question,omitempty"`
+	Answer    string `json:"answer,omitempty"` // Markdown
+	IsVisible *bool  `json:"is_visible,omitempty"`
+}
+
+// ReorderFAQsRequest request urutkan ulang FAQ section, urutan array = urutan tampil.
+// Harus berisi semua FAQ section tepat satu kali
+type ReorderFAQsRequest struct {
+	FAQIDs []int64 `json:"faq_ids" validate:"required,min=1,dive,gt=0"`
+}
+
+// UpsertFAQRequest FAQ dengan ID diupdate, tanpa ID dibuat baru
+type UpsertFAQRequest struct {
+	ID        int64  `json:"id,omitempty"`
+	Question  string `json:"question" validate:"required"`
+	Answer    string `json:"answer" validate:"required"` // Markdown
+	IsVisible bool   `json:"is_visible"`
+}
+
+// CreateLegalVersionRequest request teks legal baru, setiap perubahan menjadi versi baru
+type CreateLegalVersionRequest struct {
+	Terms   string `json:"terms" validate:"required_without=Privacy,max=100000"` // Markdown
+	Privacy string `json:"privacy" validate:"required_without=Terms,max=100000"` // Markdown
+}
+
+// LegalVersionResponse response untuk satu versi teks legal
+type LegalVersionResponse struct {
+	ID          int64     `json:"id"`
+	SectionID   int64     `json:"section_id"`
+	Version     int       `json:"version"`
+	Terms       string    `json:"terms"`
+	TermsHTML   string    `json:"terms_html"`
+	Privacy     string    `json:"privacy"`
+	PrivacyHTML string    `json:"privacy_html"`
+	CreatedBy   int64     `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FAQResponse response untuk FAQ
+type FAQResponse struct {
+	ID           int64      `json:"id"`
+	SectionID    int64      `json:"section_id"`
+	Question     string     `json:"question"`
+	Answer       string     `json:"answer"`
+	AnswerHTML   string     `json:"answer_html"`
+	IsVisible    bool       `json:"is_visible"`
+	DisplayOrder int        `json:"display_order"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    *time.Time `json:"updated_at,omitempty"`
+}
+
 // SocialRequest request untuk social link
 type SocialRequest struct {
 	Platform  string `json:"platform" validate:"required,oneof=facebook instagram twitter linkedin youtube tiktok whatsapp telegram pinterest github"`
@@ -229,6 +686,30 @@ type TestimonialRequest struct {
 	IsVisible bool   `json:"is_visible"`
 }
 
+// DirectoryFilter filter direktori katalog publik
+type DirectoryFilter struct {
+	Search   string `json:"search,omitempty"`
+	Category string `json:"category,omitempty"` // slug kategori master
+	Type     string `json:"type,omitempty"`     // tipe business
+	City     string `json:"city,omitempty"`
+}
+
+// CatalogQRResponse QR code katalog hasil generate
+type CatalogQRResponse struct {
+	QRUrl     string `json:"qr_url"`
+	TargetURL string `json:"target_url"` // URL publik yang di-encode di QR
+}
+
+// PurgeCacheRequest request purge cache manual, tanpa section_id berarti seluruh katalog
+type PurgeCacheRequest struct {
+	SectionID int64 `json:"section_id,omitempty" validate:"omitempty,gt=0"`
+}
+
+// PurgeCacheResponse URL yang sudah di-purge
+type PurgeCacheResponse struct {
+	URLs []string `json:"urls"`
+}
+
 // CatalogFilter filter untuk query catalogs
 type CatalogFilter struct {
 	Search     string     `json:"search,omitempty"`
@@ -246,9 +727,25 @@ type PublicCatalogResponse struct {
 	Title      string                 `json:"title"`
 	Subtitle   string                 `json:"subtitle,omitempty"`
 	Settings   map[string]interface{} `json:"settings"`
+	Robots     string                 `json:"robots"` // isi meta robots, juga dikirim sebagai X-Robots-Tag
+	MetaTitle  string                 `json:"meta_title"`              // default title katalog
+	MetaDescription string            `json:"meta_description,omitempty"` // default subtitle katalog
+	OGImage    string                 `json:"og_image,omitempty"`      // default logo business
+	CanonicalURL string               `json:"canonical_url,omitempty"`
+	AnalyticsToken string             `json:"analytics_token,omitempty"` // dikirim balik bersama event analytics
+	Rating     RatingSummary          `json:"rating"`
 	Business   PublicBusinessInfo     `json:"business"`
 	Theme      ThemeResponse          `json:"theme"`
 	Sections   []PublicSectionResponse `json:"sections"`
+	Tags       []CardTagResponse       `json:"tags"`                 // tag yang dipakai card yang tampil, untuk filter
+	Tag        string                  `json:"tag,omitempty"`        // filter tag yang sedang aktif
+	SnapshotAt *time.Time            `json:"snapshot_at,omitempty"` // diisi jika dilayani dari snapshot saat database bermasalah
+}
+
+// RatingSummary agregat rating dari ulasan yang sudah disetujui
+type RatingSummary struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
 }
 
 // PublicBusinessInfo public business info
@@ -262,4 +759,56 @@ type PublicSectionResponse struct {
 	Type      string                 `json:"type"`
 	Config    map[string]interface{} `json:"config"`
 	Content   interface{}            `json:"content"`
-}
\ No newline at end of file
+}
+
+// PresenceResponse editor yang sedang membuka katalog
+type PresenceResponse struct {
+	ProfileID   int64     `json:"profile_id"`
+	DisplayName string    `json:"display_name"`
+	IsSelf      bool      `json:"is_self"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// MediaAccessLogRequest batch log akses media dari CDN
+type MediaAccessLogRequest struct {
+	Entries []MediaAccessEntry `json:"entries" validate:"required,min=1,max=5000,dive"`
+}
+
+// MediaAccessEntry satu akses media, URL boleh URL replika
+type MediaAccessEntry struct {
+	URL        string    `json:"url" validate:"required,url"`
+	AccessedAt time.Time `json:"accessed_at" validate:"required"`
+}
+
+// MediaAccessLogResponse hasil ingest log akses media
+type MediaAccessLogResponse struct {
+	Received int   `json:"received"`
+	Updated  int64 `json:"updated"`
+}
+
+// EmbedConfigResponse pengaturan widget embed katalog
+type EmbedConfigResponse struct {
+	Version      string      `json:"version"` // versi embed.js yang cocok dengan config ini
+	Slug         string      `json:"slug"`
+	Title        string      `json:"title"`
+	CatalogURL   string      `json:"catalog_url,omitempty"` // halaman katalog penuh
+	Colors       EmbedColors `json:"colors"`
+	FontFamily   string      `json:"font_family,omitempty"`
+	BorderRadius string      `json:"border_radius,omitempty"`
+	Layout       EmbedLayout `json:"layout"`
+}
+
+// EmbedColors warna widget dari settings katalog
+type EmbedColors struct {
+	Primary    string `json:"primary,omitempty"`
+	Secondary  string `json:"secondary,omitempty"`
+	Background string `json:"background,omitempty"`
+	Text       string `json:"text,omitempty"`
+}
+
+// EmbedLayout layout card default widget, section cards tetap memakai layout di config masing-masing
+type EmbedLayout struct {
+	Type             string `json:"type"`
+	Columns          int    `json:"columns"` // kolom desktop, widget menyesuaikan lebar container
+	ImageAspectRatio string `json:"image_aspect_ratio"`
+}
@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"time"
+)
+
+// ExportFormatVersion versi format JSON export katalog
+const ExportFormatVersion = 1
+
+// BusinessExport format JSON export seluruh katalog milik satu business
+type BusinessExport struct {
+	FormatVersion int             `json:"format_version"`
+	ExportedAt    time.Time       `json:"exported_at"`
+	BusinessID    int64           `json:"business_id"`
+	BusinessName  string          `json:"business_name"`
+	Catalogs      []CatalogExport `json:"catalogs"`
+}
+
+// CatalogExport satu katalog beserta kontennya
+type CatalogExport struct {
+	ID              int64                  `json:"id"`
+	ThemeID         int64                  `json:"theme_id"`
+	Slug            string                 `json:"slug"`
+	Title           string                 `json:"title"`
+	Subtitle        string                 `json:"subtitle,omitempty"`
+	IsActive        bool                   `json:"is_active"`
+	Status          string                 `json:"status"`
+	Settings        map[string]interface{} `json:"settings"`
+	MetaTitle       string                 `json:"meta_title,omitempty"`
+	MetaDescription string                 `json:"meta_description,omitempty"`
+	OGImage         string                 `json:"og_image,omitempty"`
+	AllowIndexing   *bool                  `json:"allow_indexing,omitempty"` // kosong di backup lama = true
+	Listed          bool                   `json:"listed,omitempty"`
+	CategoryID      int64                  `json:"category_id,omitempty"`
+	Sections        []SectionExport        `json:"sections"`
+}
+
+// IndexingAllowed nilai allow_indexing, backup sebelum opsi ini ada dianggap boleh diindex
+func (c *CatalogExport) IndexingAllowed() bool {
+	return c.AllowIndexing == nil || *c.AllowIndexing
+}
+
+// SectionExport section beserta konten sesuai tipe
+type SectionExport struct {
+	Type      string                 `json:"type"`
+	IsVisible bool                   `json:"is_visible"`
+	Config    map[string]interface{} `json:"config"`
+	Cards     []CardExport           `json:"cards,omitempty"`
+	FAQs      []FAQExport            `json:"faqs,omitempty"`
+	Legal     *LegalExport           `json:"legal,omitempty"`
+}
+
+// CardExport card beserta detail dan media
+type CardExport struct {
+	Title                   string            `json:"title"`
+	Subtitle                string            `json:"subtitle,omitempty"`
+	Type                    string            `json:"type"`
+	URL                     string            `json:"url,omitempty"`
+	IsVisible               bool              `json:"is_visible"`
+	Price                   *int64            `json:"price,omitempty"`
+	Discount                int               `json:"discount"`
+	Currency                string            `json:"currency"`
+	AffiliatePartnerID      string            `json:"affiliate_partner_id,omitempty"`
+	AffiliateCommissionRate *float64          `json:"affiliate_commission_rate,omitempty"`
+	Detail                  *CardDetailExport `json:"detail,omitempty"`
+	Media                   []CardMediaExport `json:"media,omitempty"`
+}
+
+// CardDetailExport halaman detail card
+type CardDetailExport struct {
+	Slug        string           `json:"slug"`
+	Description string           `json:"description,omitempty"`
+	IsVisible   bool             `json:"is_visible"`
+	Links       []CardLinkExport `json:"links,omitempty"`
+}
+
+// CardLinkExport link pada detail card
+type CardLinkExport struct {
+	Type      string   `json:"type"`
+	URL       string   `json:"url"`
+	UTM       *LinkUTM `json:"utm,omitempty"`
+	IsVisible bool     `json:"is_visible"`
+}
+
+// CardMediaExport media card
+type CardMediaExport struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// LegalExport versi legal terbaru section, riwayat versi tidak ikut diekspor
+type LegalExport struct {
+	Terms   string `json:"terms"`
+	Privacy string `json:"privacy"`
+}
+
+// FAQExport item FAQ
+type FAQExport struct {
+	Question  string `json:"question"`
+	Answer    string `json:"answer"`
+	IsVisible bool   `json:"is_visible"`
+}
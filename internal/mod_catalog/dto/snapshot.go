@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+)
+
+// SnapshotFormatVersion versi format file snapshot katalog publik
+const SnapshotFormatVersion = 1
+
+// PublicCatalogSnapshot isi file snapshot satu katalog publik
+type PublicCatalogSnapshot struct {
+	FormatVersion int                    `json:"format_version"`
+	GeneratedAt   time.Time              `json:"generated_at"`
+	Catalog       *PublicCatalogResponse `json:"catalog"`
+}
+
+// SnapshotIndex daftar slug pada snapshot terakhir, dipakai untuk membersihkan
+// file katalog yang sudah tidak publik
+type SnapshotIndex struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Slugs       []string  `json:"slugs"`
+}
@@ -3,7 +3,10 @@ package entity
 import (
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"time"
+
+	"github.com/atam/atamlink/pkg/utils"
 )
 
 // Catalog entity untuk tabel catalogs
@@ -17,10 +20,31 @@ type Catalog struct {
 	Subtitle   sql.NullString         `json:"subtitle" db:"c_subtitle"`
 	IsActive   bool                   `json:"is_active" db:"c_is_active"`
 	Settings   map[string]interface{} `json:"settings" db:"c_settings"`
+	MetaTitle  sql.NullString         `json:"meta_title" db:"c_meta_title"`
+	MetaDescription sql.NullString    `json:"meta_description" db:"c_meta_description"`
+	OGImage    sql.NullString         `json:"og_image" db:"c_og_image"`
+	AllowIndexing bool                `json:"allow_indexing" db:"c_allow_indexing"`
+	Listed     bool                   `json:"listed" db:"c_listed"`
+	CategoryID sql.NullInt64          `json:"category_id" db:"c_mc_id"` // kosong = ikut kategori business
+	RatingAvg  float64                `json:"rating_avg" db:"c_rating_avg"`     // rata-rata ulasan approved
+	RatingCount int                   `json:"rating_count" db:"c_rating_count"`
+	Status     string                 `json:"status" db:"c_status"`
+	PublishedAt *time.Time            `json:"published_at" db:"c_published_at"`
+	PublishedBy sql.NullInt64         `json:"published_by" db:"c_published_by"`
+	PublishScheduledAt *time.Time     `json:"publish_scheduled_at" db:"c_publish_scheduled_at"`
+	PublishScheduledBy sql.NullInt64  `json:"publish_scheduled_by" db:"c_publish_scheduled_by"`
+	PublishAt   *time.Time            `json:"publish_at" db:"c_publish_at"`     // jadwal c_is_active = true
+	UnpublishAt *time.Time            `json:"unpublish_at" db:"c_unpublish_at"` // jadwal c_is_active = false
+	VisibilityScheduledBy sql.NullInt64 `json:"visibility_scheduled_by" db:"c_visibility_scheduled_by"`
+	ArchivedAt *time.Time             `json:"archived_at" db:"c_archived_at"`
+	ArchiveKey sql.NullString         `json:"-" db:"c_archive_key"`
+	DeletedAt  *time.Time             `json:"deleted_at" db:"c_deleted_at"` // di trash, bisa dipulihkan
+	DeletedBy  sql.NullInt64          `json:"deleted_by" db:"c_deleted_by"`
 	CreatedBy  int64                  `json:"created_by" db:"c_created_by"`
 	CreatedAt  time.Time              `json:"created_at" db:"c_created_at"`
 	UpdatedBy  sql.NullInt64          `json:"updated_by" db:"c_updated_by"`
 	UpdatedAt  *time.Time             `json:"updated_at" db:"c_updated_at"`
+	Version    int                    `json:"version" db:"c_version"` // naik setiap update, untuk optimistic locking
 
 	// Relations
 	Business *Business         `json:"business,omitempty"`
@@ -28,6 +52,23 @@ type Catalog struct {
 	Sections []*CatalogSection `json:"sections,omitempty"`
 }
 
+// CatalogPublishRequest entity untuk tabel catalog_publish_requests
+type CatalogPublishRequest struct {
+	ID            int64          `json:"id" db:"cpr_id"`
+	CatalogID     int64          `json:"catalog_id" db:"cpr_c_id"`
+	Status        string         `json:"status" db:"cpr_status"`
+	Note          sql.NullString `json:"note" db:"cpr_note"`
+	ReviewComment sql.NullString `json:"review_comment" db:"cpr_review_comment"`
+	RequestedBy   int64          `json:"requested_by" db:"cpr_requested_by"`
+	RequestedAt   time.Time      `json:"requested_at" db:"cpr_requested_at"`
+	ReviewedBy    sql.NullInt64  `json:"reviewed_by" db:"cpr_reviewed_by"`
+	ReviewedAt    *time.Time     `json:"reviewed_at" db:"cpr_reviewed_at"`
+
+	// Display name pengaju dan reviewer (join user_profiles)
+	RequesterName sql.NullString `json:"-" db:"requester_name"`
+	ReviewerName  sql.NullString `json:"-" db:"reviewer_name"`
+}
+
 // CatalogSection entity untuk tabel catalog_sections
 type CatalogSection struct {
 	ID        int64                  `json:"id" db:"cs_id"`
@@ -35,13 +76,20 @@ type CatalogSection struct {
 	Type      string                 `json:"type" db:"cs_type"`
 	IsVisible bool                   `json:"is_visible" db:"cs_is_visible"`
 	Config    map[string]interface{} `json:"config" db:"cs_config"`
+	Position  int                    `json:"position" db:"cs_position"`
+	CreatedBy sql.NullInt64          `json:"created_by" db:"cs_created_by"`
 	CreatedAt time.Time              `json:"created_at" db:"cs_created_at"`
+	UpdatedBy sql.NullInt64          `json:"updated_by" db:"cs_updated_by"`
 	UpdatedAt *time.Time             `json:"updated_at" db:"cs_updated_at"`
 
+	// Display name editor terakhir (join user_profiles)
+	LastModifiedByName sql.NullString `json:"-" db:"up_display_name"`
+
 	// Relations - based on type
 	Cards        []*CatalogCard        `json:"cards,omitempty"`
 	Carousels    []*CatalogCarousel    `json:"carousels,omitempty"`
 	FAQs         []*CatalogFAQ         `json:"faqs,omitempty"`
+	Legal        *CatalogLegalVersion  `json:"legal,omitempty"`
 	Links        []*CatalogLink        `json:"links,omitempty"`
 	Socials      []*CatalogSocial      `json:"socials,omitempty"`
 	Testimonials []*CatalogTestimonial `json:"testimonials,omitempty"`
@@ -60,22 +108,53 @@ type CatalogCard struct {
 	Price      sql.NullInt64   `json:"price" db:"cc_price"`
 	Discount   int             `json:"discount" db:"cc_discount"`
 	Currency   string          `json:"currency" db:"cc_currency"`
+	AffiliatePartnerID      sql.NullString  `json:"affiliate_partner_id" db:"cc_affiliate_partner_id"`
+	AffiliateCommissionRate sql.NullFloat64 `json:"affiliate_commission_rate" db:"cc_affiliate_commission_rate"`
+	Position   int             `json:"position" db:"cc_position"`
+	PublishAt   *time.Time     `json:"publish_at" db:"cc_publish_at"`     // jadwal cc_is_visible = true
+	UnpublishAt *time.Time     `json:"unpublish_at" db:"cc_unpublish_at"` // jadwal cc_is_visible = false
+	VisibilityScheduledBy sql.NullInt64 `json:"visibility_scheduled_by" db:"cc_visibility_scheduled_by"`
+	Stock           sql.NullInt64 `json:"stock" db:"cc_stock"`                          // NULL = stok tidak dilacak
+	SoldOut         bool          `json:"sold_out" db:"cc_sold_out"`                    // tanda habis manual
+	HideWhenSoldOut bool          `json:"hide_when_sold_out" db:"cc_hide_when_sold_out"` // sembunyikan dari publik saat habis
 	CreatedBy  int64           `json:"created_by" db:"cc_created_by"`
 	CreatedAt  time.Time       `json:"created_at" db:"cc_created_at"`
 	UpdatedBy  sql.NullInt64   `json:"updated_by" db:"cc_updated_by"`
 	UpdatedAt  *time.Time      `json:"updated_at" db:"cc_updated_at"`
+	Version    int             `json:"version" db:"cc_version"` // naik setiap update, untuk optimistic locking
+
+	// Display name editor terakhir (join user_profiles)
+	LastModifiedByName sql.NullString `json:"-" db:"up_display_name"`
 
 	// Relations
 	Detail *CatalogCardDetail  `json:"detail,omitempty"`
 	Media  []*CatalogCardMedia `json:"media,omitempty"`
+	Tags   []*CatalogTag       `json:"tags,omitempty"`
+}
+
+// CatalogTag entity untuk tabel catalog_tags, tag produk per katalog
+type CatalogTag struct {
+	ID        int64         `json:"id" db:"ct_id"`
+	CatalogID int64         `json:"catalog_id" db:"ct_c_id"`
+	Name      string        `json:"name" db:"ct_name"`
+	Slug      string        `json:"slug" db:"ct_slug"`
+	CreatedBy int64         `json:"created_by" db:"ct_created_by"`
+	CreatedAt time.Time     `json:"created_at" db:"ct_created_at"`
+	UpdatedBy sql.NullInt64 `json:"updated_by" db:"ct_updated_by"`
+	UpdatedAt *time.Time    `json:"updated_at" db:"ct_updated_at"`
+
+	// Jumlah card yang memakai tag (hanya diisi saat list)
+	CardCount int `json:"card_count" db:"card_count"`
 }
 
 // CatalogCardDetail entity untuk tabel catalog_card_details
 type CatalogCardDetail struct {
 	ID          int64          `json:"id" db:"ccd_id"`
 	CardID      int64          `json:"card_id" db:"ccd_cc_id"`
+	CatalogID   int64          `json:"catalog_id" db:"ccd_c_id"` // slug unik per katalog
 	Slug        string         `json:"slug" db:"ccd_slug"`
-	Description sql.NullString `json:"description" db:"ccd_description"`
+	Description sql.NullString `json:"description" db:"ccd_description"` // Markdown
+	DescriptionHTML sql.NullString `json:"description_html" db:"ccd_description_html"`
 	IsVisible   bool           `json:"is_visible" db:"ccd_is_visible"`
 	CreatedBy   int64          `json:"created_by" db:"ccd_created_by"`
 	CreatedAt   time.Time      `json:"created_at" db:"ccd_created_at"`
@@ -86,6 +165,47 @@ type CatalogCardDetail struct {
 	Links []*CatalogCardLink `json:"links,omitempty"`
 }
 
+// CardPriceSchedule entity untuk tabel catalog_card_price_schedules
+type CardPriceSchedule struct {
+	ID          int64         `json:"id" db:"ccps_id"`
+	CardID      int64         `json:"card_id" db:"ccps_cc_id"`
+	Price       int64         `json:"price" db:"ccps_price"`
+	Discount    sql.NullInt64 `json:"discount" db:"ccps_discount"` // NULL = diskon tidak diubah
+	EffectiveAt time.Time     `json:"effective_at" db:"ccps_effective_at"`
+	Status      string        `json:"status" db:"ccps_status"`
+	CreatedBy   int64         `json:"created_by" db:"ccps_created_by"`
+	CreatedAt   time.Time     `json:"created_at" db:"ccps_created_at"`
+	AppliedAt   *time.Time    `json:"applied_at" db:"ccps_applied_at"`
+	CancelledBy sql.NullInt64 `json:"cancelled_by" db:"ccps_cancelled_by"`
+	CancelledAt *time.Time    `json:"cancelled_at" db:"ccps_cancelled_at"`
+
+	// Katalog pemilik card (join), dipakai scheduler
+	CatalogID  int64 `json:"-" db:"c_id"`
+	BusinessID int64 `json:"-" db:"c_b_id"`
+}
+
+// VisibilityDue katalog atau card yang jadwal tampil/sembunyinya sudah lewat, dipakai scheduler
+type VisibilityDue struct {
+	ID          int64         `db:"id"` // c_id atau cc_id
+	CatalogID   int64         `db:"c_id"`
+	BusinessID  int64         `db:"c_b_id"`
+	PublishAt   *time.Time    `db:"publish_at"`
+	UnpublishAt *time.Time    `db:"unpublish_at"`
+	ScheduledBy sql.NullInt64 `db:"scheduled_by"`
+}
+
+// DiscountEnding jadwal harga yang menurunkan diskon card yang sedang berlaku
+type DiscountEnding struct {
+	ScheduleID   int64     `db:"ccps_id"`
+	CardID       int64     `db:"cc_id"`
+	CardTitle    string    `db:"cc_title"`
+	CatalogTitle string    `db:"c_title"`
+	BusinessID   int64     `db:"c_b_id"`
+	Discount     int       `db:"cc_discount"` // diskon saat ini
+	NewDiscount  int       `db:"new_discount"`
+	EndsAt       time.Time `db:"ccps_effective_at"`
+}
+
 // CatalogCardMedia entity untuk tabel catalog_card_media
 type CatalogCardMedia struct {
 	ID        int64         `json:"id" db:"ccm_id"`
@@ -98,12 +218,26 @@ type CatalogCardMedia struct {
 	UpdatedAt *time.Time    `json:"updated_at" db:"ccm_updated_at"`
 }
 
+// CatalogMediaReplica entity untuk tabel catalog_media_replicas
+type CatalogMediaReplica struct {
+	ID         int64          `json:"id" db:"cmr_id"`
+	SourceURL  string         `json:"source_url" db:"cmr_source_url"`
+	ReplicaURL sql.NullString `json:"replica_url" db:"cmr_replica_url"`
+	Status     string         `json:"status" db:"cmr_status"`
+	Attempts   int            `json:"attempts" db:"cmr_attempts"`
+	Error      sql.NullString `json:"error" db:"cmr_error"`
+	CreatedAt  time.Time      `json:"created_at" db:"cmr_created_at"`
+	UpdatedAt  *time.Time     `json:"updated_at" db:"cmr_updated_at"`
+}
+
 // CatalogCardLink entity untuk tabel catalog_card_links
 type CatalogCardLink struct {
 	ID        int64         `json:"id" db:"ccl_id"`
 	DetailID  int64         `json:"detail_id" db:"ccl_ccd_id"`
 	Type      string        `json:"type" db:"ccl_type"`
 	URL       string        `json:"url" db:"ccl_url"`
+	Token     string        `json:"token" db:"ccl_token"` // token publik redirect /r/{token}
+	UTM       *utils.UTMOverride `json:"utm,omitempty" db:"ccl_utm"` // nil = ikut UTM katalog
 	IsVisible bool          `json:"is_visible" db:"ccl_is_visible"`
 	CreatedBy int64         `json:"created_by" db:"ccl_created_by"`
 	CreatedAt time.Time     `json:"created_at" db:"ccl_created_at"`
@@ -111,6 +245,14 @@ type CatalogCardLink struct {
 	UpdatedAt *time.Time    `json:"updated_at" db:"ccl_updated_at"`
 }
 
+// SitemapEntry satu URL katalog publik atau detail card untuk sitemap,
+// CardSlug kosong berarti halaman katalog
+type SitemapEntry struct {
+	CatalogSlug string    `db:"c_slug"`
+	CardSlug    string    `db:"card_slug"`
+	LastMod     time.Time `db:"lastmod"`
+}
+
 // CatalogCarousel entity untuk tabel catalog_carousels
 type CatalogCarousel struct {
 	ID        int64          `json:"id" db:"cr_id"`
@@ -145,20 +287,36 @@ type CatalogFAQ struct {
 	ID        int64         `json:"id" db:"cf_id"`
 	SectionID int64         `json:"section_id" db:"cf_cs_id"`
 	Question  string        `json:"question" db:"cf_question"`
-	Answer    string        `json:"answer" db:"cf_answer"`
+	Answer    string        `json:"answer" db:"cf_answer"` // Markdown
+	AnswerHTML sql.NullString `json:"answer_html" db:"cf_answer_html"`
 	IsVisible bool          `json:"is_visible" db:"cf_is_visible"`
+	DisplayOrder int        `json:"display_order" db:"cf_display_order"`
 	CreatedBy int64         `json:"created_by" db:"cf_created_by"`
 	CreatedAt time.Time     `json:"created_at" db:"cf_created_at"`
 	UpdatedBy sql.NullInt64 `json:"updated_by" db:"cf_updated_by"`
 	UpdatedAt *time.Time    `json:"updated_at" db:"cf_updated_at"`
 }
 
+// CatalogLegalVersion entity untuk tabel catalog_legal_versions, satu baris per versi
+type CatalogLegalVersion struct {
+	ID          int64          `json:"id" db:"clv_id"`
+	SectionID   int64          `json:"section_id" db:"clv_cs_id"`
+	Version     int            `json:"version" db:"clv_version"`
+	Terms       string         `json:"terms" db:"clv_terms"` // Markdown
+	TermsHTML   sql.NullString `json:"terms_html" db:"clv_terms_html"`
+	Privacy     string         `json:"privacy" db:"clv_privacy"` // Markdown
+	PrivacyHTML sql.NullString `json:"privacy_html" db:"clv_privacy_html"`
+	CreatedBy   int64          `json:"created_by" db:"clv_created_by"`
+	CreatedAt   time.Time      `json:"created_at" db:"clv_created_at"`
+}
+
 // CatalogLink entity untuk tabel catalog_links
 type CatalogLink struct {
 	ID          int64         `json:"id" db:"cl_id"`
 	SectionID   int64         `json:"section_id" db:"cl_cs_id"`
 	URL         string        `json:"url" db:"cl_url"`
 	DisplayName string        `json:"display_name" db:"cl_display_name"`
+	Token       string        `json:"token" db:"cl_token"` // token publik redirect /r/{token}
 	IsVisible   bool          `json:"is_visible" db:"cl_is_visible"`
 	CreatedBy   int64         `json:"created_by" db:"cl_created_by"`
 	CreatedAt   time.Time     `json:"created_at" db:"cl_created_at"`
@@ -192,6 +350,71 @@ type CatalogTestimonial struct {
 	UpdatedAt *time.Time    `json:"updated_at" db:"ct_updated_at"`
 }
 
+// CatalogAffiliateClick entity untuk tabel catalog_affiliate_clicks
+type CatalogAffiliateClick struct {
+	ID             int64     `json:"id" db:"cac_id"`
+	CardID         int64     `json:"card_id" db:"cac_cc_id"`
+	PartnerID      string    `json:"partner_id" db:"cac_partner_id"`
+	CommissionRate float64   `json:"commission_rate" db:"cac_commission_rate"`
+	Amount         int64     `json:"amount" db:"cac_amount"`
+	ClickedAt      time.Time `json:"clicked_at" db:"cac_clicked_at"`
+}
+
+// AffiliateEarning agregat estimasi komisi per card per bulan
+type AffiliateEarning struct {
+	CardID              int64     `json:"card_id" db:"cc_id"`
+	CardTitle           string    `json:"card_title" db:"cc_title"`
+	PartnerID           string    `json:"partner_id" db:"cac_partner_id"`
+	Month               time.Time `json:"month" db:"month"`
+	Clicks              int64     `json:"clicks" db:"clicks"`
+	EstimatedCommission float64   `json:"estimated_commission" db:"estimated_commission"`
+}
+
+// CatalogCheckoutLink entity untuk tabel catalog_checkout_links
+type CatalogCheckoutLink struct {
+	ID         int64          `json:"id" db:"ccl_id"`
+	CardID     int64          `json:"card_id" db:"ccl_cc_id"`
+	ExternalID string         `json:"external_id" db:"ccl_external_id"`
+	GatewayID  sql.NullString `json:"gateway_id" db:"ccl_gateway_id"`
+	Quantity   int            `json:"quantity" db:"ccl_quantity"`
+	Amount     int64          `json:"amount" db:"ccl_amount"`
+	Currency   string         `json:"currency" db:"ccl_currency"`
+	PaymentURL string         `json:"payment_url" db:"ccl_payment_url"`
+	Status     string         `json:"status" db:"ccl_status"`
+	ExpiresAt  *time.Time     `json:"expires_at" db:"ccl_expires_at"`
+	PaidAt     *time.Time     `json:"paid_at" db:"ccl_paid_at"`
+	LegalVersion sql.NullInt64 `json:"legal_version" db:"ccl_legal_version"`
+	CreatedBy  int64          `json:"created_by" db:"ccl_created_by"`
+	CreatedAt  time.Time      `json:"created_at" db:"ccl_created_at"`
+	UpdatedAt  *time.Time     `json:"updated_at" db:"ccl_updated_at"`
+}
+
+// SearchDocument dokumen katalog di search engine
+type SearchDocument struct {
+	ID         int64  `json:"id" db:"c_id"`
+	BusinessID int64  `json:"business_id" db:"c_b_id"`
+	Slug       string `json:"slug" db:"c_slug"`
+	Title      string `json:"title" db:"c_title"`
+	Subtitle   string `json:"subtitle" db:"c_subtitle"`
+	Content    string `json:"content" db:"content"` // judul & subjudul card serta pertanyaan FAQ
+	IsActive   bool   `json:"is_active" db:"c_is_active"`
+}
+
+// CatalogSearchHit katalog hasil pencarian dashboard beserta card yang judulnya cocok
+type CatalogSearchHit struct {
+	Catalog *Catalog
+	Rank    float64 `db:"rank"`
+	Cards   []*CatalogCard
+}
+
+// DirectoryEntry katalog di direktori publik beserta popularitasnya
+type DirectoryEntry struct {
+	Catalog      *Catalog
+	CategorySlug sql.NullString `db:"mc_slug"`
+	CategoryName sql.NullString `db:"mc_name"`
+	Views        int64          `db:"views"` // total view 30 hari terakhir
+}
+
 // Relations dari module lain
 // type Business struct {
 // 	ID   int64  `json:"id" db:"b_id"`
@@ -205,6 +428,7 @@ type Business struct {
 	LogoURL  sql.NullString `json:"logo_url" db:"b_logo_url"`
 	Slug     string `json:"slug" db:"b_slug"`
 	Type     string `json:"type" db:"b_type"`
+	City     sql.NullString `json:"city" db:"b_city"`
 	IsActive bool   `json:"is_active" db:"b_is_active"`
 }
 
@@ -227,6 +451,9 @@ func (CatalogFAQ) TableName() string            { return "atamlink.catalog_faqs"
 func (CatalogLink) TableName() string           { return "atamlink.catalog_links" }
 func (CatalogSocial) TableName() string         { return "atamlink.catalog_socials" }
 func (CatalogTestimonial) TableName() string    { return "atamlink.catalog_testimonials" }
+func (CatalogAffiliateClick) TableName() string { return "atamlink.catalog_affiliate_clicks" }
+func (CatalogCheckoutLink) TableName() string   { return "atamlink.catalog_checkout_links" }
+func (CatalogPublishRequest) TableName() string { return "atamlink.catalog_publish_requests" }
 
 // Helper methods
 
@@ -246,6 +473,51 @@ func (c *Catalog) GetQRUrl() string {
 	return ""
 }
 
+// SEOTitle judul untuk meta tag dan OpenGraph, default title katalog
+func (c *Catalog) SEOTitle() string {
+	if c.MetaTitle.Valid && c.MetaTitle.String != "" {
+		return c.MetaTitle.String
+	}
+	return c.Title
+}
+
+// SEODescription deskripsi untuk meta tag dan OpenGraph, default subtitle katalog
+func (c *Catalog) SEODescription() string {
+	if c.MetaDescription.Valid && c.MetaDescription.String != "" {
+		return c.MetaDescription.String
+	}
+	return c.GetSubtitle()
+}
+
+// SEOImage gambar OpenGraph/Twitter card, default logo business
+func (c *Catalog) SEOImage() string {
+	if c.OGImage.Valid && c.OGImage.String != "" {
+		return c.OGImage.String
+	}
+	if c.Business != nil && c.Business.LogoURL.Valid {
+		return c.Business.LogoURL.String
+	}
+	return ""
+}
+
+// IsPublished check apakah katalog sudah lolos review dan tampil publik
+func (c *Catalog) IsPublished() bool {
+	return c.Status == "published"
+}
+
+// IsArchived check apakah konten katalog sedang disimpan di cold storage
+func (c *Catalog) IsArchived() bool {
+	return c.ArchivedAt != nil
+}
+
+// RobotsDirective isi meta robots / X-Robots-Tag halaman publik
+func (c *Catalog) RobotsDirective() string {
+	if c.AllowIndexing {
+		return "index, follow"
+	}
+	return "noindex, nofollow"
+}
+
 // GetDiscountedPrice menghitung harga setelah diskon
 func (cc *CatalogCard) GetDiscountedPrice() int64 {
 	if !cc.Price.Valid || cc.Discount <= 0 {
@@ -256,6 +528,69 @@ func (cc *CatalogCard) GetDiscountedPrice() int64 {
 	return cc.Price.Int64 - discountAmount
 }
 
+// IsAffiliate check apakah card memiliki affiliate metadata
+func (cc *CatalogCard) IsAffiliate() bool {
+	return cc.AffiliatePartnerID.Valid && cc.AffiliatePartnerID.String != ""
+}
+
+// GetEffectivePrice harga yang dibayar visitor (setelah diskon jika ada)
+func (cc *CatalogCard) GetEffectivePrice() int64 {
+	if discounted := cc.GetDiscountedPrice(); discounted > 0 {
+		return discounted
+	}
+	return cc.Price.Int64
+}
+
+// IsSoldOut check apakah card habis: ditandai manual atau stok yang dilacak sudah 0
+func (cc *CatalogCard) IsSoldOut() bool {
+	return cc.SoldOut || (cc.Stock.Valid && cc.Stock.Int64 <= 0)
+}
+
+// IsPubliclyVisible check apakah card tampil ke publik, card habis
+// disembunyikan jika diatur begitu oleh pemilik
+func (cc *CatalogCard) IsPubliclyVisible() bool {
+	return cc.IsVisible && !(cc.HideWhenSoldOut && cc.IsSoldOut())
+}
+
+// HasTag check apakah card memiliki tag dengan ID tersebut
+func (cc *CatalogCard) HasTag(tagID int64) bool {
+	for _, tag := range cc.Tags {
+		if tag.ID == tagID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeleted check apakah katalog ada di trash
+func (c *Catalog) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
+
+// GetLastModifiedAt waktu perubahan terakhir, fallback ke waktu dibuat
+func (c *Catalog) GetLastModifiedAt() time.Time {
+	if c.UpdatedAt != nil {
+		return *c.UpdatedAt
+	}
+	return c.CreatedAt
+}
+
+// GetLastModifiedAt waktu perubahan terakhir, fallback ke waktu dibuat
+func (cc *CatalogCard) GetLastModifiedAt() time.Time {
+	if cc.UpdatedAt != nil {
+		return *cc.UpdatedAt
+	}
+	return cc.CreatedAt
+}
+
+// GetLastModifiedAt waktu perubahan terakhir, fallback ke waktu dibuat
+func (cs *CatalogSection) GetLastModifiedAt() time.Time {
+	if cs.UpdatedAt != nil {
+		return *cs.UpdatedAt
+	}
+	return cs.CreatedAt
+}
+
 // MarshalSettings marshal settings to JSON
 func (c *Catalog) MarshalSettings() ([]byte, error) {
 	return json.Marshal(c.Settings)
@@ -264,4 +599,108 @@ func (c *Catalog) MarshalSettings() ([]byte, error) {
 // UnmarshalSettings unmarshal settings from JSON
 func (c *Catalog) UnmarshalSettings(data []byte) error {
 	return json.Unmarshal(data, &c.Settings)
-}
\ No newline at end of file
+}
+
+// UTMParams UTM untuk URL keluar dari settings utm_source, utm_medium dan utm_campaign
+func (c *Catalog) UTMParams() utils.UTMParams {
+	setting := func(key string) string {
+		value, _ := c.Settings[key].(string)
+		return strings.TrimSpace(value)
+	}
+
+	return utils.UTMParams{
+		Source:   setting("utm_source"),
+		Medium:   setting("utm_medium"),
+		Campaign: setting("utm_campaign"),
+	}
+}
+
+// MarshalUTM marshal override UTM link, NULL jika tidak ada override
+func (l *CatalogCardLink) MarshalUTM() ([]byte, error) {
+	if l.UTM == nil {
+		return nil, nil
+	}
+	return json.Marshal(l.UTM)
+}
+
+// UnmarshalUTM unmarshal override UTM link dari JSON
+func (l *CatalogCardLink) UnmarshalUTM(data []byte) error {
+	if len(data) == 0 {
+		l.UTM = nil
+		return nil
+	}
+	l.UTM = &utils.UTMOverride{}
+	return json.Unmarshal(data, l.UTM)
+}
+
+// CardLayout opsi tampilan section cards di config.layout, field kosong ikut default tema
+type CardLayout struct {
+	Type             string            `json:"type,omitempty"` // grid, list, carousel
+	Columns          CardLayoutColumns `json:"columns"`
+	ImageAspectRatio string            `json:"image_aspect_ratio,omitempty"`
+}
+
+// CardLayoutColumns jumlah kolom per breakpoint
+type CardLayoutColumns struct {
+	Mobile  int `json:"mobile,omitempty"`
+	Tablet  int `json:"tablet,omitempty"`
+	Desktop int `json:"desktop,omitempty"`
+}
+
+// themeCardLayouts default layout section cards per tipe tema
+var themeCardLayouts = map[string]CardLayout{
+	"minimal":      {Type: "list", Columns: CardLayoutColumns{1, 1, 1}, ImageAspectRatio: "1:1"},
+	"modern":       {Type: "grid", Columns: CardLayoutColumns{2, 3, 4}, ImageAspectRatio: "1:1"},
+	"classic":      {Type: "grid", Columns: CardLayoutColumns{1, 2, 3}, ImageAspectRatio: "4:3"},
+	"bold":         {Type: "carousel", Columns: CardLayoutColumns{1, 2, 3}, ImageAspectRatio: "16:9"},
+	"elegant":      {Type: "grid", Columns: CardLayoutColumns{1, 2, 3}, ImageAspectRatio: "3:4"},
+	"playful":      {Type: "grid", Columns: CardLayoutColumns{2, 3, 4}, ImageAspectRatio: "1:1"},
+	"professional": {Type: "list", Columns: CardLayoutColumns{1, 2, 2}, ImageAspectRatio: "16:9"},
+	"creative":     {Type: "carousel", Columns: CardLayoutColumns{1, 2, 4}, ImageAspectRatio: "4:3"},
+}
+
+// DefaultCardLayout default layout section cards untuk tipe tema, tema tidak dikenal memakai grid
+func DefaultCardLayout(themeType string) CardLayout {
+	if layout, ok := themeCardLayouts[themeType]; ok {
+		return layout
+	}
+	return CardLayout{Type: "grid", Columns: CardLayoutColumns{2, 3, 4}, ImageAspectRatio: "1:1"}
+}
+
+// CardLayoutFromConfig baca config.layout section, ok false jika tidak diisi
+func CardLayoutFromConfig(config map[string]interface{}) (CardLayout, bool, error) {
+	var layout CardLayout
+	raw, ok := config["layout"]
+	if !ok || raw == nil {
+		return layout, false, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return layout, true, err
+	}
+	if err := json.Unmarshal(data, &layout); err != nil {
+		return layout, true, err
+	}
+	return layout, true, nil
+}
+
+// WithDefaults isi field layout yang kosong dengan default
+func (l CardLayout) WithDefaults(def CardLayout) CardLayout {
+	if l.Type == "" {
+		l.Type = def.Type
+	}
+	if l.Columns.Mobile == 0 {
+		l.Columns.Mobile = def.Columns.Mobile
+	}
+	if l.Columns.Tablet == 0 {
+		l.Columns.Tablet = def.Columns.Tablet
+	}
+	if l.Columns.Desktop == 0 {
+		l.Columns.Desktop = def.Columns.Desktop
+	}
+	if l.ImageAspectRatio == "" {
+		l.ImageAspectRatio = def.ImageAspectRatio
+	}
+	return l
+}
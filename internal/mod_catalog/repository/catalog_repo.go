@@ -1,60 +1,191 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/atam/atamlink/internal/constant"
 	"github.com/atam/atamlink/internal/mod_catalog/entity"
 	"github.com/atam/atamlink/pkg/database"
 	"github.com/atam/atamlink/pkg/errors"
+	"github.com/lib/pq"
 )
 
 // CatalogRepository interface untuk catalog repository
 type CatalogRepository interface {
 	// Catalog methods
-	Create(tx *sql.Tx, catalog *entity.Catalog) error
-	GetByID(id int64) (*entity.Catalog, error)
-	GetBySlug(slug string) (*entity.Catalog, error)
-	List(filter ListFilter) ([]*entity.Catalog, int64, error)
-	Update(tx *sql.Tx, catalog *entity.Catalog) error
-	Delete(tx *sql.Tx, id int64) error
-	IsSlugExists(slug string) (bool, error)
+	Create(ctx context.Context, tx *sql.Tx, catalog *entity.Catalog) error
+	GetByID(ctx context.Context, id int64) (*entity.Catalog, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.Catalog, error)
+	GetBySlugs(ctx context.Context, slugs []string) ([]*entity.Catalog, error)
+	StreamSitemapEntries(ctx context.Context, businessID int64, limit int, fn func(entry *entity.SitemapEntry) error) error
+	List(ctx context.Context, filter ListFilter) ([]*entity.Catalog, int64, error)
+	Update(ctx context.Context, tx *sql.Tx, catalog *entity.Catalog) error
+	Delete(ctx context.Context, tx *sql.Tx, id int64, profileID int64) error
+	Restore(ctx context.Context, tx *sql.Tx, id int64, profileID int64) error
+	HardDelete(ctx context.Context, tx *sql.Tx, id int64) error
+	IsSlugExists(ctx context.Context, slug string) (bool, error)
+	CountByBusiness(ctx context.Context, businessID int64) (int, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, id int64, status string, profileID int64) error
+	SetPublishSchedule(ctx context.Context, tx *sql.Tx, id int64, publishAt *time.Time, profileID int64) error
+	ListDueScheduledPublish(ctx context.Context, now time.Time, limit int) ([]*entity.Catalog, error)
+	PublishScheduled(ctx context.Context, tx *sql.Tx, id int64, now time.Time) (bool, error)
+	SetCatalogVisibilitySchedule(ctx context.Context, tx *sql.Tx, id int64, publishAt, unpublishAt *time.Time, profileID int64) error
+	ListDueCatalogVisibility(ctx context.Context, now time.Time, limit int) ([]*entity.VisibilityDue, error)
+	ApplyCatalogVisibility(ctx context.Context, tx *sql.Tx, id int64, now time.Time) (bool, bool, error)
+	ListAbandonedDrafts(ctx context.Context, before time.Time, limit int) ([]*entity.Catalog, error)
+	MarkDraftReminded(ctx context.Context, id int64, now time.Time) (bool, error)
+	UpdateQRURL(ctx context.Context, id int64, qrURL string) error
+
+	// Archive methods
+	ListInactiveCatalogs(ctx context.Context, before time.Time, limit int) ([]int64, error)
+	MarkArchived(ctx context.Context, tx *sql.Tx, id int64, key string, now time.Time) (bool, error)
+	ClearArchived(ctx context.Context, tx *sql.Tx, id int64) (bool, error)
+
+	// Snapshot methods
+	ListPublicSlugs(ctx context.Context) ([]string, error)
+	GetPublicVersion(ctx context.Context, catalogID int64) (string, error)
+
+	// Search index methods
+	GetSearchDocuments(ctx context.Context, ids []int64) ([]*entity.SearchDocument, error)
+	ListIDsAfter(ctx context.Context, afterID int64, limit int) ([]int64, error)
+	SearchDashboard(ctx context.Context, filter DashboardSearchFilter) ([]*entity.CatalogSearchHit, int64, error)
+
+	// Directory methods
+	ListDirectory(ctx context.Context, filter DirectoryFilter) ([]*entity.DirectoryEntry, int64, error)
+	IsCategoryActive(ctx context.Context, categoryID int64) (bool, error)
+
+	// Brand methods
+	GetThemeDefaultSettings(ctx context.Context, themeID int64) (map[string]interface{}, error)
+	ApplySettingsToBusiness(ctx context.Context, tx *sql.Tx, businessID int64, settings map[string]interface{}, profileID int64) ([]string, error)
+	
+	// Publish request methods
+	CreatePublishRequest(ctx context.Context, tx *sql.Tx, request *entity.CatalogPublishRequest) error
+	GetPublishRequestByID(ctx context.Context, id int64) (*entity.CatalogPublishRequest, error)
+	ListPublishRequests(ctx context.Context, catalogID int64) ([]*entity.CatalogPublishRequest, error)
+	ReviewPublishRequest(ctx context.Context, tx *sql.Tx, id int64, status, comment string, reviewerID int64) error
 	
 	// Section methods
-	CreateSection(tx *sql.Tx, section *entity.CatalogSection) error
-	GetSectionsByCatalogID(catalogID int64) ([]*entity.CatalogSection, error)
-	GetSectionByID(id int64) (*entity.CatalogSection, error)
-	UpdateSection(tx *sql.Tx, section *entity.CatalogSection) error
-	DeleteSection(tx *sql.Tx, id int64) error
+	CreateSection(ctx context.Context, tx *sql.Tx, section *entity.CatalogSection) error
+	GetSectionsByCatalogID(ctx context.Context, catalogID int64) ([]*entity.CatalogSection, error)
+	GetSectionByID(ctx context.Context, id int64) (*entity.CatalogSection, error)
+	UpdateSection(ctx context.Context, tx *sql.Tx, section *entity.CatalogSection) error
+	DeleteSection(ctx context.Context, tx *sql.Tx, id int64) error
+	CountSections(ctx context.Context, catalogID int64) (int, error)
+	UpdateSectionPosition(ctx context.Context, tx *sql.Tx, id int64, position int, profileID int64) error
+	RebalanceSectionPositions(ctx context.Context, tx *sql.Tx, catalogID, excludeID int64) error
 	
 	// Card methods
-	CreateCard(tx *sql.Tx, card *entity.CatalogCard) error
-	GetCardsBySectionID(sectionID int64) ([]*entity.CatalogCard, error)
-	GetCardByID(id int64) (*entity.CatalogCard, error)
-	UpdateCard(tx *sql.Tx, card *entity.CatalogCard) error
-	DeleteCard(tx *sql.Tx, id int64) error
+	CreateCard(ctx context.Context, tx *sql.Tx, card *entity.CatalogCard) error
+	GetCardsBySectionID(ctx context.Context, sectionID int64) ([]*entity.CatalogCard, error)
+	GetCardsBySectionIDs(ctx context.Context, sectionIDs []int64) (map[int64][]*entity.CatalogCard, error)
+	GetCardsWithRelationsBySectionIDs(ctx context.Context, sectionIDs []int64) (map[int64][]*entity.CatalogCard, error)
+	GetCardsWithRelationsByIDs(ctx context.Context, ids []int64) (map[int64]*entity.CatalogCard, error)
+	SearchPublicCards(ctx context.Context, catalogID int64, search string, limit, offset int) ([]int64, int64, error)
+	GetCardByID(ctx context.Context, id int64) (*entity.CatalogCard, error)
+	UpdateCard(ctx context.Context, tx *sql.Tx, card *entity.CatalogCard) error
+	DeleteCard(ctx context.Context, tx *sql.Tx, id int64) error
+	CountCards(ctx context.Context, sectionID int64) (int, error)
+	CountCardsByBusiness(ctx context.Context, businessID int64) (int, error)
+	CountCardsByCatalog(ctx context.Context, catalogID int64) (int, error)
+	UpdateCardPosition(ctx context.Context, tx *sql.Tx, id int64, position int, profileID int64) error
+	RebalanceCardPositions(ctx context.Context, tx *sql.Tx, sectionID, excludeID int64) error
+
+	// Affiliate methods
+	CreateAffiliateClick(ctx context.Context, tx *sql.Tx, click *entity.CatalogAffiliateClick) error
+	GetAffiliateEarnings(ctx context.Context, catalogID int64, from, to time.Time) ([]*entity.AffiliateEarning, error)
+
+	// Checkout link methods
+	CreateCheckoutLink(ctx context.Context, tx *sql.Tx, link *entity.CatalogCheckoutLink) error
+	GetCheckoutLinkByExternalID(ctx context.Context, externalID string) (*entity.CatalogCheckoutLink, error)
+	UpdateCheckoutLinkStatus(ctx context.Context, tx *sql.Tx, id int64, status string, paidAt *time.Time) error
+
+	// Card price schedule methods
+	CreatePriceSchedule(ctx context.Context, tx *sql.Tx, schedule *entity.CardPriceSchedule) error
+	GetPriceScheduleByID(ctx context.Context, id int64) (*entity.CardPriceSchedule, error)
+	ListPriceSchedules(ctx context.Context, cardID int64) ([]*entity.CardPriceSchedule, error)
+	CancelPriceSchedule(ctx context.Context, tx *sql.Tx, id int64, profileID int64) (bool, error)
+	ListDuePriceSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.CardPriceSchedule, error)
+	ApplyPriceSchedule(ctx context.Context, tx *sql.Tx, schedule *entity.CardPriceSchedule, now time.Time) (bool, error)
+	ListEndingDiscounts(ctx context.Context, now, before time.Time, limit int) ([]*entity.DiscountEnding, error)
+	MarkDiscountAlerted(ctx context.Context, scheduleID int64, now time.Time) (bool, error)
+
+	// Card visibility schedule methods
+	SetCardVisibilitySchedule(ctx context.Context, tx *sql.Tx, id int64, publishAt, unpublishAt *time.Time, profileID int64) error
+	ListDueCardVisibility(ctx context.Context, now time.Time, limit int) ([]*entity.VisibilityDue, error)
+	ApplyCardVisibility(ctx context.Context, tx *sql.Tx, id int64, now time.Time) (bool, bool, error)
+
+	// Card stock methods
+	UpdateCardStock(ctx context.Context, tx *sql.Tx, id int64, stock sql.NullInt64, soldOut, hideWhenSoldOut bool, profileID int64) error
+	AdjustCardStock(ctx context.Context, tx *sql.Tx, id int64, delta int64, profileID int64) (int64, error)
+
+	// Tag methods
+	CreateTag(ctx context.Context, tx *sql.Tx, tag *entity.CatalogTag) error
+	GetTagByID(ctx context.Context, id int64) (*entity.CatalogTag, error)
+	GetTagBySlug(ctx context.Context, catalogID int64, slug string) (*entity.CatalogTag, error)
+	GetTagsByCatalogID(ctx context.Context, catalogID int64) ([]*entity.CatalogTag, error)
+	GetTagsByIDs(ctx context.Context, catalogID int64, ids []int64) ([]*entity.CatalogTag, error)
+	GetTagsByCardIDs(ctx context.Context, cardIDs []int64) (map[int64][]*entity.CatalogTag, error)
+	IsTagSlugTaken(ctx context.Context, catalogID int64, slug string, excludeTagID int64) (bool, error)
+	CountTags(ctx context.Context, catalogID int64) (int, error)
+	UpdateTag(ctx context.Context, tx *sql.Tx, tag *entity.CatalogTag) error
+	DeleteTag(ctx context.Context, tx *sql.Tx, id int64) error
+	SetCardTags(ctx context.Context, tx *sql.Tx, cardID int64, tagIDs []int64) error
 	
 	// Card detail methods
-	CreateCardDetail(tx *sql.Tx, detail *entity.CatalogCardDetail) error
-	GetCardDetailByCardID(cardID int64) (*entity.CatalogCardDetail, error)
-	UpdateCardDetail(tx *sql.Tx, detail *entity.CatalogCardDetail) error
+	CreateCardDetail(ctx context.Context, tx *sql.Tx, detail *entity.CatalogCardDetail) error
+	GetCardDetailByCardID(ctx context.Context, cardID int64) (*entity.CatalogCardDetail, error)
+	UpdateCardDetail(ctx context.Context, tx *sql.Tx, detail *entity.CatalogCardDetail) error
+	IsCardSlugTaken(ctx context.Context, catalogID int64, slug string, excludeCardID int64) (bool, error)
+	GetCardDetailBySlug(ctx context.Context, catalogID int64, slug string) (*entity.CatalogCardDetail, error)
+	GetCardSlugRedirect(ctx context.Context, catalogID int64, slug string) (string, error)
+	AddCardSlugHistory(ctx context.Context, tx *sql.Tx, catalogID, cardID int64, slug string) error
+	DeleteCardSlugHistory(ctx context.Context, tx *sql.Tx, catalogID int64, slug string) error
+	ListCardSlugs(ctx context.Context, catalogID, sectionID int64) ([]string, error)
+
+	// Card link methods
+	CreateCardLink(ctx context.Context, tx *sql.Tx, link *entity.CatalogCardLink) error
+	GetCardLinkByID(ctx context.Context, id int64) (*entity.CatalogCardLink, error)
+	GetCardLinksByDetailID(ctx context.Context, detailID int64) ([]*entity.CatalogCardLink, error)
+	UpdateCardLink(ctx context.Context, tx *sql.Tx, link *entity.CatalogCardLink) error
+	DeleteCardLink(ctx context.Context, tx *sql.Tx, id int64) error
+	DeleteCardLinksByDetailID(ctx context.Context, tx *sql.Tx, detailID int64) error
 	
 	// Card media methods
-	CreateCardMedia(tx *sql.Tx, media *entity.CatalogCardMedia) error
-	GetCardMediaByCardID(cardID int64) ([]*entity.CatalogCardMedia, error)
-	DeleteCardMedia(tx *sql.Tx, id int64) error
+	CreateCardMedia(ctx context.Context, tx *sql.Tx, media *entity.CatalogCardMedia) error
+	GetCardMediaByCardID(ctx context.Context, cardID int64) ([]*entity.CatalogCardMedia, error)
+	GetMediaByCardIDs(ctx context.Context, cardIDs []int64) (map[int64][]*entity.CatalogCardMedia, error)
+	DeleteCardMedia(ctx context.Context, tx *sql.Tx, id int64) error
+
+	// Media replication methods
+	ListMediaPendingReplication(ctx context.Context, limit, maxAttempts int) ([]string, error)
+	SaveMediaReplica(ctx context.Context, replica *entity.CatalogMediaReplica) error
+	GetMediaReplicaURLs(ctx context.Context, sourceURLs []string) (map[string]string, error)
+
+	// Media lifecycle methods
+	TouchMediaAccess(ctx context.Context, accessed map[string]time.Time) (int64, error)
+	ListColdMedia(ctx context.Context, before time.Time, limit, maxAttempts int) ([]string, error)
+	MarkMediaArchived(ctx context.Context, sourceURL, archiveURL string) ([]string, error)
+	MarkMediaArchiveFailed(ctx context.Context, sourceURL string) error
 	
 	// Section content methods (FAQs, Links, etc)
-	CreateFAQ(tx *sql.Tx, faq *entity.CatalogFAQ) error
-	GetFAQsBySectionID(sectionID int64) ([]*entity.CatalogFAQ, error)
-	UpdateFAQ(tx *sql.Tx, faq *entity.CatalogFAQ) error
-	DeleteFAQ(tx *sql.Tx, id int64) error
+	CreateFAQ(ctx context.Context, tx *sql.Tx, faq *entity.CatalogFAQ) error
+	GetFAQsBySectionID(ctx context.Context, sectionID int64) ([]*entity.CatalogFAQ, error)
+	GetFAQByID(ctx context.Context, id int64) (*entity.CatalogFAQ, error)
+	UpdateFAQ(ctx context.Context, tx *sql.Tx, faq *entity.CatalogFAQ) error
+	DeleteFAQ(ctx context.Context, tx *sql.Tx, id int64) error
+	DeleteFAQsExcept(ctx context.Context, tx *sql.Tx, sectionID int64, keepIDs []int64) error
+	CreateLegalVersion(ctx context.Context, tx *sql.Tx, legal *entity.CatalogLegalVersion) error
+	GetCurrentLegalVersion(ctx context.Context, sectionID int64) (*entity.CatalogLegalVersion, error)
+	GetLegalVersionsBySectionID(ctx context.Context, sectionID int64) ([]*entity.CatalogLegalVersion, error)
 }
 
 type catalogRepository struct {
-	db *sql.DB
+	db  *sql.DB
 }
 
 // NewCatalogRepository membuat instance catalog repository baru
@@ -65,16 +196,40 @@ func NewCatalogRepository(db *sql.DB) CatalogRepository {
 // ListFilter filter untuk list catalogs
 type ListFilter struct {
 	Search     string
-	BusinessID int64
-	ThemeID    int64
-	IsActive   *bool
-	Limit      int
-	Offset     int
-	OrderBy    string
+	IDs        []int64 // hasil search engine, nil = tidak dibatasi
+	BusinessID  int64
+	BusinessIDs []int64 // business milik user, nil = tidak dibatasi, kosong = tidak ada hasil
+	ThemeID     int64
+	IsActive    *bool
+	Limit       int
+	Offset      int
+	OrderBy     string
+	Keyset      *database.Keyset // cursor pagination, menggantikan Offset/OrderBy dan total tidak dihitung
+	Deleted     bool             // true = hanya katalog di trash, false = trash tidak ikut
+}
+
+// DashboardSearchFilter filter pencarian katalog dan card di dashboard
+type DashboardSearchFilter struct {
+	BusinessIDs  []int64 // business milik user, kosong = tidak ada hasil
+	Search       string
+	CardsPerItem int // card cocok yang dikembalikan per katalog
+	Limit        int
+	Offset       int
+}
+
+// DirectoryFilter filter direktori katalog publik
+type DirectoryFilter struct {
+	Search   string
+	Category string // slug kategori master
+	Type     string // tipe business
+	City     string
+	Limit    int
+	Offset   int
+	OrderBy  string
 }
 
 // Create membuat catalog baru
-func (r *catalogRepository) Create(tx *sql.Tx, catalog *entity.Catalog) error {
+func (r *catalogRepository) Create(ctx context.Context, tx *sql.Tx, catalog *entity.Catalog) error {
 	settingsJSON, err := json.Marshal(catalog.Settings)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal settings")
@@ -83,11 +238,12 @@ func (r *catalogRepository) Create(tx *sql.Tx, catalog *entity.Catalog) error {
 	query := `
 		INSERT INTO atamlink.catalogs (
 			c_b_id, c_mt_id, c_slug, c_title, c_subtitle,
-			c_is_active, c_settings, c_created_by, c_created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			c_is_active, c_settings, c_meta_title, c_meta_description, c_og_image,
+			c_allow_indexing, c_listed, c_mc_id, c_status, c_created_by, c_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING c_id`
 
-	err = tx.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		query,
 		catalog.BusinessID,
 		catalog.ThemeID,
@@ -96,6 +252,13 @@ func (r *catalogRepository) Create(tx *sql.Tx, catalog *entity.Catalog) error {
 		catalog.Subtitle,
 		catalog.IsActive,
 		settingsJSON,
+		catalog.MetaTitle,
+		catalog.MetaDescription,
+		catalog.OGImage,
+		catalog.AllowIndexing,
+		catalog.Listed,
+		catalog.CategoryID,
+		catalog.Status,
 		catalog.CreatedBy,
 		catalog.CreatedAt,
 	).Scan(&catalog.ID)
@@ -108,12 +271,17 @@ func (r *catalogRepository) Create(tx *sql.Tx, catalog *entity.Catalog) error {
 }
 
 // GetByID mendapatkan catalog by ID
-func (r *catalogRepository) GetByID(id int64) (*entity.Catalog, error) {
+func (r *catalogRepository) GetByID(ctx context.Context, id int64) (*entity.Catalog, error) {
 	query := `
 		SELECT 
 			c.c_id, c.c_b_id, c.c_mt_id, c.c_slug, c.c_qr_url,
-			c.c_title, c.c_subtitle, c.c_is_active, c.c_settings,
-			c.c_created_by, c.c_created_at, c.c_updated_by, c.c_updated_at,
+			c.c_title, c.c_subtitle, c.c_is_active, c.c_settings, c.c_allow_indexing, c.c_listed, c.c_mc_id,
+			c.c_meta_title, c.c_meta_description, c.c_og_image,
+			c.c_status, c.c_published_at, c.c_published_by,
+			c.c_publish_scheduled_at, c.c_publish_scheduled_by,
+			c.c_publish_at, c.c_unpublish_at,
+			c.c_archived_at, c.c_archive_key, c.c_deleted_at, c.c_deleted_by,
+			c.c_created_by, c.c_created_at, c.c_updated_by, c.c_updated_at, c.c_version,
 			b.b_id, b.b_name, b.b_logo_url, b.b_slug,
 			mt.mt_id, mt.mt_name, mt.mt_type
 		FROM atamlink.catalogs c
@@ -121,35 +289,7 @@ func (r *catalogRepository) GetByID(id int64) (*entity.Catalog, error) {
 		INNER JOIN atamlink.master_themes mt ON mt.mt_id = c.c_mt_id
 		WHERE c.c_id = $1`
 
-	catalog := &entity.Catalog{
-		Business: &entity.Business{},
-		Theme:    &entity.MasterTheme{},
-	}
-
-	var settingsJSON []byte
-	err := r.db.QueryRow(query, id).Scan(
-		&catalog.ID,
-		&catalog.BusinessID,
-		&catalog.ThemeID,
-		&catalog.Slug,
-		&catalog.QRUrl,
-		&catalog.Title,
-		&catalog.Subtitle,
-		&catalog.IsActive,
-		&settingsJSON,
-		&catalog.CreatedBy,
-		&catalog.CreatedAt,
-		&catalog.UpdatedBy,
-		&catalog.UpdatedAt,
-		&catalog.Business.ID,
-		&catalog.Business.Name,
-		&catalog.Business.LogoURL,
-		&catalog.Business.Slug,
-		&catalog.Theme.ID,
-		&catalog.Theme.Name,
-		&catalog.Theme.Type,
-	)
-
+	catalog, err := database.Get[entity.Catalog](ctx, r.db, query, id)
 	if err == sql.ErrNoRows {
 		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
 	}
@@ -157,20 +297,18 @@ func (r *catalogRepository) GetByID(id int64) (*entity.Catalog, error) {
 		return nil, errors.Wrap(err, "failed to get catalog")
 	}
 
-	// Parse settings
-	if err := json.Unmarshal(settingsJSON, &catalog.Settings); err != nil {
-		return nil, errors.Wrap(err, "failed to parse settings")
-	}
-
 	return catalog, nil
 }
 
 // GetBySlug mendapatkan catalog by slug
-func (r *catalogRepository) GetBySlug(slug string) (*entity.Catalog, error) {
+func (r *catalogRepository) GetBySlug(ctx context.Context, slug string) (*entity.Catalog, error) {
 	query := `
 		SELECT 
-			c.c_id, c.c_b_id, c.c_mt_id, c.c_slug, c.c_qr_url,
-			c.c_title, c.c_subtitle, c.c_is_active, c.c_settings,
+			c.c_id, c.c_b_id, c.c_mt_id, c.c_slug, b.b_type, b.b_is_active, c.c_qr_url,
+			c.c_title, c.c_subtitle, c.c_is_active, c.c_settings, c.c_allow_indexing,
+			c.c_meta_title, c.c_meta_description, c.c_og_image,
+			c.c_rating_avg, c.c_rating_count,
+			c.c_status, c.c_published_at, c.c_published_by, c.c_archived_at,
 			c.c_created_by, c.c_created_at, c.c_updated_by, c.c_updated_at,
 			b.b_id, b.b_name, b.b_logo_url, b.b_slug,
 			mt.mt_id, mt.mt_name, mt.mt_type
@@ -179,37 +317,7 @@ func (r *catalogRepository) GetBySlug(slug string) (*entity.Catalog, error) {
 		INNER JOIN atamlink.master_themes mt ON mt.mt_id = c.c_mt_id
 		WHERE c.c_slug = $1`
 
-	catalog := &entity.Catalog{
-		Business: &entity.Business{},
-		Theme:    &entity.MasterTheme{},
-	}
-
-	var settingsJSON []byte
-	err := r.db.QueryRow(query, slug).Scan(
-		&catalog.ID,
-		&catalog.BusinessID,
-		&catalog.ThemeID,
-		&catalog.Slug,
-		&catalog.Business.Type,
-    	&catalog.Business.IsActive,
-		&catalog.QRUrl,
-		&catalog.Title,
-		&catalog.Subtitle,
-		&catalog.IsActive,
-		&settingsJSON,
-		&catalog.CreatedBy,
-		&catalog.CreatedAt,
-		&catalog.UpdatedBy,
-		&catalog.UpdatedAt,
-		&catalog.Business.ID,
-		&catalog.Business.Name,
-		&catalog.Business.LogoURL,
-		&catalog.Business.Slug,
-		&catalog.Theme.ID,
-		&catalog.Theme.Name,
-		&catalog.Theme.Type,
-	)
-
+	catalog, err := database.Get[entity.Catalog](ctx, r.db, query, slug)
 	if err == sql.ErrNoRows {
 		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
 	}
@@ -217,21 +325,38 @@ func (r *catalogRepository) GetBySlug(slug string) (*entity.Catalog, error) {
 		return nil, errors.Wrap(err, "failed to get catalog by slug")
 	}
 
-	// Parse settings
-	if err := json.Unmarshal(settingsJSON, &catalog.Settings); err != nil {
-		return nil, errors.Wrap(err, "failed to parse settings")
+	return catalog, nil
+}
+
+// GetBySlugs ringkasan beberapa catalog sekaligus untuk batch publik, slug yang
+// tidak ada tidak ikut dikembalikan
+func (r *catalogRepository) GetBySlugs(ctx context.Context, slugs []string) ([]*entity.Catalog, error) {
+	query := `
+		SELECT
+			c.c_id, c.c_b_id, c.c_slug, c.c_title, c.c_subtitle, c.c_is_active,
+			c.c_status, c.c_archived_at, c.c_meta_title, c.c_meta_description, c.c_og_image,
+			c.c_rating_avg, c.c_rating_count,
+			b.b_id, b.b_name, b.b_logo_url, b.b_slug, b.b_is_active
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_slug = ANY($1)`
+
+	catalogs, err := database.Select[entity.Catalog](ctx, r.db, query, pq.Array(slugs))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get catalogs by slugs")
 	}
 
-	return catalog, nil
+	return catalogs, nil
 }
 
 // List mendapatkan list catalogs
-func (r *catalogRepository) List(filter ListFilter) ([]*entity.Catalog, int64, error) {
+func (r *catalogRepository) List(ctx context.Context, filter ListFilter) ([]*entity.Catalog, int64, error) {
 	// Build query
 	qb := database.NewQueryBuilder()
 	qb.Select(
 		"c.c_id", "c.c_b_id", "c.c_mt_id", "c.c_slug", "c.c_qr_url",
 		"c.c_title", "c.c_subtitle", "c.c_is_active", "c.c_settings",
+		"c.c_status", "c.c_published_at", "c.c_archived_at", "c.c_deleted_at",
 		"c.c_created_by", "c.c_created_at", "c.c_updated_by", "c.c_updated_at",
 		"b.b_name", "b.b_logo_url", "mt.mt_name",
 	).From("atamlink.catalogs c")
@@ -240,12 +365,33 @@ func (r *catalogRepository) List(filter ListFilter) ([]*entity.Catalog, int64, e
 
 	// Apply filters
 	if filter.Search != "" {
-		qb.Where("(LOWER(c.c_title) LIKE LOWER($1) OR LOWER(c.c_slug) LIKE LOWER($1))", "%"+filter.Search+"%")
+		tsQuery := prefixTSQuery(filter.Search)
+		if tsQuery == "" {
+			qb.Where("FALSE")
+		} else {
+			qb.Where("c.c_search @@ to_tsquery('simple', ?)", tsQuery)
+		}
+	}
+
+	if filter.IDs != nil {
+		qb.Where("c.c_id = ANY(?)", pq.Array(filter.IDs))
 	}
 	
 	if filter.BusinessID > 0 {
 		qb.Where("c.c_b_id = ?", filter.BusinessID)
 	}
+
+	if filter.BusinessIDs != nil {
+		if len(filter.BusinessIDs) == 0 {
+			qb.Where("FALSE")
+		} else {
+			businessIDs := make([]interface{}, len(filter.BusinessIDs))
+			for i, id := range filter.BusinessIDs {
+				businessIDs[i] = id
+			}
+			qb.WhereIn("c.c_b_id", businessIDs)
+		}
+	}
 	
 	if filter.ThemeID > 0 {
 		qb.Where("c.c_mt_id = ?", filter.ThemeID)
@@ -255,69 +401,41 @@ func (r *catalogRepository) List(filter ListFilter) ([]*entity.Catalog, int64, e
 		qb.Where("c.c_is_active = ?", *filter.IsActive)
 	}
 
-	// Count total
-	countQuery, countArgs := qb.BuildCount()
+	if filter.Deleted {
+		qb.Where("c.c_deleted_at IS NOT NULL")
+	} else {
+		qb.Where("c.c_deleted_at IS NULL")
+	}
+
 	var total int64
-	err := r.db.QueryRow(countQuery, countArgs...).Scan(&total)
-	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to count catalogs")
+	if filter.Keyset != nil {
+		qb.ApplyKeyset(filter.Keyset)
+	} else {
+		// Count total
+		countQuery, countArgs := qb.BuildCount()
+		err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to count catalogs")
+		}
+
+		qb.OrderBy(filter.OrderBy)
+		qb.Offset(filter.Offset)
 	}
 
 	// Get data
-	qb.OrderBy(filter.OrderBy)
 	qb.Limit(filter.Limit)
-	qb.Offset(filter.Offset)
 
 	query, args := qb.Build()
-	rows, err := r.db.Query(query, args...)
+	catalogs, err := database.Select[entity.Catalog](ctx, r.db, query, args...)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to query catalogs")
 	}
-	defer rows.Close()
-
-	catalogs := make([]*entity.Catalog, 0)
-	for rows.Next() {
-		catalog := &entity.Catalog{
-			Business: &entity.Business{},
-			Theme:    &entity.MasterTheme{},
-		}
-		var settingsJSON []byte
-		
-		err := rows.Scan(
-			&catalog.ID,
-			&catalog.BusinessID,
-			&catalog.ThemeID,
-			&catalog.Slug,
-			&catalog.QRUrl,
-			&catalog.Title,
-			&catalog.Subtitle,
-			&catalog.IsActive,
-			&settingsJSON,
-			&catalog.CreatedBy,
-			&catalog.CreatedAt,
-			&catalog.UpdatedBy,
-			&catalog.UpdatedAt,
-			&catalog.Business.Name,
-			&catalog.Business.LogoURL,
-			&catalog.Theme.Name,
-		)
-		if err != nil {
-			return nil, 0, errors.Wrap(err, "failed to scan catalog row")
-		}
-
-		// Parse settings
-		if err := json.Unmarshal(settingsJSON, &catalog.Settings); err != nil {
-			return nil, 0, errors.Wrap(err, "failed to parse settings")
-		}
-
-		catalogs = append(catalogs, catalog)
-	}
 
 	return catalogs, total, nil
 }
 
 // Update update catalog
-func (r *catalogRepository) Update(tx *sql.Tx, catalog *entity.Catalog) error {
+func (r *catalogRepository) Update(ctx context.Context, tx *sql.Tx, catalog *entity.Catalog) error {
 	settingsJSON, err := json.Marshal(catalog.Settings)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal settings")
@@ -330,11 +448,18 @@ func (r *catalogRepository) Update(tx *sql.Tx, catalog *entity.Catalog) error {
 			c_subtitle = $4,
 			c_is_active = $5,
 			c_settings = $6,
-			c_updated_by = $7,
-			c_updated_at = $8
-		WHERE c_id = $1`
-
-	result, err := tx.Exec(
+			c_allow_indexing = $7,
+			c_listed = $8,
+			c_mc_id = $9,
+			c_meta_title = $10,
+			c_meta_description = $11,
+			c_og_image = $12,
+			c_updated_by = $13,
+			c_updated_at = $14,
+			c_version = c_version + 1
+		WHERE c_id = $1 AND c_version = $15`
+
+	result, err := tx.ExecContext(ctx,
 		query,
 		catalog.ID,
 		catalog.ThemeID,
@@ -342,8 +467,15 @@ func (r *catalogRepository) Update(tx *sql.Tx, catalog *entity.Catalog) error {
 		catalog.Subtitle,
 		catalog.IsActive,
 		settingsJSON,
+		catalog.AllowIndexing,
+		catalog.Listed,
+		catalog.CategoryID,
+		catalog.MetaTitle,
+		catalog.MetaDescription,
+		catalog.OGImage,
 		catalog.UpdatedBy,
 		time.Now(),
+		catalog.Version,
 	)
 
 	if err != nil {
@@ -355,21 +487,24 @@ func (r *catalogRepository) Update(tx *sql.Tx, catalog *entity.Catalog) error {
 		return errors.Wrap(err, "failed to check rows affected")
 	}
 
+	// Versi berubah sejak dibaca, katalog sudah diupdate request lain
 	if rowsAffected == 0 {
-		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+		return errors.New(errors.ErrConflict, constant.ErrMsgCatalogModified, 409)
 	}
 
 	return nil
 }
 
-// Delete soft delete catalog
-func (r *catalogRepository) Delete(tx *sql.Tx, id int64) error {
+// Delete soft delete catalog: nonaktifkan dan pindahkan ke trash.
+// Jadwal tampil/sembunyi dibatalkan agar katalog tidak aktif lagi otomatis
+func (r *catalogRepository) Delete(ctx context.Context, tx *sql.Tx, id int64, profileID int64) error {
 	query := `
 		UPDATE atamlink.catalogs 
-		SET c_is_active = false, c_updated_at = $2
-		WHERE c_id = $1`
+		SET c_is_active = false, c_publish_at = NULL, c_unpublish_at = NULL,
+			c_deleted_at = $2, c_deleted_by = $3, c_updated_at = $2
+		WHERE c_id = $1 AND c_deleted_at IS NULL`
 
-	result, err := tx.Exec(query, id, time.Now())
+	result, err := tx.ExecContext(ctx, query, id, time.Now(), profileID)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete catalog")
 	}
@@ -386,12 +521,58 @@ func (r *catalogRepository) Delete(tx *sql.Tx, id int64) error {
 	return nil
 }
 
+// Restore keluarkan katalog dari trash, katalog tetap nonaktif sampai diaktifkan lagi
+func (r *catalogRepository) Restore(ctx context.Context, tx *sql.Tx, id int64, profileID int64) error {
+	query := `
+		UPDATE atamlink.catalogs
+		SET c_deleted_at = NULL, c_deleted_by = NULL,
+			c_updated_by = $2, c_updated_at = $3, c_version = c_version + 1
+		WHERE c_id = $1 AND c_deleted_at IS NOT NULL`
+
+	result, err := tx.ExecContext(ctx, query, id, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to restore catalog")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCatalogNotInTrash, 400)
+	}
+
+	return nil
+}
+
+// HardDelete hapus permanen katalog di trash beserta semua child row (ON DELETE CASCADE)
+func (r *catalogRepository) HardDelete(ctx context.Context, tx *sql.Tx, id int64) error {
+	query := `DELETE FROM atamlink.catalogs WHERE c_id = $1 AND c_deleted_at IS NOT NULL`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to hard delete catalog")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCatalogNotInTrash, 400)
+	}
+
+	return nil
+}
+
 // IsSlugExists check if slug exists
-func (r *catalogRepository) IsSlugExists(slug string) (bool, error) {
+func (r *catalogRepository) IsSlugExists(ctx context.Context, slug string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM atamlink.catalogs WHERE c_slug = $1)`
 	
 	var exists bool
-	err := r.db.QueryRow(query, slug).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(&exists)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check slug exists")
 	}
@@ -399,8 +580,20 @@ func (r *catalogRepository) IsSlugExists(slug string) (bool, error) {
 	return exists, nil
 }
 
+// CountByBusiness jumlah katalog business yang tidak di trash
+func (r *catalogRepository) CountByBusiness(ctx context.Context, businessID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM atamlink.catalogs WHERE c_b_id = $1 AND c_deleted_at IS NULL`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, businessID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count business catalogs")
+	}
+	return count, nil
+}
+
 // CreateSection create catalog section
-func (r *catalogRepository) CreateSection(tx *sql.Tx, section *entity.CatalogSection) error {
+func (r *catalogRepository) CreateSection(ctx context.Context, tx *sql.Tx, section *entity.CatalogSection) error {
 	configJSON, err := json.Marshal(section.Config)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal config")
@@ -408,18 +601,23 @@ func (r *catalogRepository) CreateSection(tx *sql.Tx, section *entity.CatalogSec
 
 	query := `
 		INSERT INTO atamlink.catalog_sections (
-			cs_c_id, cs_type, cs_is_visible, cs_config, cs_created_at
-		) VALUES ($1, $2, $3, $4, $5)
-		RETURNING cs_id`
+			cs_c_id, cs_type, cs_is_visible, cs_config, cs_created_by, cs_created_at, cs_position
+		) VALUES (
+			$1, $2, $3, $4, $5, $6,
+			COALESCE((SELECT MAX(cs_position) FROM atamlink.catalog_sections WHERE cs_c_id = $1), 0) + $7
+		)
+		RETURNING cs_id, cs_position`
 
-	err = tx.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		query,
 		section.CatalogID,
 		section.Type,
 		section.IsVisible,
 		configJSON,
+		section.CreatedBy,
 		section.CreatedAt,
-	).Scan(&section.ID)
+		constant.PositionGap,
+	).Scan(&section.ID, &section.Position)
 
 	if err != nil {
 		return errors.Wrap(err, "failed to create section")
@@ -429,68 +627,37 @@ func (r *catalogRepository) CreateSection(tx *sql.Tx, section *entity.CatalogSec
 }
 
 // GetSectionsByCatalogID get sections by catalog ID
-func (r *catalogRepository) GetSectionsByCatalogID(catalogID int64) ([]*entity.CatalogSection, error) {
+func (r *catalogRepository) GetSectionsByCatalogID(ctx context.Context, catalogID int64) ([]*entity.CatalogSection, error) {
 	query := `
-		SELECT cs_id, cs_c_id, cs_type, cs_is_visible, cs_config, cs_created_at, cs_updated_at
-		FROM atamlink.catalog_sections
-		WHERE cs_c_id = $1
-		ORDER BY cs_id ASC`
-
-	rows, err := r.db.Query(query, catalogID)
+		SELECT 
+			cs.cs_id, cs.cs_c_id, cs.cs_type, cs.cs_is_visible, cs.cs_config, cs.cs_position,
+			cs.cs_created_by, cs.cs_created_at, cs.cs_updated_by, cs.cs_updated_at,
+			up.up_display_name
+		FROM atamlink.catalog_sections cs
+		LEFT JOIN atamlink.user_profiles up ON up.up_id = COALESCE(cs.cs_updated_by, cs.cs_created_by)
+		WHERE cs.cs_c_id = $1
+		ORDER BY cs.cs_position ASC, cs.cs_id ASC`
+
+	sections, err := database.Select[entity.CatalogSection](ctx, r.db, query, catalogID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get sections")
 	}
-	defer rows.Close()
-
-	sections := make([]*entity.CatalogSection, 0)
-	for rows.Next() {
-		section := &entity.CatalogSection{}
-		var configJSON []byte
-		
-		err := rows.Scan(
-			&section.ID,
-			&section.CatalogID,
-			&section.Type,
-			&section.IsVisible,
-			&configJSON,
-			&section.CreatedAt,
-			&section.UpdatedAt,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan section")
-		}
-
-		// Parse config
-		if err := json.Unmarshal(configJSON, &section.Config); err != nil {
-			return nil, errors.Wrap(err, "failed to parse config")
-		}
-
-		sections = append(sections, section)
-	}
 
 	return sections, nil
 }
 
 // GetSectionByID get section by ID
-func (r *catalogRepository) GetSectionByID(id int64) (*entity.CatalogSection, error) {
+func (r *catalogRepository) GetSectionByID(ctx context.Context, id int64) (*entity.CatalogSection, error) {
 	query := `
-		SELECT cs_id, cs_c_id, cs_type, cs_is_visible, cs_config, cs_created_at, cs_updated_at
-		FROM atamlink.catalog_sections
-		WHERE cs_id = $1`
-
-	section := &entity.CatalogSection{}
-	var configJSON []byte
-	
-	err := r.db.QueryRow(query, id).Scan(
-		&section.ID,
-		&section.CatalogID,
-		&section.Type,
-		&section.IsVisible,
-		&configJSON,
-		&section.CreatedAt,
-		&section.UpdatedAt,
-	)
-
+		SELECT 
+			cs.cs_id, cs.cs_c_id, cs.cs_type, cs.cs_is_visible, cs.cs_config, cs.cs_position,
+			cs.cs_created_by, cs.cs_created_at, cs.cs_updated_by, cs.cs_updated_at,
+			up.up_display_name
+		FROM atamlink.catalog_sections cs
+		LEFT JOIN atamlink.user_profiles up ON up.up_id = COALESCE(cs.cs_updated_by, cs.cs_created_by)
+		WHERE cs.cs_id = $1`
+
+	section, err := database.Get[entity.CatalogSection](ctx, r.db, query, id)
 	if err == sql.ErrNoRows {
 		return nil, errors.New(errors.ErrSectionNotFound, constant.ErrMsgSectionNotFound, 404)
 	}
@@ -498,16 +665,11 @@ func (r *catalogRepository) GetSectionByID(id int64) (*entity.CatalogSection, er
 		return nil, errors.Wrap(err, "failed to get section")
 	}
 
-	// Parse config
-	if err := json.Unmarshal(configJSON, &section.Config); err != nil {
-		return nil, errors.Wrap(err, "failed to parse config")
-	}
-
 	return section, nil
 }
 
 // UpdateSection update section
-func (r *catalogRepository) UpdateSection(tx *sql.Tx, section *entity.CatalogSection) error {
+func (r *catalogRepository) UpdateSection(ctx context.Context, tx *sql.Tx, section *entity.CatalogSection) error {
 	configJSON, err := json.Marshal(section.Config)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal config")
@@ -518,15 +680,17 @@ func (r *catalogRepository) UpdateSection(tx *sql.Tx, section *entity.CatalogSec
 			cs_type = $2,
 			cs_is_visible = $3,
 			cs_config = $4,
-			cs_updated_at = $5
+			cs_updated_by = $5,
+			cs_updated_at = $6
 		WHERE cs_id = $1`
 
-	result, err := tx.Exec(
+	result, err := tx.ExecContext(ctx,
 		query,
 		section.ID,
 		section.Type,
 		section.IsVisible,
 		configJSON,
+		section.UpdatedBy,
 		time.Now(),
 	)
 
@@ -547,10 +711,10 @@ func (r *catalogRepository) UpdateSection(tx *sql.Tx, section *entity.CatalogSec
 }
 
 // DeleteSection delete section
-func (r *catalogRepository) DeleteSection(tx *sql.Tx, id int64) error {
+func (r *catalogRepository) DeleteSection(ctx context.Context, tx *sql.Tx, id int64) error {
 	query := `DELETE FROM atamlink.catalog_sections WHERE cs_id = $1`
 
-	result, err := tx.Exec(query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete section")
 	}
@@ -567,17 +731,67 @@ func (r *catalogRepository) DeleteSection(tx *sql.Tx, id int64) error {
 	return nil
 }
 
+// UpdateSectionPosition pindahkan section ke posisi baru
+func (r *catalogRepository) UpdateSectionPosition(ctx context.Context, tx *sql.Tx, id int64, position int, profileID int64) error {
+	query := `
+		UPDATE atamlink.catalog_sections SET
+			cs_position = $2,
+			cs_updated_by = $3,
+			cs_updated_at = $4
+		WHERE cs_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, position, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update section position")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrSectionNotFound, constant.ErrMsgSectionNotFound, 404)
+	}
+
+	return nil
+}
+
+// RebalanceSectionPositions nomori ulang posisi section katalog (100, 200, ...)
+// sesuai urutan sekarang, section yang sedang dipindah tidak ikut dinomori
+func (r *catalogRepository) RebalanceSectionPositions(ctx context.Context, tx *sql.Tx, catalogID, excludeID int64) error {
+	query := `
+		UPDATE atamlink.catalog_sections s
+		SET cs_position = o.rn * $3
+		FROM (
+			SELECT cs_id, ROW_NUMBER() OVER (ORDER BY cs_position, cs_id) AS rn
+			FROM atamlink.catalog_sections
+			WHERE cs_c_id = $1 AND cs_id <> $2
+		) o
+		WHERE s.cs_id = o.cs_id`
+
+	if _, err := tx.ExecContext(ctx, query, catalogID, excludeID, constant.PositionGap); err != nil {
+		return errors.Wrap(err, "failed to rebalance section positions")
+	}
+
+	return nil
+}
+
 // CreateCard create catalog card
-func (r *catalogRepository) CreateCard(tx *sql.Tx, card *entity.CatalogCard) error {
+func (r *catalogRepository) CreateCard(ctx context.Context, tx *sql.Tx, card *entity.CatalogCard) error {
 	query := `
 		INSERT INTO atamlink.catalog_cards (
 			cc_cs_id, cc_title, cc_subtitle, cc_type, cc_url,
 			cc_is_visible, cc_has_detail, cc_price, cc_discount,
-			cc_currency, cc_created_by, cc_created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING cc_id`
+			cc_currency, cc_affiliate_partner_id, cc_affiliate_commission_rate,
+			cc_created_by, cc_created_at, cc_position
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+			COALESCE((SELECT MAX(cc_position) FROM atamlink.catalog_cards WHERE cc_cs_id = $1), 0) + $15
+		)
+		RETURNING cc_id, cc_position`
 
-	err := tx.QueryRow(
+	err := tx.QueryRowContext(ctx,
 		query,
 		card.SectionID,
 		card.Title,
@@ -589,9 +803,12 @@ func (r *catalogRepository) CreateCard(tx *sql.Tx, card *entity.CatalogCard) err
 		card.Price,
 		card.Discount,
 		card.Currency,
+		card.AffiliatePartnerID,
+		card.AffiliateCommissionRate,
 		card.CreatedBy,
 		card.CreatedAt,
-	).Scan(&card.ID)
+		constant.PositionGap,
+	).Scan(&card.ID, &card.Position)
 
 	if err != nil {
 		return errors.Wrap(err, "failed to create card")
@@ -600,354 +817,2503 @@ func (r *catalogRepository) CreateCard(tx *sql.Tx, card *entity.CatalogCard) err
 	return nil
 }
 
-// GetCardsBySectionID get cards by section ID
-func (r *catalogRepository) GetCardsBySectionID(sectionID int64) ([]*entity.CatalogCard, error) {
-	query := `
-		SELECT 
-			cc_id, cc_cs_id, cc_title, cc_subtitle, cc_type, cc_url,
-			cc_is_visible, cc_has_detail, cc_price, cc_discount,
-			cc_currency, cc_created_by, cc_created_at, cc_updated_by, cc_updated_at
-		FROM atamlink.catalog_cards
-		WHERE cc_cs_id = $1
-		ORDER BY cc_id ASC`
-
-	rows, err := r.db.Query(query, sectionID)
+// CountSections jumlah section dalam katalog
+func (r *catalogRepository) CountSections(ctx context.Context, catalogID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM atamlink.catalog_sections WHERE cs_c_id = $1`, catalogID).Scan(&count)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get cards")
+		return 0, errors.Wrap(err, "failed to count sections")
 	}
-	defer rows.Close()
+	return count, nil
+}
 
-	cards := make([]*entity.CatalogCard, 0)
-	for rows.Next() {
-		card := &entity.CatalogCard{}
-		err := rows.Scan(
-			&card.ID,
-			&card.SectionID,
-			&card.Title,
-			&card.Subtitle,
-			&card.Type,
-			&card.URL,
-			&card.IsVisible,
-			&card.HasDetail,
-			&card.Price,
-			&card.Discount,
-			&card.Currency,
-			&card.CreatedBy,
-			&card.CreatedAt,
-			&card.UpdatedBy,
-			&card.UpdatedAt,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan card")
-		}
-		cards = append(cards, card)
+// CountCards jumlah card dalam section
+func (r *catalogRepository) CountCards(ctx context.Context, sectionID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM atamlink.catalog_cards WHERE cc_cs_id = $1`, sectionID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count cards")
 	}
-
-	return cards, nil
+	return count, nil
 }
 
-// GetCardByID get card by ID
-func (r *catalogRepository) GetCardByID(id int64) (*entity.CatalogCard, error) {
+// CountCardsByBusiness jumlah card di semua katalog business yang tidak di trash
+func (r *catalogRepository) CountCardsByBusiness(ctx context.Context, businessID int64) (int, error) {
 	query := `
-		SELECT 
-			cc_id, cc_cs_id, cc_title, cc_subtitle, cc_type, cc_url,
-			cc_is_visible, cc_has_detail, cc_price, cc_discount,
-			cc_currency, cc_created_by, cc_created_at, cc_updated_by, cc_updated_at
-		FROM atamlink.catalog_cards
-		WHERE cc_id = $1`
+		SELECT COUNT(*)
+		FROM atamlink.catalog_cards cc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		WHERE c.c_b_id = $1 AND c.c_deleted_at IS NULL`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, businessID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count business cards")
+	}
+	return count, nil
+}
 
-	card := &entity.CatalogCard{}
-	err := r.db.QueryRow(query, id).Scan(
-		&card.ID,
-		&card.SectionID,
-		&card.Title,
-		&card.Subtitle,
-		&card.Type,
-		&card.URL,
-		&card.IsVisible,
-		&card.HasDetail,
-		&card.Price,
-		&card.Discount,
-		&card.Currency,
-		&card.CreatedBy,
-		&card.CreatedAt,
-		&card.UpdatedBy,
-		&card.UpdatedAt,
-	)
+// CountCardsByCatalog jumlah card di semua section katalog
+func (r *catalogRepository) CountCardsByCatalog(ctx context.Context, catalogID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM atamlink.catalog_cards cc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		WHERE cs.cs_c_id = $1`
 
-	if err == sql.ErrNoRows {
-		return nil, errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
-	}
+	var count int
+	err := r.db.QueryRowContext(ctx, query, catalogID).Scan(&count)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get card")
+		return 0, errors.Wrap(err, "failed to count catalog cards")
 	}
-
-	return card, nil
+	return count, nil
 }
 
-// UpdateCard update card
-func (r *catalogRepository) UpdateCard(tx *sql.Tx, card *entity.CatalogCard) error {
+// GetCardsBySectionID get cards by section ID
+func (r *catalogRepository) GetCardsBySectionID(ctx context.Context, sectionID int64) ([]*entity.CatalogCard, error) {
 	query := `
-		UPDATE atamlink.catalog_cards SET
-			cc_title = $2,
-			cc_subtitle = $3,
-			cc_type = $4,
-			cc_url = $5,
-			cc_is_visible = $6,
-			cc_has_detail = $7,
-			cc_price = $8,
-			cc_discount = $9,
-			cc_currency = $10,
-			cc_updated_by = $11,
-			cc_updated_at = $12
-		WHERE cc_id = $1`
+		SELECT 
+			cc.cc_id, cc.cc_cs_id, cc.cc_title, cc.cc_subtitle, cc.cc_type, cc.cc_url,
+			cc.cc_is_visible, cc.cc_has_detail, cc.cc_price, cc.cc_discount,
+			cc.cc_currency, cc.cc_affiliate_partner_id, cc.cc_affiliate_commission_rate, cc.cc_position,
+			cc.cc_publish_at, cc.cc_unpublish_at,
+			cc.cc_stock, cc.cc_sold_out, cc.cc_hide_when_sold_out,
+			cc.cc_created_by, cc.cc_created_at, cc.cc_updated_by, cc.cc_updated_at, cc.cc_version,
+			up.up_display_name
+		FROM atamlink.catalog_cards cc
+		LEFT JOIN atamlink.user_profiles up ON up.up_id = COALESCE(cc.cc_updated_by, cc.cc_created_by)
+		WHERE cc.cc_cs_id = $1
+		ORDER BY cc.cc_position ASC, cc.cc_id ASC`
+
+	cards, err := database.Select[entity.CatalogCard](ctx, r.db, query, sectionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cards")
+	}
 
-	result, err := tx.Exec(
-		query,
-		card.ID,
-		card.Title,
-		card.Subtitle,
-		card.Type,
-		card.URL,
-		card.IsVisible,
-		card.HasDetail,
-		card.Price,
-		card.Discount,
-		card.Currency,
-		card.UpdatedBy,
-		time.Now(),
-	)
+	return cards, nil
+}
 
-	if err != nil {
-		return errors.Wrap(err, "failed to update card")
+// GetCardsBySectionIDs get cards beberapa section sekaligus (1 query), dikelompokkan per section ID
+func (r *catalogRepository) GetCardsBySectionIDs(ctx context.Context, sectionIDs []int64) (map[int64][]*entity.CatalogCard, error) {
+	cardsBySection := make(map[int64][]*entity.CatalogCard)
+	if len(sectionIDs) == 0 {
+		return cardsBySection, nil
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	query := `
+		SELECT 
+			cc.cc_id, cc.cc_cs_id, cc.cc_title, cc.cc_subtitle, cc.cc_type, cc.cc_url,
+			cc.cc_is_visible, cc.cc_has_detail, cc.cc_price, cc.cc_discount,
+			cc.cc_currency, cc.cc_affiliate_partner_id, cc.cc_affiliate_commission_rate, cc.cc_position,
+			cc.cc_publish_at, cc.cc_unpublish_at,
+			cc.cc_stock, cc.cc_sold_out, cc.cc_hide_when_sold_out,
+			cc.cc_created_by, cc.cc_created_at, cc.cc_updated_by, cc.cc_updated_at, cc.cc_version,
+			up.up_display_name
+		FROM atamlink.catalog_cards cc
+		LEFT JOIN atamlink.user_profiles up ON up.up_id = COALESCE(cc.cc_updated_by, cc.cc_created_by)
+		WHERE cc.cc_cs_id = ANY($1)
+		ORDER BY cc.cc_cs_id ASC, cc.cc_position ASC, cc.cc_id ASC`
+
+	cards, err := database.Select[entity.CatalogCard](ctx, r.db, query, pq.Array(sectionIDs))
 	if err != nil {
-		return errors.Wrap(err, "failed to check rows affected")
+		return nil, errors.Wrap(err, "failed to get cards")
 	}
 
-	if rowsAffected == 0 {
-		return errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
+	for _, card := range cards {
+		cardsBySection[card.SectionID] = append(cardsBySection[card.SectionID], card)
 	}
 
-	return nil
+	return cardsBySection, nil
 }
 
-// DeleteCard delete card
-func (r *catalogRepository) DeleteCard(tx *sql.Tx, id int64) error {
-	query := `DELETE FROM atamlink.catalog_cards WHERE cc_id = $1`
-
-	result, err := tx.Exec(query, id)
-	if err != nil {
-		return errors.Wrap(err, "failed to delete card")
+// GetCardsWithRelationsBySectionIDs get cards beberapa section sekaligus beserta
+// detail dan links-nya (2 query), dikelompokkan per section ID
+func (r *catalogRepository) GetCardsWithRelationsBySectionIDs(ctx context.Context, sectionIDs []int64) (map[int64][]*entity.CatalogCard, error) {
+	cardsBySection := make(map[int64][]*entity.CatalogCard)
+	if len(sectionIDs) == 0 {
+		return cardsBySection, nil
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	cards, err := r.queryCardsWithRelations(ctx, func(qb *database.QueryBuilder) {
+		qb.WhereIn("cc.cc_cs_id", int64sToArgs(sectionIDs))
+		qb.OrderBy("cc.cc_cs_id ASC, cc.cc_position ASC, cc.cc_id ASC")
+	})
 	if err != nil {
-		return errors.Wrap(err, "failed to check rows affected")
+		return nil, err
 	}
 
-	if rowsAffected == 0 {
-		return errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
+	for _, card := range cards {
+		cardsBySection[card.SectionID] = append(cardsBySection[card.SectionID], card)
 	}
 
-	return nil
+	return cardsBySection, nil
 }
 
-// CreateCardDetail create card detail
-func (r *catalogRepository) CreateCardDetail(tx *sql.Tx, detail *entity.CatalogCardDetail) error {
-	query := `
-		INSERT INTO atamlink.catalog_card_details (
-			ccd_cc_id, ccd_slug, ccd_description, ccd_is_visible,
-			ccd_created_by, ccd_created_at
-		) VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING ccd_id`
-
-	err := tx.QueryRow(
-		query,
-		detail.CardID,
-		detail.Slug,
-		detail.Description,
-		detail.IsVisible,
-		detail.CreatedBy,
-		detail.CreatedAt,
-	).Scan(&detail.ID)
+// GetCardsWithRelationsByIDs get cards dari daftar ID beserta detail dan links-nya,
+// dikelompokkan per card ID
+func (r *catalogRepository) GetCardsWithRelationsByIDs(ctx context.Context, ids []int64) (map[int64]*entity.CatalogCard, error) {
+	cardsByID := make(map[int64]*entity.CatalogCard)
+	if len(ids) == 0 {
+		return cardsByID, nil
+	}
 
+	cards, err := r.queryCardsWithRelations(ctx, func(qb *database.QueryBuilder) {
+		qb.Where("cc.cc_id = ANY(?)", pq.Array(ids))
+	})
 	if err != nil {
-		return errors.Wrap(err, "failed to create card detail")
+		return nil, err
 	}
 
-	return nil
+	for _, card := range cards {
+		cardsByID[card.ID] = card
+	}
+
+	return cardsByID, nil
 }
 
-// GetCardDetailByCardID get card detail by card ID
-func (r *catalogRepository) GetCardDetailByCardID(cardID int64) (*entity.CatalogCardDetail, error) {
-	query := `
-		SELECT 
-			ccd_id, ccd_cc_id, ccd_slug, ccd_description, ccd_is_visible,
-			ccd_created_by, ccd_created_at, ccd_updated_by, ccd_updated_at
-		FROM atamlink.catalog_card_details
-		WHERE ccd_cc_id = $1`
+// queryCardsWithRelations query cards beserta detail dan links-nya (2 query),
+// filter dan urutan diisi lewat scope
+func (r *catalogRepository) queryCardsWithRelations(ctx context.Context, scope func(qb *database.QueryBuilder)) ([]*entity.CatalogCard, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(
+		"cc.cc_id", "cc.cc_cs_id", "cc.cc_title", "cc.cc_subtitle", "cc.cc_type", "cc.cc_url",
+		"cc.cc_is_visible", "cc.cc_has_detail", "cc.cc_price", "cc.cc_discount",
+		"cc.cc_currency", "cc.cc_affiliate_partner_id", "cc.cc_affiliate_commission_rate", "cc.cc_position",
+		"cc.cc_publish_at", "cc.cc_unpublish_at",
+		"cc.cc_stock", "cc.cc_sold_out", "cc.cc_hide_when_sold_out",
+		"cc.cc_created_by", "cc.cc_created_at", "cc.cc_updated_by", "cc.cc_updated_at", "cc.cc_version",
+		"up.up_display_name",
+		"ccd.ccd_id", "ccd.ccd_c_id", "ccd.ccd_slug", "ccd.ccd_description", "ccd.ccd_description_html",
+		"ccd.ccd_is_visible", "ccd.ccd_created_by", "ccd.ccd_created_at", "ccd.ccd_updated_by", "ccd.ccd_updated_at",
+	).From("atamlink.catalog_cards cc")
+	qb.LeftJoin("atamlink.user_profiles up", "up.up_id = COALESCE(cc.cc_updated_by, cc.cc_created_by)")
+	qb.LeftJoin("atamlink.catalog_card_details ccd", "ccd.ccd_cc_id = cc.cc_id AND cc.cc_has_detail")
+	scope(qb)
 
-	detail := &entity.CatalogCardDetail{}
-	err := r.db.QueryRow(query, cardID).Scan(
-		&detail.ID,
-		&detail.CardID,
-		&detail.Slug,
-		&detail.Description,
-		&detail.IsVisible,
-		&detail.CreatedBy,
-		&detail.CreatedAt,
-		&detail.UpdatedBy,
-		&detail.UpdatedAt,
-	)
+	query, args := qb.Build()
+	rows, err := database.Select[cardDetailRow](ctx, r.db, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cards")
+	}
+
+	cards := make([]*entity.CatalogCard, 0, len(rows))
+	details := make(map[int64]*entity.CatalogCardDetail)
+	detailIDs := make([]int64, 0)
+	for _, row := range rows {
+		card := &row.CatalogCard
+		if detail := row.detail(card.ID); detail != nil {
+			card.Detail = detail
+			details[detail.ID] = detail
+			detailIDs = append(detailIDs, detail.ID)
+		}
+		cards = append(cards, card)
+	}
+
+	if len(detailIDs) == 0 {
+		return cards, nil
+	}
+
+	// Links semua detail dalam satu query
+	qb = database.NewQueryBuilder()
+	qb.Select(
+		"ccl_id", "ccl_ccd_id", "ccl_type", "ccl_url", "ccl_token", "ccl_utm", "ccl_is_visible",
+		"ccl_created_by", "ccl_created_at", "ccl_updated_by", "ccl_updated_at",
+	).From("atamlink.catalog_card_links")
+	qb.WhereIn("ccl_ccd_id", int64sToArgs(detailIDs))
+	qb.OrderBy("ccl_id ASC")
+
+	query, args = qb.Build()
+	links, err := database.Select[entity.CatalogCardLink](ctx, r.db, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card links")
+	}
+
+	for _, link := range links {
+		if detail, ok := details[link.DetailID]; ok {
+			detail.Links = append(detail.Links, link)
+		}
+	}
+
+	return cards, nil
+}
+
+// cardDetailRow card dengan kolom detail dari LEFT JOIN, kolom detail NULL
+// jika card tidak punya detail
+type cardDetailRow struct {
+	entity.CatalogCard
+	DetailID              sql.NullInt64  `db:"ccd_id"`
+	DetailCatalogID       sql.NullInt64  `db:"ccd_c_id"`
+	DetailSlug            sql.NullString `db:"ccd_slug"`
+	DetailDescription     sql.NullString `db:"ccd_description"`
+	DetailDescriptionHTML sql.NullString `db:"ccd_description_html"`
+	DetailIsVisible       sql.NullBool   `db:"ccd_is_visible"`
+	DetailCreatedBy       sql.NullInt64  `db:"ccd_created_by"`
+	DetailCreatedAt       *time.Time     `db:"ccd_created_at"`
+	DetailUpdatedBy       sql.NullInt64  `db:"ccd_updated_by"`
+	DetailUpdatedAt       *time.Time     `db:"ccd_updated_at"`
+}
+
+// detail detail card dari baris join, nil jika card tanpa detail
+func (row *cardDetailRow) detail(cardID int64) *entity.CatalogCardDetail {
+	if !row.DetailID.Valid {
+		return nil
+	}
+
+	detail := &entity.CatalogCardDetail{
+		ID:              row.DetailID.Int64,
+		CardID:          cardID,
+		CatalogID:       row.DetailCatalogID.Int64,
+		Slug:            row.DetailSlug.String,
+		Description:     row.DetailDescription,
+		DescriptionHTML: row.DetailDescriptionHTML,
+		IsVisible:       row.DetailIsVisible.Bool,
+		CreatedBy:       row.DetailCreatedBy.Int64,
+		UpdatedBy:       row.DetailUpdatedBy,
+		UpdatedAt:       row.DetailUpdatedAt,
+		Links:           make([]*entity.CatalogCardLink, 0),
+	}
+	if row.DetailCreatedAt != nil {
+		detail.CreatedAt = *row.DetailCreatedAt
+	}
+	return detail
+}
+
+// SearchPublicCards full-text search card yang tampil di katalog publik (judul,
+// subtitle dan deskripsi detail). Mengembalikan card ID urut relevansi dan total hasil
+func (r *catalogRepository) SearchPublicCards(ctx context.Context, catalogID int64, search string, limit, offset int) ([]int64, int64, error) {
+	ids := make([]int64, 0)
+	tsQuery := prefixTSQuery(search)
+	if tsQuery == "" {
+		return ids, 0, nil
+	}
+
+	from := `
+		FROM atamlink.catalog_cards cc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		LEFT JOIN atamlink.catalog_card_details ccd
+			ON ccd.ccd_cc_id = cc.cc_id AND cc.cc_has_detail AND ccd.ccd_is_visible
+		CROSS JOIN to_tsquery('simple', $2) q
+		WHERE cs.cs_c_id = $1 AND cs.cs_is_visible = true AND cs.cs_type = $3
+			AND cc.cc_is_visible = true
+			AND NOT (cc.cc_hide_when_sold_out AND (cc.cc_sold_out OR COALESCE(cc.cc_stock = 0, false)))
+			AND (cc.cc_search @@ q OR COALESCE(ccd.ccd_search @@ q, false))`
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+from, catalogID, tsQuery, constant.SectionTypeCards).Scan(&total); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count card search results")
+	}
+	if total == 0 {
+		return ids, 0, nil
+	}
+
+	query := "SELECT cc.cc_id" + from + `
+		ORDER BY ts_rank(cc.cc_search || COALESCE(ccd.ccd_search, ''::tsvector), q) DESC,
+			cs.cs_position ASC, cc.cc_position ASC, cc.cc_id ASC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.QueryContext(ctx, query, catalogID, tsQuery, constant.SectionTypeCards, limit, offset)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to search cards")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, 0, errors.Wrap(err, "failed to scan card search result")
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to iterate card search results")
+	}
+
+	return ids, total, nil
+}
+
+// GetCardByID get card by ID
+func (r *catalogRepository) GetCardByID(ctx context.Context, id int64) (*entity.CatalogCard, error) {
+	query := `
+		SELECT 
+			cc.cc_id, cc.cc_cs_id, cc.cc_title, cc.cc_subtitle, cc.cc_type, cc.cc_url,
+			cc.cc_is_visible, cc.cc_has_detail, cc.cc_price, cc.cc_discount,
+			cc.cc_currency, cc.cc_affiliate_partner_id, cc.cc_affiliate_commission_rate, cc.cc_position,
+			cc.cc_publish_at, cc.cc_unpublish_at,
+			cc.cc_stock, cc.cc_sold_out, cc.cc_hide_when_sold_out,
+			cc.cc_created_by, cc.cc_created_at, cc.cc_updated_by, cc.cc_updated_at, cc.cc_version,
+			up.up_display_name
+		FROM atamlink.catalog_cards cc
+		LEFT JOIN atamlink.user_profiles up ON up.up_id = COALESCE(cc.cc_updated_by, cc.cc_created_by)
+		WHERE cc.cc_id = $1`
+
+	card, err := database.Get[entity.CatalogCard](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card")
+	}
+
+	return card, nil
+}
+
+// UpdateCardPosition pindahkan card ke posisi baru
+func (r *catalogRepository) UpdateCardPosition(ctx context.Context, tx *sql.Tx, id int64, position int, profileID int64) error {
+	query := `
+		UPDATE atamlink.catalog_cards SET
+			cc_position = $2,
+			cc_updated_by = $3,
+			cc_updated_at = $4
+		WHERE cc_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, position, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update card position")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
+	}
+
+	return nil
+}
+
+// RebalanceCardPositions nomori ulang posisi card dalam section (100, 200, ...)
+// sesuai urutan sekarang, card yang sedang dipindah tidak ikut dinomori
+func (r *catalogRepository) RebalanceCardPositions(ctx context.Context, tx *sql.Tx, sectionID, excludeID int64) error {
+	query := `
+		UPDATE atamlink.catalog_cards c
+		SET cc_position = o.rn * $3
+		FROM (
+			SELECT cc_id, ROW_NUMBER() OVER (ORDER BY cc_position, cc_id) AS rn
+			FROM atamlink.catalog_cards
+			WHERE cc_cs_id = $1 AND cc_id <> $2
+		) o
+		WHERE c.cc_id = o.cc_id`
+
+	if _, err := tx.ExecContext(ctx, query, sectionID, excludeID, constant.PositionGap); err != nil {
+		return errors.Wrap(err, "failed to rebalance card positions")
+	}
+
+	return nil
+}
+
+// UpdateCard update card
+func (r *catalogRepository) UpdateCard(ctx context.Context, tx *sql.Tx, card *entity.CatalogCard) error {
+	query := `
+		UPDATE atamlink.catalog_cards SET
+			cc_title = $2,
+			cc_subtitle = $3,
+			cc_type = $4,
+			cc_url = $5,
+			cc_is_visible = $6,
+			cc_has_detail = $7,
+			cc_price = $8,
+			cc_discount = $9,
+			cc_currency = $10,
+			cc_affiliate_partner_id = $11,
+			cc_affiliate_commission_rate = $12,
+			cc_updated_by = $13,
+			cc_updated_at = $14,
+			cc_version = cc_version + 1
+		WHERE cc_id = $1 AND cc_version = $15`
+
+	now := time.Now()
+	result, err := tx.ExecContext(ctx,
+		query,
+		card.ID,
+		card.Title,
+		card.Subtitle,
+		card.Type,
+		card.URL,
+		card.IsVisible,
+		card.HasDetail,
+		card.Price,
+		card.Discount,
+		card.Currency,
+		card.AffiliatePartnerID,
+		card.AffiliateCommissionRate,
+		card.UpdatedBy,
+		now,
+		card.Version,
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to update card")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	// Versi berubah sejak dibaca, card sudah diupdate request lain
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrConflict, constant.ErrMsgCardModified, 409)
+	}
+
+	// Samakan dengan nilai yang tersimpan
+	card.UpdatedAt = &now
+
+	return nil
+}
+
+// DeleteCard delete card
+func (r *catalogRepository) DeleteCard(ctx context.Context, tx *sql.Tx, id int64) error {
+	query := `DELETE FROM atamlink.catalog_cards WHERE cc_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete card")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
+	}
+
+	return nil
+}
+
+// CreateAffiliateClick mencatat atribusi klik affiliate
+func (r *catalogRepository) CreateAffiliateClick(ctx context.Context, tx *sql.Tx, click *entity.CatalogAffiliateClick) error {
+	query := `
+		INSERT INTO atamlink.catalog_affiliate_clicks (
+			cac_cc_id, cac_partner_id, cac_commission_rate, cac_amount, cac_clicked_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING cac_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		click.CardID,
+		click.PartnerID,
+		click.CommissionRate,
+		click.Amount,
+		click.ClickedAt,
+	).Scan(&click.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create affiliate click")
+	}
+
+	return nil
+}
+
+// GetAffiliateEarnings agregat estimasi komisi per card per bulan dalam satu catalog
+func (r *catalogRepository) GetAffiliateEarnings(ctx context.Context, catalogID int64, from, to time.Time) ([]*entity.AffiliateEarning, error) {
+	query := `
+		SELECT
+			cc.cc_id, cc.cc_title, cac.cac_partner_id,
+			date_trunc('month', cac.cac_clicked_at) AS month,
+			COUNT(*) AS clicks,
+			COALESCE(SUM(cac.cac_amount * cac.cac_commission_rate / 100), 0) AS estimated_commission
+		FROM atamlink.catalog_affiliate_clicks cac
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = cac.cac_cc_id
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		WHERE cs.cs_c_id = $1
+			AND cac.cac_clicked_at >= $2
+			AND cac.cac_clicked_at < $3
+		GROUP BY cc.cc_id, cc.cc_title, cac.cac_partner_id, month
+		ORDER BY month DESC, cc.cc_id ASC`
+
+	earnings, err := database.Select[entity.AffiliateEarning](ctx, r.db, query, catalogID, from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get affiliate earnings")
+	}
+
+	return earnings, nil
+}
+
+// CreateCheckoutLink simpan checkout link baru
+func (r *catalogRepository) CreateCheckoutLink(ctx context.Context, tx *sql.Tx, link *entity.CatalogCheckoutLink) error {
+	query := `
+		INSERT INTO atamlink.catalog_checkout_links (
+			ccl_cc_id, ccl_external_id, ccl_gateway_id, ccl_quantity, ccl_amount,
+			ccl_currency, ccl_payment_url, ccl_status, ccl_expires_at,
+			ccl_legal_version, ccl_created_by, ccl_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING ccl_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		link.CardID,
+		link.ExternalID,
+		link.GatewayID,
+		link.Quantity,
+		link.Amount,
+		link.Currency,
+		link.PaymentURL,
+		link.Status,
+		link.ExpiresAt,
+		link.LegalVersion,
+		link.CreatedBy,
+		link.CreatedAt,
+	).Scan(&link.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create checkout link")
+	}
+
+	return nil
+}
+
+// GetCheckoutLinkByExternalID get checkout link by external ID (reference ke gateway)
+func (r *catalogRepository) GetCheckoutLinkByExternalID(ctx context.Context, externalID string) (*entity.CatalogCheckoutLink, error) {
+	query := `
+		SELECT
+			ccl_id, ccl_cc_id, ccl_external_id, ccl_gateway_id, ccl_quantity, ccl_amount,
+			ccl_currency, ccl_payment_url, ccl_status, ccl_expires_at, ccl_paid_at,
+			ccl_created_by, ccl_created_at, ccl_updated_at
+		FROM atamlink.catalog_checkout_links
+		WHERE ccl_external_id = $1`
+
+	link, err := database.Get[entity.CatalogCheckoutLink](ctx, r.db, query, externalID)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgCheckoutNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get checkout link")
+	}
+
+	return link, nil
+}
+
+// UpdateCheckoutLinkStatus update status checkout link dari callback gateway
+func (r *catalogRepository) UpdateCheckoutLinkStatus(ctx context.Context, tx *sql.Tx, id int64, status string, paidAt *time.Time) error {
+	query := `
+		UPDATE atamlink.catalog_checkout_links SET
+			ccl_status = $2,
+			ccl_paid_at = $3,
+			ccl_updated_at = $4
+		WHERE ccl_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, status, paidAt, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update checkout link status")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgCheckoutNotFound, 404)
+	}
+
+	return nil
+}
+
+// CreatePriceSchedule simpan jadwal perubahan harga card
+func (r *catalogRepository) CreatePriceSchedule(ctx context.Context, tx *sql.Tx, schedule *entity.CardPriceSchedule) error {
+	query := `
+		INSERT INTO atamlink.catalog_card_price_schedules (
+			ccps_cc_id, ccps_price, ccps_discount, ccps_effective_at,
+			ccps_status, ccps_created_by, ccps_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ccps_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		schedule.CardID,
+		schedule.Price,
+		schedule.Discount,
+		schedule.EffectiveAt,
+		schedule.Status,
+		schedule.CreatedBy,
+		schedule.CreatedAt,
+	).Scan(&schedule.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create price schedule")
+	}
+
+	return nil
+}
+
+// GetPriceScheduleByID get jadwal harga by ID
+func (r *catalogRepository) GetPriceScheduleByID(ctx context.Context, id int64) (*entity.CardPriceSchedule, error) {
+	query := `
+		SELECT
+			ccps_id, ccps_cc_id, ccps_price, ccps_discount, ccps_effective_at, ccps_status,
+			ccps_created_by, ccps_created_at, ccps_applied_at, ccps_cancelled_by, ccps_cancelled_at
+		FROM atamlink.catalog_card_price_schedules
+		WHERE ccps_id = $1`
+
+	schedule, err := database.Get[entity.CardPriceSchedule](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgPriceScheduleNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get price schedule")
+	}
+
+	return schedule, nil
+}
+
+// ListPriceSchedules seluruh jadwal harga card, urut waktu berlaku
+func (r *catalogRepository) ListPriceSchedules(ctx context.Context, cardID int64) ([]*entity.CardPriceSchedule, error) {
+	query := `
+		SELECT
+			ccps_id, ccps_cc_id, ccps_price, ccps_discount, ccps_effective_at, ccps_status,
+			ccps_created_by, ccps_created_at, ccps_applied_at, ccps_cancelled_by, ccps_cancelled_at
+		FROM atamlink.catalog_card_price_schedules
+		WHERE ccps_cc_id = $1
+		ORDER BY ccps_effective_at DESC, ccps_id DESC`
+
+	schedules, err := database.Select[entity.CardPriceSchedule](ctx, r.db, query, cardID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list price schedules")
+	}
+
+	return schedules, nil
+}
+
+// CancelPriceSchedule batalkan jadwal harga, false jika jadwal sudah tidak pending
+func (r *catalogRepository) CancelPriceSchedule(ctx context.Context, tx *sql.Tx, id int64, profileID int64) (bool, error) {
+	query := `
+		UPDATE atamlink.catalog_card_price_schedules SET
+			ccps_status = 'cancelled',
+			ccps_cancelled_by = $2,
+			ccps_cancelled_at = $3
+		WHERE ccps_id = $1 AND ccps_status = 'pending'`
+
+	result, err := tx.ExecContext(ctx, query, id, profileID, time.Now())
+	if err != nil {
+		return false, errors.Wrap(err, "failed to cancel price schedule")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ListDuePriceSchedules jadwal harga pending yang waktu berlakunya sudah lewat
+func (r *catalogRepository) ListDuePriceSchedules(ctx context.Context, now time.Time, limit int) ([]*entity.CardPriceSchedule, error) {
+	query := `
+		SELECT
+			ccps.ccps_id, ccps.ccps_cc_id, ccps.ccps_price, ccps.ccps_discount,
+			ccps.ccps_effective_at, ccps.ccps_status, ccps.ccps_created_by, ccps.ccps_created_at,
+			c.c_id, c.c_b_id
+		FROM atamlink.catalog_card_price_schedules ccps
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = ccps.ccps_cc_id
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		WHERE ccps.ccps_status = 'pending'
+			AND ccps.ccps_effective_at <= $1
+		ORDER BY ccps.ccps_effective_at, ccps.ccps_id
+		LIMIT $2`
+
+	schedules, err := database.Select[entity.CardPriceSchedule](ctx, r.db, query, now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get due price schedules")
+	}
+
+	return schedules, nil
+}
+
+// ApplyPriceSchedule terapkan jadwal ke harga card, false jika jadwal sudah
+// diterapkan atau dibatalkan sebelumnya
+func (r *catalogRepository) ApplyPriceSchedule(ctx context.Context, tx *sql.Tx, schedule *entity.CardPriceSchedule, now time.Time) (bool, error) {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE atamlink.catalog_card_price_schedules SET
+			ccps_status = 'applied',
+			ccps_applied_at = $2
+		WHERE ccps_id = $1 AND ccps_status = 'pending'`,
+		schedule.ID, now,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to apply price schedule")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	// Perubahan harga dicatat atas nama pembuat jadwal
+	_, err = tx.ExecContext(ctx, `
+		UPDATE atamlink.catalog_cards SET
+			cc_price = $2,
+			cc_discount = COALESCE($3, cc_discount),
+			cc_updated_by = $4,
+			cc_updated_at = $5
+		WHERE cc_id = $1`,
+		schedule.CardID, schedule.Price, schedule.Discount, schedule.CreatedBy, now,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to update card price")
+	}
+
+	return true, nil
+}
+
+// ListEndingDiscounts jadwal harga pending yang berlaku sebelum before dan menurunkan
+// diskon card saat ini, belum dikirimi notifikasi
+func (r *catalogRepository) ListEndingDiscounts(ctx context.Context, now, before time.Time, limit int) ([]*entity.DiscountEnding, error) {
+	query := `
+		SELECT
+			ccps.ccps_id, cc.cc_id, cc.cc_title, c.c_title, c.c_b_id,
+			cc.cc_discount, COALESCE(ccps.ccps_discount, cc.cc_discount) AS new_discount, ccps.ccps_effective_at
+		FROM atamlink.catalog_card_price_schedules ccps
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = ccps.ccps_cc_id
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		WHERE ccps.ccps_status = 'pending'
+			AND ccps.ccps_alerted_at IS NULL
+			AND ccps.ccps_effective_at > $1
+			AND ccps.ccps_effective_at <= $2
+			AND cc.cc_discount > 0
+			AND COALESCE(ccps.ccps_discount, cc.cc_discount) < cc.cc_discount
+		ORDER BY ccps.ccps_effective_at, ccps.ccps_id
+		LIMIT $3`
+
+	endings, err := database.Select[entity.DiscountEnding](ctx, r.db, query, now, before, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get ending discounts")
+	}
+
+	return endings, nil
+}
+
+// MarkDiscountAlerted tandai notifikasi diskon berakhir sudah dikirim, false jika
+// sudah ditandai proses lain
+func (r *catalogRepository) MarkDiscountAlerted(ctx context.Context, scheduleID int64, now time.Time) (bool, error) {
+	query := `
+		UPDATE atamlink.catalog_card_price_schedules
+		SET ccps_alerted_at = $2
+		WHERE ccps_id = $1 AND ccps_alerted_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, scheduleID, now)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to mark discount alerted")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UpdateStatus update status publish katalog, published_at/by diisi saat published.
+// Setiap perubahan status membatalkan jadwal publish yang ada
+func (r *catalogRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, id int64, status string, profileID int64) error {
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_status = $2,
+			c_published_at = CASE WHEN $2 = 'published' THEN $4 ELSE c_published_at END,
+			c_published_by = CASE WHEN $2 = 'published' THEN $3 ELSE c_published_by END,
+			c_publish_scheduled_at = NULL,
+			c_publish_scheduled_by = NULL,
+			c_updated_by = $3,
+			c_updated_at = $4
+		WHERE c_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, status, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update catalog status")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	return nil
+}
+
+// SetPublishSchedule set atau hapus (publishAt nil) jadwal publish katalog
+func (r *catalogRepository) SetPublishSchedule(ctx context.Context, tx *sql.Tx, id int64, publishAt *time.Time, profileID int64) error {
+	var scheduledBy sql.NullInt64
+	if publishAt != nil {
+		scheduledBy = database.NullInt64(profileID)
+	}
+
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_publish_scheduled_at = $2,
+			c_publish_scheduled_by = $3,
+			c_updated_by = $4,
+			c_updated_at = $5
+		WHERE c_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, publishAt, scheduledBy, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to set publish schedule")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	return nil
+}
+
+// ListDueScheduledPublish katalog draft yang jadwal publish-nya sudah lewat
+func (r *catalogRepository) ListDueScheduledPublish(ctx context.Context, now time.Time, limit int) ([]*entity.Catalog, error) {
+	query := `
+		SELECT c_id, c_b_id, c_slug, c_title, c_publish_scheduled_at, c_publish_scheduled_by
+		FROM atamlink.catalogs
+		WHERE c_publish_scheduled_at IS NOT NULL
+			AND c_publish_scheduled_at <= $1
+			AND c_status = 'draft'
+		ORDER BY c_publish_scheduled_at
+		LIMIT $2`
+
+	catalogs, err := database.Select[entity.Catalog](ctx, r.db, query, now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get scheduled catalogs")
+	}
+
+	return catalogs, nil
+}
+
+// ListAbandonedDrafts katalog draft yang belum pernah dipublish sejak dibuat sebelum
+// before dan belum dikirimi pengingat
+func (r *catalogRepository) ListAbandonedDrafts(ctx context.Context, before time.Time, limit int) ([]*entity.Catalog, error) {
+	query := `
+		SELECT c.c_id, c.c_b_id, c.c_slug, c.c_title, c.c_created_at
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_status = 'draft'
+			AND c.c_published_at IS NULL
+			AND c.c_draft_reminded_at IS NULL
+			AND c.c_publish_scheduled_at IS NULL
+			AND c.c_archived_at IS NULL
+			AND c.c_created_at < $1
+			AND b.b_is_active = true
+		ORDER BY c.c_created_at
+		LIMIT $2`
+
+	catalogs, err := database.Select[entity.Catalog](ctx, r.db, query, before, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get abandoned drafts")
+	}
+
+	return catalogs, nil
+}
+
+// MarkDraftReminded tandai pengingat draft terkirim, false jika sudah ditandai proses lain
+func (r *catalogRepository) MarkDraftReminded(ctx context.Context, id int64, now time.Time) (bool, error) {
+	query := `
+		UPDATE atamlink.catalogs SET c_draft_reminded_at = $2
+		WHERE c_id = $1 AND c_draft_reminded_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, now)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to mark draft reminded")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// UpdateQRURL simpan URL file QR code katalog
+func (r *catalogRepository) UpdateQRURL(ctx context.Context, id int64, qrURL string) error {
+	query := `UPDATE atamlink.catalogs SET c_qr_url = $2 WHERE c_id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, qrURL)
+	if err != nil {
+		return errors.Wrap(err, "failed to update catalog qr url")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	return nil
+}
+
+// ListInactiveCatalogs katalog yang belum diarsipkan dan tidak diubah maupun
+// dikunjungi sejak before
+func (r *catalogRepository) ListInactiveCatalogs(ctx context.Context, before time.Time, limit int) ([]int64, error) {
+	query := `
+		SELECT c.c_id
+		FROM atamlink.catalogs c
+		WHERE c.c_archived_at IS NULL
+			AND COALESCE(c.c_updated_at, c.c_created_at) < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM atamlink.catalog_sections cs
+				WHERE cs.cs_c_id = c.c_id
+					AND COALESCE(cs.cs_updated_at, cs.cs_created_at) >= $1
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM atamlink.catalog_daily_stats cds
+				WHERE cds.cds_c_id = c.c_id AND cds.cds_date >= $1::date
+			)
+		ORDER BY c.c_id
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list inactive catalogs")
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, "failed to scan inactive catalog")
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// GetPublicVersion sidik konten halaman publik katalog: waktu ubah katalog dan
+// business, rating, serta waktu ubah terakhir & jumlah baris setiap child.
+// Berubah setiap ada insert, update atau delete yang tampil di halaman publik
+func (r *catalogRepository) GetPublicVersion(ctx context.Context, catalogID int64) (string, error) {
+	query := `
+		SELECT concat_ws('|',
+			c.c_updated_at, c.c_status, c.c_rating_count, c.c_rating_avg, b.b_updated_at,
+			(SELECT concat_ws(':', MAX(COALESCE(cs_updated_at, cs_created_at)), COUNT(*))
+				FROM atamlink.catalog_sections WHERE cs_c_id = c.c_id),
+			(SELECT concat_ws(':', MAX(COALESCE(cc.cc_updated_at, cc.cc_created_at)), COUNT(*))
+				FROM atamlink.catalog_cards cc
+				INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+				WHERE cs.cs_c_id = c.c_id),
+			(SELECT concat_ws(':', MAX(COALESCE(ccd_updated_at, ccd_created_at)), COUNT(*))
+				FROM atamlink.catalog_card_details WHERE ccd_c_id = c.c_id),
+			(SELECT concat_ws(':', MAX(COALESCE(ccm.ccm_updated_at, ccm.ccm_created_at)), COUNT(*))
+				FROM atamlink.catalog_card_media ccm
+				INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = ccm.ccm_cc_id
+				INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+				WHERE cs.cs_c_id = c.c_id),
+			(SELECT concat_ws(':', MAX(COALESCE(ccl.ccl_updated_at, ccl.ccl_created_at)), COUNT(*))
+				FROM atamlink.catalog_card_links ccl
+				INNER JOIN atamlink.catalog_card_details ccd ON ccd.ccd_id = ccl.ccl_ccd_id
+				WHERE ccd.ccd_c_id = c.c_id),
+			(SELECT concat_ws(':', MAX(COALESCE(cf.cf_updated_at, cf.cf_created_at)), COUNT(*))
+				FROM atamlink.catalog_faqs cf
+				INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cf.cf_cs_id
+				WHERE cs.cs_c_id = c.c_id),
+			(SELECT concat_ws(':', MAX(clv.clv_created_at), COUNT(*))
+				FROM atamlink.catalog_legal_versions clv
+				INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = clv.clv_cs_id
+				WHERE cs.cs_c_id = c.c_id),
+			(SELECT concat_ws(':', MAX(COALESCE(ct_updated_at, ct_created_at)), COUNT(*))
+				FROM atamlink.catalog_tags WHERE ct_c_id = c.c_id),
+			-- Relasi card-tag tidak punya waktu ubah, checksum pasangan ID
+			(SELECT concat_ws(':', COALESCE(SUM(cct.cct_cc_id * 1000003 + cct.cct_ct_id), 0), COUNT(*))
+				FROM atamlink.catalog_card_tags cct
+				INNER JOIN atamlink.catalog_tags ct ON ct.ct_id = cct.cct_ct_id
+				WHERE ct.ct_c_id = c.c_id)
+		)
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_id = $1`
+
+	var version string
+	err := r.db.QueryRowContext(ctx, query, catalogID).Scan(&version)
+	if err == sql.ErrNoRows {
+		return "", errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get catalog public version")
+	}
+
+	return version, nil
+}
+
+// ListPublicSlugs slug semua katalog yang tampil publik untuk snapshot
+func (r *catalogRepository) ListPublicSlugs(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT c.c_slug
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_is_active = true AND c.c_status = $1
+			AND c.c_archived_at IS NULL AND b.b_is_active = true
+		ORDER BY c.c_id`
+
+	rows, err := r.db.QueryContext(ctx, query, constant.CatalogStatusPublished)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list public slugs")
+	}
+	defer rows.Close()
+
+	slugs := make([]string, 0)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, errors.Wrap(err, "failed to scan public slug")
+		}
+		slugs = append(slugs, slug)
+	}
+
+	return slugs, rows.Err()
+}
+
+// StreamSitemapEntries iterasi URL katalog publik yang boleh diindex beserta detail
+// card yang tampil tanpa menampung semuanya di memori. businessID 0 = semua business
+func (r *catalogRepository) StreamSitemapEntries(ctx context.Context, businessID int64, limit int, fn func(entry *entity.SitemapEntry) error) error {
+	query := `
+		WITH public_catalogs AS (
+			SELECT c.c_id, c.c_slug, COALESCE(c.c_updated_at, c.c_published_at, c.c_created_at) AS lastmod
+			FROM atamlink.catalogs c
+			INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+			WHERE c.c_is_active = true AND c.c_status = $1 AND c.c_allow_indexing = true
+				AND c.c_archived_at IS NULL AND b.b_is_active = true
+				AND ($2 = 0 OR c.c_b_id = $2)
+		)
+		SELECT c_slug, '' AS card_slug, lastmod, c_id
+		FROM public_catalogs
+		UNION ALL
+		SELECT pc.c_slug, ccd.ccd_slug,
+			GREATEST(COALESCE(ccd.ccd_updated_at, ccd.ccd_created_at), COALESCE(cc.cc_updated_at, cc.cc_created_at)),
+			pc.c_id
+		FROM public_catalogs pc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_c_id = pc.c_id
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_cs_id = cs.cs_id
+		INNER JOIN atamlink.catalog_card_details ccd ON ccd.ccd_cc_id = cc.cc_id
+		WHERE cs.cs_is_visible = true AND cs.cs_type = $3
+			AND cc.cc_is_visible = true AND cc.cc_has_detail = true AND ccd.ccd_is_visible = true
+			AND NOT (cc.cc_hide_when_sold_out AND (cc.cc_sold_out OR COALESCE(cc.cc_stock = 0, false)))
+		ORDER BY 4, 2
+		LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, constant.CatalogStatusPublished, businessID, constant.SectionTypeCards, limit)
+	if err != nil {
+		return errors.Wrap(err, "failed to query sitemap entries")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := &sitemapRow{}
+		if err := database.ScanRow(rows, row); err != nil {
+			return errors.Wrap(err, "failed to scan sitemap entry")
+		}
+		if err := fn(&row.SitemapEntry); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// sitemapRow entry sitemap beserta katalognya, c_id hanya untuk urutan
+type sitemapRow struct {
+	entity.SitemapEntry
+	CatalogID int64 `db:"c_id"`
+}
+
+// MarkArchived tandai katalog sudah diarsipkan, false jika sudah diarsipkan proses lain
+func (r *catalogRepository) MarkArchived(ctx context.Context, tx *sql.Tx, id int64, key string, now time.Time) (bool, error) {
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_archived_at = $2,
+			c_archive_key = $3
+		WHERE c_id = $1 AND c_archived_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, id, now, key)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to mark catalog archived")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ClearArchived hapus tanda arsip, false jika katalog sudah dipulihkan proses lain
+func (r *catalogRepository) ClearArchived(ctx context.Context, tx *sql.Tx, id int64) (bool, error) {
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_archived_at = NULL,
+			c_archive_key = NULL
+		WHERE c_id = $1 AND c_archived_at IS NOT NULL`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to clear catalog archive")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetSearchDocuments dokumen search katalog, katalog yang tidak ada tidak ikut dikembalikan
+func (r *catalogRepository) GetSearchDocuments(ctx context.Context, ids []int64) ([]*entity.SearchDocument, error) {
+	query := `
+		SELECT
+			c.c_id, c.c_b_id, c.c_slug, c.c_title, COALESCE(c.c_subtitle, '') AS c_subtitle,
+			c.c_is_active,
+			COALESCE((
+				SELECT string_agg(cc.cc_title || ' ' || COALESCE(cc.cc_subtitle, ''), ' ')
+				FROM atamlink.catalog_cards cc
+				INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+				WHERE cs.cs_c_id = c.c_id AND cs.cs_is_visible = true AND cc.cc_is_visible = true
+			), '') || ' ' ||
+			COALESCE((
+				SELECT string_agg(cf.cf_question, ' ')
+				FROM atamlink.catalog_faqs cf
+				INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cf.cf_cs_id
+				WHERE cs.cs_c_id = c.c_id AND cs.cs_is_visible = true
+			), '') AS content
+		FROM atamlink.catalogs c
+		WHERE c.c_id = ANY($1)`
+
+	docs, err := database.Select[entity.SearchDocument](ctx, r.db, query, pq.Array(ids))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query search documents")
+	}
+
+	for _, doc := range docs {
+		doc.Content = strings.TrimSpace(doc.Content)
+	}
+
+	return docs, nil
+}
+
+// ListIDsAfter ID katalog urut naik setelah afterID, untuk reindex bertahap
+func (r *catalogRepository) ListIDsAfter(ctx context.Context, afterID int64, limit int) ([]int64, error) {
+	query := `
+		SELECT c_id
+		FROM atamlink.catalogs
+		WHERE c_id > $1
+		ORDER BY c_id
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list catalog ids")
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, "failed to scan catalog id")
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// SearchDashboard full-text search katalog (judul, subtitle, slug) dan judul card di
+// business milik user. Katalog dengan card cocok ikut tampil meski katalognya tidak
+// cocok; kecocokan di katalog bobotnya lebih tinggi dari kecocokan card
+func (r *catalogRepository) SearchDashboard(ctx context.Context, filter DashboardSearchFilter) ([]*entity.CatalogSearchHit, int64, error) {
+	hits := make([]*entity.CatalogSearchHit, 0)
+	tsQuery := prefixTSQuery(filter.Search)
+	if tsQuery == "" || len(filter.BusinessIDs) == 0 {
+		return hits, 0, nil
+	}
+	// Card hanya dicocokkan ke judul (bobot A di cc_search)
+	cardTSQuery := strings.ReplaceAll(tsQuery, ":*", ":*A")
+
+	from := `
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		CROSS JOIN to_tsquery('simple', $2) q
+		CROSS JOIN to_tsquery('simple', $3) cq
+		LEFT JOIN LATERAL (
+			SELECT MAX(ts_rank(cc.cc_search, cq)) AS rank
+			FROM atamlink.catalog_cards cc
+			INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+			WHERE cs.cs_c_id = c.c_id AND cc.cc_search @@ cq
+		) card ON TRUE
+		WHERE c.c_b_id = ANY($1) AND c.c_deleted_at IS NULL
+			AND (c.c_search @@ q OR card.rank IS NOT NULL)`
+	args := []interface{}{pq.Array(filter.BusinessIDs), tsQuery, cardTSQuery}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&total); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count dashboard search results")
+	}
+	if total == 0 {
+		return hits, 0, nil
+	}
+
+	query := `
+		SELECT c.c_id, c.c_b_id, c.c_slug, c.c_title, c.c_subtitle, c.c_is_active,
+			c.c_status, c.c_archived_at, c.c_updated_at, b.b_name,
+			ts_rank(c.c_search, q) * 2 + COALESCE(card.rank, 0) AS rank` + from + `
+		ORDER BY rank DESC, COALESCE(c.c_updated_at, c.c_created_at) DESC, c.c_id DESC
+		LIMIT $4 OFFSET $5`
+
+	hits, err := database.Select[entity.CatalogSearchHit](ctx, r.db, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to search catalogs")
+	}
+
+	byID := make(map[int64]*entity.CatalogSearchHit)
+	catalogIDs := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		catalog := hit.Catalog
+		catalog.Business.ID = catalog.BusinessID
+		hit.Cards = make([]*entity.CatalogCard, 0)
+		byID[catalog.ID] = hit
+		catalogIDs = append(catalogIDs, catalog.ID)
+	}
+
+	if filter.CardsPerItem <= 0 || len(catalogIDs) == 0 {
+		return hits, total, nil
+	}
+
+	// Card cocok per katalog di halaman ini, paling relevan lebih dulu
+	cardQuery := `
+		SELECT c_id, cc_id, cc_cs_id, cc_title, cc_is_visible
+		FROM (
+			SELECT cs.cs_c_id AS c_id, cc.cc_id, cc.cc_cs_id, cc.cc_title, cc.cc_is_visible,
+				ROW_NUMBER() OVER (
+					PARTITION BY cs.cs_c_id
+					ORDER BY ts_rank(cc.cc_search, cq) DESC, cs.cs_position, cc.cc_position, cc.cc_id
+				) AS rn
+			FROM atamlink.catalog_cards cc
+			INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+			CROSS JOIN to_tsquery('simple', $2) cq
+			WHERE cs.cs_c_id = ANY($1) AND cc.cc_search @@ cq
+		) matched
+		WHERE rn <= $3
+		ORDER BY c_id, rn`
+
+	cards, err := database.Select[catalogCardRow](ctx, r.db, cardQuery, pq.Array(catalogIDs), cardTSQuery, filter.CardsPerItem)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to search catalog cards")
+	}
+
+	for _, row := range cards {
+		if hit, ok := byID[row.CatalogID]; ok {
+			hit.Cards = append(hit.Cards, &row.CatalogCard)
+		}
+	}
+
+	return hits, total, nil
+}
+
+// ListDirectory katalog published yang ikut direktori publik, business aktif dan
+// tidak disuspend; popularitas dari total view 30 hari terakhir
+func (r *catalogRepository) ListDirectory(ctx context.Context, filter DirectoryFilter) ([]*entity.DirectoryEntry, int64, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(
+		"c.c_id", "c.c_slug", "c.c_title", "c.c_subtitle", "c.c_published_at",
+		"b.b_id", "b.b_name", "b.b_slug", "b.b_logo_url", "b.b_type", "b.b_city",
+		"mc.mc_slug", "mc.mc_name", "c.c_rating_avg", "c.c_rating_count",
+		"COALESCE(st.views, 0) AS views",
+	).From("atamlink.catalogs c")
+	qb.InnerJoin("atamlink.businesses b", "b.b_id = c.c_b_id")
+	// Kategori katalog menggantikan kategori business, kategori nonaktif tidak ditampilkan
+	qb.LeftJoin("atamlink.master_categories mc", "mc.mc_id = COALESCE(c.c_mc_id, b.b_mc_id) AND mc.mc_is_active = true")
+
+	qb.Where("c.c_listed = true")
+	qb.Where("c.c_is_active = true")
+	qb.Where("c.c_status = ?", constant.CatalogStatusPublished)
+	qb.Where("c.c_archived_at IS NULL")
+	qb.Where("b.b_is_active = true AND b.b_is_suspended = false")
+
+	if filter.Search != "" {
+		tsQuery := prefixTSQuery(filter.Search)
+		if tsQuery == "" {
+			qb.Where("FALSE")
+		} else {
+			qb.Where("c.c_search @@ to_tsquery('simple', ?)", tsQuery)
+		}
+	}
+
+	if filter.Category != "" {
+		qb.Where("mc.mc_slug = ?", filter.Category)
+	}
+
+	if filter.Type != "" {
+		qb.Where("b.b_type = ?", filter.Type)
+	}
+
+	if filter.City != "" {
+		qb.Where("LOWER(b.b_city) = LOWER(?)", filter.City)
+	}
+
+	// Count total
+	countQuery, countArgs := qb.BuildCount()
+	var total int64
+	if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count directory catalogs")
+	}
+
+	// Join statistik setelah count, total tidak bergantung pada view
+	qb.Join("LEFT", `LATERAL (
+		SELECT SUM(cds_views) AS views
+		FROM atamlink.catalog_daily_stats
+		WHERE cds_c_id = c.c_id AND cds_date >= CURRENT_DATE - 30
+	) st`, "TRUE")
+	qb.OrderBy(filter.OrderBy + ", c.c_id DESC")
+	qb.Limit(filter.Limit)
+	qb.Offset(filter.Offset)
+
+	query, args := qb.Build()
+	entries, err := database.Select[entity.DirectoryEntry](ctx, r.db, query, args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to query directory catalogs")
+	}
+
+	for _, entry := range entries {
+		entry.Catalog.BusinessID = entry.Catalog.Business.ID
+	}
+
+	return entries, total, nil
+}
+
+// IsCategoryActive check apakah kategori master ada dan aktif
+func (r *catalogRepository) IsCategoryActive(ctx context.Context, categoryID int64) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM atamlink.master_categories WHERE mc_id = $1 AND mc_is_active = true)`
+
+	var active bool
+	if err := r.db.QueryRowContext(ctx, query, categoryID).Scan(&active); err != nil {
+		return false, errors.Wrap(err, "failed to check category")
+	}
+
+	return active, nil
+}
+
+// GetThemeDefaultSettings default settings theme yang masih aktif
+func (r *catalogRepository) GetThemeDefaultSettings(ctx context.Context, themeID int64) (map[string]interface{}, error) {
+	query := `
+		SELECT mt_default_settings
+		FROM atamlink.master_themes
+		WHERE mt_id = $1 AND mt_is_active = true`
+
+	var settingsJSON []byte
+	err := r.db.QueryRowContext(ctx, query, themeID).Scan(&settingsJSON)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgThemeNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get theme settings")
+	}
+
+	settings := make(map[string]interface{})
+	if len(settingsJSON) > 0 {
+		if err := json.Unmarshal(settingsJSON, &settings); err != nil {
+			return nil, errors.Wrap(err, "failed to parse theme settings")
+		}
+	}
+
+	return settings, nil
+}
+
+// ApplySettingsToBusiness timpa key settings di semua katalog business,
+// key lain di settings katalog tetap. Mengembalikan slug katalog yang berubah.
+func (r *catalogRepository) ApplySettingsToBusiness(ctx context.Context, tx *sql.Tx, businessID int64, settings map[string]interface{}, profileID int64) ([]string, error) {
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal settings")
+	}
+
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_settings = COALESCE(c_settings, '{}'::jsonb) || $2::jsonb,
+			c_updated_by = $3,
+			c_updated_at = $4
+		WHERE c_b_id = $1
+		RETURNING c_slug`
+
+	rows, err := tx.QueryContext(ctx, query, businessID, settingsJSON, profileID, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply catalog settings")
+	}
+	defer rows.Close()
+
+	var slugs []string
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, errors.Wrap(err, "failed to scan catalog slug")
+		}
+		slugs = append(slugs, slug)
+	}
+
+	return slugs, rows.Err()
+}
+
+// PublishScheduled publish katalog sesuai jadwal, false jika jadwal sudah
+// dibatalkan atau sudah diproses instance lain
+func (r *catalogRepository) PublishScheduled(ctx context.Context, tx *sql.Tx, id int64, now time.Time) (bool, error) {
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_status = 'published',
+			c_published_at = $2,
+			c_published_by = c_publish_scheduled_by,
+			c_publish_scheduled_at = NULL,
+			c_publish_scheduled_by = NULL,
+			c_updated_at = $2
+		WHERE c_id = $1
+			AND c_status = 'draft'
+			AND c_publish_scheduled_at IS NOT NULL
+			AND c_publish_scheduled_at <= $2`
+
+	result, err := tx.ExecContext(ctx, query, id, now)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to publish scheduled catalog")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check rows affected")
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// SetCatalogVisibilitySchedule set atau hapus (keduanya nil) jadwal tampil/sembunyi
+// katalog. Katalog dengan jadwal tampil disembunyikan sampai waktunya tiba
+func (r *catalogRepository) SetCatalogVisibilitySchedule(ctx context.Context, tx *sql.Tx, id int64, publishAt, unpublishAt *time.Time, profileID int64) error {
+	var scheduledBy sql.NullInt64
+	if publishAt != nil || unpublishAt != nil {
+		scheduledBy = database.NullInt64(profileID)
+	}
+
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_publish_at = $2,
+			c_unpublish_at = $3,
+			c_visibility_scheduled_by = $4,
+			c_is_active = CASE WHEN $2::timestamp IS NOT NULL THEN false ELSE c_is_active END,
+			c_updated_by = $5,
+			c_updated_at = $6
+		WHERE c_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, publishAt, unpublishAt, scheduledBy, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to set catalog visibility schedule")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	return nil
+}
+
+// ListDueCatalogVisibility katalog yang jadwal tampil atau sembunyinya sudah lewat
+func (r *catalogRepository) ListDueCatalogVisibility(ctx context.Context, now time.Time, limit int) ([]*entity.VisibilityDue, error) {
+	query := `
+		SELECT c_id AS id, c_id, c_b_id, c_publish_at AS publish_at, c_unpublish_at AS unpublish_at,
+			c_visibility_scheduled_by AS scheduled_by
+		FROM atamlink.catalogs
+		WHERE c_publish_at <= $1 OR c_unpublish_at <= $1
+		ORDER BY LEAST(c_publish_at, c_unpublish_at)
+		LIMIT $2`
+
+	return r.listDueVisibility(ctx, query, now, limit)
+}
+
+// ListDueCardVisibility card yang jadwal tampil atau sembunyinya sudah lewat
+func (r *catalogRepository) ListDueCardVisibility(ctx context.Context, now time.Time, limit int) ([]*entity.VisibilityDue, error) {
+	query := `
+		SELECT cc.cc_id AS id, c.c_id, c.c_b_id, cc.cc_publish_at AS publish_at, cc.cc_unpublish_at AS unpublish_at,
+			cc.cc_visibility_scheduled_by AS scheduled_by
+		FROM atamlink.catalog_cards cc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		WHERE cc.cc_publish_at <= $1 OR cc.cc_unpublish_at <= $1
+		ORDER BY LEAST(cc.cc_publish_at, cc.cc_unpublish_at)
+		LIMIT $2`
+
+	return r.listDueVisibility(ctx, query, now, limit)
+}
+
+func (r *catalogRepository) listDueVisibility(ctx context.Context, query string, now time.Time, limit int) ([]*entity.VisibilityDue, error) {
+	due, err := database.Select[entity.VisibilityDue](ctx, r.db, query, now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get due visibility schedules")
+	}
+
+	return due, nil
+}
+
+// ApplyCatalogVisibility terapkan jadwal tampil/sembunyi katalog yang sudah lewat dan
+// kembalikan c_is_active baru. Jika keduanya lewat (mis. scheduler sempat mati),
+// jadwal sembunyi yang menang. ok false jika sudah diproses instance lain
+func (r *catalogRepository) ApplyCatalogVisibility(ctx context.Context, tx *sql.Tx, id int64, now time.Time) (bool, bool, error) {
+	query := `
+		UPDATE atamlink.catalogs SET
+			c_is_active = CASE WHEN c_unpublish_at <= $2 THEN false ELSE true END,
+			c_publish_at = CASE WHEN c_publish_at <= $2 THEN NULL ELSE c_publish_at END,
+			c_unpublish_at = CASE WHEN c_unpublish_at <= $2 THEN NULL ELSE c_unpublish_at END,
+			c_visibility_scheduled_by = CASE
+				WHEN c_publish_at > $2 OR c_unpublish_at > $2 THEN c_visibility_scheduled_by
+			END,
+			c_updated_at = $2
+		WHERE c_id = $1
+			AND (c_publish_at <= $2 OR c_unpublish_at <= $2)
+		RETURNING c_is_active`
+
+	return r.applyVisibility(ctx, tx, query, id, now)
+}
+
+// ApplyCardVisibility terapkan jadwal tampil/sembunyi card, aturannya sama dengan
+// ApplyCatalogVisibility
+func (r *catalogRepository) ApplyCardVisibility(ctx context.Context, tx *sql.Tx, id int64, now time.Time) (bool, bool, error) {
+	query := `
+		UPDATE atamlink.catalog_cards SET
+			cc_is_visible = CASE WHEN cc_unpublish_at <= $2 THEN false ELSE true END,
+			cc_publish_at = CASE WHEN cc_publish_at <= $2 THEN NULL ELSE cc_publish_at END,
+			cc_unpublish_at = CASE WHEN cc_unpublish_at <= $2 THEN NULL ELSE cc_unpublish_at END,
+			cc_visibility_scheduled_by = CASE
+				WHEN cc_publish_at > $2 OR cc_unpublish_at > $2 THEN cc_visibility_scheduled_by
+			END,
+			cc_updated_at = $2
+		WHERE cc_id = $1
+			AND (cc_publish_at <= $2 OR cc_unpublish_at <= $2)
+		RETURNING cc_is_visible`
+
+	return r.applyVisibility(ctx, tx, query, id, now)
+}
+
+func (r *catalogRepository) applyVisibility(ctx context.Context, tx *sql.Tx, query string, id int64, now time.Time) (bool, bool, error) {
+	var visible bool
+	err := tx.QueryRowContext(ctx, query, id, now).Scan(&visible)
+	if err == sql.ErrNoRows {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, errors.Wrap(err, "failed to apply visibility schedule")
+	}
+
+	return visible, true, nil
+}
+
+// SetCardVisibilitySchedule set atau hapus (keduanya nil) jadwal tampil/sembunyi
+// card. Card dengan jadwal tampil disembunyikan sampai waktunya tiba
+func (r *catalogRepository) SetCardVisibilitySchedule(ctx context.Context, tx *sql.Tx, id int64, publishAt, unpublishAt *time.Time, profileID int64) error {
+	var scheduledBy sql.NullInt64
+	if publishAt != nil || unpublishAt != nil {
+		scheduledBy = database.NullInt64(profileID)
+	}
+
+	query := `
+		UPDATE atamlink.catalog_cards SET
+			cc_publish_at = $2,
+			cc_unpublish_at = $3,
+			cc_visibility_scheduled_by = $4,
+			cc_is_visible = CASE WHEN $2::timestamp IS NOT NULL THEN false ELSE cc_is_visible END,
+			cc_updated_by = $5,
+			cc_updated_at = $6
+		WHERE cc_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, publishAt, unpublishAt, scheduledBy, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to set card visibility schedule")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
+	}
+
+	return nil
+}
+
+// UpdateCardStock set pengaturan stok card, stock NULL berarti stok tidak dilacak
+func (r *catalogRepository) UpdateCardStock(ctx context.Context, tx *sql.Tx, id int64, stock sql.NullInt64, soldOut, hideWhenSoldOut bool, profileID int64) error {
+	query := `
+		UPDATE atamlink.catalog_cards SET
+			cc_stock = $2,
+			cc_sold_out = $3,
+			cc_hide_when_sold_out = $4,
+			cc_updated_by = $5,
+			cc_updated_at = $6
+		WHERE cc_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id, stock, soldOut, hideWhenSoldOut, profileID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update card stock")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrCardNotFound, constant.ErrMsgCardNotFound, 404)
+	}
+
+	return nil
+}
+
+// AdjustCardStock tambah/kurangi stok card secara atomik dan kembalikan stok baru.
+// Gagal jika stok tidak dilacak atau hasilnya kurang dari 0
+func (r *catalogRepository) AdjustCardStock(ctx context.Context, tx *sql.Tx, id int64, delta int64, profileID int64) (int64, error) {
+	query := `
+		UPDATE atamlink.catalog_cards SET
+			cc_stock = cc_stock + $2,
+			cc_updated_by = $3,
+			cc_updated_at = $4
+		WHERE cc_id = $1 AND cc_stock IS NOT NULL AND cc_stock + $2 >= 0
+		RETURNING cc_stock`
+
+	var stock int64
+	err := tx.QueryRowContext(ctx, query, id, delta, profileID, time.Now()).Scan(&stock)
+	if err == sql.ErrNoRows {
+		return 0, errors.New(errors.ErrValidation, constant.ErrMsgCardStockNegative, 400)
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to adjust card stock")
+	}
+
+	return stock, nil
+}
+
+// CreatePublishRequest create pengajuan publish
+func (r *catalogRepository) CreatePublishRequest(ctx context.Context, tx *sql.Tx, request *entity.CatalogPublishRequest) error {
+	query := `
+		INSERT INTO atamlink.catalog_publish_requests (
+			cpr_c_id, cpr_status, cpr_note, cpr_requested_by, cpr_requested_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING cpr_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		request.CatalogID,
+		request.Status,
+		request.Note,
+		request.RequestedBy,
+		request.RequestedAt,
+	).Scan(&request.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create publish request")
+	}
+
+	return nil
+}
+
+const publishRequestQuery = `
+	SELECT
+		cpr.cpr_id, cpr.cpr_c_id, cpr.cpr_status, cpr.cpr_note, cpr.cpr_review_comment,
+		cpr.cpr_requested_by, cpr.cpr_requested_at, cpr.cpr_reviewed_by, cpr.cpr_reviewed_at,
+		req.up_display_name AS requester_name, rev.up_display_name AS reviewer_name
+	FROM atamlink.catalog_publish_requests cpr
+	LEFT JOIN atamlink.user_profiles req ON req.up_id = cpr.cpr_requested_by
+	LEFT JOIN atamlink.user_profiles rev ON rev.up_id = cpr.cpr_reviewed_by`
+
+// GetPublishRequestByID get pengajuan publish by ID
+func (r *catalogRepository) GetPublishRequestByID(ctx context.Context, id int64) (*entity.CatalogPublishRequest, error) {
+	query := publishRequestQuery + `
+		WHERE cpr.cpr_id = $1`
+
+	request, err := database.Get[entity.CatalogPublishRequest](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgPublishRequestNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get publish request")
+	}
+
+	return request, nil
+}
+
+// ListPublishRequests riwayat pengajuan publish katalog, terbaru dulu
+func (r *catalogRepository) ListPublishRequests(ctx context.Context, catalogID int64) ([]*entity.CatalogPublishRequest, error) {
+	query := publishRequestQuery + `
+		WHERE cpr.cpr_c_id = $1
+		ORDER BY cpr.cpr_requested_at DESC, cpr.cpr_id DESC`
+
+	requests, err := database.Select[entity.CatalogPublishRequest](ctx, r.db, query, catalogID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get publish requests")
+	}
+
+	return requests, nil
+}
+
+// ReviewPublishRequest simpan hasil review, hanya untuk pengajuan yang masih pending
+func (r *catalogRepository) ReviewPublishRequest(ctx context.Context, tx *sql.Tx, id int64, status, comment string, reviewerID int64) error {
+	query := `
+		UPDATE atamlink.catalog_publish_requests SET
+			cpr_status = $2,
+			cpr_review_comment = $3,
+			cpr_reviewed_by = $4,
+			cpr_reviewed_at = $5
+		WHERE cpr_id = $1 AND cpr_status = 'pending'`
+
+	result, err := tx.ExecContext(ctx, query, id, status, database.NullString(comment), reviewerID, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to review publish request")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrConflict, constant.ErrMsgPublishRequestReviewed, 409)
+	}
+
+	return nil
+}
+
+// CreateCardDetail create card detail
+func (r *catalogRepository) CreateCardDetail(ctx context.Context, tx *sql.Tx, detail *entity.CatalogCardDetail) error {
+	query := `
+		INSERT INTO atamlink.catalog_card_details (
+			ccd_cc_id, ccd_c_id, ccd_slug, ccd_description, ccd_description_html, ccd_is_visible,
+			ccd_created_by, ccd_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ccd_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		detail.CardID,
+		detail.CatalogID,
+		detail.Slug,
+		detail.Description,
+		detail.DescriptionHTML,
+		detail.IsVisible,
+		detail.CreatedBy,
+		detail.CreatedAt,
+	).Scan(&detail.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create card detail")
+	}
+
+	return nil
+}
+
+// GetCardDetailByCardID get card detail by card ID
+func (r *catalogRepository) GetCardDetailByCardID(ctx context.Context, cardID int64) (*entity.CatalogCardDetail, error) {
+	query := `
+		SELECT 
+			ccd_id, ccd_cc_id, ccd_c_id, ccd_slug, ccd_description, ccd_description_html, ccd_is_visible,
+			ccd_created_by, ccd_created_at, ccd_updated_by, ccd_updated_at
+		FROM atamlink.catalog_card_details
+		WHERE ccd_cc_id = $1`
+
+	detail, err := database.Get[entity.CatalogCardDetail](ctx, r.db, query, cardID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card detail")
+	}
+
+	return detail, nil
+}
+
+// UpdateCardDetail update card detail
+func (r *catalogRepository) UpdateCardDetail(ctx context.Context, tx *sql.Tx, detail *entity.CatalogCardDetail) error {
+	query := `
+		UPDATE atamlink.catalog_card_details SET
+			ccd_slug = $2,
+			ccd_description = $3,
+			ccd_description_html = $4,
+			ccd_is_visible = $5,
+			ccd_updated_by = $6,
+			ccd_updated_at = $7
+		WHERE ccd_id = $1`
+
+	result, err := tx.ExecContext(ctx,
+		query,
+		detail.ID,
+		detail.Slug,
+		detail.Description,
+		detail.DescriptionHTML,
+		detail.IsVisible,
+		detail.UpdatedBy,
+		time.Now(),
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to update card detail")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, "Detail card tidak ditemukan", 404)
+	}
+
+	return nil
+}
+
+// IsCardSlugTaken cek slug sudah dipakai card lain di katalog yang sama,
+// termasuk slug lama card lain yang masih dipakai untuk redirect
+func (r *catalogRepository) IsCardSlugTaken(ctx context.Context, catalogID int64, slug string, excludeCardID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM atamlink.catalog_card_details
+			WHERE ccd_c_id = $1 AND ccd_slug = $2 AND ccd_cc_id != $3
+		) OR EXISTS (
+			SELECT 1 FROM atamlink.catalog_card_slug_history
+			WHERE ccsh_c_id = $1 AND ccsh_slug = $2 AND ccsh_cc_id != $3
+		)`
+
+	var taken bool
+	if err := r.db.QueryRowContext(ctx, query, catalogID, slug, excludeCardID).Scan(&taken); err != nil {
+		return false, errors.Wrap(err, "failed to check card slug")
+	}
+	return taken, nil
+}
+
+// GetCardDetailBySlug get detail card berdasarkan slug di katalog, nil jika tidak ada
+func (r *catalogRepository) GetCardDetailBySlug(ctx context.Context, catalogID int64, slug string) (*entity.CatalogCardDetail, error) {
+	var cardID int64
+	query := `SELECT ccd_cc_id FROM atamlink.catalog_card_details WHERE ccd_c_id = $1 AND ccd_slug = $2`
+	err := r.db.QueryRowContext(ctx, query, catalogID, slug).Scan(&cardID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card detail by slug")
+	}
+	return r.GetCardDetailByCardID(ctx, cardID)
+}
+
+// GetCardSlugRedirect slug terbaru untuk slug lama card, kosong jika tidak ada
+func (r *catalogRepository) GetCardSlugRedirect(ctx context.Context, catalogID int64, slug string) (string, error) {
+	query := `
+		SELECT d.ccd_slug
+		FROM atamlink.catalog_card_slug_history h
+		JOIN atamlink.catalog_card_details d ON d.ccd_cc_id = h.ccsh_cc_id
+		WHERE h.ccsh_c_id = $1 AND h.ccsh_slug = $2`
+
+	var current string
+	err := r.db.QueryRowContext(ctx, query, catalogID, slug).Scan(&current)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get card slug redirect")
+	}
+	return current, nil
+}
+
+// AddCardSlugHistory simpan slug lama card setelah rename
+func (r *catalogRepository) AddCardSlugHistory(ctx context.Context, tx *sql.Tx, catalogID, cardID int64, slug string) error {
+	query := `
+		INSERT INTO atamlink.catalog_card_slug_history (ccsh_c_id, ccsh_cc_id, ccsh_slug, ccsh_created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (ccsh_c_id, ccsh_slug) DO UPDATE SET
+			ccsh_cc_id = EXCLUDED.ccsh_cc_id,
+			ccsh_created_at = EXCLUDED.ccsh_created_at`
+
+	if _, err := tx.ExecContext(ctx, query, catalogID, cardID, slug, time.Now()); err != nil {
+		return errors.Wrap(err, "failed to add card slug history")
+	}
+	return nil
+}
+
+// DeleteCardSlugHistory hapus slug dari riwayat saat dipakai lagi sebagai slug aktif
+func (r *catalogRepository) DeleteCardSlugHistory(ctx context.Context, tx *sql.Tx, catalogID int64, slug string) error {
+	query := `DELETE FROM atamlink.catalog_card_slug_history WHERE ccsh_c_id = $1 AND ccsh_slug = $2`
+	if _, err := tx.ExecContext(ctx, query, catalogID, slug); err != nil {
+		return errors.Wrap(err, "failed to delete card slug history")
+	}
+	return nil
+}
+
+// ListCardSlugs slug detail card di katalog, sectionID 0 berarti semua section
+func (r *catalogRepository) ListCardSlugs(ctx context.Context, catalogID, sectionID int64) ([]string, error) {
+	query := `
+		SELECT d.ccd_slug
+		FROM atamlink.catalog_card_details d
+		JOIN atamlink.catalog_cards c ON c.cc_id = d.ccd_cc_id
+		WHERE d.ccd_c_id = $1 AND ($2 = 0 OR c.cc_cs_id = $2)
+		ORDER BY d.ccd_id`
+
+	rows, err := r.db.QueryContext(ctx, query, catalogID, sectionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list card slugs")
+	}
+	defer rows.Close()
+
+	slugs := make([]string, 0)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, errors.Wrap(err, "failed to scan card slug")
+		}
+		slugs = append(slugs, slug)
+	}
+
+	return slugs, rows.Err()
+}
+
+// CreateCardLink create link pada detail card
+func (r *catalogRepository) CreateCardLink(ctx context.Context, tx *sql.Tx, link *entity.CatalogCardLink) error {
+	query := `
+		INSERT INTO atamlink.catalog_card_links (
+			ccl_ccd_id, ccl_type, ccl_url, ccl_utm, ccl_is_visible, ccl_created_by, ccl_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ccl_id, ccl_token`
+
+	utmJSON, err := link.MarshalUTM()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal card link utm")
+	}
+
+	err = tx.QueryRowContext(ctx,
+		query,
+		link.DetailID,
+		link.Type,
+		link.URL,
+		database.NullString(string(utmJSON)),
+		link.IsVisible,
+		link.CreatedBy,
+		link.CreatedAt,
+	).Scan(&link.ID, &link.Token)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create card link")
+	}
+
+	return nil
+}
+
+// GetCardLinkByID get card link by ID
+func (r *catalogRepository) GetCardLinkByID(ctx context.Context, id int64) (*entity.CatalogCardLink, error) {
+	query := `
+		SELECT 
+			ccl_id, ccl_ccd_id, ccl_type, ccl_url, ccl_token, ccl_utm, ccl_is_visible,
+			ccl_created_by, ccl_created_at, ccl_updated_by, ccl_updated_at
+		FROM atamlink.catalog_card_links
+		WHERE ccl_id = $1`
+
+	link, err := database.Get[entity.CatalogCardLink](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgCardLinkNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card link")
+	}
+
+	return link, nil
+}
+
+// GetCardLinksByDetailID get links detail card sesuai urutan dibuat
+func (r *catalogRepository) GetCardLinksByDetailID(ctx context.Context, detailID int64) ([]*entity.CatalogCardLink, error) {
+	query := `
+		SELECT 
+			ccl_id, ccl_ccd_id, ccl_type, ccl_url, ccl_token, ccl_utm, ccl_is_visible,
+			ccl_created_by, ccl_created_at, ccl_updated_by, ccl_updated_at
+		FROM atamlink.catalog_card_links
+		WHERE ccl_ccd_id = $1
+		ORDER BY ccl_id ASC`
+
+	links, err := database.Select[entity.CatalogCardLink](ctx, r.db, query, detailID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card links")
+	}
+
+	return links, nil
+}
+
+// UpdateCardLink update card link
+func (r *catalogRepository) UpdateCardLink(ctx context.Context, tx *sql.Tx, link *entity.CatalogCardLink) error {
+	query := `
+		UPDATE atamlink.catalog_card_links SET
+			ccl_type = $2,
+			ccl_url = $3,
+			ccl_utm = $4,
+			ccl_is_visible = $5,
+			ccl_updated_by = $6,
+			ccl_updated_at = $7
+		WHERE ccl_id = $1`
+
+	utmJSON, err := link.MarshalUTM()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal card link utm")
+	}
+
+	result, err := tx.ExecContext(ctx,
+		query,
+		link.ID,
+		link.Type,
+		link.URL,
+		database.NullString(string(utmJSON)),
+		link.IsVisible,
+		link.UpdatedBy,
+		time.Now(),
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to update card link")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgCardLinkNotFound, 404)
+	}
+
+	return nil
+}
+
+// DeleteCardLink delete card link
+func (r *catalogRepository) DeleteCardLink(ctx context.Context, tx *sql.Tx, id int64) error {
+	query := `DELETE FROM atamlink.catalog_card_links WHERE ccl_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete card link")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgCardLinkNotFound, 404)
+	}
+
+	return nil
+}
+
+// DeleteCardLinksByDetailID hapus semua link detail card, dipakai saat links diganti
+func (r *catalogRepository) DeleteCardLinksByDetailID(ctx context.Context, tx *sql.Tx, detailID int64) error {
+	query := `DELETE FROM atamlink.catalog_card_links WHERE ccl_ccd_id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, detailID); err != nil {
+		return errors.Wrap(err, "failed to delete card links")
+	}
+
+	return nil
+}
+
+// CreateCardMedia create card media
+func (r *catalogRepository) CreateCardMedia(ctx context.Context, tx *sql.Tx, media *entity.CatalogCardMedia) error {
+	query := `
+		INSERT INTO atamlink.catalog_card_media (
+			ccm_cc_id, ccm_type, ccm_url, ccm_created_by, ccm_created_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING ccm_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		media.CardID,
+		media.Type,
+		media.URL,
+		media.CreatedBy,
+		media.CreatedAt,
+	).Scan(&media.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create card media")
+	}
+
+	return nil
+}
+
+// GetCardMediaByCardID get card media by card ID
+func (r *catalogRepository) GetCardMediaByCardID(ctx context.Context, cardID int64) ([]*entity.CatalogCardMedia, error) {
+	query := `
+		SELECT 
+			ccm_id, ccm_cc_id, ccm_type, ccm_url,
+			ccm_created_by, ccm_created_at, ccm_updated_by, ccm_updated_at
+		FROM atamlink.catalog_card_media
+		WHERE ccm_cc_id = $1
+		ORDER BY ccm_id ASC`
+
+	mediaList, err := database.Select[entity.CatalogCardMedia](ctx, r.db, query, cardID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card media")
+	}
+
+	return mediaList, nil
+}
+
+// GetMediaByCardIDs get media beberapa card sekaligus, dikelompokkan per card ID
+func (r *catalogRepository) GetMediaByCardIDs(ctx context.Context, cardIDs []int64) (map[int64][]*entity.CatalogCardMedia, error) {
+	mediaByCard := make(map[int64][]*entity.CatalogCardMedia)
+	if len(cardIDs) == 0 {
+		return mediaByCard, nil
+	}
+
+	qb := database.NewQueryBuilder()
+	qb.Select(
+		"ccm_id", "ccm_cc_id", "ccm_type", "ccm_url",
+		"ccm_created_by", "ccm_created_at", "ccm_updated_by", "ccm_updated_at",
+	).From("atamlink.catalog_card_media")
+	qb.WhereIn("ccm_cc_id", int64sToArgs(cardIDs))
+	qb.OrderBy("ccm_id ASC")
+
+	query, args := qb.Build()
+	mediaList, err := database.Select[entity.CatalogCardMedia](ctx, r.db, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card media")
+	}
+
+	for _, media := range mediaList {
+		mediaByCard[media.CardID] = append(mediaByCard[media.CardID], media)
+	}
+
+	return mediaByCard, nil
+}
+
+// DeleteCardMedia delete card media
+func (r *catalogRepository) DeleteCardMedia(ctx context.Context, tx *sql.Tx, id int64) error {
+	query := `DELETE FROM atamlink.catalog_card_media WHERE ccm_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete card media")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
 
-	if err == sql.ErrNoRows {
-		return nil, nil
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, "Media tidak ditemukan", 404)
 	}
+
+	return nil
+}
+
+// ListMediaPendingReplication URL media milik business dengan replikasi aktif
+// yang belum punya salinan di region kedua atau gagal di bawah batas percobaan
+func (r *catalogRepository) ListMediaPendingReplication(ctx context.Context, limit, maxAttempts int) ([]string, error) {
+	query := `
+		SELECT DISTINCT ccm.ccm_url
+		FROM atamlink.catalog_card_media ccm
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = ccm.ccm_cc_id
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		LEFT JOIN atamlink.catalog_media_replicas cmr ON cmr.cmr_source_url = ccm.ccm_url
+		WHERE b.b_media_replication = true
+		AND (cmr.cmr_id IS NULL OR (cmr.cmr_status = 'failed' AND cmr.cmr_attempts < $2))
+		LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, maxAttempts)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get card detail")
+		return nil, errors.Wrap(err, "failed to list media pending replication")
 	}
+	defer rows.Close()
 
-	return detail, nil
+	urls := make([]string, 0)
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, errors.Wrap(err, "failed to scan media url")
+		}
+		urls = append(urls, url)
+	}
+
+	return urls, nil
 }
 
-// UpdateCardDetail update card detail
-func (r *catalogRepository) UpdateCardDetail(tx *sql.Tx, detail *entity.CatalogCardDetail) error {
+// SaveMediaReplica simpan hasil replikasi, percobaan ulang menambah attempts
+func (r *catalogRepository) SaveMediaReplica(ctx context.Context, replica *entity.CatalogMediaReplica) error {
 	query := `
-		UPDATE atamlink.catalog_card_details SET
-			ccd_slug = $2,
-			ccd_description = $3,
-			ccd_is_visible = $4,
-			ccd_updated_by = $5,
-			ccd_updated_at = $6
-		WHERE ccd_id = $1`
-
-	result, err := tx.Exec(
+		INSERT INTO atamlink.catalog_media_replicas (
+			cmr_source_url, cmr_replica_url, cmr_status, cmr_attempts, cmr_error, cmr_created_at
+		) VALUES ($1, $2, $3, 1, $4, $5)
+		ON CONFLICT (cmr_source_url) DO UPDATE SET
+			cmr_replica_url = EXCLUDED.cmr_replica_url,
+			cmr_status = EXCLUDED.cmr_status,
+			cmr_attempts = atamlink.catalog_media_replicas.cmr_attempts + 1,
+			cmr_error = EXCLUDED.cmr_error,
+			cmr_updated_at = EXCLUDED.cmr_created_at`
+
+	_, err := r.db.ExecContext(ctx,
 		query,
-		detail.ID,
-		detail.Slug,
-		detail.Description,
-		detail.IsVisible,
-		detail.UpdatedBy,
+		replica.SourceURL,
+		replica.ReplicaURL,
+		replica.Status,
+		replica.Error,
 		time.Now(),
 	)
-
 	if err != nil {
-		return errors.Wrap(err, "failed to update card detail")
+		return errors.Wrap(err, "failed to save media replica")
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return nil
+}
+
+// GetMediaReplicaURLs map URL sumber ke URL replika yang sudah selesai
+func (r *catalogRepository) GetMediaReplicaURLs(ctx context.Context, sourceURLs []string) (map[string]string, error) {
+	replicas := make(map[string]string)
+	if len(sourceURLs) == 0 {
+		return replicas, nil
+	}
+
+	query := `
+		SELECT cmr_source_url, cmr_replica_url
+		FROM atamlink.catalog_media_replicas
+		WHERE cmr_source_url = ANY($1) AND cmr_status = 'completed'`
+
+	rows, err := database.Select[entity.CatalogMediaReplica](ctx, r.db, query, pq.Array(sourceURLs))
 	if err != nil {
-		return errors.Wrap(err, "failed to check rows affected")
+		return nil, errors.Wrap(err, "failed to get media replicas")
 	}
 
-	if rowsAffected == 0 {
-		return errors.New(errors.ErrNotFound, "Detail card tidak ditemukan", 404)
+	for _, replica := range rows {
+		replicas[replica.SourceURL] = replica.ReplicaURL.String
 	}
 
-	return nil
+	return replicas, nil
 }
 
-// CreateCardMedia create card media
-func (r *catalogRepository) CreateCardMedia(tx *sql.Tx, media *entity.CatalogCardMedia) error {
-	query := `
-		INSERT INTO atamlink.catalog_card_media (
-			ccm_cc_id, ccm_type, ccm_url, ccm_created_by, ccm_created_at
-		) VALUES ($1, $2, $3, $4, $5)
-		RETURNING ccm_id`
+// TouchMediaAccess perbarui waktu akses terakhir media dari log CDN. URL replika
+// dipetakan ke URL sumber, waktu yang lebih lama dari yang tersimpan diabaikan
+func (r *catalogRepository) TouchMediaAccess(ctx context.Context, accessed map[string]time.Time) (int64, error) {
+	if len(accessed) == 0 {
+		return 0, nil
+	}
 
-	err := tx.QueryRow(
-		query,
-		media.CardID,
-		media.Type,
-		media.URL,
-		media.CreatedBy,
-		media.CreatedAt,
-	).Scan(&media.ID)
+	// Kolom TIMESTAMP menyimpan waktu lokal, dikirim sebagai teks supaya bisa di-unnest
+	urls := make([]string, 0, len(accessed))
+	times := make([]string, 0, len(accessed))
+	for url, at := range accessed {
+		urls = append(urls, url)
+		times = append(times, at.Local().Format("2006-01-02 15:04:05.999999"))
+	}
 
+	query := `
+		UPDATE atamlink.catalog_card_media ccm
+		SET ccm_last_accessed_at = a.accessed_at
+		FROM (
+			SELECT COALESCE(cmr.cmr_source_url, l.url) AS url, MAX(l.accessed_at) AS accessed_at
+			FROM unnest($1::text[], $2::timestamp[]) AS l(url, accessed_at)
+			LEFT JOIN atamlink.catalog_media_replicas cmr ON cmr.cmr_replica_url = l.url
+			GROUP BY 1
+		) a
+		WHERE ccm.ccm_url = a.url
+		AND (ccm.ccm_last_accessed_at IS NULL OR ccm.ccm_last_accessed_at < a.accessed_at)`
+
+	result, err := r.db.ExecContext(ctx, query, pq.Array(urls), pq.Array(times))
 	if err != nil {
-		return errors.Wrap(err, "failed to create card media")
+		return 0, errors.Wrap(err, "failed to touch media access")
 	}
 
-	return nil
+	return result.RowsAffected()
 }
 
-// GetCardMediaByCardID get card media by card ID
-func (r *catalogRepository) GetCardMediaByCardID(cardID int64) ([]*entity.CatalogCardMedia, error) {
+// ListColdMedia URL original media yang tidak diakses sejak before. URL yang
+// juga dipakai sebagai thumbnail tidak ikut dipindah
+func (r *catalogRepository) ListColdMedia(ctx context.Context, before time.Time, limit, maxAttempts int) ([]string, error) {
 	query := `
-		SELECT 
-			ccm_id, ccm_cc_id, ccm_type, ccm_url,
-			ccm_created_by, ccm_created_at, ccm_updated_by, ccm_updated_at
+		SELECT ccm_url
 		FROM atamlink.catalog_card_media
-		WHERE ccm_cc_id = $1
-		ORDER BY ccm_id ASC`
-
-	rows, err := r.db.Query(query, cardID)
+		WHERE ccm_storage_class = 'standard'
+		GROUP BY ccm_url
+		HAVING bool_and(ccm_type <> 'thumbnail')
+		AND MAX(COALESCE(ccm_last_accessed_at, ccm_created_at)) < $1
+		AND MAX(ccm_archive_attempts) < $3
+		LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit, maxAttempts)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get card media")
+		return nil, errors.Wrap(err, "failed to list cold media")
 	}
 	defer rows.Close()
 
-	mediaList := make([]*entity.CatalogCardMedia, 0)
+	urls := make([]string, 0)
 	for rows.Next() {
-		media := &entity.CatalogCardMedia{}
-		err := rows.Scan(
-			&media.ID,
-			&media.CardID,
-			&media.Type,
-			&media.URL,
-			&media.CreatedBy,
-			&media.CreatedAt,
-			&media.UpdatedBy,
-			&media.UpdatedAt,
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			return nil, errors.Wrap(err, "failed to scan media url")
+		}
+		urls = append(urls, url)
+	}
+
+	return urls, nil
+}
+
+// MarkMediaArchived ganti URL media dengan URL arsip, mengembalikan slug katalog
+// published yang memakai media tersebut untuk purge cache
+func (r *catalogRepository) MarkMediaArchived(ctx context.Context, sourceURL, archiveURL string) ([]string, error) {
+	query := `
+		WITH archived AS (
+			UPDATE atamlink.catalog_card_media
+			SET ccm_url = $2, ccm_storage_class = 'infrequent', ccm_archived_at = $3
+			WHERE ccm_url = $1 AND ccm_storage_class = 'standard'
+			RETURNING ccm_cc_id
 		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan card media")
+		SELECT DISTINCT c.c_slug
+		FROM archived
+		INNER JOIN atamlink.catalog_cards cc ON cc.cc_id = archived.ccm_cc_id
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		INNER JOIN atamlink.catalogs c ON c.c_id = cs.cs_c_id
+		WHERE c.c_status = 'published'`
+
+	rows, err := r.db.QueryContext(ctx, query, sourceURL, archiveURL, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to mark media archived")
+	}
+	defer rows.Close()
+
+	slugs := make([]string, 0)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return nil, errors.Wrap(err, "failed to scan catalog slug")
 		}
-		mediaList = append(mediaList, media)
+		slugs = append(slugs, slug)
 	}
 
-	return mediaList, nil
+	return slugs, nil
 }
 
-// DeleteCardMedia delete card media
-func (r *catalogRepository) DeleteCardMedia(tx *sql.Tx, id int64) error {
-	query := `DELETE FROM atamlink.catalog_card_media WHERE ccm_id = $1`
+// MarkMediaArchiveFailed tambah jumlah percobaan arsip media yang gagal
+func (r *catalogRepository) MarkMediaArchiveFailed(ctx context.Context, sourceURL string) error {
+	query := `
+		UPDATE atamlink.catalog_card_media
+		SET ccm_archive_attempts = ccm_archive_attempts + 1
+		WHERE ccm_url = $1 AND ccm_storage_class = 'standard'`
+
+	if _, err := r.db.ExecContext(ctx, query, sourceURL); err != nil {
+		return errors.Wrap(err, "failed to mark media archive failed")
+	}
+
+	return nil
+}
+
+// CreateLegalVersion simpan teks legal sebagai versi baru (versi terakhir + 1)
+func (r *catalogRepository) CreateLegalVersion(ctx context.Context, tx *sql.Tx, legal *entity.CatalogLegalVersion) error {
+	query := `
+		INSERT INTO atamlink.catalog_legal_versions (
+			clv_cs_id, clv_version, clv_terms, clv_terms_html,
+			clv_privacy, clv_privacy_html, clv_created_by, clv_created_at
+		)
+		SELECT $1, COALESCE(MAX(clv_version), 0) + 1, $2, $3, $4, $5, $6, $7
+		FROM atamlink.catalog_legal_versions
+		WHERE clv_cs_id = $1
+		RETURNING clv_id, clv_version`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		legal.SectionID,
+		legal.Terms,
+		legal.TermsHTML,
+		legal.Privacy,
+		legal.PrivacyHTML,
+		legal.CreatedBy,
+		legal.CreatedAt,
+	).Scan(&legal.ID, &legal.Version)
 
-	result, err := tx.Exec(query, id)
 	if err != nil {
-		return errors.Wrap(err, "failed to delete card media")
+		return errors.Wrap(err, "failed to create legal version")
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return nil
+}
+
+// GetCurrentLegalVersion get versi legal terbaru section
+func (r *catalogRepository) GetCurrentLegalVersion(ctx context.Context, sectionID int64) (*entity.CatalogLegalVersion, error) {
+	query := `
+		SELECT
+			clv_id, clv_cs_id, clv_version, clv_terms, clv_terms_html,
+			clv_privacy, clv_privacy_html, clv_created_by, clv_created_at
+		FROM atamlink.catalog_legal_versions
+		WHERE clv_cs_id = $1
+		ORDER BY clv_version DESC
+		LIMIT 1`
+
+	legal, err := database.Get[entity.CatalogLegalVersion](ctx, r.db, query, sectionID)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgLegalVersionNotFound, 404)
+	}
 	if err != nil {
-		return errors.Wrap(err, "failed to check rows affected")
+		return nil, errors.Wrap(err, "failed to get legal version")
 	}
 
-	if rowsAffected == 0 {
-		return errors.New(errors.ErrNotFound, "Media tidak ditemukan", 404)
+	return legal, nil
+}
+
+// GetLegalVersionsBySectionID get semua versi legal section, terbaru lebih dulu
+func (r *catalogRepository) GetLegalVersionsBySectionID(ctx context.Context, sectionID int64) ([]*entity.CatalogLegalVersion, error) {
+	query := `
+		SELECT
+			clv_id, clv_cs_id, clv_version, clv_terms, clv_terms_html,
+			clv_privacy, clv_privacy_html, clv_created_by, clv_created_at
+		FROM atamlink.catalog_legal_versions
+		WHERE clv_cs_id = $1
+		ORDER BY clv_version DESC`
+
+	versions, err := database.Select[entity.CatalogLegalVersion](ctx, r.db, query, sectionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get legal versions")
 	}
 
-	return nil
+	return versions, nil
 }
 
 // CreateFAQ create FAQ
-func (r *catalogRepository) CreateFAQ(tx *sql.Tx, faq *entity.CatalogFAQ) error {
+func (r *catalogRepository) CreateFAQ(ctx context.Context, tx *sql.Tx, faq *entity.CatalogFAQ) error {
 	query := `
 		INSERT INTO atamlink.catalog_faqs (
-			cf_cs_id, cf_question, cf_answer, cf_is_visible,
-			cf_created_by, cf_created_at
-		) VALUES ($1, $2, $3, $4, $5, $6)
+			cf_cs_id, cf_question, cf_answer, cf_answer_html, cf_is_visible,
+			cf_display_order, cf_created_by, cf_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING cf_id`
 
-	err := tx.QueryRow(
+	err := tx.QueryRowContext(ctx,
 		query,
 		faq.SectionID,
 		faq.Question,
 		faq.Answer,
+		faq.AnswerHTML,
 		faq.IsVisible,
+		faq.DisplayOrder,
 		faq.CreatedBy,
 		faq.CreatedAt,
 	).Scan(&faq.ID)
@@ -960,61 +3326,64 @@ func (r *catalogRepository) CreateFAQ(tx *sql.Tx, faq *entity.CatalogFAQ) error
 }
 
 // GetFAQsBySectionID get FAQs by section ID
-func (r *catalogRepository) GetFAQsBySectionID(sectionID int64) ([]*entity.CatalogFAQ, error) {
+func (r *catalogRepository) GetFAQsBySectionID(ctx context.Context, sectionID int64) ([]*entity.CatalogFAQ, error) {
 	query := `
 		SELECT 
-			cf_id, cf_cs_id, cf_question, cf_answer, cf_is_visible,
+			cf_id, cf_cs_id, cf_question, cf_answer, cf_answer_html, cf_is_visible, cf_display_order,
 			cf_created_by, cf_created_at, cf_updated_by, cf_updated_at
 		FROM atamlink.catalog_faqs
 		WHERE cf_cs_id = $1
-		ORDER BY cf_id ASC`
+		ORDER BY cf_display_order ASC, cf_id ASC`
 
-	rows, err := r.db.Query(query, sectionID)
+	faqs, err := database.Select[entity.CatalogFAQ](ctx, r.db, query, sectionID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get FAQs")
 	}
-	defer rows.Close()
 
-	faqs := make([]*entity.CatalogFAQ, 0)
-	for rows.Next() {
-		faq := &entity.CatalogFAQ{}
-		err := rows.Scan(
-			&faq.ID,
-			&faq.SectionID,
-			&faq.Question,
-			&faq.Answer,
-			&faq.IsVisible,
-			&faq.CreatedBy,
-			&faq.CreatedAt,
-			&faq.UpdatedBy,
-			&faq.UpdatedAt,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan FAQ")
-		}
-		faqs = append(faqs, faq)
+	return faqs, nil
+}
+
+// GetFAQByID get FAQ by ID
+func (r *catalogRepository) GetFAQByID(ctx context.Context, id int64) (*entity.CatalogFAQ, error) {
+	query := `
+		SELECT 
+			cf_id, cf_cs_id, cf_question, cf_answer, cf_answer_html, cf_is_visible, cf_display_order,
+			cf_created_by, cf_created_at, cf_updated_by, cf_updated_at
+		FROM atamlink.catalog_faqs
+		WHERE cf_id = $1`
+
+	faq, err := database.Get[entity.CatalogFAQ](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgFAQNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get FAQ")
 	}
 
-	return faqs, nil
+	return faq, nil
 }
 
 // UpdateFAQ update FAQ
-func (r *catalogRepository) UpdateFAQ(tx *sql.Tx, faq *entity.CatalogFAQ) error {
+func (r *catalogRepository) UpdateFAQ(ctx context.Context, tx *sql.Tx, faq *entity.CatalogFAQ) error {
 	query := `
 		UPDATE atamlink.catalog_faqs SET
 			cf_question = $2,
 			cf_answer = $3,
-			cf_is_visible = $4,
-			cf_updated_by = $5,
-			cf_updated_at = $6
+			cf_answer_html = $4,
+			cf_is_visible = $5,
+			cf_display_order = $6,
+			cf_updated_by = $7,
+			cf_updated_at = $8
 		WHERE cf_id = $1`
 
-	result, err := tx.Exec(
+	result, err := tx.ExecContext(ctx,
 		query,
 		faq.ID,
 		faq.Question,
 		faq.Answer,
+		faq.AnswerHTML,
 		faq.IsVisible,
+		faq.DisplayOrder,
 		faq.UpdatedBy,
 		time.Now(),
 	)
@@ -1035,11 +3404,28 @@ func (r *catalogRepository) UpdateFAQ(tx *sql.Tx, faq *entity.CatalogFAQ) error
 	return nil
 }
 
+// DeleteFAQsExcept hapus semua FAQ section selain keepIDs
+func (r *catalogRepository) DeleteFAQsExcept(ctx context.Context, tx *sql.Tx, sectionID int64, keepIDs []int64) error {
+	if keepIDs == nil {
+		keepIDs = []int64{}
+	}
+
+	query := `
+		DELETE FROM atamlink.catalog_faqs
+		WHERE cf_cs_id = $1 AND NOT (cf_id = ANY($2))`
+
+	if _, err := tx.ExecContext(ctx, query, sectionID, pq.Array(keepIDs)); err != nil {
+		return errors.Wrap(err, "failed to delete FAQs")
+	}
+
+	return nil
+}
+
 // DeleteFAQ delete FAQ
-func (r *catalogRepository) DeleteFAQ(tx *sql.Tx, id int64) error {
+func (r *catalogRepository) DeleteFAQ(ctx context.Context, tx *sql.Tx, id int64) error {
 	query := `DELETE FROM atamlink.catalog_faqs WHERE cf_id = $1`
 
-	result, err := tx.Exec(query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete FAQ")
 	}
@@ -1054,4 +3440,259 @@ func (r *catalogRepository) DeleteFAQ(tx *sql.Tx, id int64) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// CreateTag create tag katalog
+func (r *catalogRepository) CreateTag(ctx context.Context, tx *sql.Tx, tag *entity.CatalogTag) error {
+	query := `
+		INSERT INTO atamlink.catalog_tags (
+			ct_c_id, ct_name, ct_slug, ct_created_by, ct_created_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING ct_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		tag.CatalogID,
+		tag.Name,
+		tag.Slug,
+		tag.CreatedBy,
+		tag.CreatedAt,
+	).Scan(&tag.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create tag")
+	}
+
+	return nil
+}
+
+// GetTagByID get tag by ID
+func (r *catalogRepository) GetTagByID(ctx context.Context, id int64) (*entity.CatalogTag, error) {
+	query := `
+		SELECT ct_id, ct_c_id, ct_name, ct_slug, ct_created_by, ct_created_at, ct_updated_by, ct_updated_at
+		FROM atamlink.catalog_tags
+		WHERE ct_id = $1`
+
+	tag, err := database.Get[entity.CatalogTag](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgTagNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tag")
+	}
+
+	return tag, nil
+}
+
+// GetTagBySlug get tag berdasarkan slug di katalog, nil jika tidak ada
+func (r *catalogRepository) GetTagBySlug(ctx context.Context, catalogID int64, slug string) (*entity.CatalogTag, error) {
+	query := `
+		SELECT ct_id, ct_c_id, ct_name, ct_slug, ct_created_by, ct_created_at, ct_updated_by, ct_updated_at
+		FROM atamlink.catalog_tags
+		WHERE ct_c_id = $1 AND ct_slug = $2`
+
+	tag, err := database.Get[entity.CatalogTag](ctx, r.db, query, catalogID, slug)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tag by slug")
+	}
+
+	return tag, nil
+}
+
+// GetTagsByCatalogID semua tag katalog beserta jumlah card yang memakainya, urut nama
+func (r *catalogRepository) GetTagsByCatalogID(ctx context.Context, catalogID int64) ([]*entity.CatalogTag, error) {
+	query := `
+		SELECT ct.ct_id, ct.ct_c_id, ct.ct_name, ct.ct_slug, ct.ct_created_by, ct.ct_created_at,
+			ct.ct_updated_by, ct.ct_updated_at,
+			(SELECT COUNT(*) FROM atamlink.catalog_card_tags cct WHERE cct.cct_ct_id = ct.ct_id) AS card_count
+		FROM atamlink.catalog_tags ct
+		WHERE ct.ct_c_id = $1
+		ORDER BY ct.ct_name ASC, ct.ct_id ASC`
+
+	tags, err := database.Select[entity.CatalogTag](ctx, r.db, query, catalogID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tags")
+	}
+
+	return tags, nil
+}
+
+// GetTagsByIDs tag katalog dari daftar ID, ID milik katalog lain tidak dikembalikan
+func (r *catalogRepository) GetTagsByIDs(ctx context.Context, catalogID int64, ids []int64) ([]*entity.CatalogTag, error) {
+	tags := make([]*entity.CatalogTag, 0)
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	query := `
+		SELECT ct_id, ct_c_id, ct_name, ct_slug, ct_created_by, ct_created_at, ct_updated_by, ct_updated_at
+		FROM atamlink.catalog_tags
+		WHERE ct_c_id = $1 AND ct_id = ANY($2)`
+
+	tags, err := database.Select[entity.CatalogTag](ctx, r.db, query, catalogID, pq.Array(ids))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get tags by ids")
+	}
+
+	return tags, nil
+}
+
+// GetTagsByCardIDs tag beberapa card sekaligus, dikelompokkan per card ID dan urut nama
+func (r *catalogRepository) GetTagsByCardIDs(ctx context.Context, cardIDs []int64) (map[int64][]*entity.CatalogTag, error) {
+	tagsByCard := make(map[int64][]*entity.CatalogTag)
+	if len(cardIDs) == 0 {
+		return tagsByCard, nil
+	}
+
+	query := `
+		SELECT cct.cct_cc_id, ct.ct_id, ct.ct_c_id, ct.ct_name, ct.ct_slug, ct.ct_created_by,
+			ct.ct_created_at, ct.ct_updated_by, ct.ct_updated_at
+		FROM atamlink.catalog_card_tags cct
+		INNER JOIN atamlink.catalog_tags ct ON ct.ct_id = cct.cct_ct_id
+		WHERE cct.cct_cc_id = ANY($1)
+		ORDER BY ct.ct_name ASC, ct.ct_id ASC`
+
+	rows, err := database.Select[cardTagRow](ctx, r.db, query, pq.Array(cardIDs))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get card tags")
+	}
+
+	for _, row := range rows {
+		tagsByCard[row.CardID] = append(tagsByCard[row.CardID], &row.CatalogTag)
+	}
+
+	return tagsByCard, nil
+}
+
+// IsTagSlugTaken check slug tag sudah dipakai tag lain di katalog
+func (r *catalogRepository) IsTagSlugTaken(ctx context.Context, catalogID int64, slug string, excludeTagID int64) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM atamlink.catalog_tags
+			WHERE ct_c_id = $1 AND ct_slug = $2 AND ct_id != $3
+		)`
+
+	var taken bool
+	if err := r.db.QueryRowContext(ctx, query, catalogID, slug, excludeTagID).Scan(&taken); err != nil {
+		return false, errors.Wrap(err, "failed to check tag slug")
+	}
+	return taken, nil
+}
+
+// CountTags jumlah tag dalam katalog
+func (r *catalogRepository) CountTags(ctx context.Context, catalogID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM atamlink.catalog_tags WHERE ct_c_id = $1`, catalogID).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to count tags")
+	}
+	return count, nil
+}
+
+// UpdateTag update nama dan slug tag
+func (r *catalogRepository) UpdateTag(ctx context.Context, tx *sql.Tx, tag *entity.CatalogTag) error {
+	query := `
+		UPDATE atamlink.catalog_tags SET
+			ct_name = $2,
+			ct_slug = $3,
+			ct_updated_by = $4,
+			ct_updated_at = $5
+		WHERE ct_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, tag.ID, tag.Name, tag.Slug, tag.UpdatedBy, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update tag")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgTagNotFound, 404)
+	}
+
+	return nil
+}
+
+// DeleteTag hapus tag, relasi ke card ikut terhapus (ON DELETE CASCADE)
+func (r *catalogRepository) DeleteTag(ctx context.Context, tx *sql.Tx, id int64) error {
+	query := `DELETE FROM atamlink.catalog_tags WHERE ct_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete tag")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgTagNotFound, 404)
+	}
+
+	return nil
+}
+
+// SetCardTags ganti seluruh tag card dengan daftar tag ID
+func (r *catalogRepository) SetCardTags(ctx context.Context, tx *sql.Tx, cardID int64, tagIDs []int64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM atamlink.catalog_card_tags WHERE cct_cc_id = $1`, cardID); err != nil {
+		return errors.Wrap(err, "failed to clear card tags")
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO atamlink.catalog_card_tags (cct_cc_id, cct_ct_id)
+		SELECT $1, UNNEST($2::bigint[])
+		ON CONFLICT DO NOTHING`
+
+	if _, err := tx.ExecContext(ctx, query, cardID, pq.Array(tagIDs)); err != nil {
+		return errors.Wrap(err, "failed to set card tags")
+	}
+
+	return nil
+}
+
+// cardTagRow tag beserta card yang memakainya
+type cardTagRow struct {
+	CardID int64 `db:"cct_cc_id"`
+	entity.CatalogTag
+}
+
+// catalogCardRow card beserta katalog pemiliknya
+type catalogCardRow struct {
+	CatalogID int64 `db:"c_id"`
+	entity.CatalogCard
+}
+
+// int64sToArgs ubah slice ID menjadi argumen WhereIn
+func int64sToArgs(ids []int64) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
+}
+
+// prefixTSQuery ubah kata kunci bebas jadi tsquery prefix ("kopi sus" -> "kopi:* & sus:*"),
+// karakter selain huruf & angka dibuang agar input user tidak merusak sintaks tsquery
+func prefixTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, word+":*")
+	}
+	return strings.Join(terms, " & ")
+}
@@ -1,11 +1,28 @@
 package usecase
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
 	"github.com/atam/atamlink/internal/constant"
 	"github.com/atam/atamlink/internal/middleware"
@@ -16,26 +33,137 @@ import (
 	"github.com/atam/atamlink/internal/service"
 	"github.com/atam/atamlink/pkg/database"
 	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
 )
 
 // CatalogUseCase interface untuk catalog use case
 type CatalogUseCase interface {
-	Create(profileID int64, req *dto.CreateCatalogRequest) (*dto.CatalogResponse, error)
-	GetByID(id int64, profileID int64) (*dto.CatalogResponse, error)
-	GetBySlug(slug string) (*dto.PublicCatalogResponse, error)
-	List(profileID int64, filter *dto.CatalogFilter, page, perPage int, orderBy string) ([]*dto.CatalogListResponse, int64, error)
+	Create(ctx *gin.Context, profileID int64, req *dto.CreateCatalogRequest) (*dto.CatalogResponse, error)
+	GetByID(ctx *gin.Context, id int64, profileID int64) (*dto.CatalogResponse, error)
+	GetBySlug(ctx context.Context, slug, visitorCountry, tag string) (*dto.PublicCatalogResponse, error)
+	PublicCatalogETag(ctx context.Context, slug, visitorCountry, tag string) (string, error)
+	GetPublicCard(ctx context.Context, catalogSlug, cardSlug, visitorCountry string) (*dto.CardResponse, string, error)
+	SearchPublicCards(ctx context.Context, slug, search, visitorCountry string, page, perPage int) ([]*dto.CardResponse, int64, error)
+	GetEmbedConfig(ctx context.Context, slug string) (*dto.EmbedConfigResponse, error)
+	GenerateQR(ctx *gin.Context, id int64, profileID int64) (*dto.CatalogQRResponse, error)
+	PurgeCache(ctx *gin.Context, id int64, profileID int64, req *dto.PurgeCacheRequest) (*dto.PurgeCacheResponse, error)
+	List(ctx context.Context, profileID int64, filter *dto.CatalogFilter, page, perPage int, orderBy string) ([]*dto.CatalogListResponse, int64, error)
+	ListByCursor(ctx context.Context, profileID int64, filter *dto.CatalogFilter, keyset *database.Keyset, limit int) ([]*dto.CatalogListResponse, string, error)
+	SearchDashboard(ctx context.Context, profileID int64, search string, page, perPage int) ([]*dto.CatalogSearchResponse, int64, error)
+	GetByIDs(ctx *gin.Context, profileID int64, ids []int64) ([]*dto.CatalogBatchItem, error)
+	GetPublicBySlugs(ctx context.Context, slugs []string) ([]*dto.PublicCatalogBatchItem, error)
+	StreamSitemap(ctx context.Context, businessID int64, baseURL string, fn func(url *dto.SitemapURL) error) error
+	Discover(ctx context.Context, filter *dto.DirectoryFilter, page, perPage int, orderBy string) ([]*dto.DirectoryCatalogResponse, int64, error)
 	Update(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateCatalogRequest) (*dto.CatalogResponse, error)
 	Delete(ctx *gin.Context, id int64, profileID int64) error
+	ListTrash(ctx *gin.Context, profileID int64, filter *dto.CatalogFilter, page, perPage int, orderBy string) ([]*dto.CatalogListResponse, int64, error)
+	Restore(ctx *gin.Context, id int64, profileID int64) (*dto.CatalogResponse, error)
+	Purge(ctx *gin.Context, id int64, profileID int64) error
 
 	// Section management
-	CreateSection(catalogID int64, profileID int64, req *dto.CreateSectionRequest) error
+	CreateSection(ctx *gin.Context, catalogID int64, profileID int64, req *dto.CreateSectionRequest) error
 	UpdateSection(ctx *gin.Context, sectionID int64, profileID int64, req *dto.UpdateSectionRequest) error
 	DeleteSection(ctx *gin.Context, sectionID int64, profileID int64) error
+	MoveSection(ctx *gin.Context, sectionID int64, profileID int64, req *dto.MovePositionRequest) error
+	ReorderSections(ctx *gin.Context, catalogID int64, profileID int64, req *dto.ReorderSectionsRequest) error
+
+	// FAQ management
+	CreateFAQs(ctx *gin.Context, sectionID int64, profileID int64, req *dto.CreateFAQsRequest) ([]*dto.FAQResponse, error)
+	ReplaceFAQs(ctx *gin.Context, sectionID int64, profileID int64, req *dto.ReplaceFAQsRequest) ([]*dto.FAQResponse, error)
+	DeleteFAQs(ctx *gin.Context, sectionID int64, profileID int64) error
+	UpdateFAQ(ctx *gin.Context, faqID int64, profileID int64, req *dto.UpdateFAQRequest) (*dto.FAQResponse, error)
+	DeleteFAQ(ctx *gin.Context, faqID int64, profileID int64) error
+	ReorderFAQs(ctx *gin.Context, sectionID int64, profileID int64, req *dto.ReorderFAQsRequest) ([]*dto.FAQResponse, error)
+
+	// Legal section
+	CreateLegalVersion(ctx *gin.Context, sectionID int64, profileID int64, req *dto.CreateLegalVersionRequest) (*dto.LegalVersionResponse, error)
+	ListLegalVersions(ctx *gin.Context, sectionID int64, profileID int64) ([]*dto.LegalVersionResponse, error)
 
 	// Card management
-	CreateCard(sectionID int64, profileID int64, req *dto.CreateCardRequest) error
+	CreateCard(ctx *gin.Context, sectionID int64, profileID int64, req *dto.CreateCardRequest) error
+	ImportCards(ctx *gin.Context, sectionID int64, profileID int64, filename string, file io.Reader) (*dto.CardImportResponse, error)
+	GetCard(ctx *gin.Context, cardID int64, profileID int64) (*dto.CardResponse, error)
 	UpdateCard(ctx *gin.Context, cardID int64, profileID int64, req *dto.UpdateCardRequest) error
 	DeleteCard(ctx *gin.Context, cardID int64, profileID int64) error
+
+	// Card links
+	ListCardLinks(ctx *gin.Context, cardID int64, profileID int64) ([]*dto.LinkResponse, error)
+	CreateCardLink(ctx *gin.Context, cardID int64, profileID int64, req *dto.LinkRequest) (*dto.LinkResponse, error)
+	UpdateCardLink(ctx *gin.Context, cardID, linkID int64, profileID int64, req *dto.UpdateLinkRequest) (*dto.LinkResponse, error)
+	DeleteCardLink(ctx *gin.Context, cardID, linkID int64, profileID int64) error
+	MoveCard(ctx *gin.Context, cardID int64, profileID int64, req *dto.MovePositionRequest) error
+
+	// Brand
+	ApplyBrand(ctx *gin.Context, businessID int64, profileID int64) (*dto.ApplyBrandResponse, error)
+
+	// Affiliate
+	TrackAffiliateClick(ctx context.Context, cardID int64) error
+	GetAffiliateEarnings(ctx *gin.Context, catalogID int64, profileID int64, from, to time.Time) ([]*dto.AffiliateEarningResponse, error)
+
+	// Checkout
+	CreateCheckoutLink(ctx *gin.Context, cardID int64, profileID int64, req *dto.CreateCheckoutLinkRequest) (*dto.CheckoutLinkResponse, error)
+	HandlePaymentCallback(ctx *gin.Context, callbackToken string, req *dto.PaymentCallbackRequest) error
+
+	// Price schedule
+	SchedulePrice(ctx *gin.Context, cardID int64, profileID int64, req *dto.SchedulePriceRequest) (*dto.PriceScheduleResponse, error)
+	ListPriceSchedules(ctx *gin.Context, cardID int64, profileID int64) ([]*dto.PriceScheduleResponse, error)
+	CancelPriceSchedule(ctx *gin.Context, cardID, scheduleID int64, profileID int64) error
+	ApplyDuePriceSchedules(ctx context.Context, batchSize int) error
+	AlertEndingDiscounts(ctx context.Context, within time.Duration, batchSize int) error
+
+	// Presence
+	Heartbeat(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.PresenceResponse, error)
+	ListPresence(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.PresenceResponse, error)
+
+	// Publish approval
+	SubmitPublishRequest(ctx *gin.Context, catalogID int64, profileID int64, req *dto.SubmitPublishRequest) (*dto.PublishRequestResponse, error)
+	ListPublishRequests(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.PublishRequestResponse, error)
+	ApprovePublishRequest(ctx *gin.Context, requestID int64, profileID int64, req *dto.ReviewPublishRequest) (*dto.PublishRequestResponse, error)
+	RequestPublishChanges(ctx *gin.Context, requestID int64, profileID int64, req *dto.ReviewPublishRequest) (*dto.PublishRequestResponse, error)
+
+	// Scheduled publish
+	SchedulePublish(ctx *gin.Context, catalogID int64, profileID int64, req *dto.SchedulePublishRequest) (*dto.CatalogResponse, error)
+	CancelPublishSchedule(ctx *gin.Context, catalogID int64, profileID int64) (*dto.CatalogResponse, error)
+	PublishDue(ctx context.Context, batchSize int) error
+
+	// Scheduled visibility (publish_at / unpublish_at)
+	ScheduleCatalogVisibility(ctx *gin.Context, catalogID int64, profileID int64, req *dto.VisibilityScheduleRequest) (*dto.CatalogResponse, error)
+	CancelCatalogVisibilitySchedule(ctx *gin.Context, catalogID int64, profileID int64) (*dto.CatalogResponse, error)
+	ScheduleCardVisibility(ctx *gin.Context, cardID int64, profileID int64, req *dto.VisibilityScheduleRequest) (*dto.CardResponse, error)
+	CancelCardVisibilitySchedule(ctx *gin.Context, cardID int64, profileID int64) (*dto.CardResponse, error)
+	UpdateCardStock(ctx *gin.Context, cardID int64, profileID int64, req *dto.CardStockRequest) (*dto.CardResponse, error)
+	AdjustCardStock(ctx *gin.Context, cardID int64, profileID int64, req *dto.AdjustCardStockRequest) (*dto.CardResponse, error)
+
+	// Tag operations
+	ListTags(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.TagResponse, error)
+	CreateTag(ctx *gin.Context, catalogID int64, profileID int64, req *dto.CreateTagRequest) (*dto.TagResponse, error)
+	UpdateTag(ctx *gin.Context, tagID int64, profileID int64, req *dto.UpdateTagRequest) (*dto.TagResponse, error)
+	DeleteTag(ctx *gin.Context, tagID int64, profileID int64) error
+	SetCardTags(ctx *gin.Context, cardID int64, profileID int64, req *dto.SetCardTagsRequest) (*dto.CardResponse, error)
+	ListCards(ctx *gin.Context, catalogID int64, profileID int64, tag string) ([]*dto.CardResponse, error)
+	ApplyDueVisibilitySchedules(ctx context.Context, batchSize int) error
+
+	// Usage hints
+	UsageHints(ctx context.Context, catalogID int64) ([]utils.Hint, error)
+	RemindAbandonedDrafts(ctx context.Context, afterDays, batchSize int, resumeURL string) error
+
+	// Media replication
+	ReplicateMedia(ctx context.Context, batchSize, maxAttempts int) error
+
+	// Media lifecycle
+	IngestMediaAccess(ctx context.Context, req *dto.MediaAccessLogRequest) (*dto.MediaAccessLogResponse, error)
+	ArchiveColdMedia(ctx context.Context, coldAfterDays, batchSize, maxAttempts int) error
+
+	// Search index
+	ReindexSearch(ctx context.Context) error
+
+	// Snapshot katalog publik
+	GenerateSnapshots(ctx context.Context) error
+}
+
+// CatalogRehydrator pulihkan konten katalog yang sudah diarsipkan
+type CatalogRehydrator interface {
+	Rehydrate(ctx context.Context, catalog *entity.Catalog) error
 }
 
 type catalogUseCase struct {
@@ -43,6 +171,36 @@ type catalogUseCase struct {
 	catalogRepo  catalogRepo.CatalogRepository
 	businessRepo repository.BusinessRepository
 	slugService  service.SlugService
+	paymentService service.PaymentService
+	notificationService service.NotificationService
+	presenceService service.PresenceService
+	auditService service.AuditService
+	mediaReplicationService service.MediaReplicationService
+	mediaArchiveService service.MediaArchiveService
+	cacheService service.CacheInvalidationService
+	botFilter    service.BotFilter
+	rehydrator   CatalogRehydrator
+	searchIndexer service.SearchIndexer
+	qrService    service.QRService
+
+	// Batas purge cache manual per katalog
+	purgeLimiter service.RateLimiter
+	purgeLimit   int
+
+	// Template canonical URL halaman publik
+	catalogURLTemplate string
+	cardURLTemplate    string
+	redirectURLTemplate string
+
+	// Snapshot katalog publik, nil = nonaktif
+	snapshotStorage  service.BackupStorage
+	snapshotFallback bool
+
+	// Sumber waktu jadwal harga, publish, visibilitas dan langganan
+	clock service.Clock
+
+	// Batas katalog dan produk per plan
+	planEnforcement service.PlanEnforcementService
 }
 
 // NewCatalogUseCase membuat instance catalog use case baru
@@ -51,24 +209,64 @@ func NewCatalogUseCase(
 	catalogRepo catalogRepo.CatalogRepository,
 	businessRepo repository.BusinessRepository,
 	slugService service.SlugService,
+	paymentService service.PaymentService,
+	notificationService service.NotificationService,
+	presenceService service.PresenceService,
+	auditService service.AuditService,
+	mediaReplicationService service.MediaReplicationService,
+	mediaArchiveService service.MediaArchiveService,
+	cacheService service.CacheInvalidationService,
+	botFilter service.BotFilter,
+	rehydrator CatalogRehydrator,
+	searchIndexer service.SearchIndexer,
+	qrService service.QRService,
+	purgeLimiter service.RateLimiter,
+	purgeLimit int,
+	catalogURLTemplate string,
+	cardURLTemplate string,
+	redirectURLTemplate string,
+	snapshotStorage service.BackupStorage,
+	snapshotFallback bool,
+	clock service.Clock,
+	planEnforcement service.PlanEnforcementService,
 ) CatalogUseCase {
 	return &catalogUseCase{
 		db:           db,
 		catalogRepo:  catalogRepo,
 		businessRepo: businessRepo,
 		slugService:  slugService,
+		paymentService: paymentService,
+		notificationService: notificationService,
+		presenceService: presenceService,
+		auditService: auditService,
+		mediaReplicationService: mediaReplicationService,
+		mediaArchiveService: mediaArchiveService,
+		cacheService: cacheService,
+		botFilter:    botFilter,
+		rehydrator:   rehydrator,
+		searchIndexer: searchIndexer,
+		qrService:    qrService,
+		purgeLimiter: purgeLimiter,
+		purgeLimit:   purgeLimit,
+		catalogURLTemplate: catalogURLTemplate,
+		cardURLTemplate:    cardURLTemplate,
+		redirectURLTemplate: redirectURLTemplate,
+		snapshotStorage:     snapshotStorage,
+		snapshotFallback:    snapshotFallback,
+		clock:               clock,
+		planEnforcement:     planEnforcement,
 	}
 }
 
 // Create membuat catalog baru
-func (uc *catalogUseCase) Create(profileID int64, req *dto.CreateCatalogRequest) (*dto.CatalogResponse, error) {
+func (uc *catalogUseCase) Create(ctx *gin.Context, profileID int64, req *dto.CreateCatalogRequest) (*dto.CatalogResponse, error) {
 	// Check business access
-	if err := uc.checkBusinessAccess(req.BusinessID, profileID, constant.PermCatalogCreate); err != nil {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, req.BusinessID, profileID, constant.PermCatalogCreate); err != nil {
 		return nil, err
 	}
 
 	// Check if business exists and active
-	business, err := uc.businessRepo.GetByID(req.BusinessID)
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), req.BusinessID)
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +274,11 @@ func (uc *catalogUseCase) Create(profileID int64, req *dto.CreateCatalogRequest)
 		return nil, errors.New(errors.ErrBusinessInactive, constant.ErrMsgBusinessInactive, 400)
 	}
 
+	// Cek batas jumlah katalog sesuai plan
+	if err := uc.planEnforcement.CheckCatalogLimit(utils.ContextFrom(ctx), req.BusinessID); err != nil {
+		return nil, err
+	}
+
 	// Generate atau validate slug
 	var slug string
 	if req.Slug != "" {
@@ -83,7 +286,7 @@ func (uc *catalogUseCase) Create(profileID int64, req *dto.CreateCatalogRequest)
 			return nil, errors.New(errors.ErrValidation, "Slug tidak valid", 400)
 		}
 
-		exists, err := uc.catalogRepo.IsSlugExists(req.Slug)
+		exists, err := uc.catalogRepo.IsSlugExists(utils.ContextFrom(ctx), req.Slug)
 		if err != nil {
 			return nil, err
 		}
@@ -96,7 +299,7 @@ func (uc *catalogUseCase) Create(profileID int64, req *dto.CreateCatalogRequest)
 			req.Title,
 			uc.slugService,
 			func(s string) (bool, error) {
-				return uc.catalogRepo.IsSlugExists(s)
+				return uc.catalogRepo.IsSlugExists(utils.ContextFrom(ctx), s)
 			},
 			5,
 		)
@@ -106,13 +309,32 @@ func (uc *catalogUseCase) Create(profileID int64, req *dto.CreateCatalogRequest)
 		slug = generatedSlug
 	}
 
-	// Set default settings if empty
-	if req.Settings == nil {
-		req.Settings = make(map[string]interface{})
+	// Cek batas section sesuai plan
+	if len(req.Sections) > 0 {
+		limits, err := uc.contentLimits(utils.ContextFrom(ctx), req.BusinessID)
+		if err != nil {
+			return nil, err
+		}
+		if len(req.Sections) > limits.maxSections {
+			return nil, errors.New(errors.ErrConflict, fmt.Sprintf(constant.ErrMsgSectionLimitReached, limits.maxSections), 409)
+		}
+	}
+
+	if req.CategoryID > 0 {
+		if err := uc.checkCategory(utils.ContextFrom(ctx), req.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Settings awal: default theme, ditimpa brand business, ditimpa request
+	themeSettings, err := uc.catalogRepo.GetThemeDefaultSettings(utils.ContextFrom(ctx), req.ThemeID)
+	if err != nil {
+		return nil, err
 	}
+	req.Settings = mergeSettings(themeSettings, business.Brand.CatalogSettings(), req.Settings)
 
 	// Start transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -126,19 +348,26 @@ func (uc *catalogUseCase) Create(profileID int64, req *dto.CreateCatalogRequest)
 		Title:      req.Title,
 		Subtitle:   database.NullString(req.Subtitle),
 		IsActive:   true,
+		Status:     constant.CatalogStatusDraft,
 		Settings:   req.Settings,
+		MetaTitle:  database.NullString(req.MetaTitle),
+		MetaDescription: database.NullString(req.MetaDescription),
+		OGImage:    database.NullString(req.OGImage),
+		AllowIndexing: req.AllowIndexing == nil || *req.AllowIndexing,
+		Listed:     req.Listed != nil && *req.Listed,
+		CategoryID: database.NullInt64(req.CategoryID),
 		CreatedBy:  profileID,
 		CreatedAt:  time.Now(),
 	}
 
-	if err := uc.catalogRepo.Create(tx, catalog); err != nil {
+	if err := uc.catalogRepo.Create(utils.ContextFrom(ctx), tx, catalog); err != nil {
 		return nil, err
 	}
 
 	// Create sections if provided
 	if len(req.Sections) > 0 {
 		for _, sectionReq := range req.Sections {
-			if err := uc.createSectionInternal(tx, catalog.ID, profileID, &sectionReq); err != nil {
+			if err := uc.createSectionInternal(utils.ContextFrom(ctx), tx, catalog.ID, profileID, &sectionReq); err != nil {
 				return nil, err
 			}
 		}
@@ -149,652 +378,5166 @@ func (uc *catalogUseCase) Create(profileID int64, req *dto.CreateCatalogRequest)
 		return nil, errors.Wrap(err, "failed to commit transaction")
 	}
 
+	uc.searchIndexer.Publish(service.SearchEvent{Type: constant.SearchEventCatalogCreated, CatalogID: catalog.ID})
+
+	// QR code gagal tidak membatalkan katalog, bisa dibuat ulang lewat POST /catalogs/{id}/qr
+	if targetURL := uc.catalogCanonicalURL(catalog.Slug); targetURL != "" {
+		if qrURL, err := uc.qrService.GenerateCatalogQR(middleware.RequestContext(ctx), catalog.ID, targetURL); err == nil {
+			if err := uc.catalogRepo.UpdateQRURL(utils.ContextFrom(ctx), catalog.ID, qrURL); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Get complete catalog data
-	return uc.GetByID(catalog.ID, profileID)
+	return uc.GetByID(ctx, catalog.ID, profileID)
 }
 
-// GetByID mendapatkan catalog by ID
-func (uc *catalogUseCase) GetByID(id int64, profileID int64) (*dto.CatalogResponse, error) {
-	// Get catalog
-	catalog, err := uc.catalogRepo.GetByID(id)
+// GenerateQR generate ulang QR code URL publik katalog
+func (uc *catalogUseCase) GenerateQR(ctx *gin.Context, id int64, profileID int64) (*dto.CatalogQRResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check access
-	if profileID > 0 {
-		if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
-			return nil, err
-		}
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
 	}
 
-	// Get sections
-	sections, err := uc.catalogRepo.GetSectionsByCatalogID(id)
+	targetURL := uc.catalogCanonicalURL(catalog.Slug)
+	if targetURL == "" {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgQRUnavailable, 503)
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, catalog)
+	}
+
+	qrURL, err := uc.qrService.GenerateCatalogQR(middleware.RequestContext(ctx), catalog.ID, targetURL)
 	if err != nil {
 		return nil, err
 	}
+	if err := uc.catalogRepo.UpdateQRURL(utils.ContextFrom(ctx), catalog.ID, qrURL); err != nil {
+		return nil, err
+	}
 
-	// Convert to response
-	return uc.toCatalogResponse(catalog, sections), nil
+	return &dto.CatalogQRResponse{
+		QRUrl:     qrURL,
+		TargetURL: targetURL,
+	}, nil
 }
 
-// GetBySlug mendapatkan public catalog by slug
-func (uc *catalogUseCase) GetBySlug(slug string) (*dto.PublicCatalogResponse, error) {
+// GetByID mendapatkan catalog by ID
+func (uc *catalogUseCase) GetByID(ctx *gin.Context, id int64, profileID int64) (*dto.CatalogResponse, error) {
 	// Get catalog
-	catalog, err := uc.catalogRepo.GetBySlug(slug)
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if catalog is active
-	if !catalog.IsActive {
-		return nil, errors.New(errors.ErrCatalogInactive, constant.ErrMsgCatalogInactive, 404)
+	// Check access, service account hanya boleh membaca katalog business pemiliknya
+	if scopeID, ok := service.ServiceAccountScope(utils.ContextFrom(ctx)); ok {
+		if scopeID != catalog.BusinessID {
+			return nil, errors.New(errors.ErrNotMember, constant.ErrMsgBusinessAccessDenied, 403)
+		}
+	} else if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
 	}
 
-	// Check if business is accessible
-	if !catalog.Business.IsActive {
-		return nil, errors.New(errors.ErrBusinessInactive, constant.ErrMsgBusinessInactive, 404)
+	// Pemilik membuka katalog yang diarsipkan, pulihkan kontennya dulu
+	if err := uc.rehydrator.Rehydrate(utils.ContextFrom(ctx), catalog); err != nil {
+		return nil, err
 	}
 
 	// Get sections
-	sections, err := uc.catalogRepo.GetSectionsByCatalogID(catalog.ID)
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Load section content
+	// Load cards agar editor bisa lihat siapa yang terakhir mengubah. Cards semua
+	// section di-load sekaligus, termasuk section yang disembunyikan
+	sectionIDs := make([]int64, 0, len(sections))
 	for _, section := range sections {
-		if !section.IsVisible {
-			continue
+		if section.Type == constant.SectionTypeCards {
+			sectionIDs = append(sectionIDs, section.ID)
 		}
+	}
 
-		switch section.Type {
-		case constant.SectionTypeCards:
-			cards, err := uc.catalogRepo.GetCardsBySectionID(section.ID)
-			if err != nil {
-				return nil, err
-			}
-			// Load card details and media
-			for _, card := range cards {
-				if card.HasDetail {
-					detail, err := uc.catalogRepo.GetCardDetailByCardID(card.ID)
-					if err != nil {
-						return nil, err
-					}
-					card.Detail = detail
-				}
-				media, err := uc.catalogRepo.GetCardMediaByCardID(card.ID)
-				if err != nil {
-					return nil, err
-				}
-				card.Media = media
-			}
-			section.Cards = cards
-
-		case constant.SectionTypeFAQs:
-			faqs, err := uc.catalogRepo.GetFAQsBySectionID(section.ID)
-			if err != nil {
-				return nil, err
-			}
-			section.FAQs = faqs
+	cardsBySection, err := uc.catalogRepo.GetCardsBySectionIDs(utils.ContextFrom(ctx), sectionIDs)
+	if err != nil {
+		return nil, err
+	}
 
-			// TODO: Implement other section types
+	for _, section := range sections {
+		if section.Type != constant.SectionTypeCards {
+			continue
+		}
+		section.Cards = cardsBySection[section.ID]
+		if section.Cards == nil {
+			section.Cards = make([]*entity.CatalogCard, 0)
 		}
 	}
 
-	// Convert to public response
-	return uc.toPublicCatalogResponse(catalog, sections), nil
-}
+	if err := uc.loadCardTags(utils.ContextFrom(ctx), sections); err != nil {
+		return nil, err
+	}
 
-// List mendapatkan list catalogs
-func (uc *catalogUseCase) List(profileID int64, filter *dto.CatalogFilter, page, perPage int, orderBy string) ([]*dto.CatalogListResponse, int64, error) {
-	// If profileID provided, filter by user's businesses
-	businessIDs := []int64{}
-	if profileID > 0 && (filter == nil || filter.BusinessID == 0) {
-		// Get user businesses
-		businesses, _, err := uc.businessRepo.List(repository.ListFilter{
-			ProfileID: profileID,
-			Limit:     100, // Get all user businesses
-		})
-		if err != nil {
-			return nil, 0, err
-		}
+	// Convert to response
+	return uc.toCatalogResponse(catalog, sections), nil
+}
 
-		for _, b := range businesses {
-			businessIDs = append(businessIDs, b.ID)
-		}
+// GetBySlug mendapatkan public catalog by slug, tag tidak kosong hanya menampilkan
+// card dengan tag tersebut. Jika query database gagal (bukan karena katalog tidak
+// publik) dan fallback aktif, dilayani dari snapshot
+func (uc *catalogUseCase) GetBySlug(ctx context.Context, slug, visitorCountry, tag string) (*dto.PublicCatalogResponse, error) {
+	resp, err := uc.getBySlug(ctx, slug, visitorCountry, tag)
+	// Snapshot hanya berisi katalog lengkap, tidak bisa difilter tag
+	if err == nil || tag != "" || !uc.snapshotFallback || uc.snapshotStorage == nil {
+		return resp, err
 	}
 
-	// Build filter
-	repoFilter := catalogRepo.ListFilter{
-		Limit:   perPage,
-		Offset:  (page - 1) * perPage,
-		OrderBy: orderBy,
+	var appErr *errors.AppError
+	if errors.As(err, &appErr) {
+		return nil, err
 	}
 
-	if filter != nil {
-		repoFilter.Search = filter.Search
-		repoFilter.BusinessID = filter.BusinessID
-		repoFilter.ThemeID = filter.ThemeID
-		repoFilter.IsActive = filter.IsActive
+	snapshot, snapErr := uc.loadSnapshot(slug)
+	if snapErr != nil {
+		return nil, err
 	}
 
-	// Apply business filter
-	if len(businessIDs) > 0 && repoFilter.BusinessID == 0 {
-		// TODO: Implement multiple business IDs filter in repository
-		// For now, we'll filter manually after fetching
+	// Token JS-challenge di snapshot sudah kedaluwarsa, terbitkan ulang (tanpa database)
+	resp = snapshot.Catalog
+	resp.AnalyticsToken = uc.botFilter.IssueChallenge(resp.ID)
+	resp.SnapshotAt = &snapshot.GeneratedAt
+
+	return resp, nil
+}
+
+// PublicCatalogETag ETag halaman publik katalog dari versi konten (katalog dan
+// semua child), dihitung tanpa me-load katalog lengkap. Selama token JS-challenge
+// aktif, ETag ikut berganti tiap setengah umur token agar token di cache klien
+// tidak kedaluwarsa
+func (uc *catalogUseCase) PublicCatalogETag(ctx context.Context, slug, visitorCountry, tag string) (string, error) {
+	catalog, err := uc.getPublicCatalog(ctx, slug)
+	if err != nil {
+		return "", err
 	}
 
-	// Get catalogs
-	catalogs, total, err := uc.catalogRepo.List(repoFilter)
+	version, err := uc.catalogRepo.GetPublicVersion(ctx, catalog.ID)
 	if err != nil {
-		return nil, 0, err
+		return "", err
 	}
 
-	// Convert to response
-	responses := make([]*dto.CatalogListResponse, 0)
-	for _, catalog := range catalogs {
-		// Manual filter for multiple businesses (temporary)
-		if len(businessIDs) > 0 && repoFilter.BusinessID == 0 {
-			found := false
-			for _, bid := range businessIDs {
-				if catalog.BusinessID == bid {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
+	var window int64
+	if uc.botFilter.ChallengeEnabled() {
+		if half := int64(uc.botFilter.ChallengeTTL() / time.Second / 2); half > 0 {
+			window = uc.clock.Now().Unix() / half
 		}
-
-		responses = append(responses, &dto.CatalogListResponse{
-			ID:           catalog.ID,
-			BusinessID:   catalog.BusinessID,
-			BusinessName: catalog.Business.Name,
-			Slug:         catalog.Slug,
-			Title:        catalog.Title,
-			Subtitle:     catalog.GetSubtitle(),
-			IsActive:     catalog.IsActive,
-			ThemeName:    catalog.Theme.Name,
-			CreatedAt:    catalog.CreatedAt,
-			UpdatedAt:    catalog.UpdatedAt,
-			PublicURL:    fmt.Sprintf("/c/%s", catalog.Slug),
-		})
 	}
 
-	return responses, total, nil
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%s|%s|%t|%d",
+		catalog.ID, version, tag, uc.mediaReplicationService.UseReplica(visitorCountry), window)))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
 }
 
-// Update update catalog
-func (uc *catalogUseCase) Update(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateCatalogRequest) (*dto.CatalogResponse, error) {
-	// Get existing catalog
-	catalog, err := uc.catalogRepo.GetByID(id)
+func (uc *catalogUseCase) getBySlug(ctx context.Context, slug, visitorCountry, tag string) (*dto.PublicCatalogResponse, error) {
+	catalog, err := uc.getPublicCatalog(ctx, slug)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set old_data ke Gin Context
-	if ctx != nil {
-		ctx.Set(middleware.GinKeyAuditOldData, catalog)
+	var filterTag *entity.CatalogTag
+	if tag != "" {
+		filterTag, err = uc.catalogRepo.GetTagBySlug(ctx, catalog.ID, tag)
+		if err != nil {
+			return nil, err
+		}
+		if filterTag == nil {
+			return nil, errors.New(errors.ErrNotFound, constant.ErrMsgTagNotFound, 404)
+		}
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+	// Get sections
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, catalog.ID)
+	if err != nil {
 		return nil, err
 	}
 
-	// Update fields
-	if req.ThemeID > 0 {
-		catalog.ThemeID = req.ThemeID
-	}
-	if req.Title != "" {
-		catalog.Title = req.Title
-	}
-	if req.Subtitle != "" {
-		catalog.Subtitle = database.NullString(req.Subtitle)
-	}
-	if req.IsActive != nil {
-		catalog.IsActive = *req.IsActive
-	}
-	if req.Settings != nil {
-		catalog.Settings = req.Settings
+	// Load section content. Cards, detail, links dan media semua section
+	// di-load sekaligus supaya jumlah query tidak bergantung jumlah card
+	if err := uc.loadSectionCards(ctx, sections); err != nil {
+		return nil, err
 	}
 
-	catalog.UpdatedBy = database.NullInt64(profileID)
-	catalog.UpdatedAt = &[]time.Time{time.Now()}[0]
+	for _, section := range sections {
+		if !section.IsVisible {
+			continue
+		}
 
-	// Update in transaction
-	tx, err := uc.db.Begin()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to begin transaction")
-	}
-	defer tx.Rollback()
+		switch section.Type {
+		case constant.SectionTypeFAQs:
+			faqs, err := uc.catalogRepo.GetFAQsBySectionID(ctx, section.ID)
+			if err != nil {
+				return nil, err
+			}
+			section.FAQs = faqs
 
-	if err := uc.catalogRepo.Update(tx, catalog); err != nil {
-		return nil, err
+		case constant.SectionTypeLegal:
+			legal, err := uc.catalogRepo.GetCurrentLegalVersion(ctx, section.ID)
+			if err != nil && !errors.Is(err, errors.ErrNotFound) {
+				return nil, err
+			}
+			section.Legal = legal
+
+			// TODO: Implement other section types
+		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, errors.Wrap(err, "failed to commit transaction")
+	// Pengunjung di luar region utama dilayani dari replika media
+	if uc.mediaReplicationService.UseReplica(visitorCountry) {
+		if err := uc.applyMediaReplicas(ctx, catalog.BusinessID, sections); err != nil {
+			return nil, err
+		}
 	}
 
-	// Return updated catalog
-	return uc.GetByID(id, profileID)
+	// Convert to public response
+	return uc.toPublicCatalogResponse(catalog, sections, filterTag), nil
 }
 
-// Delete soft delete catalog
-func (uc *catalogUseCase) Delete(ctx *gin.Context, id int64, profileID int64) error {
+// getPublicCatalog get katalog by slug dan pastikan boleh tampil ke publik
+func (uc *catalogUseCase) getPublicCatalog(ctx context.Context, slug string) (*entity.Catalog, error) {
 	// Get catalog
-	catalog, err := uc.catalogRepo.GetByID(id)
+	catalog, err := uc.catalogRepo.GetBySlug(ctx, slug)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Inject old_data ke audit context
-	if ctx != nil {
-		ctx.Set(middleware.GinKeyAuditOldData, catalog)
+	if err := checkPublicCatalog(catalog); err != nil {
+		return nil, err
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogDelete); err != nil {
-		return err
+	return catalog, nil
+}
+
+// checkPublicCatalog validasi katalog boleh tampil ke publik
+func checkPublicCatalog(catalog *entity.Catalog) error {
+	// Check if catalog is active
+	if !catalog.IsActive {
+		return errors.New(errors.ErrCatalogInactive, constant.ErrMsgCatalogInactive, 404)
 	}
 
-	// Delete in transaction
-	tx, err := uc.db.Begin()
-	if err != nil {
-		return errors.Wrap(err, "failed to begin transaction")
+	// Konten katalog yang diarsipkan baru dipulihkan saat pemilik membukanya
+	if catalog.IsArchived() {
+		return errors.New(errors.ErrCatalogInactive, constant.ErrMsgCatalogArchived, 404)
 	}
-	defer tx.Rollback()
 
-	if err := uc.catalogRepo.Delete(tx, id); err != nil {
-		return err
+	// Katalog yang belum disetujui reviewer tidak tampil ke publik
+	if !catalog.IsPublished() {
+		return errors.New(errors.ErrCatalogInactive, constant.ErrMsgCatalogNotPublished, 404)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return errors.Wrap(err, "failed to commit transaction")
+	// Check if business is accessible
+	if !catalog.Business.IsActive {
+		return errors.New(errors.ErrBusinessInactive, constant.ErrMsgBusinessInactive, 404)
 	}
 
 	return nil
 }
 
-// CreateSection membuat section baru
-func (uc *catalogUseCase) CreateSection(catalogID int64, profileID int64, req *dto.CreateSectionRequest) error {
-	// Get catalog
-	catalog, err := uc.catalogRepo.GetByID(catalogID)
-	if err != nil {
-		return err
+// loadSectionCards isi cards (beserta detail, links dan media) untuk semua
+// section cards yang visible dengan query batch
+func (uc *catalogUseCase) loadSectionCards(ctx context.Context, sections []*entity.CatalogSection) error {
+	sectionIDs := make([]int64, 0, len(sections))
+	for _, section := range sections {
+		if section.IsVisible && section.Type == constant.SectionTypeCards {
+			sectionIDs = append(sectionIDs, section.ID)
+		}
+	}
+	if len(sectionIDs) == 0 {
+		return nil
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+	cardsBySection, err := uc.catalogRepo.GetCardsWithRelationsBySectionIDs(ctx, sectionIDs)
+	if err != nil {
 		return err
 	}
 
-	// Validate section type
-	if !constant.IsValidSectionType(req.Type) {
-		return errors.New(errors.ErrValidation, constant.ErrMsgSectionTypeInvalid, 400)
+	cardIDs := make([]int64, 0)
+	for _, cards := range cardsBySection {
+		for _, card := range cards {
+			cardIDs = append(cardIDs, card.ID)
+		}
 	}
 
-	// Create in transaction
-	tx, err := uc.db.Begin()
+	mediaByCard, err := uc.catalogRepo.GetMediaByCardIDs(ctx, cardIDs)
 	if err != nil {
-		return errors.Wrap(err, "failed to begin transaction")
+		return err
 	}
-	defer tx.Rollback()
 
-	if err := uc.createSectionInternal(tx, catalogID, profileID, req); err != nil {
+	tagsByCard, err := uc.catalogRepo.GetTagsByCardIDs(ctx, cardIDs)
+	if err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	for _, section := range sections {
+		if !section.IsVisible || section.Type != constant.SectionTypeCards {
+			continue
+		}
+		cards := cardsBySection[section.ID]
+		if cards == nil {
+			cards = make([]*entity.CatalogCard, 0)
+		}
+		for _, card := range cards {
+			card.Media = mediaByCard[card.ID]
+			if card.Media == nil {
+				card.Media = make([]*entity.CatalogCardMedia, 0)
+			}
+			card.Tags = tagsByCard[card.ID]
+		}
+		section.Cards = cards
+	}
+
+	return nil
 }
 
-// UpdateSection update section
-func (uc *catalogUseCase) UpdateSection(ctx *gin.Context, sectionID int64, profileID int64, req *dto.UpdateSectionRequest) error {
-	// Get section
-	section, err := uc.catalogRepo.GetSectionByID(sectionID)
+// GetPublicCard mendapatkan card publik berdasarkan slug detail di katalog untuk
+// halaman detail produk. Slug lama hasil rename mengembalikan slug terbaru untuk redirect
+func (uc *catalogUseCase) GetPublicCard(ctx context.Context, catalogSlug, cardSlug, visitorCountry string) (*dto.CardResponse, string, error) {
+	catalog, err := uc.getPublicCatalog(ctx, catalogSlug)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
-	// Inject old_data ke audit context
-	if ctx != nil {
-		ctx.Set(middleware.GinKeyAuditOldData, section)
+	detail, err := uc.catalogRepo.GetCardDetailBySlug(ctx, catalog.ID, cardSlug)
+	if err != nil {
+		return nil, "", err
+	}
+	if detail == nil {
+		redirect, err := uc.catalogRepo.GetCardSlugRedirect(ctx, catalog.ID, cardSlug)
+		if err != nil {
+			return nil, "", err
+		}
+		if redirect != "" && redirect != cardSlug {
+			return nil, redirect, nil
+		}
+		return nil, "", errors.New(errors.ErrNotFound, constant.ErrMsgCardNotFound, 404)
 	}
 
-	// Get catalog for permission check
-	catalog, err := uc.catalogRepo.GetByID(section.CatalogID)
+	card, err := uc.catalogRepo.GetCardByID(ctx, detail.CardID)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
-		return err
+	section, err := uc.catalogRepo.GetSectionByID(ctx, card.SectionID)
+	if err != nil {
+		return nil, "", err
 	}
 
-	// Validate updates
-	if req.Type != "" && !constant.IsValidSectionType(req.Type) {
-		return errors.New(errors.ErrValidation, constant.ErrMsgSectionTypeInvalid, 400)
+	// Card, detail dan section harus visible, sama seperti di halaman katalog
+	if !section.IsVisible || section.Type != constant.SectionTypeCards ||
+		!card.IsPubliclyVisible() || !card.HasDetail || !detail.IsVisible {
+		return nil, "", errors.New(errors.ErrNotFound, constant.ErrMsgCardNotFound, 404)
 	}
 
-	// Update fields
-	if req.Type != "" {
-		section.Type = req.Type
+	detail.Links, err = uc.catalogRepo.GetCardLinksByDetailID(ctx, detail.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	card.Detail = detail
+
+	card.Media, err = uc.catalogRepo.GetCardMediaByCardID(ctx, card.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tagsByCard, err := uc.catalogRepo.GetTagsByCardIDs(ctx, []int64{card.ID})
+	if err != nil {
+		return nil, "", err
+	}
+	card.Tags = tagsByCard[card.ID]
+
+	// Pengunjung di luar region utama dilayani dari replika media
+	if uc.mediaReplicationService.UseReplica(visitorCountry) {
+		section.Cards = []*entity.CatalogCard{card}
+		if err := uc.applyMediaReplicas(ctx, catalog.BusinessID, []*entity.CatalogSection{section}); err != nil {
+			return nil, "", err
+		}
+	}
+
+	resp := uc.toPublicCardResponse(catalog, card)
+	return &resp, "", nil
+}
+
+// SearchPublicCards cari card yang tampil di katalog publik berdasarkan judul,
+// subtitle dan deskripsi detail, hasil diurutkan dari yang paling relevan
+func (uc *catalogUseCase) SearchPublicCards(ctx context.Context, slug, search, visitorCountry string, page, perPage int) ([]*dto.CardResponse, int64, error) {
+	search, err := validateSearchQuery(search)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	catalog, err := uc.getPublicCatalog(ctx, slug)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids, total, err := uc.catalogRepo.SearchPublicCards(ctx, catalog.ID, search, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cardsByID, err := uc.catalogRepo.GetCardsWithRelationsByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mediaByCard, err := uc.catalogRepo.GetMediaByCardIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tagsByCard, err := uc.catalogRepo.GetTagsByCardIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Urutan relevansi dari hasil pencarian dipertahankan
+	cards := make([]*entity.CatalogCard, 0, len(ids))
+	for _, id := range ids {
+		card, ok := cardsByID[id]
+		if !ok {
+			continue
+		}
+		card.Media = mediaByCard[card.ID]
+		if card.Media == nil {
+			card.Media = make([]*entity.CatalogCardMedia, 0)
+		}
+		card.Tags = tagsByCard[card.ID]
+		cards = append(cards, card)
+	}
+
+	// Pengunjung di luar region utama dilayani dari replika media
+	if uc.mediaReplicationService.UseReplica(visitorCountry) {
+		section := &entity.CatalogSection{Cards: cards}
+		if err := uc.applyMediaReplicas(ctx, catalog.BusinessID, []*entity.CatalogSection{section}); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	responses := make([]*dto.CardResponse, len(cards))
+	for i, card := range cards {
+		resp := uc.toPublicCardResponse(catalog, card)
+		responses[i] = &resp
+	}
+
+	return responses, total, nil
+}
+
+// GetEmbedConfig pengaturan widget embed katalog publik: warna dari settings
+// katalog dan layout dari section cards pertama yang tampil
+func (uc *catalogUseCase) GetEmbedConfig(ctx context.Context, slug string) (*dto.EmbedConfigResponse, error) {
+	catalog, err := uc.getPublicCatalog(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, catalog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	themeType := ""
+	if catalog.Theme != nil {
+		themeType = catalog.Theme.Type
+	}
+	layout := entity.DefaultCardLayout(themeType)
+	for _, section := range sections {
+		if section.IsVisible && section.Type == constant.SectionTypeCards {
+			if sectionLayout, ok, err := entity.CardLayoutFromConfig(section.Config); ok && err == nil {
+				layout = sectionLayout.WithDefaults(layout)
+			}
+			break
+		}
+	}
+
+	setting := func(key string) string {
+		value, _ := catalog.Settings[key].(string)
+		return value
+	}
+
+	return &dto.EmbedConfigResponse{
+		Version:    constant.EmbedScriptVersion,
+		Slug:       catalog.Slug,
+		Title:      catalog.Title,
+		CatalogURL: uc.catalogCanonicalURL(catalog.Slug),
+		Colors: dto.EmbedColors{
+			Primary:    setting("primary_color"),
+			Secondary:  setting("secondary_color"),
+			Background: setting("background_color"),
+			Text:       setting("text_color"),
+		},
+		FontFamily:   setting("font_family"),
+		BorderRadius: setting("border_radius"),
+		Layout: dto.EmbedLayout{
+			Type:             layout.Type,
+			Columns:          layout.Columns.Desktop,
+			ImageAspectRatio: layout.ImageAspectRatio,
+		},
+	}, nil
+}
+
+// catalogCanonicalURL canonical URL katalog publik, kosong jika template tidak diset
+func (uc *catalogUseCase) catalogCanonicalURL(catalogSlug string) string {
+	if uc.catalogURLTemplate == "" {
+		return ""
+	}
+	return strings.ReplaceAll(uc.catalogURLTemplate, "{slug}", catalogSlug)
+}
+
+// linkRedirectURL URL redirect link dengan pencatatan klik, kosong jika template tidak diset
+func (uc *catalogUseCase) linkRedirectURL(token string) string {
+	if uc.redirectURLTemplate == "" || token == "" {
+		return ""
+	}
+	return strings.ReplaceAll(uc.redirectURLTemplate, "{token}", token)
+}
+
+// cardCanonicalURL canonical URL detail card publik, kosong jika template tidak diset
+func (uc *catalogUseCase) cardCanonicalURL(catalogSlug, cardSlug string) string {
+	if uc.cardURLTemplate == "" {
+		return ""
+	}
+	return strings.NewReplacer("{slug}", catalogSlug, "{card_slug}", cardSlug).Replace(uc.cardURLTemplate)
+}
+
+// List mendapatkan list catalogs
+func (uc *catalogUseCase) List(ctx context.Context, profileID int64, filter *dto.CatalogFilter, page, perPage int, orderBy string) ([]*dto.CatalogListResponse, int64, error) {
+	repoFilter, err := uc.listFilter(ctx, profileID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	repoFilter.Limit = perPage
+	repoFilter.Offset = (page - 1) * perPage
+	repoFilter.OrderBy = orderBy
+
+	// Get catalogs
+	catalogs, total, err := uc.catalogRepo.List(ctx, repoFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Convert to response
+	responses := make([]*dto.CatalogListResponse, 0)
+	for _, catalog := range catalogs {
+		responses = append(responses, toCatalogListResponse(catalog))
+	}
+
+	return responses, total, nil
+}
+
+// ListByCursor list katalog dengan cursor pagination, next cursor kosong berarti halaman terakhir
+func (uc *catalogUseCase) ListByCursor(ctx context.Context, profileID int64, filter *dto.CatalogFilter, keyset *database.Keyset, limit int) ([]*dto.CatalogListResponse, string, error) {
+	repoFilter, err := uc.listFilter(ctx, profileID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	// Ambil satu baris lebih untuk tahu masih ada halaman berikutnya
+	repoFilter.Limit = limit + 1
+	repoFilter.Keyset = keyset
+
+	catalogs, _, err := uc.catalogRepo.List(ctx, repoFilter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var nextCursor string
+	if len(catalogs) > limit {
+		catalogs = catalogs[:limit]
+		last := catalogs[limit-1]
+		nextCursor = utils.EncodeCursor(keyset, catalogCursorValue(last, keyset.Sort), last.ID)
+	}
+
+	responses := make([]*dto.CatalogListResponse, len(catalogs))
+	for i, catalog := range catalogs {
+		responses[i] = toCatalogListResponse(catalog)
+	}
+
+	return responses, nextCursor, nil
+}
+
+// listFilter filter repository untuk list katalog: dibatasi ke business milik user
+// dan memakai search engine (jika dikonfigurasi) untuk pencarian
+func (uc *catalogUseCase) listFilter(ctx context.Context, profileID int64, filter *dto.CatalogFilter) (catalogRepo.ListFilter, error) {
+	// Filter by business yang bisa diakses user atau service account
+	var businessIDs []int64
+	_, isServiceAccount := service.ServiceAccountScope(ctx)
+	if isServiceAccount || filter == nil || filter.BusinessID == 0 {
+		var err error
+		businessIDs, err = uc.accessibleBusinessIDs(ctx, profileID)
+		if err != nil {
+			return catalogRepo.ListFilter{}, err
+		}
+	}
+
+	// Build filter
+	repoFilter := catalogRepo.ListFilter{}
+
+	if filter != nil {
+		repoFilter.Search = filter.Search
+		repoFilter.BusinessID = filter.BusinessID
+		repoFilter.ThemeID = filter.ThemeID
+		repoFilter.IsActive = filter.IsActive
+	}
+
+	// Apply business filter, user tanpa business tidak melihat katalog apa pun.
+	// Service account tetap dibatasi ke business pemiliknya walau filter business diisi
+	if isServiceAccount {
+		if repoFilter.BusinessID != 0 && repoFilter.BusinessID != businessIDs[0] {
+			businessIDs = []int64{}
+		}
+		repoFilter.BusinessID = 0
+		repoFilter.BusinessIDs = businessIDs
+	} else if businessIDs != nil && repoFilter.BusinessID == 0 {
+		repoFilter.BusinessIDs = businessIDs
+	}
+
+	// Search engine (jika dikonfigurasi) menggantikan Postgres full-text search,
+	// filter lain dan paginasi tetap di database
+	if repoFilter.Search != "" && uc.searchIndexer.Enabled() {
+		searchBusinessIDs := businessIDs
+		if repoFilter.BusinessID > 0 {
+			searchBusinessIDs = []int64{repoFilter.BusinessID}
+		}
+
+		ids, err := uc.searchIndexer.Search(repoFilter.Search, searchBusinessIDs)
+		if err != nil {
+			return catalogRepo.ListFilter{}, errors.Wrap(err, "failed to search catalogs")
+		}
+
+		repoFilter.Search = ""
+		repoFilter.IDs = ids
+	}
+
+	return repoFilter, nil
+}
+
+// accessibleBusinessIDs business yang boleh diakses request: business pemilik untuk
+// service account, business tempat profile menjadi member untuk user
+func (uc *catalogUseCase) accessibleBusinessIDs(ctx context.Context, profileID int64) ([]int64, error) {
+	if scopeID, ok := service.ServiceAccountScope(ctx); ok {
+		return []int64{scopeID}, nil
+	}
+
+	// Profile 0 bukan berarti tanpa batasan
+	if profileID <= 0 {
+		return nil, errors.New(errors.ErrUnauthorized, constant.ErrMsgUnauthorized, 401)
+	}
+
+	businesses, _, err := uc.businessRepo.List(ctx, repository.ListFilter{
+		ProfileID: profileID,
+		Limit:     100, // Get all user businesses
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	businessIDs := make([]int64, 0, len(businesses))
+	for _, b := range businesses {
+		businessIDs = append(businessIDs, b.ID)
+	}
+
+	return businessIDs, nil
+}
+
+// SearchDashboard cari katalog (judul, slug) dan judul card di semua business
+// milik user, hasil diurutkan dari yang paling relevan dengan kata cocok di-highlight
+func (uc *catalogUseCase) SearchDashboard(ctx context.Context, profileID int64, search string, page, perPage int) ([]*dto.CatalogSearchResponse, int64, error) {
+	search, err := validateSearchQuery(search)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	businessIDs, err := uc.accessibleBusinessIDs(ctx, profileID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits, total, err := uc.catalogRepo.SearchDashboard(ctx, catalogRepo.DashboardSearchFilter{
+		BusinessIDs:  businessIDs,
+		Search:       search,
+		CardsPerItem: constant.MaxSearchMatchedCards,
+		Limit:        perPage,
+		Offset:       (page - 1) * perPage,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	terms := searchTerms(search)
+	responses := make([]*dto.CatalogSearchResponse, len(hits))
+	for i, hit := range hits {
+		responses[i] = toCatalogSearchResponse(hit, terms)
+	}
+
+	return responses, total, nil
+}
+
+// GetByIDs ringkasan beberapa katalog sekaligus untuk dashboard. Error per item
+// (tidak ditemukan, tanpa akses) tidak menggagalkan item lain
+func (uc *catalogUseCase) GetByIDs(ctx *gin.Context, profileID int64, ids []int64) ([]*dto.CatalogBatchItem, error) {
+	catalogs, _, err := uc.catalogRepo.List(utils.ContextFrom(ctx), catalogRepo.ListFilter{
+		IDs:     ids,
+		Limit:   len(ids),
+		OrderBy: "c.c_id ASC",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]*entity.Catalog, len(catalogs))
+	for _, catalog := range catalogs {
+		byID[catalog.ID] = catalog
+	}
+
+	items := make([]*dto.CatalogBatchItem, len(ids))
+	for i, id := range ids {
+		item := &dto.CatalogBatchItem{ID: id}
+		items[i] = item
+
+		catalog, ok := byID[id]
+		if !ok {
+			item.Err = errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+			continue
+		}
+		// Permission di-cache per request, cukup sekali query per business
+		if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+			item.Err = err
+			continue
+		}
+		item.Catalog = toCatalogListResponse(catalog)
+	}
+
+	return items, nil
+}
+
+// GetPublicBySlugs ringkasan beberapa katalog publik sekaligus untuk aplikasi agregator
+func (uc *catalogUseCase) GetPublicBySlugs(ctx context.Context, slugs []string) ([]*dto.PublicCatalogBatchItem, error) {
+	catalogs, err := uc.catalogRepo.GetBySlugs(ctx, slugs)
+	if err != nil {
+		return nil, err
+	}
+
+	bySlug := make(map[string]*entity.Catalog, len(catalogs))
+	for _, catalog := range catalogs {
+		bySlug[catalog.Slug] = catalog
+	}
+
+	items := make([]*dto.PublicCatalogBatchItem, len(slugs))
+	for i, slug := range slugs {
+		item := &dto.PublicCatalogBatchItem{Slug: slug}
+		items[i] = item
+
+		catalog, ok := bySlug[slug]
+		if !ok {
+			item.Err = errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+			continue
+		}
+		if err := checkPublicCatalog(catalog); err != nil {
+			item.Err = err
+			continue
+		}
+
+		summary := &dto.PublicCatalogSummary{
+			Slug:            catalog.Slug,
+			Title:           catalog.Title,
+			Subtitle:        catalog.GetSubtitle(),
+			MetaDescription: catalog.SEODescription(),
+			OGImage:         catalog.SEOImage(),
+			BusinessName:    catalog.Business.Name,
+			BusinessSlug:    catalog.Business.Slug,
+			Rating: dto.RatingSummary{
+				Average: catalog.RatingAvg,
+				Count:   catalog.RatingCount,
+			},
+			CanonicalURL: uc.catalogCanonicalURL(catalog.Slug),
+			PublicURL:    fmt.Sprintf("/c/%s", catalog.Slug),
+		}
+		if catalog.Business.LogoURL.Valid {
+			summary.BusinessLogo = &catalog.Business.LogoURL.String
+		}
+		item.Catalog = summary
+	}
+
+	return items, nil
+}
+
+// StreamSitemap kirim URL sitemap satu per satu ke fn. URL mengikuti template
+// canonical, baseURL (mis. https://host/api/v1) dipakai jika template tidak diset.
+// businessID 0 = semua business, business yang tidak ada atau nonaktif = 404
+func (uc *catalogUseCase) StreamSitemap(ctx context.Context, businessID int64, baseURL string, fn func(url *dto.SitemapURL) error) error {
+	if businessID > 0 {
+		business, err := uc.businessRepo.GetByID(ctx, businessID)
+		if err != nil {
+			return err
+		}
+		if !business.IsActive {
+			return errors.New(errors.ErrBusinessInactive, constant.ErrMsgBusinessInactive, 404)
+		}
+	}
+
+	return uc.catalogRepo.StreamSitemapEntries(ctx, businessID, constant.SitemapMaxURLs, func(entry *entity.SitemapEntry) error {
+		var loc string
+		if entry.CardSlug == "" {
+			loc = uc.catalogCanonicalURL(entry.CatalogSlug)
+			if loc == "" {
+				loc = fmt.Sprintf("%s/c/%s", baseURL, url.PathEscape(entry.CatalogSlug))
+			}
+		} else {
+			loc = uc.cardCanonicalURL(entry.CatalogSlug, entry.CardSlug)
+			if loc == "" {
+				loc = fmt.Sprintf("%s/c/%s/cards/%s", baseURL, url.PathEscape(entry.CatalogSlug), url.PathEscape(entry.CardSlug))
+			}
+		}
+
+		return fn(&dto.SitemapURL{Loc: loc, LastMod: entry.LastMod})
+	})
+}
+
+// catalogCursorValue nilai kolom urutan katalog untuk cursor berikutnya,
+// harus sama dengan kolom sort cursor di handler
+func catalogCursorValue(catalog *entity.Catalog, sort string) string {
+	switch sort {
+	case "updated_at":
+		if catalog.UpdatedAt != nil {
+			return catalog.UpdatedAt.Format(time.RFC3339Nano)
+		}
+		return catalog.CreatedAt.Format(time.RFC3339Nano)
+	case "title":
+		return catalog.Title
+	default:
+		return catalog.CreatedAt.Format(time.RFC3339Nano)
+	}
+}
+
+func toCatalogSearchResponse(hit *entity.CatalogSearchHit, terms []string) *dto.CatalogSearchResponse {
+	catalog := hit.Catalog
+	resp := &dto.CatalogSearchResponse{
+		ID:             catalog.ID,
+		BusinessID:     catalog.BusinessID,
+		BusinessName:   catalog.Business.Name,
+		Slug:           catalog.Slug,
+		SlugHighlight:  highlightMatches(catalog.Slug, terms),
+		Title:          catalog.Title,
+		TitleHighlight: highlightMatches(catalog.Title, terms),
+		Subtitle:       catalog.GetSubtitle(),
+		IsActive:       catalog.IsActive,
+		Status:         catalog.Status,
+		ArchivedAt:     catalog.ArchivedAt,
+		UpdatedAt:      catalog.UpdatedAt,
+		Rank:           hit.Rank,
+		PublicURL:      fmt.Sprintf("/c/%s", catalog.Slug),
+		MatchedCards:   make([]dto.CatalogSearchCardResponse, len(hit.Cards)),
+	}
+	for i, card := range hit.Cards {
+		resp.MatchedCards[i] = dto.CatalogSearchCardResponse{
+			ID:             card.ID,
+			SectionID:      card.SectionID,
+			Title:          card.Title,
+			TitleHighlight: highlightMatches(card.Title, terms),
+			IsVisible:      card.IsVisible,
+		}
+	}
+	return resp
+}
+
+func toCatalogListResponse(catalog *entity.Catalog) *dto.CatalogListResponse {
+	return &dto.CatalogListResponse{
+		ID:           catalog.ID,
+		BusinessID:   catalog.BusinessID,
+		BusinessName: catalog.Business.Name,
+		Slug:         catalog.Slug,
+		Title:        catalog.Title,
+		Subtitle:     catalog.GetSubtitle(),
+		IsActive:     catalog.IsActive,
+		Status:       catalog.Status,
+		PublishedAt:  catalog.PublishedAt,
+		ArchivedAt:   catalog.ArchivedAt,
+		DeletedAt:    catalog.DeletedAt,
+		ThemeName:    catalog.Theme.Name,
+		CreatedAt:    catalog.CreatedAt,
+		UpdatedAt:    catalog.UpdatedAt,
+		PublicURL:    fmt.Sprintf("/c/%s", catalog.Slug),
+	}
+}
+
+// Discover direktori publik katalog yang ikut serta (opt-in)
+func (uc *catalogUseCase) Discover(ctx context.Context, filter *dto.DirectoryFilter, page, perPage int, orderBy string) ([]*dto.DirectoryCatalogResponse, int64, error) {
+	if filter.Type != "" && !constant.IsValidBusinessType(filter.Type) {
+		return nil, 0, errors.New(errors.ErrValidation, constant.ErrMsgBusinessTypeInvalid, 400)
+	}
+
+	entries, total, err := uc.catalogRepo.ListDirectory(ctx, catalogRepo.DirectoryFilter{
+		Search:   filter.Search,
+		Category: filter.Category,
+		Type:     filter.Type,
+		City:     strings.TrimSpace(filter.City),
+		Limit:    perPage,
+		Offset:   (page - 1) * perPage,
+		OrderBy:  orderBy,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.DirectoryCatalogResponse, len(entries))
+	for i, entry := range entries {
+		catalog := entry.Catalog
+		resp := &dto.DirectoryCatalogResponse{
+			Slug:         catalog.Slug,
+			Title:        catalog.Title,
+			Subtitle:     catalog.GetSubtitle(),
+			BusinessName: catalog.Business.Name,
+			BusinessSlug: catalog.Business.Slug,
+			Category:     entry.CategorySlug.String,
+			CategoryName: entry.CategoryName.String,
+			BusinessType: catalog.Business.Type,
+			City:         catalog.Business.City.String,
+			Views:        entry.Views,
+			RatingAvg:    catalog.RatingAvg,
+			RatingCount:  catalog.RatingCount,
+			PublishedAt:  catalog.PublishedAt,
+			PublicURL:    fmt.Sprintf("/c/%s", catalog.Slug),
+		}
+		if catalog.Business.LogoURL.Valid {
+			resp.BusinessLogo = &catalog.Business.LogoURL.String
+		}
+		responses[i] = resp
+	}
+
+	return responses, total, nil
+}
+
+// Update update catalog
+func (uc *catalogUseCase) Update(ctx *gin.Context, id int64, profileID int64, req *dto.UpdateCatalogRequest) (*dto.CatalogResponse, error) {
+	// Get existing catalog
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set old_data ke Gin Context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, catalog)
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	// Tolak jika katalog sudah berubah sejak dibaca klien. Versi yang dibaca
+	// di atas juga dicek di WHERE update untuk perubahan di antara keduanya
+	if preconditionFailed(catalog.Version, catalog.GetLastModifiedAt(), req.Version, req.UnmodifiedSince) {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgCatalogModified, 409)
+	}
+
+	// Update fields
+	if req.ThemeID > 0 {
+		catalog.ThemeID = req.ThemeID
+	}
+	if req.Title != "" {
+		catalog.Title = req.Title
+	}
+	if req.Subtitle != "" {
+		catalog.Subtitle = database.NullString(req.Subtitle)
+	}
+	if req.IsActive != nil {
+		catalog.IsActive = *req.IsActive
+	}
+	if req.Settings != nil {
+		catalog.Settings = req.Settings
+	}
+	if req.MetaTitle != nil {
+		catalog.MetaTitle = database.NullString(*req.MetaTitle)
+	}
+	if req.MetaDescription != nil {
+		catalog.MetaDescription = database.NullString(*req.MetaDescription)
+	}
+	if req.OGImage != nil {
+		catalog.OGImage = database.NullString(*req.OGImage)
+	}
+	if req.AllowIndexing != nil {
+		catalog.AllowIndexing = *req.AllowIndexing
+	}
+	if req.Listed != nil {
+		catalog.Listed = *req.Listed
+	}
+	if req.CategoryID != nil {
+		if *req.CategoryID > 0 {
+			if err := uc.checkCategory(utils.ContextFrom(ctx), *req.CategoryID); err != nil {
+				return nil, err
+			}
+		}
+		catalog.CategoryID = database.NullInt64(*req.CategoryID)
+	}
+
+	catalog.UpdatedBy = database.NullInt64(profileID)
+	catalog.UpdatedAt = &[]time.Time{time.Now()}[0]
+
+	// Update in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.Update(utils.ContextFrom(ctx), tx, catalog); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+
+	// Return updated catalog
+	return uc.GetByID(ctx, id, profileID)
+}
+
+// Delete soft delete catalog
+func (uc *catalogUseCase) Delete(ctx *gin.Context, id int64, profileID int64) error {
+	// Get catalog
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, catalog)
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogDelete); err != nil {
+		return err
+	}
+
+	// Delete in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.Delete(utils.ContextFrom(ctx), tx, id, profileID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+
+	return nil
+}
+
+// ListTrash list katalog yang sudah dihapus (di trash) pada business milik user
+func (uc *catalogUseCase) ListTrash(ctx *gin.Context, profileID int64, filter *dto.CatalogFilter, page, perPage int, orderBy string) ([]*dto.CatalogListResponse, int64, error) {
+	if filter != nil && filter.BusinessID > 0 {
+		if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, filter.BusinessID, profileID, constant.PermCatalogView); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	repoFilter, err := uc.listFilter(utils.ContextFrom(ctx), profileID, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	repoFilter.Deleted = true
+	repoFilter.Limit = perPage
+	repoFilter.Offset = (page - 1) * perPage
+	repoFilter.OrderBy = orderBy
+
+	catalogs, total, err := uc.catalogRepo.List(utils.ContextFrom(ctx), repoFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.CatalogListResponse, len(catalogs))
+	for i, catalog := range catalogs {
+		responses[i] = toCatalogListResponse(catalog)
+	}
+
+	return responses, total, nil
+}
+
+// Restore pulihkan katalog dari trash. Katalog tetap nonaktif sampai diaktifkan lewat update
+func (uc *catalogUseCase) Restore(ctx *gin.Context, id int64, profileID int64) (*dto.CatalogResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, catalog)
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogRestore); err != nil {
+		return nil, err
+	}
+
+	// Katalog yang dipulihkan ikut dihitung batas plan
+	if err := uc.planEnforcement.CheckCatalogLimit(utils.ContextFrom(ctx), catalog.BusinessID); err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.Restore(utils.ContextFrom(ctx), tx, id, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+
+	return uc.GetByID(ctx, id, profileID)
+}
+
+// Purge hapus permanen katalog beserta section, card dan data turunannya.
+// Hanya untuk katalog yang sudah ada di trash
+func (uc *catalogUseCase) Purge(ctx *gin.Context, id int64, profileID int64) error {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, catalog)
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogPurge); err != nil {
+		return err
+	}
+
+	if !catalog.IsDeleted() {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCatalogNotInTrash, 400)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.HardDelete(utils.ContextFrom(ctx), tx, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+
+	return nil
+}
+
+// CreateSection membuat section baru
+func (uc *catalogUseCase) CreateSection(ctx *gin.Context, catalogID int64, profileID int64, req *dto.CreateSectionRequest) error {
+	// Get catalog
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	if err := uc.rehydrator.Rehydrate(utils.ContextFrom(ctx), catalog); err != nil {
+		return err
+	}
+
+	// Validate section type
+	if !constant.IsValidSectionType(req.Type) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgSectionTypeInvalid, 400)
+	}
+
+	// Cek batas section sesuai plan
+	limits, err := uc.contentLimits(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return err
+	}
+	count, err := uc.catalogRepo.CountSections(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return err
+	}
+	if count >= limits.maxSections {
+		return errors.New(errors.ErrConflict, fmt.Sprintf(constant.ErrMsgSectionLimitReached, limits.maxSections), 409)
+	}
+
+	// Create in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.createSectionInternal(utils.ContextFrom(ctx), tx, catalogID, profileID, req); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// UpdateSection update section
+func (uc *catalogUseCase) UpdateSection(ctx *gin.Context, sectionID int64, profileID int64, req *dto.UpdateSectionRequest) error {
+	// Get section
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, section)
+	}
+
+	// Get catalog for permission check
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	// Validate updates
+	if req.Type != "" && !constant.IsValidSectionType(req.Type) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgSectionTypeInvalid, 400)
+	}
+
+	// Update fields
+	if req.Type != "" {
+		section.Type = req.Type
+	}
+	if req.IsVisible != nil {
+		section.IsVisible = *req.IsVisible
+	}
+	if req.Config != nil {
+		section.Config = req.Config
+	}
+	if section.Config == nil {
+		section.Config = make(map[string]interface{})
+	}
+	if err := validateSectionConfig(section.Type, section.Config); err != nil {
+		return err
+	}
+	section.UpdatedBy = sql.NullInt64{Int64: profileID, Valid: true}
+
+	// Update in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.UpdateSection(utils.ContextFrom(ctx), tx, section); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// DeleteSection delete section
+func (uc *catalogUseCase) DeleteSection(ctx *gin.Context, sectionID int64, profileID int64) error {
+	// Get section
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, section)
+	}
+
+	// Get catalog for permission check
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	// Delete in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.DeleteSection(utils.ContextFrom(ctx), tx, sectionID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// ApplyBrand timpa settings brand di semua katalog business dengan brand terbaru
+func (uc *catalogUseCase) ApplyBrand(ctx *gin.Context, businessID int64, profileID int64) (*dto.ApplyBrandResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := business.Brand.CatalogSettings()
+	if len(settings) == 0 {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgBrandEmpty, 400)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	slugs, err := uc.catalogRepo.ApplySettingsToBusiness(utils.ContextFrom(ctx), tx, businessID, settings, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	for _, slug := range slugs {
+		uc.cacheService.InvalidateCatalog(slug)
+	}
+
+	return &dto.ApplyBrandResponse{
+		BusinessID:   businessID,
+		UpdatedCount: len(slugs),
+		Settings:     settings,
+	}, nil
+}
+
+// MoveSection pindahkan section tepat setelah section after_id (0 = paling atas)
+func (uc *catalogUseCase) MoveSection(ctx *gin.Context, sectionID int64, profileID int64, req *dto.MovePositionRequest) error {
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, section)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(utils.ContextFrom(ctx), catalog.ID)
+	if err != nil {
+		return err
+	}
+
+	// Posisi section lain sesuai urutan sekarang
+	ids := make([]int64, 0, len(sections))
+	positions := make([]int, 0, len(sections))
+	for _, s := range sections {
+		if s.ID == sectionID {
+			continue
+		}
+		ids = append(ids, s.ID)
+		positions = append(positions, s.Position)
+	}
+
+	index, ok := positionIndex(ids, req.AfterID)
+	if !ok {
+		return errors.New(errors.ErrValidation, constant.ErrMsgPositionAfterInvalid, 400)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	position, ok := positionBetween(positions, index)
+	if !ok {
+		// Jarak habis, nomori ulang section lain lalu hitung lagi
+		if err := uc.catalogRepo.RebalanceSectionPositions(utils.ContextFrom(ctx), tx, catalog.ID, sectionID); err != nil {
+			return err
+		}
+		position, _ = positionBetween(rebalancedPositions(len(positions)), index)
+	}
+
+	if err := uc.catalogRepo.UpdateSectionPosition(utils.ContextFrom(ctx), tx, sectionID, position, profileID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// ReorderSections urutkan ulang semua section katalog dalam satu transaksi
+func (uc *catalogUseCase) ReorderSections(ctx *gin.Context, catalogID int64, profileID int64, req *dto.ReorderSectionsRequest) error {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(utils.ContextFrom(ctx), catalog.ID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, sections)
+	}
+
+	// Urutan baru harus memuat semua section katalog tanpa duplikat
+	remaining := make(map[int64]bool, len(sections))
+	for _, s := range sections {
+		remaining[s.ID] = true
+	}
+	if len(req.SectionIDs) != len(sections) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgSectionReorderMismatch, 400)
+	}
+	for _, id := range req.SectionIDs {
+		if !remaining[id] {
+			return errors.New(errors.ErrValidation, constant.ErrMsgSectionReorderMismatch, 400)
+		}
+		delete(remaining, id)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	for i, id := range req.SectionIDs {
+		if err := uc.catalogRepo.UpdateSectionPosition(utils.ContextFrom(ctx), tx, id, (i+1)*constant.PositionGap, profileID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// CreateFAQs tambah FAQ di akhir section
+func (uc *catalogUseCase) CreateFAQs(ctx *gin.Context, sectionID int64, profileID int64, req *dto.CreateFAQsRequest) ([]*dto.FAQResponse, error) {
+	catalog, err := uc.faqSectionCatalog(ctx, sectionID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.catalogRepo.GetFAQsBySectionID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	nextOrder := 1
+	if len(existing) > 0 {
+		nextOrder = existing[len(existing)-1].DisplayOrder + 1
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for i, faqReq := range req.FAQs {
+		faq := &entity.CatalogFAQ{
+			SectionID:    sectionID,
+			Question:     faqReq.Question,
+			Answer:       faqReq.Answer,
+			AnswerHTML:   database.NullString(utils.RenderMarkdown(faqReq.Answer)),
+			IsVisible:    faqReq.IsVisible,
+			DisplayOrder: nextOrder + i,
+			CreatedBy:    profileID,
+			CreatedAt:    now,
+		}
+		if err := uc.catalogRepo.CreateFAQ(utils.ContextFrom(ctx), tx, faq); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	uc.catalogChanged(catalog)
+	return uc.listFAQResponses(utils.ContextFrom(ctx), sectionID)
+}
+
+// ReplaceFAQs ganti seluruh FAQ section secara atomik.
+// FAQ dengan ID diupdate, tanpa ID dibuat, yang tidak dikirim dihapus; urutan mengikuti array.
+func (uc *catalogUseCase) ReplaceFAQs(ctx *gin.Context, sectionID int64, profileID int64, req *dto.ReplaceFAQsRequest) ([]*dto.FAQResponse, error) {
+	catalog, err := uc.faqSectionCatalog(ctx, sectionID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.catalogRepo.GetFAQsBySectionID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, existing)
+	}
+
+	existingByID := make(map[int64]*entity.CatalogFAQ, len(existing))
+	for _, faq := range existing {
+		existingByID[faq.ID] = faq
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	keepIDs := make([]int64, 0, len(req.FAQs))
+	seen := make(map[int64]bool, len(req.FAQs))
+	for i, faqReq := range req.FAQs {
+		if faqReq.ID == 0 {
+			faq := &entity.CatalogFAQ{
+				SectionID:    sectionID,
+				Question:     faqReq.Question,
+				Answer:       faqReq.Answer,
+				AnswerHTML:   database.NullString(utils.RenderMarkdown(faqReq.Answer)),
+				IsVisible:    faqReq.IsVisible,
+				DisplayOrder: i + 1,
+				CreatedBy:    profileID,
+				CreatedAt:    now,
+			}
+			if err := uc.catalogRepo.CreateFAQ(utils.ContextFrom(ctx), tx, faq); err != nil {
+				return nil, err
+			}
+			keepIDs = append(keepIDs, faq.ID)
+			continue
+		}
+
+		faq, ok := existingByID[faqReq.ID]
+		if !ok {
+			return nil, errors.New(errors.ErrNotFound, constant.ErrMsgFAQNotFound, 404)
+		}
+		if seen[faqReq.ID] {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgFAQDuplicate, 400)
+		}
+		seen[faqReq.ID] = true
+
+		faq.Question = faqReq.Question
+		faq.Answer = faqReq.Answer
+		faq.AnswerHTML = database.NullString(utils.RenderMarkdown(faqReq.Answer))
+		faq.IsVisible = faqReq.IsVisible
+		faq.DisplayOrder = i + 1
+		faq.UpdatedBy = sql.NullInt64{Int64: profileID, Valid: true}
+		if err := uc.catalogRepo.UpdateFAQ(utils.ContextFrom(ctx), tx, faq); err != nil {
+			return nil, err
+		}
+		keepIDs = append(keepIDs, faq.ID)
+	}
+
+	if err := uc.catalogRepo.DeleteFAQsExcept(utils.ContextFrom(ctx), tx, sectionID, keepIDs); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	uc.catalogChanged(catalog)
+	return uc.listFAQResponses(utils.ContextFrom(ctx), sectionID)
+}
+
+// DeleteFAQs hapus semua FAQ section
+func (uc *catalogUseCase) DeleteFAQs(ctx *gin.Context, sectionID int64, profileID int64) error {
+	catalog, err := uc.faqSectionCatalog(ctx, sectionID, profileID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := uc.catalogRepo.GetFAQsBySectionID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, existing)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.DeleteFAQsExcept(utils.ContextFrom(ctx), tx, sectionID, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// UpdateFAQ update satu FAQ tanpa mengubah urutan
+func (uc *catalogUseCase) UpdateFAQ(ctx *gin.Context, faqID int64, profileID int64, req *dto.UpdateFAQRequest) (*dto.FAQResponse, error) {
+	faq, err := uc.catalogRepo.GetFAQByID(utils.ContextFrom(ctx), faqID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.faqSectionCatalog(ctx, faq.SectionID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, *faq)
+	}
+
+	if req.Question != "" {
+		faq.Question = req.Question
+	}
+	if req.Answer != "" {
+		faq.Answer = req.Answer
+		faq.AnswerHTML = database.NullString(utils.RenderMarkdown(req.Answer))
+	}
+	if req.IsVisible != nil {
+		faq.IsVisible = *req.IsVisible
+	}
+	faq.UpdatedBy = sql.NullInt64{Int64: profileID, Valid: true}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.UpdateFAQ(utils.ContextFrom(ctx), tx, faq); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	uc.catalogChanged(catalog)
+
+	updated, err := uc.catalogRepo.GetFAQByID(utils.ContextFrom(ctx), faqID)
+	if err != nil {
+		return nil, err
+	}
+	return toFAQResponse(updated), nil
+}
+
+// DeleteFAQ hapus satu FAQ, urutan FAQ lain tidak berubah
+func (uc *catalogUseCase) DeleteFAQ(ctx *gin.Context, faqID int64, profileID int64) error {
+	faq, err := uc.catalogRepo.GetFAQByID(utils.ContextFrom(ctx), faqID)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := uc.faqSectionCatalog(ctx, faq.SectionID, profileID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, faq)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.DeleteFAQ(utils.ContextFrom(ctx), tx, faqID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// ReorderFAQs urutkan ulang semua FAQ section dalam satu transaksi
+func (uc *catalogUseCase) ReorderFAQs(ctx *gin.Context, sectionID int64, profileID int64, req *dto.ReorderFAQsRequest) ([]*dto.FAQResponse, error) {
+	catalog, err := uc.faqSectionCatalog(ctx, sectionID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.catalogRepo.GetFAQsBySectionID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, existing)
+	}
+
+	// Urutan baru harus memuat semua FAQ section tanpa duplikat
+	existingByID := make(map[int64]*entity.CatalogFAQ, len(existing))
+	for _, faq := range existing {
+		existingByID[faq.ID] = faq
+	}
+	if len(req.FAQIDs) != len(existing) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgFAQReorderMismatch, 400)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	for i, id := range req.FAQIDs {
+		faq, ok := existingByID[id]
+		if !ok {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgFAQReorderMismatch, 400)
+		}
+		delete(existingByID, id)
+
+		if faq.DisplayOrder == i+1 {
+			continue
+		}
+		faq.DisplayOrder = i + 1
+		faq.UpdatedBy = sql.NullInt64{Int64: profileID, Valid: true}
+		if err := uc.catalogRepo.UpdateFAQ(utils.ContextFrom(ctx), tx, faq); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	uc.catalogChanged(catalog)
+	return uc.listFAQResponses(utils.ContextFrom(ctx), sectionID)
+}
+
+// faqSectionCatalog ambil katalog dari section FAQ dan cek izin update
+func (uc *catalogUseCase) faqSectionCatalog(ctx *gin.Context, sectionID, profileID int64) (*entity.Catalog, error) {
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if section.Type != constant.SectionTypeFAQs {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgSectionNotFAQ, 400)
+	}
+
+	return catalog, nil
+}
+
+func (uc *catalogUseCase) listFAQResponses(ctx context.Context, sectionID int64) ([]*dto.FAQResponse, error) {
+	faqs, err := uc.catalogRepo.GetFAQsBySectionID(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.FAQResponse, 0, len(faqs))
+	for _, faq := range faqs {
+		resp = append(resp, toFAQResponse(faq))
+	}
+
+	return resp, nil
+}
+
+func toFAQResponse(faq *entity.CatalogFAQ) *dto.FAQResponse {
+	return &dto.FAQResponse{
+		ID:           faq.ID,
+		SectionID:    faq.SectionID,
+		Question:     faq.Question,
+		Answer:       faq.Answer,
+		AnswerHTML:   markdownHTML(faq.AnswerHTML, faq.Answer),
+		IsVisible:    faq.IsVisible,
+		DisplayOrder: faq.DisplayOrder,
+		CreatedAt:    faq.CreatedAt,
+		UpdatedAt:    faq.UpdatedAt,
+	}
+}
+
+// CreateLegalVersion simpan teks legal section sebagai versi baru. Versi lama
+// tidak diubah supaya versi yang sudah disetujui pembeli tetap bisa dilihat
+func (uc *catalogUseCase) CreateLegalVersion(ctx *gin.Context, sectionID int64, profileID int64, req *dto.CreateLegalVersionRequest) (*dto.LegalVersionResponse, error) {
+	catalog, err := uc.legalSectionCatalog(ctx, sectionID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	legal := &entity.CatalogLegalVersion{
+		SectionID:   sectionID,
+		Terms:       req.Terms,
+		TermsHTML:   database.NullString(utils.RenderMarkdown(req.Terms)),
+		Privacy:     req.Privacy,
+		PrivacyHTML: database.NullString(utils.RenderMarkdown(req.Privacy)),
+		CreatedBy:   profileID,
+		CreatedAt:   time.Now(),
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.CreateLegalVersion(utils.ContextFrom(ctx), tx, legal); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+	return toLegalVersionResponse(legal), nil
+}
+
+// ListLegalVersions riwayat versi teks legal section, terbaru lebih dulu
+func (uc *catalogUseCase) ListLegalVersions(ctx *gin.Context, sectionID int64, profileID int64) ([]*dto.LegalVersionResponse, error) {
+	if _, err := uc.legalSectionCatalog(ctx, sectionID, profileID); err != nil {
+		return nil, err
+	}
+
+	versions, err := uc.catalogRepo.GetLegalVersionsBySectionID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.LegalVersionResponse, 0, len(versions))
+	for _, legal := range versions {
+		resp = append(resp, toLegalVersionResponse(legal))
+	}
+
+	return resp, nil
+}
+
+// legalSectionCatalog ambil katalog dari section legal dan cek izin update
+func (uc *catalogUseCase) legalSectionCatalog(ctx *gin.Context, sectionID, profileID int64) (*entity.Catalog, error) {
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if section.Type != constant.SectionTypeLegal {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgSectionNotLegal, 400)
+	}
+
+	return catalog, nil
+}
+
+// currentLegal versi legal terbaru dari section legal katalog yang tampil,
+// nil jika katalog tidak punya section legal atau belum ada versinya
+func (uc *catalogUseCase) currentLegal(ctx context.Context, catalogID int64) (*entity.CatalogSection, error) {
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range sections {
+		if section.Type != constant.SectionTypeLegal || !section.IsVisible {
+			continue
+		}
+
+		legal, err := uc.catalogRepo.GetCurrentLegalVersion(ctx, section.ID)
+		if errors.Is(err, errors.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		section.Legal = legal
+		return section, nil
+	}
+
+	return nil, nil
+}
+
+// legalAckRequired check apakah section legal mewajibkan persetujuan saat checkout
+func legalAckRequired(section *entity.CatalogSection) bool {
+	required, _ := section.Config[constant.SectionConfigRequireAcknowledgment].(bool)
+	return required
+}
+
+func toLegalVersionResponse(legal *entity.CatalogLegalVersion) *dto.LegalVersionResponse {
+	return &dto.LegalVersionResponse{
+		ID:          legal.ID,
+		SectionID:   legal.SectionID,
+		Version:     legal.Version,
+		Terms:       legal.Terms,
+		TermsHTML:   markdownHTML(legal.TermsHTML, legal.Terms),
+		Privacy:     legal.Privacy,
+		PrivacyHTML: markdownHTML(legal.PrivacyHTML, legal.Privacy),
+		CreatedBy:   legal.CreatedBy,
+		CreatedAt:   legal.CreatedAt,
+	}
+}
+
+// CreateCard membuat card baru
+func (uc *catalogUseCase) CreateCard(ctx *gin.Context, sectionID int64, profileID int64, req *dto.CreateCardRequest) error {
+	// Get section
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return err
+	}
+
+	// Validate section type
+	if section.Type != constant.SectionTypeCards {
+		return errors.New(errors.ErrValidation, "Section bukan tipe cards", 400)
+	}
+
+	// Get catalog for permission check
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	// Validate card type
+	if !constant.IsValidCardType(req.Type) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCardTypeInvalid, 400)
+	}
+
+	// Cek batas card dan media sesuai plan
+	limits, err := uc.contentLimits(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return err
+	}
+	if len(req.MediaURLs) > limits.maxMedia {
+		return errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgMediaLimitReached, limits.maxMedia), 400)
+	}
+	count, err := uc.catalogRepo.CountCards(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return err
+	}
+	if count >= limits.maxCards {
+		return errors.New(errors.ErrConflict, fmt.Sprintf(constant.ErrMsgCardLimitReached, limits.maxCards), 409)
+	}
+	if err := uc.planEnforcement.CheckProductLimit(utils.ContextFrom(ctx), catalog.BusinessID, 1); err != nil {
+		return err
+	}
+
+	// Slug detail custom harus unik di katalog
+	if req.HasDetail && req.Detail != nil && req.Detail.Slug != "" {
+		if err := uc.checkCardSlug(utils.ContextFrom(ctx), catalog.ID, req.Detail.Slug, 0); err != nil {
+			return err
+		}
+	}
+
+	// Start transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	// Create card
+	card := &entity.CatalogCard{
+		SectionID: sectionID,
+		Title:     req.Title,
+		Subtitle:  database.NullString(req.Subtitle),
+		Type:      req.Type,
+		URL:       database.NullString(req.URL),
+		IsVisible: req.IsVisible,
+		HasDetail: req.HasDetail,
+		Price:     database.NullInt64(req.Price),
+		Discount:  req.Discount,
+		Currency:  req.Currency,
+		CreatedBy: profileID,
+		CreatedAt: time.Now(),
+	}
+
+	if card.Currency == "" {
+		card.Currency = constant.CurrencyIDR
+	}
+
+	if req.Affiliate != nil {
+		applyAffiliate(card, req.Affiliate)
+	}
+
+	if err := uc.catalogRepo.CreateCard(utils.ContextFrom(ctx), tx, card); err != nil {
+		return err
+	}
+
+	// Create detail if requested
+	if req.HasDetail && req.Detail != nil {
+		var detailSlug string
+		if req.Detail.Slug != "" {
+			detailSlug = req.Detail.Slug
+		} else {
+			detailSlug = uc.slugService.GenerateUnique(req.Title, 50)
+		}
+
+		detail := &entity.CatalogCardDetail{
+			CardID:      card.ID,
+			CatalogID:   catalog.ID,
+			Slug:        detailSlug,
+			Description: database.NullString(req.Detail.Description),
+			DescriptionHTML: database.NullString(utils.RenderMarkdown(req.Detail.Description)),
+			IsVisible:   req.Detail.IsVisible,
+			CreatedBy:   profileID,
+			CreatedAt:   time.Now(),
+		}
+
+		if err := uc.catalogRepo.CreateCardDetail(utils.ContextFrom(ctx), tx, detail); err != nil {
+			return err
+		}
+		if err := uc.createCardLinks(utils.ContextFrom(ctx), tx, detail.ID, req.Detail.Links, profileID); err != nil {
+			return err
+		}
+	}
+
+	// Create media if provided
+	for _, mediaURL := range req.MediaURLs {
+		media := &entity.CatalogCardMedia{
+			CardID:    card.ID,
+			Type:      constant.MediaTypeThumbnail,
+			URL:       mediaURL,
+			CreatedBy: profileID,
+			CreatedAt: time.Now(),
+		}
+
+		if err := uc.catalogRepo.CreateCardMedia(utils.ContextFrom(ctx), tx, media); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// cardImportColumns nama kolom header file import (lowercase) ke field card
+var cardImportColumns = map[string]string{
+	"title":     "title",
+	"name":      "title",
+	"subtitle":  "subtitle",
+	"price":     "price",
+	"discount":  "discount",
+	"url":       "url",
+	"link":      "url",
+	"image_url": "image_url",
+	"image":     "image_url",
+}
+
+// cardImportRow satu baris file import yang lolos validasi
+type cardImportRow struct {
+	row      int
+	title    string
+	subtitle string
+	price    sql.NullInt64
+	discount int
+	url      string
+	imageURL string
+}
+
+// ImportCards buat card produk dari file CSV/XLSX. Setiap baris divalidasi dulu,
+// baris yang valid dibuat per batch dan baris yang gagal dilaporkan per baris
+func (uc *catalogUseCase) ImportCards(ctx *gin.Context, sectionID int64, profileID int64, filename string, file io.Reader) (*dto.CardImportResponse, error) {
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section.Type != constant.SectionTypeCards {
+		return nil, errors.New(errors.ErrValidation, "Section bukan tipe cards", 400)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	records, err := readCardImportFile(filename, file)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardImportEmpty, 400)
+	}
+	if len(records)-1 > constant.CardImportMaxRows {
+		return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgCardImportTooMany, constant.CardImportMaxRows), 400)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if field, ok := cardImportColumns[name]; ok {
+			if _, exists := columns[field]; !exists {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardImportHeader, 400)
+	}
+
+	resp := &dto.CardImportResponse{Errors: make([]dto.CardImportRowError, 0)}
+	rows := make([]*cardImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		// Baris kosong (mis. sisa format di Excel) dilewati
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		resp.TotalRows++
+
+		row, rowErr := parseCardImportRow(i+2, record, columns)
+		if rowErr != nil {
+			resp.Errors = append(resp.Errors, *rowErr)
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if resp.TotalRows == 0 {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardImportEmpty, 400)
+	}
+
+	// Baris yang melewati batas card plan dilaporkan gagal
+	limits, err := uc.contentLimits(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := uc.catalogRepo.CountCards(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if remaining := limits.maxCards - count; len(rows) > remaining {
+		if remaining < 0 {
+			remaining = 0
+		}
+		for _, row := range rows[remaining:] {
+			resp.Errors = append(resp.Errors, dto.CardImportRowError{
+				Row:     row.row,
+				Message: fmt.Sprintf(constant.ErrMsgCardLimitReached, limits.maxCards),
+			})
+		}
+		rows = rows[:remaining]
+	}
+
+	// Baris yang melewati batas produk business juga dilaporkan gagal
+	quota, err := uc.planEnforcement.ProductQuota(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	if remaining := quota.Remaining(); remaining >= 0 && len(rows) > remaining {
+		for _, row := range rows[remaining:] {
+			resp.Errors = append(resp.Errors, dto.CardImportRowError{
+				Row:     row.row,
+				Message: quota.Message(),
+			})
+		}
+		rows = rows[:remaining]
+	}
+
+	for start := 0; start < len(rows); start += constant.CardImportBatchSize {
+		end := start + constant.CardImportBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if err := uc.createImportedCards(utils.ContextFrom(ctx), sectionID, profileID, rows[start:end]); err != nil {
+			return nil, err
+		}
+		resp.Created += end - start
+	}
+
+	sort.Slice(resp.Errors, func(i, j int) bool {
+		return resp.Errors[i].Row < resp.Errors[j].Row
+	})
+	resp.Failed = len(resp.Errors)
+
+	if resp.Created > 0 {
+		uc.catalogChanged(catalog)
+	}
+	return resp, nil
+}
+
+// createImportedCards buat satu batch card import dalam satu transaksi
+func (uc *catalogUseCase) createImportedCards(ctx context.Context, sectionID, profileID int64, rows []*cardImportRow) error {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, row := range rows {
+		card := &entity.CatalogCard{
+			SectionID: sectionID,
+			Title:     row.title,
+			Subtitle:  database.NullString(row.subtitle),
+			Type:      constant.CardTypeProduct,
+			URL:       database.NullString(row.url),
+			IsVisible: true,
+			Price:     row.price,
+			Discount:  row.discount,
+			Currency:  constant.CurrencyIDR,
+			CreatedBy: profileID,
+			CreatedAt: now,
+		}
+		if err := uc.catalogRepo.CreateCard(ctx, tx, card); err != nil {
+			return err
+		}
+
+		if row.imageURL != "" {
+			media := &entity.CatalogCardMedia{
+				CardID:    card.ID,
+				Type:      constant.MediaTypeThumbnail,
+				URL:       row.imageURL,
+				CreatedBy: profileID,
+				CreatedAt: now,
+			}
+			if err := uc.catalogRepo.CreateCardMedia(ctx, tx, media); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+// readCardImportFile baca file import sebagai baris teks sesuai ekstensinya
+func readCardImportFile(filename string, file io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardImportUnreadable, 400)
+		}
+		return records, nil
+
+	case ".xlsx":
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read import file")
+		}
+		records, err := utils.ReadXLSXRows(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardImportUnreadable, 400)
+		}
+		return records, nil
+	}
+
+	return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardImportFormat, 400)
+}
+
+// parseCardImportRow validasi satu baris import, error pertama yang ditemukan dilaporkan
+func parseCardImportRow(rowNum int, record []string, columns map[string]int) (*cardImportRow, *dto.CardImportRowError) {
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	fail := func(field, message string) *dto.CardImportRowError {
+		return &dto.CardImportRowError{Row: rowNum, Field: field, Message: message}
+	}
+
+	row := &cardImportRow{
+		row:      rowNum,
+		title:    value("title"),
+		subtitle: value("subtitle"),
+		url:      value("url"),
+		imageURL: value("image_url"),
+	}
+
+	if row.title == "" {
+		return nil, fail("title", constant.ErrMsgCardTitleRequired)
+	}
+	if len([]rune(row.title)) > 200 {
+		return nil, fail("title", constant.ErrMsgCardImportTitleLong)
+	}
+	if len([]rune(row.subtitle)) > 300 {
+		row.subtitle = string([]rune(row.subtitle)[:300])
+	}
+
+	if raw := value("price"); raw != "" {
+		price, ok := parseImportPrice(raw)
+		if !ok {
+			return nil, fail("price", constant.ErrMsgCardPriceInvalid)
+		}
+		row.price = sql.NullInt64{Int64: price, Valid: true}
+	}
+
+	if raw := strings.TrimSuffix(value("discount"), "%"); raw != "" {
+		discount, err := strconv.ParseFloat(raw, 64)
+		if err != nil || discount < 0 || discount > 100 || discount != float64(int(discount)) {
+			return nil, fail("discount", constant.ErrMsgCardImportDiscount)
+		}
+		row.discount = int(discount)
+	}
+
+	if row.url != "" && !isImportURL(row.url) {
+		return nil, fail("url", constant.ErrMsgCardImportURL)
+	}
+	if row.imageURL != "" && !isImportURL(row.imageURL) {
+		return nil, fail("image_url", constant.ErrMsgCardImportURL)
+	}
+
+	return row, nil
+}
+
+// parseImportPrice parse harga rupiah: "15000", "15.000", "Rp 15,000" atau angka
+// dari sel Excel ("15000.0")
+func parseImportPrice(raw string) (int64, bool) {
+	s := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(raw, "Rp"), "."))
+	s = strings.ReplaceAll(s, " ", "")
+
+	if importThousandsPattern.MatchString(s) {
+		s = strings.NewReplacer(".", "", ",", "").Replace(s)
+	}
+
+	price, err := strconv.ParseFloat(s, 64)
+	if err != nil || price < 0 || price != float64(int64(price)) {
+		return 0, false
+	}
+	return int64(price), true
+}
+
+// importThousandsPattern angka dengan pemisah ribuan titik atau koma
+var importThousandsPattern = regexp.MustCompile(`^\d{1,3}([.,]\d{3})+$`)
+
+// isImportURL URL absolut http/https
+func isImportURL(raw string) bool {
+	u, err := url.ParseRequestURI(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// GetCard mendapatkan card untuk editor
+func (uc *catalogUseCase) GetCard(ctx *gin.Context, cardID int64, profileID int64) (*dto.CardResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	tagsByCard, err := uc.catalogRepo.GetTagsByCardIDs(utils.ContextFrom(ctx), []int64{card.ID})
+	if err != nil {
+		return nil, err
+	}
+	card.Tags = tagsByCard[card.ID]
+
+	resp := uc.toAdminCardResponse(card)
+	return &resp, nil
+}
+
+// UpdateCard update card
+func (uc *catalogUseCase) UpdateCard(ctx *gin.Context, cardID int64, profileID int64, req *dto.UpdateCardRequest) error {
+	// Get card
+	card, err := uc.catalogRepo.GetCardByID(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, card)
+	}
+
+	// Get section
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), card.SectionID)
+	if err != nil {
+		return err
+	}
+
+	// Get catalog for permission check
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	// Tolak jika card sudah berubah sejak dibaca klien
+	if preconditionFailed(card.Version, card.GetLastModifiedAt(), req.Version, req.UnmodifiedSince) {
+		return errors.New(errors.ErrConflict, constant.ErrMsgCardModified, 409)
+	}
+
+	// Validate updates
+	if req.Type != "" && !constant.IsValidCardType(req.Type) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCardTypeInvalid, 400)
+	}
+
+	// Update fields
+	if req.Title != "" {
+		card.Title = req.Title
+	}
+	if req.Subtitle != "" {
+		card.Subtitle = database.NullString(req.Subtitle)
+	}
+	if req.Type != "" {
+		card.Type = req.Type
+	}
+	if req.URL != "" {
+		card.URL = database.NullString(req.URL)
+	}
+	if req.IsVisible != nil {
+		card.IsVisible = *req.IsVisible
+	}
+	if req.HasDetail != nil {
+		card.HasDetail = *req.HasDetail
+	}
+	if req.Price != nil {
+		card.Price = database.NullInt64(*req.Price)
+	}
+	if req.Discount != nil {
+		card.Discount = *req.Discount
+	}
+	if req.Currency != "" {
+		card.Currency = req.Currency
+	}
+	if req.Affiliate != nil {
+		applyAffiliate(card, req.Affiliate)
+	}
+
+	card.UpdatedBy = database.NullInt64(profileID)
+	card.UpdatedAt = &[]time.Time{time.Now()}[0]
+
+	// Update in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.UpdateCard(utils.ContextFrom(ctx), tx, card); err != nil {
+		return err
+	}
+
+	if req.Detail != nil {
+		if err := uc.saveCardDetail(utils.ContextFrom(ctx), tx, card, catalog.ID, req.Detail, profileID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// saveCardDetail update detail card, dibuat jika card belum punya detail.
+// Links hanya diganti jika dikirim (termasuk [] untuk menghapus semua).
+// Slug lama disimpan di riwayat supaya link lama diarahkan ke slug baru
+func (uc *catalogUseCase) saveCardDetail(ctx context.Context, tx *sql.Tx, card *entity.CatalogCard, catalogID int64, req *dto.CardDetailRequest, profileID int64) error {
+	detail, err := uc.catalogRepo.GetCardDetailByCardID(ctx, card.ID)
+	if err != nil {
+		return err
+	}
+
+	if req.Slug != "" && (detail == nil || req.Slug != detail.Slug) {
+		if err := uc.checkCardSlug(ctx, catalogID, req.Slug, card.ID); err != nil {
+			return err
+		}
+		// Slug lama milik card ini dipakai lagi, keluarkan dari riwayat
+		if err := uc.catalogRepo.DeleteCardSlugHistory(ctx, tx, catalogID, req.Slug); err != nil {
+			return err
+		}
+	}
+
+	if detail == nil {
+		slug := req.Slug
+		if slug == "" {
+			slug = uc.slugService.GenerateUnique(card.Title, 50)
+		}
+		detail = &entity.CatalogCardDetail{
+			CardID:          card.ID,
+			CatalogID:       catalogID,
+			Slug:            slug,
+			Description:     database.NullString(req.Description),
+			DescriptionHTML: database.NullString(utils.RenderMarkdown(req.Description)),
+			IsVisible:       req.IsVisible,
+			CreatedBy:       profileID,
+			CreatedAt:       time.Now(),
+		}
+		if err := uc.catalogRepo.CreateCardDetail(ctx, tx, detail); err != nil {
+			return err
+		}
+		return uc.createCardLinks(ctx, tx, detail.ID, req.Links, profileID)
+	}
+
+	if req.Slug != "" && req.Slug != detail.Slug {
+		if err := uc.catalogRepo.AddCardSlugHistory(ctx, tx, catalogID, card.ID, detail.Slug); err != nil {
+			return err
+		}
+		detail.Slug = req.Slug
+	}
+	detail.Description = database.NullString(req.Description)
+	detail.DescriptionHTML = database.NullString(utils.RenderMarkdown(req.Description))
+	detail.IsVisible = req.IsVisible
+	detail.UpdatedBy = database.NullInt64(profileID)
+	if err := uc.catalogRepo.UpdateCardDetail(ctx, tx, detail); err != nil {
+		return err
+	}
+
+	if req.Links == nil {
+		return nil
+	}
+	if err := uc.catalogRepo.DeleteCardLinksByDetailID(ctx, tx, detail.ID); err != nil {
+		return err
+	}
+	return uc.createCardLinks(ctx, tx, detail.ID, req.Links, profileID)
+}
+
+// checkCardSlug pastikan slug detail belum dipakai card lain di katalog
+func (uc *catalogUseCase) checkCardSlug(ctx context.Context, catalogID int64, slug string, cardID int64) error {
+	taken, err := uc.catalogRepo.IsCardSlugTaken(ctx, catalogID, slug, cardID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errors.New(errors.ErrConflict, constant.ErrMsgCardSlugExists, 409)
+	}
+	return nil
+}
+
+// createCardLinks simpan links detail card sesuai urutan request
+func (uc *catalogUseCase) createCardLinks(ctx context.Context, tx *sql.Tx, detailID int64, links []dto.LinkRequest, profileID int64) error {
+	now := time.Now()
+	for _, req := range links {
+		link := &entity.CatalogCardLink{
+			DetailID:  detailID,
+			Type:      req.Type,
+			URL:       req.URL,
+			UTM:       req.UTM.Override(),
+			IsVisible: req.IsVisible,
+			CreatedBy: profileID,
+			CreatedAt: now,
+		}
+		if err := uc.catalogRepo.CreateCardLink(ctx, tx, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListCardLinks daftar link pada detail card
+func (uc *catalogUseCase) ListCardLinks(ctx *gin.Context, cardID int64, profileID int64) ([]*dto.LinkResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	detail, err := uc.catalogRepo.GetCardDetailByCardID(utils.ContextFrom(ctx), card.ID)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		return []*dto.LinkResponse{}, nil
+	}
+
+	links, err := uc.catalogRepo.GetCardLinksByDetailID(utils.ContextFrom(ctx), detail.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.LinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = uc.toLinkResponse(link)
+	}
+	return responses, nil
+}
+
+// CreateCardLink tambah link pada detail card
+func (uc *catalogUseCase) CreateCardLink(ctx *gin.Context, cardID int64, profileID int64, req *dto.LinkRequest) (*dto.LinkResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	detail, err := uc.getCardDetail(utils.ContextFrom(ctx), card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	link := &entity.CatalogCardLink{
+		DetailID:  detail.ID,
+		Type:      req.Type,
+		URL:       req.URL,
+		UTM:       req.UTM.Override(),
+		IsVisible: req.IsVisible,
+		CreatedBy: profileID,
+		CreatedAt: time.Now(),
+	}
+	if err := uc.catalogRepo.CreateCardLink(utils.ContextFrom(ctx), tx, link); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+	return uc.toLinkResponse(link), nil
+}
+
+// UpdateCardLink update link pada detail card
+func (uc *catalogUseCase) UpdateCardLink(ctx *gin.Context, cardID, linkID int64, profileID int64, req *dto.UpdateLinkRequest) (*dto.LinkResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	link, err := uc.getCardLink(utils.ContextFrom(ctx), card.ID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	ctx.Set(middleware.GinKeyAuditOldData, *link)
+
+	if req.Type != "" {
+		link.Type = req.Type
+	}
+	if req.URL != "" {
+		link.URL = req.URL
 	}
 	if req.IsVisible != nil {
-		section.IsVisible = *req.IsVisible
+		link.IsVisible = *req.IsVisible
+	}
+	if req.UTM != nil {
+		link.UTM = req.UTM.Override()
+	}
+	link.UpdatedBy = database.NullInt64(profileID)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.UpdateCardLink(utils.ContextFrom(ctx), tx, link); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	now := time.Now()
+	link.UpdatedAt = &now
+
+	uc.catalogChanged(catalog)
+	return uc.toLinkResponse(link), nil
+}
+
+// DeleteCardLink hapus link dari detail card
+func (uc *catalogUseCase) DeleteCardLink(ctx *gin.Context, cardID, linkID int64, profileID int64) error {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	link, err := uc.getCardLink(utils.ContextFrom(ctx), card.ID, linkID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	ctx.Set(middleware.GinKeyAuditOldData, link)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.DeleteCardLink(utils.ContextFrom(ctx), tx, link.ID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// getCardDetail detail card, error jika card belum punya halaman detail
+func (uc *catalogUseCase) getCardDetail(ctx context.Context, cardID int64) (*entity.CatalogCardDetail, error) {
+	detail, err := uc.catalogRepo.GetCardDetailByCardID(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+	if detail == nil {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardDetailMissing, 400)
+	}
+	return detail, nil
+}
+
+// getCardLink link milik detail card, link card lain dianggap tidak ditemukan
+func (uc *catalogUseCase) getCardLink(ctx context.Context, cardID, linkID int64) (*entity.CatalogCardLink, error) {
+	detail, err := uc.getCardDetail(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := uc.catalogRepo.GetCardLinkByID(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.DetailID != detail.ID {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgCardLinkNotFound, 404)
+	}
+	return link, nil
+}
+
+// DeleteCard delete card
+func (uc *catalogUseCase) DeleteCard(ctx *gin.Context, cardID int64, profileID int64) error {
+	// Get card
+	card, err := uc.catalogRepo.GetCardByID(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, card)
+	}
+
+	// Get section
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), card.SectionID)
+	if err != nil {
+		return err
+	}
+
+	// Get catalog for permission check
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	// Delete in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.DeleteCard(utils.ContextFrom(ctx), tx, cardID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// MoveCard pindahkan card tepat setelah card after_id (0 = paling atas) dalam section yang sama
+func (uc *catalogUseCase) MoveCard(ctx *gin.Context, cardID int64, profileID int64, req *dto.MovePositionRequest) error {
+	card, err := uc.catalogRepo.GetCardByID(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, card)
+	}
+
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), card.SectionID)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	cards, err := uc.catalogRepo.GetCardsBySectionID(utils.ContextFrom(ctx), section.ID)
+	if err != nil {
+		return err
+	}
+
+	// Posisi card lain sesuai urutan sekarang
+	ids := make([]int64, 0, len(cards))
+	positions := make([]int, 0, len(cards))
+	for _, c := range cards {
+		if c.ID == cardID {
+			continue
+		}
+		ids = append(ids, c.ID)
+		positions = append(positions, c.Position)
+	}
+
+	index, ok := positionIndex(ids, req.AfterID)
+	if !ok {
+		return errors.New(errors.ErrValidation, constant.ErrMsgPositionAfterInvalid, 400)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	position, ok := positionBetween(positions, index)
+	if !ok {
+		// Jarak habis, nomori ulang card lain lalu hitung lagi
+		if err := uc.catalogRepo.RebalanceCardPositions(utils.ContextFrom(ctx), tx, section.ID, cardID); err != nil {
+			return err
+		}
+		position, _ = positionBetween(rebalancedPositions(len(positions)), index)
+	}
+
+	if err := uc.catalogRepo.UpdateCardPosition(utils.ContextFrom(ctx), tx, cardID, position, profileID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// TrackAffiliateClick mencatat atribusi affiliate untuk klik card.
+// Dipanggil redirect link publik (GET /r/:token); card tanpa affiliate diabaikan.
+func (uc *catalogUseCase) TrackAffiliateClick(ctx context.Context, cardID int64) error {
+	card, err := uc.catalogRepo.GetCardByID(ctx, cardID)
+	if err != nil {
+		return err
+	}
+
+	if !card.IsAffiliate() {
+		return nil
+	}
+
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	click := &entity.CatalogAffiliateClick{
+		CardID:         card.ID,
+		PartnerID:      card.AffiliatePartnerID.String,
+		CommissionRate: card.AffiliateCommissionRate.Float64,
+		Amount:         card.GetEffectivePrice(),
+		ClickedAt:      time.Now(),
+	}
+
+	if err := uc.catalogRepo.CreateAffiliateClick(ctx, tx, click); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetAffiliateEarnings laporan estimasi komisi affiliate per card per bulan
+func (uc *catalogUseCase) GetAffiliateEarnings(ctx *gin.Context, catalogID int64, profileID int64, from, to time.Time) ([]*dto.AffiliateEarningResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	if !from.Before(to) {
+		return nil, errors.New(errors.ErrValidation, "Rentang tanggal tidak valid", 400)
+	}
+
+	earnings, err := uc.catalogRepo.GetAffiliateEarnings(utils.ContextFrom(ctx), catalogID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.AffiliateEarningResponse, len(earnings))
+	for i, earning := range earnings {
+		responses[i] = &dto.AffiliateEarningResponse{
+			CardID:              earning.CardID,
+			CardTitle:           earning.CardTitle,
+			PartnerID:           earning.PartnerID,
+			Month:               earning.Month.Format("2006-01"),
+			Clicks:              earning.Clicks,
+			EstimatedCommission: earning.EstimatedCommission,
+		}
+	}
+
+	return responses, nil
+}
+
+// CreateCheckoutLink generate payment link untuk card dengan harga setelah diskon
+func (uc *catalogUseCase) CreateCheckoutLink(ctx *gin.Context, cardID int64, profileID int64, req *dto.CreateCheckoutLinkRequest) (*dto.CheckoutLinkResponse, error) {
+	card, err := uc.catalogRepo.GetCardByID(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), card.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	unitPrice := card.GetEffectivePrice()
+	if unitPrice <= 0 {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCheckoutPriceRequired, 400)
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+
+	// Versi legal yang dikirim harus versi terbaru; wajib jika section legal
+	// mengaktifkan require_acknowledgment
+	var legalVersion sql.NullInt64
+	legalSection, err := uc.currentLegal(utils.ContextFrom(ctx), catalog.ID)
+	if err != nil {
+		return nil, err
+	}
+	if legalSection != nil {
+		required := legalAckRequired(legalSection)
+		if (required || req.LegalVersion > 0) && req.LegalVersion != legalSection.Legal.Version {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgLegalAckRequired, 400)
+		}
+		if req.LegalVersion > 0 {
+			legalVersion = sql.NullInt64{Int64: int64(req.LegalVersion), Valid: true}
+		}
+	} else if req.LegalVersion > 0 {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgLegalVersionNotFound, 404)
+	}
+
+	link := &entity.CatalogCheckoutLink{
+		CardID:     card.ID,
+		ExternalID: fmt.Sprintf("ccl-%d-%s", card.ID, uuid.New().String()),
+		Quantity:   quantity,
+		Amount:     unitPrice * int64(quantity),
+		Currency:   card.Currency,
+		Status:     constant.CheckoutStatusPending,
+		LegalVersion: legalVersion,
+		CreatedBy:  profileID,
+		CreatedAt:  time.Now(),
+	}
+
+	paymentLink, err := uc.paymentService.CreatePaymentLink(middleware.RequestContext(ctx), &service.PaymentLinkRequest{
+		ExternalID:  link.ExternalID,
+		Amount:      link.Amount,
+		Currency:    link.Currency,
+		Description: fmt.Sprintf("%s x%d", card.Title, quantity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	link.GatewayID = database.NullString(paymentLink.GatewayID)
+	link.PaymentURL = paymentLink.URL
+	if !paymentLink.ExpiresAt.IsZero() {
+		link.ExpiresAt = &paymentLink.ExpiresAt
+	}
+
+	// Invoice sudah dibuat di gateway, simpan link walau client sudah putus
+	// agar webhook pembayaran tetap menemukan pasangannya
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.CreateCheckoutLink(context.Background(), tx, link); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	resp := &dto.CheckoutLinkResponse{
+		ID:         link.ID,
+		CardID:     link.CardID,
+		ExternalID: link.ExternalID,
+		Quantity:   link.Quantity,
+		Amount:     link.Amount,
+		Currency:   link.Currency,
+		PaymentURL: link.PaymentURL,
+		Status:     link.Status,
+		ExpiresAt:  link.ExpiresAt,
+		CreatedAt:  link.CreatedAt,
+	}
+	if link.LegalVersion.Valid {
+		version := int(link.LegalVersion.Int64)
+		resp.LegalVersion = &version
+	}
+
+	return resp, nil
+}
+
+// HandlePaymentCallback catat status pembayaran dari callback gateway
+func (uc *catalogUseCase) HandlePaymentCallback(ctx *gin.Context, callbackToken string, req *dto.PaymentCallbackRequest) error {
+	if !uc.paymentService.VerifyCallback(callbackToken) {
+		return errors.New(errors.ErrUnauthorized, constant.ErrMsgPaymentCallbackInvalid, 401)
+	}
+
+	link, err := uc.catalogRepo.GetCheckoutLinkByExternalID(utils.ContextFrom(ctx), req.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	var status string
+	switch strings.ToUpper(req.Status) {
+	case "PAID", "SETTLED":
+		status = constant.CheckoutStatusPaid
+	case "EXPIRED":
+		status = constant.CheckoutStatusExpired
+	case "FAILED":
+		status = constant.CheckoutStatusFailed
+	default:
+		status = constant.CheckoutStatusPending
+	}
+
+	// Link yang sudah dibayar tidak boleh turun status karena callback terlambat
+	if link.Status == constant.CheckoutStatusPaid || link.Status == status {
+		return nil
+	}
+
+	paidAt := link.PaidAt
+	if status == constant.CheckoutStatusPaid {
+		now := time.Now()
+		if req.PaidAt != nil {
+			now = *req.PaidAt
+		}
+		paidAt = &now
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.UpdateCheckoutLinkStatus(utils.ContextFrom(ctx), tx, link.ID, status, paidAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Checkout yang dibayar dianggap pesanan baru, kabari owner
+	if status == constant.CheckoutStatusPaid {
+		uc.notifyPaidCheckout(utils.ContextFrom(ctx), middleware.GetRequestID(ctx), link)
+	}
+
+	return nil
+}
+
+// SchedulePrice jadwalkan perubahan harga card pada waktu tertentu
+func (uc *catalogUseCase) SchedulePrice(ctx *gin.Context, cardID int64, profileID int64, req *dto.SchedulePriceRequest) (*dto.PriceScheduleResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if !req.EffectiveAt.After(uc.clock.Now()) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgPriceScheduleInPast, 400)
+	}
+
+	schedule := &entity.CardPriceSchedule{
+		CardID:      card.ID,
+		Price:       *req.Price,
+		EffectiveAt: req.EffectiveAt.UTC(),
+		Status:      constant.PriceScheduleStatusPending,
+		CreatedBy:   profileID,
+		CreatedAt:   time.Now(),
+	}
+	if req.Discount != nil {
+		schedule.Discount = database.NullInt64(int64(*req.Discount))
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.CreatePriceSchedule(utils.ContextFrom(ctx), tx, schedule); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toPriceScheduleResponse(schedule), nil
+}
+
+// ListPriceSchedules riwayat dan jadwal harga card
+func (uc *catalogUseCase) ListPriceSchedules(ctx *gin.Context, cardID int64, profileID int64) ([]*dto.PriceScheduleResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	schedules, err := uc.catalogRepo.ListPriceSchedules(utils.ContextFrom(ctx), card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.PriceScheduleResponse, len(schedules))
+	for i, schedule := range schedules {
+		responses[i] = toPriceScheduleResponse(schedule)
+	}
+
+	return responses, nil
+}
+
+// CancelPriceSchedule batalkan jadwal harga yang belum diterapkan
+func (uc *catalogUseCase) CancelPriceSchedule(ctx *gin.Context, cardID, scheduleID int64, profileID int64) error {
+	schedule, err := uc.catalogRepo.GetPriceScheduleByID(utils.ContextFrom(ctx), scheduleID)
+	if err != nil {
+		return err
+	}
+	if schedule.CardID != cardID {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgPriceScheduleNotFound, 404)
+	}
+
+	_, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, schedule)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	cancelled, err := uc.catalogRepo.CancelPriceSchedule(utils.ContextFrom(ctx), tx, schedule.ID, profileID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return errors.New(errors.ErrConflict, constant.ErrMsgPriceScheduleClosed, 409)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// ApplyDuePriceSchedules terapkan jadwal harga yang sudah berlaku, dipanggil scheduler
+func (uc *catalogUseCase) ApplyDuePriceSchedules(ctx context.Context, batchSize int) error {
+	now := uc.clock.Now().UTC()
+
+	schedules, err := uc.catalogRepo.ListDuePriceSchedules(ctx, now, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		if err := uc.applyPriceSchedule(ctx, schedule, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *catalogUseCase) applyPriceSchedule(ctx context.Context, schedule *entity.CardPriceSchedule, now time.Time) error {
+	card, err := uc.catalogRepo.GetCardByID(ctx, schedule.CardID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	applied, err := uc.catalogRepo.ApplyPriceSchedule(ctx, tx, schedule, now)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		return nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Audit dicatat atas nama profile yang membuat jadwal
+	discount := card.Discount
+	if schedule.Discount.Valid {
+		discount = int(schedule.Discount.Int64)
+	}
+	oldData, _ := json.Marshal(map[string]interface{}{
+		"price":    card.Price,
+		"discount": card.Discount,
+	})
+	newData, _ := json.Marshal(map[string]interface{}{
+		"price":    schedule.Price,
+		"discount": discount,
+	})
+	uc.auditService.Log(&service.AuditEntry{
+		UserProfileID: &schedule.CreatedBy,
+		BusinessID:    &schedule.BusinessID,
+		Action:        "SCHEDULED_PRICE_CHANGE",
+		Table:         "catalog_cards",
+		RecordID:      strconv.FormatInt(card.ID, 10),
+		OldData:       oldData,
+		NewData:       newData,
+		Context: map[string]interface{}{
+			"source":       "scheduler",
+			"schedule_id":  schedule.ID,
+			"effective_at": schedule.EffectiveAt,
+		},
+	})
+
+	catalog, err := uc.catalogRepo.GetByID(ctx, schedule.CatalogID)
+	if err != nil {
+		return err
+	}
+	uc.catalogChanged(catalog)
+
+	return nil
+}
+
+// AlertEndingDiscounts kirim notifikasi sekali untuk diskon card yang akan turun atau
+// berakhir dalam rentang within karena jadwal harga, dipanggil scheduler
+func (uc *catalogUseCase) AlertEndingDiscounts(ctx context.Context, within time.Duration, batchSize int) error {
+	now := uc.clock.Now().UTC()
+
+	endings, err := uc.catalogRepo.ListEndingDiscounts(ctx, now, now.Add(within), batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, ending := range endings {
+		// Ditandai dulu agar notifikasi tidak terkirim ganda
+		marked, err := uc.catalogRepo.MarkDiscountAlerted(ctx, ending.ScheduleID, now)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			continue
+		}
+
+		uc.notificationService.Notify(&service.Notification{
+			BusinessID: ending.BusinessID,
+			Event:      constant.NotificationEventDiscountEnding,
+			Discount: &service.DiscountNotification{
+				CatalogTitle: ending.CatalogTitle,
+				CardTitle:    ending.CardTitle,
+				Discount:     ending.Discount,
+				NewDiscount:  ending.NewDiscount,
+				EndsAt:       ending.EndsAt,
+			},
+		})
+	}
+
+	return nil
+}
+
+// getCardCatalog card beserta katalog pemiliknya
+func (uc *catalogUseCase) getCardCatalog(ctx context.Context, cardID int64) (*entity.CatalogCard, *entity.Catalog, error) {
+	card, err := uc.catalogRepo.GetCardByID(ctx, cardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	section, err := uc.catalogRepo.GetSectionByID(ctx, card.SectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(ctx, section.CatalogID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return card, catalog, nil
+}
+
+func (uc *catalogUseCase) toLinkResponse(link *entity.CatalogCardLink) *dto.LinkResponse {
+	return &dto.LinkResponse{
+		ID:          link.ID,
+		Type:        link.Type,
+		URL:         link.URL,
+		RedirectURL: uc.linkRedirectURL(link.Token),
+		UTM:         dto.NewLinkUTM(link.UTM),
+		IsVisible:   link.IsVisible,
+		CreatedAt:   link.CreatedAt,
+		UpdatedAt:   link.UpdatedAt,
+	}
+}
+
+func toPriceScheduleResponse(schedule *entity.CardPriceSchedule) *dto.PriceScheduleResponse {
+	resp := &dto.PriceScheduleResponse{
+		ID:          schedule.ID,
+		CardID:      schedule.CardID,
+		Price:       schedule.Price,
+		EffectiveAt: schedule.EffectiveAt,
+		Status:      schedule.Status,
+		CreatedBy:   schedule.CreatedBy,
+		CreatedAt:   schedule.CreatedAt,
+		AppliedAt:   schedule.AppliedAt,
+		CancelledAt: schedule.CancelledAt,
+	}
+	if schedule.Discount.Valid {
+		discount := int(schedule.Discount.Int64)
+		resp.Discount = &discount
+	}
+	return resp
+}
+
+// notifyPaidCheckout kirim notifikasi pesanan baru ke owner business
+func (uc *catalogUseCase) notifyPaidCheckout(ctx context.Context, requestID string, link *entity.CatalogCheckoutLink) {
+	card, err := uc.catalogRepo.GetCardByID(ctx, link.CardID)
+	if err != nil {
+		return
+	}
+	section, err := uc.catalogRepo.GetSectionByID(ctx, card.SectionID)
+	if err != nil {
+		return
+	}
+	catalog, err := uc.catalogRepo.GetByID(ctx, section.CatalogID)
+	if err != nil {
+		return
+	}
+
+	uc.notificationService.Notify(&service.Notification{
+		BusinessID: catalog.BusinessID,
+		Event:      constant.NotificationEventNewOrder,
+		RequestID:  requestID,
+		Order: &service.OrderNotification{
+			OrderRef:  link.ExternalID,
+			ItemTitle: card.Title,
+			Quantity:  link.Quantity,
+			Amount:    link.Amount,
+			Currency:  link.Currency,
+		},
+	})
+}
+
+// Helper methods
+
+// applyAffiliate set affiliate metadata card; partner_id kosong menghapus affiliate
+func applyAffiliate(card *entity.CatalogCard, req *dto.AffiliateRequest) {
+	if req.PartnerID == "" {
+		card.AffiliatePartnerID = sql.NullString{}
+		card.AffiliateCommissionRate = sql.NullFloat64{}
+		return
+	}
+
+	card.AffiliatePartnerID = database.NullString(req.PartnerID)
+	card.AffiliateCommissionRate = sql.NullFloat64{Float64: req.CommissionRate, Valid: true}
+}
+
+// Heartbeat tandai editor sedang membuka katalog, kembalikan editor aktif
+func (uc *catalogUseCase) Heartbeat(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.PresenceResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if !uc.presenceService.Enabled() {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgPresenceUnavailable, 503)
+	}
+
+	if err := uc.presenceService.Heartbeat(catalogID, profileID); err != nil {
+		return nil, errors.Wrap(err, "failed to record presence")
+	}
+
+	return uc.activeEditors(utils.ContextFrom(ctx), catalog.ID, catalog.BusinessID, profileID)
+}
+
+// ListPresence daftar editor yang sedang membuka katalog
+func (uc *catalogUseCase) ListPresence(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.PresenceResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	if !uc.presenceService.Enabled() {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgPresenceUnavailable, 503)
+	}
+
+	return uc.activeEditors(utils.ContextFrom(ctx), catalog.ID, catalog.BusinessID, profileID)
+}
+
+// activeEditors gabungkan presence dengan display name anggota business
+func (uc *catalogUseCase) activeEditors(ctx context.Context, catalogID, businessID, profileID int64) ([]*dto.PresenceResponse, error) {
+	entries, err := uc.presenceService.ListActive(catalogID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list presence")
+	}
+
+	users, err := uc.businessRepo.GetUsersByBusinessID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(users))
+	for _, user := range users {
+		names[user.ProfileID] = user.Profile.GetDisplayName()
+	}
+
+	editors := make([]*dto.PresenceResponse, 0, len(entries))
+	for _, entry := range entries {
+		// Abaikan profile yang sudah bukan anggota aktif
+		name, ok := names[entry.ProfileID]
+		if !ok {
+			continue
+		}
+		editors = append(editors, &dto.PresenceResponse{
+			ProfileID:   entry.ProfileID,
+			DisplayName: name,
+			IsSelf:      entry.ProfileID == profileID,
+			LastSeenAt:  entry.LastSeenAt,
+		})
+	}
+
+	return editors, nil
+}
+
+// SubmitPublishRequest ajukan katalog draft untuk direview sebelum publish
+func (uc *catalogUseCase) SubmitPublishRequest(ctx *gin.Context, catalogID int64, profileID int64, req *dto.SubmitPublishRequest) (*dto.PublishRequestResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if err := uc.rehydrator.Rehydrate(utils.ContextFrom(ctx), catalog); err != nil {
+		return nil, err
+	}
+
+	switch catalog.Status {
+	case constant.CatalogStatusInReview:
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgPublishRequestPending, 409)
+	case constant.CatalogStatusPublished:
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgCatalogAlreadyPublished, 409)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	request := &entity.CatalogPublishRequest{
+		CatalogID:   catalog.ID,
+		Status:      constant.PublishRequestStatusPending,
+		Note:        database.NullString(req.Note),
+		RequestedBy: profileID,
+		RequestedAt: time.Now(),
+	}
+
+	if err := uc.catalogRepo.CreatePublishRequest(utils.ContextFrom(ctx), tx, request); err != nil {
+		return nil, err
+	}
+
+	if err := uc.catalogRepo.UpdateStatus(utils.ContextFrom(ctx), tx, catalog.ID, constant.CatalogStatusInReview, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	request, err = uc.catalogRepo.GetPublishRequestByID(utils.ContextFrom(ctx), request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPublishRequestResponse(request), nil
+}
+
+// ListPublishRequests riwayat pengajuan publish katalog
+func (uc *catalogUseCase) ListPublishRequests(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.PublishRequestResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	requests, err := uc.catalogRepo.ListPublishRequests(utils.ContextFrom(ctx), catalog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.PublishRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, toPublishRequestResponse(request))
+	}
+
+	return responses, nil
+}
+
+// ApprovePublishRequest reviewer menyetujui pengajuan, katalog langsung published
+func (uc *catalogUseCase) ApprovePublishRequest(ctx *gin.Context, requestID int64, profileID int64, req *dto.ReviewPublishRequest) (*dto.PublishRequestResponse, error) {
+	return uc.reviewPublishRequest(ctx, requestID, profileID, constant.PublishRequestStatusApproved, constant.CatalogStatusPublished, req.Comment)
+}
+
+// RequestPublishChanges reviewer minta perubahan, katalog kembali ke draft
+func (uc *catalogUseCase) RequestPublishChanges(ctx *gin.Context, requestID int64, profileID int64, req *dto.ReviewPublishRequest) (*dto.PublishRequestResponse, error) {
+	if strings.TrimSpace(req.Comment) == "" {
+		return nil, errors.New(errors.ErrValidation, "Komentar wajib diisi saat meminta perubahan", 400)
+	}
+	return uc.reviewPublishRequest(ctx, requestID, profileID, constant.PublishRequestStatusChangesRequested, constant.CatalogStatusDraft, req.Comment)
+}
+
+func (uc *catalogUseCase) reviewPublishRequest(ctx *gin.Context, requestID, profileID int64, status, catalogStatus, comment string) (*dto.PublishRequestResponse, error) {
+	request, err := uc.catalogRepo.GetPublishRequestByID(utils.ContextFrom(ctx), requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), request.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogReview); err != nil {
+		return nil, err
+	}
+
+	if request.Status != constant.PublishRequestStatusPending {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgPublishRequestReviewed, 409)
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, request)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.ReviewPublishRequest(utils.ContextFrom(ctx), tx, request.ID, status, strings.TrimSpace(comment), profileID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.catalogRepo.UpdateStatus(utils.ContextFrom(ctx), tx, catalog.ID, catalogStatus, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Halaman publik yang sebelumnya 404 bisa saja sudah ter-cache di edge
+	if catalogStatus == constant.CatalogStatusPublished {
+		uc.cacheService.InvalidateCatalog(catalog.Slug)
+	}
+
+	request, err = uc.catalogRepo.GetPublishRequestByID(utils.ContextFrom(ctx), request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPublishRequestResponse(request), nil
+}
+
+// SchedulePublish jadwalkan katalog draft untuk publish otomatis
+func (uc *catalogUseCase) SchedulePublish(ctx *gin.Context, catalogID int64, profileID int64, req *dto.SchedulePublishRequest) (*dto.CatalogResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Menjadwalkan publish setara dengan menyetujui publish
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogReview); err != nil {
+		return nil, err
+	}
+
+	if err := uc.rehydrator.Rehydrate(utils.ContextFrom(ctx), catalog); err != nil {
+		return nil, err
+	}
+
+	switch catalog.Status {
+	case constant.CatalogStatusInReview:
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgPublishRequestPending, 409)
+	case constant.CatalogStatusPublished:
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgCatalogAlreadyPublished, 409)
+	}
+
+	if !req.PublishAt.After(uc.clock.Now()) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgPublishScheduleInPast, 400)
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, catalog)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	publishAt := req.PublishAt.UTC()
+	if err := uc.catalogRepo.SetPublishSchedule(utils.ContextFrom(ctx), tx, catalog.ID, &publishAt, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return uc.GetByID(ctx, catalog.ID, profileID)
+}
+
+// CancelPublishSchedule batalkan jadwal publish katalog
+func (uc *catalogUseCase) CancelPublishSchedule(ctx *gin.Context, catalogID int64, profileID int64) (*dto.CatalogResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogReview); err != nil {
+		return nil, err
+	}
+
+	if catalog.PublishScheduledAt == nil {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgPublishScheduleNotFound, 404)
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, catalog)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.SetPublishSchedule(utils.ContextFrom(ctx), tx, catalog.ID, nil, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return uc.GetByID(ctx, catalog.ID, profileID)
+}
+
+// ScheduleCatalogVisibility jadwalkan katalog tampil (publish_at) dan/atau
+// disembunyikan (unpublish_at) otomatis, mis. untuk katalog promo
+func (uc *catalogUseCase) ScheduleCatalogVisibility(ctx *gin.Context, catalogID int64, profileID int64, req *dto.VisibilityScheduleRequest) (*dto.CatalogResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	publishAt, unpublishAt, err := validateVisibilitySchedule(req, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, catalog)
+
+	if err := uc.setCatalogVisibilitySchedule(utils.ContextFrom(ctx), catalog, publishAt, unpublishAt, profileID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetByID(ctx, catalog.ID, profileID)
+}
+
+// CancelCatalogVisibilitySchedule hapus jadwal tampil/sembunyi katalog,
+// c_is_active dibiarkan seperti sekarang
+func (uc *catalogUseCase) CancelCatalogVisibilitySchedule(ctx *gin.Context, catalogID int64, profileID int64) (*dto.CatalogResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if catalog.PublishAt == nil && catalog.UnpublishAt == nil {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgVisibilityScheduleNotFound, 404)
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, catalog)
+
+	if err := uc.setCatalogVisibilitySchedule(utils.ContextFrom(ctx), catalog, nil, nil, profileID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetByID(ctx, catalog.ID, profileID)
+}
+
+func (uc *catalogUseCase) setCatalogVisibilitySchedule(ctx context.Context, catalog *entity.Catalog, publishAt, unpublishAt *time.Time, profileID int64) error {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.SetCatalogVisibilitySchedule(ctx, tx, catalog.ID, publishAt, unpublishAt, profileID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Jadwal tampil langsung menyembunyikan katalog
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// ScheduleCardVisibility jadwalkan card tampil (publish_at) dan/atau
+// disembunyikan (unpublish_at) otomatis, mis. untuk penawaran terbatas
+func (uc *catalogUseCase) ScheduleCardVisibility(ctx *gin.Context, cardID int64, profileID int64, req *dto.VisibilityScheduleRequest) (*dto.CardResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	publishAt, unpublishAt, err := validateVisibilitySchedule(req, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, card)
+
+	if err := uc.setCardVisibilitySchedule(utils.ContextFrom(ctx), card, catalog, publishAt, unpublishAt, profileID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetCard(ctx, card.ID, profileID)
+}
+
+// CancelCardVisibilitySchedule hapus jadwal tampil/sembunyi card,
+// cc_is_visible dibiarkan seperti sekarang
+func (uc *catalogUseCase) CancelCardVisibilitySchedule(ctx *gin.Context, cardID int64, profileID int64) (*dto.CardResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if card.PublishAt == nil && card.UnpublishAt == nil {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgVisibilityScheduleNotFound, 404)
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, card)
+
+	if err := uc.setCardVisibilitySchedule(utils.ContextFrom(ctx), card, catalog, nil, nil, profileID); err != nil {
+		return nil, err
+	}
+
+	return uc.GetCard(ctx, card.ID, profileID)
+}
+
+// UpdateCardStock atur stok card: jumlah stok (null = tidak dilacak), tanda habis
+// manual, dan apakah card disembunyikan dari publik saat habis
+func (uc *catalogUseCase) UpdateCardStock(ctx *gin.Context, cardID int64, profileID int64, req *dto.CardStockRequest) (*dto.CardResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	var stock sql.NullInt64
+	if req.Stock != nil {
+		if *req.Stock < 0 {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardStockNegative, 400)
+		}
+		stock = sql.NullInt64{Int64: *req.Stock, Valid: true}
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, card)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.UpdateCardStock(utils.ContextFrom(ctx), tx, card.ID, stock, req.SoldOut, req.HideWhenSoldOut, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+	return uc.GetCard(ctx, card.ID, profileID)
+}
+
+// AdjustCardStock tambah (restock) atau kurangi (terjual) stok card yang dilacak.
+// Pengurangan melebihi sisa stok ditolak
+func (uc *catalogUseCase) AdjustCardStock(ctx *gin.Context, cardID int64, profileID int64, req *dto.AdjustCardStockRequest) (*dto.CardResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if !card.Stock.Valid {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCardStockNotTracked, 400)
+	}
+
+	// Set old data for audit
+	ctx.Set(middleware.GinKeyAuditOldData, card)
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if _, err := uc.catalogRepo.AdjustCardStock(utils.ContextFrom(ctx), tx, card.ID, req.Delta, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Stok habis/tersedia lagi mengubah tampilan publik
+	uc.catalogChanged(catalog)
+	return uc.GetCard(ctx, card.ID, profileID)
+}
+
+// ListTags daftar tag katalog beserta jumlah card yang memakainya
+func (uc *catalogUseCase) ListTags(ctx *gin.Context, catalogID int64, profileID int64) ([]*dto.TagResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	tags, err := uc.catalogRepo.GetTagsByCatalogID(utils.ContextFrom(ctx), catalog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = toTagResponse(tag)
+	}
+	return responses, nil
+}
+
+// CreateTag buat tag katalog, slug default dari nama
+func (uc *catalogUseCase) CreateTag(ctx *gin.Context, catalogID int64, profileID int64, req *dto.CreateTagRequest) (*dto.TagResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	count, err := uc.catalogRepo.CountTags(utils.ContextFrom(ctx), catalog.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= constant.MaxTagsPerCatalog {
+		return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgTagLimitReached, constant.MaxTagsPerCatalog), 400)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	slug, err := uc.tagSlug(utils.ContextFrom(ctx), catalog.ID, name, req.Slug, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &entity.CatalogTag{
+		CatalogID: catalog.ID,
+		Name:      name,
+		Slug:      slug,
+		CreatedBy: profileID,
+		CreatedAt: time.Now(),
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.CreateTag(utils.ContextFrom(ctx), tx, tag); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toTagResponse(tag), nil
+}
+
+// UpdateTag ubah nama dan/atau slug tag. Slug tidak ikut berubah saat nama diubah
+// agar link filter yang sudah dibagikan tetap berlaku
+func (uc *catalogUseCase) UpdateTag(ctx *gin.Context, tagID int64, profileID int64, req *dto.UpdateTagRequest) (*dto.TagResponse, error) {
+	tag, catalog, err := uc.getTagCatalog(utils.ContextFrom(ctx), tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, *tag)
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		tag.Name = name
+	}
+	if req.Slug != "" && req.Slug != tag.Slug {
+		slug, err := uc.tagSlug(utils.ContextFrom(ctx), catalog.ID, tag.Name, req.Slug, tag.ID)
+		if err != nil {
+			return nil, err
+		}
+		tag.Slug = slug
+	}
+	tag.UpdatedBy = sql.NullInt64{Int64: profileID, Valid: true}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.UpdateTag(utils.ContextFrom(ctx), tx, tag); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+
+	updated, err := uc.catalogRepo.GetTagByID(utils.ContextFrom(ctx), tag.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toTagResponse(updated), nil
+}
+
+// DeleteTag hapus tag, card yang memakainya kehilangan tag tersebut
+func (uc *catalogUseCase) DeleteTag(ctx *gin.Context, tagID int64, profileID int64) error {
+	tag, catalog, err := uc.getTagCatalog(utils.ContextFrom(ctx), tagID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, tag)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.DeleteTag(utils.ContextFrom(ctx), tx, tag.ID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+	return nil
+}
+
+// SetCardTags ganti seluruh tag card, tag harus milik katalog yang sama dengan card
+func (uc *catalogUseCase) SetCardTags(ctx *gin.Context, cardID int64, profileID int64, req *dto.SetCardTagsRequest) (*dto.CardResponse, error) {
+	card, catalog, err := uc.getCardCatalog(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]int64, 0, len(req.TagIDs))
+	seen := make(map[int64]bool)
+	for _, id := range req.TagIDs {
+		if !seen[id] {
+			seen[id] = true
+			tagIDs = append(tagIDs, id)
+		}
+	}
+	if len(tagIDs) > constant.MaxTagsPerCard {
+		return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgCardTagLimitReached, constant.MaxTagsPerCard), 400)
+	}
+
+	tags, err := uc.catalogRepo.GetTagsByIDs(utils.ContextFrom(ctx), catalog.ID, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]bool, len(tags))
+	for _, tag := range tags {
+		found[tag.ID] = true
+	}
+	for _, id := range tagIDs {
+		if !found[id] {
+			return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgCardTagInvalid, id), 400)
+		}
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		tagsByCard, err := uc.catalogRepo.GetTagsByCardIDs(utils.ContextFrom(ctx), []int64{card.ID})
+		if err != nil {
+			return nil, err
+		}
+		card.Tags = tagsByCard[card.ID]
+		ctx.Set(middleware.GinKeyAuditOldData, card)
+	}
+
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.catalogRepo.SetCardTags(utils.ContextFrom(ctx), tx, card.ID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.catalogChanged(catalog)
+	return uc.GetCard(ctx, card.ID, profileID)
+}
+
+// ListCards daftar semua card katalog untuk dashboard sesuai urutan section,
+// tag tidak kosong hanya mengembalikan card dengan tag tersebut
+func (uc *catalogUseCase) ListCards(ctx *gin.Context, catalogID int64, profileID int64, tag string) ([]*dto.CardResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	var filterTag *entity.CatalogTag
+	if tag != "" {
+		filterTag, err = uc.catalogRepo.GetTagBySlug(utils.ContextFrom(ctx), catalog.ID, tag)
+		if err != nil {
+			return nil, err
+		}
+		if filterTag == nil {
+			return nil, errors.New(errors.ErrNotFound, constant.ErrMsgTagNotFound, 404)
+		}
+	}
+
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(utils.ContextFrom(ctx), catalog.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range sections {
+		if section.Type != constant.SectionTypeCards {
+			continue
+		}
+		cards, err := uc.catalogRepo.GetCardsBySectionID(utils.ContextFrom(ctx), section.ID)
+		if err != nil {
+			return nil, err
+		}
+		section.Cards = cards
+	}
+
+	if err := uc.loadCardTags(utils.ContextFrom(ctx), sections); err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.CardResponse, 0)
+	for _, section := range sections {
+		for _, card := range section.Cards {
+			if filterTag != nil && !card.HasTag(filterTag.ID) {
+				continue
+			}
+			resp := uc.toAdminCardResponse(card)
+			responses = append(responses, &resp)
+		}
+	}
+
+	return responses, nil
+}
+
+// loadCardTags isi tag untuk semua card di section dengan satu query
+func (uc *catalogUseCase) loadCardTags(ctx context.Context, sections []*entity.CatalogSection) error {
+	cardIDs := make([]int64, 0)
+	for _, section := range sections {
+		for _, card := range section.Cards {
+			cardIDs = append(cardIDs, card.ID)
+		}
+	}
+	if len(cardIDs) == 0 {
+		return nil
+	}
+
+	tagsByCard, err := uc.catalogRepo.GetTagsByCardIDs(ctx, cardIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, section := range sections {
+		for _, card := range section.Cards {
+			card.Tags = tagsByCard[card.ID]
+		}
+	}
+
+	return nil
+}
+
+// getTagCatalog get tag beserta katalognya untuk pengecekan akses
+func (uc *catalogUseCase) getTagCatalog(ctx context.Context, tagID int64) (*entity.CatalogTag, *entity.Catalog, error) {
+	tag, err := uc.catalogRepo.GetTagByID(ctx, tagID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(ctx, tag.CatalogID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tag, catalog, nil
+}
+
+// tagSlug slug tag dari request atau dari nama, harus valid dan belum dipakai
+// tag lain di katalog
+func (uc *catalogUseCase) tagSlug(ctx context.Context, catalogID int64, name, slug string, excludeTagID int64) (string, error) {
+	if slug == "" {
+		slug = uc.slugService.Normalize(name)
+		if len(slug) > constant.MaxTagSlugLength {
+			slug = strings.Trim(slug[:constant.MaxTagSlugLength], "-")
+		}
+	}
+	if !uc.slugService.IsValid(slug) {
+		return "", errors.New(errors.ErrValidation, constant.ErrMsgTagSlugInvalid, 400)
+	}
+
+	taken, err := uc.catalogRepo.IsTagSlugTaken(ctx, catalogID, slug, excludeTagID)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return "", errors.New(errors.ErrConflict, constant.ErrMsgTagSlugExists, 409)
+	}
+
+	return slug, nil
+}
+
+func toTagResponse(tag *entity.CatalogTag) *dto.TagResponse {
+	return &dto.TagResponse{
+		ID:        tag.ID,
+		CatalogID: tag.CatalogID,
+		Name:      tag.Name,
+		Slug:      tag.Slug,
+		CardCount: tag.CardCount,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
 	}
-	if req.Config != nil {
-		section.Config = req.Config
+}
+
+func toCardTagResponses(tags []*entity.CatalogTag) []dto.CardTagResponse {
+	if len(tags) == 0 {
+		return nil
+	}
+	responses := make([]dto.CardTagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = dto.CardTagResponse{ID: tag.ID, Name: tag.Name, Slug: tag.Slug}
 	}
+	return responses
+}
 
-	// Update in transaction
-	tx, err := uc.db.Begin()
+func (uc *catalogUseCase) setCardVisibilitySchedule(ctx context.Context, card *entity.CatalogCard, catalog *entity.Catalog, publishAt, unpublishAt *time.Time, profileID int64) error {
+	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.catalogRepo.UpdateSection(tx, section); err != nil {
+	if err := uc.catalogRepo.SetCardVisibilitySchedule(ctx, tx, card.ID, publishAt, unpublishAt, profileID); err != nil {
 		return err
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Jadwal tampil langsung menyembunyikan card
+	uc.catalogChanged(catalog)
+	return nil
 }
 
-// DeleteSection delete section
-func (uc *catalogUseCase) DeleteSection(ctx *gin.Context, sectionID int64, profileID int64) error {
-	// Get section
-	section, err := uc.catalogRepo.GetSectionByID(sectionID)
+// validateVisibilitySchedule validasi jadwal tampil/sembunyi terhadap now dan ubah ke UTC
+func validateVisibilitySchedule(req *dto.VisibilityScheduleRequest, now time.Time) (*time.Time, *time.Time, error) {
+	if req.PublishAt == nil && req.UnpublishAt == nil {
+		return nil, nil, errors.New(errors.ErrValidation, constant.ErrMsgVisibilityScheduleEmpty, 400)
+	}
+
+	var publishAt, unpublishAt *time.Time
+	if req.PublishAt != nil {
+		if !req.PublishAt.After(now) {
+			return nil, nil, errors.New(errors.ErrValidation, constant.ErrMsgVisibilityScheduleInPast, 400)
+		}
+		t := req.PublishAt.UTC()
+		publishAt = &t
+	}
+	if req.UnpublishAt != nil {
+		if !req.UnpublishAt.After(now) {
+			return nil, nil, errors.New(errors.ErrValidation, constant.ErrMsgVisibilityScheduleInPast, 400)
+		}
+		t := req.UnpublishAt.UTC()
+		unpublishAt = &t
+	}
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		return nil, nil, errors.New(errors.ErrValidation, constant.ErrMsgVisibilityScheduleOrder, 400)
+	}
+
+	return publishAt, unpublishAt, nil
+}
+
+// ApplyDueVisibilitySchedules tampilkan/sembunyikan katalog dan card yang
+// jadwalnya sudah lewat, dipanggil scheduler
+func (uc *catalogUseCase) ApplyDueVisibilitySchedules(ctx context.Context, batchSize int) error {
+	now := uc.clock.Now().UTC()
+	changed := make(map[int64]bool)
+
+	catalogs, err := uc.catalogRepo.ListDueCatalogVisibility(ctx, now, batchSize)
+	if err != nil {
+		return err
+	}
+	for _, item := range catalogs {
+		applied, err := uc.applyVisibility(ctx, item, "catalogs", "is_active", uc.catalogRepo.ApplyCatalogVisibility, now)
+		if err != nil {
+			return err
+		}
+		if applied {
+			changed[item.CatalogID] = true
+		}
+	}
+
+	cards, err := uc.catalogRepo.ListDueCardVisibility(ctx, now, batchSize)
 	if err != nil {
 		return err
 	}
+	for _, item := range cards {
+		applied, err := uc.applyVisibility(ctx, item, "catalog_cards", "is_visible", uc.catalogRepo.ApplyCardVisibility, now)
+		if err != nil {
+			return err
+		}
+		if applied {
+			changed[item.CatalogID] = true
+		}
+	}
 
-	// Inject old_data ke audit context
-	if ctx != nil {
-		ctx.Set(middleware.GinKeyAuditOldData, section)
+	// Cache dan search index katalog yang berubah cukup diperbarui sekali
+	for catalogID := range changed {
+		catalog, err := uc.catalogRepo.GetByID(ctx, catalogID)
+		if err != nil {
+			return err
+		}
+		uc.catalogChanged(catalog)
 	}
 
-	// Get catalog for permission check
-	catalog, err := uc.catalogRepo.GetByID(section.CatalogID)
+	return nil
+}
+
+func (uc *catalogUseCase) applyVisibility(
+	ctx context.Context,
+	item *entity.VisibilityDue,
+	table, field string,
+	apply func(ctx context.Context, tx *sql.Tx, id int64, now time.Time) (bool, bool, error),
+	now time.Time,
+) (bool, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	visible, applied, err := apply(ctx, tx, item.ID, now)
+	if err != nil {
+		return false, err
+	}
+	if !applied {
+		return false, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	// Audit dicatat atas nama profile yang membuat jadwal
+	var profileID *int64
+	if item.ScheduledBy.Valid {
+		profileID = &item.ScheduledBy.Int64
+	}
+	newData, _ := json.Marshal(map[string]interface{}{
+		field: visible,
+	})
+	uc.auditService.Log(&service.AuditEntry{
+		UserProfileID: profileID,
+		BusinessID:    &item.BusinessID,
+		Action:        "SCHEDULED_VISIBILITY",
+		Table:         table,
+		RecordID:      strconv.FormatInt(item.ID, 10),
+		NewData:       newData,
+		Context: map[string]interface{}{
+			"source":       "scheduler",
+			"publish_at":   item.PublishAt,
+			"unpublish_at": item.UnpublishAt,
+		},
+	})
+
+	return true, nil
+}
+
+// RemindAbandonedDrafts kirim pengingat sekali untuk katalog draft yang belum dipublish
+// setelah afterDays hari, dipanggil scheduler
+func (uc *catalogUseCase) RemindAbandonedDrafts(ctx context.Context, afterDays, batchSize int, resumeURL string) error {
+	now := uc.clock.Now()
+
+	catalogs, err := uc.catalogRepo.ListAbandonedDrafts(ctx, now.AddDate(0, 0, -afterDays), batchSize)
 	if err != nil {
 		return err
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+	for _, catalog := range catalogs {
+		// Ditandai dulu agar pengingat tidak terkirim ganda
+		marked, err := uc.catalogRepo.MarkDraftReminded(ctx, catalog.ID, now)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			continue
+		}
+
+		uc.notificationService.Notify(&service.Notification{
+			BusinessID: catalog.BusinessID,
+			Event:      constant.NotificationEventDraftReminder,
+			Draft: &service.DraftNotification{
+				CatalogTitle: catalog.Title,
+				CreatedAt:    catalog.CreatedAt,
+				ResumeURL:    strings.ReplaceAll(resumeURL, "{id}", strconv.FormatInt(catalog.ID, 10)),
+			},
+		})
+	}
+
+	return nil
+}
+
+// PublishDue publish katalog yang jadwalnya sudah lewat, dipanggil scheduler
+func (uc *catalogUseCase) PublishDue(ctx context.Context, batchSize int) error {
+	now := uc.clock.Now().UTC()
+
+	catalogs, err := uc.catalogRepo.ListDueScheduledPublish(ctx, now, batchSize)
+	if err != nil {
 		return err
 	}
 
-	// Delete in transaction
-	tx, err := uc.db.Begin()
+	for _, catalog := range catalogs {
+		if err := uc.publishScheduled(ctx, catalog, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *catalogUseCase) publishScheduled(ctx context.Context, catalog *entity.Catalog, now time.Time) error {
+	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.catalogRepo.DeleteSection(tx, sectionID); err != nil {
+	published, err := uc.catalogRepo.PublishScheduled(ctx, tx, catalog.ID, now)
+	if err != nil {
 		return err
 	}
+	if !published {
+		return nil
+	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.cacheService.InvalidateCatalog(catalog.Slug)
+
+	// Audit dicatat atas nama profile yang membuat jadwal
+	var profileID *int64
+	if catalog.PublishScheduledBy.Valid {
+		profileID = &catalog.PublishScheduledBy.Int64
+	}
+	businessID := catalog.BusinessID
+	newData, _ := json.Marshal(map[string]interface{}{
+		"status":       constant.CatalogStatusPublished,
+		"published_at": now,
+	})
+	uc.auditService.Log(&service.AuditEntry{
+		UserProfileID: profileID,
+		BusinessID:    &businessID,
+		Action:        "SCHEDULED_PUBLISH",
+		Table:         "catalogs",
+		RecordID:      strconv.FormatInt(catalog.ID, 10),
+		NewData:       newData,
+		Context: map[string]interface{}{
+			"source":       "scheduler",
+			"scheduled_at": catalog.PublishScheduledAt,
+		},
+	})
+
+	uc.notificationService.Notify(&service.Notification{
+		BusinessID: catalog.BusinessID,
+		Event:      constant.NotificationEventCatalogPublished,
+		Catalog: &service.CatalogNotification{
+			Title:       catalog.Title,
+			PublicURL:   fmt.Sprintf("/c/%s", catalog.Slug),
+			PublishedAt: now,
+		},
+	})
+
+	return nil
 }
 
-// CreateCard membuat card baru
-func (uc *catalogUseCase) CreateCard(sectionID int64, profileID int64, req *dto.CreateCardRequest) error {
-	// Get section
-	section, err := uc.catalogRepo.GetSectionByID(sectionID)
+// catalogChanged purge cache publik dan antrikan update search index setelah konten katalog berubah
+func (uc *catalogUseCase) catalogChanged(catalog *entity.Catalog) {
+	uc.invalidatePublicCache(catalog)
+	uc.searchIndexer.Publish(service.SearchEvent{Type: constant.SearchEventCatalogUpdated, CatalogID: catalog.ID})
+}
+
+// PurgeCache purge cache CDN katalog sekarang juga, untuk halaman publik yang masih basi.
+// Dengan section_id hanya halaman detail card di section tersebut yang ikut di-purge
+func (uc *catalogUseCase) PurgeCache(ctx *gin.Context, id int64, profileID int64, req *dto.PurgeCacheRequest) (*dto.PurgeCacheResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if !uc.cacheService.Enabled() {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCachePurgeDisabled, 400)
+	}
+
+	if req.SectionID > 0 {
+		section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), req.SectionID)
+		if err != nil {
+			return nil, err
+		}
+		if section.CatalogID != catalog.ID {
+			return nil, errors.New(errors.ErrNotFound, constant.ErrMsgSectionNotFound, 404)
+		}
+	}
+
+	// Redis error tidak memblokir purge, sama seperti rate limit API
+	if uc.purgeLimit > 0 && uc.purgeLimiter.Enabled() {
+		allowed, _, err := uc.purgeLimiter.Allow(fmt.Sprintf("cache-purge:catalog:%d", catalog.ID), uc.purgeLimit)
+		if err == nil && !allowed {
+			return nil, errors.New(errors.ErrRateLimited, constant.ErrMsgCachePurgeRateLimited, 429)
+		}
+	}
+
+	cardSlugs, err := uc.catalogRepo.ListCardSlugs(utils.ContextFrom(ctx), catalog.ID, req.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	urls, err := uc.cacheService.PurgeCatalog(catalog.Slug, cardSlugs)
+	if err != nil {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgCachePurgeFailed, 502)
+	}
+
+	return &dto.PurgeCacheResponse{URLs: urls}, nil
+}
+
+// ReindexSearch mulai reindex penuh search engine di background
+func (uc *catalogUseCase) ReindexSearch(ctx context.Context) error {
+	if !uc.searchIndexer.Enabled() {
+		return errors.New(errors.ErrValidation, constant.ErrMsgSearchEngineDisabled, 400)
+	}
+
+	if !uc.searchIndexer.StartReindex() {
+		return errors.New(errors.ErrConflict, constant.ErrMsgSearchReindexRunning, 409)
+	}
+
+	return nil
+}
+
+// invalidatePublicCache purge cache CDN halaman katalog yang sedang tayang
+func (uc *catalogUseCase) invalidatePublicCache(catalog *entity.Catalog) {
+	if catalog.IsPublished() {
+		uc.cacheService.InvalidateCatalog(catalog.Slug)
+	}
+}
+
+// applyMediaReplicas ganti URL media dengan replika yang sudah tersedia,
+// media yang belum tereplikasi tetap memakai URL asli
+func (uc *catalogUseCase) applyMediaReplicas(ctx context.Context, businessID int64, sections []*entity.CatalogSection) error {
+	business, err := uc.businessRepo.GetByID(ctx, businessID)
 	if err != nil {
 		return err
 	}
+	if !business.MediaReplication {
+		return nil
+	}
 
-	// Validate section type
-	if section.Type != constant.SectionTypeCards {
-		return errors.New(errors.ErrValidation, "Section bukan tipe cards", 400)
+	urls := make([]string, 0)
+	for _, section := range sections {
+		for _, card := range section.Cards {
+			for _, media := range card.Media {
+				urls = append(urls, media.URL)
+			}
+		}
 	}
 
-	// Get catalog for permission check
-	catalog, err := uc.catalogRepo.GetByID(section.CatalogID)
+	replicas, err := uc.catalogRepo.GetMediaReplicaURLs(ctx, urls)
 	if err != nil {
 		return err
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+	for _, section := range sections {
+		for _, card := range section.Cards {
+			for _, media := range card.Media {
+				if replica, ok := replicas[media.URL]; ok {
+					media.URL = replica
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// GenerateSnapshots tulis snapshot JSON semua katalog publik (satu file per slug),
+// dipanggil scheduler. File katalog yang sudah tidak publik sejak snapshot
+// sebelumnya dihapus
+func (uc *catalogUseCase) GenerateSnapshots(ctx context.Context) error {
+	if uc.snapshotStorage == nil {
+		return nil
+	}
+
+	slugs, err := uc.catalogRepo.ListPublicSlugs(ctx)
+	if err != nil {
 		return err
 	}
 
-	// Validate card type
-	if !constant.IsValidCardType(req.Type) {
-		return errors.New(errors.ErrValidation, constant.ErrMsgCardTypeInvalid, 400)
+	now := time.Now()
+	written := make([]string, 0, len(slugs))
+	for _, slug := range slugs {
+		catalog, err := uc.getBySlug(ctx, slug, "", "")
+		if err != nil {
+			// Katalog berubah status di tengah proses, lewati
+			var appErr *errors.AppError
+			if errors.As(err, &appErr) {
+				continue
+			}
+			return err
+		}
+
+		data, err := json.Marshal(&dto.PublicCatalogSnapshot{
+			FormatVersion: dto.SnapshotFormatVersion,
+			GeneratedAt:   now,
+			Catalog:       catalog,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to encode catalog snapshot")
+		}
+
+		if err := uc.snapshotStorage.Put(snapshotKey(slug), data); err != nil {
+			return errors.Wrap(err, "failed to write catalog snapshot")
+		}
+		written = append(written, slug)
 	}
 
-	// Start transaction
-	tx, err := uc.db.Begin()
+	// Hapus file katalog yang tidak ada lagi di daftar publik
+	if previous, err := uc.loadSnapshotIndex(); err == nil {
+		current := make(map[string]bool, len(written))
+		for _, slug := range written {
+			current[slug] = true
+		}
+		for _, slug := range previous.Slugs {
+			if !current[slug] {
+				if err := uc.snapshotStorage.Delete(snapshotKey(slug)); err != nil {
+					return errors.Wrap(err, "failed to delete catalog snapshot")
+				}
+			}
+		}
+	}
+
+	data, err := json.Marshal(&dto.SnapshotIndex{GeneratedAt: now, Slugs: written})
 	if err != nil {
-		return errors.Wrap(err, "failed to begin transaction")
+		return errors.Wrap(err, "failed to encode snapshot index")
+	}
+	if err := uc.snapshotStorage.Put(snapshotIndexKey, data); err != nil {
+		return errors.Wrap(err, "failed to write snapshot index")
 	}
-	defer tx.Rollback()
 
-	// Create card
-	card := &entity.CatalogCard{
-		SectionID: sectionID,
-		Title:     req.Title,
-		Subtitle:  database.NullString(req.Subtitle),
-		Type:      req.Type,
-		URL:       database.NullString(req.URL),
-		IsVisible: req.IsVisible,
-		HasDetail: req.HasDetail,
-		Price:     database.NullInt64(req.Price),
-		Discount:  req.Discount,
-		Currency:  req.Currency,
-		CreatedBy: profileID,
-		CreatedAt: time.Now(),
+	return nil
+}
+
+// loadSnapshot baca snapshot katalog publik by slug
+func (uc *catalogUseCase) loadSnapshot(slug string) (*dto.PublicCatalogSnapshot, error) {
+	data, err := uc.snapshotStorage.Get(snapshotKey(slug))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &dto.PublicCatalogSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.FormatVersion != dto.SnapshotFormatVersion || snapshot.Catalog == nil {
+		return nil, fmt.Errorf("snapshot: unsupported format version %d", snapshot.FormatVersion)
+	}
+
+	return snapshot, nil
+}
+
+func (uc *catalogUseCase) loadSnapshotIndex() (*dto.SnapshotIndex, error) {
+	data, err := uc.snapshotStorage.Get(snapshotIndexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &dto.SnapshotIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// snapshotIndexKey key daftar slug snapshot terakhir
+const snapshotIndexKey = "index.json"
+
+// snapshotKey key file snapshot satu katalog
+func snapshotKey(slug string) string {
+	return "catalogs/" + slug + ".json"
+}
+
+// ReplicateMedia salin media business yang mengaktifkan replikasi ke region
+// kedua, dipanggil scheduler. Kegagalan per media dicatat untuk dicoba ulang
+func (uc *catalogUseCase) ReplicateMedia(ctx context.Context, batchSize, maxAttempts int) error {
+	if !uc.mediaReplicationService.Enabled() {
+		return nil
+	}
+
+	urls, err := uc.catalogRepo.ListMediaPendingReplication(ctx, batchSize, maxAttempts)
+	if err != nil {
+		return err
+	}
+
+	for _, url := range urls {
+		replica := &entity.CatalogMediaReplica{
+			SourceURL: url,
+			Status:    constant.MediaReplicaStatusCompleted,
+		}
+
+		replicaURL, err := uc.mediaReplicationService.Replicate(url)
+		if err != nil {
+			replica.Status = constant.MediaReplicaStatusFailed
+			replica.Error = sql.NullString{String: err.Error(), Valid: true}
+		} else {
+			replica.ReplicaURL = sql.NullString{String: replicaURL, Valid: true}
+		}
+
+		if err := uc.catalogRepo.SaveMediaReplica(ctx, replica); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IngestMediaAccess catat waktu akses terakhir media dari log CDN. Query string
+// diabaikan dan beberapa akses ke URL yang sama diambil yang terbaru
+func (uc *catalogUseCase) IngestMediaAccess(ctx context.Context, req *dto.MediaAccessLogRequest) (*dto.MediaAccessLogResponse, error) {
+	accessed := make(map[string]time.Time, len(req.Entries))
+	for _, entry := range req.Entries {
+		url := entry.URL
+		if i := strings.IndexByte(url, '?'); i >= 0 {
+			url = url[:i]
+		}
+		if at, ok := accessed[url]; !ok || entry.AccessedAt.After(at) {
+			accessed[url] = entry.AccessedAt
+		}
 	}
 
-	if card.Currency == "" {
-		card.Currency = constant.CurrencyIDR
+	updated, err := uc.catalogRepo.TouchMediaAccess(ctx, accessed)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := uc.catalogRepo.CreateCard(tx, card); err != nil {
-		return err
+	return &dto.MediaAccessLogResponse{
+		Received: len(req.Entries),
+		Updated:  updated,
+	}, nil
+}
+
+// ArchiveColdMedia pindahkan original media yang tidak diakses selama coldAfterDays
+// ke storage arsip. Original di storage utama baru dihapus setelah URL diganti
+func (uc *catalogUseCase) ArchiveColdMedia(ctx context.Context, coldAfterDays, batchSize, maxAttempts int) error {
+	if !uc.mediaArchiveService.Enabled() || coldAfterDays <= 0 {
+		return nil
 	}
 
-	// Create detail if requested
-	if req.HasDetail && req.Detail != nil {
-		var detailSlug string
-		if req.Detail.Slug != "" {
-			detailSlug = req.Detail.Slug
-		} else {
-			detailSlug = uc.slugService.GenerateUnique(req.Title, 50)
-		}
+	before := time.Now().AddDate(0, 0, -coldAfterDays)
+	urls, err := uc.catalogRepo.ListColdMedia(ctx, before, batchSize, maxAttempts)
+	if err != nil {
+		return err
+	}
 
-		detail := &entity.CatalogCardDetail{
-			CardID:      card.ID,
-			Slug:        detailSlug,
-			Description: database.NullString(req.Detail.Description),
-			IsVisible:   req.Detail.IsVisible,
-			CreatedBy:   profileID,
-			CreatedAt:   time.Now(),
+	for _, url := range urls {
+		archiveURL, err := uc.mediaArchiveService.Archive(url)
+		if err != nil {
+			if err := uc.catalogRepo.MarkMediaArchiveFailed(ctx, url); err != nil {
+				return err
+			}
+			continue
 		}
 
-		if err := uc.catalogRepo.CreateCardDetail(tx, detail); err != nil {
+		slugs, err := uc.catalogRepo.MarkMediaArchived(ctx, url, archiveURL)
+		if err != nil {
 			return err
 		}
-	}
 
-	// Create media if provided
-	for _, mediaURL := range req.MediaURLs {
-		media := &entity.CatalogCardMedia{
-			CardID:    card.ID,
-			Type:      constant.MediaTypeThumbnail,
-			URL:       mediaURL,
-			CreatedBy: profileID,
-			CreatedAt: time.Now(),
-		}
+		// Original tidak lagi direferensikan, gagal hapus hanya menyisakan file yatim
+		_ = uc.mediaArchiveService.DeleteOriginal(url)
 
-		if err := uc.catalogRepo.CreateCardMedia(tx, media); err != nil {
-			return err
+		for _, slug := range slugs {
+			uc.cacheService.InvalidateCatalog(slug)
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-// UpdateCard update card
-func (uc *catalogUseCase) UpdateCard(ctx *gin.Context, cardID int64, profileID int64, req *dto.UpdateCardRequest) error {
-	// Get card
-	card, err := uc.catalogRepo.GetCardByID(cardID)
-	if err != nil {
-		return err
+func toPublishRequestResponse(request *entity.CatalogPublishRequest) *dto.PublishRequestResponse {
+	resp := &dto.PublishRequestResponse{
+		ID:            request.ID,
+		CatalogID:     request.CatalogID,
+		Status:        request.Status,
+		Note:          request.Note.String,
+		ReviewComment: request.ReviewComment.String,
+		RequestedBy: dto.PublishActorResponse{
+			ProfileID:   request.RequestedBy,
+			DisplayName: request.RequesterName.String,
+		},
+		RequestedAt: request.RequestedAt,
+		ReviewedAt:  request.ReviewedAt,
 	}
 
-	// Inject old_data ke audit context
-	if ctx != nil {
-		ctx.Set(middleware.GinKeyAuditOldData, card)
+	if request.ReviewedBy.Valid {
+		resp.ReviewedBy = &dto.PublishActorResponse{
+			ProfileID:   request.ReviewedBy.Int64,
+			DisplayName: request.ReviewerName.String,
+		}
 	}
 
-	// Get section
-	section, err := uc.catalogRepo.GetSectionByID(card.SectionID)
+	return resp
+}
+
+// catalogLimits batas jumlah konten katalog yang berlaku untuk business
+type catalogLimits struct {
+	planName    string
+	maxSections int
+	maxCards    int
+	maxMedia    int
+}
+
+// UsageHints hint pemakaian konten katalog yang mendekati atau mencapai batas plan
+func (uc *catalogUseCase) UsageHints(ctx context.Context, catalogID int64) ([]utils.Hint, error) {
+	catalog, err := uc.catalogRepo.GetByID(ctx, catalogID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Get catalog for permission check
-	catalog, err := uc.catalogRepo.GetByID(section.CatalogID)
+	limits, err := uc.contentLimits(ctx, catalog.BusinessID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
-		return err
+	sections, err := uc.catalogRepo.GetSectionsByCatalogID(ctx, catalog.ID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate updates
-	if req.Type != "" && !constant.IsValidCardType(req.Type) {
-		return errors.New(errors.ErrValidation, constant.ErrMsgCardTypeInvalid, 400)
+	hints := make([]utils.Hint, 0)
+	if hint := usageHint(constant.PlanFeatureMaxSectionsPerCatalog, constant.HintMsgSectionUsage, len(sections), limits.maxSections, limits.planName); hint != nil {
+		hint.ResourceID = catalog.ID
+		hints = append(hints, *hint)
 	}
 
-	// Update fields
-	if req.Title != "" {
-		card.Title = req.Title
-	}
-	if req.Subtitle != "" {
-		card.Subtitle = database.NullString(req.Subtitle)
-	}
-	if req.Type != "" {
-		card.Type = req.Type
-	}
-	if req.URL != "" {
-		card.URL = database.NullString(req.URL)
-	}
-	if req.IsVisible != nil {
-		card.IsVisible = *req.IsVisible
-	}
-	if req.HasDetail != nil {
-		card.HasDetail = *req.HasDetail
-	}
-	if req.Price != nil {
-		card.Price = database.NullInt64(*req.Price)
-	}
-	if req.Discount != nil {
-		card.Discount = *req.Discount
-	}
-	if req.Currency != "" {
-		card.Currency = req.Currency
+	for _, section := range sections {
+		if section.Type != constant.SectionTypeCards {
+			continue
+		}
+		count, err := uc.catalogRepo.CountCards(ctx, section.ID)
+		if err != nil {
+			return nil, err
+		}
+		if hint := usageHint(constant.PlanFeatureMaxCardsPerSection, constant.HintMsgCardUsage, count, limits.maxCards, limits.planName); hint != nil {
+			hint.ResourceID = section.ID
+			hints = append(hints, *hint)
+		}
 	}
 
-	card.UpdatedBy = database.NullInt64(profileID)
-	card.UpdatedAt = &[]time.Time{time.Now()}[0]
+	return hints, nil
+}
 
-	// Update in transaction
-	tx, err := uc.db.Begin()
-	if err != nil {
-		return errors.Wrap(err, "failed to begin transaction")
+// usageHint hint untuk satu batas plan, nil jika pemakaian masih di bawah ambang
+func usageHint(feature, message string, used, limit int, planName string) *utils.Hint {
+	if limit <= 0 || used*100 < limit*constant.UsageHintThresholdPercent {
+		return nil
 	}
-	defer tx.Rollback()
 
-	if err := uc.catalogRepo.UpdateCard(tx, card); err != nil {
-		return err
+	code := constant.HintCodeLimitApproaching
+	if used >= limit {
+		code = constant.HintCodeLimitReached
 	}
 
-	return tx.Commit()
-}
-
-// DeleteCard delete card
-func (uc *catalogUseCase) DeleteCard(ctx *gin.Context, cardID int64, profileID int64) error {
-	// Get card
-	card, err := uc.catalogRepo.GetCardByID(cardID)
-	if err != nil {
-		return err
+	return &utils.Hint{
+		Code:    code,
+		Message: fmt.Sprintf(message, used, limit, planName),
+		Feature: feature,
+		Used:    used,
+		Limit:   limit,
+		Plan:    planName,
 	}
+}
 
-	// Inject old_data ke audit context
-	if ctx != nil {
-		ctx.Set(middleware.GinKeyAuditOldData, card)
+// contentLimits ambil batas konten dari plan aktif, fallback ke default
+func (uc *catalogUseCase) contentLimits(ctx context.Context, businessID int64) (*catalogLimits, error) {
+	limits := &catalogLimits{
+		planName:    "Free",
+		maxSections: constant.DefaultMaxSectionsPerCatalog,
+		maxCards:    constant.DefaultMaxCardsPerSection,
+		maxMedia:    constant.DefaultMaxMediaPerCard,
 	}
 
-	// Get section
-	section, err := uc.catalogRepo.GetSectionByID(card.SectionID)
+	subscription, err := uc.businessRepo.GetActiveSubscription(ctx, businessID, uc.clock.NowFor(businessID))
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	// Get catalog for permission check
-	catalog, err := uc.catalogRepo.GetByID(section.CatalogID)
-	if err != nil {
-		return err
+	if subscription == nil || subscription.Plan == nil {
+		return limits, nil
 	}
 
-	// Check permission
-	if err := uc.checkBusinessAccess(catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
-		return err
+	plan := subscription.Plan
+	limits.planName = plan.Name
+	limits.maxSections = plan.FeatureInt(constant.PlanFeatureMaxSectionsPerCatalog, limits.maxSections)
+	limits.maxCards = plan.FeatureInt(constant.PlanFeatureMaxCardsPerSection, limits.maxCards)
+	limits.maxMedia = plan.FeatureInt(constant.PlanFeatureMaxMediaPerCard, limits.maxMedia)
+	return limits, nil
+}
+
+// validateSectionConfig validasi config.layout section cards lalu simpan dalam bentuk baku
+func validateSectionConfig(sectionType string, config map[string]interface{}) error {
+	if sectionType != constant.SectionTypeCards {
+		return nil
 	}
 
-	// Delete in transaction
-	tx, err := uc.db.Begin()
+	layout, ok, err := entity.CardLayoutFromConfig(config)
+	if !ok {
+		return nil
+	}
 	if err != nil {
-		return errors.Wrap(err, "failed to begin transaction")
+		return errors.New(errors.ErrValidation, constant.ErrMsgCardLayoutInvalid, 400)
 	}
-	defer tx.Rollback()
 
-	if err := uc.catalogRepo.DeleteCard(tx, cardID); err != nil {
-		return err
+	if layout.Type != "" && !constant.IsValidCardLayout(layout.Type) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCardLayoutType, 400)
+	}
+	// 0 berarti ikut default tema
+	for _, columns := range []int{layout.Columns.Mobile, layout.Columns.Tablet, layout.Columns.Desktop} {
+		if columns < 0 || columns > constant.MaxCardLayoutColumns {
+			return errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgCardLayoutColumns, constant.MaxCardLayoutColumns), 400)
+		}
+	}
+	if layout.ImageAspectRatio != "" && !constant.IsValidImageAspectRatio(layout.ImageAspectRatio) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCardLayoutAspectRatio, 400)
 	}
 
-	return tx.Commit()
+	config["layout"] = layout
+	return nil
 }
 
-// Helper methods
-
-func (uc *catalogUseCase) checkBusinessAccess(businessID, profileID int64, permission string) error {
-	// Get user role in business
-	user, err := uc.businessRepo.GetUserByBusinessAndProfile(businessID, profileID)
-	if err != nil {
-		return err
+// withResolvedCardLayout salinan config dengan layout yang sudah digabung default tema
+func withResolvedCardLayout(config map[string]interface{}, theme *entity.MasterTheme) map[string]interface{} {
+	themeType := ""
+	if theme != nil {
+		themeType = theme.Type
 	}
 
-	if user == nil || !user.IsActive {
-		return errors.New(errors.ErrForbidden, constant.ErrMsgBusinessAccessDenied, 403)
+	// Config lama yang tidak valid tetap ditampilkan dengan default tema
+	layout, _, err := entity.CardLayoutFromConfig(config)
+	if err != nil {
+		layout = entity.CardLayout{}
 	}
 
-	// Check permission
-	if !constant.HasPermission(user.Role, permission) {
-		return errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	resolved := make(map[string]interface{}, len(config)+1)
+	for key, value := range config {
+		resolved[key] = value
 	}
-
-	return nil
+	resolved["layout"] = layout.WithDefaults(entity.DefaultCardLayout(themeType))
+	return resolved
 }
 
-func (uc *catalogUseCase) createSectionInternal(tx *sql.Tx, catalogID int64, profileID int64, req *dto.CreateSectionRequest) error {
+func (uc *catalogUseCase) createSectionInternal(ctx context.Context, tx *sql.Tx, catalogID int64, profileID int64, req *dto.CreateSectionRequest) error {
 	// Set default config if empty
 	if req.Config == nil {
 		req.Config = make(map[string]interface{})
 	}
+	if err := validateSectionConfig(req.Type, req.Config); err != nil {
+		return err
+	}
 
 	// Create section
 	section := &entity.CatalogSection{
@@ -802,10 +5545,11 @@ func (uc *catalogUseCase) createSectionInternal(tx *sql.Tx, catalogID int64, pro
 		Type:      req.Type,
 		IsVisible: req.IsVisible,
 		Config:    req.Config,
+		CreatedBy: sql.NullInt64{Int64: profileID, Valid: true},
 		CreatedAt: time.Now(),
 	}
 
-	if err := uc.catalogRepo.CreateSection(tx, section); err != nil {
+	if err := uc.catalogRepo.CreateSection(ctx, tx, section); err != nil {
 		return err
 	}
 
@@ -816,16 +5560,18 @@ func (uc *catalogUseCase) createSectionInternal(tx *sql.Tx, catalogID int64, pro
 
 	case constant.SectionTypeFAQs:
 		if faqs, ok := req.Content.([]dto.FAQRequest); ok {
-			for _, faqReq := range faqs {
+			for i, faqReq := range faqs {
 				faq := &entity.CatalogFAQ{
 					SectionID: section.ID,
 					Question:  faqReq.Question,
 					Answer:    faqReq.Answer,
+					AnswerHTML: database.NullString(utils.RenderMarkdown(faqReq.Answer)),
 					IsVisible: faqReq.IsVisible,
+					DisplayOrder: i + 1,
 					CreatedBy: profileID,
 					CreatedAt: time.Now(),
 				}
-				if err := uc.catalogRepo.CreateFAQ(tx, faq); err != nil {
+				if err := uc.catalogRepo.CreateFAQ(ctx, tx, faq); err != nil {
 					return err
 				}
 			}
@@ -837,6 +5583,18 @@ func (uc *catalogUseCase) createSectionInternal(tx *sql.Tx, catalogID int64, pro
 	return nil
 }
 
+// checkCategory pastikan kategori master ada dan aktif
+func (uc *catalogUseCase) checkCategory(ctx context.Context, categoryID int64) error {
+	active, err := uc.catalogRepo.IsCategoryActive(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return errors.New(errors.ErrValidation, constant.ErrMsgCategoryInactive, 400)
+	}
+	return nil
+}
+
 func (uc *catalogUseCase) toCatalogResponse(catalog *entity.Catalog, sections []*entity.CatalogSection) *dto.CatalogResponse {
 	resp := &dto.CatalogResponse{
 		ID:         catalog.ID,
@@ -847,12 +5605,27 @@ func (uc *catalogUseCase) toCatalogResponse(catalog *entity.Catalog, sections []
 		Title:      catalog.Title,
 		Subtitle:   catalog.GetSubtitle(),
 		IsActive:   catalog.IsActive,
+		Status:     catalog.Status,
+		PublishedAt: catalog.PublishedAt,
+		PublishScheduledAt: catalog.PublishScheduledAt,
+		PublishAt:   catalog.PublishAt,
+		UnpublishAt: catalog.UnpublishAt,
 		Settings:   catalog.Settings,
+		MetaTitle:  catalog.MetaTitle.String,
+		MetaDescription: catalog.MetaDescription.String,
+		OGImage:    catalog.OGImage.String,
+		AllowIndexing: catalog.AllowIndexing,
+		Listed:     catalog.Listed,
 		CreatedBy:  catalog.CreatedBy,
 		CreatedAt:  catalog.CreatedAt,
 		UpdatedAt:  catalog.UpdatedAt,
+		DeletedAt:  catalog.DeletedAt,
+		Version:    catalog.Version,
 		PublicURL:  fmt.Sprintf("/c/%s", catalog.Slug),
 	}
+	if catalog.CategoryID.Valid {
+		resp.CategoryID = &catalog.CategoryID.Int64
+	}
 
 	// Add business info
 	if catalog.Business != nil {
@@ -882,8 +5655,20 @@ func (uc *catalogUseCase) toCatalogResponse(catalog *entity.Catalog, sections []
 				Type:      section.Type,
 				IsVisible: section.IsVisible,
 				Config:    section.Config,
+				Position:  section.Position,
 				CreatedAt: section.CreatedAt,
 				UpdatedAt: section.UpdatedAt,
+				LastModifiedBy: section.LastModifiedByName.String,
+			}
+			lastModifiedAt := section.GetLastModifiedAt()
+			resp.Sections[i].LastModifiedAt = &lastModifiedAt
+
+			if section.Type == constant.SectionTypeCards && section.Cards != nil {
+				cards := make([]dto.CardResponse, len(section.Cards))
+				for j, card := range section.Cards {
+					cards[j] = uc.toAdminCardResponse(card)
+				}
+				resp.Sections[i].Content = cards
 			}
 		}
 	}
@@ -891,13 +5676,68 @@ func (uc *catalogUseCase) toCatalogResponse(catalog *entity.Catalog, sections []
 	return resp
 }
 
-func (uc *catalogUseCase) toPublicCatalogResponse(catalog *entity.Catalog, sections []*entity.CatalogSection) *dto.PublicCatalogResponse {
+// toAdminCardResponse card response untuk dashboard, termasuk affiliate dan editor terakhir
+func (uc *catalogUseCase) toAdminCardResponse(card *entity.CatalogCard) dto.CardResponse {
+	lastModifiedAt := card.GetLastModifiedAt()
+	resp := dto.CardResponse{
+		ID:              card.ID,
+		SectionID:       card.SectionID,
+		Title:           card.Title,
+		Subtitle:        card.Subtitle.String,
+		Type:            card.Type,
+		URL:             card.URL.String,
+		IsVisible:       card.IsVisible,
+		HasDetail:       card.HasDetail,
+		Price:           card.Price.Int64,
+		Discount:        card.Discount,
+		Currency:        card.Currency,
+		DiscountedPrice: card.GetDiscountedPrice(),
+		Position:        card.Position,
+		PublishAt:       card.PublishAt,
+		UnpublishAt:     card.UnpublishAt,
+		SoldOut:         card.IsSoldOut(),
+		HideWhenSoldOut: card.HideWhenSoldOut,
+		CreatedAt:       card.CreatedAt,
+		UpdatedAt:       card.UpdatedAt,
+		Version:         card.Version,
+		LastModifiedBy:  card.LastModifiedByName.String,
+		LastModifiedAt:  &lastModifiedAt,
+	}
+
+	if card.Stock.Valid {
+		resp.Stock = &card.Stock.Int64
+	}
+	resp.Tags = toCardTagResponses(card.Tags)
+
+	if card.IsAffiliate() {
+		resp.Affiliate = &dto.AffiliateResponse{
+			PartnerID:      card.AffiliatePartnerID.String,
+			CommissionRate: card.AffiliateCommissionRate.Float64,
+		}
+	}
+
+	return resp
+}
+
+// toPublicCatalogResponse convert katalog ke response publik. Tags berisi semua tag
+// card yang tampil, filterTag tidak nil hanya menyertakan card dengan tag tersebut
+func (uc *catalogUseCase) toPublicCatalogResponse(catalog *entity.Catalog, sections []*entity.CatalogSection, filterTag *entity.CatalogTag) *dto.PublicCatalogResponse {
 	resp := &dto.PublicCatalogResponse{
 		ID:       catalog.ID,
 		Slug:     catalog.Slug,
 		Title:    catalog.Title,
 		Subtitle: catalog.GetSubtitle(),
 		Settings: catalog.Settings,
+		Robots:   catalog.RobotsDirective(),
+		MetaTitle:       catalog.SEOTitle(),
+		MetaDescription: catalog.SEODescription(),
+		OGImage:         catalog.SEOImage(),
+		AnalyticsToken: uc.botFilter.IssueChallenge(catalog.ID),
+		CanonicalURL: uc.catalogCanonicalURL(catalog.Slug),
+		Rating: dto.RatingSummary{
+			Average: catalog.RatingAvg,
+			Count:   catalog.RatingCount,
+		},
 		Business: dto.PublicBusinessInfo{
 			Name: catalog.Business.Name,
 			Type: catalog.Business.Type,
@@ -909,6 +5749,12 @@ func (uc *catalogUseCase) toPublicCatalogResponse(catalog *entity.Catalog, secti
 		},
 	}
 
+	resp.Tags = make([]dto.CardTagResponse, 0)
+	if filterTag != nil {
+		resp.Tag = filterTag.Slug
+	}
+	seenTags := make(map[int64]bool)
+
 	// Add visible sections only
 	resp.Sections = make([]dto.PublicSectionResponse, 0)
 	for _, section := range sections {
@@ -921,46 +5767,36 @@ func (uc *catalogUseCase) toPublicCatalogResponse(catalog *entity.Catalog, secti
 			Config: section.Config,
 		}
 
+		// Layout cards selalu lengkap supaya client tidak perlu menebak default tema
+		if section.Type == constant.SectionTypeCards {
+			publicSection.Config = withResolvedCardLayout(section.Config, catalog.Theme)
+		}
+
 		// Convert content based on type
 		switch section.Type {
 		case constant.SectionTypeCards:
 			cards := make([]dto.CardResponse, 0)
 			for _, card := range section.Cards {
-				if !card.IsVisible {
+				if !card.IsPubliclyVisible() {
 					continue
 				}
 
-				cardResp := dto.CardResponse{
-					ID:              card.ID,
-					SectionID:       card.SectionID,
-					Title:           card.Title,
-					Subtitle:        card.Subtitle.String,
-					Type:            card.Type,
-					URL:             card.URL.String,
-					IsVisible:       card.IsVisible,
-					HasDetail:       card.HasDetail,
-					Price:           card.Price.Int64,
-					Discount:        card.Discount,
-					Currency:        card.Currency,
-					DiscountedPrice: card.GetDiscountedPrice(),
-					CreatedAt:       card.CreatedAt,
-					UpdatedAt:       card.UpdatedAt,
+				for _, tag := range card.Tags {
+					if !seenTags[tag.ID] {
+						seenTags[tag.ID] = true
+						resp.Tags = append(resp.Tags, dto.CardTagResponse{ID: tag.ID, Name: tag.Name, Slug: tag.Slug})
+					}
 				}
 
-				// Add media
-				if card.Media != nil {
-					cardResp.Media = make([]dto.MediaResponse, len(card.Media))
-					for j, media := range card.Media {
-						cardResp.Media[j] = dto.MediaResponse{
-							ID:        media.ID,
-							Type:      media.Type,
-							URL:       media.URL,
-							CreatedAt: media.CreatedAt,
-						}
-					}
+				if filterTag != nil && !card.HasTag(filterTag.ID) {
+					continue
 				}
 
-				cards = append(cards, cardResp)
+				cards = append(cards, uc.toPublicCardResponse(catalog, card))
+			}
+			// Saat difilter, section tanpa card yang cocok tidak ditampilkan
+			if filterTag != nil && len(cards) == 0 {
+				continue
 			}
 			publicSection.Content = cards
 
@@ -971,17 +5807,237 @@ func (uc *catalogUseCase) toPublicCatalogResponse(catalog *entity.Catalog, secti
 					continue
 				}
 				faqs = append(faqs, map[string]interface{}{
-					"question": faq.Question,
-					"answer":   faq.Answer,
+					"question":    faq.Question,
+					"answer":      faq.Answer,
+					"answer_html": markdownHTML(faq.AnswerHTML, faq.Answer),
 				})
 			}
 			publicSection.Content = faqs
 
+		case constant.SectionTypeLegal:
+			// Section legal tanpa versi tidak ditampilkan
+			if section.Legal == nil {
+				continue
+			}
+			publicSection.Content = map[string]interface{}{
+				"version":                section.Legal.Version,
+				"terms":                  section.Legal.Terms,
+				"terms_html":             markdownHTML(section.Legal.TermsHTML, section.Legal.Terms),
+				"privacy":                section.Legal.Privacy,
+				"privacy_html":           markdownHTML(section.Legal.PrivacyHTML, section.Legal.Privacy),
+				"require_acknowledgment": legalAckRequired(section),
+				"updated_at":             section.Legal.CreatedAt,
+			}
+
 			// TODO: Implement other section types
 		}
 
 		resp.Sections = append(resp.Sections, publicSection)
 	}
 
+	sort.SliceStable(resp.Tags, func(i, j int) bool {
+		return resp.Tags[i].Name < resp.Tags[j].Name
+	})
+
 	return resp
-}
\ No newline at end of file
+}
+
+// toPublicCardResponse convert card ke response publik. Detail hanya disertakan
+// jika visible, links yang tersembunyi dibuang dan URL keluar diberi UTM katalog
+func (uc *catalogUseCase) toPublicCardResponse(catalog *entity.Catalog, card *entity.CatalogCard) dto.CardResponse {
+	utm := catalog.UTMParams()
+	cardResp := dto.CardResponse{
+		ID:              card.ID,
+		SectionID:       card.SectionID,
+		Title:           card.Title,
+		Subtitle:        card.Subtitle.String,
+		Type:            card.Type,
+		URL:             utils.AppendUTM(card.URL.String, utm),
+		IsVisible:       card.IsVisible,
+		HasDetail:       card.HasDetail,
+		Price:           card.Price.Int64,
+		Discount:        card.Discount,
+		Currency:        card.Currency,
+		DiscountedPrice: card.GetDiscountedPrice(),
+		SoldOut:         card.IsSoldOut(),
+		CreatedAt:       card.CreatedAt,
+		UpdatedAt:       card.UpdatedAt,
+	}
+	if card.Stock.Valid {
+		cardResp.Stock = &card.Stock.Int64
+	}
+	cardResp.Tags = toCardTagResponses(card.Tags)
+
+	// Detail publik hanya berisi deskripsi yang sudah disanitasi
+	if card.Detail != nil && card.Detail.IsVisible {
+		cardResp.Detail = &dto.CardDetailResponse{
+			ID:              card.Detail.ID,
+			CardID:          card.Detail.CardID,
+			Slug:            card.Detail.Slug,
+			DescriptionHTML: markdownHTML(card.Detail.DescriptionHTML, card.Detail.Description.String),
+			IsVisible:       card.Detail.IsVisible,
+			CreatedAt:       card.Detail.CreatedAt,
+			UpdatedAt:       card.Detail.UpdatedAt,
+			CanonicalURL:    uc.cardCanonicalURL(catalog.Slug, card.Detail.Slug),
+		}
+		for _, link := range card.Detail.Links {
+			if !link.IsVisible {
+				continue
+			}
+			// URL asli disembunyikan agar klik tercatat lewat redirect, UTM
+			// ditambahkan saat redirect
+			linkResp := uc.toLinkResponse(link)
+			linkResp.UTM = nil
+			if linkResp.RedirectURL != "" {
+				linkResp.URL = linkResp.RedirectURL
+			} else {
+				linkResp.URL = utils.AppendUTM(link.URL, utm.WithOverride(link.UTM))
+			}
+			cardResp.Detail.Links = append(cardResp.Detail.Links, *linkResp)
+		}
+	}
+
+	// Add media
+	if card.Media != nil {
+		cardResp.Media = make([]dto.MediaResponse, len(card.Media))
+		for j, media := range card.Media {
+			cardResp.Media[j] = dto.MediaResponse{
+				ID:        media.ID,
+				Type:      media.Type,
+				URL:       media.URL,
+				CreatedAt: media.CreatedAt,
+			}
+		}
+	}
+
+	return cardResp
+}
+
+// positionIndex index tujuan dalam urutan ids, tepat setelah afterID (0 = paling atas)
+func positionIndex(ids []int64, afterID int64) (int, bool) {
+	if afterID == 0 {
+		return 0, true
+	}
+	for i, id := range ids {
+		if id == afterID {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// positionBetween posisi di tengah tetangga pada index, false jika jaraknya sudah habis
+func positionBetween(positions []int, index int) (int, bool) {
+	prev := 0
+	if index > 0 {
+		prev = positions[index-1]
+	}
+	if index == len(positions) {
+		return prev + constant.PositionGap, true
+	}
+
+	next := positions[index]
+	if next-prev < 2 {
+		return 0, false
+	}
+	return prev + (next-prev)/2, true
+}
+
+// rebalancedPositions posisi n item setelah dinomori ulang (100, 200, ...)
+func rebalancedPositions(n int) []int {
+	positions := make([]int, n)
+	for i := range positions {
+		positions[i] = (i + 1) * constant.PositionGap
+	}
+	return positions
+}
+
+// markdownHTML HTML hasil render yang tersimpan, data lama tanpa hasil render dirender saat dibaca
+func markdownHTML(html sql.NullString, source string) string {
+	if html.Valid {
+		return html.String
+	}
+	return utils.RenderMarkdown(source)
+}
+
+// mergeSettings gabung settings secara berurutan, key di layer belakang menimpa layer depan
+func mergeSettings(layers ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, layer := range layers {
+		for key, value := range layer {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// validateSearchQuery rapikan kata kunci pencarian, wajib diisi dan dibatasi panjangnya
+func validateSearchQuery(search string) (string, error) {
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return "", errors.New(errors.ErrValidation, constant.ErrMsgSearchQueryRequired, 400)
+	}
+	if utf8.RuneCountInString(search) > constant.MaxSearchQueryLength {
+		return "", errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgSearchQueryTooLong, constant.MaxSearchQueryLength), 400)
+	}
+	return search, nil
+}
+
+// searchTerms kata kunci pencarian dalam huruf kecil, dipecah seperti tsquery di repository
+func searchTerms(search string) []string {
+	return strings.FieldsFunc(strings.ToLower(search), isNotWordRune)
+}
+
+// highlightMatches escape HTML teks lalu bungkus kata yang diawali salah satu
+// kata kunci dengan <mark>, sama seperti pencocokan prefix full-text search
+func highlightMatches(text string, terms []string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		j := i
+		if isNotWordRune(runes[i]) {
+			for j < len(runes) && isNotWordRune(runes[j]) {
+				j++
+			}
+			b.WriteString(html.EscapeString(string(runes[i:j])))
+			i = j
+			continue
+		}
+
+		for j < len(runes) && !isNotWordRune(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		lower := strings.ToLower(word)
+		matched := false
+		for _, term := range terms {
+			if strings.HasPrefix(lower, term) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			b.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// preconditionFailed check precondition optimistic locking dari klien: field version
+// atau header If-Unmodified-Since. Tanpa keduanya update selalu diizinkan
+func preconditionFailed(version int, lastModified time.Time, reqVersion *int, unmodifiedSince *time.Time) bool {
+	if reqVersion != nil && *reqVersion != version {
+		return true
+	}
+	if unmodifiedSince != nil && utils.ModifiedSince(lastModified, *unmodifiedSince) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// CreateCommentRequest request untuk membuat komentar atau balasan
+type CreateCommentRequest struct {
+	Body     string  `json:"body" validate:"required,min=1,max=2000"`
+	ParentID *int64  `json:"parent_id,omitempty"`                            // isi untuk membalas thread
+	Mentions []int64 `json:"mentions,omitempty" validate:"omitempty,max=20"` // profile ID anggota business
+}
+
+// ResolveCommentRequest request untuk resolve / buka kembali thread
+type ResolveCommentRequest struct {
+	IsResolved *bool `json:"is_resolved" validate:"required"`
+}
+
+// CommentAuthorResponse profile penulis atau yang di-mention
+type CommentAuthorResponse struct {
+	ProfileID   int64  `json:"profile_id"`
+	DisplayName string `json:"display_name"`
+}
+
+// CommentResponse response komentar, thread berisi replies
+type CommentResponse struct {
+	ID         int64                   `json:"id"`
+	CatalogID  int64                   `json:"catalog_id"`
+	SectionID  *int64                  `json:"section_id,omitempty"`
+	CardID     *int64                  `json:"card_id,omitempty"`
+	ParentID   *int64                  `json:"parent_id,omitempty"`
+	Body       string                  `json:"body"`
+	Author     CommentAuthorResponse   `json:"author"`
+	Mentions   []CommentAuthorResponse `json:"mentions,omitempty"`
+	IsResolved bool                    `json:"is_resolved"`
+	ResolvedBy *int64                  `json:"resolved_by,omitempty"`
+	ResolvedAt *time.Time              `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time               `json:"created_at"`
+	Replies    []*CommentResponse      `json:"replies,omitempty"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Comment entity untuk tabel catalog_comments
+type Comment struct {
+	ID         int64         `json:"id" db:"cmt_id"`
+	CatalogID  int64         `json:"catalog_id" db:"cmt_c_id"`
+	SectionID  sql.NullInt64 `json:"section_id" db:"cmt_cs_id"`
+	CardID     sql.NullInt64 `json:"card_id" db:"cmt_cc_id"`
+	ParentID   sql.NullInt64 `json:"parent_id" db:"cmt_parent_id"`
+	Body       string        `json:"body" db:"cmt_body"`
+	Mentions   []int64       `json:"mentions" db:"cmt_mentions"`
+	IsResolved bool          `json:"is_resolved" db:"cmt_is_resolved"`
+	ResolvedBy sql.NullInt64 `json:"resolved_by" db:"cmt_resolved_by"`
+	ResolvedAt *time.Time    `json:"resolved_at" db:"cmt_resolved_at"`
+	CreatedBy  int64         `json:"created_by" db:"cmt_created_by"`
+	CreatedAt  time.Time     `json:"created_at" db:"cmt_created_at"`
+
+	// Display name author (join user_profiles)
+	AuthorName sql.NullString `json:"-"`
+}
+
+// TableName mendapatkan nama tabel
+func (Comment) TableName() string { return "atamlink.catalog_comments" }
+
+// IsThread check apakah comment adalah awal thread (bukan balasan)
+func (c *Comment) IsThread() bool {
+	return !c.ParentID.Valid
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_comment/entity"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// CommentRepository interface untuk comment repository
+type CommentRepository interface {
+	Create(tx *sql.Tx, comment *entity.Comment) error
+	GetByID(id int64) (*entity.Comment, error)
+	ListBySectionID(sectionID int64) ([]*entity.Comment, error)
+	ListByCardID(cardID int64) ([]*entity.Comment, error)
+	UpdateResolved(tx *sql.Tx, id int64, resolved bool, profileID int64) error
+}
+
+type commentRepository struct {
+	db *sql.DB
+}
+
+// NewCommentRepository membuat instance comment repository baru
+func NewCommentRepository(db *sql.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+const commentColumns = `
+	cmt.cmt_id, cmt.cmt_c_id, cmt.cmt_cs_id, cmt.cmt_cc_id, cmt.cmt_parent_id,
+	cmt.cmt_body, cmt.cmt_mentions, cmt.cmt_is_resolved, cmt.cmt_resolved_by,
+	cmt.cmt_resolved_at, cmt.cmt_created_by, cmt.cmt_created_at,
+	up.up_display_name`
+
+const commentFrom = `
+	FROM atamlink.catalog_comments cmt
+	LEFT JOIN atamlink.user_profiles up ON up.up_id = cmt.cmt_created_by`
+
+// Create membuat comment baru
+func (r *commentRepository) Create(tx *sql.Tx, comment *entity.Comment) error {
+	query := `
+		INSERT INTO atamlink.catalog_comments (
+			cmt_c_id, cmt_cs_id, cmt_cc_id, cmt_parent_id, cmt_body,
+			cmt_mentions, cmt_created_by, cmt_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING cmt_id`
+
+	err := tx.QueryRow(
+		query,
+		comment.CatalogID,
+		comment.SectionID,
+		comment.CardID,
+		comment.ParentID,
+		comment.Body,
+		pq.Array(comment.Mentions),
+		comment.CreatedBy,
+		comment.CreatedAt,
+	).Scan(&comment.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create comment")
+	}
+
+	return nil
+}
+
+// GetByID mendapatkan comment by ID
+func (r *commentRepository) GetByID(id int64) (*entity.Comment, error) {
+	query := `SELECT ` + commentColumns + commentFrom + `
+		WHERE cmt.cmt_id = $1`
+
+	comment, err := scanComment(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgCommentNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get comment")
+	}
+
+	return comment, nil
+}
+
+// ListBySectionID mendapatkan semua comment (thread dan balasan) pada section
+func (r *commentRepository) ListBySectionID(sectionID int64) ([]*entity.Comment, error) {
+	query := `SELECT ` + commentColumns + commentFrom + `
+		WHERE cmt.cmt_cs_id = $1 AND cmt.cmt_cc_id IS NULL
+		ORDER BY cmt.cmt_created_at ASC, cmt.cmt_id ASC`
+
+	return r.queryComments(query, sectionID)
+}
+
+// ListByCardID mendapatkan semua comment (thread dan balasan) pada card
+func (r *commentRepository) ListByCardID(cardID int64) ([]*entity.Comment, error) {
+	query := `SELECT ` + commentColumns + commentFrom + `
+		WHERE cmt.cmt_cc_id = $1
+		ORDER BY cmt.cmt_created_at ASC, cmt.cmt_id ASC`
+
+	return r.queryComments(query, cardID)
+}
+
+// UpdateResolved resolve atau buka kembali thread
+func (r *commentRepository) UpdateResolved(tx *sql.Tx, id int64, resolved bool, profileID int64) error {
+	var resolvedBy sql.NullInt64
+	var resolvedAt *time.Time
+	if resolved {
+		now := time.Now()
+		resolvedBy = sql.NullInt64{Int64: profileID, Valid: true}
+		resolvedAt = &now
+	}
+
+	query := `
+		UPDATE atamlink.catalog_comments SET
+			cmt_is_resolved = $2,
+			cmt_resolved_by = $3,
+			cmt_resolved_at = $4
+		WHERE cmt_id = $1`
+
+	result, err := tx.Exec(query, id, resolved, resolvedBy, resolvedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to update comment")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgCommentNotFound, 404)
+	}
+
+	return nil
+}
+
+func (r *commentRepository) queryComments(query string, args ...interface{}) ([]*entity.Comment, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get comments")
+	}
+	defer rows.Close()
+
+	comments := make([]*entity.Comment, 0)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan comment")
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// rowScanner abstraksi *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanComment scan satu baris comment
+func scanComment(row rowScanner) (*entity.Comment, error) {
+	comment := &entity.Comment{}
+	var mentions pq.Int64Array
+
+	err := row.Scan(
+		&comment.ID,
+		&comment.CatalogID,
+		&comment.SectionID,
+		&comment.CardID,
+		&comment.ParentID,
+		&comment.Body,
+		&mentions,
+		&comment.IsResolved,
+		&comment.ResolvedBy,
+		&comment.ResolvedAt,
+		&comment.CreatedBy,
+		&comment.CreatedAt,
+		&comment.AuthorName,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Mentions = []int64(mentions)
+	return comment, nil
+}
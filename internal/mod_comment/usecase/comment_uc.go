@@ -0,0 +1,381 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogEntity "github.com/atam/atamlink/internal/mod_catalog/entity"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/internal/mod_comment/dto"
+	"github.com/atam/atamlink/internal/mod_comment/entity"
+	"github.com/atam/atamlink/internal/mod_comment/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// CommentUseCase interface untuk comment use case
+type CommentUseCase interface {
+	CreateOnSection(ctx *gin.Context, sectionID, profileID int64, req *dto.CreateCommentRequest) (*dto.CommentResponse, error)
+	CreateOnCard(ctx *gin.Context, cardID, profileID int64, req *dto.CreateCommentRequest) (*dto.CommentResponse, error)
+	ListBySection(ctx *gin.Context, sectionID, profileID int64, includeResolved bool) ([]*dto.CommentResponse, error)
+	ListByCard(ctx *gin.Context, cardID, profileID int64, includeResolved bool) ([]*dto.CommentResponse, error)
+	Resolve(ctx *gin.Context, commentID, profileID int64, req *dto.ResolveCommentRequest) (*dto.CommentResponse, error)
+}
+
+type commentUseCase struct {
+	db                  *sql.DB
+	commentRepo         repository.CommentRepository
+	catalogRepo         catalogRepo.CatalogRepository
+	businessRepo        businessRepo.BusinessRepository
+	notificationService service.NotificationService
+}
+
+// NewCommentUseCase membuat instance comment use case baru
+func NewCommentUseCase(
+	db *sql.DB,
+	commentRepo repository.CommentRepository,
+	catalogRepo catalogRepo.CatalogRepository,
+	businessRepo businessRepo.BusinessRepository,
+	notificationService service.NotificationService,
+) CommentUseCase {
+	return &commentUseCase{
+		db:                  db,
+		commentRepo:         commentRepo,
+		catalogRepo:         catalogRepo,
+		businessRepo:        businessRepo,
+		notificationService: notificationService,
+	}
+}
+
+// CreateOnSection membuat komentar pada section
+func (uc *commentUseCase) CreateOnSection(ctx *gin.Context, sectionID, profileID int64, req *dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entity.Comment{
+		CatalogID: section.CatalogID,
+		SectionID: sql.NullInt64{Int64: section.ID, Valid: true},
+	}
+	return uc.create(ctx, comment, profileID, req)
+}
+
+// CreateOnCard membuat komentar pada card
+func (uc *commentUseCase) CreateOnCard(ctx *gin.Context, cardID, profileID int64, req *dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	card, err := uc.catalogRepo.GetCardByID(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), card.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entity.Comment{
+		CatalogID: section.CatalogID,
+		SectionID: sql.NullInt64{Int64: section.ID, Valid: true},
+		CardID:    sql.NullInt64{Int64: card.ID, Valid: true},
+	}
+	return uc.create(ctx, comment, profileID, req)
+}
+
+// ListBySection daftar thread komentar pada section
+func (uc *commentUseCase) ListBySection(ctx *gin.Context, sectionID, profileID int64, includeResolved bool) ([]*dto.CommentResponse, error) {
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	names, err := uc.memberNames(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := uc.commentRepo.ListBySectionID(sectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toThreads(comments, names, includeResolved), nil
+}
+
+// ListByCard daftar thread komentar pada card
+func (uc *commentUseCase) ListByCard(ctx *gin.Context, cardID, profileID int64, includeResolved bool) ([]*dto.CommentResponse, error) {
+	card, err := uc.catalogRepo.GetCardByID(utils.ContextFrom(ctx), cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.catalogOfSection(utils.ContextFrom(ctx), card.SectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	names, err := uc.memberNames(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := uc.commentRepo.ListByCardID(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toThreads(comments, names, includeResolved), nil
+}
+
+// Resolve resolve atau buka kembali thread (penulis thread atau editor katalog)
+func (uc *commentUseCase) Resolve(ctx *gin.Context, commentID, profileID int64, req *dto.ResolveCommentRequest) (*dto.CommentResponse, error) {
+	comment, err := uc.commentRepo.GetByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, comment)
+	}
+
+	if !comment.IsThread() {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgCommentResolveReply, 400)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), comment.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	permission := constant.PermCatalogUpdate
+	if comment.CreatedBy == profileID {
+		permission = constant.PermCatalogView
+	}
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, permission); err != nil {
+		return nil, err
+	}
+
+	names, err := uc.memberNames(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.commentRepo.UpdateResolved(tx, comment.ID, *req.IsResolved, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	comment, err = uc.commentRepo.GetByID(comment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCommentResponse(comment, names), nil
+}
+
+// create validasi akses, parent dan mention lalu simpan komentar
+func (uc *commentUseCase) create(ctx *gin.Context, comment *entity.Comment, profileID int64, req *dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), comment.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	names, err := uc.memberNames(utils.ContextFrom(ctx), catalog.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Balasan harus ke thread pada target yang sama (tidak ada balasan bertingkat)
+	if req.ParentID != nil {
+		parent, err := uc.commentRepo.GetByID(*req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if !parent.IsThread() || parent.SectionID != comment.SectionID || parent.CardID != comment.CardID {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgCommentParentInvalid, 400)
+		}
+		comment.ParentID = sql.NullInt64{Int64: parent.ID, Valid: true}
+	}
+
+	mentions := make([]int64, 0, len(req.Mentions))
+	seen := make(map[int64]bool)
+	for _, mentionID := range req.Mentions {
+		if _, ok := names[mentionID]; !ok {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgCommentMentionInvalid, 400)
+		}
+		if seen[mentionID] {
+			continue
+		}
+		seen[mentionID] = true
+		mentions = append(mentions, mentionID)
+	}
+
+	comment.Body = req.Body
+	comment.Mentions = mentions
+	comment.CreatedBy = profileID
+	comment.CreatedAt = time.Now()
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.commentRepo.Create(tx, comment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	comment.AuthorName = sql.NullString{String: names[profileID], Valid: true}
+	uc.notifyMentions(middleware.GetRequestID(ctx), catalog, comment, names)
+
+	return toCommentResponse(comment, names), nil
+}
+
+// notifyMentions kirim notifikasi mention lewat channel notifikasi business
+func (uc *commentUseCase) notifyMentions(requestID string, catalog *catalogEntity.Catalog, comment *entity.Comment, names map[int64]string) {
+	if len(comment.Mentions) == 0 {
+		return
+	}
+
+	mentioned := make([]string, 0, len(comment.Mentions))
+	for _, mentionID := range comment.Mentions {
+		mentioned = append(mentioned, names[mentionID])
+	}
+
+	uc.notificationService.Notify(&service.Notification{
+		BusinessID: catalog.BusinessID,
+		Event:      constant.NotificationEventCommentMention,
+		RequestID:  requestID,
+		Comment: &service.CommentNotification{
+			CatalogTitle:   catalog.Title,
+			AuthorName:     names[comment.CreatedBy],
+			MentionedNames: mentioned,
+			Body:           comment.Body,
+		},
+	})
+}
+
+func (uc *commentUseCase) catalogOfSection(ctx context.Context, sectionID int64) (*catalogEntity.Catalog, error) {
+	section, err := uc.catalogRepo.GetSectionByID(ctx, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.catalogRepo.GetByID(ctx, section.CatalogID)
+}
+
+// memberNames display name anggota aktif business untuk author dan mention
+func (uc *commentUseCase) memberNames(ctx context.Context, businessID int64) (map[int64]string, error) {
+	users, err := uc.businessRepo.GetUsersByBusinessID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(users))
+	for _, member := range users {
+		names[member.ProfileID] = member.Profile.GetDisplayName()
+	}
+	return names, nil
+}
+
+// toThreads susun komentar jadi thread dengan replies, thread resolved disembunyikan kecuali diminta
+func toThreads(comments []*entity.Comment, names map[int64]string, includeResolved bool) []*dto.CommentResponse {
+	threads := make([]*dto.CommentResponse, 0)
+	byID := make(map[int64]*dto.CommentResponse)
+
+	for _, comment := range comments {
+		if !comment.IsThread() {
+			continue
+		}
+		if comment.IsResolved && !includeResolved {
+			continue
+		}
+		resp := toCommentResponse(comment, names)
+		byID[comment.ID] = resp
+		threads = append(threads, resp)
+	}
+
+	for _, comment := range comments {
+		if comment.IsThread() {
+			continue
+		}
+		if thread, ok := byID[comment.ParentID.Int64]; ok {
+			thread.Replies = append(thread.Replies, toCommentResponse(comment, names))
+		}
+	}
+
+	return threads
+}
+
+func toCommentResponse(comment *entity.Comment, names map[int64]string) *dto.CommentResponse {
+	resp := &dto.CommentResponse{
+		ID:        comment.ID,
+		CatalogID: comment.CatalogID,
+		Body:      comment.Body,
+		Author: dto.CommentAuthorResponse{
+			ProfileID:   comment.CreatedBy,
+			DisplayName: comment.AuthorName.String,
+		},
+		IsResolved: comment.IsResolved,
+		ResolvedAt: comment.ResolvedAt,
+		CreatedAt:  comment.CreatedAt,
+	}
+
+	if comment.SectionID.Valid {
+		resp.SectionID = &comment.SectionID.Int64
+	}
+	if comment.CardID.Valid {
+		resp.CardID = &comment.CardID.Int64
+	}
+	if comment.ParentID.Valid {
+		resp.ParentID = &comment.ParentID.Int64
+	}
+	if comment.ResolvedBy.Valid {
+		resp.ResolvedBy = &comment.ResolvedBy.Int64
+	}
+
+	for _, mentionID := range comment.Mentions {
+		resp.Mentions = append(resp.Mentions, dto.CommentAuthorResponse{
+			ProfileID:   mentionID,
+			DisplayName: names[mentionID],
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,39 @@
+package dto
+
+import "time"
+
+// CreateInquiryRequest request inquiry publik dari pengunjung, minimal email atau
+// nomor telepon harus diisi agar pemilik bisa membalas
+type CreateInquiryRequest struct {
+	CardID  int64  `json:"card_id,omitempty" validate:"omitempty,min=1"`
+	Name    string `json:"name" validate:"required,max=100"`
+	Email   string `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Phone   string `json:"phone,omitempty" validate:"omitempty,max=30"`
+	Message string `json:"message" validate:"required,max=2000"`
+	Website string `json:"website,omitempty"` // honeypot, disembunyikan dari pengunjung dan harus kosong
+	Token   string `json:"token,omitempty"`   // analytics_token dari response katalog publik
+}
+
+// InquiryFilter filter daftar inquiry untuk pemilik
+type InquiryFilter struct {
+	Status string
+	CardID int64
+}
+
+// InquiryResponse inquiry lengkap untuk pemilik katalog
+type InquiryResponse struct {
+	ID         int64      `json:"id"`
+	CatalogID  int64      `json:"catalog_id"`
+	CardID     *int64     `json:"card_id,omitempty"`
+	CardTitle  string     `json:"card_title,omitempty"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email,omitempty"`
+	Phone      string     `json:"phone,omitempty"`
+	Message    string     `json:"message"`
+	Status     string     `json:"status"`
+	ReadBy     *int64     `json:"read_by,omitempty"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	ArchivedBy *int64     `json:"archived_by,omitempty"`
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
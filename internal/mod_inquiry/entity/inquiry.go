@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Inquiry entity untuk tabel catalog_inquiries
+type Inquiry struct {
+	ID          int64          `json:"id" db:"ci_id"`
+	CatalogID   int64          `json:"catalog_id" db:"ci_c_id"`
+	CardID      sql.NullInt64  `json:"card_id" db:"ci_cc_id"`
+	Name        string         `json:"name" db:"ci_name"`
+	Email       sql.NullString `json:"email" db:"ci_email"`
+	Phone       sql.NullString `json:"phone" db:"ci_phone"`
+	Message     string         `json:"message" db:"ci_message"`
+	Status      string         `json:"status" db:"ci_status"`
+	VisitorHash string         `json:"-" db:"ci_visitor_hash"`
+	ReadBy      sql.NullInt64  `json:"read_by" db:"ci_read_by"`
+	ReadAt      *time.Time     `json:"read_at" db:"ci_read_at"`
+	ArchivedBy  sql.NullInt64  `json:"archived_by" db:"ci_archived_by"`
+	ArchivedAt  *time.Time     `json:"archived_at" db:"ci_archived_at"`
+	CreatedAt   time.Time      `json:"created_at" db:"ci_created_at"`
+
+	// Relations
+	CardTitle sql.NullString `json:"card_title,omitempty"`
+}
+
+// TableName mendapatkan nama tabel
+func (Inquiry) TableName() string { return "atamlink.catalog_inquiries" }
+
+// IsArchived check apakah inquiry sudah diarsipkan
+func (i *Inquiry) IsArchived() bool {
+	return i.Status == "archived"
+}
+
+// PublicCatalog status katalog untuk validasi inquiry publik
+type PublicCatalog struct {
+	ID               int64
+	BusinessID       int64
+	Slug             string
+	Title            string
+	IsActive         bool
+	Status           string
+	IsArchived       bool
+	BusinessIsActive bool
+}
+
+// AcceptsInquiry hanya katalog yang tampil publik yang bisa menerima inquiry
+func (c *PublicCatalog) AcceptsInquiry() bool {
+	return c.IsActive && c.Status == "published" && !c.IsArchived && c.BusinessIsActive
+}
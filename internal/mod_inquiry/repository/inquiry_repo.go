@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_inquiry/entity"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// InquiryRepository interface untuk inquiry repository
+type InquiryRepository interface {
+	GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error)
+	GetVisibleCardTitle(catalogID, cardID int64) (string, error)
+	Create(tx *sql.Tx, inquiry *entity.Inquiry) error
+	GetByID(id int64) (*entity.Inquiry, error)
+	List(filter ListFilter) ([]*entity.Inquiry, int64, error)
+	CountByVisitorSince(visitorHash string, since time.Time) (int, error)
+	MarkRead(tx *sql.Tx, id int64, profileID int64, at time.Time) error
+	Archive(tx *sql.Tx, id int64, profileID int64, at time.Time) error
+}
+
+// ListFilter filter untuk list inquiry, Status kosong = semua yang belum diarsipkan
+type ListFilter struct {
+	CatalogID int64
+	CardID    int64
+	Status    string
+	Limit     int
+	Offset    int
+	OrderBy   string
+}
+
+type inquiryRepository struct {
+	db *sql.DB
+}
+
+// NewInquiryRepository membuat instance inquiry repository baru
+func NewInquiryRepository(db *sql.DB) InquiryRepository {
+	return &inquiryRepository{db: db}
+}
+
+var inquiryColumns = []string{
+	"ci.ci_id", "ci.ci_c_id", "ci.ci_cc_id", "ci.ci_name", "ci.ci_email",
+	"ci.ci_phone", "ci.ci_message", "ci.ci_status", "ci.ci_visitor_hash",
+	"ci.ci_read_by", "ci.ci_read_at", "ci.ci_archived_by", "ci.ci_archived_at",
+	"ci.ci_created_at", "cc.cc_title",
+}
+
+// GetPublicCatalogBySlug status katalog dan business untuk validasi inquiry publik
+func (r *inquiryRepository) GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error) {
+	query := `
+		SELECT c.c_id, c.c_b_id, c.c_slug, c.c_title, c.c_is_active, c.c_status,
+			c.c_archived_at IS NOT NULL, b.b_is_active
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_slug = $1`
+
+	catalog := &entity.PublicCatalog{}
+	err := r.db.QueryRow(query, slug).Scan(
+		&catalog.ID,
+		&catalog.BusinessID,
+		&catalog.Slug,
+		&catalog.Title,
+		&catalog.IsActive,
+		&catalog.Status,
+		&catalog.IsArchived,
+		&catalog.BusinessIsActive,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get catalog by slug")
+	}
+
+	return catalog, nil
+}
+
+// GetVisibleCardTitle judul card yang tampil di katalog, error jika card bukan milik katalog
+func (r *inquiryRepository) GetVisibleCardTitle(catalogID, cardID int64) (string, error) {
+	query := `
+		SELECT cc.cc_title
+		FROM atamlink.catalog_cards cc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		WHERE cc.cc_id = $1 AND cs.cs_c_id = $2 AND cc.cc_is_visible = true`
+
+	var title string
+	err := r.db.QueryRow(query, cardID, catalogID).Scan(&title)
+	if err == sql.ErrNoRows {
+		return "", errors.New(errors.ErrValidation, constant.ErrMsgInquiryCardInvalid, 400)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get inquiry card")
+	}
+
+	return title, nil
+}
+
+// Create membuat inquiry baru
+func (r *inquiryRepository) Create(tx *sql.Tx, inquiry *entity.Inquiry) error {
+	query := `
+		INSERT INTO atamlink.catalog_inquiries (
+			ci_c_id, ci_cc_id, ci_name, ci_email, ci_phone, ci_message,
+			ci_status, ci_visitor_hash, ci_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ci_id`
+
+	err := tx.QueryRow(
+		query,
+		inquiry.CatalogID,
+		inquiry.CardID,
+		inquiry.Name,
+		inquiry.Email,
+		inquiry.Phone,
+		inquiry.Message,
+		inquiry.Status,
+		inquiry.VisitorHash,
+		inquiry.CreatedAt,
+	).Scan(&inquiry.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create inquiry")
+	}
+
+	return nil
+}
+
+// GetByID mendapatkan inquiry by ID
+func (r *inquiryRepository) GetByID(id int64) (*entity.Inquiry, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(inquiryColumns...).From("atamlink.catalog_inquiries ci")
+	qb.LeftJoin("atamlink.catalog_cards cc", "cc.cc_id = ci.ci_cc_id")
+	qb.Where("ci.ci_id = ?", id)
+
+	query, args := qb.Build()
+	inquiry, err := scanInquiry(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgInquiryNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get inquiry")
+	}
+
+	return inquiry, nil
+}
+
+// List mendapatkan inquiry katalog dengan filter status dan card
+func (r *inquiryRepository) List(filter ListFilter) ([]*entity.Inquiry, int64, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(inquiryColumns...).From("atamlink.catalog_inquiries ci")
+	qb.LeftJoin("atamlink.catalog_cards cc", "cc.cc_id = ci.ci_cc_id")
+	qb.Where("ci.ci_c_id = ?", filter.CatalogID)
+
+	if filter.Status != "" {
+		qb.Where("ci.ci_status = ?", filter.Status)
+	} else {
+		qb.Where("ci.ci_status <> ?", constant.InquiryStatusArchived)
+	}
+	if filter.CardID > 0 {
+		qb.Where("ci.ci_cc_id = ?", filter.CardID)
+	}
+
+	// Count total
+	countQuery, countArgs := qb.BuildCount()
+	var total int64
+	if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count inquiries")
+	}
+
+	qb.OrderBy(filter.OrderBy + ", ci.ci_id DESC")
+	qb.Limit(filter.Limit)
+	qb.Offset(filter.Offset)
+
+	query, args := qb.Build()
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to query inquiries")
+	}
+	defer rows.Close()
+
+	inquiries := make([]*entity.Inquiry, 0)
+	for rows.Next() {
+		inquiry, err := scanInquiry(rows)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to scan inquiry")
+		}
+		inquiries = append(inquiries, inquiry)
+	}
+
+	return inquiries, total, rows.Err()
+}
+
+// CountByVisitorSince jumlah inquiry pengunjung (semua katalog) sejak waktu tertentu
+func (r *inquiryRepository) CountByVisitorSince(visitorHash string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM atamlink.catalog_inquiries
+		WHERE ci_visitor_hash = $1 AND ci_created_at >= $2`
+
+	var count int
+	if err := r.db.QueryRow(query, visitorHash, since).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count visitor inquiries")
+	}
+
+	return count, nil
+}
+
+// MarkRead tandai inquiry baru sebagai sudah dibaca, inquiry yang sudah dibaca
+// atau diarsipkan tidak berubah
+func (r *inquiryRepository) MarkRead(tx *sql.Tx, id int64, profileID int64, at time.Time) error {
+	query := `
+		UPDATE atamlink.catalog_inquiries SET
+			ci_status = $2,
+			ci_read_by = $3,
+			ci_read_at = $4
+		WHERE ci_id = $1 AND ci_status = $5`
+
+	if _, err := tx.Exec(query, id, constant.InquiryStatusRead, profileID, at, constant.InquiryStatusNew); err != nil {
+		return errors.Wrap(err, "failed to mark inquiry read")
+	}
+
+	return nil
+}
+
+// Archive arsipkan inquiry, waktu baca diisi jika belum pernah dibaca
+func (r *inquiryRepository) Archive(tx *sql.Tx, id int64, profileID int64, at time.Time) error {
+	query := `
+		UPDATE atamlink.catalog_inquiries SET
+			ci_status = $2,
+			ci_read_by = COALESCE(ci_read_by, $3),
+			ci_read_at = COALESCE(ci_read_at, $4),
+			ci_archived_by = $3,
+			ci_archived_at = $4
+		WHERE ci_id = $1`
+
+	result, err := tx.Exec(query, id, constant.InquiryStatusArchived, profileID, at)
+	if err != nil {
+		return errors.Wrap(err, "failed to archive inquiry")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgInquiryNotFound, 404)
+	}
+
+	return nil
+}
+
+// rowScanner abstraksi *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanInquiry scan satu baris inquiry
+func scanInquiry(row rowScanner) (*entity.Inquiry, error) {
+	inquiry := &entity.Inquiry{}
+	err := row.Scan(
+		&inquiry.ID,
+		&inquiry.CatalogID,
+		&inquiry.CardID,
+		&inquiry.Name,
+		&inquiry.Email,
+		&inquiry.Phone,
+		&inquiry.Message,
+		&inquiry.Status,
+		&inquiry.VisitorHash,
+		&inquiry.ReadBy,
+		&inquiry.ReadAt,
+		&inquiry.ArchivedBy,
+		&inquiry.ArchivedAt,
+		&inquiry.CreatedAt,
+		&inquiry.CardTitle,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return inquiry, nil
+}
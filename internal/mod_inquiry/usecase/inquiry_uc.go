@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/internal/mod_inquiry/dto"
+	"github.com/atam/atamlink/internal/mod_inquiry/entity"
+	"github.com/atam/atamlink/internal/mod_inquiry/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// InquiryUseCase interface untuk inquiry use case
+type InquiryUseCase interface {
+	Submit(ctx *gin.Context, slug string, visitor *service.VisitorInfo, req *dto.CreateInquiryRequest) error
+	List(ctx *gin.Context, catalogID, profileID int64, filter *dto.InquiryFilter, page, perPage int, orderBy string) ([]*dto.InquiryResponse, int64, error)
+	GetByID(ctx *gin.Context, inquiryID, profileID int64) (*dto.InquiryResponse, error)
+	MarkRead(ctx *gin.Context, inquiryID, profileID int64) (*dto.InquiryResponse, error)
+	Archive(ctx *gin.Context, inquiryID, profileID int64) (*dto.InquiryResponse, error)
+}
+
+type inquiryUseCase struct {
+	db                  *sql.DB
+	inquiryRepo         repository.InquiryRepository
+	catalogRepo         catalogRepo.CatalogRepository
+	businessRepo        businessRepo.BusinessRepository
+	botFilter           service.BotFilter
+	notificationService service.NotificationService
+	config              config.InquiryConfig
+	clock               service.Clock
+}
+
+// NewInquiryUseCase membuat instance inquiry use case baru
+func NewInquiryUseCase(
+	db *sql.DB,
+	inquiryRepo repository.InquiryRepository,
+	catalogRepo catalogRepo.CatalogRepository,
+	businessRepo businessRepo.BusinessRepository,
+	botFilter service.BotFilter,
+	notificationService service.NotificationService,
+	cfg config.InquiryConfig,
+	clock service.Clock,
+) InquiryUseCase {
+	return &inquiryUseCase{
+		db:                  db,
+		inquiryRepo:         inquiryRepo,
+		catalogRepo:         catalogRepo,
+		businessRepo:        businessRepo,
+		botFilter:           botFilter,
+		notificationService: notificationService,
+		config:              cfg,
+		clock:               clock,
+	}
+}
+
+// Submit simpan inquiry publik lalu kabari pemilik. Request yang mengisi honeypot
+// dianggap berhasil tanpa disimpan agar bot tidak tahu sudah terdeteksi
+func (uc *inquiryUseCase) Submit(ctx *gin.Context, slug string, visitor *service.VisitorInfo, req *dto.CreateInquiryRequest) error {
+	catalog, err := uc.inquiryRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return err
+	}
+	if !catalog.AcceptsInquiry() {
+		return errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	if req.Website != "" {
+		return nil
+	}
+
+	if uc.botFilter.IsBot(visitor, req.Token, catalog.ID) {
+		return errors.New(errors.ErrValidation, constant.ErrMsgInquiryRejected, 400)
+	}
+
+	email := strings.TrimSpace(req.Email)
+	phone := strings.TrimSpace(req.Phone)
+	if email == "" && phone == "" {
+		return errors.New(errors.ErrValidation, constant.ErrMsgInquiryContactRequired, 400)
+	}
+
+	visitorHash := uc.visitorHash(visitor)
+	now := uc.clock.NowFor(catalog.BusinessID)
+
+	// Rate limit per pengunjung lintas katalog
+	if uc.config.RateLimit > 0 {
+		count, err := uc.inquiryRepo.CountByVisitorSince(visitorHash, now.Add(-uc.config.RateWindow))
+		if err != nil {
+			return err
+		}
+		if count >= uc.config.RateLimit {
+			return errors.New(errors.ErrRateLimited, constant.ErrMsgInquiryRateLimited, 429)
+		}
+	}
+
+	inquiry := &entity.Inquiry{
+		CatalogID:   catalog.ID,
+		Name:        strings.TrimSpace(req.Name),
+		Email:       database.NullString(email),
+		Phone:       database.NullString(phone),
+		Message:     strings.TrimSpace(req.Message),
+		Status:      constant.InquiryStatusNew,
+		VisitorHash: visitorHash,
+		CreatedAt:   now,
+	}
+
+	var cardTitle string
+	if req.CardID > 0 {
+		cardTitle, err = uc.inquiryRepo.GetVisibleCardTitle(catalog.ID, req.CardID)
+		if err != nil {
+			return err
+		}
+		inquiry.CardID = sql.NullInt64{Int64: req.CardID, Valid: true}
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.inquiryRepo.Create(tx, inquiry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.notificationService.Notify(&service.Notification{
+		BusinessID: catalog.BusinessID,
+		Event:      constant.NotificationEventNewInquiry,
+		RequestID:  middleware.GetRequestID(ctx),
+		Inquiry: &service.InquiryNotification{
+			CatalogTitle: catalog.Title,
+			CardTitle:    cardTitle,
+			Name:         inquiry.Name,
+			Email:        inquiry.Email.String,
+			Phone:        inquiry.Phone.String,
+			Message:      inquiry.Message,
+		},
+	})
+
+	return nil
+}
+
+// List daftar inquiry katalog untuk pemilik
+func (uc *inquiryUseCase) List(ctx *gin.Context, catalogID, profileID int64, filter *dto.InquiryFilter, page, perPage int, orderBy string) ([]*dto.InquiryResponse, int64, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Status != "" && !constant.IsValidInquiryStatus(filter.Status) {
+		return nil, 0, errors.New(errors.ErrValidation, constant.ErrMsgInquiryStatusInvalid, 400)
+	}
+
+	inquiries, total, err := uc.inquiryRepo.List(repository.ListFilter{
+		CatalogID: catalog.ID,
+		CardID:    filter.CardID,
+		Status:    filter.Status,
+		Limit:     perPage,
+		Offset:    (page - 1) * perPage,
+		OrderBy:   orderBy,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.InquiryResponse, len(inquiries))
+	for i, inquiry := range inquiries {
+		responses[i] = toInquiryResponse(inquiry)
+	}
+
+	return responses, total, nil
+}
+
+// GetByID detail inquiry untuk pemilik
+func (uc *inquiryUseCase) GetByID(ctx *gin.Context, inquiryID, profileID int64) (*dto.InquiryResponse, error) {
+	inquiry, _, err := uc.getWithAccess(ctx, inquiryID, profileID, constant.PermCatalogView)
+	if err != nil {
+		return nil, err
+	}
+
+	return toInquiryResponse(inquiry), nil
+}
+
+// MarkRead tandai inquiry sebagai sudah dibaca
+func (uc *inquiryUseCase) MarkRead(ctx *gin.Context, inquiryID, profileID int64) (*dto.InquiryResponse, error) {
+	inquiry, businessID, err := uc.getWithAccess(ctx, inquiryID, profileID, constant.PermCatalogView)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.inquiryRepo.MarkRead(tx, inquiry.ID, profileID, uc.clock.NowFor(businessID)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return uc.reload(inquiry.ID)
+}
+
+// Archive arsipkan inquiry, hilang dari daftar default pemilik
+func (uc *inquiryUseCase) Archive(ctx *gin.Context, inquiryID, profileID int64) (*dto.InquiryResponse, error) {
+	inquiry, businessID, err := uc.getWithAccess(ctx, inquiryID, profileID, constant.PermCatalogUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.inquiryRepo.Archive(tx, inquiry.ID, profileID, uc.clock.NowFor(businessID)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return uc.reload(inquiry.ID)
+}
+
+// getWithAccess ambil inquiry dan pastikan user punya izin di business katalognya,
+// return juga ID business katalog
+func (uc *inquiryUseCase) getWithAccess(ctx *gin.Context, inquiryID, profileID int64, permission string) (*entity.Inquiry, int64, error) {
+	inquiry, err := uc.inquiryRepo.GetByID(inquiryID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, inquiry)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), inquiry.CatalogID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, permission); err != nil {
+		return nil, 0, err
+	}
+
+	return inquiry, catalog.BusinessID, nil
+}
+
+func (uc *inquiryUseCase) reload(inquiryID int64) (*dto.InquiryResponse, error) {
+	inquiry, err := uc.inquiryRepo.GetByID(inquiryID)
+	if err != nil {
+		return nil, err
+	}
+	return toInquiryResponse(inquiry), nil
+}
+
+// visitorHash identitas anonim pengunjung untuk rate limit:
+// HMAC-SHA256(secret, IP, user agent), IP tidak pernah disimpan
+func (uc *inquiryUseCase) visitorHash(visitor *service.VisitorInfo) string {
+	mac := hmac.New(sha256.New, []byte(uc.config.HashSecret))
+	mac.Write([]byte(visitor.IP))
+	mac.Write([]byte{0})
+	mac.Write([]byte(visitor.UserAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toInquiryResponse(inquiry *entity.Inquiry) *dto.InquiryResponse {
+	resp := &dto.InquiryResponse{
+		ID:         inquiry.ID,
+		CatalogID:  inquiry.CatalogID,
+		CardTitle:  inquiry.CardTitle.String,
+		Name:       inquiry.Name,
+		Email:      inquiry.Email.String,
+		Phone:      inquiry.Phone.String,
+		Message:    inquiry.Message,
+		Status:     inquiry.Status,
+		ReadAt:     inquiry.ReadAt,
+		ArchivedAt: inquiry.ArchivedAt,
+		CreatedAt:  inquiry.CreatedAt,
+	}
+	if inquiry.CardID.Valid {
+		resp.CardID = &inquiry.CardID.Int64
+	}
+	if inquiry.ReadBy.Valid {
+		resp.ReadBy = &inquiry.ReadBy.Int64
+	}
+	if inquiry.ArchivedBy.Valid {
+		resp.ArchivedBy = &inquiry.ArchivedBy.Int64
+	}
+	return resp
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+)
+
+// CreateIntegrationRequest request untuk menghubungkan akun marketplace
+type CreateIntegrationRequest struct {
+	Provider            string            `json:"provider" validate:"required,oneof=shopee tokopedia"`
+	ShopID              string            `json:"shop_id" validate:"required,max=100"`
+	Credentials         map[string]string `json:"credentials,omitempty"` // contoh: {"access_token": "..."}
+	SectionID           int64             `json:"section_id" validate:"required,gt=0"`
+	ConflictPolicy      string            `json:"conflict_policy,omitempty" validate:"omitempty,oneof=remote_wins local_wins create_only"`
+	SyncIntervalMinutes int               `json:"sync_interval_minutes,omitempty" validate:"omitempty,gte=15,lte=10080"`
+}
+
+// IntegrationResponse response untuk integration
+type IntegrationResponse struct {
+	ID                  int64      `json:"id"`
+	BusinessID          int64      `json:"business_id"`
+	Provider            string     `json:"provider"`
+	ShopID              string     `json:"shop_id"`
+	SectionID           int64      `json:"section_id"`
+	ConflictPolicy      string     `json:"conflict_policy"`
+	SyncIntervalMinutes int        `json:"sync_interval_minutes"`
+	IsActive            bool       `json:"is_active"`
+	LastSyncedAt        *time.Time `json:"last_synced_at,omitempty"`
+	LastSyncStatus      string     `json:"last_sync_status,omitempty"`
+	LastSyncError       string     `json:"last_sync_error,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// SyncResultResponse response hasil sinkronisasi produk
+type SyncResultResponse struct {
+	IntegrationID int64     `json:"integration_id"`
+	Fetched       int       `json:"fetched"`
+	Created       int       `json:"created"`
+	Updated       int       `json:"updated"`
+	Skipped       int       `json:"skipped"`
+	Conflicts     int       `json:"conflicts"`
+	LimitReached  int       `json:"limit_reached"` // produk baru yang tidak dibuat karena batas plan
+	SyncedAt      time.Time `json:"synced_at"`
+}
+
+// ProductMappingResponse response untuk mapping produk ke card
+type ProductMappingResponse struct {
+	ID         int64     `json:"id"`
+	ExternalID string    `json:"external_id"`
+	CardID     int64     `json:"card_id"`
+	SyncedAt   time.Time `json:"synced_at"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Integration entity untuk tabel business_integrations
+type Integration struct {
+	ID                  int64          `json:"id" db:"bint_id"`
+	BusinessID          int64          `json:"business_id" db:"bint_b_id"`
+	Provider            string         `json:"provider" db:"bint_provider"`
+	ShopID              string         `json:"shop_id" db:"bint_shop_id"`
+	Credentials         string         `json:"-" db:"bint_credentials"` // sealed oleh vault service
+	SectionID           int64          `json:"section_id" db:"bint_cs_id"`
+	ConflictPolicy      string         `json:"conflict_policy" db:"bint_conflict_policy"`
+	SyncIntervalMinutes int            `json:"sync_interval_minutes" db:"bint_sync_interval_minutes"`
+	IsActive            bool           `json:"is_active" db:"bint_is_active"`
+	LastSyncedAt        *time.Time     `json:"last_synced_at" db:"bint_last_synced_at"`
+	LastSyncStatus      sql.NullString `json:"last_sync_status" db:"bint_last_sync_status"`
+	LastSyncError       sql.NullString `json:"last_sync_error" db:"bint_last_sync_error"`
+	CreatedBy           int64          `json:"created_by" db:"bint_created_by"`
+	CreatedAt           time.Time      `json:"created_at" db:"bint_created_at"`
+	UpdatedAt           *time.Time     `json:"updated_at" db:"bint_updated_at"`
+}
+
+// ProductMapping entity untuk tabel integration_product_mappings
+type ProductMapping struct {
+	ID            int64     `json:"id" db:"ipm_id"`
+	IntegrationID int64     `json:"integration_id" db:"ipm_bint_id"`
+	ExternalID    string    `json:"external_id" db:"ipm_external_id"`
+	CardID        int64     `json:"card_id" db:"ipm_cc_id"`
+	Checksum      string    `json:"checksum" db:"ipm_checksum"`
+	SyncedAt      time.Time `json:"synced_at" db:"ipm_synced_at"`
+}
+
+// TableName methods
+func (Integration) TableName() string    { return "atamlink.business_integrations" }
+func (ProductMapping) TableName() string { return "atamlink.integration_product_mappings" }
+
+// IsDue check apakah integration sudah waktunya disinkronkan
+func (i *Integration) IsDue(now time.Time) bool {
+	if !i.IsActive {
+		return false
+	}
+	if i.LastSyncedAt == nil {
+		return true
+	}
+	return now.Sub(*i.LastSyncedAt) >= time.Duration(i.SyncIntervalMinutes)*time.Minute
+}
@@ -0,0 +1,351 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_integration/entity"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// IntegrationRepository interface untuk integration repository
+type IntegrationRepository interface {
+	// Integration methods
+	Create(tx *sql.Tx, integration *entity.Integration) error
+	GetByID(id int64) (*entity.Integration, error)
+	ListByBusinessID(businessID int64) ([]*entity.Integration, error)
+	ListActive() ([]*entity.Integration, error)
+	UpdateSyncState(tx *sql.Tx, id int64, syncedAt time.Time, status, syncError string) error
+	UpdateCredentials(id int64, sealed string) error
+	Delete(tx *sql.Tx, id int64) error
+	IsExists(businessID int64, provider, shopID string) (bool, error)
+
+	// Mapping methods
+	GetMapping(integrationID int64, externalID string) (*entity.ProductMapping, error)
+	CreateMapping(tx *sql.Tx, mapping *entity.ProductMapping) error
+	UpdateMapping(tx *sql.Tx, mapping *entity.ProductMapping) error
+	ListMappings(integrationID int64) ([]*entity.ProductMapping, error)
+}
+
+type integrationRepository struct {
+	db *sql.DB
+}
+
+// NewIntegrationRepository membuat instance integration repository baru
+func NewIntegrationRepository(db *sql.DB) IntegrationRepository {
+	return &integrationRepository{db: db}
+}
+
+const integrationColumns = `
+	bint_id, bint_b_id, bint_provider, bint_shop_id, bint_credentials,
+	bint_cs_id, bint_conflict_policy, bint_sync_interval_minutes, bint_is_active,
+	bint_last_synced_at, bint_last_sync_status, bint_last_sync_error,
+	bint_created_by, bint_created_at, bint_updated_at`
+
+// Create membuat integration baru
+func (r *integrationRepository) Create(tx *sql.Tx, integration *entity.Integration) error {
+	query := `
+		INSERT INTO atamlink.business_integrations (
+			bint_b_id, bint_provider, bint_shop_id, bint_credentials,
+			bint_cs_id, bint_conflict_policy, bint_sync_interval_minutes,
+			bint_is_active, bint_created_by, bint_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING bint_id`
+
+	err := tx.QueryRow(
+		query,
+		integration.BusinessID,
+		integration.Provider,
+		integration.ShopID,
+		integration.Credentials,
+		integration.SectionID,
+		integration.ConflictPolicy,
+		integration.SyncIntervalMinutes,
+		integration.IsActive,
+		integration.CreatedBy,
+		integration.CreatedAt,
+	).Scan(&integration.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create integration")
+	}
+
+	return nil
+}
+
+// GetByID mendapatkan integration by ID
+func (r *integrationRepository) GetByID(id int64) (*entity.Integration, error) {
+	query := `SELECT ` + integrationColumns + `
+		FROM atamlink.business_integrations
+		WHERE bint_id = $1`
+
+	integration, err := scanIntegration(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgIntegrationNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get integration")
+	}
+
+	return integration, nil
+}
+
+// ListByBusinessID mendapatkan semua integration milik business
+func (r *integrationRepository) ListByBusinessID(businessID int64) ([]*entity.Integration, error) {
+	query := `SELECT ` + integrationColumns + `
+		FROM atamlink.business_integrations
+		WHERE bint_b_id = $1
+		ORDER BY bint_id ASC`
+
+	return r.queryIntegrations(query, businessID)
+}
+
+// ListActive mendapatkan semua integration aktif untuk scheduler
+func (r *integrationRepository) ListActive() ([]*entity.Integration, error) {
+	query := `SELECT ` + integrationColumns + `
+		FROM atamlink.business_integrations
+		WHERE bint_is_active = true
+		ORDER BY bint_last_synced_at ASC NULLS FIRST`
+
+	return r.queryIntegrations(query)
+}
+
+// UpdateSyncState update hasil sinkronisasi terakhir
+func (r *integrationRepository) UpdateSyncState(tx *sql.Tx, id int64, syncedAt time.Time, status, syncError string) error {
+	query := `
+		UPDATE atamlink.business_integrations SET
+			bint_last_synced_at = $2,
+			bint_last_sync_status = $3,
+			bint_last_sync_error = $4,
+			bint_updated_at = $5
+		WHERE bint_id = $1`
+
+	var errValue sql.NullString
+	if syncError != "" {
+		errValue = sql.NullString{String: syncError, Valid: true}
+	}
+
+	result, err := tx.Exec(query, id, syncedAt, status, errValue, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update sync state")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgIntegrationNotFound, 404)
+	}
+
+	return nil
+}
+
+// UpdateCredentials simpan kredensial yang sudah di-seal
+func (r *integrationRepository) UpdateCredentials(id int64, sealed string) error {
+	query := `
+		UPDATE atamlink.business_integrations SET
+			bint_credentials = $2,
+			bint_updated_at = $3
+		WHERE bint_id = $1`
+
+	if _, err := r.db.Exec(query, id, sealed, time.Now()); err != nil {
+		return errors.Wrap(err, "failed to update integration credentials")
+	}
+
+	return nil
+}
+
+// Delete hapus integration beserta mapping-nya
+func (r *integrationRepository) Delete(tx *sql.Tx, id int64) error {
+	query := `DELETE FROM atamlink.business_integrations WHERE bint_id = $1`
+
+	result, err := tx.Exec(query, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete integration")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgIntegrationNotFound, 404)
+	}
+
+	return nil
+}
+
+// IsExists check apakah toko marketplace sudah terhubung ke business
+func (r *integrationRepository) IsExists(businessID int64, provider, shopID string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM atamlink.business_integrations
+			WHERE bint_b_id = $1 AND bint_provider = $2 AND bint_shop_id = $3
+		)`
+
+	var exists bool
+	err := r.db.QueryRow(query, businessID, provider, shopID).Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check integration exists")
+	}
+
+	return exists, nil
+}
+
+// GetMapping mendapatkan mapping produk, nil jika belum pernah diimport
+func (r *integrationRepository) GetMapping(integrationID int64, externalID string) (*entity.ProductMapping, error) {
+	query := `
+		SELECT ipm_id, ipm_bint_id, ipm_external_id, ipm_cc_id, ipm_checksum, ipm_synced_at
+		FROM atamlink.integration_product_mappings
+		WHERE ipm_bint_id = $1 AND ipm_external_id = $2`
+
+	mapping := &entity.ProductMapping{}
+	err := r.db.QueryRow(query, integrationID, externalID).Scan(
+		&mapping.ID,
+		&mapping.IntegrationID,
+		&mapping.ExternalID,
+		&mapping.CardID,
+		&mapping.Checksum,
+		&mapping.SyncedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get product mapping")
+	}
+
+	return mapping, nil
+}
+
+// CreateMapping membuat mapping produk baru
+func (r *integrationRepository) CreateMapping(tx *sql.Tx, mapping *entity.ProductMapping) error {
+	query := `
+		INSERT INTO atamlink.integration_product_mappings (
+			ipm_bint_id, ipm_external_id, ipm_cc_id, ipm_checksum, ipm_synced_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING ipm_id`
+
+	err := tx.QueryRow(
+		query,
+		mapping.IntegrationID,
+		mapping.ExternalID,
+		mapping.CardID,
+		mapping.Checksum,
+		mapping.SyncedAt,
+	).Scan(&mapping.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create product mapping")
+	}
+
+	return nil
+}
+
+// UpdateMapping update checksum dan waktu sync mapping
+func (r *integrationRepository) UpdateMapping(tx *sql.Tx, mapping *entity.ProductMapping) error {
+	query := `
+		UPDATE atamlink.integration_product_mappings SET
+			ipm_checksum = $2,
+			ipm_synced_at = $3
+		WHERE ipm_id = $1`
+
+	_, err := tx.Exec(query, mapping.ID, mapping.Checksum, mapping.SyncedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to update product mapping")
+	}
+
+	return nil
+}
+
+// ListMappings mendapatkan semua mapping produk untuk integration
+func (r *integrationRepository) ListMappings(integrationID int64) ([]*entity.ProductMapping, error) {
+	query := `
+		SELECT ipm_id, ipm_bint_id, ipm_external_id, ipm_cc_id, ipm_checksum, ipm_synced_at
+		FROM atamlink.integration_product_mappings
+		WHERE ipm_bint_id = $1
+		ORDER BY ipm_id ASC`
+
+	rows, err := r.db.Query(query, integrationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get product mappings")
+	}
+	defer rows.Close()
+
+	mappings := make([]*entity.ProductMapping, 0)
+	for rows.Next() {
+		mapping := &entity.ProductMapping{}
+		err := rows.Scan(
+			&mapping.ID,
+			&mapping.IntegrationID,
+			&mapping.ExternalID,
+			&mapping.CardID,
+			&mapping.Checksum,
+			&mapping.SyncedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan product mapping")
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings, nil
+}
+
+// queryIntegrations helper untuk query list integration
+func (r *integrationRepository) queryIntegrations(query string, args ...interface{}) ([]*entity.Integration, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get integrations")
+	}
+	defer rows.Close()
+
+	integrations := make([]*entity.Integration, 0)
+	for rows.Next() {
+		integration, err := scanIntegration(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan integration")
+		}
+		integrations = append(integrations, integration)
+	}
+
+	return integrations, nil
+}
+
+// rowScanner abstraksi *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanIntegration scan satu baris integration
+func scanIntegration(row rowScanner) (*entity.Integration, error) {
+	integration := &entity.Integration{}
+
+	err := row.Scan(
+		&integration.ID,
+		&integration.BusinessID,
+		&integration.Provider,
+		&integration.ShopID,
+		&integration.Credentials,
+		&integration.SectionID,
+		&integration.ConflictPolicy,
+		&integration.SyncIntervalMinutes,
+		&integration.IsActive,
+		&integration.LastSyncedAt,
+		&integration.LastSyncStatus,
+		&integration.LastSyncError,
+		&integration.CreatedBy,
+		&integration.CreatedAt,
+		&integration.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
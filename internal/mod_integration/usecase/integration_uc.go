@@ -0,0 +1,587 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogEntity "github.com/atam/atamlink/internal/mod_catalog/entity"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/internal/mod_integration/dto"
+	"github.com/atam/atamlink/internal/mod_integration/entity"
+	"github.com/atam/atamlink/internal/mod_integration/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+const (
+	defaultSyncIntervalMinutes = 360
+	maxImportedTitleLength     = 200
+)
+
+// IntegrationUseCase interface untuk integration use case
+type IntegrationUseCase interface {
+	Connect(ctx *gin.Context, businessID, profileID int64, req *dto.CreateIntegrationRequest) (*dto.IntegrationResponse, error)
+	List(ctx *gin.Context, businessID, profileID int64) ([]*dto.IntegrationResponse, error)
+	Disconnect(ctx *gin.Context, integrationID, profileID int64) error
+	Sync(ctx *gin.Context, integrationID, profileID int64) (*dto.SyncResultResponse, error)
+	SyncDue(ctx context.Context) error
+	ListMappings(ctx *gin.Context, integrationID, profileID int64) ([]*dto.ProductMappingResponse, error)
+}
+
+type integrationUseCase struct {
+	db                 *sql.DB
+	integrationRepo    repository.IntegrationRepository
+	catalogRepo        catalogRepo.CatalogRepository
+	businessRepo       businessRepo.BusinessRepository
+	marketplaceService service.MarketplaceService
+	vaultService       service.VaultService
+	planEnforcement    service.PlanEnforcementService
+}
+
+// NewIntegrationUseCase membuat instance integration use case baru
+func NewIntegrationUseCase(
+	db *sql.DB,
+	integrationRepo repository.IntegrationRepository,
+	catalogRepo catalogRepo.CatalogRepository,
+	businessRepo businessRepo.BusinessRepository,
+	marketplaceService service.MarketplaceService,
+	vaultService service.VaultService,
+	planEnforcement service.PlanEnforcementService,
+) IntegrationUseCase {
+	return &integrationUseCase{
+		db:                 db,
+		integrationRepo:    integrationRepo,
+		catalogRepo:        catalogRepo,
+		businessRepo:       businessRepo,
+		marketplaceService: marketplaceService,
+		vaultService:       vaultService,
+		planEnforcement:    planEnforcement,
+	}
+}
+
+// Connect hubungkan akun marketplace ke business
+func (uc *integrationUseCase) Connect(ctx *gin.Context, businessID, profileID int64, req *dto.CreateIntegrationRequest) (*dto.IntegrationResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	if !constant.IsValidMarketplace(req.Provider) {
+		return nil, errors.New(errors.ErrBadRequest, constant.ErrMsgMarketplaceInvalid, 400)
+	}
+
+	// Section tujuan harus milik business dan bertipe cards
+	section, err := uc.catalogRepo.GetSectionByID(utils.ContextFrom(ctx), req.SectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section.Type != constant.SectionTypeCards {
+		return nil, errors.New(errors.ErrValidation, "Section bukan tipe cards", 400)
+	}
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), section.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+	// Section business lain diperlakukan sama dengan section yang tidak ada
+	if catalog.BusinessID != businessID {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgSectionNotFound, 404)
+	}
+
+	exists, err := uc.integrationRepo.IsExists(businessID, req.Provider, req.ShopID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgIntegrationExists, 409)
+	}
+
+	// Kredensial disimpan terenkripsi, tidak pernah dikembalikan ke client
+	credentials := req.Credentials
+	if credentials == nil {
+		credentials = make(map[string]string)
+	}
+	sealed, err := uc.vaultService.Seal(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	integration := &entity.Integration{
+		BusinessID:          businessID,
+		Provider:            req.Provider,
+		ShopID:              req.ShopID,
+		Credentials:         sealed,
+		SectionID:           req.SectionID,
+		ConflictPolicy:      req.ConflictPolicy,
+		SyncIntervalMinutes: req.SyncIntervalMinutes,
+		IsActive:            true,
+		CreatedBy:           profileID,
+		CreatedAt:           time.Now(),
+	}
+
+	if integration.ConflictPolicy == "" {
+		integration.ConflictPolicy = constant.SyncConflictRemoteWins
+	}
+	if integration.SyncIntervalMinutes == 0 {
+		integration.SyncIntervalMinutes = defaultSyncIntervalMinutes
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.integrationRepo.Create(tx, integration); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return uc.toIntegrationResponse(integration), nil
+}
+
+// List daftar integration milik business
+func (uc *integrationUseCase) List(ctx *gin.Context, businessID, profileID int64) ([]*dto.IntegrationResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessView); err != nil {
+		return nil, err
+	}
+
+	integrations, err := uc.integrationRepo.ListByBusinessID(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.IntegrationResponse, len(integrations))
+	for i, integration := range integrations {
+		responses[i] = uc.toIntegrationResponse(integration)
+	}
+
+	return responses, nil
+}
+
+// Disconnect putuskan integration, card yang sudah diimport tetap ada
+func (uc *integrationUseCase) Disconnect(ctx *gin.Context, integrationID, profileID int64) error {
+	integration, err := uc.integrationRepo.GetByID(integrationID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, integration.BusinessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return err
+	}
+
+	ctx.Set(middleware.GinKeyAuditOldData, integration)
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.integrationRepo.Delete(tx, integrationID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// Sync sinkronisasi manual oleh user
+func (uc *integrationUseCase) Sync(ctx *gin.Context, integrationID, profileID int64) (*dto.SyncResultResponse, error) {
+	integration, err := uc.integrationRepo.GetByID(integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, integration.BusinessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	return uc.syncIntegration(utils.ContextFrom(ctx), integration, profileID)
+}
+
+// SyncDue sinkronisasi semua integration yang sudah jatuh tempo (dipanggil scheduler)
+func (uc *integrationUseCase) SyncDue(ctx context.Context) error {
+	integrations, err := uc.integrationRepo.ListActive()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var failed []string
+	for _, integration := range integrations {
+		if !integration.IsDue(now) {
+			continue
+		}
+		if _, err := uc.syncIntegration(ctx, integration, integration.CreatedBy); err != nil {
+			failed = append(failed, fmt.Sprintf("%d: %v", integration.ID, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("sync failed for integrations [%s]", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+// ListMappings daftar mapping produk marketplace ke card
+func (uc *integrationUseCase) ListMappings(ctx *gin.Context, integrationID, profileID int64) ([]*dto.ProductMappingResponse, error) {
+	integration, err := uc.integrationRepo.GetByID(integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, integration.BusinessID, profileID, constant.PermBusinessView); err != nil {
+		return nil, err
+	}
+
+	mappings, err := uc.integrationRepo.ListMappings(integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ProductMappingResponse, len(mappings))
+	for i, mapping := range mappings {
+		responses[i] = &dto.ProductMappingResponse{
+			ID:         mapping.ID,
+			ExternalID: mapping.ExternalID,
+			CardID:     mapping.CardID,
+			SyncedAt:   mapping.SyncedAt,
+		}
+	}
+
+	return responses, nil
+}
+
+// Helper methods
+
+// syncIntegration fetch produk marketplace dan import sebagai card
+func (uc *integrationUseCase) syncIntegration(ctx context.Context, integration *entity.Integration, actorID int64) (*dto.SyncResultResponse, error) {
+	syncedAt := time.Now()
+	result := &dto.SyncResultResponse{
+		IntegrationID: integration.ID,
+		SyncedAt:      syncedAt,
+	}
+
+	if err := uc.importProducts(ctx, integration, actorID, syncedAt, result); err != nil {
+		uc.recordSyncState(integration.ID, syncedAt, constant.SyncStatusFailed, err.Error())
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgSyncFailed, 502)
+	}
+
+	if err := uc.recordSyncState(integration.ID, syncedAt, constant.SyncStatusSuccess, ""); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// importProducts proses inti sinkronisasi dengan conflict resolution
+func (uc *integrationUseCase) importProducts(ctx context.Context, integration *entity.Integration, actorID int64, syncedAt time.Time, result *dto.SyncResultResponse) error {
+	connector, err := uc.marketplaceService.Connector(integration.Provider)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := uc.openCredentials(integration)
+	if err != nil {
+		return err
+	}
+
+	products, err := connector.FetchProducts(integration.ShopID, credentials)
+	if err != nil {
+		return err
+	}
+	result.Fetched = len(products)
+
+	// Produk baru hanya dibuat sampai batas produk plan business, sisanya
+	// menunggu sync berikutnya setelah kuota tersedia
+	quota, err := uc.planEnforcement.ProductQuota(ctx, integration.BusinessID)
+	if err != nil {
+		return err
+	}
+	remaining := quota.Remaining()
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	for _, product := range products {
+		checksum := productChecksum(product)
+
+		mapping, err := uc.integrationRepo.GetMapping(integration.ID, product.ExternalID)
+		if err != nil {
+			return err
+		}
+
+		// Produk baru, buat card
+		if mapping == nil {
+			if remaining == 0 {
+				result.LimitReached++
+				continue
+			}
+			if remaining > 0 {
+				remaining--
+			}
+
+			cardID, err := uc.createCard(ctx, tx, integration.SectionID, actorID, product)
+			if err != nil {
+				return err
+			}
+
+			if err := uc.integrationRepo.CreateMapping(tx, &entity.ProductMapping{
+				IntegrationID: integration.ID,
+				ExternalID:    product.ExternalID,
+				CardID:        cardID,
+				Checksum:      checksum,
+				SyncedAt:      syncedAt,
+			}); err != nil {
+				return err
+			}
+
+			result.Created++
+			continue
+		}
+
+		// Tidak ada perubahan di marketplace
+		if mapping.Checksum == checksum || integration.ConflictPolicy == constant.SyncConflictCreateOnly {
+			result.Skipped++
+			continue
+		}
+
+		card, err := uc.catalogRepo.GetCardByID(ctx, mapping.CardID)
+		if err != nil {
+			return err
+		}
+
+		// Card diubah lokal sejak sync terakhir
+		if integration.ConflictPolicy == constant.SyncConflictLocalWins &&
+			card.UpdatedAt != nil && card.UpdatedAt.After(mapping.SyncedAt) {
+			result.Conflicts++
+			continue
+		}
+
+		card.Title = truncateTitle(product.Title)
+		card.Price = database.NullInt64(product.Price)
+		card.URL = database.NullString(product.URL)
+		card.UpdatedBy = sql.NullInt64{Int64: actorID, Valid: true}
+
+		if err := uc.catalogRepo.UpdateCard(ctx, tx, card); err != nil {
+			return err
+		}
+
+		if err := uc.replaceCardImages(ctx, tx, card.ID, actorID, product.ImageURLs); err != nil {
+			return err
+		}
+
+		// Waktu sync disamakan dengan updated_at card yang baru ditulis, supaya
+		// update dari sync sendiri tidak terbaca sebagai perubahan lokal
+		mapping.Checksum = checksum
+		mapping.SyncedAt = syncedAt
+		if card.UpdatedAt != nil {
+			mapping.SyncedAt = *card.UpdatedAt
+		}
+		if err := uc.integrationRepo.UpdateMapping(tx, mapping); err != nil {
+			return err
+		}
+
+		result.Updated++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// createCard buat card produk beserta media dari marketplace
+func (uc *integrationUseCase) createCard(ctx context.Context, tx *sql.Tx, sectionID, actorID int64, product service.ExternalProduct) (int64, error) {
+	now := time.Now()
+	card := &catalogEntity.CatalogCard{
+		SectionID: sectionID,
+		Title:     truncateTitle(product.Title),
+		Type:      constant.CardTypeProduct,
+		URL:       database.NullString(product.URL),
+		IsVisible: true,
+		Price:     database.NullInt64(product.Price),
+		Currency:  constant.CurrencyIDR,
+		CreatedBy: actorID,
+		CreatedAt: now,
+	}
+
+	if err := uc.catalogRepo.CreateCard(ctx, tx, card); err != nil {
+		return 0, err
+	}
+
+	if err := uc.createCardImages(ctx, tx, card.ID, actorID, product.ImageURLs); err != nil {
+		return 0, err
+	}
+
+	return card.ID, nil
+}
+
+// createCardImages simpan gambar produk, gambar pertama sebagai thumbnail, sisanya gallery
+func (uc *integrationUseCase) createCardImages(ctx context.Context, tx *sql.Tx, cardID, actorID int64, imageURLs []string) error {
+	now := time.Now()
+	for i, imageURL := range imageURLs {
+		mediaType := constant.MediaTypeGallery
+		if i == 0 {
+			mediaType = constant.MediaTypeThumbnail
+		}
+
+		if err := uc.catalogRepo.CreateCardMedia(ctx, tx, &catalogEntity.CatalogCardMedia{
+			CardID:    cardID,
+			Type:      mediaType,
+			URL:       imageURL,
+			CreatedBy: actorID,
+			CreatedAt: now,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceCardImages ganti thumbnail dan gallery card dengan gambar terbaru dari
+// marketplace, tidak ada perubahan jika daftar gambar sama
+func (uc *integrationUseCase) replaceCardImages(ctx context.Context, tx *sql.Tx, cardID, actorID int64, imageURLs []string) error {
+	mediaList, err := uc.catalogRepo.GetCardMediaByCardID(ctx, cardID)
+	if err != nil {
+		return err
+	}
+
+	var current []*catalogEntity.CatalogCardMedia
+	for _, media := range mediaList {
+		if media.Type == constant.MediaTypeThumbnail || media.Type == constant.MediaTypeGallery {
+			current = append(current, media)
+		}
+	}
+
+	if sameImages(current, imageURLs) {
+		return nil
+	}
+
+	for _, media := range current {
+		if err := uc.catalogRepo.DeleteCardMedia(ctx, tx, media.ID); err != nil {
+			return err
+		}
+	}
+
+	return uc.createCardImages(ctx, tx, cardID, actorID, imageURLs)
+}
+
+// openCredentials buka kredensial marketplace. Baris lama yang masih JSON polos
+// langsung di-seal ulang
+func (uc *integrationUseCase) openCredentials(integration *entity.Integration) (map[string]string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(integration.Credentials), "{") {
+		return uc.vaultService.Open(integration.Credentials)
+	}
+
+	credentials := make(map[string]string)
+	if err := json.Unmarshal([]byte(integration.Credentials), &credentials); err != nil {
+		return nil, errors.Wrap(err, "failed to parse credentials")
+	}
+
+	sealed, err := uc.vaultService.Seal(credentials)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.integrationRepo.UpdateCredentials(integration.ID, sealed); err != nil {
+		return nil, err
+	}
+	integration.Credentials = sealed
+
+	return credentials, nil
+}
+
+// recordSyncState simpan status sync terakhir
+func (uc *integrationUseCase) recordSyncState(integrationID int64, syncedAt time.Time, status, syncError string) error {
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.integrationRepo.UpdateSyncState(tx, integrationID, syncedAt, status, syncError); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+func (uc *integrationUseCase) toIntegrationResponse(integration *entity.Integration) *dto.IntegrationResponse {
+	resp := &dto.IntegrationResponse{
+		ID:                  integration.ID,
+		BusinessID:          integration.BusinessID,
+		Provider:            integration.Provider,
+		ShopID:              integration.ShopID,
+		SectionID:           integration.SectionID,
+		ConflictPolicy:      integration.ConflictPolicy,
+		SyncIntervalMinutes: integration.SyncIntervalMinutes,
+		IsActive:            integration.IsActive,
+		LastSyncedAt:        integration.LastSyncedAt,
+		CreatedAt:           integration.CreatedAt,
+	}
+
+	if integration.LastSyncStatus.Valid {
+		resp.LastSyncStatus = integration.LastSyncStatus.String
+	}
+	if integration.LastSyncError.Valid {
+		resp.LastSyncError = integration.LastSyncError.String
+	}
+
+	return resp
+}
+
+// productChecksum hash field yang disinkronkan untuk deteksi perubahan
+func productChecksum(product service.ExternalProduct) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%d|%s|%s", product.Title, product.Price, product.URL, strings.Join(product.ImageURLs, ","))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sameImages cek apakah media card sama dengan gambar marketplace (urutan diperhitungkan)
+func sameImages(current []*catalogEntity.CatalogCardMedia, imageURLs []string) bool {
+	if len(current) != len(imageURLs) {
+		return false
+	}
+	for i, media := range current {
+		if media.URL != imageURLs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func truncateTitle(title string) string {
+	runes := []rune(strings.TrimSpace(title))
+	if len(runes) > maxImportedTitleLength {
+		return string(runes[:maxImportedTitleLength])
+	}
+	return string(runes)
+}
@@ -92,6 +92,32 @@ type ThemeListResponse struct {
 	PreviewURL  string    `json:"preview_url,omitempty"`
 }
 
+// CreateCategoryRequest request untuk create category
+type CreateCategoryRequest struct {
+	Slug        string `json:"slug" validate:"required,slug,min=2,max=100"`
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description,omitempty" validate:"max=500"`
+	IsActive    bool   `json:"is_active"`
+}
+
+// UpdateCategoryRequest request untuk update category, slug tidak dapat diubah
+// karena dipakai sebagai filter URL direktori
+type UpdateCategoryRequest struct {
+	Name        string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Description string `json:"description,omitempty" validate:"max=500"`
+	IsActive    *bool  `json:"is_active,omitempty"`
+}
+
+// CategoryResponse response untuk category
+type CategoryResponse struct {
+	ID          int64     `json:"id"`
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
 // PlanFilter filter untuk query plans
 type PlanFilter struct {
 	IsActive  *bool  `json:"is_active,omitempty"`
@@ -108,12 +134,21 @@ type ThemeFilter struct {
 	IsPremium *bool  `json:"is_premium,omitempty"`
 }
 
+// CategoryFilter filter untuk query categories
+type CategoryFilter struct {
+	Search   string `json:"search,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+}
+
 // PlanFeatures struktur standard features plan
 type PlanFeatures struct {
 	MaxCatalogs      int  `json:"max_catalogs"`
 	MaxProducts      int  `json:"max_products"`
 	MaxUsers         int  `json:"max_users"`
 	MaxStorage       int  `json:"max_storage"` // in MB
+	MaxSectionsPerCatalog int `json:"max_sections_per_catalog"`
+	MaxCardsPerSection    int `json:"max_cards_per_section"`
+	MaxMediaPerCard       int `json:"max_media_per_card"`
 	CustomDomain     bool `json:"custom_domain"`
 	Analytics        bool `json:"analytics"`
 	PrioritySupport  bool `json:"priority_support"`
@@ -30,6 +30,16 @@ type MasterTheme struct {
 	CreatedAt       time.Time              `json:"created_at" db:"mt_created_at"`
 }
 
+// MasterCategory entity untuk tabel master_categories
+type MasterCategory struct {
+	ID          int64          `json:"id" db:"mc_id"`
+	Slug        string         `json:"slug" db:"mc_slug"`
+	Name        string         `json:"name" db:"mc_name"`
+	Description sql.NullString `json:"description" db:"mc_description"`
+	IsActive    bool           `json:"is_active" db:"mc_is_active"`
+	CreatedAt   time.Time      `json:"created_at" db:"mc_created_at"`
+}
+
 // TableName methods
 func (MasterPlan) TableName() string {
 	return "atamlink.master_plans"
@@ -39,6 +49,10 @@ func (MasterTheme) TableName() string {
 	return "atamlink.master_themes"
 }
 
+func (MasterCategory) TableName() string {
+	return "atamlink.master_categories"
+}
+
 // Helper methods
 
 // GetDescription get description dengan null handling
@@ -57,6 +71,14 @@ func (mt *MasterTheme) SetDescription(desc string) {
 	}
 }
 
+// GetDescription get description dengan null handling
+func (mc *MasterCategory) GetDescription() string {
+	if mc.Description.Valid {
+		return mc.Description.String
+	}
+	return ""
+}
+
 // GetDurationDays konversi PostgreSQL interval ke days
 func (mp *MasterPlan) GetDurationDays() int {
 	// Parse PostgreSQL interval format
@@ -72,6 +94,9 @@ type PlanFeatures struct {
 	MaxCatalogs      int  `json:"max_catalogs"`
 	MaxProducts      int  `json:"max_products"`
 	MaxUsers         int  `json:"max_users"`
+	MaxSectionsPerCatalog int `json:"max_sections_per_catalog"`
+	MaxCardsPerSection    int `json:"max_cards_per_section"`
+	MaxMediaPerCard       int `json:"max_media_per_card"`
 	CustomDomain     bool `json:"custom_domain"`
 	Analytics        bool `json:"analytics"`
 	PrioritySupport  bool `json:"priority_support"`
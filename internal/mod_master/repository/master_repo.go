@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	
@@ -13,26 +14,34 @@ import (
 // MasterRepository interface untuk master repository
 type MasterRepository interface {
 	// Plan methods
-	CreatePlan(tx *sql.Tx, plan *entity.MasterPlan) error
-	UpdatePlan(tx *sql.Tx, plan *entity.MasterPlan) error
-	DeletePlan(tx *sql.Tx, id int64) error
-	ListPlans(filter PlanFilter) ([]*entity.MasterPlan, error)
-	GetPlanByID(id int64) (*entity.MasterPlan, error)
-	IsPlanNameExists(name string, excludeID int64) (bool, error)
-	HasActiveSubscriptions(planID int64) (bool, error)
+	CreatePlan(ctx context.Context, tx *sql.Tx, plan *entity.MasterPlan) error
+	UpdatePlan(ctx context.Context, tx *sql.Tx, plan *entity.MasterPlan) error
+	DeletePlan(ctx context.Context, tx *sql.Tx, id int64) error
+	ListPlans(ctx context.Context, filter PlanFilter) ([]*entity.MasterPlan, error)
+	GetPlanByID(ctx context.Context, id int64) (*entity.MasterPlan, error)
+	IsPlanNameExists(ctx context.Context, name string, excludeID int64) (bool, error)
+	HasActiveSubscriptions(ctx context.Context, planID int64) (bool, error)
 	
 	// Theme methods
-	CreateTheme(tx *sql.Tx, theme *entity.MasterTheme) error
-	UpdateTheme(tx *sql.Tx, theme *entity.MasterTheme) error
-	DeleteTheme(tx *sql.Tx, id int64) error
-	ListThemes(filter ThemeFilter) ([]*entity.MasterTheme, error)
-	GetThemeByID(id int64) (*entity.MasterTheme, error)
-	IsThemeNameExists(name string, excludeID int64) (bool, error)
-	HasActiveCatalogs(themeID int64) (bool, error)
+	CreateTheme(ctx context.Context, tx *sql.Tx, theme *entity.MasterTheme) error
+	UpdateTheme(ctx context.Context, tx *sql.Tx, theme *entity.MasterTheme) error
+	DeleteTheme(ctx context.Context, tx *sql.Tx, id int64) error
+	ListThemes(ctx context.Context, filter ThemeFilter) ([]*entity.MasterTheme, error)
+	GetThemeByID(ctx context.Context, id int64) (*entity.MasterTheme, error)
+	IsThemeNameExists(ctx context.Context, name string, excludeID int64) (bool, error)
+	HasActiveCatalogs(ctx context.Context, themeID int64) (bool, error)
+
+	// Category methods
+	CreateCategory(ctx context.Context, tx *sql.Tx, category *entity.MasterCategory) error
+	UpdateCategory(ctx context.Context, tx *sql.Tx, category *entity.MasterCategory) error
+	DeleteCategory(ctx context.Context, tx *sql.Tx, id int64) error
+	ListCategories(ctx context.Context, filter CategoryFilter) ([]*entity.MasterCategory, error)
+	GetCategoryByID(ctx context.Context, id int64) (*entity.MasterCategory, error)
+	IsCategorySlugExists(ctx context.Context, slug string) (bool, error)
 }
 
 type masterRepository struct {
-	db *sql.DB
+	db  *sql.DB
 }
 
 // NewMasterRepository membuat instance master repository baru
@@ -56,8 +65,14 @@ type ThemeFilter struct {
 	IsPremium *bool
 }
 
+// CategoryFilter filter untuk list categories
+type CategoryFilter struct {
+	Search   string
+	IsActive *bool
+}
+
 // CreatePlan membuat plan baru
-func (r *masterRepository) CreatePlan(tx *sql.Tx, plan *entity.MasterPlan) error {
+func (r *masterRepository) CreatePlan(ctx context.Context, tx *sql.Tx, plan *entity.MasterPlan) error {
 	featuresJSON, err := json.Marshal(plan.Features)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal features")
@@ -70,7 +85,7 @@ func (r *masterRepository) CreatePlan(tx *sql.Tx, plan *entity.MasterPlan) error
 		) VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING mp_id`
 
-	err = tx.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		query,
 		plan.Name,
 		plan.Price,
@@ -88,7 +103,7 @@ func (r *masterRepository) CreatePlan(tx *sql.Tx, plan *entity.MasterPlan) error
 }
 
 // UpdatePlan update existing plan
-func (r *masterRepository) UpdatePlan(tx *sql.Tx, plan *entity.MasterPlan) error {
+func (r *masterRepository) UpdatePlan(ctx context.Context, tx *sql.Tx, plan *entity.MasterPlan) error {
 	featuresJSON, err := json.Marshal(plan.Features)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal features")
@@ -103,7 +118,7 @@ func (r *masterRepository) UpdatePlan(tx *sql.Tx, plan *entity.MasterPlan) error
 			mp_is_active = $6
 		WHERE mp_id = $1`
 
-	result, err := tx.Exec(
+	result, err := tx.ExecContext(ctx,
 		query,
 		plan.ID,
 		plan.Name,
@@ -130,13 +145,13 @@ func (r *masterRepository) UpdatePlan(tx *sql.Tx, plan *entity.MasterPlan) error
 }
 
 // DeletePlan soft delete plan (set is_active = false)
-func (r *masterRepository) DeletePlan(tx *sql.Tx, id int64) error {
+func (r *masterRepository) DeletePlan(ctx context.Context, tx *sql.Tx, id int64) error {
 	query := `
 		UPDATE atamlink.master_plans 
 		SET mp_is_active = false
 		WHERE mp_id = $1`
 
-	result, err := tx.Exec(query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete plan")
 	}
@@ -154,7 +169,7 @@ func (r *masterRepository) DeletePlan(tx *sql.Tx, id int64) error {
 }
 
 // IsPlanNameExists check if plan name already exists
-func (r *masterRepository) IsPlanNameExists(name string, excludeID int64) (bool, error) {
+func (r *masterRepository) IsPlanNameExists(ctx context.Context, name string, excludeID int64) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM atamlink.master_plans 
@@ -162,7 +177,7 @@ func (r *masterRepository) IsPlanNameExists(name string, excludeID int64) (bool,
 		)`
 
 	var exists bool
-	err := r.db.QueryRow(query, name, excludeID).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, name, excludeID).Scan(&exists)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check plan name exists")
 	}
@@ -171,7 +186,7 @@ func (r *masterRepository) IsPlanNameExists(name string, excludeID int64) (bool,
 }
 
 // HasActiveSubscriptions check if plan has active subscriptions
-func (r *masterRepository) HasActiveSubscriptions(planID int64) (bool, error) {
+func (r *masterRepository) HasActiveSubscriptions(ctx context.Context, planID int64) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM atamlink.business_subscriptions 
@@ -181,7 +196,7 @@ func (r *masterRepository) HasActiveSubscriptions(planID int64) (bool, error) {
 		)`
 
 	var exists bool
-	err := r.db.QueryRow(query, planID).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, planID).Scan(&exists)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check active subscriptions")
 	}
@@ -190,7 +205,7 @@ func (r *masterRepository) HasActiveSubscriptions(planID int64) (bool, error) {
 }
 
 // CreateTheme membuat theme baru
-func (r *masterRepository) CreateTheme(tx *sql.Tx, theme *entity.MasterTheme) error {
+func (r *masterRepository) CreateTheme(ctx context.Context, tx *sql.Tx, theme *entity.MasterTheme) error {
 	settingsJSON, err := json.Marshal(theme.DefaultSettings)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal default settings")
@@ -203,7 +218,7 @@ func (r *masterRepository) CreateTheme(tx *sql.Tx, theme *entity.MasterTheme) er
 		) VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING mt_id`
 
-	err = tx.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		query,
 		theme.Name,
 		theme.Description,
@@ -222,7 +237,7 @@ func (r *masterRepository) CreateTheme(tx *sql.Tx, theme *entity.MasterTheme) er
 }
 
 // UpdateTheme update existing theme
-func (r *masterRepository) UpdateTheme(tx *sql.Tx, theme *entity.MasterTheme) error {
+func (r *masterRepository) UpdateTheme(ctx context.Context, tx *sql.Tx, theme *entity.MasterTheme) error {
 	settingsJSON, err := json.Marshal(theme.DefaultSettings)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal default settings")
@@ -238,7 +253,7 @@ func (r *masterRepository) UpdateTheme(tx *sql.Tx, theme *entity.MasterTheme) er
 			mt_is_active = $7
 		WHERE mt_id = $1`
 
-	result, err := tx.Exec(
+	result, err := tx.ExecContext(ctx,
 		query,
 		theme.ID,
 		theme.Name,
@@ -266,13 +281,13 @@ func (r *masterRepository) UpdateTheme(tx *sql.Tx, theme *entity.MasterTheme) er
 }
 
 // DeleteTheme soft delete theme (set is_active = false)
-func (r *masterRepository) DeleteTheme(tx *sql.Tx, id int64) error {
+func (r *masterRepository) DeleteTheme(ctx context.Context, tx *sql.Tx, id int64) error {
 	query := `
 		UPDATE atamlink.master_themes 
 		SET mt_is_active = false
 		WHERE mt_id = $1`
 
-	result, err := tx.Exec(query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete theme")
 	}
@@ -290,7 +305,7 @@ func (r *masterRepository) DeleteTheme(tx *sql.Tx, id int64) error {
 }
 
 // IsThemeNameExists check if theme name already exists
-func (r *masterRepository) IsThemeNameExists(name string, excludeID int64) (bool, error) {
+func (r *masterRepository) IsThemeNameExists(ctx context.Context, name string, excludeID int64) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM atamlink.master_themes 
@@ -298,7 +313,7 @@ func (r *masterRepository) IsThemeNameExists(name string, excludeID int64) (bool
 		)`
 
 	var exists bool
-	err := r.db.QueryRow(query, name, excludeID).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, name, excludeID).Scan(&exists)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check theme name exists")
 	}
@@ -307,7 +322,7 @@ func (r *masterRepository) IsThemeNameExists(name string, excludeID int64) (bool
 }
 
 // HasActiveCatalogs check if theme has active catalogs
-func (r *masterRepository) HasActiveCatalogs(themeID int64) (bool, error) {
+func (r *masterRepository) HasActiveCatalogs(ctx context.Context, themeID int64) (bool, error) {
 	query := `
 		SELECT EXISTS(
 			SELECT 1 FROM atamlink.catalogs 
@@ -315,7 +330,7 @@ func (r *masterRepository) HasActiveCatalogs(themeID int64) (bool, error) {
 		)`
 
 	var exists bool
-	err := r.db.QueryRow(query, themeID).Scan(&exists)
+	err := r.db.QueryRowContext(ctx, query, themeID).Scan(&exists)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to check active catalogs")
 	}
@@ -324,7 +339,7 @@ func (r *masterRepository) HasActiveCatalogs(themeID int64) (bool, error) {
 }
 
 // ListPlans mendapatkan list plans
-func (r *masterRepository) ListPlans(filter PlanFilter) ([]*entity.MasterPlan, error) {
+func (r *masterRepository) ListPlans(ctx context.Context, filter PlanFilter) ([]*entity.MasterPlan, error) {
 	// Build query
 	qb := database.NewQueryBuilder()
 	qb.Select(
@@ -357,43 +372,16 @@ func (r *masterRepository) ListPlans(filter PlanFilter) ([]*entity.MasterPlan, e
 	qb.OrderBy("mp_price ASC, mp_id ASC")
 
 	query, args := qb.Build()
-	rows, err := r.db.Query(query, args...)
+	plans, err := database.Select[entity.MasterPlan](ctx, r.db, query, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query plans")
 	}
-	defer rows.Close()
-
-	plans := make([]*entity.MasterPlan, 0)
-	for rows.Next() {
-		plan := &entity.MasterPlan{}
-		var featuresJSON []byte
-		
-		err := rows.Scan(
-			&plan.ID,
-			&plan.Name,
-			&plan.Price,
-			&plan.Duration,
-			&featuresJSON,
-			&plan.IsActive,
-			&plan.CreatedAt,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan plan")
-		}
-
-		// Parse features
-		if err := json.Unmarshal(featuresJSON, &plan.Features); err != nil {
-			return nil, errors.Wrap(err, "failed to parse features")
-		}
-
-		plans = append(plans, plan)
-	}
 
 	return plans, nil
 }
 
 // GetPlanByID mendapatkan plan by ID
-func (r *masterRepository) GetPlanByID(id int64) (*entity.MasterPlan, error) {
+func (r *masterRepository) GetPlanByID(ctx context.Context, id int64) (*entity.MasterPlan, error) {
 	query := `
 		SELECT 
 			mp_id, mp_name, mp_price, mp_duration,
@@ -401,19 +389,7 @@ func (r *masterRepository) GetPlanByID(id int64) (*entity.MasterPlan, error) {
 		FROM atamlink.master_plans
 		WHERE mp_id = $1`
 
-	plan := &entity.MasterPlan{}
-	var featuresJSON []byte
-	
-	err := r.db.QueryRow(query, id).Scan(
-		&plan.ID,
-		&plan.Name,
-		&plan.Price,
-		&plan.Duration,
-		&featuresJSON,
-		&plan.IsActive,
-		&plan.CreatedAt,
-	)
-
+	plan, err := database.Get[entity.MasterPlan](ctx, r.db, query, id)
 	if err == sql.ErrNoRows {
 		return nil, errors.New(errors.ErrPlanNotFound, "Plan tidak ditemukan", 404)
 	}
@@ -421,16 +397,11 @@ func (r *masterRepository) GetPlanByID(id int64) (*entity.MasterPlan, error) {
 		return nil, errors.Wrap(err, "failed to get plan")
 	}
 
-	// Parse features
-	if err := json.Unmarshal(featuresJSON, &plan.Features); err != nil {
-		return nil, errors.Wrap(err, "failed to parse features")
-	}
-
 	return plan, nil
 }
 
 // ListThemes mendapatkan list themes
-func (r *masterRepository) ListThemes(filter ThemeFilter) ([]*entity.MasterTheme, error) {
+func (r *masterRepository) ListThemes(ctx context.Context, filter ThemeFilter) ([]*entity.MasterTheme, error) {
 	// Build query
 	qb := database.NewQueryBuilder()
 	qb.Select(
@@ -459,44 +430,16 @@ func (r *masterRepository) ListThemes(filter ThemeFilter) ([]*entity.MasterTheme
 	qb.OrderBy("mt_name ASC")
 
 	query, args := qb.Build()
-	rows, err := r.db.Query(query, args...)
+	themes, err := database.Select[entity.MasterTheme](ctx, r.db, query, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to query themes")
 	}
-	defer rows.Close()
-
-	themes := make([]*entity.MasterTheme, 0)
-	for rows.Next() {
-		theme := &entity.MasterTheme{}
-		var settingsJSON []byte
-		
-		err := rows.Scan(
-			&theme.ID,
-			&theme.Name,
-			&theme.Description,
-			&theme.Type,
-			&settingsJSON,
-			&theme.IsPremium,
-			&theme.IsActive,
-			&theme.CreatedAt,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to scan theme")
-		}
-
-		// Parse default settings
-		if err := json.Unmarshal(settingsJSON, &theme.DefaultSettings); err != nil {
-			return nil, errors.Wrap(err, "failed to parse default settings")
-		}
-
-		themes = append(themes, theme)
-	}
 
 	return themes, nil
 }
 
 // GetThemeByID mendapatkan theme by ID
-func (r *masterRepository) GetThemeByID(id int64) (*entity.MasterTheme, error) {
+func (r *masterRepository) GetThemeByID(ctx context.Context, id int64) (*entity.MasterTheme, error) {
 	query := `
 		SELECT 
 			mt_id, mt_name, mt_description, mt_type,
@@ -504,20 +447,7 @@ func (r *masterRepository) GetThemeByID(id int64) (*entity.MasterTheme, error) {
 		FROM atamlink.master_themes
 		WHERE mt_id = $1`
 
-	theme := &entity.MasterTheme{}
-	var settingsJSON []byte
-	
-	err := r.db.QueryRow(query, id).Scan(
-		&theme.ID,
-		&theme.Name,
-		&theme.Description,
-		&theme.Type,
-		&settingsJSON,
-		&theme.IsPremium,
-		&theme.IsActive,
-		&theme.CreatedAt,
-	)
-
+	theme, err := database.Get[entity.MasterTheme](ctx, r.db, query, id)
 	if err == sql.ErrNoRows {
 		return nil, errors.New(errors.ErrNotFound, "Theme tidak ditemukan", 404)
 	}
@@ -525,10 +455,150 @@ func (r *masterRepository) GetThemeByID(id int64) (*entity.MasterTheme, error) {
 		return nil, errors.Wrap(err, "failed to get theme")
 	}
 
-	// Parse default settings
-	if err := json.Unmarshal(settingsJSON, &theme.DefaultSettings); err != nil {
-		return nil, errors.Wrap(err, "failed to parse default settings")
+	return theme, nil
+}
+
+// CreateCategory membuat category baru
+func (r *masterRepository) CreateCategory(ctx context.Context, tx *sql.Tx, category *entity.MasterCategory) error {
+	query := `
+		INSERT INTO atamlink.master_categories (
+			mc_slug, mc_name, mc_description, mc_is_active, mc_created_at
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING mc_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		category.Slug,
+		category.Name,
+		category.Description,
+		category.IsActive,
+		category.CreatedAt,
+	).Scan(&category.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create category")
+	}
+
+	return nil
+}
+
+// UpdateCategory update existing category
+func (r *masterRepository) UpdateCategory(ctx context.Context, tx *sql.Tx, category *entity.MasterCategory) error {
+	query := `
+		UPDATE atamlink.master_categories SET
+			mc_name = $2,
+			mc_description = $3,
+			mc_is_active = $4
+		WHERE mc_id = $1`
+
+	result, err := tx.ExecContext(ctx,
+		query,
+		category.ID,
+		category.Name,
+		category.Description,
+		category.IsActive,
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to update category")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
 	}
 
-	return theme, nil
-}
\ No newline at end of file
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgCategoryNotFound, 404)
+	}
+
+	return nil
+}
+
+// DeleteCategory soft delete category (set is_active = false), business dan katalog
+// yang memakainya tetap tersimpan tetapi kategori tidak ditampilkan lagi
+func (r *masterRepository) DeleteCategory(ctx context.Context, tx *sql.Tx, id int64) error {
+	query := `
+		UPDATE atamlink.master_categories 
+		SET mc_is_active = false
+		WHERE mc_id = $1`
+
+	result, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete category")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgCategoryNotFound, 404)
+	}
+
+	return nil
+}
+
+// ListCategories mendapatkan list categories
+func (r *masterRepository) ListCategories(ctx context.Context, filter CategoryFilter) ([]*entity.MasterCategory, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(
+		"mc_id", "mc_slug", "mc_name", "mc_description", "mc_is_active", "mc_created_at",
+	).From("atamlink.master_categories")
+
+	if filter.Search != "" {
+		pattern := "%" + filter.Search + "%"
+		qb.Where("(LOWER(mc_name) LIKE LOWER(?) OR mc_slug LIKE LOWER(?))", pattern, pattern)
+	}
+
+	if filter.IsActive != nil {
+		qb.Where("mc_is_active = ?", *filter.IsActive)
+	}
+
+	// Order by name
+	qb.OrderBy("mc_name ASC")
+
+	query, args := qb.Build()
+	categories, err := database.Select[entity.MasterCategory](ctx, r.db, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query categories")
+	}
+
+	return categories, nil
+}
+
+// GetCategoryByID mendapatkan category by ID
+func (r *masterRepository) GetCategoryByID(ctx context.Context, id int64) (*entity.MasterCategory, error) {
+	query := `
+		SELECT mc_id, mc_slug, mc_name, mc_description, mc_is_active, mc_created_at
+		FROM atamlink.master_categories
+		WHERE mc_id = $1`
+
+	category, err := database.Get[entity.MasterCategory](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgCategoryNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get category")
+	}
+
+	return category, nil
+}
+
+// IsCategorySlugExists check if category slug already exists
+func (r *masterRepository) IsCategorySlugExists(ctx context.Context, slug string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM atamlink.master_categories 
+			WHERE mc_slug = $1
+		)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check category slug exists")
+	}
+
+	return exists, nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -14,23 +15,31 @@ import (
 	"github.com/atam/atamlink/internal/mod_master/repository"
 	"github.com/atam/atamlink/pkg/database"
 	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
 )
 
 // MasterUseCase interface untuk master use case
 type MasterUseCase interface {
 	// Plan methods
-	CreatePlan(req *dto.CreatePlanRequest) (*dto.PlanResponse, error)
+	CreatePlan(ctx context.Context, req *dto.CreatePlanRequest) (*dto.PlanResponse, error)
 	UpdatePlan(ctx *gin.Context, id int64, req *dto.UpdatePlanRequest) (*dto.PlanResponse, error)
 	DeletePlan(ctx *gin.Context, id int64) error
-	ListPlans(filter *dto.PlanFilter) ([]*dto.PlanListResponse, error)
-	GetPlanByID(id int64) (*dto.PlanResponse, error)
+	ListPlans(ctx context.Context, filter *dto.PlanFilter) ([]*dto.PlanListResponse, error)
+	GetPlanByID(ctx context.Context, id int64) (*dto.PlanResponse, error)
 	
 	// Theme methods
-	CreateTheme(req *dto.CreateThemeRequest) (*dto.ThemeResponse, error)
+	CreateTheme(ctx context.Context, req *dto.CreateThemeRequest) (*dto.ThemeResponse, error)
 	UpdateTheme(ctx *gin.Context, id int64, req *dto.UpdateThemeRequest) (*dto.ThemeResponse, error)
 	DeleteTheme(ctx *gin.Context, id int64) error
-	ListThemes(filter *dto.ThemeFilter) ([]*dto.ThemeListResponse, error)
-	GetThemeByID(id int64) (*dto.ThemeResponse, error)
+	ListThemes(ctx context.Context, filter *dto.ThemeFilter) ([]*dto.ThemeListResponse, error)
+	GetThemeByID(ctx context.Context, id int64) (*dto.ThemeResponse, error)
+
+	// Category methods
+	CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
+	UpdateCategory(ctx *gin.Context, id int64, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
+	DeleteCategory(ctx *gin.Context, id int64) error
+	ListCategories(ctx context.Context, filter *dto.CategoryFilter) ([]*dto.CategoryResponse, error)
+	GetCategoryByID(ctx context.Context, id int64) (*dto.CategoryResponse, error)
 }
 
 type masterUseCase struct {
@@ -47,9 +56,9 @@ func NewMasterUseCase(db *sql.DB, masterRepo repository.MasterRepository) Master
 }
 
 // CreatePlan membuat plan baru
-func (uc *masterUseCase) CreatePlan(req *dto.CreatePlanRequest) (*dto.PlanResponse, error) {
+func (uc *masterUseCase) CreatePlan(ctx context.Context, req *dto.CreatePlanRequest) (*dto.PlanResponse, error) {
 	// Validasi nama unik
-	exists, err := uc.masterRepo.IsPlanNameExists(req.Name, 0)
+	exists, err := uc.masterRepo.IsPlanNameExists(ctx, req.Name, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +78,7 @@ func (uc *masterUseCase) CreatePlan(req *dto.CreatePlanRequest) (*dto.PlanRespon
 	}
 
 	// Start transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -85,7 +94,7 @@ func (uc *masterUseCase) CreatePlan(req *dto.CreatePlanRequest) (*dto.PlanRespon
 		CreatedAt: time.Now(),
 	}
 
-	if err := uc.masterRepo.CreatePlan(tx, plan); err != nil {
+	if err := uc.masterRepo.CreatePlan(ctx, tx, plan); err != nil {
 		return nil, err
 	}
 
@@ -110,7 +119,7 @@ func (uc *masterUseCase) CreatePlan(req *dto.CreatePlanRequest) (*dto.PlanRespon
 // UpdatePlan update existing plan
 func (uc *masterUseCase) UpdatePlan(ctx *gin.Context, id int64, req *dto.UpdatePlanRequest) (*dto.PlanResponse, error) {
 	// Get existing plan
-	plan, err := uc.masterRepo.GetPlanByID(id)
+	plan, err := uc.masterRepo.GetPlanByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +130,7 @@ func (uc *masterUseCase) UpdatePlan(ctx *gin.Context, id int64, req *dto.UpdateP
 	}
 
 	// Check if has active subscriptions
-	hasActive, err := uc.masterRepo.HasActiveSubscriptions(id)
+	hasActive, err := uc.masterRepo.HasActiveSubscriptions(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +140,7 @@ func (uc *masterUseCase) UpdatePlan(ctx *gin.Context, id int64, req *dto.UpdateP
 
 	// Validate name uniqueness if changed
 	if req.Name != "" && req.Name != plan.Name {
-		exists, err := uc.masterRepo.IsPlanNameExists(req.Name, id)
+		exists, err := uc.masterRepo.IsPlanNameExists(utils.ContextFrom(ctx), req.Name, id)
 		if err != nil {
 			return nil, err
 		}
@@ -163,13 +172,13 @@ func (uc *masterUseCase) UpdatePlan(ctx *gin.Context, id int64, req *dto.UpdateP
 	}
 
 	// Update in transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.masterRepo.UpdatePlan(tx, plan); err != nil {
+	if err := uc.masterRepo.UpdatePlan(utils.ContextFrom(ctx), tx, plan); err != nil {
 		return nil, err
 	}
 
@@ -193,7 +202,7 @@ func (uc *masterUseCase) UpdatePlan(ctx *gin.Context, id int64, req *dto.UpdateP
 // DeletePlan soft delete plan
 func (uc *masterUseCase) DeletePlan(ctx *gin.Context, id int64) error {
 	// Get existing plan
-	plan, err := uc.masterRepo.GetPlanByID(id)
+	plan, err := uc.masterRepo.GetPlanByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -204,7 +213,7 @@ func (uc *masterUseCase) DeletePlan(ctx *gin.Context, id int64) error {
 	}
 
 	// Check if has active subscriptions
-	hasActive, err := uc.masterRepo.HasActiveSubscriptions(id)
+	hasActive, err := uc.masterRepo.HasActiveSubscriptions(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -213,13 +222,13 @@ func (uc *masterUseCase) DeletePlan(ctx *gin.Context, id int64) error {
 	}
 
 	// Delete in transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.masterRepo.DeletePlan(tx, id); err != nil {
+	if err := uc.masterRepo.DeletePlan(utils.ContextFrom(ctx), tx, id); err != nil {
 		return err
 	}
 
@@ -231,14 +240,14 @@ func (uc *masterUseCase) DeletePlan(ctx *gin.Context, id int64) error {
 }
 
 // CreateTheme membuat theme baru
-func (uc *masterUseCase) CreateTheme(req *dto.CreateThemeRequest) (*dto.ThemeResponse, error) {
+func (uc *masterUseCase) CreateTheme(ctx context.Context, req *dto.CreateThemeRequest) (*dto.ThemeResponse, error) {
 	// Validate theme type
 	if !constant.IsValidThemeType(req.Type) {
 		return nil, errors.New(errors.ErrValidation, "Tipe theme tidak valid", 400)
 	}
 
 	// Validate name uniqueness
-	exists, err := uc.masterRepo.IsThemeNameExists(req.Name, 0)
+	exists, err := uc.masterRepo.IsThemeNameExists(ctx, req.Name, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -252,7 +261,7 @@ func (uc *masterUseCase) CreateTheme(req *dto.CreateThemeRequest) (*dto.ThemeRes
 	}
 
 	// Start transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
@@ -269,7 +278,7 @@ func (uc *masterUseCase) CreateTheme(req *dto.CreateThemeRequest) (*dto.ThemeRes
 		CreatedAt:       time.Now(),
 	}
 
-	if err := uc.masterRepo.CreateTheme(tx, theme); err != nil {
+	if err := uc.masterRepo.CreateTheme(ctx, tx, theme); err != nil {
 		return nil, err
 	}
 
@@ -295,7 +304,7 @@ func (uc *masterUseCase) CreateTheme(req *dto.CreateThemeRequest) (*dto.ThemeRes
 // UpdateTheme update existing theme
 func (uc *masterUseCase) UpdateTheme(ctx *gin.Context, id int64, req *dto.UpdateThemeRequest) (*dto.ThemeResponse, error) {
 	// Get existing theme
-	theme, err := uc.masterRepo.GetThemeByID(id)
+	theme, err := uc.masterRepo.GetThemeByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return nil, err
 	}
@@ -307,7 +316,7 @@ func (uc *masterUseCase) UpdateTheme(ctx *gin.Context, id int64, req *dto.Update
 
 	// Validate name uniqueness if changed
 	if req.Name != "" && req.Name != theme.Name {
-		exists, err := uc.masterRepo.IsThemeNameExists(req.Name, id)
+		exists, err := uc.masterRepo.IsThemeNameExists(utils.ContextFrom(ctx), req.Name, id)
 		if err != nil {
 			return nil, err
 		}
@@ -342,13 +351,13 @@ func (uc *masterUseCase) UpdateTheme(ctx *gin.Context, id int64, req *dto.Update
 	}
 
 	// Update in transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.masterRepo.UpdateTheme(tx, theme); err != nil {
+	if err := uc.masterRepo.UpdateTheme(utils.ContextFrom(ctx), tx, theme); err != nil {
 		return nil, err
 	}
 
@@ -373,7 +382,7 @@ func (uc *masterUseCase) UpdateTheme(ctx *gin.Context, id int64, req *dto.Update
 // DeleteTheme soft delete theme
 func (uc *masterUseCase) DeleteTheme(ctx *gin.Context, id int64) error {
 	// Get existing theme
-	theme, err := uc.masterRepo.GetThemeByID(id)
+	theme, err := uc.masterRepo.GetThemeByID(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -384,7 +393,7 @@ func (uc *masterUseCase) DeleteTheme(ctx *gin.Context, id int64) error {
 	}
 
 	// Check if has active catalogs
-	hasActive, err := uc.masterRepo.HasActiveCatalogs(id)
+	hasActive, err := uc.masterRepo.HasActiveCatalogs(utils.ContextFrom(ctx), id)
 	if err != nil {
 		return err
 	}
@@ -393,13 +402,13 @@ func (uc *masterUseCase) DeleteTheme(ctx *gin.Context, id int64) error {
 	}
 
 	// Delete in transaction
-	tx, err := uc.db.Begin()
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin transaction")
 	}
 	defer tx.Rollback()
 
-	if err := uc.masterRepo.DeleteTheme(tx, id); err != nil {
+	if err := uc.masterRepo.DeleteTheme(utils.ContextFrom(ctx), tx, id); err != nil {
 		return err
 	}
 
@@ -411,7 +420,7 @@ func (uc *masterUseCase) DeleteTheme(ctx *gin.Context, id int64) error {
 }
 
 // ListPlans mendapatkan list plans
-func (uc *masterUseCase) ListPlans(filter *dto.PlanFilter) ([]*dto.PlanListResponse, error) {
+func (uc *masterUseCase) ListPlans(ctx context.Context, filter *dto.PlanFilter) ([]*dto.PlanListResponse, error) {
 	// Build repository filter
 	repoFilter := repository.PlanFilter{}
 	
@@ -423,7 +432,7 @@ func (uc *masterUseCase) ListPlans(filter *dto.PlanFilter) ([]*dto.PlanListRespo
 	}
 
 	// Get plans
-	plans, err := uc.masterRepo.ListPlans(repoFilter)
+	plans, err := uc.masterRepo.ListPlans(ctx, repoFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -448,9 +457,9 @@ func (uc *masterUseCase) ListPlans(filter *dto.PlanFilter) ([]*dto.PlanListRespo
 }
 
 // GetPlanByID mendapatkan plan by ID
-func (uc *masterUseCase) GetPlanByID(id int64) (*dto.PlanResponse, error) {
+func (uc *masterUseCase) GetPlanByID(ctx context.Context, id int64) (*dto.PlanResponse, error) {
 	// Get plan
-	plan, err := uc.masterRepo.GetPlanByID(id)
+	plan, err := uc.masterRepo.GetPlanByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -469,7 +478,7 @@ func (uc *masterUseCase) GetPlanByID(id int64) (*dto.PlanResponse, error) {
 }
 
 // ListThemes mendapatkan list themes
-func (uc *masterUseCase) ListThemes(filter *dto.ThemeFilter) ([]*dto.ThemeListResponse, error) {
+func (uc *masterUseCase) ListThemes(ctx context.Context, filter *dto.ThemeFilter) ([]*dto.ThemeListResponse, error) {
 	// Build repository filter
 	repoFilter := repository.ThemeFilter{}
 	
@@ -481,7 +490,7 @@ func (uc *masterUseCase) ListThemes(filter *dto.ThemeFilter) ([]*dto.ThemeListRe
 	}
 
 	// Get themes
-	themes, err := uc.masterRepo.ListThemes(repoFilter)
+	themes, err := uc.masterRepo.ListThemes(ctx, repoFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -505,9 +514,9 @@ func (uc *masterUseCase) ListThemes(filter *dto.ThemeFilter) ([]*dto.ThemeListRe
 }
 
 // GetThemeByID mendapatkan theme by ID
-func (uc *masterUseCase) GetThemeByID(id int64) (*dto.ThemeResponse, error) {
+func (uc *masterUseCase) GetThemeByID(ctx context.Context, id int64) (*dto.ThemeResponse, error) {
 	// Get theme
-	theme, err := uc.masterRepo.GetThemeByID(id)
+	theme, err := uc.masterRepo.GetThemeByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -526,6 +535,153 @@ func (uc *masterUseCase) GetThemeByID(id int64) (*dto.ThemeResponse, error) {
 	}, nil
 }
 
+// CreateCategory membuat category baru
+func (uc *masterUseCase) CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+	// Validate slug uniqueness
+	exists, err := uc.masterRepo.IsCategorySlugExists(ctx, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgCategorySlugExists, 409)
+	}
+
+	// Start transaction
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	// Create category
+	category := &entity.MasterCategory{
+		Slug:        req.Slug,
+		Name:        req.Name,
+		Description: database.NullString(req.Description),
+		IsActive:    req.IsActive,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := uc.masterRepo.CreateCategory(ctx, tx, category); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toCategoryResponse(category), nil
+}
+
+// UpdateCategory update existing category
+func (uc *masterUseCase) UpdateCategory(ctx *gin.Context, id int64, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
+	// Get existing category
+	category, err := uc.masterRepo.GetCategoryByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, category)
+	}
+
+	// Update fields
+	if req.Name != "" {
+		category.Name = req.Name
+	}
+	if req.Description != "" {
+		category.Description = database.NullString(req.Description)
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+
+	// Update in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.masterRepo.UpdateCategory(utils.ContextFrom(ctx), tx, category); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toCategoryResponse(category), nil
+}
+
+// DeleteCategory soft delete category
+func (uc *masterUseCase) DeleteCategory(ctx *gin.Context, id int64) error {
+	// Get existing category
+	category, err := uc.masterRepo.GetCategoryByID(utils.ContextFrom(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, category)
+	}
+
+	// Delete in transaction
+	tx, err := uc.db.BeginTx(utils.ContextFrom(ctx), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.masterRepo.DeleteCategory(utils.ContextFrom(ctx), tx, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// ListCategories mendapatkan list categories
+func (uc *masterUseCase) ListCategories(ctx context.Context, filter *dto.CategoryFilter) ([]*dto.CategoryResponse, error) {
+	// Build repository filter
+	repoFilter := repository.CategoryFilter{}
+
+	if filter != nil {
+		repoFilter.Search = filter.Search
+		repoFilter.IsActive = filter.IsActive
+	}
+
+	// Get categories
+	categories, err := uc.masterRepo.ListCategories(ctx, repoFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to response
+	responses := make([]*dto.CategoryResponse, len(categories))
+	for i, category := range categories {
+		responses[i] = toCategoryResponse(category)
+	}
+
+	return responses, nil
+}
+
+// GetCategoryByID mendapatkan category by ID
+func (uc *masterUseCase) GetCategoryByID(ctx context.Context, id int64) (*dto.CategoryResponse, error) {
+	category, err := uc.masterRepo.GetCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCategoryResponse(category), nil
+}
+
 // Helper functions
 
 func formatPrice(price int) string {
@@ -563,6 +719,10 @@ func getDefaultPlanFeatures() map[string]interface{} {
 		"remove_watermark":  false,
 		"advanced_themes":   false,
 		"api_access":        false,
+
+		constant.PlanFeatureMaxSectionsPerCatalog: constant.DefaultMaxSectionsPerCatalog,
+		constant.PlanFeatureMaxCardsPerSection:    constant.DefaultMaxCardsPerSection,
+		constant.PlanFeatureMaxMediaPerCard:       constant.DefaultMaxMediaPerCard,
 	}
 }
 
@@ -598,4 +758,15 @@ func getDefaultThemeSettings(themeType string) map[string]interface{} {
 	}
 
 	return baseSettings
-}
\ No newline at end of file
+}
+
+func toCategoryResponse(category *entity.MasterCategory) *dto.CategoryResponse {
+	return &dto.CategoryResponse{
+		ID:          category.ID,
+		Slug:        category.Slug,
+		Name:        category.Name,
+		Description: category.GetDescription(),
+		IsActive:    category.IsActive,
+		CreatedAt:   category.CreatedAt,
+	}
+}
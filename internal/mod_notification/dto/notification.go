@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+)
+
+// UpsertWhatsAppRequest request untuk mengaktifkan notifikasi WhatsApp
+type UpsertWhatsAppRequest struct {
+	PhoneNumberID string `json:"phone_number_id" validate:"required,max=50"`
+	AccessToken   string `json:"access_token" validate:"required"`
+	Recipient     string `json:"recipient" validate:"required,numeric,min=8,max=20"` // nomor owner format internasional tanpa +
+	TemplateName  string `json:"template_name" validate:"required,max=100"`
+	Language      string `json:"language,omitempty" validate:"omitempty,max=10"`
+	IsEnabled     *bool  `json:"is_enabled,omitempty"`
+}
+
+// NotificationChannelResponse response untuk channel notifikasi
+type NotificationChannelResponse struct {
+	ID        int64                  `json:"id"`
+	Channel   string                 `json:"channel"`
+	Recipient string                 `json:"recipient"`
+	Config    map[string]interface{} `json:"config"`
+	IsEnabled bool                   `json:"is_enabled"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt *time.Time             `json:"updated_at,omitempty"`
+}
+
+// NotificationLogResponse response untuk log pengiriman notifikasi
+type NotificationLogResponse struct {
+	ID                int64      `json:"id"`
+	Channel           string     `json:"channel"`
+	Event             string     `json:"event"`
+	Status            string     `json:"status"`
+	ProviderMessageID string     `json:"provider_message_id,omitempty"`
+	Error             string     `json:"error,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+}
+
+// WhatsAppWebhookRequest payload webhook status dari WhatsApp Cloud API
+type WhatsAppWebhookRequest struct {
+	Object string `json:"object"`
+	Entry  []struct {
+		Changes []struct {
+			Value struct {
+				Statuses []struct {
+					ID     string `json:"id"`
+					Status string `json:"status"`
+					Errors []struct {
+						Title string `json:"title"`
+					} `json:"errors"`
+				} `json:"statuses"`
+			} `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+// TelegramLinkResponse response deep link untuk menghubungkan chat Telegram
+type TelegramLinkResponse struct {
+	DeepLink  string    `json:"deep_link"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TelegramUpdateRequest payload update dari Telegram Bot API webhook
+type TelegramUpdateRequest struct {
+	UpdateID int64 `json:"update_id"`
+	Message  *struct {
+		Text string `json:"text"`
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NotificationChannel entity untuk tabel business_notification_channels
+type NotificationChannel struct {
+	ID          int64                  `json:"id" db:"bnc_id"`
+	BusinessID  int64                  `json:"business_id" db:"bnc_b_id"`
+	Channel     string                 `json:"channel" db:"bnc_channel"`
+	Recipient   string                 `json:"recipient" db:"bnc_recipient"`
+	Credentials string                 `json:"-" db:"bnc_credentials"` // sealed oleh vault service
+	Config      map[string]interface{} `json:"config" db:"bnc_config"`
+	IsEnabled   bool                   `json:"is_enabled" db:"bnc_is_enabled"`
+	CreatedBy   int64                  `json:"created_by" db:"bnc_created_by"`
+	CreatedAt   time.Time              `json:"created_at" db:"bnc_created_at"`
+	UpdatedBy   sql.NullInt64          `json:"updated_by" db:"bnc_updated_by"`
+	UpdatedAt   *time.Time             `json:"updated_at" db:"bnc_updated_at"`
+}
+
+// NotificationLog entity untuk tabel notification_logs
+type NotificationLog struct {
+	ID                int64          `json:"id" db:"nl_id"`
+	ChannelID         int64          `json:"channel_id" db:"nl_bnc_id"`
+	Event             string         `json:"event" db:"nl_event"`
+	Status            string         `json:"status" db:"nl_status"`
+	ProviderMessageID sql.NullString `json:"provider_message_id" db:"nl_provider_message_id"`
+	Error             sql.NullString `json:"error" db:"nl_error"`
+	CreatedAt         time.Time      `json:"created_at" db:"nl_created_at"`
+	UpdatedAt         *time.Time     `json:"updated_at" db:"nl_updated_at"`
+}
+
+// TableName methods
+func (NotificationChannel) TableName() string { return "atamlink.business_notification_channels" }
+func (NotificationLog) TableName() string     { return "atamlink.notification_logs" }
+
+// GetConfigString ambil nilai string dari config
+func (c *NotificationChannel) GetConfigString(key string) string {
+	if v, ok := c.Config[key].(string); ok {
+		return v
+	}
+	return ""
+}
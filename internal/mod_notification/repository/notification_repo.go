@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_notification/entity"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// NotificationRepository interface untuk notification repository
+type NotificationRepository interface {
+	// Channel methods
+	UpsertChannel(tx *sql.Tx, channel *entity.NotificationChannel) error
+	GetChannel(businessID int64, channel string) (*entity.NotificationChannel, error)
+	GetChannelByLinkToken(channel, token string) (*entity.NotificationChannel, error)
+	ListChannels(businessID int64) ([]*entity.NotificationChannel, error)
+	DeleteChannel(tx *sql.Tx, businessID int64, channel string) error
+
+	// Log methods
+	CreateLog(log *entity.NotificationLog) error
+	UpdateLogStatus(providerMessageID, status, errMsg string) error
+	ListLogs(businessID int64, limit int) ([]*entity.NotificationLog, error)
+}
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository membuat instance notification repository baru
+func NewNotificationRepository(db *sql.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// UpsertChannel buat atau update channel notifikasi business
+func (r *notificationRepository) UpsertChannel(tx *sql.Tx, channel *entity.NotificationChannel) error {
+	configJSON, err := json.Marshal(channel.Config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal channel config")
+	}
+
+	query := `
+		INSERT INTO atamlink.business_notification_channels (
+			bnc_b_id, bnc_channel, bnc_recipient, bnc_credentials, bnc_config,
+			bnc_is_enabled, bnc_created_by, bnc_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (bnc_b_id, bnc_channel) DO UPDATE SET
+			bnc_recipient = EXCLUDED.bnc_recipient,
+			bnc_credentials = EXCLUDED.bnc_credentials,
+			bnc_config = EXCLUDED.bnc_config,
+			bnc_is_enabled = EXCLUDED.bnc_is_enabled,
+			bnc_updated_by = EXCLUDED.bnc_created_by,
+			bnc_updated_at = EXCLUDED.bnc_created_at
+		RETURNING bnc_id, bnc_created_at`
+
+	err = tx.QueryRow(
+		query,
+		channel.BusinessID,
+		channel.Channel,
+		channel.Recipient,
+		channel.Credentials,
+		configJSON,
+		channel.IsEnabled,
+		channel.CreatedBy,
+		channel.CreatedAt,
+	).Scan(&channel.ID, &channel.CreatedAt)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to upsert notification channel")
+	}
+
+	return nil
+}
+
+// GetChannel mendapatkan channel notifikasi, nil jika belum dikonfigurasi
+func (r *notificationRepository) GetChannel(businessID int64, channel string) (*entity.NotificationChannel, error) {
+	query := `
+		SELECT
+			bnc_id, bnc_b_id, bnc_channel, bnc_recipient, bnc_credentials, bnc_config,
+			bnc_is_enabled, bnc_created_by, bnc_created_at, bnc_updated_by, bnc_updated_at
+		FROM atamlink.business_notification_channels
+		WHERE bnc_b_id = $1 AND bnc_channel = $2`
+
+	ch, err := scanChannel(r.db.QueryRow(query, businessID, channel))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get notification channel")
+	}
+
+	return ch, nil
+}
+
+// GetChannelByLinkToken mendapatkan channel yang menunggu linking, nil jika token tidak dikenal
+func (r *notificationRepository) GetChannelByLinkToken(channel, token string) (*entity.NotificationChannel, error) {
+	query := `
+		SELECT
+			bnc_id, bnc_b_id, bnc_channel, bnc_recipient, bnc_credentials, bnc_config,
+			bnc_is_enabled, bnc_created_by, bnc_created_at, bnc_updated_by, bnc_updated_at
+		FROM atamlink.business_notification_channels
+		WHERE bnc_channel = $1 AND bnc_config->>'link_token' = $2`
+
+	ch, err := scanChannel(r.db.QueryRow(query, channel, token))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get notification channel by link token")
+	}
+
+	return ch, nil
+}
+
+// ListChannels mendapatkan semua channel notifikasi business
+func (r *notificationRepository) ListChannels(businessID int64) ([]*entity.NotificationChannel, error) {
+	query := `
+		SELECT
+			bnc_id, bnc_b_id, bnc_channel, bnc_recipient, bnc_credentials, bnc_config,
+			bnc_is_enabled, bnc_created_by, bnc_created_at, bnc_updated_by, bnc_updated_at
+		FROM atamlink.business_notification_channels
+		WHERE bnc_b_id = $1
+		ORDER BY bnc_channel ASC`
+
+	rows, err := r.db.Query(query, businessID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list notification channels")
+	}
+	defer rows.Close()
+
+	channels := make([]*entity.NotificationChannel, 0)
+	for rows.Next() {
+		ch, err := scanChannel(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan notification channel")
+		}
+		channels = append(channels, ch)
+	}
+
+	return channels, nil
+}
+
+// DeleteChannel hapus channel notifikasi (opt-out)
+func (r *notificationRepository) DeleteChannel(tx *sql.Tx, businessID int64, channel string) error {
+	query := `
+		DELETE FROM atamlink.business_notification_channels
+		WHERE bnc_b_id = $1 AND bnc_channel = $2`
+
+	result, err := tx.Exec(query, businessID, channel)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete notification channel")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgNotificationChannelNotFound, 404)
+	}
+
+	return nil
+}
+
+// CreateLog simpan log pengiriman notifikasi
+func (r *notificationRepository) CreateLog(log *entity.NotificationLog) error {
+	query := `
+		INSERT INTO atamlink.notification_logs (
+			nl_bnc_id, nl_event, nl_status, nl_provider_message_id, nl_error, nl_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING nl_id`
+
+	err := r.db.QueryRow(
+		query,
+		log.ChannelID,
+		log.Event,
+		log.Status,
+		log.ProviderMessageID,
+		log.Error,
+		log.CreatedAt,
+	).Scan(&log.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create notification log")
+	}
+
+	return nil
+}
+
+// UpdateLogStatus update status delivery berdasarkan message ID dari provider
+func (r *notificationRepository) UpdateLogStatus(providerMessageID, status, errMsg string) error {
+	query := `
+		UPDATE atamlink.notification_logs SET
+			nl_status = $2,
+			nl_error = COALESCE($3, nl_error),
+			nl_updated_at = $4
+		WHERE nl_provider_message_id = $1
+			AND NOT (nl_status = 'read' AND $2 = 'delivered')` // webhook bisa datang tidak berurutan
+
+	var errValue sql.NullString
+	if errMsg != "" {
+		errValue = sql.NullString{String: errMsg, Valid: true}
+	}
+
+	_, err := r.db.Exec(query, providerMessageID, status, errValue, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to update notification log status")
+	}
+
+	return nil
+}
+
+// ListLogs mendapatkan log notifikasi terbaru milik business
+func (r *notificationRepository) ListLogs(businessID int64, limit int) ([]*entity.NotificationLog, error) {
+	query := `
+		SELECT
+			nl.nl_id, nl.nl_bnc_id, nl.nl_event, nl.nl_status, nl.nl_provider_message_id,
+			nl.nl_error, nl.nl_created_at, nl.nl_updated_at
+		FROM atamlink.notification_logs nl
+		INNER JOIN atamlink.business_notification_channels bnc ON bnc.bnc_id = nl.nl_bnc_id
+		WHERE bnc.bnc_b_id = $1
+		ORDER BY nl.nl_created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, businessID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list notification logs")
+	}
+	defer rows.Close()
+
+	logs := make([]*entity.NotificationLog, 0)
+	for rows.Next() {
+		log := &entity.NotificationLog{}
+		err := rows.Scan(
+			&log.ID,
+			&log.ChannelID,
+			&log.Event,
+			&log.Status,
+			&log.ProviderMessageID,
+			&log.Error,
+			&log.CreatedAt,
+			&log.UpdatedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan notification log")
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, nil
+}
+
+// rowScanner abstraksi *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanChannel(row rowScanner) (*entity.NotificationChannel, error) {
+	ch := &entity.NotificationChannel{}
+	var configJSON []byte
+
+	err := row.Scan(
+		&ch.ID,
+		&ch.BusinessID,
+		&ch.Channel,
+		&ch.Recipient,
+		&ch.Credentials,
+		&configJSON,
+		&ch.IsEnabled,
+		&ch.CreatedBy,
+		&ch.CreatedAt,
+		&ch.UpdatedBy,
+		&ch.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(configJSON, &ch.Config); err != nil {
+		return nil, errors.Wrap(err, "failed to parse channel config")
+	}
+
+	return ch, nil
+}
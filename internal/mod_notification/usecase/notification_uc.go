@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	"github.com/atam/atamlink/internal/mod_notification/dto"
+	"github.com/atam/atamlink/internal/mod_notification/entity"
+	"github.com/atam/atamlink/internal/mod_notification/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+const notificationLogLimit = 100
+
+// NotificationUseCase interface untuk notification use case
+type NotificationUseCase interface {
+	ListChannels(ctx *gin.Context, businessID, profileID int64) ([]*dto.NotificationChannelResponse, error)
+	UpsertWhatsApp(ctx *gin.Context, businessID, profileID int64, req *dto.UpsertWhatsAppRequest) (*dto.NotificationChannelResponse, error)
+	DeleteChannel(ctx *gin.Context, businessID, profileID int64, channel string) error
+	ListLogs(ctx *gin.Context, businessID, profileID int64) ([]*dto.NotificationLogResponse, error)
+	HandleWhatsAppWebhook(req *dto.WhatsAppWebhookRequest) error
+
+	// Telegram
+	CreateTelegramLink(ctx *gin.Context, businessID, profileID int64) (*dto.TelegramLinkResponse, error)
+	HandleTelegramWebhook(ctx *gin.Context, secret string, req *dto.TelegramUpdateRequest) error
+
+	// Scheduled
+	NotifyExpiringSubscriptions(ctx context.Context, daysBefore int) error
+}
+
+type notificationUseCase struct {
+	db                  *sql.DB
+	notificationRepo    repository.NotificationRepository
+	businessRepo        businessRepo.BusinessRepository
+	vaultService        service.VaultService
+	telegramSender      service.TelegramSender
+	notificationService service.NotificationService
+	telegramLinkTTL     time.Duration
+	clock               service.Clock
+}
+
+// NewNotificationUseCase membuat instance notification use case baru
+func NewNotificationUseCase(
+	db *sql.DB,
+	notificationRepo repository.NotificationRepository,
+	businessRepo businessRepo.BusinessRepository,
+	vaultService service.VaultService,
+	telegramSender service.TelegramSender,
+	notificationService service.NotificationService,
+	telegramLinkTTL time.Duration,
+	clock service.Clock,
+) NotificationUseCase {
+	return &notificationUseCase{
+		db:                  db,
+		notificationRepo:    notificationRepo,
+		businessRepo:        businessRepo,
+		vaultService:        vaultService,
+		telegramSender:      telegramSender,
+		notificationService: notificationService,
+		telegramLinkTTL:     telegramLinkTTL,
+		clock:               clock,
+	}
+}
+
+// ListChannels daftar channel notifikasi business
+func (uc *notificationUseCase) ListChannels(ctx *gin.Context, businessID, profileID int64) ([]*dto.NotificationChannelResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessView); err != nil {
+		return nil, err
+	}
+
+	channels, err := uc.notificationRepo.ListChannels(businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.NotificationChannelResponse, len(channels))
+	for i, ch := range channels {
+		responses[i] = toChannelResponse(ch)
+	}
+
+	return responses, nil
+}
+
+// UpsertWhatsApp opt-in / update notifikasi WhatsApp
+func (uc *notificationUseCase) UpsertWhatsApp(ctx *gin.Context, businessID, profileID int64, req *dto.UpsertWhatsAppRequest) (*dto.NotificationChannelResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	// Kredensial disimpan terenkripsi, tidak pernah dikembalikan ke client
+	sealed, err := uc.vaultService.Seal(map[string]string{
+		"phone_number_id": req.PhoneNumberID,
+		"access_token":    req.AccessToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	language := req.Language
+	if language == "" {
+		language = "id"
+	}
+
+	channel := &entity.NotificationChannel{
+		BusinessID:  businessID,
+		Channel:     constant.NotificationChannelWhatsApp,
+		Recipient:   req.Recipient,
+		Credentials: sealed,
+		Config: map[string]interface{}{
+			"template_name": req.TemplateName,
+			"language":      language,
+		},
+		IsEnabled: true,
+		CreatedBy: profileID,
+		CreatedAt: time.Now(),
+	}
+
+	if req.IsEnabled != nil {
+		channel.IsEnabled = *req.IsEnabled
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.notificationRepo.UpsertChannel(tx, channel); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toChannelResponse(channel), nil
+}
+
+// DeleteChannel opt-out channel notifikasi
+func (uc *notificationUseCase) DeleteChannel(ctx *gin.Context, businessID, profileID int64, channel string) error {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return err
+	}
+
+	old, err := uc.notificationRepo.GetChannel(businessID, channel)
+	if err != nil {
+		return err
+	}
+	if old == nil {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgNotificationChannelNotFound, 404)
+	}
+
+	ctx.Set(middleware.GinKeyAuditOldData, old)
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.notificationRepo.DeleteChannel(tx, businessID, channel); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// ListLogs log pengiriman notifikasi terbaru
+func (uc *notificationUseCase) ListLogs(ctx *gin.Context, businessID, profileID int64) ([]*dto.NotificationLogResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessView); err != nil {
+		return nil, err
+	}
+
+	channels, err := uc.notificationRepo.ListChannels(businessID)
+	if err != nil {
+		return nil, err
+	}
+	channelNames := make(map[int64]string, len(channels))
+	for _, ch := range channels {
+		channelNames[ch.ID] = ch.Channel
+	}
+
+	logs, err := uc.notificationRepo.ListLogs(businessID, notificationLogLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.NotificationLogResponse, len(logs))
+	for i, log := range logs {
+		responses[i] = &dto.NotificationLogResponse{
+			ID:                log.ID,
+			Channel:           channelNames[log.ChannelID],
+			Event:             log.Event,
+			Status:            log.Status,
+			ProviderMessageID: log.ProviderMessageID.String,
+			Error:             log.Error.String,
+			CreatedAt:         log.CreatedAt,
+			UpdatedAt:         log.UpdatedAt,
+		}
+	}
+
+	return responses, nil
+}
+
+// HandleWhatsAppWebhook catat status delivery dari WhatsApp
+func (uc *notificationUseCase) HandleWhatsAppWebhook(req *dto.WhatsAppWebhookRequest) error {
+	for _, entry := range req.Entry {
+		for _, change := range entry.Changes {
+			for _, st := range change.Value.Statuses {
+				var status string
+				switch st.Status {
+				case "delivered":
+					status = constant.NotificationStatusDelivered
+				case "read":
+					status = constant.NotificationStatusRead
+				case "failed":
+					status = constant.NotificationStatusFailed
+				default:
+					continue
+				}
+
+				var errMsg string
+				if len(st.Errors) > 0 {
+					errMsg = st.Errors[0].Title
+				}
+
+				if err := uc.notificationRepo.UpdateLogStatus(st.ID, status, errMsg); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// CreateTelegramLink buat deep link untuk menghubungkan chat Telegram owner
+func (uc *notificationUseCase) CreateTelegramLink(ctx *gin.Context, businessID, profileID int64) (*dto.TelegramLinkResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermBusinessUpdate); err != nil {
+		return nil, err
+	}
+
+	if !uc.telegramSender.IsConfigured() {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgTelegramNotConfigured, 503)
+	}
+
+	// Link baru menimpa channel, chat yang sudah terhubung harus diputus dulu
+	// lewat DELETE /notifications/telegram supaya tidak terlepas diam-diam
+	existing, err := uc.notificationRepo.GetChannel(businessID, constant.NotificationChannelTelegram)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Recipient != "" {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgTelegramAlreadyLinked, 409)
+	}
+
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to generate link token")
+	}
+	token := hex.EncodeToString(tokenBytes)
+	expiresAt := time.Now().Add(uc.telegramLinkTTL)
+
+	// Channel disimpan nonaktif sampai owner menekan /start di bot
+	channel := &entity.NotificationChannel{
+		BusinessID: businessID,
+		Channel:    constant.NotificationChannelTelegram,
+		Config: map[string]interface{}{
+			"link_token":      token,
+			"link_expires_at": expiresAt.Format(time.RFC3339),
+		},
+		IsEnabled: false,
+		CreatedBy: profileID,
+		CreatedAt: time.Now(),
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.notificationRepo.UpsertChannel(tx, channel); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return &dto.TelegramLinkResponse{
+		DeepLink:  uc.telegramSender.DeepLink(token),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// HandleTelegramWebhook proses perintah /start <token> dari bot
+func (uc *notificationUseCase) HandleTelegramWebhook(ctx *gin.Context, secret string, req *dto.TelegramUpdateRequest) error {
+	if !uc.telegramSender.VerifyWebhook(secret) {
+		return errors.New(errors.ErrUnauthorized, "Secret webhook tidak valid", 401)
+	}
+
+	if req.Message == nil || !strings.HasPrefix(req.Message.Text, "/start ") {
+		return nil
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(req.Message.Text, "/start "))
+	chatID := strconv.FormatInt(req.Message.Chat.ID, 10)
+
+	channel, err := uc.notificationRepo.GetChannelByLinkToken(constant.NotificationChannelTelegram, token)
+	if err != nil {
+		return err
+	}
+
+	expiresAt, _ := time.Parse(time.RFC3339, channelConfigString(channel, "link_expires_at"))
+	if channel == nil || time.Now().After(expiresAt) {
+		uc.telegramSender.SendText(middleware.RequestContext(ctx), chatID, "Link sudah tidak berlaku. Silakan buat link baru dari dashboard.")
+		return nil
+	}
+
+	channel.Recipient = chatID
+	channel.IsEnabled = true
+	channel.Config = map[string]interface{}{}
+	channel.CreatedAt = time.Now()
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.notificationRepo.UpsertChannel(tx, channel); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.telegramSender.SendText(middleware.RequestContext(ctx), chatID, "Notifikasi AtamLink berhasil terhubung ke chat ini.")
+
+	return nil
+}
+
+// NotifyExpiringSubscriptions kabari owner yang subscription-nya berakhir dalam daysBefore hari
+// (dijalankan scheduler sekali sehari, jendela 24 jam mencegah notifikasi ganda)
+func (uc *notificationUseCase) NotifyExpiringSubscriptions(ctx context.Context, daysBefore int) error {
+	from := uc.clock.Now().Add(time.Duration(daysBefore) * 24 * time.Hour)
+	subs, err := uc.businessRepo.ListExpiringSubscriptions(ctx, from, from.Add(24*time.Hour))
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		uc.notificationService.Notify(&service.Notification{
+			BusinessID: sub.BusinessID,
+			Event:      constant.NotificationEventSubscriptionExpiring,
+			Subscription: &service.SubscriptionNotification{
+				PlanName:  sub.Plan.Name,
+				ExpiresAt: sub.ExpiresAt,
+			},
+		})
+	}
+
+	return nil
+}
+
+func channelConfigString(ch *entity.NotificationChannel, key string) string {
+	if ch == nil {
+		return ""
+	}
+	return ch.GetConfigString(key)
+}
+
+func toChannelResponse(ch *entity.NotificationChannel) *dto.NotificationChannelResponse {
+	return &dto.NotificationChannelResponse{
+		ID:        ch.ID,
+		Channel:   ch.Channel,
+		Recipient: ch.Recipient,
+		Config:    ch.Config,
+		IsEnabled: ch.IsEnabled,
+		CreatedAt: ch.CreatedAt,
+		UpdatedAt: ch.UpdatedAt,
+	}
+}
@@ -0,0 +1,67 @@
+package dto
+
+import "time"
+
+// CreateOrderRequest request order dari pengunjung, minimal email atau nomor
+// telepon harus diisi agar pemilik bisa menghubungi
+type CreateOrderRequest struct {
+	Items   []OrderItemRequest `json:"items" validate:"required,min=1,max=20,dive"`
+	Name    string             `json:"name" validate:"required,max=100"`
+	Email   string             `json:"email,omitempty" validate:"omitempty,email,max=255"`
+	Phone   string             `json:"phone,omitempty" validate:"omitempty,max=30"`
+	Note    string             `json:"note,omitempty" validate:"omitempty,max=1000"`
+	Website string             `json:"website,omitempty"` // honeypot, disembunyikan dari pengunjung dan harus kosong
+	Token   string             `json:"token,omitempty"`   // analytics_token dari response katalog publik
+}
+
+// OrderItemRequest card dan jumlah yang dipesan
+type OrderItemRequest struct {
+	CardID   int64 `json:"card_id" validate:"required,min=1"`
+	Quantity int   `json:"quantity" validate:"required,min=1,max=999"`
+}
+
+// UpdateOrderStatusRequest request ubah status order oleh pemilik
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=contacted completed cancelled"`
+}
+
+// OrderFilter filter daftar order untuk pemilik
+type OrderFilter struct {
+	Status string
+}
+
+// OrderItemResponse item order
+type OrderItemResponse struct {
+	CardID    *int64 `json:"card_id,omitempty"` // kosong jika card sudah dihapus
+	Title     string `json:"title"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice *int64 `json:"unit_price,omitempty"`
+	Subtotal  int64  `json:"subtotal"`
+}
+
+// PublicOrderResponse konfirmasi order untuk pengunjung
+type PublicOrderResponse struct {
+	Ref       string               `json:"ref"`
+	Items     []*OrderItemResponse `json:"items"`
+	Total     int64                `json:"total"`
+	Currency  string               `json:"currency"`
+	CreatedAt time.Time            `json:"created_at"`
+}
+
+// OrderResponse order lengkap untuk pemilik katalog
+type OrderResponse struct {
+	ID              int64                `json:"id"`
+	Ref             string               `json:"ref"`
+	CatalogID       int64                `json:"catalog_id"`
+	Name            string               `json:"name"`
+	Email           string               `json:"email,omitempty"`
+	Phone           string               `json:"phone,omitempty"`
+	Note            string               `json:"note,omitempty"`
+	Status          string               `json:"status"`
+	Items           []*OrderItemResponse `json:"items"`
+	Total           int64                `json:"total"`
+	Currency        string               `json:"currency"`
+	StatusUpdatedBy *int64               `json:"status_updated_by,omitempty"`
+	StatusUpdatedAt *time.Time           `json:"status_updated_at,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+}
@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Order entity untuk tabel catalog_orders
+type Order struct {
+	ID              int64          `json:"id" db:"co_id"`
+	Ref             string         `json:"ref" db:"co_ref"`
+	CatalogID       int64          `json:"catalog_id" db:"co_c_id"`
+	Name            string         `json:"name" db:"co_name"`
+	Email           sql.NullString `json:"email" db:"co_email"`
+	Phone           sql.NullString `json:"phone" db:"co_phone"`
+	Note            sql.NullString `json:"note" db:"co_note"`
+	Status          string         `json:"status" db:"co_status"`
+	Total           int64          `json:"total" db:"co_total"`
+	Currency        string         `json:"currency" db:"co_currency"`
+	VisitorHash     string         `json:"-" db:"co_visitor_hash"`
+	StatusUpdatedBy sql.NullInt64  `json:"status_updated_by" db:"co_status_updated_by"`
+	StatusUpdatedAt *time.Time     `json:"status_updated_at" db:"co_status_updated_at"`
+	CreatedAt       time.Time      `json:"created_at" db:"co_created_at"`
+
+	// Relations
+	Items []*OrderItem `json:"items,omitempty"`
+}
+
+// TableName mendapatkan nama tabel
+func (Order) TableName() string { return "atamlink.catalog_orders" }
+
+// OrderItem entity untuk tabel catalog_order_items
+type OrderItem struct {
+	ID        int64         `json:"id" db:"coi_id"`
+	OrderID   int64         `json:"order_id" db:"coi_co_id"`
+	CardID    sql.NullInt64 `json:"card_id" db:"coi_cc_id"`
+	Title     string        `json:"title" db:"coi_title"`
+	Quantity  int           `json:"quantity" db:"coi_quantity"`
+	UnitPrice sql.NullInt64 `json:"unit_price" db:"coi_unit_price"`
+}
+
+// TableName mendapatkan nama tabel
+func (OrderItem) TableName() string { return "atamlink.catalog_order_items" }
+
+// Subtotal harga item dikali jumlah, 0 jika card tidak punya harga
+func (i *OrderItem) Subtotal() int64 {
+	if !i.UnitPrice.Valid {
+		return 0
+	}
+	return i.UnitPrice.Int64 * int64(i.Quantity)
+}
+
+// orderTransitions status tujuan yang diizinkan dari tiap status
+var orderTransitions = map[string][]string{
+	"new":       {"contacted", "completed", "cancelled"},
+	"contacted": {"completed", "cancelled"},
+}
+
+// CanTransitionTo check apakah status order boleh diubah ke status, completed
+// dan cancelled adalah status akhir
+func (o *Order) CanTransitionTo(status string) bool {
+	for _, next := range orderTransitions[o.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderableCard card katalog yang bisa dipesan beserta harganya saat ini
+type OrderableCard struct {
+	ID       int64
+	Title    string
+	Price    sql.NullInt64
+	Discount int
+	Currency string
+	Stock    sql.NullInt64 // NULL = stok tidak dilacak
+	SoldOut  bool
+}
+
+// EffectivePrice harga setelah diskon, sama dengan perhitungan card katalog
+func (c *OrderableCard) EffectivePrice() sql.NullInt64 {
+	if !c.Price.Valid {
+		return c.Price
+	}
+	if c.Discount <= 0 {
+		return c.Price
+	}
+	discounted := c.Price.Int64 - (c.Price.Int64*int64(c.Discount))/100
+	return sql.NullInt64{Int64: discounted, Valid: true}
+}
+
+// IsSoldOut card ditandai habis atau stok yang dilacak sudah 0
+func (c *OrderableCard) IsSoldOut() bool {
+	return c.SoldOut || (c.Stock.Valid && c.Stock.Int64 <= 0)
+}
+
+// PublicCatalog status katalog untuk validasi order publik
+type PublicCatalog struct {
+	ID               int64
+	BusinessID       int64
+	Slug             string
+	Title            string
+	IsActive         bool
+	Status           string
+	IsArchived       bool
+	BusinessIsActive bool
+}
+
+// AcceptsOrder hanya katalog yang tampil publik yang bisa menerima order
+func (c *PublicCatalog) AcceptsOrder() bool {
+	return c.IsActive && c.Status == "published" && !c.IsArchived && c.BusinessIsActive
+}
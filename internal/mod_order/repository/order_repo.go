@@ -0,0 +1,333 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_order/entity"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// OrderRepository interface untuk order repository
+type OrderRepository interface {
+	GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error)
+	GetOrderableCards(catalogID int64, cardIDs []int64) (map[int64]*entity.OrderableCard, error)
+	Create(tx *sql.Tx, order *entity.Order) error
+	GetByID(id int64) (*entity.Order, error)
+	List(filter ListFilter) ([]*entity.Order, int64, error)
+	CountByVisitorSince(visitorHash string, since time.Time) (int, error)
+	UpdateStatus(tx *sql.Tx, id int64, fromStatus, toStatus string, profileID int64, at time.Time) error
+}
+
+// ListFilter filter untuk list order
+type ListFilter struct {
+	CatalogID int64
+	Status    string
+	Limit     int
+	Offset    int
+	OrderBy   string
+}
+
+type orderRepository struct {
+	db *sql.DB
+}
+
+// NewOrderRepository membuat instance order repository baru
+func NewOrderRepository(db *sql.DB) OrderRepository {
+	return &orderRepository{db: db}
+}
+
+var orderColumns = []string{
+	"co.co_id", "co.co_ref", "co.co_c_id", "co.co_name", "co.co_email",
+	"co.co_phone", "co.co_note", "co.co_status", "co.co_total", "co.co_currency",
+	"co.co_visitor_hash", "co.co_status_updated_by", "co.co_status_updated_at",
+	"co.co_created_at",
+}
+
+// GetPublicCatalogBySlug status katalog dan business untuk validasi order publik
+func (r *orderRepository) GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error) {
+	query := `
+		SELECT c.c_id, c.c_b_id, c.c_slug, c.c_title, c.c_is_active, c.c_status,
+			c.c_archived_at IS NOT NULL, b.b_is_active
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_slug = $1`
+
+	catalog := &entity.PublicCatalog{}
+	err := r.db.QueryRow(query, slug).Scan(
+		&catalog.ID,
+		&catalog.BusinessID,
+		&catalog.Slug,
+		&catalog.Title,
+		&catalog.IsActive,
+		&catalog.Status,
+		&catalog.IsArchived,
+		&catalog.BusinessIsActive,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get catalog by slug")
+	}
+
+	return catalog, nil
+}
+
+// GetOrderableCards card produk yang tampil di katalog dari daftar ID, key = card ID.
+// Card yang bukan milik katalog, tersembunyi (termasuk karena habis) atau bukan produk tidak dikembalikan
+func (r *orderRepository) GetOrderableCards(catalogID int64, cardIDs []int64) (map[int64]*entity.OrderableCard, error) {
+	query := `
+		SELECT cc.cc_id, cc.cc_title, cc.cc_price, cc.cc_discount, cc.cc_currency,
+			cc.cc_stock, cc.cc_sold_out
+		FROM atamlink.catalog_cards cc
+		INNER JOIN atamlink.catalog_sections cs ON cs.cs_id = cc.cc_cs_id
+		WHERE cc.cc_id = ANY($1) AND cs.cs_c_id = $2
+			AND cc.cc_is_visible = true AND cs.cs_is_visible = true
+			AND cc.cc_type = $3
+			AND NOT (cc.cc_hide_when_sold_out AND (cc.cc_sold_out OR COALESCE(cc.cc_stock = 0, false)))`
+
+	rows, err := r.db.Query(query, pq.Array(cardIDs), catalogID, constant.CardTypeProduct)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get orderable cards")
+	}
+	defer rows.Close()
+
+	cards := make(map[int64]*entity.OrderableCard)
+	for rows.Next() {
+		card := &entity.OrderableCard{}
+		if err := rows.Scan(&card.ID, &card.Title, &card.Price, &card.Discount, &card.Currency, &card.Stock, &card.SoldOut); err != nil {
+			return nil, errors.Wrap(err, "failed to scan orderable card")
+		}
+		cards[card.ID] = card
+	}
+
+	return cards, rows.Err()
+}
+
+// Create membuat order beserta item-nya
+func (r *orderRepository) Create(tx *sql.Tx, order *entity.Order) error {
+	query := `
+		INSERT INTO atamlink.catalog_orders (
+			co_ref, co_c_id, co_name, co_email, co_phone, co_note, co_status,
+			co_total, co_currency, co_visitor_hash, co_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING co_id`
+
+	err := tx.QueryRow(
+		query,
+		order.Ref,
+		order.CatalogID,
+		order.Name,
+		order.Email,
+		order.Phone,
+		order.Note,
+		order.Status,
+		order.Total,
+		order.Currency,
+		order.VisitorHash,
+		order.CreatedAt,
+	).Scan(&order.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to create order")
+	}
+
+	itemQuery := `
+		INSERT INTO atamlink.catalog_order_items (
+			coi_co_id, coi_cc_id, coi_title, coi_quantity, coi_unit_price
+		) VALUES ($1, $2, $3, $4, $5)
+		RETURNING coi_id`
+
+	for _, item := range order.Items {
+		item.OrderID = order.ID
+		err := tx.QueryRow(itemQuery, item.OrderID, item.CardID, item.Title, item.Quantity, item.UnitPrice).Scan(&item.ID)
+		if err != nil {
+			return errors.Wrap(err, "failed to create order item")
+		}
+	}
+
+	return nil
+}
+
+// GetByID mendapatkan order by ID beserta item-nya
+func (r *orderRepository) GetByID(id int64) (*entity.Order, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(orderColumns...).From("atamlink.catalog_orders co")
+	qb.Where("co.co_id = ?", id)
+
+	query, args := qb.Build()
+	order, err := scanOrder(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgOrderNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get order")
+	}
+
+	if err := r.loadItems([]*entity.Order{order}); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// List mendapatkan order katalog dengan filter status
+func (r *orderRepository) List(filter ListFilter) ([]*entity.Order, int64, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(orderColumns...).From("atamlink.catalog_orders co")
+	qb.Where("co.co_c_id = ?", filter.CatalogID)
+
+	if filter.Status != "" {
+		qb.Where("co.co_status = ?", filter.Status)
+	}
+
+	// Count total
+	countQuery, countArgs := qb.BuildCount()
+	var total int64
+	if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count orders")
+	}
+
+	qb.OrderBy(filter.OrderBy + ", co.co_id DESC")
+	qb.Limit(filter.Limit)
+	qb.Offset(filter.Offset)
+
+	query, args := qb.Build()
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to query orders")
+	}
+	defer rows.Close()
+
+	orders := make([]*entity.Order, 0)
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to scan order")
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to iterate orders")
+	}
+
+	if err := r.loadItems(orders); err != nil {
+		return nil, 0, err
+	}
+
+	return orders, total, nil
+}
+
+// CountByVisitorSince jumlah order pengunjung (semua katalog) sejak waktu tertentu
+func (r *orderRepository) CountByVisitorSince(visitorHash string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM atamlink.catalog_orders
+		WHERE co_visitor_hash = $1 AND co_created_at >= $2`
+
+	var count int
+	if err := r.db.QueryRow(query, visitorHash, since).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count visitor orders")
+	}
+
+	return count, nil
+}
+
+// UpdateStatus ubah status order, gagal jika status sudah diubah request lain
+func (r *orderRepository) UpdateStatus(tx *sql.Tx, id int64, fromStatus, toStatus string, profileID int64, at time.Time) error {
+	query := `
+		UPDATE atamlink.catalog_orders SET
+			co_status = $3,
+			co_status_updated_by = $4,
+			co_status_updated_at = $5
+		WHERE co_id = $1 AND co_status = $2`
+
+	result, err := tx.Exec(query, id, fromStatus, toStatus, profileID, at)
+	if err != nil {
+		return errors.Wrap(err, "failed to update order status")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrConflict, constant.ErrMsgOrderStatusChanged, 409)
+	}
+
+	return nil
+}
+
+// loadItems isi item untuk daftar order dalam satu query
+func (r *orderRepository) loadItems(orders []*entity.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(orders))
+	byID := make(map[int64]*entity.Order, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+		byID[order.ID] = order
+		order.Items = make([]*entity.OrderItem, 0)
+	}
+
+	query := `
+		SELECT coi_id, coi_co_id, coi_cc_id, coi_title, coi_quantity, coi_unit_price
+		FROM atamlink.catalog_order_items
+		WHERE coi_co_id = ANY($1)
+		ORDER BY coi_id`
+
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return errors.Wrap(err, "failed to query order items")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := &entity.OrderItem{}
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.CardID, &item.Title, &item.Quantity, &item.UnitPrice); err != nil {
+			return errors.Wrap(err, "failed to scan order item")
+		}
+		if order, ok := byID[item.OrderID]; ok {
+			order.Items = append(order.Items, item)
+		}
+	}
+
+	return rows.Err()
+}
+
+// rowScanner abstraksi *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanOrder scan satu baris order
+func scanOrder(row rowScanner) (*entity.Order, error) {
+	order := &entity.Order{}
+	err := row.Scan(
+		&order.ID,
+		&order.Ref,
+		&order.CatalogID,
+		&order.Name,
+		&order.Email,
+		&order.Phone,
+		&order.Note,
+		&order.Status,
+		&order.Total,
+		&order.Currency,
+		&order.VisitorHash,
+		&order.StatusUpdatedBy,
+		&order.StatusUpdatedAt,
+		&order.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
@@ -0,0 +1,374 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/internal/mod_order/dto"
+	"github.com/atam/atamlink/internal/mod_order/entity"
+	"github.com/atam/atamlink/internal/mod_order/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// OrderUseCase interface untuk order use case
+type OrderUseCase interface {
+	Submit(ctx *gin.Context, slug string, visitor *service.VisitorInfo, req *dto.CreateOrderRequest) (*dto.PublicOrderResponse, error)
+	List(ctx *gin.Context, catalogID, profileID int64, filter *dto.OrderFilter, page, perPage int, orderBy string) ([]*dto.OrderResponse, int64, error)
+	GetByID(ctx *gin.Context, orderID, profileID int64) (*dto.OrderResponse, error)
+	UpdateStatus(ctx *gin.Context, orderID, profileID int64, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error)
+}
+
+type orderUseCase struct {
+	db                  *sql.DB
+	orderRepo           repository.OrderRepository
+	catalogRepo         catalogRepo.CatalogRepository
+	businessRepo        businessRepo.BusinessRepository
+	slugService         service.SlugService
+	botFilter           service.BotFilter
+	notificationService service.NotificationService
+	config              config.OrderConfig
+	clock               service.Clock
+}
+
+// NewOrderUseCase membuat instance order use case baru
+func NewOrderUseCase(
+	db *sql.DB,
+	orderRepo repository.OrderRepository,
+	catalogRepo catalogRepo.CatalogRepository,
+	businessRepo businessRepo.BusinessRepository,
+	slugService service.SlugService,
+	botFilter service.BotFilter,
+	notificationService service.NotificationService,
+	cfg config.OrderConfig,
+	clock service.Clock,
+) OrderUseCase {
+	return &orderUseCase{
+		db:                  db,
+		orderRepo:           orderRepo,
+		catalogRepo:         catalogRepo,
+		businessRepo:        businessRepo,
+		slugService:         slugService,
+		botFilter:           botFilter,
+		notificationService: notificationService,
+		config:              cfg,
+		clock:               clock,
+	}
+}
+
+// Submit simpan order pengunjung sebagai lead untuk pemilik, tanpa pembayaran.
+// Harga diambil dari card saat ini, bukan dari request
+func (uc *orderUseCase) Submit(ctx *gin.Context, slug string, visitor *service.VisitorInfo, req *dto.CreateOrderRequest) (*dto.PublicOrderResponse, error) {
+	catalog, err := uc.orderRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if !catalog.AcceptsOrder() {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	if req.Website != "" || uc.botFilter.IsBot(visitor, req.Token, catalog.ID) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgOrderRejected, 400)
+	}
+
+	email := strings.TrimSpace(req.Email)
+	phone := strings.TrimSpace(req.Phone)
+	if email == "" && phone == "" {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgOrderContactRequired, 400)
+	}
+
+	visitorHash := uc.visitorHash(visitor)
+	now := uc.clock.NowFor(catalog.BusinessID)
+
+	// Rate limit per pengunjung lintas katalog
+	if uc.config.RateLimit > 0 {
+		count, err := uc.orderRepo.CountByVisitorSince(visitorHash, now.Add(-uc.config.RateWindow))
+		if err != nil {
+			return nil, err
+		}
+		if count >= uc.config.RateLimit {
+			return nil, errors.New(errors.ErrRateLimited, constant.ErrMsgOrderRateLimited, 429)
+		}
+	}
+
+	// Card yang sama digabung jumlahnya, urutan mengikuti request
+	quantities := make(map[int64]int)
+	cardIDs := make([]int64, 0, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := quantities[item.CardID]; !ok {
+			cardIDs = append(cardIDs, item.CardID)
+		}
+		quantities[item.CardID] += item.Quantity
+	}
+
+	cards, err := uc.orderRepo.GetOrderableCards(catalog.ID, cardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &entity.Order{
+		Ref:         "ORD-" + strings.ToUpper(uc.slugService.GenerateRandom(constant.OrderRefLength)),
+		CatalogID:   catalog.ID,
+		Name:        strings.TrimSpace(req.Name),
+		Email:       database.NullString(email),
+		Phone:       database.NullString(phone),
+		Note:        database.NullString(strings.TrimSpace(req.Note)),
+		Status:      constant.OrderStatusNew,
+		Currency:    constant.CurrencyIDR,
+		VisitorHash: visitorHash,
+		CreatedAt:   now,
+		Items:       make([]*entity.OrderItem, 0, len(cardIDs)),
+	}
+
+	priced := false
+	for _, cardID := range cardIDs {
+		card, ok := cards[cardID]
+		if !ok {
+			return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgOrderCardInvalid, cardID), 400)
+		}
+
+		// Stok hanya dicek, tidak dikurangi: pemilik menyesuaikan stok saat order diproses
+		if card.IsSoldOut() {
+			return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgOrderCardSoldOut, card.Title), 400)
+		}
+		if card.Stock.Valid && int64(quantities[cardID]) > card.Stock.Int64 {
+			return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgOrderStockInsufficient, card.Title, card.Stock.Int64), 400)
+		}
+
+		// Semua item dengan harga harus satu mata uang agar total bermakna
+		price := card.EffectivePrice()
+		if price.Valid {
+			if !priced {
+				order.Currency = card.Currency
+				priced = true
+			} else if card.Currency != order.Currency {
+				return nil, errors.New(errors.ErrValidation, constant.ErrMsgOrderCurrencyMismatch, 400)
+			}
+		}
+
+		item := &entity.OrderItem{
+			CardID:    sql.NullInt64{Int64: card.ID, Valid: true},
+			Title:     card.Title,
+			Quantity:  quantities[cardID],
+			UnitPrice: price,
+		}
+		order.Total += item.Subtotal()
+		order.Items = append(order.Items, item)
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.orderRepo.Create(tx, order); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.notifyNewOrder(ctx, catalog.BusinessID, order)
+
+	return &dto.PublicOrderResponse{
+		Ref:       order.Ref,
+		Items:     toOrderItemResponses(order.Items),
+		Total:     order.Total,
+		Currency:  order.Currency,
+		CreatedAt: order.CreatedAt,
+	}, nil
+}
+
+// List daftar order katalog untuk pemilik
+func (uc *orderUseCase) List(ctx *gin.Context, catalogID, profileID int64, filter *dto.OrderFilter, page, perPage int, orderBy string) ([]*dto.OrderResponse, int64, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Status != "" && !constant.IsValidOrderStatus(filter.Status) {
+		return nil, 0, errors.New(errors.ErrValidation, constant.ErrMsgOrderStatusInvalid, 400)
+	}
+
+	orders, total, err := uc.orderRepo.List(repository.ListFilter{
+		CatalogID: catalog.ID,
+		Status:    filter.Status,
+		Limit:     perPage,
+		Offset:    (page - 1) * perPage,
+		OrderBy:   orderBy,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.OrderResponse, len(orders))
+	for i, order := range orders {
+		responses[i] = toOrderResponse(order)
+	}
+
+	return responses, total, nil
+}
+
+// GetByID detail order untuk pemilik
+func (uc *orderUseCase) GetByID(ctx *gin.Context, orderID, profileID int64) (*dto.OrderResponse, error) {
+	order, err := uc.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), order.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, err
+	}
+
+	return toOrderResponse(order), nil
+}
+
+// UpdateStatus ubah status order: new -> contacted -> completed, cancelled dari
+// status mana pun yang belum selesai
+func (uc *orderUseCase) UpdateStatus(ctx *gin.Context, orderID, profileID int64, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error) {
+	order, err := uc.orderRepo.GetByID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, order)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), order.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	if !order.CanTransitionTo(req.Status) {
+		return nil, errors.New(errors.ErrValidation, fmt.Sprintf(constant.ErrMsgOrderStatusTransition, order.Status, req.Status), 400)
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.orderRepo.UpdateStatus(tx, order.ID, order.Status, req.Status, profileID, uc.clock.NowFor(catalog.BusinessID)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	updated, err := uc.orderRepo.GetByID(order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrderResponse(updated), nil
+}
+
+// notifyNewOrder kirim notifikasi order baru ke owner business, item pertama
+// dipakai sebagai judul dan jumlah adalah total semua item
+func (uc *orderUseCase) notifyNewOrder(ctx *gin.Context, businessID int64, order *entity.Order) {
+	title := order.Items[0].Title
+	if len(order.Items) > 1 {
+		title = fmt.Sprintf("%s +%d lainnya", title, len(order.Items)-1)
+	}
+
+	quantity := 0
+	for _, item := range order.Items {
+		quantity += item.Quantity
+	}
+
+	uc.notificationService.Notify(&service.Notification{
+		BusinessID: businessID,
+		Event:      constant.NotificationEventNewOrder,
+		RequestID:  middleware.GetRequestID(ctx),
+		Order: &service.OrderNotification{
+			OrderRef:  order.Ref,
+			ItemTitle: title,
+			Quantity:  quantity,
+			Amount:    order.Total,
+			Currency:  order.Currency,
+		},
+	})
+}
+
+// visitorHash identitas anonim pengunjung untuk rate limit:
+// HMAC-SHA256(secret, IP, user agent), IP tidak pernah disimpan
+func (uc *orderUseCase) visitorHash(visitor *service.VisitorInfo) string {
+	mac := hmac.New(sha256.New, []byte(uc.config.HashSecret))
+	mac.Write([]byte(visitor.IP))
+	mac.Write([]byte{0})
+	mac.Write([]byte(visitor.UserAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toOrderItemResponses(items []*entity.OrderItem) []*dto.OrderItemResponse {
+	responses := make([]*dto.OrderItemResponse, len(items))
+	for i, item := range items {
+		resp := &dto.OrderItemResponse{
+			Title:    item.Title,
+			Quantity: item.Quantity,
+			Subtotal: item.Subtotal(),
+		}
+		if item.CardID.Valid {
+			resp.CardID = &item.CardID.Int64
+		}
+		if item.UnitPrice.Valid {
+			resp.UnitPrice = &item.UnitPrice.Int64
+		}
+		responses[i] = resp
+	}
+	return responses
+}
+
+func toOrderResponse(order *entity.Order) *dto.OrderResponse {
+	resp := &dto.OrderResponse{
+		ID:              order.ID,
+		Ref:             order.Ref,
+		CatalogID:       order.CatalogID,
+		Name:            order.Name,
+		Email:           order.Email.String,
+		Phone:           order.Phone.String,
+		Note:            order.Note.String,
+		Status:          order.Status,
+		Items:           toOrderItemResponses(order.Items),
+		Total:           order.Total,
+		Currency:        order.Currency,
+		StatusUpdatedAt: order.StatusUpdatedAt,
+		CreatedAt:       order.CreatedAt,
+	}
+	if order.StatusUpdatedBy.Valid {
+		resp.StatusUpdatedBy = &order.StatusUpdatedBy.Int64
+	}
+	return resp
+}
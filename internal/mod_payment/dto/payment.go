@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+)
+
+// CreateSubscriptionPaymentRequest request pembayaran subscription plan
+type CreateSubscriptionPaymentRequest struct {
+	PlanID int64 `json:"plan_id" validate:"required,gt=0"`
+}
+
+// SubscriptionPaymentResponse response pembayaran subscription
+type SubscriptionPaymentResponse struct {
+	ID             int64      `json:"id"`
+	BusinessID     int64      `json:"business_id"`
+	PlanID         int64      `json:"plan_id"`
+	PlanName       string     `json:"plan_name,omitempty"`
+	SubscriptionID *int64     `json:"subscription_id,omitempty"` // terisi setelah pembayaran lunas
+	ExternalID     string     `json:"external_id"`
+	Gateway        string     `json:"gateway"`
+	Amount         int64      `json:"amount"`
+	Currency       string     `json:"currency"`
+	PaymentURL     string     `json:"payment_url,omitempty"` // hanya selama status pending
+	Status         string     `json:"status"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	PaidAt         *time.Time `json:"paid_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// PaymentCallbackRequest payload callback invoice dari payment gateway
+type PaymentCallbackRequest struct {
+	ID         string     `json:"id"`
+	ExternalID string     `json:"external_id" validate:"required"`
+	Status     string     `json:"status" validate:"required"`
+	Amount     int64      `json:"amount,omitempty"`
+	PaidAt     *time.Time `json:"paid_at,omitempty"`
+}
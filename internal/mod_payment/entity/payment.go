@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// SubscriptionPayment entity untuk tabel subscription_payments
+type SubscriptionPayment struct {
+	ID             int64          `json:"id" db:"sp_id"`
+	BusinessID     int64          `json:"business_id" db:"sp_b_id"`
+	PlanID         int64          `json:"plan_id" db:"sp_mp_id"`
+	SubscriptionID sql.NullInt64  `json:"subscription_id" db:"sp_bs_id"`
+	ExternalID     string         `json:"external_id" db:"sp_external_id"`
+	Gateway        string         `json:"gateway" db:"sp_gateway"`
+	GatewayID      sql.NullString `json:"gateway_id" db:"sp_gateway_id"`
+	Amount         int64          `json:"amount" db:"sp_amount"`
+	Currency       string         `json:"currency" db:"sp_currency"`
+	PaymentURL     string         `json:"payment_url" db:"sp_payment_url"`
+	Status         string         `json:"status" db:"sp_status"`
+	ExpiresAt      *time.Time     `json:"expires_at" db:"sp_expires_at"`
+	PaidAt         *time.Time     `json:"paid_at" db:"sp_paid_at"`
+	CreatedBy      int64          `json:"created_by" db:"sp_created_by"`
+	CreatedAt      time.Time      `json:"created_at" db:"sp_created_at"`
+	UpdatedAt      *time.Time     `json:"updated_at" db:"sp_updated_at"`
+
+	// Relations
+	PlanName string `json:"plan_name,omitempty" db:"mp_name"`
+}
+
+// TableName mendapatkan nama tabel
+func (SubscriptionPayment) TableName() string {
+	return "atamlink.subscription_payments"
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_payment/entity"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// PaymentRepository interface untuk payment repository
+type PaymentRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, payment *entity.SubscriptionPayment) error
+	GetByID(ctx context.Context, id int64) (*entity.SubscriptionPayment, error)
+	GetByExternalIDForUpdate(ctx context.Context, tx *sql.Tx, externalID string) (*entity.SubscriptionPayment, error)
+	ListByBusinessID(ctx context.Context, businessID int64, limit, offset int) ([]*entity.SubscriptionPayment, int64, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, payment *entity.SubscriptionPayment) error
+
+	// Helper aktivasi subscription
+	LockBusiness(ctx context.Context, tx *sql.Tx, businessID int64) error
+	PlanExpiry(ctx context.Context, tx *sql.Tx, planID int64, from time.Time) (time.Time, error)
+}
+
+type paymentRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentRepository membuat instance payment repository baru
+func NewPaymentRepository(db *sql.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+const paymentColumns = `
+	sp.sp_id, sp.sp_b_id, sp.sp_mp_id, sp.sp_bs_id, sp.sp_external_id,
+	sp.sp_gateway, sp.sp_gateway_id, sp.sp_amount, sp.sp_currency, sp.sp_payment_url,
+	sp.sp_status, sp.sp_expires_at, sp.sp_paid_at,
+	sp.sp_created_by, sp.sp_created_at, sp.sp_updated_at,
+	mp.mp_name`
+
+// Create simpan pembayaran subscription baru
+func (r *paymentRepository) Create(ctx context.Context, tx *sql.Tx, payment *entity.SubscriptionPayment) error {
+	query := `
+		INSERT INTO atamlink.subscription_payments (
+			sp_b_id, sp_mp_id, sp_external_id, sp_gateway, sp_gateway_id,
+			sp_amount, sp_currency, sp_payment_url, sp_status, sp_expires_at,
+			sp_created_by, sp_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING sp_id`
+
+	err := tx.QueryRowContext(ctx,
+		query,
+		payment.BusinessID,
+		payment.PlanID,
+		payment.ExternalID,
+		payment.Gateway,
+		payment.GatewayID,
+		payment.Amount,
+		payment.Currency,
+		payment.PaymentURL,
+		payment.Status,
+		payment.ExpiresAt,
+		payment.CreatedBy,
+		payment.CreatedAt,
+	).Scan(&payment.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create subscription payment")
+	}
+
+	return nil
+}
+
+// GetByID mendapatkan pembayaran subscription by ID
+func (r *paymentRepository) GetByID(ctx context.Context, id int64) (*entity.SubscriptionPayment, error) {
+	query := `SELECT ` + paymentColumns + `
+		FROM atamlink.subscription_payments sp
+		INNER JOIN atamlink.master_plans mp ON mp.mp_id = sp.sp_mp_id
+		WHERE sp.sp_id = $1`
+
+	payment, err := database.Get[entity.SubscriptionPayment](ctx, r.db, query, id)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgSubscriptionPaymentNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get subscription payment")
+	}
+
+	return payment, nil
+}
+
+// GetByExternalIDForUpdate ambil dan kunci pembayaran sampai transaksi selesai,
+// sehingga callback ganda dari gateway tidak mengaktifkan subscription dua kali
+func (r *paymentRepository) GetByExternalIDForUpdate(ctx context.Context, tx *sql.Tx, externalID string) (*entity.SubscriptionPayment, error) {
+	query := `SELECT ` + paymentColumns + `
+		FROM atamlink.subscription_payments sp
+		INNER JOIN atamlink.master_plans mp ON mp.mp_id = sp.sp_mp_id
+		WHERE sp.sp_external_id = $1
+		FOR UPDATE OF sp`
+
+	payment, err := database.Get[entity.SubscriptionPayment](ctx, tx, query, externalID)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgSubscriptionPaymentNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get subscription payment")
+	}
+
+	return payment, nil
+}
+
+// ListByBusinessID riwayat pembayaran subscription business, terbaru dulu
+func (r *paymentRepository) ListByBusinessID(ctx context.Context, businessID int64, limit, offset int) ([]*entity.SubscriptionPayment, int64, error) {
+	var total int64
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM atamlink.subscription_payments WHERE sp_b_id = $1`,
+		businessID,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count subscription payments")
+	}
+
+	query := `SELECT ` + paymentColumns + `
+		FROM atamlink.subscription_payments sp
+		INNER JOIN atamlink.master_plans mp ON mp.mp_id = sp.sp_mp_id
+		WHERE sp.sp_b_id = $1
+		ORDER BY sp.sp_created_at DESC, sp.sp_id DESC
+		LIMIT $2 OFFSET $3`
+
+	payments, err := database.Select[entity.SubscriptionPayment](ctx, r.db, query, businessID, limit, offset)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to list subscription payments")
+	}
+
+	return payments, total, nil
+}
+
+// UpdateStatus update status, waktu lunas dan subscription hasil pembayaran
+func (r *paymentRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, payment *entity.SubscriptionPayment) error {
+	query := `
+		UPDATE atamlink.subscription_payments SET
+			sp_status = $2,
+			sp_paid_at = $3,
+			sp_bs_id = $4,
+			sp_updated_at = $5
+		WHERE sp_id = $1`
+
+	result, err := tx.ExecContext(ctx,
+		query,
+		payment.ID,
+		payment.Status,
+		payment.PaidAt,
+		payment.SubscriptionID,
+		payment.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to update subscription payment status")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgSubscriptionPaymentNotFound, 404)
+	}
+
+	return nil
+}
+
+// LockBusiness kunci baris business agar aktivasi subscription per business berjalan berurutan
+func (r *paymentRepository) LockBusiness(ctx context.Context, tx *sql.Tx, businessID int64) error {
+	var id int64
+	err := tx.QueryRowContext(ctx,
+		`SELECT b_id FROM atamlink.businesses WHERE b_id = $1 FOR UPDATE`,
+		businessID,
+	).Scan(&id)
+	if err == sql.ErrNoRows {
+		return errors.New(errors.ErrBusinessNotFound, constant.ErrMsgBusinessNotFound, 404)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to lock business")
+	}
+
+	return nil
+}
+
+// PlanExpiry waktu berakhir subscription jika dimulai dari from, dihitung Postgres
+// dari interval mp_duration ("30 days", "1 mon", "1 year")
+func (r *paymentRepository) PlanExpiry(ctx context.Context, tx *sql.Tx, planID int64, from time.Time) (time.Time, error) {
+	var expiresAt time.Time
+	err := tx.QueryRowContext(ctx,
+		`SELECT $2::timestamp + mp_duration FROM atamlink.master_plans WHERE mp_id = $1`,
+		planID, from,
+	).Scan(&expiresAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, errors.New(errors.ErrPlanNotFound, constant.ErrMsgPlanNotFound, 404)
+	}
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to calculate plan expiry")
+	}
+
+	return expiresAt, nil
+}
@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	businessEntity "github.com/atam/atamlink/internal/mod_business/entity"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	masterRepo "github.com/atam/atamlink/internal/mod_master/repository"
+	"github.com/atam/atamlink/internal/mod_payment/dto"
+	"github.com/atam/atamlink/internal/mod_payment/entity"
+	"github.com/atam/atamlink/internal/mod_payment/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// PaymentUseCase interface untuk pembayaran subscription
+type PaymentUseCase interface {
+	CreateSubscriptionPayment(ctx *gin.Context, businessID, profileID int64, req *dto.CreateSubscriptionPaymentRequest) (*dto.SubscriptionPaymentResponse, error)
+	HandleCallback(ctx context.Context, callbackToken string, req *dto.PaymentCallbackRequest) error
+	List(ctx *gin.Context, businessID, profileID int64, page, perPage int) ([]*dto.SubscriptionPaymentResponse, int64, error)
+	GetByID(ctx *gin.Context, businessID, paymentID, profileID int64) (*dto.SubscriptionPaymentResponse, error)
+}
+
+type paymentUseCase struct {
+	db             *sql.DB
+	paymentRepo    repository.PaymentRepository
+	businessRepo   businessRepo.BusinessRepository
+	masterRepo     masterRepo.MasterRepository
+	paymentService service.PaymentService
+	clock          service.Clock
+}
+
+// NewPaymentUseCase membuat instance payment use case baru
+func NewPaymentUseCase(
+	db *sql.DB,
+	paymentRepo repository.PaymentRepository,
+	businessRepo businessRepo.BusinessRepository,
+	masterRepo masterRepo.MasterRepository,
+	paymentService service.PaymentService,
+	clock service.Clock,
+) PaymentUseCase {
+	return &paymentUseCase{
+		db:             db,
+		paymentRepo:    paymentRepo,
+		businessRepo:   businessRepo,
+		masterRepo:     masterRepo,
+		paymentService: paymentService,
+		clock:          clock,
+	}
+}
+
+// CreateSubscriptionPayment buat invoice gateway untuk plan, subscription baru aktif
+// setelah callback lunas diterima
+func (uc *paymentUseCase) CreateSubscriptionPayment(ctx *gin.Context, businessID, profileID int64, req *dto.CreateSubscriptionPaymentRequest) (*dto.SubscriptionPaymentResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermSubscriptionUpdate); err != nil {
+		return nil, err
+	}
+
+	business, err := uc.businessRepo.GetByID(utils.ContextFrom(ctx), businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.masterRepo.GetPlanByID(utils.ContextFrom(ctx), req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.IsActive {
+		return nil, errors.New(errors.ErrInvalidPlan, constant.ErrMsgPlanInactive, 400)
+	}
+	if plan.Price <= 0 {
+		return nil, errors.New(errors.ErrInvalidPlan, constant.ErrMsgPlanFree, 400)
+	}
+
+	payment := &entity.SubscriptionPayment{
+		BusinessID: business.ID,
+		PlanID:     plan.ID,
+		ExternalID: fmt.Sprintf("%s-%d-%s", constant.SubscriptionPaymentPrefix, business.ID, uuid.New().String()),
+		Gateway:    constant.PaymentGatewayXendit,
+		Amount:     int64(plan.Price),
+		Currency:   constant.CurrencyIDR,
+		Status:     constant.PaymentStatusPending,
+		CreatedBy:  profileID,
+		CreatedAt:  uc.clock.NowFor(business.ID),
+		PlanName:   plan.Name,
+	}
+
+	paymentLink, err := uc.paymentService.CreatePaymentLink(middleware.RequestContext(ctx), &service.PaymentLinkRequest{
+		ExternalID:  payment.ExternalID,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
+		Description: fmt.Sprintf("Subscription %s - %s", plan.Name, business.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payment.GatewayID = database.NullString(paymentLink.GatewayID)
+	payment.PaymentURL = paymentLink.URL
+	if !paymentLink.ExpiresAt.IsZero() {
+		payment.ExpiresAt = &paymentLink.ExpiresAt
+	}
+
+	// Invoice sudah dibuat di gateway, simpan walau client sudah putus
+	// agar callback pembayaran tetap menemukan pasangannya
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.paymentRepo.Create(context.Background(), tx, payment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toPaymentResponse(payment, payment.CreatedAt), nil
+}
+
+// HandleCallback catat status pembayaran dari callback gateway. Pembayaran lunas
+// mengaktifkan subscription dalam transaksi yang sama
+func (uc *paymentUseCase) HandleCallback(ctx context.Context, callbackToken string, req *dto.PaymentCallbackRequest) error {
+	if !uc.paymentService.VerifyCallback(callbackToken) {
+		return errors.New(errors.ErrUnauthorized, constant.ErrMsgPaymentCallbackInvalid, 401)
+	}
+
+	var status string
+	switch strings.ToUpper(req.Status) {
+	case "PAID", "SETTLED":
+		status = constant.PaymentStatusPaid
+	case "EXPIRED":
+		status = constant.PaymentStatusExpired
+	case "FAILED":
+		status = constant.PaymentStatusFailed
+	default:
+		status = constant.PaymentStatusPending
+	}
+
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	payment, err := uc.paymentRepo.GetByExternalIDForUpdate(ctx, tx, req.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	// Pembayaran yang sudah lunas tidak boleh turun status karena callback terlambat
+	if payment.Status == constant.PaymentStatusPaid || payment.Status == status {
+		return nil
+	}
+
+	if status == constant.PaymentStatusPaid {
+		if req.Amount > 0 && req.Amount != payment.Amount {
+			return errors.New(errors.ErrValidation, constant.ErrMsgPaymentAmountMismatch, 400)
+		}
+
+		paidAt := uc.clock.NowFor(payment.BusinessID)
+		if req.PaidAt != nil {
+			paidAt = *req.PaidAt
+		}
+		payment.PaidAt = &paidAt
+
+		subscriptionID, err := uc.activateSubscription(ctx, tx, payment)
+		if err != nil {
+			return err
+		}
+		payment.SubscriptionID = sql.NullInt64{Int64: subscriptionID, Valid: true}
+	}
+
+	updatedAt := uc.clock.NowFor(payment.BusinessID)
+	payment.Status = status
+	payment.UpdatedAt = &updatedAt
+	if err := uc.paymentRepo.UpdateStatus(ctx, tx, payment); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// activateSubscription subscription aktif dengan plan yang sama diperpanjang dari
+// tanggal berakhirnya, plan berbeda dibatalkan lalu diganti subscription baru
+func (uc *paymentUseCase) activateSubscription(ctx context.Context, tx *sql.Tx, payment *entity.SubscriptionPayment) (int64, error) {
+	if err := uc.paymentRepo.LockBusiness(ctx, tx, payment.BusinessID); err != nil {
+		return 0, err
+	}
+
+	now := uc.clock.NowFor(payment.BusinessID)
+	current, err := uc.businessRepo.GetActiveSubscription(ctx, payment.BusinessID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if current != nil && current.PlanID == payment.PlanID {
+		expiresAt, err := uc.paymentRepo.PlanExpiry(ctx, tx, payment.PlanID, current.ExpiresAt)
+		if err != nil {
+			return 0, err
+		}
+		current.ExpiresAt = expiresAt
+		if err := uc.businessRepo.UpdateSubscription(ctx, tx, current); err != nil {
+			return 0, err
+		}
+		return current.ID, nil
+	}
+
+	if current != nil {
+		current.Status = constant.SubscriptionStatusCancelled
+		if err := uc.businessRepo.UpdateSubscription(ctx, tx, current); err != nil {
+			return 0, err
+		}
+	}
+
+	expiresAt, err := uc.paymentRepo.PlanExpiry(ctx, tx, payment.PlanID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	subscription := &businessEntity.BusinessSubscription{
+		BusinessID: payment.BusinessID,
+		PlanID:     payment.PlanID,
+		Status:     constant.SubscriptionStatusActive,
+		StartsAt:   now,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  now,
+	}
+	if err := uc.businessRepo.CreateSubscription(ctx, tx, subscription); err != nil {
+		return 0, err
+	}
+
+	return subscription.ID, nil
+}
+
+// List riwayat pembayaran subscription business
+func (uc *paymentUseCase) List(ctx *gin.Context, businessID, profileID int64, page, perPage int) ([]*dto.SubscriptionPaymentResponse, int64, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermSubscriptionView); err != nil {
+		return nil, 0, err
+	}
+
+	payments, total, err := uc.paymentRepo.ListByBusinessID(utils.ContextFrom(ctx), businessID, perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := uc.clock.NowFor(businessID)
+	responses := make([]*dto.SubscriptionPaymentResponse, len(payments))
+	for i, payment := range payments {
+		responses[i] = toPaymentResponse(payment, now)
+	}
+
+	return responses, total, nil
+}
+
+// GetByID detail satu pembayaran subscription business
+func (uc *paymentUseCase) GetByID(ctx *gin.Context, businessID, paymentID, profileID int64) (*dto.SubscriptionPaymentResponse, error) {
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, businessID, profileID, constant.PermSubscriptionView); err != nil {
+		return nil, err
+	}
+
+	payment, err := uc.paymentRepo.GetByID(utils.ContextFrom(ctx), paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.BusinessID != businessID {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgSubscriptionPaymentNotFound, 404)
+	}
+
+	return toPaymentResponse(payment, uc.clock.NowFor(businessID)), nil
+}
+
+// toPaymentResponse pembayaran pending yang invoice-nya sudah lewat masa berlaku
+// pada waktu now ditampilkan expired walau callback gateway belum diterima
+func toPaymentResponse(payment *entity.SubscriptionPayment, now time.Time) *dto.SubscriptionPaymentResponse {
+	resp := &dto.SubscriptionPaymentResponse{
+		ID:         payment.ID,
+		BusinessID: payment.BusinessID,
+		PlanID:     payment.PlanID,
+		PlanName:   payment.PlanName,
+		ExternalID: payment.ExternalID,
+		Gateway:    payment.Gateway,
+		Amount:     payment.Amount,
+		Currency:   payment.Currency,
+		Status:     payment.Status,
+		ExpiresAt:  payment.ExpiresAt,
+		PaidAt:     payment.PaidAt,
+		CreatedAt:  payment.CreatedAt,
+	}
+	if payment.Status == constant.PaymentStatusPending {
+		if payment.ExpiresAt != nil && !now.Before(*payment.ExpiresAt) {
+			resp.Status = constant.PaymentStatusExpired
+		} else {
+			resp.PaymentURL = payment.PaymentURL
+		}
+	}
+	if payment.SubscriptionID.Valid {
+		subscriptionID := payment.SubscriptionID.Int64
+		resp.SubscriptionID = &subscriptionID
+	}
+	return resp
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// CreateReviewRequest request ulasan publik dari pengunjung
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Name   string `json:"name,omitempty" validate:"omitempty,max=100"`
+	Body   string `json:"body,omitempty" validate:"omitempty,max=2000"`
+	Token  string `json:"token,omitempty"` // analytics_token dari response katalog publik
+}
+
+// ModerateReviewRequest request approve / reject ulasan
+type ModerateReviewRequest struct {
+	Status string `json:"status" validate:"required,oneof=approved rejected"`
+}
+
+// ReplyReviewRequest request balasan pemilik, kosong untuk menghapus balasan
+type ReplyReviewRequest struct {
+	Reply string `json:"reply" validate:"max=2000"`
+}
+
+// ReviewFilter filter daftar ulasan untuk pemilik
+type ReviewFilter struct {
+	Status string
+}
+
+// PublicReviewResponse ulasan yang tampil di halaman publik
+type PublicReviewResponse struct {
+	ID        int64      `json:"id"`
+	Rating    int        `json:"rating"`
+	Name      string     `json:"name,omitempty"`
+	Body      string     `json:"body,omitempty"`
+	Reply     string     `json:"reply,omitempty"`
+	RepliedAt *time.Time `json:"replied_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ReviewResponse ulasan lengkap untuk pemilik katalog
+type ReviewResponse struct {
+	ID          int64      `json:"id"`
+	CatalogID   int64      `json:"catalog_id"`
+	Rating      int        `json:"rating"`
+	Name        string     `json:"name,omitempty"`
+	Body        string     `json:"body,omitempty"`
+	Status      string     `json:"status"`
+	Reply       string     `json:"reply,omitempty"`
+	RepliedBy   *int64     `json:"replied_by,omitempty"`
+	RepliedAt   *time.Time `json:"replied_at,omitempty"`
+	ModeratedBy *int64     `json:"moderated_by,omitempty"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
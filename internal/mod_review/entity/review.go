@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Review entity untuk tabel catalog_reviews
+type Review struct {
+	ID          int64          `json:"id" db:"cr_id"`
+	CatalogID   int64          `json:"catalog_id" db:"cr_c_id"`
+	Rating      int            `json:"rating" db:"cr_rating"`
+	Name        sql.NullString `json:"name" db:"cr_name"`
+	Body        sql.NullString `json:"body" db:"cr_body"`
+	Status      string         `json:"status" db:"cr_status"`
+	VisitorHash string         `json:"-" db:"cr_visitor_hash"`
+	Reply       sql.NullString `json:"reply" db:"cr_reply"`
+	RepliedBy   sql.NullInt64  `json:"replied_by" db:"cr_replied_by"`
+	RepliedAt   *time.Time     `json:"replied_at" db:"cr_replied_at"`
+	ModeratedBy sql.NullInt64  `json:"moderated_by" db:"cr_moderated_by"`
+	ModeratedAt *time.Time     `json:"moderated_at" db:"cr_moderated_at"`
+	CreatedAt   time.Time      `json:"created_at" db:"cr_created_at"`
+}
+
+// TableName mendapatkan nama tabel
+func (Review) TableName() string { return "atamlink.catalog_reviews" }
+
+// IsApproved check apakah ulasan sudah disetujui dan tampil publik
+func (r *Review) IsApproved() bool {
+	return r.Status == "approved"
+}
+
+// PublicCatalog status katalog untuk validasi ulasan publik
+type PublicCatalog struct {
+	ID               int64
+	BusinessID       int64
+	Slug             string
+	Title            string
+	IsActive         bool
+	Status           string
+	IsArchived       bool
+	BusinessIsActive bool
+}
+
+// IsReviewable hanya katalog yang tampil publik yang bisa diulas
+func (c *PublicCatalog) IsReviewable() bool {
+	return c.IsActive && c.Status == "published" && !c.IsArchived && c.BusinessIsActive
+}
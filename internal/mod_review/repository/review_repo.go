@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_review/entity"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// ReviewRepository interface untuk review repository
+type ReviewRepository interface {
+	GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error)
+	Create(tx *sql.Tx, review *entity.Review) error
+	GetByID(id int64) (*entity.Review, error)
+	List(filter ListFilter) ([]*entity.Review, int64, error)
+	CountByVisitorSince(visitorHash string, since time.Time) (int, error)
+	HasVisitorReviewed(catalogID int64, visitorHash string) (bool, error)
+	UpdateStatus(tx *sql.Tx, id int64, status string, profileID int64) error
+	UpdateReply(tx *sql.Tx, id int64, reply sql.NullString, profileID int64) error
+	RecalculateRating(tx *sql.Tx, catalogID int64) error
+}
+
+// ListFilter filter untuk list ulasan
+type ListFilter struct {
+	CatalogID int64
+	Status    string
+	Limit     int
+	Offset    int
+	OrderBy   string
+}
+
+type reviewRepository struct {
+	db *sql.DB
+}
+
+// NewReviewRepository membuat instance review repository baru
+func NewReviewRepository(db *sql.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+var reviewColumns = []string{
+	"cr.cr_id", "cr.cr_c_id", "cr.cr_rating", "cr.cr_name", "cr.cr_body",
+	"cr.cr_status", "cr.cr_visitor_hash", "cr.cr_reply", "cr.cr_replied_by",
+	"cr.cr_replied_at", "cr.cr_moderated_by", "cr.cr_moderated_at", "cr.cr_created_at",
+}
+
+// GetPublicCatalogBySlug status katalog dan business untuk validasi ulasan publik
+func (r *reviewRepository) GetPublicCatalogBySlug(slug string) (*entity.PublicCatalog, error) {
+	query := `
+		SELECT c.c_id, c.c_b_id, c.c_slug, c.c_title, c.c_is_active, c.c_status,
+			c.c_archived_at IS NOT NULL, b.b_is_active
+		FROM atamlink.catalogs c
+		INNER JOIN atamlink.businesses b ON b.b_id = c.c_b_id
+		WHERE c.c_slug = $1`
+
+	catalog := &entity.PublicCatalog{}
+	err := r.db.QueryRow(query, slug).Scan(
+		&catalog.ID,
+		&catalog.BusinessID,
+		&catalog.Slug,
+		&catalog.Title,
+		&catalog.IsActive,
+		&catalog.Status,
+		&catalog.IsArchived,
+		&catalog.BusinessIsActive,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get catalog by slug")
+	}
+
+	return catalog, nil
+}
+
+// Create membuat ulasan baru
+func (r *reviewRepository) Create(tx *sql.Tx, review *entity.Review) error {
+	query := `
+		INSERT INTO atamlink.catalog_reviews (
+			cr_c_id, cr_rating, cr_name, cr_body, cr_status, cr_visitor_hash, cr_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING cr_id`
+
+	err := tx.QueryRow(
+		query,
+		review.CatalogID,
+		review.Rating,
+		review.Name,
+		review.Body,
+		review.Status,
+		review.VisitorHash,
+		review.CreatedAt,
+	).Scan(&review.ID)
+
+	if err != nil {
+		return errors.Wrap(err, "failed to create review")
+	}
+
+	return nil
+}
+
+// GetByID mendapatkan ulasan by ID
+func (r *reviewRepository) GetByID(id int64) (*entity.Review, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(reviewColumns...).From("atamlink.catalog_reviews cr")
+	qb.Where("cr.cr_id = ?", id)
+
+	query, args := qb.Build()
+	review, err := scanReview(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgReviewNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get review")
+	}
+
+	return review, nil
+}
+
+// List mendapatkan ulasan katalog dengan filter status
+func (r *reviewRepository) List(filter ListFilter) ([]*entity.Review, int64, error) {
+	qb := database.NewQueryBuilder()
+	qb.Select(reviewColumns...).From("atamlink.catalog_reviews cr")
+	qb.Where("cr.cr_c_id = ?", filter.CatalogID)
+
+	if filter.Status != "" {
+		qb.Where("cr.cr_status = ?", filter.Status)
+	}
+
+	// Count total
+	countQuery, countArgs := qb.BuildCount()
+	var total int64
+	if err := r.db.QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count reviews")
+	}
+
+	qb.OrderBy(filter.OrderBy + ", cr.cr_id DESC")
+	qb.Limit(filter.Limit)
+	qb.Offset(filter.Offset)
+
+	query, args := qb.Build()
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to query reviews")
+	}
+	defer rows.Close()
+
+	reviews := make([]*entity.Review, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed to scan review")
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, total, rows.Err()
+}
+
+// CountByVisitorSince jumlah ulasan pengunjung (semua katalog) sejak waktu tertentu
+func (r *reviewRepository) CountByVisitorSince(visitorHash string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM atamlink.catalog_reviews
+		WHERE cr_visitor_hash = $1 AND cr_created_at >= $2`
+
+	var count int
+	if err := r.db.QueryRow(query, visitorHash, since).Scan(&count); err != nil {
+		return 0, errors.Wrap(err, "failed to count visitor reviews")
+	}
+
+	return count, nil
+}
+
+// HasVisitorReviewed check apakah pengunjung sudah pernah mengulas katalog (selain yang ditolak)
+func (r *reviewRepository) HasVisitorReviewed(catalogID int64, visitorHash string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM atamlink.catalog_reviews
+			WHERE cr_c_id = $1 AND cr_visitor_hash = $2 AND cr_status <> $3
+		)`
+
+	var exists bool
+	if err := r.db.QueryRow(query, catalogID, visitorHash, constant.ReviewStatusRejected).Scan(&exists); err != nil {
+		return false, errors.Wrap(err, "failed to check visitor review")
+	}
+
+	return exists, nil
+}
+
+// UpdateStatus approve / reject ulasan
+func (r *reviewRepository) UpdateStatus(tx *sql.Tx, id int64, status string, profileID int64) error {
+	query := `
+		UPDATE atamlink.catalog_reviews SET
+			cr_status = $2,
+			cr_moderated_by = $3,
+			cr_moderated_at = $4
+		WHERE cr_id = $1`
+
+	return r.execUpdate(tx, query, id, status, profileID, time.Now())
+}
+
+// UpdateReply simpan atau hapus balasan pemilik
+func (r *reviewRepository) UpdateReply(tx *sql.Tx, id int64, reply sql.NullString, profileID int64) error {
+	var repliedBy sql.NullInt64
+	var repliedAt *time.Time
+	if reply.Valid {
+		now := time.Now()
+		repliedBy = sql.NullInt64{Int64: profileID, Valid: true}
+		repliedAt = &now
+	}
+
+	query := `
+		UPDATE atamlink.catalog_reviews SET
+			cr_reply = $2,
+			cr_replied_by = $3,
+			cr_replied_at = $4
+		WHERE cr_id = $1`
+
+	return r.execUpdate(tx, query, id, reply, repliedBy, repliedAt)
+}
+
+// RecalculateRating hitung ulang agregat rating katalog dari ulasan approved
+func (r *reviewRepository) RecalculateRating(tx *sql.Tx, catalogID int64) error {
+	query := `
+		UPDATE atamlink.catalogs c SET
+			c_rating_avg = COALESCE(agg.avg, 0),
+			c_rating_count = agg.count
+		FROM (
+			SELECT ROUND(AVG(cr_rating), 2) AS avg, COUNT(*) AS count
+			FROM atamlink.catalog_reviews
+			WHERE cr_c_id = $1 AND cr_status = $2
+		) agg
+		WHERE c.c_id = $1`
+
+	if _, err := tx.Exec(query, catalogID, constant.ReviewStatusApproved); err != nil {
+		return errors.Wrap(err, "failed to recalculate catalog rating")
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) execUpdate(tx *sql.Tx, query string, args ...interface{}) error {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to update review")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to check rows affected")
+	}
+
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgReviewNotFound, 404)
+	}
+
+	return nil
+}
+
+// rowScanner abstraksi *sql.Row dan *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanReview scan satu baris ulasan
+func scanReview(row rowScanner) (*entity.Review, error) {
+	review := &entity.Review{}
+	err := row.Scan(
+		&review.ID,
+		&review.CatalogID,
+		&review.Rating,
+		&review.Name,
+		&review.Body,
+		&review.Status,
+		&review.VisitorHash,
+		&review.Reply,
+		&review.RepliedBy,
+		&review.RepliedAt,
+		&review.ModeratedBy,
+		&review.ModeratedAt,
+		&review.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
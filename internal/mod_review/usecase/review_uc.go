@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/internal/mod_review/dto"
+	"github.com/atam/atamlink/internal/mod_review/entity"
+	"github.com/atam/atamlink/internal/mod_review/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/utils"
+)
+
+// ReviewUseCase interface untuk review use case
+type ReviewUseCase interface {
+	Submit(ctx *gin.Context, slug string, visitor *service.VisitorInfo, req *dto.CreateReviewRequest) (*dto.PublicReviewResponse, error)
+	ListPublic(slug string, page, perPage int, orderBy string) ([]*dto.PublicReviewResponse, int64, error)
+	List(ctx *gin.Context, catalogID, profileID int64, filter *dto.ReviewFilter, page, perPage int, orderBy string) ([]*dto.ReviewResponse, int64, error)
+	Moderate(ctx *gin.Context, reviewID, profileID int64, req *dto.ModerateReviewRequest) (*dto.ReviewResponse, error)
+	Reply(ctx *gin.Context, reviewID, profileID int64, req *dto.ReplyReviewRequest) (*dto.ReviewResponse, error)
+}
+
+type reviewUseCase struct {
+	db                  *sql.DB
+	reviewRepo          repository.ReviewRepository
+	catalogRepo         catalogRepo.CatalogRepository
+	businessRepo        businessRepo.BusinessRepository
+	botFilter           service.BotFilter
+	notificationService service.NotificationService
+	cacheService        service.CacheInvalidationService
+	config              config.ReviewConfig
+}
+
+// NewReviewUseCase membuat instance review use case baru
+func NewReviewUseCase(
+	db *sql.DB,
+	reviewRepo repository.ReviewRepository,
+	catalogRepo catalogRepo.CatalogRepository,
+	businessRepo businessRepo.BusinessRepository,
+	botFilter service.BotFilter,
+	notificationService service.NotificationService,
+	cacheService service.CacheInvalidationService,
+	cfg config.ReviewConfig,
+) ReviewUseCase {
+	return &reviewUseCase{
+		db:                  db,
+		reviewRepo:          reviewRepo,
+		catalogRepo:         catalogRepo,
+		businessRepo:        businessRepo,
+		botFilter:           botFilter,
+		notificationService: notificationService,
+		cacheService:        cacheService,
+		config:              cfg,
+	}
+}
+
+// Submit simpan ulasan publik sebagai pending, tampil setelah disetujui pemilik
+func (uc *reviewUseCase) Submit(ctx *gin.Context, slug string, visitor *service.VisitorInfo, req *dto.CreateReviewRequest) (*dto.PublicReviewResponse, error) {
+	catalog, err := uc.reviewRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if !catalog.IsReviewable() {
+		return nil, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	if uc.botFilter.IsBot(visitor, req.Token, catalog.ID) {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgReviewRejected, 400)
+	}
+
+	visitorHash := uc.visitorHash(visitor)
+	now := time.Now()
+
+	// Rate limit per pengunjung lintas katalog
+	if uc.config.RateLimit > 0 {
+		count, err := uc.reviewRepo.CountByVisitorSince(visitorHash, now.Add(-uc.config.RateWindow))
+		if err != nil {
+			return nil, err
+		}
+		if count >= uc.config.RateLimit {
+			return nil, errors.New(errors.ErrRateLimited, constant.ErrMsgReviewRateLimited, 429)
+		}
+	}
+
+	reviewed, err := uc.reviewRepo.HasVisitorReviewed(catalog.ID, visitorHash)
+	if err != nil {
+		return nil, err
+	}
+	if reviewed {
+		return nil, errors.New(errors.ErrConflict, constant.ErrMsgReviewDuplicate, 409)
+	}
+
+	review := &entity.Review{
+		CatalogID:   catalog.ID,
+		Rating:      req.Rating,
+		Name:        database.NullString(strings.TrimSpace(req.Name)),
+		Body:        database.NullString(strings.TrimSpace(req.Body)),
+		Status:      constant.ReviewStatusPending,
+		VisitorHash: visitorHash,
+		CreatedAt:   now,
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.reviewRepo.Create(tx, review); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.notificationService.Notify(&service.Notification{
+		BusinessID: catalog.BusinessID,
+		Event:      constant.NotificationEventNewReview,
+		RequestID:  middleware.GetRequestID(ctx),
+		Review: &service.ReviewNotification{
+			CatalogTitle: catalog.Title,
+			Name:         review.Name.String,
+			Rating:       review.Rating,
+			Body:         review.Body.String,
+		},
+	})
+
+	return toPublicReviewResponse(review), nil
+}
+
+// ListPublic daftar ulasan approved untuk halaman katalog publik
+func (uc *reviewUseCase) ListPublic(slug string, page, perPage int, orderBy string) ([]*dto.PublicReviewResponse, int64, error) {
+	catalog, err := uc.reviewRepo.GetPublicCatalogBySlug(slug)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !catalog.IsReviewable() {
+		return nil, 0, errors.New(errors.ErrCatalogNotFound, constant.ErrMsgCatalogNotFound, 404)
+	}
+
+	reviews, total, err := uc.reviewRepo.List(repository.ListFilter{
+		CatalogID: catalog.ID,
+		Status:    constant.ReviewStatusApproved,
+		Limit:     perPage,
+		Offset:    (page - 1) * perPage,
+		OrderBy:   orderBy,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.PublicReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = toPublicReviewResponse(review)
+	}
+
+	return responses, total, nil
+}
+
+// List daftar ulasan katalog untuk moderasi pemilik
+func (uc *reviewUseCase) List(ctx *gin.Context, catalogID, profileID int64, filter *dto.ReviewFilter, page, perPage int, orderBy string) ([]*dto.ReviewResponse, int64, error) {
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), catalogID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogView); err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Status != "" && !constant.IsValidReviewStatus(filter.Status) {
+		return nil, 0, errors.New(errors.ErrValidation, constant.ErrMsgReviewStatusInvalid, 400)
+	}
+
+	reviews, total, err := uc.reviewRepo.List(repository.ListFilter{
+		CatalogID: catalog.ID,
+		Status:    filter.Status,
+		Limit:     perPage,
+		Offset:    (page - 1) * perPage,
+		OrderBy:   orderBy,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = toReviewResponse(review)
+	}
+
+	return responses, total, nil
+}
+
+// Moderate approve / reject ulasan lalu hitung ulang agregat rating katalog
+func (uc *reviewUseCase) Moderate(ctx *gin.Context, reviewID, profileID int64, req *dto.ModerateReviewRequest) (*dto.ReviewResponse, error) {
+	review, err := uc.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, review)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), review.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.reviewRepo.UpdateStatus(tx, review.ID, req.Status, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.reviewRepo.RecalculateRating(tx, catalog.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	uc.cacheService.InvalidateCatalog(catalog.Slug)
+
+	return uc.reload(review.ID)
+}
+
+// Reply simpan balasan pemilik pada ulasan yang sudah disetujui
+func (uc *reviewUseCase) Reply(ctx *gin.Context, reviewID, profileID int64, req *dto.ReplyReviewRequest) (*dto.ReviewResponse, error) {
+	review, err := uc.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, review)
+	}
+
+	catalog, err := uc.catalogRepo.GetByID(utils.ContextFrom(ctx), review.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.CheckBusinessAccess(utils.ContextFrom(ctx), uc.businessRepo, catalog.BusinessID, profileID, constant.PermCatalogUpdate); err != nil {
+		return nil, err
+	}
+
+	reply := database.NullString(strings.TrimSpace(req.Reply))
+	if reply.Valid && !review.IsApproved() {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgReviewNotApproved, 400)
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.reviewRepo.UpdateReply(tx, review.ID, reply, profileID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	if review.IsApproved() {
+		uc.cacheService.InvalidateCatalog(catalog.Slug)
+	}
+
+	return uc.reload(review.ID)
+}
+
+func (uc *reviewUseCase) reload(reviewID int64) (*dto.ReviewResponse, error) {
+	review, err := uc.reviewRepo.GetByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return toReviewResponse(review), nil
+}
+
+// visitorHash identitas anonim pengunjung untuk rate limit dan cegah ulasan ganda:
+// HMAC-SHA256(secret, IP, user agent), IP tidak pernah disimpan
+func (uc *reviewUseCase) visitorHash(visitor *service.VisitorInfo) string {
+	mac := hmac.New(sha256.New, []byte(uc.config.HashSecret))
+	mac.Write([]byte(visitor.IP))
+	mac.Write([]byte{0})
+	mac.Write([]byte(visitor.UserAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toPublicReviewResponse(review *entity.Review) *dto.PublicReviewResponse {
+	return &dto.PublicReviewResponse{
+		ID:        review.ID,
+		Rating:    review.Rating,
+		Name:      review.Name.String,
+		Body:      review.Body.String,
+		Reply:     review.Reply.String,
+		RepliedAt: review.RepliedAt,
+		CreatedAt: review.CreatedAt,
+	}
+}
+
+func toReviewResponse(review *entity.Review) *dto.ReviewResponse {
+	resp := &dto.ReviewResponse{
+		ID:          review.ID,
+		CatalogID:   review.CatalogID,
+		Rating:      review.Rating,
+		Name:        review.Name.String,
+		Body:        review.Body.String,
+		Status:      review.Status,
+		Reply:       review.Reply.String,
+		RepliedAt:   review.RepliedAt,
+		ModeratedAt: review.ModeratedAt,
+		CreatedAt:   review.CreatedAt,
+	}
+	if review.RepliedBy.Valid {
+		resp.RepliedBy = &review.RepliedBy.Int64
+	}
+	if review.ModeratedBy.Valid {
+		resp.ModeratedBy = &review.ModeratedBy.Int64
+	}
+	return resp
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// StatusResponse data status page publik
+type StatusResponse struct {
+	Status     string              `json:"status"` // operational, degraded, outage
+	UpdatedAt  time.Time           `json:"updated_at"`
+	Components []ComponentResponse `json:"components"`
+	Incidents  []IncidentResponse  `json:"incidents"` // aktif dan yang baru selesai
+}
+
+// ComponentResponse status satu komponen. Uptime dalam persen, null jika
+// belum ada health check di rentang tersebut
+type ComponentResponse struct {
+	Name          string     `json:"name"`
+	Status        string     `json:"status"` // operational, degraded, outage, unknown
+	LatencyMs     int        `json:"latency_ms"`
+	LastCheckedAt *time.Time `json:"last_checked_at"`
+	Uptime24h     *float64   `json:"uptime_24h"`
+	Uptime7d      *float64   `json:"uptime_7d"`
+	Uptime30d     *float64   `json:"uptime_30d"`
+}
+
+// CreateIncidentRequest request untuk membuat insiden
+type CreateIncidentRequest struct {
+	Title      string     `json:"title" validate:"required,min=3,max=200"`
+	Message    string     `json:"message,omitempty" validate:"max=5000"`
+	Status     string     `json:"status" validate:"required,oneof=investigating identified monitoring resolved"`
+	Impact     string     `json:"impact" validate:"required,oneof=minor major critical"`
+	Components []string   `json:"components" validate:"required,min=1,dive,oneof=api uploads public_pages"`
+	StartedAt  *time.Time `json:"started_at,omitempty"` // default sekarang
+}
+
+// UpdateIncidentRequest request untuk update insiden. Status resolved
+// mengisi resolved_at, status lain mengosongkannya kembali
+type UpdateIncidentRequest struct {
+	Title      string     `json:"title,omitempty" validate:"omitempty,min=3,max=200"`
+	Message    *string    `json:"message,omitempty" validate:"omitempty,max=5000"`
+	Status     string     `json:"status,omitempty" validate:"omitempty,oneof=investigating identified monitoring resolved"`
+	Impact     string     `json:"impact,omitempty" validate:"omitempty,oneof=minor major critical"`
+	Components []string   `json:"components,omitempty" validate:"omitempty,min=1,dive,oneof=api uploads public_pages"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+}
+
+// IncidentResponse response insiden
+type IncidentResponse struct {
+	ID         int64      `json:"id"`
+	Title      string     `json:"title"`
+	Message    string     `json:"message,omitempty"`
+	Status     string     `json:"status"`
+	Impact     string     `json:"impact"`
+	Components []string   `json:"components"`
+	StartedAt  time.Time  `json:"started_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"database/sql"
+	"time"
+)
+
+// StatusCheck entity untuk tabel status_checks
+type StatusCheck struct {
+	ID        int64          `json:"id" db:"sc_id"`
+	Component string         `json:"component" db:"sc_component"`
+	IsUp      bool           `json:"is_up" db:"sc_is_up"`
+	LatencyMs int            `json:"latency_ms" db:"sc_latency_ms"`
+	Error     sql.NullString `json:"error" db:"sc_error"`
+	CheckedAt time.Time      `json:"checked_at" db:"sc_checked_at"`
+}
+
+// StatusIncident entity untuk tabel status_incidents
+type StatusIncident struct {
+	ID         int64          `json:"id" db:"si_id"`
+	Title      string         `json:"title" db:"si_title"`
+	Message    sql.NullString `json:"message" db:"si_message"`
+	Status     string         `json:"status" db:"si_status"`
+	Impact     string         `json:"impact" db:"si_impact"`
+	Components []string       `json:"components" db:"si_components"`
+	StartedAt  time.Time      `json:"started_at" db:"si_started_at"`
+	ResolvedAt *time.Time     `json:"resolved_at" db:"si_resolved_at"`
+	CreatedAt  time.Time      `json:"created_at" db:"si_created_at"`
+	UpdatedAt  *time.Time     `json:"updated_at" db:"si_updated_at"`
+}
+
+// IsResolved check apakah insiden sudah selesai
+func (i *StatusIncident) IsResolved() bool {
+	return i.ResolvedAt != nil
+}
+
+// Affects check apakah insiden berdampak ke komponen
+func (i *StatusIncident) Affects(component string) bool {
+	for _, c := range i.Components {
+		if c == component {
+			return true
+		}
+	}
+	return false
+}
+
+// ComponentUptime jumlah health check komponen dalam satu rentang
+type ComponentUptime struct {
+	Component string
+	Total     int64
+	Up        int64
+}
+
+// Percent persentase uptime, nil jika belum ada health check
+func (u *ComponentUptime) Percent() *float64 {
+	if u == nil || u.Total == 0 {
+		return nil
+	}
+	percent := float64(u.Up) / float64(u.Total) * 100
+	return &percent
+}
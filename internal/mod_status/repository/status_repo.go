@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_status/entity"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// StatusRepository interface untuk status page repository
+type StatusRepository interface {
+	// Health check methods
+	CreateCheck(check *entity.StatusCheck) error
+	GetLatestChecks() (map[string]*entity.StatusCheck, error)
+	GetUptime(since time.Time) (map[string]*entity.ComponentUptime, error)
+	DeleteChecksBefore(before time.Time) (int64, error)
+
+	// Incident methods
+	CreateIncident(tx *sql.Tx, incident *entity.StatusIncident) error
+	UpdateIncident(tx *sql.Tx, incident *entity.StatusIncident) error
+	DeleteIncident(tx *sql.Tx, id int64) error
+	GetIncidentByID(id int64) (*entity.StatusIncident, error)
+	ListIncidents(limit, offset int) ([]*entity.StatusIncident, int64, error)
+	ListRecentIncidents(resolvedSince time.Time) ([]*entity.StatusIncident, error)
+}
+
+type statusRepository struct {
+	db *sql.DB
+}
+
+// NewStatusRepository membuat instance status repository baru
+func NewStatusRepository(db *sql.DB) StatusRepository {
+	return &statusRepository{db: db}
+}
+
+const incidentColumns = `
+	si_id, si_title, si_message, si_status, si_impact, si_components,
+	si_started_at, si_resolved_at, si_created_at, si_updated_at`
+
+// CreateCheck simpan hasil health check komponen
+func (r *statusRepository) CreateCheck(check *entity.StatusCheck) error {
+	query := `
+		INSERT INTO atamlink.status_checks (sc_component, sc_is_up, sc_latency_ms, sc_error, sc_checked_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING sc_id`
+
+	err := r.db.QueryRow(
+		query,
+		check.Component,
+		check.IsUp,
+		check.LatencyMs,
+		check.Error,
+		check.CheckedAt,
+	).Scan(&check.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to create status check")
+	}
+
+	return nil
+}
+
+// GetLatestChecks health check terakhir per komponen
+func (r *statusRepository) GetLatestChecks() (map[string]*entity.StatusCheck, error) {
+	query := `
+		SELECT DISTINCT ON (sc_component)
+			sc_id, sc_component, sc_is_up, sc_latency_ms, sc_error, sc_checked_at
+		FROM atamlink.status_checks
+		ORDER BY sc_component, sc_checked_at DESC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest status checks")
+	}
+	defer rows.Close()
+
+	checks := make(map[string]*entity.StatusCheck)
+	for rows.Next() {
+		check := &entity.StatusCheck{}
+		err := rows.Scan(
+			&check.ID,
+			&check.Component,
+			&check.IsUp,
+			&check.LatencyMs,
+			&check.Error,
+			&check.CheckedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan status check")
+		}
+		checks[check.Component] = check
+	}
+
+	return checks, nil
+}
+
+// GetUptime jumlah health check (total dan up) per komponen sejak waktu tertentu
+func (r *statusRepository) GetUptime(since time.Time) (map[string]*entity.ComponentUptime, error) {
+	query := `
+		SELECT sc_component, COUNT(*), COUNT(*) FILTER (WHERE sc_is_up)
+		FROM atamlink.status_checks
+		WHERE sc_checked_at >= $1
+		GROUP BY sc_component`
+
+	rows, err := r.db.Query(query, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get uptime")
+	}
+	defer rows.Close()
+
+	uptimes := make(map[string]*entity.ComponentUptime)
+	for rows.Next() {
+		uptime := &entity.ComponentUptime{}
+		if err := rows.Scan(&uptime.Component, &uptime.Total, &uptime.Up); err != nil {
+			return nil, errors.Wrap(err, "failed to scan uptime")
+		}
+		uptimes[uptime.Component] = uptime
+	}
+
+	return uptimes, nil
+}
+
+// DeleteChecksBefore hapus riwayat health check yang melewati masa retensi
+func (r *statusRepository) DeleteChecksBefore(before time.Time) (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM atamlink.status_checks WHERE sc_checked_at < $1`, before)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete status checks")
+	}
+	return result.RowsAffected()
+}
+
+// CreateIncident membuat insiden baru
+func (r *statusRepository) CreateIncident(tx *sql.Tx, incident *entity.StatusIncident) error {
+	query := `
+		INSERT INTO atamlink.status_incidents (
+			si_title, si_message, si_status, si_impact, si_components,
+			si_started_at, si_resolved_at, si_created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING si_id`
+
+	err := tx.QueryRow(
+		query,
+		incident.Title,
+		incident.Message,
+		incident.Status,
+		incident.Impact,
+		pq.Array(incident.Components),
+		incident.StartedAt,
+		incident.ResolvedAt,
+		incident.CreatedAt,
+	).Scan(&incident.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to create incident")
+	}
+
+	return nil
+}
+
+// UpdateIncident update insiden
+func (r *statusRepository) UpdateIncident(tx *sql.Tx, incident *entity.StatusIncident) error {
+	query := `
+		UPDATE atamlink.status_incidents SET
+			si_title = $2,
+			si_message = $3,
+			si_status = $4,
+			si_impact = $5,
+			si_components = $6,
+			si_started_at = $7,
+			si_resolved_at = $8,
+			si_updated_at = $9
+		WHERE si_id = $1`
+
+	result, err := tx.Exec(
+		query,
+		incident.ID,
+		incident.Title,
+		incident.Message,
+		incident.Status,
+		incident.Impact,
+		pq.Array(incident.Components),
+		incident.StartedAt,
+		incident.ResolvedAt,
+		incident.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to update incident")
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgIncidentNotFound, 404)
+	}
+
+	return nil
+}
+
+// DeleteIncident hapus insiden
+func (r *statusRepository) DeleteIncident(tx *sql.Tx, id int64) error {
+	result, err := tx.Exec(`DELETE FROM atamlink.status_incidents WHERE si_id = $1`, id)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete incident")
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return errors.New(errors.ErrNotFound, constant.ErrMsgIncidentNotFound, 404)
+	}
+
+	return nil
+}
+
+// GetIncidentByID mendapatkan insiden by ID
+func (r *statusRepository) GetIncidentByID(id int64) (*entity.StatusIncident, error) {
+	query := `SELECT ` + incidentColumns + ` FROM atamlink.status_incidents WHERE si_id = $1`
+
+	incident, err := scanIncident(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgIncidentNotFound, 404)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get incident")
+	}
+
+	return incident, nil
+}
+
+// ListIncidents list semua insiden, terbaru lebih dulu
+func (r *statusRepository) ListIncidents(limit, offset int) ([]*entity.StatusIncident, int64, error) {
+	var total int64
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM atamlink.status_incidents`).Scan(&total); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to count incidents")
+	}
+
+	query := `SELECT ` + incidentColumns + `
+		FROM atamlink.status_incidents
+		ORDER BY si_started_at DESC, si_id DESC
+		LIMIT $1 OFFSET $2`
+
+	incidents, err := r.queryIncidents(query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return incidents, total, nil
+}
+
+// ListRecentIncidents insiden yang belum selesai atau selesai sejak waktu tertentu
+func (r *statusRepository) ListRecentIncidents(resolvedSince time.Time) ([]*entity.StatusIncident, error) {
+	query := `SELECT ` + incidentColumns + `
+		FROM atamlink.status_incidents
+		WHERE si_resolved_at IS NULL OR si_resolved_at >= $1
+		ORDER BY si_started_at DESC, si_id DESC`
+
+	return r.queryIncidents(query, resolvedSince)
+}
+
+func (r *statusRepository) queryIncidents(query string, args ...interface{}) ([]*entity.StatusIncident, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query incidents")
+	}
+	defer rows.Close()
+
+	incidents := make([]*entity.StatusIncident, 0)
+	for rows.Next() {
+		incident, err := scanIncident(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan incident")
+		}
+		incidents = append(incidents, incident)
+	}
+
+	return incidents, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanIncident(row rowScanner) (*entity.StatusIncident, error) {
+	incident := &entity.StatusIncident{}
+	err := row.Scan(
+		&incident.ID,
+		&incident.Title,
+		&incident.Message,
+		&incident.Status,
+		&incident.Impact,
+		pq.Array(&incident.Components),
+		&incident.StartedAt,
+		&incident.ResolvedAt,
+		&incident.CreatedAt,
+		&incident.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
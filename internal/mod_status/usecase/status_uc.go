@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/middleware"
+	"github.com/atam/atamlink/internal/mod_status/dto"
+	"github.com/atam/atamlink/internal/mod_status/entity"
+	"github.com/atam/atamlink/internal/mod_status/repository"
+	"github.com/atam/atamlink/internal/service"
+	"github.com/atam/atamlink/pkg/database"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// statusComponents urutan komponen di status page
+var statusComponents = []string{
+	constant.StatusComponentAPI,
+	constant.StatusComponentUploads,
+	constant.StatusComponentPublicPages,
+}
+
+// StatusUseCase interface untuk status page use case
+type StatusUseCase interface {
+	GetStatus() (*dto.StatusResponse, error)
+	RunChecks() error
+	PurgeChecks() error
+
+	// Incident methods (admin)
+	CreateIncident(req *dto.CreateIncidentRequest) (*dto.IncidentResponse, error)
+	UpdateIncident(ctx *gin.Context, id int64, req *dto.UpdateIncidentRequest) (*dto.IncidentResponse, error)
+	DeleteIncident(ctx *gin.Context, id int64) error
+	ListIncidents(page, perPage int) ([]*dto.IncidentResponse, int64, error)
+	GetIncidentByID(id int64) (*dto.IncidentResponse, error)
+}
+
+type statusUseCase struct {
+	db         *sql.DB
+	statusRepo repository.StatusRepository
+	checker    service.StatusChecker
+	cfg        config.StatusConfig
+}
+
+// NewStatusUseCase membuat instance status use case baru
+func NewStatusUseCase(db *sql.DB, statusRepo repository.StatusRepository, checker service.StatusChecker, cfg config.StatusConfig) StatusUseCase {
+	return &statusUseCase{
+		db:         db,
+		statusRepo: statusRepo,
+		checker:    checker,
+		cfg:        cfg,
+	}
+}
+
+// GetStatus status komponen terkini, uptime 24 jam/7 hari/30 hari dan insiden terbaru
+func (uc *statusUseCase) GetStatus() (*dto.StatusResponse, error) {
+	now := time.Now()
+
+	latest, err := uc.statusRepo.GetLatestChecks()
+	if err != nil {
+		return nil, err
+	}
+
+	uptime24h, err := uc.statusRepo.GetUptime(now.Add(-24 * time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	uptime7d, err := uc.statusRepo.GetUptime(now.AddDate(0, 0, -7))
+	if err != nil {
+		return nil, err
+	}
+	uptime30d, err := uc.statusRepo.GetUptime(now.AddDate(0, 0, -30))
+	if err != nil {
+		return nil, err
+	}
+
+	incidents, err := uc.statusRepo.ListRecentIncidents(now.AddDate(0, 0, -uc.cfg.IncidentDays))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.StatusResponse{
+		Status:     constant.ComponentStatusOperational,
+		UpdatedAt:  now,
+		Components: make([]dto.ComponentResponse, 0, len(statusComponents)),
+		Incidents:  make([]dto.IncidentResponse, 0, len(incidents)),
+	}
+
+	for _, name := range statusComponents {
+		component := dto.ComponentResponse{
+			Name:      name,
+			Status:    componentStatus(latest[name], incidents, name),
+			Uptime24h: uptime24h[name].Percent(),
+			Uptime7d:  uptime7d[name].Percent(),
+			Uptime30d: uptime30d[name].Percent(),
+		}
+		if check, ok := latest[name]; ok {
+			component.LatencyMs = check.LatencyMs
+			checkedAt := check.CheckedAt
+			component.LastCheckedAt = &checkedAt
+		}
+		resp.Components = append(resp.Components, component)
+
+		switch component.Status {
+		case constant.ComponentStatusOutage:
+			resp.Status = constant.ComponentStatusOutage
+		case constant.ComponentStatusDegraded:
+			if resp.Status == constant.ComponentStatusOperational {
+				resp.Status = constant.ComponentStatusDegraded
+			}
+		}
+	}
+
+	for _, incident := range incidents {
+		resp.Incidents = append(resp.Incidents, *toIncidentResponse(incident))
+	}
+
+	return resp, nil
+}
+
+// componentStatus health check terakhir gagal = outage, insiden aktif yang
+// berdampak ke komponen = degraded (critical = outage)
+func componentStatus(check *entity.StatusCheck, incidents []*entity.StatusIncident, component string) string {
+	status := constant.ComponentStatusUnknown
+	if check != nil {
+		status = constant.ComponentStatusOperational
+		if !check.IsUp {
+			return constant.ComponentStatusOutage
+		}
+	}
+
+	for _, incident := range incidents {
+		if incident.IsResolved() || !incident.Affects(component) {
+			continue
+		}
+		if incident.Impact == constant.IncidentImpactCritical {
+			return constant.ComponentStatusOutage
+		}
+		status = constant.ComponentStatusDegraded
+	}
+
+	return status
+}
+
+// RunChecks jalankan health check semua komponen dan simpan hasilnya, dipanggil scheduler
+func (uc *statusUseCase) RunChecks() error {
+	results := uc.checker.Check(context.Background())
+
+	checkedAt := time.Now()
+	for _, result := range results {
+		check := &entity.StatusCheck{
+			Component: result.Component,
+			IsUp:      result.IsUp,
+			LatencyMs: int(result.Latency.Milliseconds()),
+			Error:     database.NullString(result.Error),
+			CheckedAt: checkedAt,
+		}
+		if err := uc.statusRepo.CreateCheck(check); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeChecks hapus riwayat health check yang melewati masa retensi
+func (uc *statusUseCase) PurgeChecks() error {
+	if uc.cfg.RetentionDays <= 0 {
+		return nil
+	}
+
+	_, err := uc.statusRepo.DeleteChecksBefore(time.Now().AddDate(0, 0, -uc.cfg.RetentionDays))
+	return err
+}
+
+// CreateIncident membuat insiden baru
+func (uc *statusUseCase) CreateIncident(req *dto.CreateIncidentRequest) (*dto.IncidentResponse, error) {
+	now := time.Now()
+	incident := &entity.StatusIncident{
+		Title:      req.Title,
+		Message:    database.NullString(req.Message),
+		Status:     req.Status,
+		Impact:     req.Impact,
+		Components: req.Components,
+		StartedAt:  now,
+		CreatedAt:  now,
+	}
+	if req.StartedAt != nil {
+		incident.StartedAt = *req.StartedAt
+	}
+	if incident.Status == constant.IncidentStatusResolved {
+		incident.ResolvedAt = &now
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.statusRepo.CreateIncident(tx, incident); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toIncidentResponse(incident), nil
+}
+
+// UpdateIncident update insiden, mis. perubahan status sampai resolved
+func (uc *statusUseCase) UpdateIncident(ctx *gin.Context, id int64, req *dto.UpdateIncidentRequest) (*dto.IncidentResponse, error) {
+	incident, err := uc.statusRepo.GetIncidentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, incident)
+	}
+
+	now := time.Now()
+	if req.Title != "" {
+		incident.Title = req.Title
+	}
+	if req.Message != nil {
+		incident.Message = database.NullString(*req.Message)
+	}
+	if req.Impact != "" {
+		incident.Impact = req.Impact
+	}
+	if req.Components != nil {
+		incident.Components = req.Components
+	}
+	if req.StartedAt != nil {
+		incident.StartedAt = *req.StartedAt
+	}
+	if req.Status != "" && req.Status != incident.Status {
+		incident.Status = req.Status
+		if req.Status == constant.IncidentStatusResolved {
+			incident.ResolvedAt = &now
+		} else {
+			incident.ResolvedAt = nil
+		}
+	}
+	incident.UpdatedAt = &now
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.statusRepo.UpdateIncident(tx, incident); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return toIncidentResponse(incident), nil
+}
+
+// DeleteIncident hapus insiden, mis. yang dibuat karena salah input
+func (uc *statusUseCase) DeleteIncident(ctx *gin.Context, id int64) error {
+	incident, err := uc.statusRepo.GetIncidentByID(id)
+	if err != nil {
+		return err
+	}
+
+	// Inject old_data ke audit context
+	if ctx != nil {
+		ctx.Set(middleware.GinKeyAuditOldData, incident)
+	}
+
+	tx, err := uc.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	if err := uc.statusRepo.DeleteIncident(tx, id); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	return nil
+}
+
+// ListIncidents list semua insiden dengan pagination
+func (uc *statusUseCase) ListIncidents(page, perPage int) ([]*dto.IncidentResponse, int64, error) {
+	incidents, total, err := uc.statusRepo.ListIncidents(perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp := make([]*dto.IncidentResponse, len(incidents))
+	for i, incident := range incidents {
+		resp[i] = toIncidentResponse(incident)
+	}
+
+	return resp, total, nil
+}
+
+// GetIncidentByID mendapatkan insiden by ID
+func (uc *statusUseCase) GetIncidentByID(id int64) (*dto.IncidentResponse, error) {
+	incident, err := uc.statusRepo.GetIncidentByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return toIncidentResponse(incident), nil
+}
+
+func toIncidentResponse(incident *entity.StatusIncident) *dto.IncidentResponse {
+	components := incident.Components
+	if components == nil {
+		components = []string{}
+	}
+	return &dto.IncidentResponse{
+		ID:         incident.ID,
+		Title:      incident.Title,
+		Message:    incident.Message.String,
+		Status:     incident.Status,
+		Impact:     incident.Impact,
+		Components: components,
+		StartedAt:  incident.StartedAt,
+		ResolvedAt: incident.ResolvedAt,
+		CreatedAt:  incident.CreatedAt,
+		UpdatedAt:  incident.UpdatedAt,
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/mod_analytics/entity"
+	"github.com/atam/atamlink/internal/mod_analytics/repository"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// APIUsageService catat pemakaian API service account, diagregasi per hari
+// di memori lalu ditulis berkala supaya tidak menambah query per request
+type APIUsageService interface {
+	Start()
+	Stop()
+	Enabled() bool
+	Record(record APIUsageRecord)
+	PurgeExpired() error
+}
+
+// APIUsageRecord satu request API dari service account
+type APIUsageRecord struct {
+	BusinessID       int64
+	ServiceAccountID int64
+	Method           string
+	Endpoint         string // route template, bukan path asli
+	Status           int
+	Latency          time.Duration
+	BytesIn          int64
+	BytesOut         int64
+	At               time.Time
+}
+
+type apiUsageKey struct {
+	businessID       int64
+	serviceAccountID int64
+	date             string
+	method           string
+	endpoint         string
+}
+
+type apiUsageService struct {
+	repo repository.AnalyticsRepository
+	cfg  config.APIUsageConfig
+	log  logger.Logger
+
+	mu      sync.Mutex
+	pending map[apiUsageKey]*entity.APIUsageDaily
+
+	wg   sync.WaitGroup
+	stop chan bool
+}
+
+// NewAPIUsageService membuat instance API usage service baru
+func NewAPIUsageService(repo repository.AnalyticsRepository, cfg config.APIUsageConfig, log logger.Logger) APIUsageService {
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Minute
+	}
+	return &apiUsageService{
+		repo:    repo,
+		cfg:     cfg,
+		log:     log,
+		pending: make(map[apiUsageKey]*entity.APIUsageDaily),
+		stop:    make(chan bool),
+	}
+}
+
+// Start memulai flush worker
+func (s *apiUsageService) Start() {
+	if !s.cfg.Enabled {
+		return
+	}
+	s.wg.Add(1)
+	go s.worker()
+}
+
+// Stop menghentikan worker dan menulis agregat yang tersisa
+func (s *apiUsageService) Stop() {
+	if !s.cfg.Enabled {
+		return
+	}
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// Enabled check apakah pencatatan pemakaian API aktif
+func (s *apiUsageService) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Record tambahkan satu request ke agregat harian (non-blocking)
+func (s *apiUsageService) Record(record APIUsageRecord) {
+	if !s.cfg.Enabled || record.BusinessID == 0 || record.Endpoint == "" {
+		return
+	}
+
+	date := record.At.Format("2006-01-02")
+	key := apiUsageKey{
+		businessID:       record.BusinessID,
+		serviceAccountID: record.ServiceAccountID,
+		date:             date,
+		method:           record.Method,
+		endpoint:         record.Endpoint,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.pending[key]
+	if !ok {
+		day, _ := time.ParseInLocation("2006-01-02", date, record.At.Location())
+		usage = &entity.APIUsageDaily{
+			BusinessID:       record.BusinessID,
+			ServiceAccountID: record.ServiceAccountID,
+			Date:             day,
+			Method:           record.Method,
+			Endpoint:         record.Endpoint,
+			LatencyBuckets:   entity.NewAPIUsageBuckets(),
+		}
+		s.pending[key] = usage
+	}
+
+	usage.Requests++
+	switch {
+	case record.Status >= 500:
+		usage.ServerErrors++
+	case record.Status >= 400:
+		usage.ClientErrors++
+	}
+	if record.BytesIn > 0 {
+		usage.BytesIn += record.BytesIn
+	}
+	if record.BytesOut > 0 {
+		usage.BytesOut += record.BytesOut
+	}
+	usage.ObserveLatency(record.Latency.Milliseconds())
+}
+
+// PurgeExpired hapus pemakaian API yang melewati masa retensi
+func (s *apiUsageService) PurgeExpired() error {
+	if s.cfg.RetentionDays <= 0 {
+		return nil
+	}
+
+	before := time.Now().AddDate(0, 0, -s.cfg.RetentionDays)
+	deleted, err := s.repo.DeleteAPIUsageBefore(before)
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		s.log.Info("Expired api usage purged", logger.Int64("rows", deleted))
+	}
+	return nil
+}
+
+func (s *apiUsageService) worker() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.stop:
+			s.flush()
+			return
+		}
+	}
+}
+
+// flush tulis agregat yang terkumpul, agregat yang gagal ditulis dikembalikan
+// ke antrian supaya ikut flush berikutnya
+func (s *apiUsageService) flush() {
+	s.mu.Lock()
+	if len(s.pending) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	batch := s.pending
+	s.pending = make(map[apiUsageKey]*entity.APIUsageDaily)
+	s.mu.Unlock()
+
+	usages := make([]*entity.APIUsageDaily, 0, len(batch))
+	for _, usage := range batch {
+		usages = append(usages, usage)
+	}
+
+	if err := s.repo.UpsertAPIUsage(usages); err != nil {
+		s.log.Error("Failed to flush api usage",
+			logger.Int("rows", len(usages)),
+			logger.Error(err),
+		)
+
+		s.mu.Lock()
+		for key, usage := range batch {
+			if current, ok := s.pending[key]; ok {
+				current.Merge(usage)
+			} else {
+				s.pending[key] = usage
+			}
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/atam/atamlink/internal/config"
+)
+
+// auditRedactedValue pengganti nilai field sensitif yang bukan email/telepon
+const auditRedactedValue = "[REDACTED]"
+
+// auditRedactor penyamar field sensitif di snapshot old/new data audit log.
+// Field dicocokkan berdasarkan nama key JSON (case-insensitive) di semua kedalaman
+type auditRedactor struct {
+	fields      map[string]bool            // berlaku untuk semua tabel
+	tableFields map[string]map[string]bool // tambahan per tabel
+}
+
+// newAuditRedactor membuat redactor dari konfigurasi, nil jika redaksi nonaktif
+func newAuditRedactor(cfg config.AuditRedactionConfig) *auditRedactor {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	r := &auditRedactor{
+		fields:      fieldSet(cfg.Fields),
+		tableFields: make(map[string]map[string]bool, len(cfg.TableFields)),
+	}
+	for table, fields := range cfg.TableFields {
+		r.tableFields[strings.ToLower(strings.TrimSpace(table))] = fieldSet(fields)
+	}
+	return r
+}
+
+func fieldSet(fields []string) map[string]bool {
+	set := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			set[field] = true
+		}
+	}
+	return set
+}
+
+// Redact samarkan field sensitif, data yang tidak berubah atau bukan JSON dikembalikan apa adanya
+func (r *auditRedactor) Redact(table string, data json.RawMessage) json.RawMessage {
+	if r == nil || len(data) == 0 {
+		return data
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return data
+	}
+
+	redacted, changed := r.redactValue(r.tableFields[strings.ToLower(table)], value)
+	if !changed {
+		return data
+	}
+
+	result, err := json.Marshal(redacted)
+	if err != nil {
+		return data
+	}
+	return result
+}
+
+func (r *auditRedactor) redactValue(tableFields map[string]bool, value interface{}) (interface{}, bool) {
+	changed := false
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			name := strings.ToLower(key)
+			if r.fields[name] || tableFields[name] {
+				if child != nil {
+					v[key] = maskAuditValue(child)
+					changed = true
+				}
+				continue
+			}
+			if redacted, ok := r.redactValue(tableFields, child); ok {
+				v[key] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			if redacted, ok := r.redactValue(tableFields, child); ok {
+				v[i] = redacted
+				changed = true
+			}
+		}
+	}
+
+	return value, changed
+}
+
+// maskAuditValue samarkan satu nilai. Email menyisakan huruf pertama dan domain,
+// nomor telepon menyisakan 3 digit terakhir supaya entri masih bisa ditelusuri
+func maskAuditValue(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return auditRedactedValue
+	}
+
+	if at := strings.LastIndexByte(s, '@'); at > 0 && at < len(s)-1 {
+		return s[:1] + "***" + s[at:]
+	}
+
+	var digits []rune
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case strings.ContainsRune("+-() .", c):
+		default:
+			return auditRedactedValue
+		}
+	}
+	if len(digits) >= 6 {
+		return "***" + string(digits[len(digits)-3:])
+	}
+
+	return auditRedactedValue
+}
@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/atam/atamlink/internal/config"
 	"github.com/atam/atamlink/internal/mod_audit/entity"
 	"github.com/atam/atamlink/internal/mod_audit/repository"
 	"github.com/atam/atamlink/pkg/logger"
@@ -16,6 +19,42 @@ type AuditService interface {
 	Start()
 	Stop()
 	Log(entry *AuditEntry)
+	Health() AuditHealth
+}
+
+const (
+	// auditErrorWindow jumlah batch terakhir untuk menghitung batch error rate
+	auditErrorWindow = 100
+	// auditDegradedWindow status degraded selama ada drop dalam rentang ini
+	auditDegradedWindow = 5 * time.Minute
+)
+
+// AuditHealth kondisi pipeline audit log
+type AuditHealth struct {
+	Status             string           `json:"status"` // healthy, degraded, stopped
+	WorkerRunning      bool             `json:"worker_running"`
+	QueueDepth         int              `json:"queue_depth"`
+	QueueCapacity      int              `json:"queue_capacity"`
+	DroppedTotal       int64            `json:"dropped_total"`
+	DroppedQueueFull   int64            `json:"dropped_queue_full"`
+	DroppedFlushFailed int64            `json:"dropped_flush_failed"`
+	LastDropAt         *time.Time       `json:"last_drop_at,omitempty"`
+	LastFlushAt        *time.Time       `json:"last_flush_at,omitempty"`
+	BatchesTotal       int64            `json:"batches_total"`
+	BatchErrorsTotal   int64            `json:"batch_errors_total"`
+	BatchErrorRate     float64          `json:"batch_error_rate"` // dari 100 batch terakhir
+	Sink               *AuditSinkHealth `json:"sink,omitempty"`
+}
+
+// AuditSinkHealth kondisi pengiriman salinan audit log ke SIEM
+type AuditSinkHealth struct {
+	Provider      string     `json:"provider"`
+	QueueDepth    int        `json:"queue_depth"` // dalam batch
+	QueueCapacity int        `json:"queue_capacity"`
+	SentTotal     int64      `json:"sent_total"`
+	DroppedTotal  int64      `json:"dropped_total"` // antrian penuh atau gagal setelah semua percobaan
+	FailedBatches int64      `json:"failed_batches"`
+	LastSentAt    *time.Time `json:"last_sent_at,omitempty"`
 }
 
 // AuditEntry entry untuk audit log
@@ -39,24 +78,81 @@ type auditService struct {
 	flushTime time.Duration
 	wg        sync.WaitGroup
 	stop      chan bool
+
+	// Alert email saat entry audit hilang
+	mail          MailService
+	alertTo       string
+	alertCooldown time.Duration
+
+	droppedQueueFull   atomic.Int64
+	droppedFlushFailed atomic.Int64
+	batches            atomic.Int64
+	batchErrors        atomic.Int64
+	running            atomic.Bool // worker utama sedang berjalan
+
+	mu          sync.Mutex
+	lastDropAt  time.Time
+	lastFlushAt time.Time
+	lastAlertAt time.Time
+	recent      [auditErrorWindow]bool // true = batch gagal
+	recentCount int
+	recentNext  int
+
+	// Salinan ke SIEM, antrian terpisah supaya SIEM lambat tidak menahan penyimpanan database
+	sink            AuditSink
+	sinkQueue       chan []*entity.AuditLog
+	sinkMaxAttempts int
+	sinkWG          sync.WaitGroup
+	sinkSent        atomic.Int64
+	sinkDropped     atomic.Int64
+	sinkFailed      atomic.Int64
+	sinkLastSentAt  atomic.Int64 // unix nano, 0 = belum pernah
+
+	// Penyamaran field sensitif, nil = nonaktif
+	redactor *auditRedactor
 }
 
-// NewAuditService membuat instance audit service baru
-func NewAuditService(repo repository.AuditRepository, log logger.Logger) AuditService {
-	return &auditService{
-		repo:      repo,
-		log:       log,
-		queue:     make(chan *entity.AuditLog, 1000),
-		batchSize: 10,
-		flushTime: 5 * time.Second,
-		stop:      make(chan bool),
+// NewAuditService membuat instance audit service baru,
+// sink nil berarti audit log hanya disimpan di database
+func NewAuditService(repo repository.AuditRepository, log logger.Logger, mail MailService, sink AuditSink, cfg config.AuditConfig) AuditService {
+	s := &auditService{
+		repo:          repo,
+		log:           log,
+		queue:         make(chan *entity.AuditLog, 1000),
+		batchSize:     10,
+		flushTime:     5 * time.Second,
+		stop:          make(chan bool),
+		mail:          mail,
+		alertTo:       cfg.AlertEmail,
+		alertCooldown: cfg.AlertCooldown,
+		sink:          sink,
+		redactor:      newAuditRedactor(cfg.Redaction),
 	}
+
+	if sink != nil {
+		queueSize := cfg.Sink.QueueSize
+		if queueSize <= 0 {
+			queueSize = 100
+		}
+		s.sinkQueue = make(chan []*entity.AuditLog, queueSize)
+		s.sinkMaxAttempts = cfg.Sink.MaxAttempts
+		if s.sinkMaxAttempts <= 0 {
+			s.sinkMaxAttempts = 1
+		}
+	}
+
+	return s
 }
 
 // Start memulai audit service worker
 func (s *auditService) Start() {
 	s.wg.Add(1)
 	go s.worker()
+
+	if s.sink != nil {
+		s.sinkWG.Add(1)
+		go s.sinkWorker()
+	}
 }
 
 // Stop menghentikan audit service
@@ -64,6 +160,12 @@ func (s *auditService) Stop() {
 	close(s.stop)
 	s.wg.Wait()
 	close(s.queue)
+
+	// Worker utama sudah selesai, sisa batch SIEM dikirim sekali lalu berhenti
+	if s.sink != nil {
+		close(s.sinkQueue)
+		s.sinkWG.Wait()
+	}
 }
 
 // Log menambahkan entry ke queue
@@ -99,8 +201,8 @@ func (s *auditService) Log(entry *AuditEntry) {
 		RecordID:      entry.RecordID,
 		// OldData:       oldDataJSON,
 		// NewData:       newDataJSON,
-		OldData:       entry.OldData,
-		NewData:       entry.NewData,
+		OldData:       s.redactor.Redact(entry.Table, entry.OldData),
+		NewData:       s.redactor.Redact(entry.Table, entry.NewData),
 		Context:       entry.Context,
 		Reason:        entry.Reason,
 	}
@@ -116,13 +218,128 @@ func (s *auditService) Log(entry *AuditEntry) {
 			logger.String("table", entry.Table),
 			logger.String("record", entry.RecordID),
 		)
+		s.droppedQueueFull.Add(1)
+		s.recordDrop("queue penuh", 1)
+	}
+}
+
+// Health snapshot kondisi pipeline audit untuk metrics dan endpoint admin
+func (s *auditService) Health() AuditHealth {
+	health := AuditHealth{
+		QueueDepth:         len(s.queue),
+		QueueCapacity:      cap(s.queue),
+		DroppedQueueFull:   s.droppedQueueFull.Load(),
+		DroppedFlushFailed: s.droppedFlushFailed.Load(),
+		BatchesTotal:       s.batches.Load(),
+		BatchErrorsTotal:   s.batchErrors.Load(),
+		WorkerRunning:      s.running.Load(),
+	}
+	health.DroppedTotal = health.DroppedQueueFull + health.DroppedFlushFailed
+
+	s.mu.Lock()
+	if !s.lastDropAt.IsZero() {
+		lastDropAt := s.lastDropAt
+		health.LastDropAt = &lastDropAt
+	}
+	if !s.lastFlushAt.IsZero() {
+		lastFlushAt := s.lastFlushAt
+		health.LastFlushAt = &lastFlushAt
+	}
+	if s.recentCount > 0 {
+		failed := 0
+		for i := 0; i < s.recentCount; i++ {
+			if s.recent[i] {
+				failed++
+			}
+		}
+		health.BatchErrorRate = float64(failed) / float64(s.recentCount)
+	}
+	s.mu.Unlock()
+
+	if s.sink != nil {
+		health.Sink = &AuditSinkHealth{
+			Provider:      s.sink.Provider(),
+			QueueDepth:    len(s.sinkQueue),
+			QueueCapacity: cap(s.sinkQueue),
+			SentTotal:     s.sinkSent.Load(),
+			DroppedTotal:  s.sinkDropped.Load(),
+			FailedBatches: s.sinkFailed.Load(),
+		}
+		if lastSent := s.sinkLastSentAt.Load(); lastSent > 0 {
+			lastSentAt := time.Unix(0, lastSent)
+			health.Sink.LastSentAt = &lastSentAt
+		}
+	}
+
+	health.Status = "healthy"
+	if health.LastDropAt != nil && time.Since(*health.LastDropAt) < auditDegradedWindow {
+		health.Status = "degraded"
+	}
+	if health.BatchErrorRate >= 0.5 || health.QueueDepth >= health.QueueCapacity*9/10 {
+		health.Status = "degraded"
+	}
+	if !health.WorkerRunning {
+		health.Status = "stopped"
+	}
+	return health
+}
+
+// recordDrop catat waktu drop dan kirim alert email, dibatasi cooldown
+func (s *auditService) recordDrop(reason string, count int) {
+	now := time.Now()
+
+	s.mu.Lock()
+	s.lastDropAt = now
+	shouldAlert := s.alertTo != "" && s.mail != nil && s.mail.IsConfigured() &&
+		now.Sub(s.lastAlertAt) >= s.alertCooldown
+	if shouldAlert {
+		s.lastAlertAt = now
+	}
+	s.mu.Unlock()
+
+	if !shouldAlert {
+		return
 	}
+
+	total := s.droppedQueueFull.Load() + s.droppedFlushFailed.Load()
+	subject := "[AtamLink] Audit log entry hilang"
+	body := fmt.Sprintf(
+		"%d entry audit log hilang (%s) pada %s.\nTotal entry hilang sejak service berjalan: %d.\n\nCek GET /admin/audit/health untuk detail.",
+		count, reason, now.Format(time.RFC3339), total,
+	)
+
+	// Kirim di goroutine supaya Log tetap non-blocking
+	go func() {
+		if err := s.mail.Send(s.alertTo, subject, body); err != nil {
+			s.log.Error("Failed to send audit drop alert", logger.Error(err))
+		}
+	}()
+}
+
+// recordBatch catat hasil flush untuk batch error rate
+func (s *auditService) recordBatch(failed bool) {
+	s.batches.Add(1)
+	if failed {
+		s.batchErrors.Add(1)
+	}
+
+	s.mu.Lock()
+	s.lastFlushAt = time.Now()
+	s.recent[s.recentNext] = failed
+	s.recentNext = (s.recentNext + 1) % auditErrorWindow
+	if s.recentCount < auditErrorWindow {
+		s.recentCount++
+	}
+	s.mu.Unlock()
 }
 
 // worker process audit logs dari queue
 func (s *auditService) worker() {
 	defer s.wg.Done()
 
+	s.running.Store(true)
+	defer s.running.Store(false)
+
 	batch := make([]*entity.AuditLog, 0, s.batchSize)
 	ticker := time.NewTicker(s.flushTime)
 	defer ticker.Stop()
@@ -177,6 +394,9 @@ func (s *auditService) flush(batch []*entity.AuditLog) {
 		return
 	}
 
+	// Salinan ke SIEM berjalan paralel dengan penyimpanan database
+	s.forward(batch)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -186,9 +406,11 @@ func (s *auditService) flush(batch []*entity.AuditLog) {
 		done <- s.repo.BatchCreate(batch)
 	}()
 
+	failed := false
 	select {
 	case err := <-done:
 		if err != nil {
+			failed = true
 			s.log.Error("Failed to save audit logs",
 				logger.Error(err),
 				logger.Int("batch_size", len(batch)),
@@ -199,8 +421,77 @@ func (s *auditService) flush(batch []*entity.AuditLog) {
 			)
 		}
 	case <-ctx.Done():
+		failed = true
 		s.log.Error("Audit log save timeout",
 			logger.Int("batch_size", len(batch)),
 		)
 	}
-}
\ No newline at end of file
+
+	s.recordBatch(failed)
+	if failed {
+		// Batch gagal tidak dicoba ulang, entry di dalamnya hilang
+		s.droppedFlushFailed.Add(int64(len(batch)))
+		s.recordDrop("gagal simpan batch", len(batch))
+	}
+}
+
+// forward antrikan salinan batch untuk SIEM, dibuang jika antrian penuh (backpressure)
+func (s *auditService) forward(batch []*entity.AuditLog) {
+	if s.sink == nil {
+		return
+	}
+
+	// Slice batch dipakai ulang worker setelah flush
+	logs := make([]*entity.AuditLog, len(batch))
+	copy(logs, batch)
+
+	select {
+	case s.sinkQueue <- logs:
+	default:
+		s.sinkDropped.Add(int64(len(logs)))
+		s.log.Error("Audit sink queue full, dropping batch",
+			logger.String("provider", s.sink.Provider()),
+			logger.Int("batch_size", len(logs)),
+		)
+	}
+}
+
+// sinkWorker kirim batch ke SIEM sampai antrian ditutup saat Stop
+func (s *auditService) sinkWorker() {
+	defer s.sinkWG.Done()
+
+	for batch := range s.sinkQueue {
+		s.send(batch)
+	}
+}
+
+// send kirim satu batch dengan retry backoff, retry dihentikan saat service berhenti
+func (s *auditService) send(batch []*entity.AuditLog) {
+	backoff := time.Second
+	var err error
+	for attempt := 1; attempt <= s.sinkMaxAttempts; attempt++ {
+		if err = s.sink.Send(batch); err == nil {
+			s.sinkSent.Add(int64(len(batch)))
+			s.sinkLastSentAt.Store(time.Now().UnixNano())
+			return
+		}
+
+		if attempt == s.sinkMaxAttempts {
+			break
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-s.stop:
+			attempt = s.sinkMaxAttempts
+		}
+	}
+
+	s.sinkFailed.Add(1)
+	s.sinkDropped.Add(int64(len(batch)))
+	s.log.Error("Failed to forward audit logs to sink",
+		logger.Error(err),
+		logger.String("provider", s.sink.Provider()),
+		logger.Int("batch_size", len(batch)),
+	)
+}
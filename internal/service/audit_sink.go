@@ -0,0 +1,112 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/syslog"
+	"net/http"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_audit/entity"
+)
+
+// AuditSink tujuan eksternal (SIEM) untuk salinan audit log
+type AuditSink interface {
+	Provider() string
+	Send(batch []*entity.AuditLog) error
+}
+
+// NewAuditSink membuat sink sesuai provider, provider kosong berarti
+// audit log hanya disimpan di database (nil)
+func NewAuditSink(cfg config.AuditSinkConfig) (AuditSink, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case constant.AuditSinkHTTP:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("audit sink: url not configured")
+		}
+		return &httpAuditSink{config: cfg, client: NewHTTPClient(cfg.HTTPTimeout)}, nil
+	case constant.AuditSinkSyslog:
+		if cfg.SyslogAddress == "" {
+			return nil, fmt.Errorf("audit sink: syslog address not configured")
+		}
+		writer, err := syslog.Dial(cfg.SyslogNetwork, cfg.SyslogAddress, syslog.LOG_INFO|syslog.LOG_AUTH, cfg.SyslogTag)
+		if err != nil {
+			return nil, fmt.Errorf("audit sink: dial syslog: %w", err)
+		}
+		return &syslogAuditSink{writer: writer}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported audit sink provider %q", cfg.Provider)
+}
+
+// httpAuditSink kirim batch sebagai NDJSON (satu entry per baris) ke endpoint bulk
+type httpAuditSink struct {
+	config config.AuditSinkConfig
+	client *http.Client
+}
+
+// Provider nama provider
+func (s *httpAuditSink) Provider() string {
+	return constant.AuditSinkHTTP
+}
+
+// Send POST satu batch, status non-2xx dianggap gagal supaya dicoba ulang
+func (s *httpAuditSink) Send(batch []*entity.AuditLog) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, log := range batch {
+		if err := encoder.Encode(log); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.config.URL, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.config.AuthHeader != "" {
+		req.Header.Set("Authorization", s.config.AuthHeader)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("audit sink: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit sink: endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// syslogAuditSink tulis setiap entry sebagai pesan syslog berisi JSON
+type syslogAuditSink struct {
+	writer *syslog.Writer
+}
+
+// Provider nama provider
+func (s *syslogAuditSink) Provider() string {
+	return constant.AuditSinkSyslog
+}
+
+// Send tulis entry satu per satu, writer syslog menyambung ulang sendiri saat koneksi putus
+func (s *syslogAuditSink) Send(batch []*entity.AuditLog) error {
+	for _, log := range batch {
+		line, err := json.Marshal(log)
+		if err != nil {
+			return err
+		}
+		if err := s.writer.Info(string(line)); err != nil {
+			return fmt.Errorf("audit sink: %w", err)
+		}
+	}
+	return nil
+}
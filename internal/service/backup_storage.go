@@ -0,0 +1,75 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BackupStorage penyimpanan file backup katalog
+type BackupStorage interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	Delete(key string) error
+}
+
+type localBackupStorage struct {
+	basePath string
+}
+
+// NewLocalBackupStorage membuat backup storage di filesystem lokal,
+// sebaiknya diarahkan ke volume terpisah dari database
+func NewLocalBackupStorage(basePath string) (BackupStorage, error) {
+	if err := os.MkdirAll(basePath, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	return &localBackupStorage{basePath: basePath}, nil
+}
+
+// Put tulis file backup secara atomic (tulis ke file sementara lalu rename)
+func (s *localBackupStorage) Put(key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Get baca file backup
+func (s *localBackupStorage) Get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(path)
+}
+
+// Delete hapus file backup, file yang sudah tidak ada diabaikan
+func (s *localBackupStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// path cegah key keluar dari base path
+func (s *localBackupStorage) path(key string) (string, error) {
+	if strings.Contains(key, "..") {
+		return "", fmt.Errorf("backup: invalid key %q", key)
+	}
+	return filepath.Join(s.basePath, filepath.Clean("/"+key)), nil
+}
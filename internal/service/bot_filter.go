@@ -0,0 +1,124 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+)
+
+// VisitorInfo data request pengunjung yang dipakai untuk klasifikasi bot
+type VisitorInfo struct {
+	UserAgent      string
+	AcceptLanguage string
+	IP             string
+}
+
+// BotFilter klasifikasi event analytics dari bot/crawler
+type BotFilter interface {
+	IsBot(visitor *VisitorInfo, challengeToken string, catalogID int64) bool
+	IsCrawler(visitor *VisitorInfo) bool
+	ChallengeEnabled() bool
+	ChallengeTTL() time.Duration
+	IssueChallenge(catalogID int64) string
+}
+
+// User agent crawler, preview link, HTTP client dan headless browser
+var botUserAgentPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|scrape|facebookexternalhit|whatsapp|telegram|discord|preview|embedly|` +
+	`curl|wget|httpie|python-|go-http-client|java/|okhttp|axios|node-fetch|libwww|http_request|` +
+	`headless|phantomjs|selenium|puppeteer|playwright|lighthouse|pingdom|uptime|monitor|statuscake`)
+
+type botFilter struct {
+	config config.AnalyticsConfig
+}
+
+// NewBotFilter membuat filter bot berbasis user agent, heuristik header dan
+// token JS-challenge (jika secret diset)
+func NewBotFilter(cfg config.AnalyticsConfig) BotFilter {
+	return &botFilter{config: cfg}
+}
+
+// IsBot true jika event tidak boleh dihitung sebagai view manusia
+func (f *botFilter) IsBot(visitor *VisitorInfo, challengeToken string, catalogID int64) bool {
+	if !f.config.BotFilterEnabled {
+		return false
+	}
+
+	if f.IsCrawler(visitor) {
+		return true
+	}
+
+	if f.ChallengeEnabled() && !f.verifyChallenge(challengeToken, catalogID) {
+		return true
+	}
+
+	return false
+}
+
+// IsCrawler cek header saja tanpa token JS-challenge, untuk request navigasi
+// biasa (mis. redirect link) yang tidak bisa membawa token
+func (f *botFilter) IsCrawler(visitor *VisitorInfo) bool {
+	if !f.config.BotFilterEnabled {
+		return false
+	}
+
+	ua := strings.TrimSpace(visitor.UserAgent)
+
+	// Browser asli selalu kirim user agent lengkap dan Accept-Language
+	if len(ua) < 20 || visitor.AcceptLanguage == "" {
+		return true
+	}
+	return botUserAgentPattern.MatchString(ua)
+}
+
+// ChallengeEnabled check apakah verifikasi token JS-challenge aktif
+func (f *botFilter) ChallengeEnabled() bool {
+	return f.config.BotFilterEnabled && f.config.ChallengeSecret != ""
+}
+
+// ChallengeTTL umur maksimal token JS-challenge
+func (f *botFilter) ChallengeTTL() time.Duration {
+	return f.config.ChallengeTTL
+}
+
+// IssueChallenge token untuk dikirim balik oleh script tema bersama event,
+// format: <unix timestamp>.<hmac>
+func (f *botFilter) IssueChallenge(catalogID int64) string {
+	if !f.ChallengeEnabled() {
+		return ""
+	}
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	return ts + "." + f.sign(catalogID, ts)
+}
+
+// verifyChallenge token valid, milik katalog yang sama, dan umurnya wajar:
+// beacon yang dikirim terlalu cepat setelah halaman dimuat dianggap bot
+func (f *botFilter) verifyChallenge(token string, catalogID int64) bool {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return false
+	}
+
+	if !hmac.Equal([]byte(parts[1]), []byte(f.sign(catalogID, parts[0]))) {
+		return false
+	}
+
+	issued, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return false
+	}
+	age := time.Since(time.Unix(issued, 0))
+
+	return age >= f.config.ChallengeMinAge && age <= f.config.ChallengeTTL
+}
+
+func (f *botFilter) sign(catalogID int64, ts string) string {
+	mac := hmac.New(sha256.New, []byte(f.config.ChallengeSecret))
+	mac.Write([]byte(strconv.FormatInt(catalogID, 10) + ":" + ts))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+)
+
+// CanaryService pembagian traffic stable/canary dan metrics per variant.
+// Feature flag hanya aktif untuk request di variant canary
+type CanaryService interface {
+	Enabled() bool
+	Assign(requested string) string
+	FeatureEnabled(variant, flag string) bool
+	Observe(variant string, status int, latency time.Duration)
+	Stats() []VariantStats
+}
+
+// VariantStats metrics request satu variant sejak service berjalan
+type VariantStats struct {
+	Variant        string
+	Requests       int64
+	ClientErrors   int64 // status 4xx
+	ServerErrors   int64 // status 5xx
+	LatencySeconds float64
+}
+
+type canaryService struct {
+	cfg   config.CanaryConfig
+	flags map[string]bool
+
+	mu    sync.Mutex
+	rng   *rand.Rand
+	stats map[string]*VariantStats
+}
+
+// NewCanaryService membuat instance canary service baru
+func NewCanaryService(cfg config.CanaryConfig) CanaryService {
+	if cfg.Percent < 0 {
+		cfg.Percent = 0
+	}
+	if cfg.Percent > 100 {
+		cfg.Percent = 100
+	}
+
+	flags := make(map[string]bool, len(cfg.Flags))
+	for _, flag := range cfg.Flags {
+		flags[strings.TrimSpace(flag)] = true
+	}
+
+	return &canaryService{
+		cfg:   cfg,
+		flags: flags,
+		rng:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		stats: make(map[string]*VariantStats),
+	}
+}
+
+// Enabled check apakah canary routing aktif
+func (s *canaryService) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Assign tentukan variant request. Variant yang diminta (header/cookie) dipakai
+// jika valid, selain itu diundi sesuai persentase canary
+func (s *canaryService) Assign(requested string) string {
+	if !s.cfg.Enabled {
+		return constant.VariantStable
+	}
+
+	switch requested {
+	case constant.VariantStable, constant.VariantCanary:
+		return requested
+	}
+
+	if s.cfg.Percent == 0 {
+		return constant.VariantStable
+	}
+
+	s.mu.Lock()
+	roll := s.rng.Intn(100)
+	s.mu.Unlock()
+
+	if roll < s.cfg.Percent {
+		return constant.VariantCanary
+	}
+	return constant.VariantStable
+}
+
+// FeatureEnabled check apakah feature flag aktif untuk variant
+func (s *canaryService) FeatureEnabled(variant, flag string) bool {
+	return s.cfg.Enabled && variant == constant.VariantCanary && s.flags[flag]
+}
+
+// Observe catat satu request untuk metrics per variant
+func (s *canaryService) Observe(variant string, status int, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats, ok := s.stats[variant]
+	if !ok {
+		stats = &VariantStats{Variant: variant}
+		s.stats[variant] = stats
+	}
+
+	stats.Requests++
+	switch {
+	case status >= 500:
+		stats.ServerErrors++
+	case status >= 400:
+		stats.ClientErrors++
+	}
+	stats.LatencySeconds += latency.Seconds()
+}
+
+// Stats salinan metrics semua variant, urut nama variant
+func (s *canaryService) Stats() []VariantStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make([]VariantStats, 0, len(s.stats))
+	for _, stat := range s.stats {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Variant < stats[j].Variant
+	})
+	return stats
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+)
+
+// Cloudflare membatasi jumlah file per request purge
+const cloudflarePurgeBatchSize = 30
+
+type cloudflarePurger struct {
+	config config.CloudflareConfig
+	client *http.Client
+}
+
+func newCloudflarePurger(cfg config.CloudflareConfig, client *http.Client) CDNPurger {
+	return &cloudflarePurger{config: cfg, client: client}
+}
+
+// Provider nama provider
+func (p *cloudflarePurger) Provider() string {
+	return constant.CDNProviderCloudflare
+}
+
+type cloudflarePurgeResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Purge purge cache per URL via zone purge_cache
+func (p *cloudflarePurger) Purge(urls []string) error {
+	if p.config.ZoneID == "" || p.config.APIToken == "" {
+		return fmt.Errorf("cloudflare: zone id or api token not configured")
+	}
+
+	for start := 0; start < len(urls); start += cloudflarePurgeBatchSize {
+		end := start + cloudflarePurgeBatchSize
+		if end > len(urls) {
+			end = len(urls)
+		}
+
+		body, err := json.Marshal(map[string]interface{}{"files": urls[start:end]})
+		if err != nil {
+			return err
+		}
+
+		url := fmt.Sprintf("%s/zones/%s/purge_cache", p.config.BaseURL, p.config.ZoneID)
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+p.config.APIToken)
+		req.Header.Set("Content-Type", "application/json")
+
+		var resp cloudflarePurgeResponse
+		if err := doJSONRequest(p.client, req, &resp); err != nil {
+			return err
+		}
+		if !resp.Success {
+			if len(resp.Errors) > 0 {
+				return fmt.Errorf("cloudflare: %s", resp.Errors[0].Message)
+			}
+			return fmt.Errorf("cloudflare: purge failed")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+)
+
+type fastlyPurger struct {
+	config config.FastlyConfig
+	client *http.Client
+}
+
+func newFastlyPurger(cfg config.FastlyConfig, client *http.Client) CDNPurger {
+	return &fastlyPurger{config: cfg, client: client}
+}
+
+// Provider nama provider
+func (p *fastlyPurger) Provider() string {
+	return constant.CDNProviderFastly
+}
+
+type fastlyPurgeResponse struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+}
+
+// Purge purge cache per URL, Fastly tidak punya purge batch berbasis URL
+func (p *fastlyPurger) Purge(urls []string) error {
+	if p.config.APIToken == "" {
+		return fmt.Errorf("fastly: api token not configured")
+	}
+
+	for _, target := range urls {
+		// Endpoint purge menerima host + path tanpa scheme
+		path := strings.TrimPrefix(strings.TrimPrefix(target, "https://"), "http://")
+
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/purge/%s", p.config.BaseURL, path), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Fastly-Key", p.config.APIToken)
+		req.Header.Set("Accept", "application/json")
+
+		var resp fastlyPurgeResponse
+		if err := doJSONRequest(p.client, req, &resp); err != nil {
+			return err
+		}
+		if resp.Status != "ok" {
+			return fmt.Errorf("fastly: purge %s returned status %q", target, resp.Status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// CDNPurger purge cache edge CDN untuk daftar URL
+type CDNPurger interface {
+	Provider() string
+	Purge(urls []string) error
+}
+
+// NewCDNPurger membuat purger sesuai provider, provider kosong berarti
+// tidak ada CDN di environment ini (nil)
+func NewCDNPurger(cfg config.CDNConfig) (CDNPurger, error) {
+	client := &http.Client{Timeout: cfg.HTTPTimeout}
+
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case constant.CDNProviderCloudflare:
+		return newCloudflarePurger(cfg.Cloudflare, client), nil
+	case constant.CDNProviderFastly:
+		return newFastlyPurger(cfg.Fastly, client), nil
+	}
+
+	return nil, fmt.Errorf("unsupported cdn provider %q", cfg.Provider)
+}
+
+// CacheInvalidationService invalidasi cache halaman katalog publik
+type CacheInvalidationService interface {
+	Start()
+	Stop()
+	InvalidateCatalog(slug string)
+	Enabled() bool
+	// PurgeCatalog purge langsung halaman katalog dan halaman detail card,
+	// mengembalikan URL yang di-purge
+	PurgeCatalog(slug string, cardSlugs []string) ([]string, error)
+}
+
+type cacheInvalidationService struct {
+	purger        CDNPurger
+	purgeURLs     []string
+	cardPurgeURLs []string
+	log           logger.Logger
+	queue         chan string
+	wg            sync.WaitGroup
+	stop          chan bool
+}
+
+// NewCacheInvalidationService membuat service invalidasi cache,
+// purger nil berarti invalidasi dinonaktifkan
+func NewCacheInvalidationService(purger CDNPurger, purgeURLs, cardPurgeURLs []string, log logger.Logger) CacheInvalidationService {
+	return &cacheInvalidationService{
+		purger:        purger,
+		purgeURLs:     purgeURLs,
+		cardPurgeURLs: cardPurgeURLs,
+		log:           log,
+		queue:         make(chan string, 500),
+		stop:          make(chan bool),
+	}
+}
+
+// Start memulai purge worker
+func (s *cacheInvalidationService) Start() {
+	s.wg.Add(1)
+	go s.worker()
+}
+
+// Stop menghentikan worker setelah queue habis
+func (s *cacheInvalidationService) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// InvalidateCatalog antrikan purge halaman publik katalog (non-blocking)
+func (s *cacheInvalidationService) InvalidateCatalog(slug string) {
+	if s.purger == nil || len(s.purgeURLs) == 0 || slug == "" {
+		return
+	}
+
+	select {
+	case s.queue <- slug:
+	default:
+		s.log.Error("Cache invalidation queue full, dropping purge",
+			logger.String("slug", slug),
+		)
+	}
+}
+
+// Enabled check apakah CDN purge dikonfigurasi
+func (s *cacheInvalidationService) Enabled() bool {
+	return s.purger != nil && len(s.purgeURLs) > 0
+}
+
+// PurgeCatalog purge sinkron untuk permintaan manual pemilik katalog
+func (s *cacheInvalidationService) PurgeCatalog(slug string, cardSlugs []string) ([]string, error) {
+	urls := s.catalogURLs(slug)
+	for _, cardSlug := range cardSlugs {
+		replacer := strings.NewReplacer("{slug}", slug, "{card_slug}", cardSlug)
+		for _, tmpl := range s.cardPurgeURLs {
+			urls = append(urls, replacer.Replace(tmpl))
+		}
+	}
+
+	if err := s.purger.Purge(urls); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+func (s *cacheInvalidationService) worker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case slug := <-s.queue:
+			s.purge(slug)
+		case <-s.stop:
+			for {
+				select {
+				case slug := <-s.queue:
+					s.purge(slug)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *cacheInvalidationService) catalogURLs(slug string) []string {
+	urls := make([]string, 0, len(s.purgeURLs))
+	for _, tmpl := range s.purgeURLs {
+		urls = append(urls, strings.ReplaceAll(tmpl, "{slug}", slug))
+	}
+	return urls
+}
+
+func (s *cacheInvalidationService) purge(slug string) {
+	urls := s.catalogURLs(slug)
+
+	if err := s.purger.Purge(urls); err != nil {
+		s.log.Warn("Failed to purge CDN cache",
+			logger.String("provider", s.purger.Provider()),
+			logger.String("slug", slug),
+			logger.Error(err),
+		)
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock sumber waktu untuk use case dan scheduler, dipakai menggantikan
+// time.Now() agar alur kedaluwarsa bisa diuji secara deterministik
+type Clock interface {
+	Now() time.Time
+	// NowFor waktu untuk business, memperhitungkan offset time-travel business tersebut
+	NowFor(businessID int64) time.Time
+	// TravelingBusinesses business yang punya offset sendiri, dipakai job sweep
+	// untuk memproses business tersebut dengan waktunya masing-masing
+	TravelingBusinesses() []int64
+}
+
+// ClockStatus status clock time-travel untuk endpoint admin
+type ClockStatus struct {
+	BusinessID    int64     `json:"business_id,omitempty"`
+	Now           time.Time `json:"now"`
+	Offset        string    `json:"offset"`
+	OffsetSeconds int64     `json:"offset_seconds"`
+}
+
+// ClockOffsetRequest request ubah offset clock, format durasi Go (72h, -30m)
+type ClockOffsetRequest struct {
+	Offset string `json:"offset" binding:"required"`
+}
+
+type systemClock struct{}
+
+// NewSystemClock membuat clock yang mengikuti waktu sistem
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+// Now waktu sistem saat ini
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NowFor waktu sistem, tanpa offset per business
+func (systemClock) NowFor(int64) time.Time {
+	return time.Now()
+}
+
+// TravelingBusinesses selalu kosong
+func (systemClock) TravelingBusinesses() []int64 {
+	return nil
+}
+
+// FrozenClock clock yang berhenti di satu titik waktu, hanya bergerak
+// lewat Set atau Advance. Dipakai di test
+type FrozenClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewFrozenClock membuat clock yang berhenti di waktu t
+func NewFrozenClock(t time.Time) *FrozenClock {
+	return &FrozenClock{now: t}
+}
+
+// Now waktu clock saat ini
+func (c *FrozenClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// NowFor waktu clock saat ini, sama untuk semua business
+func (c *FrozenClock) NowFor(int64) time.Time {
+	return c.Now()
+}
+
+// TravelingBusinesses selalu kosong
+func (c *FrozenClock) TravelingBusinesses() []int64 {
+	return nil
+}
+
+// Set pindahkan clock ke waktu t
+func (c *FrozenClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
+
+// Advance majukan clock sebanyak d
+func (c *FrozenClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// OffsetClock clock yang berjalan normal dengan selisih offset dari clock
+// dasar. Dipakai untuk time-travel QA di lingkungan sandbox. Business bisa
+// punya offset sendiri yang menggantikan offset global, sehingga beberapa
+// sandbox bisa time-travel tanpa saling mengganggu
+type OffsetClock struct {
+	base     Clock
+	mu       sync.RWMutex
+	offset   time.Duration
+	business map[int64]time.Duration
+}
+
+// NewOffsetClock membuat clock dengan offset dari base, base nil berarti waktu sistem
+func NewOffsetClock(base Clock, offset time.Duration) *OffsetClock {
+	if base == nil {
+		base = NewSystemClock()
+	}
+	return &OffsetClock{base: base, offset: offset, business: make(map[int64]time.Duration)}
+}
+
+// Now waktu clock dasar ditambah offset global
+func (c *OffsetClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.Now().Add(c.offset)
+}
+
+// NowFor waktu clock dasar ditambah offset business, atau offset global jika
+// business tidak punya offset sendiri
+func (c *OffsetClock) NowFor(businessID int64) time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.Now().Add(c.businessOffset(businessID))
+}
+
+// TravelingBusinesses business yang punya offset sendiri
+func (c *OffsetClock) TravelingBusinesses() []int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ids := make([]int64, 0, len(c.business))
+	for id := range c.business {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Offset selisih clock terhadap clock dasar
+func (c *OffsetClock) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
+
+// SetOffset ubah selisih clock, 0 berarti kembali ke waktu clock dasar
+func (c *OffsetClock) SetOffset(offset time.Duration) {
+	c.mu.Lock()
+	c.offset = offset
+	c.mu.Unlock()
+}
+
+// SetBusinessOffset ubah offset satu business
+func (c *OffsetClock) SetBusinessOffset(businessID int64, offset time.Duration) {
+	c.mu.Lock()
+	c.business[businessID] = offset
+	c.mu.Unlock()
+}
+
+// ResetBusinessOffset hapus offset business, business kembali mengikuti offset global
+func (c *OffsetClock) ResetBusinessOffset(businessID int64) {
+	c.mu.Lock()
+	delete(c.business, businessID)
+	c.mu.Unlock()
+}
+
+// Status status clock saat ini
+func (c *OffsetClock) Status() *ClockStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &ClockStatus{
+		Now:           c.base.Now().Add(c.offset),
+		Offset:        c.offset.String(),
+		OffsetSeconds: int64(c.offset / time.Second),
+	}
+}
+
+// BusinessStatus status clock yang berlaku untuk business
+func (c *OffsetClock) BusinessStatus(businessID int64) *ClockStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	offset := c.businessOffset(businessID)
+	return &ClockStatus{
+		BusinessID:    businessID,
+		Now:           c.base.Now().Add(offset),
+		Offset:        offset.String(),
+		OffsetSeconds: int64(offset / time.Second),
+	}
+}
+
+// businessOffset offset yang berlaku untuk business, mu harus sudah dikunci
+func (c *OffsetClock) businessOffset(businessID int64) time.Duration {
+	if offset, ok := c.business[businessID]; ok {
+		return offset
+	}
+	return c.offset
+}
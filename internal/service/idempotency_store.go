@@ -0,0 +1,154 @@
+package service
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/pkg/redis"
+)
+
+// IdempotencyStore penyimpanan response request yang membawa Idempotency-Key
+type IdempotencyStore interface {
+	Enabled() bool
+	// Begin klaim key untuk request baru. Jika key sudah pernah dipakai,
+	// record yang tersimpan dikembalikan dengan found = true
+	Begin(key, requestHash string) (record *IdempotencyRecord, found bool, err error)
+	// Complete simpan response request yang berhasil untuk di-replay
+	Complete(key string, record *IdempotencyRecord) error
+	// Release lepas klaim key agar request bisa dicoba ulang
+	Release(key string) error
+}
+
+// IdempotencyRecord request yang sudah diklaim beserta response-nya,
+// Status 0 berarti request pertama masih diproses
+type IdempotencyRecord struct {
+	RequestHash string `json:"h"`
+	Status      int    `json:"s"`
+	ContentType string `json:"ct,omitempty"`
+	Body        []byte `json:"b,omitempty"`
+}
+
+// InProgress check apakah request pertama belum selesai
+func (r *IdempotencyRecord) InProgress() bool {
+	return r.Status == 0
+}
+
+type idempotencyStore struct {
+	cfg    config.IdempotencyConfig
+	client *redis.Client
+
+	// Record disimpan di memori proses jika Redis tidak tersedia
+	mu      sync.Mutex
+	records map[string]*memoryIdempotencyRecord
+}
+
+type memoryIdempotencyRecord struct {
+	record    *IdempotencyRecord
+	expiresAt time.Time
+}
+
+// NewIdempotencyStore membuat store idempotency berbasis Redis,
+// client nil berarti record disimpan di memori proses (hanya untuk satu instance)
+func NewIdempotencyStore(cfg config.IdempotencyConfig, client *redis.Client) IdempotencyStore {
+	return &idempotencyStore{
+		cfg:     cfg,
+		client:  client,
+		records: make(map[string]*memoryIdempotencyRecord),
+	}
+}
+
+// Enabled check apakah Idempotency-Key dihormati
+func (s *idempotencyStore) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Begin klaim key dengan record "sedang diproses" yang kedaluwarsa setelah LockTTL,
+// sehingga request yang crash tidak mengunci key selamanya
+func (s *idempotencyStore) Begin(key, requestHash string) (*IdempotencyRecord, bool, error) {
+	pending := &IdempotencyRecord{RequestHash: requestHash}
+
+	if s.client != nil {
+		value, err := json.Marshal(pending)
+		if err != nil {
+			return nil, false, err
+		}
+
+		reply, err := s.client.Do("SET", idempotencyKey(key), string(value), "NX", "PX", strconv.FormatInt(s.cfg.LockTTL.Milliseconds(), 10))
+		if err != nil {
+			return nil, false, err
+		}
+		if reply != nil {
+			return nil, false, nil
+		}
+
+		stored, err := s.client.Do("GET", idempotencyKey(key))
+		if err != nil {
+			return nil, false, err
+		}
+		raw, ok := stored.(string)
+		if !ok {
+			// Key kedaluwarsa di antara SET dan GET, anggap request baru
+			return s.Begin(key, requestHash)
+		}
+
+		record := &IdempotencyRecord{}
+		if err := json.Unmarshal([]byte(raw), record); err != nil {
+			return nil, false, err
+		}
+		return record, true, nil
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, entry := range s.records {
+		if now.After(entry.expiresAt) {
+			delete(s.records, k)
+		}
+	}
+
+	if entry, ok := s.records[key]; ok {
+		return entry.record, true, nil
+	}
+	s.records[key] = &memoryIdempotencyRecord{record: pending, expiresAt: now.Add(s.cfg.LockTTL)}
+	return nil, false, nil
+}
+
+// Complete simpan response selama TTL
+func (s *idempotencyStore) Complete(key string, record *IdempotencyRecord) error {
+	if s.client != nil {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		_, err = s.client.Do("SET", idempotencyKey(key), string(value), "PX", strconv.FormatInt(s.cfg.TTL.Milliseconds(), 10))
+		return err
+	}
+
+	s.mu.Lock()
+	s.records[key] = &memoryIdempotencyRecord{record: record, expiresAt: time.Now().Add(s.cfg.TTL)}
+	s.mu.Unlock()
+	return nil
+}
+
+// Release hapus klaim key
+func (s *idempotencyStore) Release(key string) error {
+	if s.client != nil {
+		_, err := s.client.Do("DEL", idempotencyKey(key))
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.records, key)
+	s.mu.Unlock()
+	return nil
+}
+
+func idempotencyKey(key string) string {
+	return "idempotency:" + key
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"database/sql"
+	"sync/atomic"
+
+	"github.com/atam/atamlink/internal/config"
+)
+
+// Alasan load shedding, dipakai sebagai label metrics
+const (
+	ShedReasonInFlight   = "in_flight"
+	ShedReasonDBPool     = "db_pool"
+	ShedReasonAuditQueue = "audit_queue"
+)
+
+// LoadShedder pantau tekanan resource (request in-flight, pool koneksi
+// database, queue audit) untuk menolak request prioritas rendah lebih dulu
+type LoadShedder interface {
+	Enabled() bool
+	Begin() func()
+	Overloaded() (bool, string)
+	Shed(reason string)
+	Stats() LoadShedStats
+}
+
+// LoadShedStats kondisi load shedder untuk metrics
+type LoadShedStats struct {
+	InFlight int64
+	Shed     map[string]int64 // jumlah request ditolak per alasan
+}
+
+type loadShedder struct {
+	cfg          config.LoadShedConfig
+	db           *sql.DB
+	auditService AuditService
+
+	inFlight     atomic.Int64
+	shedInFlight atomic.Int64
+	shedDBPool   atomic.Int64
+	shedAudit    atomic.Int64
+}
+
+// NewLoadShedder membuat instance load shedder baru
+func NewLoadShedder(cfg config.LoadShedConfig, db *sql.DB, auditService AuditService) LoadShedder {
+	return &loadShedder{
+		cfg:          cfg,
+		db:           db,
+		auditService: auditService,
+	}
+}
+
+// Enabled check apakah load shedding aktif
+func (s *loadShedder) Enabled() bool {
+	return s.cfg.Enabled
+}
+
+// Begin catat satu request in-flight, panggil fungsi hasilnya saat request selesai
+func (s *loadShedder) Begin() func() {
+	s.inFlight.Add(1)
+	return func() {
+		s.inFlight.Add(-1)
+	}
+}
+
+// Overloaded check apakah salah satu resource melewati ambang, beserta alasannya
+func (s *loadShedder) Overloaded() (bool, string) {
+	if !s.cfg.Enabled {
+		return false, ""
+	}
+
+	if s.cfg.MaxInFlight > 0 && s.inFlight.Load() > int64(s.cfg.MaxInFlight) {
+		return true, ShedReasonInFlight
+	}
+
+	if s.cfg.DBPoolPercent > 0 {
+		stats := s.db.Stats()
+		if stats.MaxOpenConnections > 0 && stats.InUse*100 >= stats.MaxOpenConnections*s.cfg.DBPoolPercent {
+			return true, ShedReasonDBPool
+		}
+	}
+
+	if s.cfg.AuditQueuePercent > 0 {
+		health := s.auditService.Health()
+		if health.QueueCapacity > 0 && health.QueueDepth*100 >= health.QueueCapacity*s.cfg.AuditQueuePercent {
+			return true, ShedReasonAuditQueue
+		}
+	}
+
+	return false, ""
+}
+
+// Shed catat satu request yang ditolak
+func (s *loadShedder) Shed(reason string) {
+	switch reason {
+	case ShedReasonInFlight:
+		s.shedInFlight.Add(1)
+	case ShedReasonDBPool:
+		s.shedDBPool.Add(1)
+	case ShedReasonAuditQueue:
+		s.shedAudit.Add(1)
+	}
+}
+
+// Stats kondisi load shedder saat ini
+func (s *loadShedder) Stats() LoadShedStats {
+	return LoadShedStats{
+		InFlight: s.inFlight.Load(),
+		Shed: map[string]int64{
+			ShedReasonInFlight:   s.shedInFlight.Load(),
+			ShedReasonDBPool:     s.shedDBPool.Load(),
+			ShedReasonAuditQueue: s.shedAudit.Load(),
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/atam/atamlink/internal/config"
+)
+
+// MailService pengirim email teks lewat SMTP
+type MailService interface {
+	IsConfigured() bool
+	Send(to, subject, body string) error
+}
+
+type mailService struct {
+	config config.MailConfig
+}
+
+// NewMailService membuat mail service SMTP
+func NewMailService(cfg config.MailConfig) MailService {
+	return &mailService{config: cfg}
+}
+
+// IsConfigured check apakah host SMTP dan alamat pengirim sudah diset
+func (s *mailService) IsConfigured() bool {
+	return s.config.SMTPHost != "" && s.config.From != ""
+}
+
+// Send kirim email plain text
+func (s *mailService) Send(to, subject, body string) error {
+	if !s.IsConfigured() {
+		return fmt.Errorf("mail: smtp not configured")
+	}
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("mail: invalid header value")
+	}
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.SMTPHost)
+	}
+
+	message := "From: " + s.config.From + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body
+
+	addr := net.JoinHostPort(s.config.SMTPHost, s.config.SMTPPort)
+	return smtp.SendMail(addr, auth, s.config.From, []string{to}, []byte(message))
+}
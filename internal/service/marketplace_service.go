@@ -0,0 +1,86 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// ExternalProduct produk hasil fetch dari marketplace
+type ExternalProduct struct {
+	ExternalID string
+	Title      string
+	Price      int64
+	URL        string
+	ImageURLs  []string
+}
+
+// MarketplaceConnector connector untuk satu marketplace
+type MarketplaceConnector interface {
+	Provider() string
+	FetchProducts(shopID string, credentials map[string]string) ([]ExternalProduct, error)
+}
+
+// MarketplaceService registry connector marketplace
+type MarketplaceService interface {
+	Connector(provider string) (MarketplaceConnector, error)
+}
+
+type marketplaceService struct {
+	connectors map[string]MarketplaceConnector
+}
+
+// NewMarketplaceService membuat instance marketplace service baru
+func NewMarketplaceService(cfg config.IntegrationConfig) MarketplaceService {
+	client := &http.Client{Timeout: cfg.HTTPTimeout}
+
+	connectors := []MarketplaceConnector{
+		newShopeeConnector(cfg.Shopee, client),
+		newTokopediaConnector(cfg.Tokopedia, client),
+	}
+
+	s := &marketplaceService{connectors: make(map[string]MarketplaceConnector)}
+	for _, c := range connectors {
+		s.connectors[c.Provider()] = c
+	}
+
+	return s
+}
+
+// Connector mendapatkan connector untuk provider
+func (s *marketplaceService) Connector(provider string) (MarketplaceConnector, error) {
+	c, ok := s.connectors[provider]
+	if !ok {
+		return nil, errors.New(errors.ErrBadRequest, constant.ErrMsgMarketplaceInvalid, 400)
+	}
+	return c, nil
+}
+
+// doJSONRequest eksekusi request ke API eksternal dan decode response JSON
+func doJSONRequest(client *http.Client, req *http.Request, out interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call external api")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return errors.Wrap(err, "failed to read external api response")
+	}
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("external api returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return errors.Wrap(err, "failed to parse external api response")
+	}
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+)
+
+const (
+	shopeeItemListPath     = "/api/v2/product/get_item_list"
+	shopeeItemBaseInfoPath = "/api/v2/product/get_item_base_info"
+	shopeePageSize         = 50
+)
+
+type shopeeConnector struct {
+	config config.ShopeeConfig
+	client *http.Client
+}
+
+func newShopeeConnector(cfg config.ShopeeConfig, client *http.Client) MarketplaceConnector {
+	return &shopeeConnector{config: cfg, client: client}
+}
+
+// Provider nama provider
+func (c *shopeeConnector) Provider() string {
+	return constant.MarketplaceShopee
+}
+
+type shopeeItemListResponse struct {
+	Error    string `json:"error"`
+	Message  string `json:"message"`
+	Response struct {
+		Item []struct {
+			ItemID int64 `json:"item_id"`
+		} `json:"item"`
+		HasNextPage bool `json:"has_next_page"`
+		NextOffset  int  `json:"next_offset"`
+	} `json:"response"`
+}
+
+type shopeeItemBaseInfoResponse struct {
+	Error    string `json:"error"`
+	Message  string `json:"message"`
+	Response struct {
+		ItemList []struct {
+			ItemID    int64  `json:"item_id"`
+			ItemName  string `json:"item_name"`
+			PriceInfo []struct {
+				CurrentPrice float64 `json:"current_price"`
+			} `json:"price_info"`
+			Image struct {
+				ImageURLList []string `json:"image_url_list"`
+			} `json:"image"`
+		} `json:"item_list"`
+	} `json:"response"`
+}
+
+// FetchProducts ambil semua produk normal dari toko Shopee
+func (c *shopeeConnector) FetchProducts(shopID string, credentials map[string]string) ([]ExternalProduct, error) {
+	accessToken := credentials["access_token"]
+	if accessToken == "" {
+		return nil, fmt.Errorf("shopee: access_token is required")
+	}
+
+	// Kumpulkan semua item ID
+	var itemIDs []int64
+	offset := 0
+	for {
+		params := url.Values{}
+		params.Set("offset", strconv.Itoa(offset))
+		params.Set("page_size", strconv.Itoa(shopeePageSize))
+		params.Set("item_status", "NORMAL")
+
+		var resp shopeeItemListResponse
+		if err := c.get(shopeeItemListPath, shopID, accessToken, params, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("shopee: %s: %s", resp.Error, resp.Message)
+		}
+
+		for _, item := range resp.Response.Item {
+			itemIDs = append(itemIDs, item.ItemID)
+		}
+
+		if !resp.Response.HasNextPage {
+			break
+		}
+		offset = resp.Response.NextOffset
+	}
+
+	// Ambil detail per batch (maksimal 50 item per request)
+	products := make([]ExternalProduct, 0, len(itemIDs))
+	for start := 0; start < len(itemIDs); start += shopeePageSize {
+		end := start + shopeePageSize
+		if end > len(itemIDs) {
+			end = len(itemIDs)
+		}
+
+		ids := make([]string, 0, end-start)
+		for _, id := range itemIDs[start:end] {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+
+		params := url.Values{}
+		params.Set("item_id_list", strings.Join(ids, ","))
+
+		var resp shopeeItemBaseInfoResponse
+		if err := c.get(shopeeItemBaseInfoPath, shopID, accessToken, params, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("shopee: %s: %s", resp.Error, resp.Message)
+		}
+
+		for _, item := range resp.Response.ItemList {
+			product := ExternalProduct{
+				ExternalID: strconv.FormatInt(item.ItemID, 10),
+				Title:      item.ItemName,
+				URL:        fmt.Sprintf("https://shopee.co.id/product/%s/%d", shopID, item.ItemID),
+				ImageURLs:  item.Image.ImageURLList,
+			}
+			if len(item.PriceInfo) > 0 {
+				product.Price = int64(math.Round(item.PriceInfo[0].CurrentPrice))
+			}
+			products = append(products, product)
+		}
+	}
+
+	return products, nil
+}
+
+// get kirim signed request ke Shopee Open Platform
+func (c *shopeeConnector) get(path, shopID, accessToken string, params url.Values, out interface{}) error {
+	timestamp := time.Now().Unix()
+
+	params.Set("partner_id", strconv.FormatInt(c.config.PartnerID, 10))
+	params.Set("timestamp", strconv.FormatInt(timestamp, 10))
+	params.Set("access_token", accessToken)
+	params.Set("shop_id", shopID)
+	params.Set("sign", c.sign(path, timestamp, accessToken, shopID))
+
+	req, err := http.NewRequest(http.MethodGet, c.config.BaseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	return doJSONRequest(c.client, req, out)
+}
+
+// sign HMAC-SHA256 dari partner_id+path+timestamp+access_token+shop_id
+func (c *shopeeConnector) sign(path string, timestamp int64, accessToken, shopID string) string {
+	base := fmt.Sprintf("%d%s%d%s%s", c.config.PartnerID, path, timestamp, accessToken, shopID)
+	mac := hmac.New(sha256.New, []byte(c.config.PartnerKey))
+	mac.Write([]byte(base))
+	return hex.EncodeToString(mac.Sum(nil))
+}
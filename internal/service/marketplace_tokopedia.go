@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+)
+
+const tokopediaPageSize = 50
+
+type tokopediaConnector struct {
+	config config.TokopediaConfig
+	client *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func newTokopediaConnector(cfg config.TokopediaConfig, client *http.Client) MarketplaceConnector {
+	return &tokopediaConnector{config: cfg, client: client}
+}
+
+// Provider nama provider
+func (c *tokopediaConnector) Provider() string {
+	return constant.MarketplaceTokopedia
+}
+
+type tokopediaTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type tokopediaProductResponse struct {
+	Header struct {
+		Reason string `json:"reason"`
+	} `json:"header"`
+	Data []struct {
+		Basic struct {
+			ProductID int64  `json:"productID"`
+			Name      string `json:"name"`
+		} `json:"basic"`
+		Price struct {
+			Value float64 `json:"value"`
+		} `json:"price"`
+		Other struct {
+			URL string `json:"url"`
+		} `json:"other"`
+		Pictures []struct {
+			OriginalURL string `json:"OriginalURL"`
+		} `json:"pictures"`
+	} `json:"data"`
+}
+
+// FetchProducts ambil semua produk dari toko Tokopedia
+func (c *tokopediaConnector) FetchProducts(shopID string, credentials map[string]string) ([]ExternalProduct, error) {
+	token, err := c.token()
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]ExternalProduct, 0)
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("shop_id", shopID)
+		params.Set("page", strconv.Itoa(page))
+		params.Set("per_page", strconv.Itoa(tokopediaPageSize))
+
+		endpoint := fmt.Sprintf("%s/inventory/v1/fs/%s/product/info?%s", c.config.BaseURL, c.config.FSID, params.Encode())
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		var resp tokopediaProductResponse
+		if err := doJSONRequest(c.client, req, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Header.Reason != "" {
+			return nil, fmt.Errorf("tokopedia: %s", resp.Header.Reason)
+		}
+
+		for _, item := range resp.Data {
+			images := make([]string, 0, len(item.Pictures))
+			for _, pic := range item.Pictures {
+				images = append(images, pic.OriginalURL)
+			}
+
+			products = append(products, ExternalProduct{
+				ExternalID: strconv.FormatInt(item.Basic.ProductID, 10),
+				Title:      item.Basic.Name,
+				Price:      int64(math.Round(item.Price.Value)),
+				URL:        item.Other.URL,
+				ImageURLs:  images,
+			})
+		}
+
+		if len(resp.Data) < tokopediaPageSize {
+			break
+		}
+	}
+
+	return products, nil
+}
+
+// token ambil access token client credentials (di-cache sampai expired)
+func (c *tokopediaConnector) token() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Now().Before(c.expiresAt) {
+		return c.accessToken, nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.config.AuthURL+"?grant_type=client_credentials", nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(c.config.ClientID, c.config.ClientSecret)
+
+	var resp tokopediaTokenResponse
+	if err := doJSONRequest(c.client, req, &resp); err != nil {
+		return "", err
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf("tokopedia: empty access token")
+	}
+
+	c.accessToken = resp.AccessToken
+	// Refresh 1 menit sebelum expired
+	c.expiresAt = time.Now().Add(time.Duration(resp.ExpiresIn)*time.Second - time.Minute)
+
+	return c.accessToken, nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+
+	"github.com/atam/atamlink/internal/config"
+)
+
+// cloudinaryVersionPattern segmen versi di URL Cloudinary, mis. v1700000000
+var cloudinaryVersionPattern = regexp.MustCompile(`^v\d+$`)
+
+// MediaArchiveService pindahkan original media yang jarang diakses ke akun
+// Cloudinary infrequent-access (dikompres saat dipindah)
+type MediaArchiveService interface {
+	Enabled() bool
+	Archive(sourceURL string) (string, error)
+	DeleteOriginal(sourceURL string) error
+}
+
+type mediaArchiveService struct {
+	config    config.MediaArchiveConfig
+	hotCloud  string
+	hot       *cloudinary.Cloudinary
+	archive   *cloudinary.Cloudinary
+	transform string
+}
+
+// NewMediaArchiveService membuat service arsip media. hot adalah akun Cloudinary
+// utama tempat original dihapus setelah dipindah. Jika tidak dikonfigurasi,
+// arsip media dinonaktifkan
+func NewMediaArchiveService(cfg config.MediaArchiveConfig, hot config.CloudinaryConfig) (MediaArchiveService, error) {
+	s := &mediaArchiveService{
+		config:   cfg,
+		hotCloud: hot.CloudName,
+	}
+	if cfg.Quality != "" {
+		s.transform = "q_" + cfg.Quality
+	}
+
+	if !cfg.Enabled {
+		return s, nil
+	}
+
+	archive, err := cloudinary.NewFromParams(cfg.CloudName, cfg.APIKey, cfg.APISecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize archive storage: %w", err)
+	}
+	s.archive = archive
+
+	hotCld, err := cloudinary.NewFromParams(hot.CloudName, hot.APIKey, hot.APISecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize media storage: %w", err)
+	}
+	s.hot = hotCld
+
+	return s, nil
+}
+
+// Enabled check apakah storage arsip tersedia
+func (s *mediaArchiveService) Enabled() bool {
+	return s.archive != nil
+}
+
+// Archive salin media ke storage arsip dengan kompresi, public ID diturunkan
+// dari URL sumber sehingga percobaan ulang menimpa file yang sama
+func (s *mediaArchiveService) Archive(sourceURL string) (string, error) {
+	if s.archive == nil {
+		return "", fmt.Errorf("media archive not configured")
+	}
+
+	sum := sha1.Sum([]byte(sourceURL))
+	overwrite := true
+	params := uploader.UploadParams{
+		PublicID:  hex.EncodeToString(sum[:]),
+		Folder:    s.config.Folder,
+		Overwrite: &overwrite,
+	}
+	// Video dan dokumen disalin apa adanya, kompresi hanya untuk gambar
+	if resourceType, _, ok := s.parseHotURL(sourceURL); !ok || resourceType == "image" {
+		params.Transformation = s.transform
+	} else {
+		params.ResourceType = resourceType
+	}
+
+	result, err := s.archive.Upload.Upload(context.Background(), sourceURL, params)
+	if err != nil {
+		return "", err
+	}
+	if result.Error.Message != "" {
+		return "", fmt.Errorf("archive storage: %s", result.Error.Message)
+	}
+
+	return result.SecureURL, nil
+}
+
+// DeleteOriginal hapus original dari akun Cloudinary utama. URL di luar akun
+// utama (mis. upload lokal) dibiarkan
+func (s *mediaArchiveService) DeleteOriginal(sourceURL string) error {
+	if s.hot == nil {
+		return nil
+	}
+
+	resourceType, publicID, ok := s.parseHotURL(sourceURL)
+	if !ok {
+		return nil
+	}
+
+	invalidate := true
+	_, err := s.hot.Upload.Destroy(context.Background(), uploader.DestroyParams{
+		PublicID:     publicID,
+		ResourceType: resourceType,
+		Invalidate:   &invalidate,
+	})
+	return err
+}
+
+// parseHotURL ambil resource type dan public ID dari URL delivery akun utama,
+// format https://res.cloudinary.com/<cloud>/<resource>/upload/[v123/]<public_id>.<ext>
+func (s *mediaArchiveService) parseHotURL(rawURL string) (string, string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host != "res.cloudinary.com" {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != s.hotCloud || parts[2] != "upload" {
+		return "", "", false
+	}
+
+	rest := parts[3:]
+	if len(rest) > 1 && cloudinaryVersionPattern.MatchString(rest[0]) {
+		rest = rest[1:]
+	}
+	publicID := strings.Join(rest, "/")
+	// Public ID file raw termasuk ekstensinya
+	if parts[1] != "raw" {
+		publicID = strings.TrimSuffix(publicID, path.Ext(publicID))
+	}
+	if publicID == "" {
+		return "", "", false
+	}
+
+	return parts[1], publicID, true
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+
+	"github.com/atam/atamlink/internal/config"
+)
+
+// MediaReplicationService replikasi media ke storage/CDN region kedua
+type MediaReplicationService interface {
+	Enabled() bool
+	Replicate(sourceURL string) (string, error)
+	UseReplica(country string) bool
+}
+
+type mediaReplicationService struct {
+	config     config.MediaReplicationConfig
+	cloudinary *cloudinary.Cloudinary
+	home       map[string]bool
+}
+
+// NewMediaReplicationService membuat service replikasi media ke akun Cloudinary
+// kedua (region lain). Jika tidak dikonfigurasi, replikasi dinonaktifkan
+func NewMediaReplicationService(cfg config.MediaReplicationConfig) (MediaReplicationService, error) {
+	s := &mediaReplicationService{
+		config: cfg,
+		home:   make(map[string]bool, len(cfg.PrimaryCountries)),
+	}
+	for _, country := range cfg.PrimaryCountries {
+		s.home[strings.ToUpper(strings.TrimSpace(country))] = true
+	}
+
+	if !cfg.Enabled {
+		return s, nil
+	}
+
+	cld, err := cloudinary.NewFromParams(cfg.CloudName, cfg.APIKey, cfg.APISecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize replica storage: %w", err)
+	}
+	s.cloudinary = cld
+
+	return s, nil
+}
+
+// Enabled check apakah storage region kedua tersedia
+func (s *mediaReplicationService) Enabled() bool {
+	return s.cloudinary != nil
+}
+
+// Replicate salin media dari URL sumber ke storage kedua, public ID diturunkan
+// dari URL sumber sehingga replikasi ulang menimpa file yang sama
+func (s *mediaReplicationService) Replicate(sourceURL string) (string, error) {
+	if s.cloudinary == nil {
+		return "", fmt.Errorf("media replication not configured")
+	}
+
+	sum := sha1.Sum([]byte(sourceURL))
+	overwrite := true
+
+	result, err := s.cloudinary.Upload.Upload(context.Background(), sourceURL, uploader.UploadParams{
+		PublicID:  hex.EncodeToString(sum[:]),
+		Folder:    s.config.Folder,
+		Overwrite: &overwrite,
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.Error.Message != "" {
+		return "", fmt.Errorf("replica storage: %s", result.Error.Message)
+	}
+
+	return result.SecureURL, nil
+}
+
+// UseReplica pengunjung dari luar negara utama dilayani dari region kedua.
+// Negara tidak diketahui tetap dilayani dari region utama
+func (s *mediaReplicationService) UseReplica(country string) bool {
+	if s.cloudinary == nil {
+		return false
+	}
+	country = strings.ToUpper(strings.TrimSpace(country))
+	// XX dan T1 dipakai CDN untuk negara tidak dikenal / Tor
+	if country == "" || country == "XX" || country == "T1" {
+		return false
+	}
+	return !s.home[country]
+}
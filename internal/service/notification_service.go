@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_notification/entity"
+	"github.com/atam/atamlink/internal/mod_notification/repository"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// Notification event yang dikirim ke owner business, isi sesuai Event
+type Notification struct {
+	BusinessID   int64
+	Event        string
+	RequestID    string // request asal, diteruskan ke provider untuk korelasi log
+	Order        *OrderNotification
+	Testimonial  *TestimonialNotification
+	Subscription *SubscriptionNotification
+	Comment      *CommentNotification
+	Catalog      *CatalogNotification
+	Review       *ReviewNotification
+	Draft        *DraftNotification
+	Discount     *DiscountNotification
+	Inquiry      *InquiryNotification
+}
+
+// OrderNotification data pesanan baru
+type OrderNotification struct {
+	OrderRef  string
+	ItemTitle string
+	Quantity  int
+	Amount    int64
+	Currency  string
+}
+
+// TestimonialNotification data testimoni baru
+type TestimonialNotification struct {
+	CatalogTitle string
+	Name         string
+	Message      string
+}
+
+// SubscriptionNotification data subscription yang akan berakhir
+type SubscriptionNotification struct {
+	PlanName  string
+	ExpiresAt time.Time
+}
+
+// CommentNotification data mention pada komentar internal
+type CommentNotification struct {
+	CatalogTitle   string
+	AuthorName     string
+	MentionedNames []string
+	Body           string
+}
+
+// CatalogNotification data katalog yang dipublish sesuai jadwal
+type CatalogNotification struct {
+	Title       string
+	PublicURL   string
+	PublishedAt time.Time
+}
+
+// ReviewNotification data ulasan baru yang menunggu moderasi
+type ReviewNotification struct {
+	CatalogTitle string
+	Name         string
+	Rating       int
+	Body         string
+}
+
+// DraftNotification data katalog draft yang belum pernah dipublish
+type DraftNotification struct {
+	CatalogTitle string
+	CreatedAt    time.Time
+	ResumeURL    string
+}
+
+// DiscountNotification data diskon card yang akan berakhir karena jadwal harga
+type DiscountNotification struct {
+	CatalogTitle string
+	CardTitle    string
+	Discount     int
+	NewDiscount  int
+	EndsAt       time.Time
+}
+
+// InquiryNotification data pesan inquiry baru dari pengunjung katalog
+type InquiryNotification struct {
+	CatalogTitle string
+	CardTitle    string // kosong jika inquiry untuk katalog
+	Name         string
+	Email        string
+	Phone        string
+	Message      string
+}
+
+// NotificationSender pengirim notifikasi untuk satu channel
+type NotificationSender interface {
+	Channel() string
+	Supports(event string) bool
+	Send(ctx context.Context, channel *entity.NotificationChannel, credentials map[string]string, notification *Notification) (string, error)
+}
+
+// NotificationService service untuk kirim notifikasi ke owner business
+type NotificationService interface {
+	Start()
+	Stop()
+	Notify(notification *Notification)
+}
+
+type notificationService struct {
+	repo    repository.NotificationRepository
+	vault   VaultService
+	senders map[string]NotificationSender
+	log     logger.Logger
+	queue   chan *Notification
+	wg      sync.WaitGroup
+	stop    chan bool
+}
+
+// NewNotificationService membuat instance notification service baru
+func NewNotificationService(
+	repo repository.NotificationRepository,
+	vault VaultService,
+	log logger.Logger,
+	senders ...NotificationSender,
+) NotificationService {
+	s := &notificationService{
+		repo:    repo,
+		vault:   vault,
+		senders: make(map[string]NotificationSender),
+		log:     log,
+		queue:   make(chan *Notification, 500),
+		stop:    make(chan bool),
+	}
+	for _, sender := range senders {
+		s.senders[sender.Channel()] = sender
+	}
+	return s
+}
+
+// Start memulai notification worker
+func (s *notificationService) Start() {
+	s.wg.Add(1)
+	go s.worker()
+}
+
+// Stop menghentikan worker setelah queue habis
+func (s *notificationService) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// Notify antrikan notifikasi (non-blocking)
+func (s *notificationService) Notify(notification *Notification) {
+	select {
+	case s.queue <- notification:
+	default:
+		s.log.Error("Notification queue full, dropping notification",
+			logger.Int64("business_id", notification.BusinessID),
+			logger.String("event", notification.Event),
+		)
+	}
+}
+
+func (s *notificationService) worker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case notification := <-s.queue:
+			s.dispatch(notification)
+		case <-s.stop:
+			for {
+				select {
+				case notification := <-s.queue:
+					s.dispatch(notification)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// dispatch kirim ke semua channel yang di-opt-in business
+func (s *notificationService) dispatch(notification *Notification) {
+	channels, err := s.repo.ListChannels(notification.BusinessID)
+	if err != nil {
+		s.log.Error("Failed to load notification channels",
+			logger.Int64("business_id", notification.BusinessID),
+			logger.String("request_id", notification.RequestID),
+			logger.Error(err),
+		)
+		return
+	}
+
+	ctx := WithRequestID(context.Background(), notification.RequestID)
+	for _, channel := range channels {
+		if !channel.IsEnabled || channel.Recipient == "" {
+			continue
+		}
+
+		sender, ok := s.senders[channel.Channel]
+		if !ok || !sender.Supports(notification.Event) {
+			continue
+		}
+
+		notifLog := &entity.NotificationLog{
+			ChannelID: channel.ID,
+			Event:     notification.Event,
+			Status:    constant.NotificationStatusSent,
+			CreatedAt: time.Now(),
+		}
+
+		messageID, err := s.send(ctx, sender, channel, notification)
+		if err != nil {
+			notifLog.Status = constant.NotificationStatusFailed
+			notifLog.Error = sql.NullString{String: err.Error(), Valid: true}
+			s.log.Warn("Failed to send notification",
+				logger.String("channel", channel.Channel),
+				logger.String("event", notification.Event),
+				logger.Int64("business_id", notification.BusinessID),
+				logger.String("request_id", notification.RequestID),
+				logger.Error(err),
+			)
+		} else {
+			notifLog.ProviderMessageID = sql.NullString{String: messageID, Valid: messageID != ""}
+		}
+
+		if err := s.repo.CreateLog(notifLog); err != nil {
+			s.log.Error("Failed to save notification log", logger.Error(err))
+		}
+	}
+}
+
+func (s *notificationService) send(ctx context.Context, sender NotificationSender, channel *entity.NotificationChannel, notification *Notification) (string, error) {
+	// Channel tanpa kredensial per business (misal Telegram pakai bot global)
+	credentials := map[string]string{}
+	if channel.Credentials != "" {
+		opened, err := s.vault.Open(channel.Credentials)
+		if err != nil {
+			return "", err
+		}
+		credentials = opened
+	}
+	return sender.Send(ctx, channel, credentials, notification)
+}
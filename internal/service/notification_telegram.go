@@ -0,0 +1,233 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_notification/entity"
+)
+
+// TelegramSender pengirim notifikasi via Telegram Bot API
+type TelegramSender interface {
+	NotificationSender
+	IsConfigured() bool
+	SendText(ctx context.Context, chatID, text string) (string, error)
+	DeepLink(token string) string
+	VerifyWebhook(secret string) bool
+}
+
+type telegramSender struct {
+	config config.TelegramConfig
+	client *http.Client
+}
+
+// NewTelegramSender membuat sender Telegram Bot API
+func NewTelegramSender(cfg config.TelegramConfig, client *http.Client) TelegramSender {
+	return &telegramSender{config: cfg, client: client}
+}
+
+// Channel nama channel
+func (s *telegramSender) Channel() string {
+	return constant.NotificationChannelTelegram
+}
+
+// Supports Telegram menerima semua event
+func (s *telegramSender) Supports(event string) bool {
+	switch event {
+	case constant.NotificationEventNewOrder,
+		constant.NotificationEventNewTestimonial,
+		constant.NotificationEventSubscriptionExpiring,
+		constant.NotificationEventCommentMention,
+		constant.NotificationEventCatalogPublished,
+		constant.NotificationEventNewReview,
+		constant.NotificationEventDraftReminder,
+		constant.NotificationEventDiscountEnding,
+		constant.NotificationEventNewInquiry:
+		return true
+	}
+	return false
+}
+
+// Send kirim notifikasi ke chat owner
+func (s *telegramSender) Send(ctx context.Context, channel *entity.NotificationChannel, credentials map[string]string, notification *Notification) (string, error) {
+	text, err := telegramMessage(notification)
+	if err != nil {
+		return "", err
+	}
+	return s.SendText(ctx, channel.Recipient, text)
+}
+
+type telegramSendResponse struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+	Result      struct {
+		MessageID int64 `json:"message_id"`
+	} `json:"result"`
+}
+
+// SendText kirim pesan HTML ke chat
+func (s *telegramSender) SendText(ctx context.Context, chatID, text string) (string, error) {
+	if s.config.BotToken == "" {
+		return "", fmt.Errorf("telegram: bot token not configured")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id":    chatID,
+		"text":       text,
+		"parse_mode": "HTML",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", s.config.BaseURL, s.config.BotToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp telegramSendResponse
+	if err := doJSONRequest(s.client, req, &resp); err != nil {
+		return "", err
+	}
+	if !resp.OK {
+		return "", fmt.Errorf("telegram: %s", resp.Description)
+	}
+
+	return strconv.FormatInt(resp.Result.MessageID, 10), nil
+}
+
+// IsConfigured check apakah bot token dan username sudah diset
+func (s *telegramSender) IsConfigured() bool {
+	return s.config.BotToken != "" && s.config.BotUsername != ""
+}
+
+// DeepLink URL t.me untuk menghubungkan chat dengan token
+func (s *telegramSender) DeepLink(token string) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s", s.config.BotUsername, token)
+}
+
+// VerifyWebhook validasi secret token webhook
+func (s *telegramSender) VerifyWebhook(secret string) bool {
+	if s.config.WebhookSecret == "" || secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(s.config.WebhookSecret)) == 1
+}
+
+// telegramMessage susun teks pesan per event
+func telegramMessage(n *Notification) (string, error) {
+	switch n.Event {
+	case constant.NotificationEventNewOrder:
+		if n.Order == nil {
+			return "", fmt.Errorf("telegram: order payload is required")
+		}
+		return fmt.Sprintf("<b>Pesanan baru</b>\n%s x%d\nTotal: %s\nRef: <code>%s</code>",
+			html.EscapeString(n.Order.ItemTitle),
+			n.Order.Quantity,
+			formatAmount(n.Order.Amount, n.Order.Currency),
+			html.EscapeString(n.Order.OrderRef),
+		), nil
+
+	case constant.NotificationEventNewTestimonial:
+		if n.Testimonial == nil {
+			return "", fmt.Errorf("telegram: testimonial payload is required")
+		}
+		return fmt.Sprintf("<b>Testimoni baru</b> di %s\n%s: \"%s\"",
+			html.EscapeString(n.Testimonial.CatalogTitle),
+			html.EscapeString(n.Testimonial.Name),
+			html.EscapeString(n.Testimonial.Message),
+		), nil
+
+	case constant.NotificationEventSubscriptionExpiring:
+		if n.Subscription == nil {
+			return "", fmt.Errorf("telegram: subscription payload is required")
+		}
+		return fmt.Sprintf("<b>Langganan akan berakhir</b>\nPaket %s berakhir pada %s. Perpanjang agar katalog tetap aktif.",
+			html.EscapeString(n.Subscription.PlanName),
+			n.Subscription.ExpiresAt.Format("02 Jan 2006"),
+		), nil
+
+	case constant.NotificationEventCommentMention:
+		if n.Comment == nil {
+			return "", fmt.Errorf("telegram: comment payload is required")
+		}
+		return fmt.Sprintf("<b>Mention di %s</b>\n%s menyebut %s:\n\"%s\"",
+			html.EscapeString(n.Comment.CatalogTitle),
+			html.EscapeString(n.Comment.AuthorName),
+			html.EscapeString(strings.Join(n.Comment.MentionedNames, ", ")),
+			html.EscapeString(n.Comment.Body),
+		), nil
+
+	case constant.NotificationEventCatalogPublished:
+		if n.Catalog == nil {
+			return "", fmt.Errorf("telegram: catalog payload is required")
+		}
+		return fmt.Sprintf("<b>Katalog dipublish</b>\n%s sudah tayang sesuai jadwal (%s).\n%s",
+			html.EscapeString(n.Catalog.Title),
+			n.Catalog.PublishedAt.Format("02 Jan 2006 15:04"),
+			html.EscapeString(n.Catalog.PublicURL),
+		), nil
+
+	case constant.NotificationEventNewReview:
+		if n.Review == nil {
+			return "", fmt.Errorf("telegram: review payload is required")
+		}
+		return fmt.Sprintf("<b>Ulasan baru</b> di %s menunggu moderasi\n%s %s: \"%s\"",
+			html.EscapeString(n.Review.CatalogTitle),
+			strings.Repeat("★", n.Review.Rating),
+			html.EscapeString(n.Review.Name),
+			html.EscapeString(n.Review.Body),
+		), nil
+
+	case constant.NotificationEventDraftReminder:
+		if n.Draft == nil {
+			return "", fmt.Errorf("telegram: draft payload is required")
+		}
+		return fmt.Sprintf("<b>Katalog belum dipublish</b>\n%s masih berupa draft sejak %s. Lanjutkan dan publish agar bisa dilihat pelanggan:\n%s",
+			html.EscapeString(n.Draft.CatalogTitle),
+			n.Draft.CreatedAt.Format("02 Jan 2006"),
+			html.EscapeString(n.Draft.ResumeURL),
+		), nil
+
+	case constant.NotificationEventDiscountEnding:
+		if n.Discount == nil {
+			return "", fmt.Errorf("telegram: discount payload is required")
+		}
+		return fmt.Sprintf("<b>Diskon segera berakhir</b>\nDiskon %d%% untuk %s di %s berubah menjadi %d%% pada %s",
+			n.Discount.Discount,
+			html.EscapeString(n.Discount.CardTitle),
+			html.EscapeString(n.Discount.CatalogTitle),
+			n.Discount.NewDiscount,
+			n.Discount.EndsAt.Format("02 Jan 2006 15:04"),
+		), nil
+
+	case constant.NotificationEventNewInquiry:
+		if n.Inquiry == nil {
+			return "", fmt.Errorf("telegram: inquiry payload is required")
+		}
+		subject := n.Inquiry.CatalogTitle
+		if n.Inquiry.CardTitle != "" {
+			subject = n.Inquiry.CardTitle + " (" + n.Inquiry.CatalogTitle + ")"
+		}
+		contact := strings.TrimSpace(n.Inquiry.Email + " " + n.Inquiry.Phone)
+		return fmt.Sprintf("<b>Pesan baru</b> tentang %s\nDari: %s (%s)\n\"%s\"",
+			html.EscapeString(subject),
+			html.EscapeString(n.Inquiry.Name),
+			html.EscapeString(contact),
+			html.EscapeString(n.Inquiry.Message),
+		), nil
+	}
+
+	return "", fmt.Errorf("telegram: unsupported event %s", n.Event)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_notification/entity"
+)
+
+type whatsAppSender struct {
+	config config.WhatsAppConfig
+	client *http.Client
+}
+
+// NewWhatsAppSender membuat sender WhatsApp Business Cloud API
+func NewWhatsAppSender(cfg config.WhatsAppConfig, client *http.Client) NotificationSender {
+	return &whatsAppSender{config: cfg, client: client}
+}
+
+// Channel nama channel
+func (s *whatsAppSender) Channel() string {
+	return constant.NotificationChannelWhatsApp
+}
+
+type whatsAppParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type whatsAppMessageResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+}
+
+// Supports WhatsApp hanya untuk pesanan baru (template message yang disetujui)
+func (s *whatsAppSender) Supports(event string) bool {
+	return event == constant.NotificationEventNewOrder
+}
+
+// Send kirim template message pesanan baru ke nomor owner
+func (s *whatsAppSender) Send(ctx context.Context, channel *entity.NotificationChannel, credentials map[string]string, notification *Notification) (string, error) {
+	order := notification.Order
+	if order == nil {
+		return "", fmt.Errorf("whatsapp: order payload is required")
+	}
+
+	phoneNumberID := credentials["phone_number_id"]
+	accessToken := credentials["access_token"]
+	if phoneNumberID == "" || accessToken == "" {
+		return "", fmt.Errorf("whatsapp: credentials incomplete")
+	}
+
+	language := channel.GetConfigString("language")
+	if language == "" {
+		language = "id"
+	}
+
+	// Urutan parameter template: {{1}} order ref, {{2}} item, {{3}} qty, {{4}} total
+	params := []whatsAppParameter{
+		{Type: "text", Text: order.OrderRef},
+		{Type: "text", Text: order.ItemTitle},
+		{Type: "text", Text: strconv.Itoa(order.Quantity)},
+		{Type: "text", Text: formatAmount(order.Amount, order.Currency)},
+	}
+
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"to":                channel.Recipient,
+		"type":              "template",
+		"template": map[string]interface{}{
+			"name":     channel.GetConfigString("template_name"),
+			"language": map[string]string{"code": language},
+			"components": []map[string]interface{}{
+				{"type": "body", "parameters": params},
+			},
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/messages", s.config.BaseURL, s.config.APIVersion, phoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	var resp whatsAppMessageResponse
+	if err := doJSONRequest(s.client, req, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Messages) == 0 {
+		return "", fmt.Errorf("whatsapp: empty message id")
+	}
+
+	return resp.Messages[0].ID, nil
+}
+
+// VerifyWhatsAppSignature validasi header X-Hub-Signature-256 dari webhook
+func VerifyWhatsAppSignature(appSecret string, body []byte, signature string) bool {
+	if appSecret == "" || !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(signature, "sha256=")))
+}
+
+// formatAmount format nominal ke string, misal "IDR 150.000"
+func formatAmount(amount int64, currency string) string {
+	digits := strconv.FormatInt(amount, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	return currency + " " + b.String()
+}
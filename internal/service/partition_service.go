@@ -0,0 +1,198 @@
+package service
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// PartitionService service maintenance partisi bulanan tabel audit dan analytics
+type PartitionService interface {
+	Maintain() error
+}
+
+// partitionedTable tabel yang dipartisi per bulan (lihat migration 017 dan 043)
+type partitionedTable struct {
+	name      string
+	column    string
+	retention int // bulan, 0 = simpan selamanya
+}
+
+type partitionService struct {
+	db      *sql.DB
+	tables  []partitionedTable
+	premake int
+	log     logger.Logger
+}
+
+// NewPartitionService membuat instance partition service baru
+func NewPartitionService(db *sql.DB, cfg config.PartitionConfig, log logger.Logger) PartitionService {
+	return &partitionService{
+		db: db,
+		tables: []partitionedTable{
+			{name: "audit_logs", column: "al_timestamp", retention: cfg.AuditRetentionMonths},
+			{name: "catalog_daily_stats", column: "cds_date", retention: cfg.AnalyticsRetentionMonths},
+			{name: "catalog_click_stats", column: "ccs_date", retention: cfg.AnalyticsRetentionMonths},
+			{name: "catalog_daily_conversions", column: "cdc_date", retention: cfg.AnalyticsRetentionMonths},
+			{name: "catalog_daily_referrers", column: "cdr_date", retention: cfg.AnalyticsRetentionMonths},
+			{name: "catalog_daily_link_clicks", column: "cdlc_date", retention: cfg.AnalyticsRetentionMonths},
+		},
+		premake: cfg.PremakeMonths,
+		log:     log,
+	}
+}
+
+// Maintain buat partisi bulan berjalan sampai premake bulan ke depan,
+// lalu hapus partisi yang lebih tua dari retensi tabel
+func (s *partitionService) Maintain() error {
+	now := time.Now()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	for _, table := range s.tables {
+		existing, err := s.listPartitions(table.name)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i <= s.premake; i++ {
+			month := current.AddDate(0, i, 0)
+			if existing[partitionName(table.name, month)] {
+				continue
+			}
+			if err := s.createPartition(table, month); err != nil {
+				return err
+			}
+		}
+
+		if table.retention <= 0 {
+			continue
+		}
+
+		cutoff := current.AddDate(0, -table.retention, 0)
+		if err := s.dropExpired(table, existing, cutoff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listPartitions nama partisi yang sudah ada untuk tabel induk
+func (s *partitionService) listPartitions(table string) (map[string]bool, error) {
+	query := `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		JOIN pg_namespace n ON n.oid = p.relnamespace
+		WHERE n.nspname = 'atamlink' AND p.relname = $1`
+
+	rows, err := s.db.Query(query, table)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list partitions")
+	}
+	defer rows.Close()
+
+	partitions := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "failed to scan partition")
+		}
+		partitions[name] = true
+	}
+
+	return partitions, rows.Err()
+}
+
+// createPartition buat partisi satu bulan. Baris bulan tersebut yang sudah
+// terlanjur masuk partisi default dipindahkan dulu agar ATTACH tidak gagal.
+func (s *partitionService) createPartition(table partitionedTable, month time.Time) error {
+	name := partitionName(table.name, month)
+	parent := qualifiedTable(table.name)
+	partition := qualifiedTable(name)
+	defaultPartition := qualifiedTable(table.name + "_default")
+	column := pq.QuoteIdentifier(table.column)
+	from := pq.QuoteLiteral(month.Format("2006-01-02"))
+	to := pq.QuoteLiteral(month.AddDate(0, 1, 0).Format("2006-01-02"))
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS)", partition, parent),
+		fmt.Sprintf("INSERT INTO %s SELECT * FROM %s WHERE %s >= %s AND %s < %s", partition, defaultPartition, column, from, column, to),
+		fmt.Sprintf("DELETE FROM %s WHERE %s >= %s AND %s < %s", defaultPartition, column, from, column, to),
+		fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM (%s) TO (%s)", parent, partition, from, to),
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return errors.Wrap(err, "failed to create partition "+name)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	s.log.Info("Partition created",
+		logger.String("table", table.name),
+		logger.String("partition", name),
+	)
+
+	return nil
+}
+
+// dropExpired hapus partisi bulan sebelum cutoff dan baris lama di partisi default
+func (s *partitionService) dropExpired(table partitionedTable, existing map[string]bool, cutoff time.Time) error {
+	prefix := table.name + "_p"
+
+	for name := range existing {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		month, err := time.ParseInLocation("200601", strings.TrimPrefix(name, prefix), time.Local)
+		if err != nil || !month.Before(cutoff) {
+			continue
+		}
+
+		if _, err := s.db.Exec("DROP TABLE IF EXISTS " + qualifiedTable(name)); err != nil {
+			return errors.Wrap(err, "failed to drop partition "+name)
+		}
+
+		s.log.Info("Expired partition dropped",
+			logger.String("table", table.name),
+			logger.String("partition", name),
+		)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s < %s",
+		qualifiedTable(table.name+"_default"),
+		pq.QuoteIdentifier(table.column),
+		pq.QuoteLiteral(cutoff.Format("2006-01-02")),
+	)
+	if _, err := s.db.Exec(query); err != nil {
+		return errors.Wrap(err, "failed to purge default partition")
+	}
+
+	return nil
+}
+
+func partitionName(table string, month time.Time) string {
+	return table + "_p" + month.Format("200601")
+}
+
+func qualifiedTable(name string) string {
+	return "atamlink." + pq.QuoteIdentifier(name)
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// PaymentLinkRequest data untuk membuat payment link
+type PaymentLinkRequest struct {
+	ExternalID  string
+	Amount      int64
+	Currency    string
+	Description string
+}
+
+// PaymentLink hasil pembuatan payment link
+type PaymentLink struct {
+	GatewayID string
+	URL       string
+	ExpiresAt time.Time
+}
+
+// PaymentService service untuk payment gateway
+type PaymentService interface {
+	CreatePaymentLink(ctx context.Context, req *PaymentLinkRequest) (*PaymentLink, error)
+	VerifyCallback(token string) bool
+}
+
+type paymentService struct {
+	config config.PaymentConfig
+	client *http.Client
+}
+
+// NewPaymentService membuat instance payment service baru
+func NewPaymentService(cfg config.PaymentConfig) PaymentService {
+	return &paymentService{
+		config: cfg,
+		client: NewHTTPClient(cfg.HTTPTimeout),
+	}
+}
+
+type xenditInvoiceRequest struct {
+	ExternalID         string `json:"external_id"`
+	Amount             int64  `json:"amount"`
+	Currency           string `json:"currency"`
+	Description        string `json:"description"`
+	InvoiceDuration    int64  `json:"invoice_duration"`
+	SuccessRedirectURL string `json:"success_redirect_url,omitempty"`
+}
+
+type xenditInvoiceResponse struct {
+	ID         string    `json:"id"`
+	InvoiceURL string    `json:"invoice_url"`
+	ExpiryDate time.Time `json:"expiry_date"`
+}
+
+// CreatePaymentLink buat invoice Xendit yang bisa dibagikan, request ID di ctx
+// ikut dikirim supaya invoice bisa dicocokkan dengan request asalnya
+func (s *paymentService) CreatePaymentLink(ctx context.Context, req *PaymentLinkRequest) (*PaymentLink, error) {
+	if s.config.SecretKey == "" {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgPaymentUnavailable, 503)
+	}
+
+	body, err := json.Marshal(xenditInvoiceRequest{
+		ExternalID:         req.ExternalID,
+		Amount:             req.Amount,
+		Currency:           req.Currency,
+		Description:        req.Description,
+		InvoiceDuration:    int64(s.config.LinkExpiry.Seconds()),
+		SuccessRedirectURL: s.config.RedirectURL,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal invoice request")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.BaseURL+"/v2/invoices", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build invoice request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.SetBasicAuth(s.config.SecretKey, "")
+
+	var resp xenditInvoiceResponse
+	if err := doJSONRequest(s.client, httpReq, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to create payment link")
+	}
+
+	return &PaymentLink{
+		GatewayID: resp.ID,
+		URL:       resp.InvoiceURL,
+		ExpiresAt: resp.ExpiryDate,
+	}, nil
+}
+
+// VerifyCallback validasi callback token dari gateway
+func (s *paymentService) VerifyCallback(token string) bool {
+	if s.config.CallbackToken == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.config.CallbackToken)) == 1
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"github.com/atam/atamlink/internal/constant"
+	businessEntity "github.com/atam/atamlink/internal/mod_business/entity"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// PermissionLoader sumber permission set member business untuk satu request.
+// Middleware memasang implementasi yang menyimpan hasil lookup selama request
+// dan memeriksa service account serta IP allowlist business
+type PermissionLoader interface {
+	LoadPermissions(businessID, profileID int64) (constant.PermissionSet, error)
+	// LoadMemberPermissions sama dengan LoadPermissions tanpa pengecekan IP allowlist
+	LoadMemberPermissions(businessID, profileID int64) (constant.PermissionSet, error)
+}
+
+// MemberLookup sumber data member untuk load permission tanpa PermissionLoader
+type MemberLookup interface {
+	GetUserByBusinessAndProfile(ctx context.Context, businessID, profileID int64) (*businessEntity.BusinessUser, error)
+}
+
+type permissionLoaderKey struct{}
+
+// WithPermissionLoader pasang loader permission request di context
+func WithPermissionLoader(ctx context.Context, loader PermissionLoader) context.Context {
+	return context.WithValue(ctx, permissionLoaderKey{}, loader)
+}
+
+// PermissionLoaderFromContext loader permission request, false untuk job background
+func PermissionLoaderFromContext(ctx context.Context) (PermissionLoader, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	loader, ok := ctx.Value(permissionLoaderKey{}).(PermissionLoader)
+	return loader, ok
+}
+
+// CheckBusinessAccess pastikan profile member aktif business dengan permission
+// tertentu. Bukan member = ErrNotMember, tanpa permission = ErrForbidden (403)
+func CheckBusinessAccess(ctx context.Context, repo MemberLookup, businessID, profileID int64, permission string) error {
+	perms, err := LoadPermissions(ctx, repo, businessID, profileID)
+	if err != nil {
+		return err
+	}
+	return requirePermission(perms, permission)
+}
+
+// CheckBusinessAccessWithoutAllowlist sama dengan CheckBusinessAccess tanpa IP
+// allowlist, hanya untuk break-glass owner yang terkunci di luar allowlist
+func CheckBusinessAccessWithoutAllowlist(ctx context.Context, repo MemberLookup, businessID, profileID int64, permission string) error {
+	var perms constant.PermissionSet
+	var err error
+	if loader, ok := PermissionLoaderFromContext(ctx); ok {
+		perms, err = loader.LoadMemberPermissions(businessID, profileID)
+	} else {
+		perms, err = loadMemberPermissions(ctx, repo, businessID, profileID)
+	}
+	if err != nil {
+		return err
+	}
+	return requirePermission(perms, permission)
+}
+
+// LoadPermissions permission set profile di business, return nil jika bukan member
+// aktif. Tanpa loader di ctx (job background) permission di-load langsung dari repo
+// tanpa cache dan tanpa pengecekan IP allowlist
+func LoadPermissions(ctx context.Context, repo MemberLookup, businessID, profileID int64) (constant.PermissionSet, error) {
+	if loader, ok := PermissionLoaderFromContext(ctx); ok {
+		return loader.LoadPermissions(businessID, profileID)
+	}
+	return loadMemberPermissions(ctx, repo, businessID, profileID)
+}
+
+// loadMemberPermissions permission set dari repo, tanpa loader request
+func loadMemberPermissions(ctx context.Context, repo MemberLookup, businessID, profileID int64) (constant.PermissionSet, error) {
+	// Role service account hanya diketahui loader request, tolak tanpa loader
+	if _, ok := ServiceAccountScope(ctx); ok {
+		return nil, nil
+	}
+
+	user, err := repo.GetUserByBusinessAndProfile(ctx, businessID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		return nil, nil
+	}
+
+	return constant.PermissionsForRole(user.Role), nil
+}
+
+func requirePermission(perms constant.PermissionSet, permission string) error {
+	if perms == nil {
+		return errors.New(errors.ErrNotMember, constant.ErrMsgBusinessAccessDenied, 403)
+	}
+	if !perms.Has(permission) {
+		return errors.New(errors.ErrForbidden, "Anda tidak memiliki izin untuk aksi ini", 403)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/atam/atamlink/internal/constant"
+	businessEntity "github.com/atam/atamlink/internal/mod_business/entity"
+	businessRepo "github.com/atam/atamlink/internal/mod_business/repository"
+	catalogRepo "github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// PlanEnforcementService cek batas features plan aktif business sebelum resource
+// baru dibuat. Business tanpa subscription aktif memakai batas plan Free.
+// Batas tercapai = 403, fitur tidak termasuk plan = 402
+type PlanEnforcementService interface {
+	CheckCatalogLimit(ctx context.Context, businessID int64) error
+	CheckProductLimit(ctx context.Context, businessID int64, adding int) error
+	CheckUserLimit(ctx context.Context, businessID int64) error
+	CheckFeature(ctx context.Context, businessID int64, feature string) error
+	CatalogQuota(ctx context.Context, businessID int64) (*PlanQuota, error)
+	ProductQuota(ctx context.Context, businessID int64) (*PlanQuota, error)
+}
+
+// PlanQuota pemakaian dan batas satu resource plan
+type PlanQuota struct {
+	Plan  string
+	Limit int // 0 = tanpa batas
+	Used  int
+
+	message string
+}
+
+// Remaining sisa kuota, -1 = tanpa batas
+func (q *PlanQuota) Remaining() int {
+	if q.Limit == 0 {
+		return -1
+	}
+	if q.Used >= q.Limit {
+		return 0
+	}
+	return q.Limit - q.Used
+}
+
+// Check error 403 jika menambah sejumlah adding melewati batas
+func (q *PlanQuota) Check(adding int) error {
+	if q.Limit == 0 || q.Used+adding <= q.Limit {
+		return nil
+	}
+	return errors.New(errors.ErrPlanLimitExceeded, q.Message(), 403)
+}
+
+// Message pesan batas plan tercapai
+func (q *PlanQuota) Message() string {
+	return fmt.Sprintf(q.message, q.Plan, q.Limit)
+}
+
+type planEnforcementService struct {
+	businessRepo businessRepo.BusinessRepository
+	catalogRepo  catalogRepo.CatalogRepository
+	clock        Clock
+}
+
+// NewPlanEnforcementService membuat instance plan enforcement service baru
+func NewPlanEnforcementService(businessRepo businessRepo.BusinessRepository, catalogRepo catalogRepo.CatalogRepository, clock Clock) PlanEnforcementService {
+	return &planEnforcementService{
+		businessRepo: businessRepo,
+		catalogRepo:  catalogRepo,
+		clock:        clock,
+	}
+}
+
+// CheckCatalogLimit cek sebelum menambah satu katalog
+func (s *planEnforcementService) CheckCatalogLimit(ctx context.Context, businessID int64) error {
+	quota, err := s.CatalogQuota(ctx, businessID)
+	if err != nil {
+		return err
+	}
+	return quota.Check(1)
+}
+
+// CatalogQuota katalog di trash tidak dihitung
+func (s *planEnforcementService) CatalogQuota(ctx context.Context, businessID int64) (*PlanQuota, error) {
+	return s.quota(ctx, businessID, constant.PlanFeatureMaxCatalogs, constant.DefaultMaxCatalogs, constant.ErrMsgPlanCatalogLimit,
+		s.catalogRepo.CountByBusiness)
+}
+
+// CheckProductLimit cek sebelum menambah sejumlah adding card
+func (s *planEnforcementService) CheckProductLimit(ctx context.Context, businessID int64, adding int) error {
+	quota, err := s.ProductQuota(ctx, businessID)
+	if err != nil {
+		return err
+	}
+	return quota.Check(adding)
+}
+
+// ProductQuota semua card di katalog business yang tidak di trash dihitung sebagai produk
+func (s *planEnforcementService) ProductQuota(ctx context.Context, businessID int64) (*PlanQuota, error) {
+	return s.quota(ctx, businessID, constant.PlanFeatureMaxProducts, constant.DefaultMaxProducts, constant.ErrMsgPlanProductLimit,
+		s.catalogRepo.CountCardsByBusiness)
+}
+
+// CheckUserLimit owner ikut dihitung
+func (s *planEnforcementService) CheckUserLimit(ctx context.Context, businessID int64) error {
+	quota, err := s.quota(ctx, businessID, constant.PlanFeatureMaxUsers, constant.DefaultMaxUsers, constant.ErrMsgPlanUserLimit,
+		s.businessRepo.CountActiveUsers)
+	if err != nil {
+		return err
+	}
+	return quota.Check(1)
+}
+
+// CheckFeature feature boolean plan, misal api_access
+func (s *planEnforcementService) CheckFeature(ctx context.Context, businessID int64, feature string) error {
+	plan, err := s.activePlan(ctx, businessID)
+	if err != nil {
+		return err
+	}
+
+	if !plan.FeatureEnabled(feature) {
+		return errors.New(errors.ErrFeatureNotInPlan, fmt.Sprintf(constant.ErrMsgPlanFeatureMissing, feature, plan.Name), 402)
+	}
+	return nil
+}
+
+// activePlan plan dari subscription aktif, fallback plan Free tanpa features
+// sehingga semua batas memakai nilai default
+func (s *planEnforcementService) activePlan(ctx context.Context, businessID int64) (*businessEntity.MasterPlan, error) {
+	subscription, err := s.businessRepo.GetActiveSubscription(ctx, businessID, s.clock.NowFor(businessID))
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil || subscription.Plan == nil {
+		return &businessEntity.MasterPlan{Name: constant.DefaultPlanName}, nil
+	}
+	return subscription.Plan, nil
+}
+
+// quota pemakaian hanya dihitung jika plan punya batas
+func (s *planEnforcementService) quota(ctx context.Context, businessID int64, feature string, fallback int, message string, count func(ctx context.Context, businessID int64) (int, error)) (*PlanQuota, error) {
+	plan, err := s.activePlan(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	quota := &PlanQuota{
+		Plan:    plan.Name,
+		Limit:   plan.FeatureLimit(feature, fallback),
+		message: message,
+	}
+	if quota.Limit == 0 {
+		return quota, nil
+	}
+
+	quota.Used, err = count(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
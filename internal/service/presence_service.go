@@ -0,0 +1,94 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/atam/atamlink/pkg/redis"
+)
+
+// PresenceEntry editor yang sedang aktif di katalog
+type PresenceEntry struct {
+	ProfileID  int64
+	LastSeenAt time.Time
+}
+
+// PresenceService service untuk tracking editor yang sedang membuka katalog
+type PresenceService interface {
+	Enabled() bool
+	Heartbeat(catalogID, profileID int64) error
+	ListActive(catalogID int64) ([]*PresenceEntry, error)
+}
+
+type presenceService struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewPresenceService membuat presence service berbasis Redis sorted set,
+// client nil berarti presence dinonaktifkan
+func NewPresenceService(client *redis.Client, ttl time.Duration) PresenceService {
+	return &presenceService{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// Enabled check apakah Redis tersedia
+func (s *presenceService) Enabled() bool {
+	return s.client != nil
+}
+
+// Heartbeat catat editor masih aktif, score = waktu heartbeat terakhir (ms)
+func (s *presenceService) Heartbeat(catalogID, profileID int64) error {
+	key := presenceKey(catalogID)
+	now := time.Now().UnixMilli()
+
+	if _, err := s.client.Do("ZADD", key, strconv.FormatInt(now, 10), strconv.FormatInt(profileID, 10)); err != nil {
+		return err
+	}
+
+	// Key ikut kedaluwarsa jika semua editor pergi
+	_, err := s.client.Do("PEXPIRE", key, strconv.FormatInt(s.ttl.Milliseconds(), 10))
+	return err
+}
+
+// ListActive daftar editor yang heartbeat-nya masih dalam TTL
+func (s *presenceService) ListActive(catalogID int64) ([]*PresenceEntry, error) {
+	key := presenceKey(catalogID)
+	cutoff := time.Now().Add(-s.ttl).UnixMilli()
+
+	// Buang editor yang sudah lewat TTL
+	if _, err := s.client.Do("ZREMRANGEBYSCORE", key, "-inf", "("+strconv.FormatInt(cutoff, 10)); err != nil {
+		return nil, err
+	}
+
+	// Reply berupa pasangan member, score
+	values, err := s.client.Strings("ZRANGE", key, "0", "-1", "WITHSCORES")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*PresenceEntry, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		profileID, err := strconv.ParseInt(values[i], 10, 64)
+		if err != nil {
+			continue
+		}
+		score, err := strconv.ParseFloat(values[i+1], 64)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, &PresenceEntry{
+			ProfileID:  profileID,
+			LastSeenAt: time.UnixMilli(int64(score)),
+		})
+	}
+
+	return entries, nil
+}
+
+func presenceKey(catalogID int64) string {
+	return fmt.Sprintf("atamlink:presence:catalog:%d", catalogID)
+}
@@ -0,0 +1,21 @@
+package service
+
+import "context"
+
+type serviceAccountScopeKey struct{}
+
+// WithServiceAccountScope tandai request dari service account, akses request
+// dibatasi ke business pemilik service account
+func WithServiceAccountScope(ctx context.Context, businessID int64) context.Context {
+	return context.WithValue(ctx, serviceAccountScopeKey{}, businessID)
+}
+
+// ServiceAccountScope business pemilik service account yang mengautentikasi
+// request, false untuk request user dan job background
+func ServiceAccountScope(ctx context.Context) (int64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	businessID, ok := ctx.Value(serviceAccountScopeKey{}).(int64)
+	return businessID, ok
+}
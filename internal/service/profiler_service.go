@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// Tipe profile yang bisa di-capture on-demand
+const (
+	ProfileCPU  = "cpu"
+	ProfileHeap = "heap"
+)
+
+// profileNamePattern nama file hasil capture, dicek sebelum dibaca dari storage
+var profileNamePattern = regexp.MustCompile(`^(cpu|heap)-\d{8}T\d{6}\.pprof$`)
+
+// ProfilerService capture CPU/heap profile ke storage untuk dianalisis
+// belakangan dengan go tool pprof
+type ProfilerService interface {
+	Enabled() bool
+	Capture(kind string, duration time.Duration) (*ProfileCapture, error)
+	Get(name string) ([]byte, error)
+	Stop()
+}
+
+// ProfileCapture hasil permintaan capture profile
+type ProfileCapture struct {
+	Name    string    `json:"name"`
+	Type    string    `json:"type"`
+	Seconds int       `json:"seconds,omitempty"`
+	ReadyAt time.Time `json:"ready_at"` // CPU profile baru tersedia setelah durasi capture
+}
+
+type profilerService struct {
+	cfg     config.ProfilingConfig
+	storage BackupStorage
+	log     logger.Logger
+
+	cpuRunning atomic.Bool
+	wg         sync.WaitGroup
+	stop       chan struct{}
+}
+
+// NewProfilerService membuat instance profiler service baru, storage boleh nil
+// jika profiling tidak diaktifkan
+func NewProfilerService(cfg config.ProfilingConfig, storage BackupStorage, log logger.Logger) ProfilerService {
+	if cfg.CaptureDuration <= 0 {
+		cfg.CaptureDuration = 30 * time.Second
+	}
+	if cfg.MaxDuration < cfg.CaptureDuration {
+		cfg.MaxDuration = cfg.CaptureDuration
+	}
+	return &profilerService{
+		cfg:     cfg,
+		storage: storage,
+		log:     log,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Enabled check apakah profiling aktif
+func (s *profilerService) Enabled() bool {
+	return s.cfg.Enabled && s.storage != nil
+}
+
+// Capture mulai capture profile. Heap profile langsung ditulis, CPU profile
+// di-capture di background selama duration (0 = durasi default)
+func (s *profilerService) Capture(kind string, duration time.Duration) (*ProfileCapture, error) {
+	if !s.Enabled() {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgProfilingDisabled, 400)
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.pprof", kind, now.UTC().Format("20060102T150405"))
+
+	switch kind {
+	case ProfileHeap:
+		if err := s.captureHeap(name); err != nil {
+			return nil, err
+		}
+		return &ProfileCapture{Name: name, Type: kind, ReadyAt: now}, nil
+
+	case ProfileCPU:
+		if duration == 0 {
+			duration = s.cfg.CaptureDuration
+		}
+		if duration < time.Second || duration > s.cfg.MaxDuration {
+			return nil, errors.New(errors.ErrValidation, constant.ErrMsgProfilingDurationInvalid, 400)
+		}
+
+		// Runtime hanya mendukung satu CPU profile dalam satu waktu
+		if !s.cpuRunning.CompareAndSwap(false, true) {
+			return nil, errors.New(errors.ErrConflict, constant.ErrMsgProfilingRunning, 409)
+		}
+
+		var buf bytes.Buffer
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			s.cpuRunning.Store(false)
+			return nil, errors.New(errors.ErrConflict, constant.ErrMsgProfilingRunning, 409)
+		}
+
+		s.wg.Add(1)
+		go s.finishCPU(name, &buf, duration)
+
+		return &ProfileCapture{
+			Name:    name,
+			Type:    kind,
+			Seconds: int(duration.Seconds()),
+			ReadyAt: now.Add(duration),
+		}, nil
+	}
+
+	return nil, errors.New(errors.ErrValidation, constant.ErrMsgProfilingTypeInvalid, 400)
+}
+
+// Get baca hasil capture dari storage
+func (s *profilerService) Get(name string) ([]byte, error) {
+	if !s.Enabled() {
+		return nil, errors.New(errors.ErrValidation, constant.ErrMsgProfilingDisabled, 400)
+	}
+	if !profileNamePattern.MatchString(name) {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgProfilingNotFound, 404)
+	}
+
+	data, err := s.storage.Get(name)
+	if err != nil {
+		return nil, errors.New(errors.ErrNotFound, constant.ErrMsgProfilingNotFound, 404)
+	}
+	return data, nil
+}
+
+// Stop hentikan CPU profile yang sedang berjalan lebih awal dan tunggu hasilnya tersimpan
+func (s *profilerService) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *profilerService) captureHeap(name string) error {
+	// GC dulu supaya statistik heap mencerminkan objek yang masih hidup
+	runtime.GC()
+
+	var buf bytes.Buffer
+	if err := pprof.Lookup("heap").WriteTo(&buf, 0); err != nil {
+		return err
+	}
+	if err := s.storage.Put(name, buf.Bytes()); err != nil {
+		return err
+	}
+
+	s.log.Info("Heap profile captured", logger.String("name", name))
+	return nil
+}
+
+func (s *profilerService) finishCPU(name string, buf *bytes.Buffer, duration time.Duration) {
+	defer s.wg.Done()
+	defer s.cpuRunning.Store(false)
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-s.stop:
+	}
+	pprof.StopCPUProfile()
+
+	if err := s.storage.Put(name, buf.Bytes()); err != nil {
+		s.log.Error("Failed to store cpu profile",
+			logger.String("name", name),
+			logger.Error(err),
+		)
+		return
+	}
+
+	s.log.Info("CPU profile captured",
+		logger.String("name", name),
+		logger.Int("seconds", int(duration.Seconds())),
+	)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/pkg/redis"
+)
+
+// ProofOfWork challenge proof-of-work untuk endpoint tulis publik. Client
+// mencari nonce sehingga sha256(challenge + nonce) diawali Difficulty bit nol
+type ProofOfWork interface {
+	Enabled() bool
+	Issue(action string) (*PoWChallenge, error)
+	Verify(action, challenge, nonce string) bool
+}
+
+// PoWChallenge challenge yang dikirim ke client
+type PoWChallenge struct {
+	Challenge  string    `json:"challenge"`
+	Algorithm  string    `json:"algorithm"`  // sha256(challenge + nonce)
+	Difficulty int       `json:"difficulty"` // jumlah bit nol di awal hash
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Panjang maksimal nonce yang diterima
+const maxPoWNonceLength = 64
+
+type proofOfWork struct {
+	cfg    config.ProofOfWorkConfig
+	secret []byte
+	client *redis.Client
+
+	// Challenge terpakai jika Redis tidak tersedia
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+// NewProofOfWork membuat instance proof-of-work baru. Challenge yang sudah
+// dipakai dicatat di Redis, client nil berarti dicatat di memori proses
+func NewProofOfWork(cfg config.ProofOfWorkConfig, client *redis.Client) (ProofOfWork, error) {
+	secret := []byte(cfg.Secret)
+	if cfg.Enabled && len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate proof-of-work secret: %w", err)
+		}
+	}
+
+	return &proofOfWork{
+		cfg:    cfg,
+		secret: secret,
+		client: client,
+		used:   make(map[string]time.Time),
+	}, nil
+}
+
+// Enabled check apakah proof-of-work aktif
+func (p *proofOfWork) Enabled() bool {
+	return p.cfg.Enabled
+}
+
+// Issue terbitkan challenge untuk action, format: <unix timestamp>.<random>.<hmac>
+func (p *proofOfWork) Issue(action string) (*PoWChallenge, error) {
+	random := make([]byte, 12)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate proof-of-work challenge: %w", err)
+	}
+
+	now := time.Now()
+	payload := strconv.FormatInt(now.Unix(), 10) + "." + hex.EncodeToString(random)
+
+	return &PoWChallenge{
+		Challenge:  payload + "." + p.sign(action, payload),
+		Algorithm:  "sha256",
+		Difficulty: p.cfg.Difficulty,
+		ExpiresAt:  now.Add(p.cfg.TTL),
+	}, nil
+}
+
+// Verify challenge milik action, belum kedaluwarsa, nonce memenuhi difficulty
+// dan challenge belum pernah dipakai
+func (p *proofOfWork) Verify(action, challenge, nonce string) bool {
+	if nonce == "" || len(nonce) > maxPoWNonceLength {
+		return false
+	}
+
+	i := strings.LastIndexByte(challenge, '.')
+	if i < 0 {
+		return false
+	}
+	payload, signature := challenge[:i], challenge[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(p.sign(action, payload))) {
+		return false
+	}
+
+	issued, err := strconv.ParseInt(strings.SplitN(payload, ".", 2)[0], 10, 64)
+	if err != nil || time.Since(time.Unix(issued, 0)) > p.cfg.TTL {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(challenge + nonce))
+	if leadingZeroBits(sum[:]) < p.cfg.Difficulty {
+		return false
+	}
+
+	return p.markUsed(challenge)
+}
+
+func (p *proofOfWork) sign(action, payload string) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(action))
+	mac.Write([]byte{0})
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// markUsed catat challenge terpakai, false jika sudah pernah dipakai
+func (p *proofOfWork) markUsed(challenge string) bool {
+	if p.client != nil {
+		reply, err := p.client.Do("SET", "pow:"+challenge, "1", "NX", "PX", strconv.FormatInt(p.cfg.TTL.Milliseconds(), 10))
+		if err == nil {
+			return reply != nil
+		}
+		// Redis bermasalah, lanjut ke pencatatan di memori
+	}
+
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, expiresAt := range p.used {
+		if now.After(expiresAt) {
+			delete(p.used, key)
+		}
+	}
+
+	if _, ok := p.used[challenge]; ok {
+		return false
+	}
+	p.used[challenge] = now.Add(p.cfg.TTL)
+	return true
+}
+
+// leadingZeroBits jumlah bit nol di awal hash
+func leadingZeroBits(sum []byte) int {
+	count := 0
+	for _, b := range sum {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
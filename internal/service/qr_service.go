@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// QRService generate QR code URL publik katalog dan upload ke storage
+type QRService interface {
+	GenerateCatalogQR(ctx context.Context, catalogID int64, targetURL string) (string, error)
+}
+
+type qrService struct {
+	uploadService UploadService
+	cfg           config.QRConfig
+	log           logger.Logger
+}
+
+// NewQRService membuat instance QR service baru
+func NewQRService(uploadService UploadService, cfg config.QRConfig, log logger.Logger) QRService {
+	if cfg.Format != constant.QRFormatSVG {
+		cfg.Format = constant.QRFormatPNG
+	}
+	if cfg.Size <= 0 {
+		cfg.Size = 512
+	}
+	return &qrService{
+		uploadService: uploadService,
+		cfg:           cfg,
+		log:           log,
+	}
+}
+
+// GenerateCatalogQR generate QR berisi targetURL lalu upload, mengembalikan URL file.
+// Public ID per katalog sehingga generate ulang menimpa file lama
+func (s *qrService) GenerateCatalogQR(ctx context.Context, catalogID int64, targetURL string) (string, error) {
+	qr, err := qrcode.New(targetURL, qrcode.Medium)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode qr code")
+	}
+
+	var data []byte
+	if s.cfg.Format == constant.QRFormatSVG {
+		data = renderQRSVG(qr.Bitmap())
+	} else {
+		data, err = qr.PNG(s.cfg.Size)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to render qr code")
+		}
+	}
+
+	url, err := s.uploadService.UploadBytesToCloudinary(ctx, data, s.cfg.Folder, fmt.Sprintf("catalog-%d", catalogID), s.cfg.Format)
+	if err != nil {
+		s.log.Error("Failed to upload catalog qr code",
+			logger.Int64("catalog_id", catalogID),
+			logger.Error(err),
+		)
+		return "", err
+	}
+
+	return url, nil
+}
+
+// renderQRSVG render bitmap QR (sudah termasuk quiet zone) sebagai SVG,
+// satu path untuk semua modul gelap
+func renderQRSVG(bitmap [][]bool) []byte {
+	size := len(bitmap)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, size, size)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&sb, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	sb.WriteString(`"/></svg>`)
+
+	return []byte(sb.String())
+}
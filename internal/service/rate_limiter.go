@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/atam/atamlink/pkg/redis"
+)
+
+// RateLimiter pembatas jumlah request API per principal dalam window tetap
+type RateLimiter interface {
+	Enabled() bool
+	// Allow catat satu request untuk key, false jika sudah melewati limit di window berjalan
+	Allow(key string, limit int) (allowed bool, remaining int, err error)
+}
+
+type rateLimiter struct {
+	client *redis.Client
+	window time.Duration
+}
+
+// NewRateLimiter membuat rate limiter berbasis counter Redis,
+// client nil berarti rate limit dinonaktifkan
+func NewRateLimiter(client *redis.Client, window time.Duration) RateLimiter {
+	return &rateLimiter{
+		client: client,
+		window: window,
+	}
+}
+
+// Enabled check apakah Redis tersedia
+func (l *rateLimiter) Enabled() bool {
+	return l.client != nil
+}
+
+// Allow increment counter window berjalan, key kedaluwarsa bersama window-nya
+func (l *rateLimiter) Allow(key string, limit int) (bool, int, error) {
+	window := time.Now().UnixNano() / l.window.Nanoseconds()
+	counterKey := fmt.Sprintf("ratelimit:%s:%d", key, window)
+
+	count, err := l.client.Int("INCR", counterKey)
+	if err != nil {
+		return true, limit, err
+	}
+	if count == 1 {
+		if _, err := l.client.Do("PEXPIRE", counterKey, strconv.FormatInt(l.window.Milliseconds(), 10)); err != nil {
+			return true, limit, err
+		}
+	}
+
+	remaining := limit - int(count)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return int(count) <= limit, remaining, nil
+}
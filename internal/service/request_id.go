@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HeaderRequestID header korelasi request, diteruskan ke panggilan keluar
+// (upload provider, payment gateway, notifikasi) supaya log antar sistem bisa dicocokkan
+const HeaderRequestID = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID simpan request ID di context untuk panggilan keluar
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext request ID dari context, kosong jika tidak ada (misal job background)
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// requestIDTransport set header X-Request-ID dari context request keluar
+type requestIDTransport struct {
+	base http.RoundTripper
+}
+
+// NewRequestIDTransport bungkus transport supaya request ID di context ikut terkirim
+func NewRequestIDTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &requestIDTransport{base: base}
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := RequestIDFromContext(req.Context())
+	if requestID == "" || req.Header.Get(HeaderRequestID) != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTripper tidak boleh mengubah request asli
+	clone := req.Clone(req.Context())
+	clone.Header.Set(HeaderRequestID, requestID)
+	return t.base.RoundTrip(clone)
+}
+
+// NewHTTPClient http client untuk provider eksternal yang meneruskan request ID
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: NewRequestIDTransport(nil),
+	}
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_catalog/entity"
+)
+
+type elasticsearchEngine struct {
+	config config.SearchConfig
+	client *http.Client
+}
+
+func newElasticsearchEngine(cfg config.SearchConfig, client *http.Client) SearchEngine {
+	return &elasticsearchEngine{config: cfg, client: client}
+}
+
+// Provider nama provider
+func (e *elasticsearchEngine) Provider() string {
+	return constant.SearchProviderElasticsearch
+}
+
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+}
+
+type elasticsearchSearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			ID string `json:"_id"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Upsert index ulang dokumen lewat bulk API
+func (e *elasticsearchEngine) Upsert(docs []*entity.SearchDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, doc := range docs {
+		action := map[string]interface{}{"index": map[string]interface{}{"_id": strconv.FormatInt(doc.ID, 10)}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	return e.bulk(&buf)
+}
+
+// Delete hapus dokumen lewat bulk API
+func (e *elasticsearchEngine) Delete(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, id := range ids {
+		action := map[string]interface{}{"delete": map[string]interface{}{"_id": strconv.FormatInt(id, 10)}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+	}
+
+	return e.bulk(&buf)
+}
+
+// Search cari katalog dengan filter business, kata terakhir dicocokkan sebagai prefix
+func (e *elasticsearchEngine) Search(query SearchQuery) ([]int64, error) {
+	if len(query.BusinessIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	body := map[string]interface{}{
+		"size":    query.Limit,
+		"_source": false,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":  query.Text,
+						"type":   "bool_prefix",
+						"fields": []string{"title^3", "subtitle^2", "slug", "content"},
+					},
+				},
+				"filter": map[string]interface{}{
+					"terms": map[string]interface{}{"business_id": query.BusinessIDs},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := e.newRequest(http.MethodPost, "/_search", bytes.NewReader(data), "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp elasticsearchSearchResponse
+	if err := doJSONRequest(e.client, req, &resp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		id, err := strconv.ParseInt(hit.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Reset hapus index, index dibuat ulang otomatis saat dokumen pertama masuk
+func (e *elasticsearchEngine) Reset() error {
+	req, err := e.newRequest(http.MethodDelete, "?ignore_unavailable=true", nil, "application/json")
+	if err != nil {
+		return err
+	}
+	return doJSONRequest(e.client, req, &map[string]interface{}{})
+}
+
+func (e *elasticsearchEngine) bulk(body *bytes.Buffer) error {
+	req, err := e.newRequest(http.MethodPost, "/_bulk", body, "application/x-ndjson")
+	if err != nil {
+		return err
+	}
+
+	var resp elasticsearchBulkResponse
+	if err := doJSONRequest(e.client, req, &resp); err != nil {
+		return err
+	}
+	if resp.Errors {
+		return fmt.Errorf("elasticsearch: bulk request has failed items")
+	}
+	return nil
+}
+
+func (e *elasticsearchEngine) newRequest(method, path string, body io.Reader, contentType string) (*http.Request, error) {
+	url := fmt.Sprintf("%s/%s%s", strings.TrimRight(e.config.URL, "/"), e.config.Index, path)
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.config.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+e.config.APIKey)
+	}
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
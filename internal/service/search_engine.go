@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_catalog/entity"
+)
+
+// SearchQuery pencarian katalog di search engine
+type SearchQuery struct {
+	Text        string
+	BusinessIDs []int64 // hanya katalog milik business ini
+	Limit       int
+}
+
+// SearchEngine search engine eksternal untuk katalog
+type SearchEngine interface {
+	Provider() string
+	Upsert(docs []*entity.SearchDocument) error
+	Delete(ids []int64) error
+	// Search ID katalog yang cocok, urut sesuai relevansi
+	Search(query SearchQuery) ([]int64, error)
+	// Reset kosongkan index sebelum reindex penuh
+	Reset() error
+}
+
+// NewSearchEngine membuat search engine sesuai provider, provider kosong berarti
+// pencarian memakai Postgres full-text search (nil)
+func NewSearchEngine(cfg config.SearchConfig) (SearchEngine, error) {
+	if cfg.Provider == "" {
+		return nil, nil
+	}
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("search url not configured for provider %q", cfg.Provider)
+	}
+
+	client := &http.Client{Timeout: cfg.HTTPTimeout}
+
+	switch cfg.Provider {
+	case constant.SearchProviderMeilisearch:
+		return newMeilisearchEngine(cfg, client), nil
+	case constant.SearchProviderElasticsearch:
+		return newElasticsearchEngine(cfg, client), nil
+	}
+
+	return nil, fmt.Errorf("unsupported search provider %q", cfg.Provider)
+}
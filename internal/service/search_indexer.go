@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/atam/atamlink/internal/mod_catalog/repository"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// reindexBatchSize jumlah katalog per batch saat reindex penuh
+const reindexBatchSize = 200
+
+// SearchEvent domain event perubahan katalog untuk search index
+type SearchEvent struct {
+	Type      string // constant.SearchEventCatalog*, untuk log
+	CatalogID int64
+}
+
+// SearchIndexer jaga dokumen search engine tetap sinkron dengan katalog
+type SearchIndexer interface {
+	Start()
+	Stop()
+	// Enabled false jika search engine tidak dikonfigurasi (pakai Postgres FTS)
+	Enabled() bool
+	Publish(event SearchEvent)
+	// Search ID katalog yang cocok, maksimal maxHits hasil
+	Search(text string, businessIDs []int64) ([]int64, error)
+	// StartReindex mulai reindex penuh di background, false jika reindex lain masih berjalan
+	StartReindex() bool
+}
+
+type searchIndexer struct {
+	engine     SearchEngine
+	repo       repository.CatalogRepository
+	maxHits    int
+	log        logger.Logger
+	queue      chan SearchEvent
+	batchSize  int
+	flushTime  time.Duration
+	reindexing int32
+	wg         sync.WaitGroup
+	stop       chan bool
+}
+
+// NewSearchIndexer membuat indexer, engine nil berarti indexing dinonaktifkan
+func NewSearchIndexer(engine SearchEngine, repo repository.CatalogRepository, maxHits int, log logger.Logger) SearchIndexer {
+	return &searchIndexer{
+		engine:    engine,
+		repo:      repo,
+		maxHits:   maxHits,
+		log:       log,
+		queue:     make(chan SearchEvent, 1000),
+		batchSize: 50,
+		flushTime: 2 * time.Second,
+		stop:      make(chan bool),
+	}
+}
+
+// Start memulai indexing worker
+func (s *searchIndexer) Start() {
+	s.wg.Add(1)
+	go s.worker()
+}
+
+// Stop menghentikan worker setelah queue dan reindex yang berjalan selesai
+func (s *searchIndexer) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// Enabled cek apakah search engine dikonfigurasi
+func (s *searchIndexer) Enabled() bool {
+	return s.engine != nil
+}
+
+// Publish antrikan event perubahan katalog (non-blocking)
+func (s *searchIndexer) Publish(event SearchEvent) {
+	if s.engine == nil || event.CatalogID == 0 {
+		return
+	}
+
+	select {
+	case s.queue <- event:
+	default:
+		s.log.Error("Search index queue full, dropping event",
+			logger.String("type", event.Type),
+			logger.Int64("catalog_id", event.CatalogID),
+		)
+	}
+}
+
+// Search cari ID katalog di search engine
+func (s *searchIndexer) Search(text string, businessIDs []int64) ([]int64, error) {
+	return s.engine.Search(SearchQuery{
+		Text:        text,
+		BusinessIDs: businessIDs,
+		Limit:       s.maxHits,
+	})
+}
+
+// StartReindex kosongkan index lalu isi ulang semua katalog di background
+func (s *searchIndexer) StartReindex() bool {
+	if !atomic.CompareAndSwapInt32(&s.reindexing, 0, 1) {
+		return false
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer atomic.StoreInt32(&s.reindexing, 0)
+
+		started := time.Now()
+		count, err := s.reindex(context.Background())
+		if err != nil {
+			s.log.Error("Search reindex failed",
+				logger.String("provider", s.engine.Provider()),
+				logger.Int("indexed", count),
+				logger.Error(err),
+			)
+			return
+		}
+
+		s.log.Info("Search reindex finished",
+			logger.String("provider", s.engine.Provider()),
+			logger.Int("indexed", count),
+			logger.Duration("duration", time.Since(started)),
+		)
+	}()
+
+	return true
+}
+
+func (s *searchIndexer) reindex(ctx context.Context) (int, error) {
+	if err := s.engine.Reset(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	var afterID int64
+	for {
+		ids, err := s.repo.ListIDsAfter(ctx, afterID, reindexBatchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(ids) == 0 {
+			return count, nil
+		}
+
+		docs, err := s.repo.GetSearchDocuments(ctx, ids)
+		if err != nil {
+			return count, err
+		}
+		if err := s.engine.Upsert(docs); err != nil {
+			return count, err
+		}
+
+		count += len(docs)
+		afterID = ids[len(ids)-1]
+	}
+}
+
+func (s *searchIndexer) worker() {
+	defer s.wg.Done()
+
+	// Worker tidak terikat request, flush yang berjalan diselesaikan saat Stop
+	ctx := context.Background()
+	batch := make([]SearchEvent, 0, s.batchSize)
+	ticker := time.NewTicker(s.flushTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-s.queue:
+			batch = append(batch, event)
+			if len(batch) >= s.batchSize {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+
+		case <-ticker.C:
+			if len(batch) > 0 {
+				s.flush(ctx, batch)
+				batch = batch[:0]
+			}
+
+		case <-s.stop:
+			for {
+				select {
+				case event := <-s.queue:
+					batch = append(batch, event)
+				default:
+					if len(batch) > 0 {
+						s.flush(ctx, batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush muat ulang dokumen katalog dalam batch, beberapa event untuk katalog
+// yang sama cukup diindex sekali
+func (s *searchIndexer) flush(ctx context.Context, batch []SearchEvent) {
+	seen := make(map[int64]bool, len(batch))
+	ids := make([]int64, 0, len(batch))
+	for _, event := range batch {
+		if !seen[event.CatalogID] {
+			seen[event.CatalogID] = true
+			ids = append(ids, event.CatalogID)
+		}
+	}
+
+	docs, err := s.repo.GetSearchDocuments(ctx, ids)
+	if err != nil {
+		s.log.Warn("Failed to load search documents", logger.Error(err))
+		return
+	}
+
+	if err := s.engine.Upsert(docs); err != nil {
+		s.log.Warn("Failed to update search index",
+			logger.String("provider", s.engine.Provider()),
+			logger.Int("documents", len(docs)),
+			logger.Error(err),
+		)
+	}
+
+	// Katalog yang sudah tidak ada ikut dihapus dari index
+	for _, doc := range docs {
+		delete(seen, doc.ID)
+	}
+	deleteIDs := make([]int64, 0, len(seen))
+	for catalogID := range seen {
+		deleteIDs = append(deleteIDs, catalogID)
+	}
+
+	if err := s.engine.Delete(deleteIDs); err != nil {
+		s.log.Warn("Failed to delete from search index",
+			logger.String("provider", s.engine.Provider()),
+			logger.Int("documents", len(deleteIDs)),
+			logger.Error(err),
+		)
+	}
+}
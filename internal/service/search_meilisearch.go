@@ -0,0 +1,116 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_catalog/entity"
+)
+
+type meilisearchEngine struct {
+	config config.SearchConfig
+	client *http.Client
+}
+
+func newMeilisearchEngine(cfg config.SearchConfig, client *http.Client) SearchEngine {
+	return &meilisearchEngine{config: cfg, client: client}
+}
+
+// Provider nama provider
+func (e *meilisearchEngine) Provider() string {
+	return constant.SearchProviderMeilisearch
+}
+
+type meilisearchSearchResponse struct {
+	Hits []struct {
+		ID int64 `json:"id"`
+	} `json:"hits"`
+}
+
+// Upsert tambah/ganti dokumen, Meilisearch memproses task secara async
+func (e *meilisearchEngine) Upsert(docs []*entity.SearchDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	return e.do(http.MethodPost, "/documents?primaryKey=id", docs, &map[string]interface{}{})
+}
+
+// Delete hapus dokumen berdasarkan ID katalog
+func (e *meilisearchEngine) Delete(ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return e.do(http.MethodPost, "/documents/delete-batch", ids, &map[string]interface{}{})
+}
+
+// Search cari katalog dengan filter business
+func (e *meilisearchEngine) Search(query SearchQuery) ([]int64, error) {
+	if len(query.BusinessIDs) == 0 {
+		return []int64{}, nil
+	}
+
+	businessIDs := make([]string, len(query.BusinessIDs))
+	for i, id := range query.BusinessIDs {
+		businessIDs[i] = fmt.Sprintf("%d", id)
+	}
+
+	body := map[string]interface{}{
+		"q":                    query.Text,
+		"filter":               fmt.Sprintf("business_id IN [%s]", strings.Join(businessIDs, ", ")),
+		"limit":                query.Limit,
+		"attributesToRetrieve": []string{"id"},
+	}
+
+	var resp meilisearchSearchResponse
+	if err := e.do(http.MethodPost, "/search", body, &resp); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(resp.Hits))
+	for i, hit := range resp.Hits {
+		ids[i] = hit.ID
+	}
+	return ids, nil
+}
+
+// Reset hapus semua dokumen dan pastikan atribut filter & pencarian terdaftar
+func (e *meilisearchEngine) Reset() error {
+	if err := e.do(http.MethodDelete, "/documents", nil, &map[string]interface{}{}); err != nil {
+		return err
+	}
+
+	settings := map[string]interface{}{
+		"searchableAttributes": []string{"title", "subtitle", "slug", "content"},
+		"filterableAttributes": []string{"business_id", "is_active"},
+	}
+	return e.do(http.MethodPatch, "/settings", settings, &map[string]interface{}{})
+}
+
+func (e *meilisearchEngine) do(method, path string, payload interface{}, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	url := fmt.Sprintf("%s/indexes/%s%s", strings.TrimRight(e.config.URL, "/"), e.config.Index, path)
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	if e.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doJSONRequest(e.client, req, out)
+}
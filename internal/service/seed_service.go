@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/internal/mod_master/entity"
+	"github.com/atam/atamlink/internal/mod_master/repository"
+	"github.com/atam/atamlink/pkg/errors"
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// SeedService service untuk mengisi master data default
+type SeedService interface {
+	Seed(ctx context.Context) error
+}
+
+type seedService struct {
+	db         *sql.DB
+	masterRepo repository.MasterRepository
+	log        logger.Logger
+}
+
+// NewSeedService membuat instance seed service baru
+func NewSeedService(db *sql.DB, masterRepo repository.MasterRepository, log logger.Logger) SeedService {
+	return &seedService{
+		db:         db,
+		masterRepo: masterRepo,
+		log:        log,
+	}
+}
+
+// Seed insert plan gratis, theme dan kategori awal jika tabel masih kosong.
+// Aman dipanggil berulang kali karena tabel yang sudah berisi dilewati.
+func (s *seedService) Seed(ctx context.Context) error {
+	plans, err := s.masterRepo.ListPlans(ctx, repository.PlanFilter{})
+	if err != nil {
+		return err
+	}
+
+	themes, err := s.masterRepo.ListThemes(ctx, repository.ThemeFilter{})
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.masterRepo.ListCategories(ctx, repository.CategoryFilter{})
+	if err != nil {
+		return err
+	}
+
+	if len(plans) > 0 && len(themes) > 0 && len(categories) > 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	if len(plans) == 0 {
+		for _, plan := range defaultPlans(now) {
+			if err := s.masterRepo.CreatePlan(ctx, tx, plan); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(themes) == 0 {
+		for _, theme := range defaultThemes(now) {
+			if err := s.masterRepo.CreateTheme(ctx, tx, theme); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(categories) == 0 {
+		for _, category := range defaultCategories(now) {
+			if err := s.masterRepo.CreateCategory(ctx, tx, category); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+
+	s.log.Info("Default master data seeded",
+		logger.Bool("plans", len(plans) == 0),
+		logger.Bool("themes", len(themes) == 0),
+		logger.Bool("categories", len(categories) == 0),
+	)
+
+	return nil
+}
+
+// defaultPlans plan bawaan untuk instalasi baru
+func defaultPlans(now time.Time) []*entity.MasterPlan {
+	return []*entity.MasterPlan{
+		{
+			Name:     "Free",
+			Price:    0,
+			Duration: "30 days",
+			Features: map[string]interface{}{
+				"max_catalogs":     1,
+				"max_products":     50,
+				"max_users":        1,
+				"custom_domain":    false,
+				"analytics":        false,
+				"priority_support": false,
+				"remove_watermark": false,
+				"advanced_themes":  false,
+				"api_access":       false,
+
+				constant.PlanFeatureMaxSectionsPerCatalog: constant.DefaultMaxSectionsPerCatalog,
+				constant.PlanFeatureMaxCardsPerSection:    constant.DefaultMaxCardsPerSection,
+				constant.PlanFeatureMaxMediaPerCard:       constant.DefaultMaxMediaPerCard,
+			},
+			IsActive:  true,
+			CreatedAt: now,
+		},
+	}
+}
+
+// defaultThemes theme bawaan untuk instalasi baru
+func defaultThemes(now time.Time) []*entity.MasterTheme {
+	themes := []*entity.MasterTheme{
+		{
+			Name: "Minimal",
+			Type: constant.ThemeMinimal,
+			DefaultSettings: map[string]interface{}{
+				"primary_color":    "#111827",
+				"secondary_color":  "#6B7280",
+				"background_color": "#FFFFFF",
+				"text_color":       "#111827",
+				"font_family":      "Inter",
+				"border_radius":    "8px",
+				"layout":           "list",
+			},
+		},
+		{
+			Name: "Modern",
+			Type: constant.ThemeModern,
+			DefaultSettings: map[string]interface{}{
+				"primary_color":    "#2563EB",
+				"secondary_color":  "#7C3AED",
+				"background_color": "#F9FAFB",
+				"text_color":       "#1F2937",
+				"font_family":      "Poppins",
+				"border_radius":    "16px",
+				"layout":           "grid",
+			},
+		},
+		{
+			Name: "Classic",
+			Type: constant.ThemeClassic,
+			DefaultSettings: map[string]interface{}{
+				"primary_color":    "#92400E",
+				"secondary_color":  "#B45309",
+				"background_color": "#FFFBEB",
+				"text_color":       "#292524",
+				"font_family":      "Merriweather",
+				"border_radius":    "4px",
+				"layout":           "list",
+			},
+		},
+	}
+
+	for _, theme := range themes {
+		theme.SetDescription("Theme bawaan " + theme.Name)
+		theme.IsActive = true
+		theme.CreatedAt = now
+	}
+
+	return themes
+}
+
+// defaultCategories kategori bawaan untuk instalasi baru
+func defaultCategories(now time.Time) []*entity.MasterCategory {
+	categories := []*entity.MasterCategory{
+		{Slug: "food", Name: "Makanan & Minuman"},
+		{Slug: "fashion", Name: "Fashion"},
+		{Slug: "beauty", Name: "Kecantikan"},
+		{Slug: "electronics", Name: "Elektronik"},
+		{Slug: "home", Name: "Rumah Tangga"},
+		{Slug: "services", Name: "Jasa"},
+		{Slug: "education", Name: "Pendidikan"},
+		{Slug: "health", Name: "Kesehatan"},
+		{Slug: "travel", Name: "Wisata"},
+		{Slug: "other", Name: "Lainnya"},
+	}
+
+	for _, category := range categories {
+		category.IsActive = true
+		category.CreatedAt = now
+	}
+
+	return categories
+}
@@ -2,13 +2,25 @@ package service
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"fmt"
 	"strings"
 	"unicode"
 
+	"github.com/mozillazg/go-pinyin"
+	"golang.org/x/text/unicode/norm"
+
 	"github.com/atam/atamlink/internal/constant"
 )
 
+// latinSpecial huruf latin yang tidak terurai lewat NFKD
+var latinSpecial = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d",
+	'ħ': "h", 'ı': "i", 'ł': "l", 'þ': "th", 'ŋ': "ng",
+}
+
+var pinyinArgs = pinyin.NewArgs()
+
 // SlugService service untuk generate dan validasi slug
 type SlugService interface {
 	Generate(text string) string
@@ -27,9 +39,8 @@ func NewSlugService() SlugService {
 
 // Generate membuat slug dari text
 func (s *slugService) Generate(text string) string {
-	// Normalize text
-	slug := s.Normalize(text)
-	
+	slug := s.base(text)
+
 	// Jika kosong, generate random
 	if slug == "" {
 		return s.GenerateRandom(constant.DefaultSlugLength)
@@ -40,7 +51,7 @@ func (s *slugService) Generate(text string) string {
 
 // GenerateUnique membuat slug unique dengan suffix random
 func (s *slugService) GenerateUnique(text string, length int) string {
-	baseSlug := s.Normalize(text)
+	baseSlug := s.base(text)
 	
 	// Jika base slug kosong, langsung random
 	if baseSlug == "" {
@@ -58,7 +69,8 @@ func (s *slugService) GenerateUnique(text string, length int) string {
 		if maxBase < 3 {
 			return s.GenerateRandom(length)
 		}
-		baseSlug = baseSlug[:maxBase]
+		// Potongan yang berakhir di dash tidak boleh menghasilkan "--"
+		baseSlug = strings.TrimRight(baseSlug[:maxBase], "-")
 		slug = fmt.Sprintf("%s-%s", baseSlug, suffix)
 	}
 	
@@ -71,7 +83,6 @@ func (s *slugService) GenerateRandom(length int) string {
 		length = constant.DefaultSlugLength
 	}
 	
-	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
 	bytes := make([]byte, length)
 	
 	// Use crypto/rand for better randomness
@@ -80,41 +91,65 @@ func (s *slugService) GenerateRandom(length int) string {
 		return s.simpleRandom(length)
 	}
 	
-	for i, b := range bytes {
-		bytes[i] = charset[b%byte(len(charset))]
+	return encodeSlug(bytes)
+}
+
+// base slug dari text. Text yang tidak menghasilkan karakter latin sama sekali
+// (mis. hanya emoji atau aksara yang belum didukung) dapat slug hash yang
+// deterministik, sehingga judul yang sama selalu menghasilkan slug dasar yang sama.
+func (s *slugService) base(text string) string {
+	if slug := s.Normalize(text); slug != "" {
+		return slug
 	}
-	
-	// Ensure first character is letter
-	if bytes[0] >= '0' && bytes[0] <= '9' {
-		bytes[0] = charset[int(bytes[0])%26] // Force to letter
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
 	}
-	
-	return string(bytes)
+
+	sum := sha256.Sum256([]byte(text))
+	return encodeSlug(sum[:constant.DefaultSlugLength])
 }
 
-// Normalize normalize text menjadi slug format
+// Normalize normalize text menjadi slug format.
+// Huruf berdiakritik ditransliterasi (é → e), aksara Han diubah ke pinyin,
+// emoji, simbol dan aksara lain dianggap pemisah.
 func (s *slugService) Normalize(text string) string {
-	// Convert to lowercase
-	text = strings.ToLower(text)
-	
-	// Replace spaces and special chars with dash
-	var result []rune
-	lastWasDash := false
-	
+	text = norm.NFKD.String(strings.ToLower(text))
+
+	var result strings.Builder
+	needDash := false
+	write := func(part string) {
+		if needDash && result.Len() > 0 {
+			result.WriteByte('-')
+		}
+		result.WriteString(part)
+		needDash = false
+	}
+
 	for _, r := range text {
-		// Keep alphanumeric
-		if unicode.IsLetter(r) || unicode.IsNumber(r) {
-			result = append(result, r)
-			lastWasDash = false
-		} else if !lastWasDash && len(result) > 0 {
-			// Replace non-alphanumeric with dash
-			result = append(result, '-')
-			lastWasDash = true
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Tanda diakritik hasil dekomposisi dibuang
+		case r == '\'' || r == '’':
+			// Apostrof tidak memisah kata: "mama's" → "mamas"
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(r))
+		case latinSpecial[r] != "":
+			write(latinSpecial[r])
+		case unicode.Is(unicode.Han, r):
+			// Tiap karakter Han jadi satu kata pinyin: "日本" → "ri-ben"
+			needDash = true
+			if py := pinyin.SinglePinyin(r, pinyinArgs); len(py) > 0 {
+				write(py[0])
+				needDash = true
+			}
+		default:
+			needDash = true
 		}
 	}
-	
-	// Trim trailing dash
-	slug := strings.Trim(string(result), "-")
+
+	slug := strings.Trim(result.String(), "-")
 	
 	// Remove consecutive dashes
 	for strings.Contains(slug, "--") {
@@ -156,6 +191,23 @@ func (s *slugService) IsValid(slug string) bool {
 	return true
 }
 
+// encodeSlug ubah byte acak/hash ke karakter slug, karakter pertama selalu huruf
+func encodeSlug(bytes []byte) string {
+	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	result := make([]byte, len(bytes))
+	for i, b := range bytes {
+		result[i] = charset[b%byte(len(charset))]
+	}
+
+	// Ensure first character is letter
+	if result[0] >= '0' && result[0] <= '9' {
+		result[0] = charset[int(result[0])%26] // Force to letter
+	}
+
+	return string(result)
+}
+
 // simpleRandom fallback random generator
 func (s *slugService) simpleRandom(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyz"
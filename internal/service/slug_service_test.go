@@ -0,0 +1,94 @@
+package service
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugNormalizeTitles(t *testing.T) {
+	s := NewSlugService()
+
+	cases := []struct {
+		title string
+		want  string
+	}{
+		// Diakritik dan huruf latin khusus
+		{"Café Déjà Vu", "cafe-deja-vu"},
+		{"Kopi Kenangan Mantan — Jl. Sudirman", "kopi-kenangan-mantan-jl-sudirman"},
+		{"Crème Brûlée & Macarons", "creme-brulee-macarons"},
+		{"Straße Bäckerei", "strasse-backerei"},
+		{"Smørrebrød Łódź", "smorrebrod-lodz"},
+		{"Mama's Kitchen!", "mamas-kitchen"},
+		{"Warung Bu Sri’s", "warung-bu-sris"},
+		// Emoji dan simbol jadi pemisah
+		{"🍕 Pizza Party 🎉", "pizza-party"},
+		{"Promo 50% OFF!!!", "promo-50-off"},
+		// Aksara Han ke pinyin
+		{"日本料理", "ri-ben-liao-li"},
+		{"北京 Duck", "bei-jing-duck"},
+		{"Toko 中华 Jaya", "toko-zhong-hua-jaya"},
+		// Tidak ada karakter latin
+		{"🍕🍔🍟", ""},
+		{"   ", ""},
+	}
+
+	for _, tc := range cases {
+		if got := s.Normalize(tc.title); got != tc.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tc.title, got, tc.want)
+		}
+	}
+}
+
+func TestSlugGenerateHashFallback(t *testing.T) {
+	s := NewSlugService()
+
+	// Emoji saja: slug hash yang deterministik dan valid
+	first := s.Generate("🍕🍔🍟")
+	if first == "" || !s.IsValid(first) {
+		t.Fatalf("Generate emoji = %q, want slug valid", first)
+	}
+	if again := s.Generate("🍕🍔🍟"); again != first {
+		t.Errorf("Generate emoji tidak deterministik: %q != %q", again, first)
+	}
+	if other := s.Generate("🎂🎉"); other == first {
+		t.Errorf("emoji berbeda menghasilkan slug yang sama: %q", other)
+	}
+
+	// Aksara yang belum didukung (Thai) juga pakai hash
+	if thai := s.Generate("ร้านอาหาร"); !s.IsValid(thai) {
+		t.Errorf("Generate thai = %q, want slug valid", thai)
+	}
+
+	// Text kosong tetap dapat slug random yang valid
+	if empty := s.Generate("  "); !s.IsValid(empty) {
+		t.Errorf("Generate kosong = %q, want slug valid", empty)
+	}
+}
+
+func TestSlugGenerateUniqueLengthCap(t *testing.T) {
+	s := NewSlugService()
+
+	titles := []string{
+		"Kopi Kenangan Mantan Cabang Jalan Jenderal Sudirman Jakarta Pusat",
+		"a-b-c-d-e-f-g-h-i-j-k-l-m-n-o-p-q-r-s-t-u-v-w-x-y-z",
+		"日本料理 Sushi Ramen Tempura Yakitori Izakaya Omakase",
+		"🍕🍔🍟",
+	}
+
+	for _, title := range titles {
+		for length := 8; length <= 60; length++ {
+			slug := s.GenerateUnique(title, length)
+			if len(slug) > length {
+				t.Errorf("GenerateUnique(%q, %d) = %q, panjang %d", title, length, slug, len(slug))
+			}
+			if !s.IsValid(slug) {
+				t.Errorf("GenerateUnique(%q, %d) = %q, slug tidak valid", title, length, slug)
+			}
+		}
+	}
+
+	// Slug pendek tetap memakai slug dasar
+	if slug := s.GenerateUnique("Café Déjà Vu", 50); !strings.HasPrefix(slug, "cafe-deja-vu-") {
+		t.Errorf("GenerateUnique = %q, want prefix cafe-deja-vu-", slug)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/internal/constant"
+)
+
+// StatusChecker health check komponen untuk status page publik
+type StatusChecker interface {
+	Check(ctx context.Context) []ComponentCheckResult
+}
+
+// ComponentCheckResult hasil health check satu komponen
+type ComponentCheckResult struct {
+	Component string
+	IsUp      bool
+	Latency   time.Duration
+	Error     string
+}
+
+type statusChecker struct {
+	db         *sql.DB
+	client     *http.Client
+	timeout    time.Duration
+	uploadsURL string
+	publicURL  string
+}
+
+// NewStatusChecker membuat instance status checker baru. publicURL dipakai
+// jika STATUS_PUBLIC_CHECK_URL kosong
+func NewStatusChecker(db *sql.DB, cfg config.StatusConfig, publicURL string) StatusChecker {
+	if cfg.PublicCheckURL != "" {
+		publicURL = cfg.PublicCheckURL
+	}
+	return &statusChecker{
+		db:         db,
+		client:     NewHTTPClient(cfg.CheckTimeout),
+		timeout:    cfg.CheckTimeout,
+		uploadsURL: cfg.UploadsCheckURL,
+		publicURL:  publicURL,
+	}
+}
+
+// Check jalankan health check semua komponen. API dicek dari koneksi database,
+// uploads dan halaman publik dari HTTP request (komponen tanpa URL dilewati)
+func (s *statusChecker) Check(ctx context.Context) []ComponentCheckResult {
+	results := []ComponentCheckResult{
+		s.measure(constant.StatusComponentAPI, func() error {
+			pingCtx, cancel := context.WithTimeout(ctx, s.timeout)
+			defer cancel()
+			return s.db.PingContext(pingCtx)
+		}),
+	}
+
+	if s.uploadsURL != "" {
+		results = append(results, s.measure(constant.StatusComponentUploads, func() error {
+			return s.checkURL(ctx, s.uploadsURL)
+		}))
+	}
+
+	if s.publicURL != "" {
+		results = append(results, s.measure(constant.StatusComponentPublicPages, func() error {
+			return s.checkURL(ctx, s.publicURL)
+		}))
+	}
+
+	return results
+}
+
+func (s *statusChecker) measure(component string, check func() error) ComponentCheckResult {
+	start := time.Now()
+	err := check()
+	result := ComponentCheckResult{
+		Component: component,
+		IsUp:      err == nil,
+		Latency:   time.Since(start),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// checkURL komponen dianggap up selama server merespons tanpa 5xx
+func (s *statusChecker) checkURL(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -34,8 +34,11 @@ type UploadService interface {
 	GetRelativePath(fullPath string) string
 	
 	// New methods for Cloudinary integration
-	UploadImageToCloudinary(file *multipart.FileHeader, imageType string) (string, error)
-	DeleteFromCloudinary(publicID string) error
+	UploadImageToCloudinary(ctx context.Context, file *multipart.FileHeader, imageType string) (string, error)
+	DeleteFromCloudinary(ctx context.Context, publicID string) error
+	UploadBytesToCloudinary(ctx context.Context, data []byte, folder, publicID, format string) (string, error)
+	// PingCloudinary check Cloudinary bisa dijangkau dari instance ini
+	PingCloudinary(ctx context.Context) error
 }
 
 type uploadService struct {
@@ -59,6 +62,8 @@ func NewUploadService(config config.UploadConfig) UploadService {
 	if err != nil {
 		panic(fmt.Sprintf("failed to initialize Cloudinary: %v", err))
 	}
+	// Request ID dari ctx upload ikut dikirim sebagai header X-Request-ID
+	cld.Upload.Client.Transport = NewRequestIDTransport(cld.Upload.Client.Transport)
 
 	return &uploadService{
 		config:     config,
@@ -68,7 +73,7 @@ func NewUploadService(config config.UploadConfig) UploadService {
 
 // UploadImageToCloudinary upload image ke Cloudinary dengan proses kompresi dan konversi
 // UploadImageToCloudinary upload image ke Cloudinary dengan proses kompresi dan konversi
-func (s *uploadService) UploadImageToCloudinary(file *multipart.FileHeader, imageType string) (string, error) {
+func (s *uploadService) UploadImageToCloudinary(ctx context.Context, file *multipart.FileHeader, imageType string) (string, error) {
 	// 1. Validasi tipe gambar internal
 	if !isValidImageType(imageType) {
 		return "", errors.New(errors.ErrValidation, "Tipe gambar tidak valid", 400)
@@ -127,7 +132,6 @@ func (s *uploadService) UploadImageToCloudinary(file *multipart.FileHeader, imag
 	filename := s.generateCloudinaryFilename(imageType)
 
 	// 9. Upload ke Cloudinary
-	ctx := context.Background()
 	uploadResult, err := s.cloudinary.Upload.Upload(ctx, bytes.NewReader(imageData), uploader.UploadParams{
 		PublicID: filename,
 		Folder:   s.config.Cloudinary.Folder,
@@ -142,8 +146,7 @@ func (s *uploadService) UploadImageToCloudinary(file *multipart.FileHeader, imag
 }
 
 // DeleteFromCloudinary hapus file dari Cloudinary
-func (s *uploadService) DeleteFromCloudinary(publicID string) error {
-	ctx := context.Background()
+func (s *uploadService) DeleteFromCloudinary(ctx context.Context, publicID string) error {
 	_, err := s.cloudinary.Upload.Destroy(ctx, uploader.DestroyParams{
 		PublicID: publicID,
 	})
@@ -153,6 +156,40 @@ func (s *uploadService) DeleteFromCloudinary(publicID string) error {
 	return nil
 }
 
+// UploadBytesToCloudinary upload file hasil generate (mis. QR code) apa adanya,
+// public ID yang sama ditimpa supaya URL tetap stabil
+func (s *uploadService) UploadBytesToCloudinary(ctx context.Context, data []byte, folder, publicID, format string) (string, error) {
+	overwrite := true
+	uploadResult, err := s.cloudinary.Upload.Upload(ctx, bytes.NewReader(data), uploader.UploadParams{
+		PublicID:  publicID,
+		Folder:    folder,
+		Format:    format,
+		Overwrite: &overwrite,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to upload to Cloudinary")
+	}
+
+	return uploadResult.SecureURL, nil
+}
+
+// PingCloudinary cukup memastikan DNS, koneksi dan TLS ke API Cloudinary berhasil.
+// Status HTTP apa pun dianggap terjangkau, Admin API sengaja tidak dipakai karena
+// kuotanya per jam dan akan habis oleh probe
+func (s *uploadService) PingCloudinary(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, "https://api.cloudinary.com/v1_1/"+s.config.Cloudinary.CloudName, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
 // // validateImageFile validasi file gambar
 // func (s *uploadService) validateImageFile(file *multipart.FileHeader) error {
 // 	// Check ukuran maksimal 10MB
@@ -594,12 +631,13 @@ func ProcessUpload(
 
 // ProcessImageUpload process image upload ke Cloudinary
 func ProcessImageUpload(
+	ctx context.Context,
 	file *multipart.FileHeader,
 	imageType string,
 	uploadService UploadService,
 ) (string, error) {
 	// Upload image ke Cloudinary
-	url, err := uploadService.UploadImageToCloudinary(file, imageType)
+	url, err := uploadService.UploadImageToCloudinary(ctx, file, imageType)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+
+	"github.com/atam/atamlink/internal/constant"
+	"github.com/atam/atamlink/pkg/errors"
+)
+
+// VaultService service untuk enkripsi kredensial pihak ketiga sebelum disimpan
+type VaultService interface {
+	Seal(secrets map[string]string) (string, error)
+	Open(sealed string) (map[string]string, error)
+}
+
+type vaultService struct {
+	key []byte
+}
+
+// NewVaultService membuat instance vault service baru
+func NewVaultService(secret string) VaultService {
+	var key []byte
+	if secret != "" {
+		sum := sha256.Sum256([]byte(secret))
+		key = sum[:]
+	}
+	return &vaultService{key: key}
+}
+
+// Seal enkripsi secrets dengan AES-GCM, hasil base64(nonce|ciphertext)
+func (s *vaultService) Seal(secrets map[string]string) (string, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal secrets")
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+
+	sealed := gcm.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open dekripsi secrets hasil Seal
+func (s *vaultService) Open(sealed string) (map[string]string, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode sealed secrets")
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.Wrap(errors.ErrBadRequest, "sealed secrets too short")
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt secrets")
+	}
+
+	secrets := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal secrets")
+	}
+
+	return secrets, nil
+}
+
+func (s *vaultService) gcm() (cipher.AEAD, error) {
+	if s.key == nil {
+		return nil, errors.New(errors.ErrInternalServer, constant.ErrMsgVaultNotConfigured, 503)
+	}
+
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init cipher")
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,291 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// migrationLockKey key pg_advisory_lock agar hanya satu instance yang menjalankan migrasi
+const migrationLockKey = 724_190_531
+
+// migrationFile format nama file golang-migrate: 001_nama.up.sql / 001_nama.down.sql
+var migrationFile = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration satu versi skema
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus posisi skema database terhadap file migrasi
+type MigrationStatus struct {
+	Version uint64 // 0 = belum ada migrasi yang dijalankan
+	Dirty   bool   // migrasi terakhir gagal di tengah jalan, perlu Force
+	Pending []*Migration
+}
+
+// Migrator menjalankan file migrasi SQL. Versi disimpan di tabel schema_migrations
+// dengan format yang sama dengan golang-migrate, sehingga database yang sebelumnya
+// dimigrasi lewat CLI migrate bisa langsung dilanjutkan
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+	log        logger.Logger
+}
+
+// NewMigrator membaca pasangan file up/down dari source
+func NewMigrator(db *sql.DB, source fs.FS, log logger.Logger) (*Migrator, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version %q: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return &Migrator{db: db, migrations: migrations, log: log}, nil
+}
+
+// Status versi skema saat ini dan migrasi yang belum dijalankan
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	var status *MigrationStatus
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		status = &MigrationStatus{Version: version, Dirty: dirty, Pending: m.pending(version)}
+		return nil
+	})
+	return status, err
+}
+
+// Up jalankan semua migrasi yang belum dijalankan, masing-masing dalam satu transaksi
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("database is dirty at version %d, fix it manually then run migrate force", version)
+		}
+
+		for _, migration := range m.pending(version) {
+			if err := m.apply(ctx, conn, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			m.log.Info("Migration applied",
+				logger.Int64("version", int64(migration.Version)),
+				logger.String("name", migration.Name),
+			)
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rollback sejumlah steps migrasi terakhir
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("database is dirty at version %d, fix it manually then run migrate force", version)
+		}
+
+		for reverted < steps && version > 0 {
+			index := m.indexOf(version)
+			if index < 0 {
+				return fmt.Errorf("migration file for version %d not found", version)
+			}
+
+			migration := m.migrations[index]
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			var previous uint64
+			if index > 0 {
+				previous = m.migrations[index-1].Version
+			}
+			if err := m.apply(ctx, conn, migration.Down, previous); err != nil {
+				return fmt.Errorf("rollback %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			m.log.Info("Migration rolled back",
+				logger.Int64("version", int64(migration.Version)),
+				logger.String("name", migration.Name),
+			)
+
+			version = previous
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Force set versi skema tanpa menjalankan SQL, untuk menandai database yang
+// skemanya sudah dibuat manual atau membersihkan status dirty
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if version > 0 && m.indexOf(version) < 0 {
+		return fmt.Errorf("migration file for version %d not found", version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := setVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// withLock jalankan fn di satu koneksi yang memegang advisory lock migrasi
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			dirty BOOLEAN NOT NULL
+		)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) currentVersion(ctx context.Context, conn *sql.Conn) (uint64, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return uint64(version), dirty, nil
+}
+
+// apply jalankan SQL dan catat versi baru dalam satu transaksi, sehingga migrasi
+// yang gagal tidak meninggalkan skema setengah jadi
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, query string, version uint64) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Migrasi boleh lebih lama dari DB_STATEMENT_TIMEOUT
+	if _, err := tx.ExecContext(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	if err := setVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setVersion(ctx context.Context, tx *sql.Tx, version uint64) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", int64(version))
+	return err
+}
+
+func (m *Migrator) pending(version uint64) []*Migration {
+	pending := make([]*Migration, 0)
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+func (m *Migrator) indexOf(version uint64) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
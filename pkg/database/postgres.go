@@ -6,13 +6,15 @@ import (
 	"fmt"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"github.com/atam/atamlink/internal/config"
+	"github.com/atam/atamlink/pkg/logger"
 )
 
-// NewPostgresDB membuat koneksi baru ke PostgreSQL
-func NewPostgresDB(cfg config.DatabaseConfig) (*sql.DB, error) {
+// NewPostgresDB membuat koneksi baru ke PostgreSQL.
+// Jika SlowQueryThreshold diisi, statement yang lebih lambat dicatat beserta query plan-nya.
+func NewPostgresDB(cfg config.DatabaseConfig, log logger.Logger) (*sql.DB, error) {
 	// Build DSN (Data Source Name)
 	dsn := fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
@@ -24,12 +26,38 @@ func NewPostgresDB(cfg config.DatabaseConfig) (*sql.DB, error) {
 		cfg.SSLMode,
 	)
 
+	// Batas waktu per statement di sisi server, query yang macet dibatalkan Postgres
+	if cfg.StatementTimeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", cfg.StatementTimeout.Milliseconds())
+	}
+
 	// Buka koneksi
-	db, err := sql.Open("postgres", dsn)
+	connector, err := pq.NewConnector(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
+	var db *sql.DB
+	if cfg.SlowQueryThreshold > 0 {
+		explainDB := sql.OpenDB(connector)
+		explainDB.SetMaxOpenConns(2)
+
+		db = sql.OpenDB(&slowQueryConnector{
+			base: connector,
+			hook: &slowQueryHook{
+				opts: SlowQueryOptions{
+					Threshold:      cfg.SlowQueryThreshold,
+					AnalyzePercent: cfg.SlowQueryAnalyzePercent,
+				},
+				explainDB: explainDB,
+				explainer: make(chan struct{}, 2),
+				log:       log,
+			},
+		})
+	} else {
+		db = sql.OpenDB(connector)
+	}
+
 	// Test koneksi
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
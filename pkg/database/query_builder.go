@@ -151,6 +151,34 @@ func (qb *QueryBuilder) Offset(offset int) *QueryBuilder {
 	return qb
 }
 
+// Keyset parameter keyset (cursor) pagination
+type Keyset struct {
+	Sort     string      // key sort request, untuk membentuk cursor berikutnya
+	Column   string      // ekspresi kolom urutan, harus NOT NULL
+	IDColumn string      // kolom ID sebagai pemecah urutan yang sama
+	Desc     bool
+	After    bool        // false = halaman pertama
+	Value    interface{} // nilai Column baris terakhir halaman sebelumnya
+	ID       int64       // ID baris terakhir halaman sebelumnya
+}
+
+// ApplyKeyset tambah kondisi dan ORDER BY keyset pagination, menggantikan Offset.
+// Baris yang disisipkan atau dihapus di halaman sebelumnya tidak menggeser hasil
+func (qb *QueryBuilder) ApplyKeyset(k *Keyset) *QueryBuilder {
+	direction, op := "ASC", ">"
+	if k.Desc {
+		direction, op = "DESC", "<"
+	}
+
+	if k.After {
+		qb.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", k.Column, k.IDColumn, op), k.Value, k.ID)
+	}
+
+	qb.orderBy = fmt.Sprintf("%s %s, %s %s", k.Column, direction, k.IDColumn, direction)
+	qb.offset = 0
+	return qb
+}
+
 // Build build the final SQL query
 func (qb *QueryBuilder) Build() (string, []interface{}) {
 	parts := []string{}
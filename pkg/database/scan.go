@@ -0,0 +1,279 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Querier dipenuhi *sql.DB dan *sql.Tx
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Get jalankan query lalu scan baris pertama ke struct T berdasarkan tag `db`,
+// sql.ErrNoRows jika query tidak mengembalikan baris
+func Get[T any](ctx context.Context, q Querier, query string, args ...interface{}) (*T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	dest := new(T)
+	if err := ScanRow(rows, dest); err != nil {
+		return nil, err
+	}
+
+	return dest, rows.Close()
+}
+
+// Select jalankan query lalu scan semua baris ke struct T berdasarkan tag `db`
+func Select[T any](ctx context.Context, q Querier, query string, args ...interface{}) ([]*T, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scanner, err := newRowScanner(rows, reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*T, 0)
+	for rows.Next() {
+		dest := new(T)
+		if err := scanner.scan(rows, reflect.ValueOf(dest).Elem()); err != nil {
+			return nil, err
+		}
+		result = append(result, dest)
+	}
+
+	return result, rows.Err()
+}
+
+// ScanRow scan baris aktif rows ke dest (pointer ke struct) berdasarkan nama kolom.
+// Setiap kolom harus punya field dengan tag `db` yang sama, sehingga kolom yang
+// ditambah, diganti nama atau salah alias langsung gagal alih-alih tertukar diam-diam.
+// Field relasi tanpa tag (struct atau pointer ke struct) ikut dipetakan, misal
+// kolom b_name dari JOIN masuk ke Catalog.Business.Name
+func ScanRow(rows *sql.Rows, dest interface{}) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("database: scan destination must be a pointer to struct, got %T", dest)
+	}
+
+	scanner, err := newRowScanner(rows, v.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	return scanner.scan(rows, v.Elem())
+}
+
+// scanKind cara kolom di-scan ke field
+type scanKind int
+
+const (
+	scanDirect scanKind = iota // tipe yang didukung database/sql
+	scanJSON                   // map/struct disimpan sebagai JSONB
+	scanArray                  // slice disimpan sebagai array Postgres
+)
+
+type fieldInfo struct {
+	index []int
+	kind  scanKind
+}
+
+// rowScanner pemetaan kolom hasil query ke field struct, dibuat sekali per query
+type rowScanner struct {
+	columns []string
+	fields  []*fieldInfo
+}
+
+func newRowScanner(rows *sql.Rows, t reflect.Type) (*rowScanner, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := structFields(t)
+	scanner := &rowScanner{columns: columns, fields: make([]*fieldInfo, len(columns))}
+	for i, column := range columns {
+		info, ok := mapping[column]
+		if !ok {
+			return nil, fmt.Errorf("database: column %q has no field with db tag in %s", column, t)
+		}
+		scanner.fields[i] = info
+	}
+
+	return scanner, nil
+}
+
+type jsonTarget struct {
+	raw    *[]byte
+	field  reflect.Value
+	column string
+}
+
+func (s *rowScanner) scan(rows *sql.Rows, dest reflect.Value) error {
+	targets := make([]interface{}, len(s.columns))
+	var jsonTargets []jsonTarget
+
+	for i, info := range s.fields {
+		field := fieldByIndex(dest, info.index)
+		switch info.kind {
+		case scanJSON:
+			raw := new([]byte)
+			targets[i] = raw
+			jsonTargets = append(jsonTargets, jsonTarget{raw: raw, field: field, column: s.columns[i]})
+		case scanArray:
+			targets[i] = pq.Array(field.Addr().Interface())
+		default:
+			targets[i] = field.Addr().Interface()
+		}
+	}
+
+	if err := rows.Scan(targets...); err != nil {
+		return err
+	}
+
+	// JSON NULL dibiarkan zero value
+	for _, target := range jsonTargets {
+		if len(*target.raw) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(*target.raw, target.field.Addr().Interface()); err != nil {
+			return fmt.Errorf("database: failed to parse column %q: %w", target.column, err)
+		}
+	}
+
+	return nil
+}
+
+// fieldByIndex seperti reflect.Value.FieldByIndex, tetapi pointer relasi yang nil dialokasikan
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+var (
+	structFieldCache sync.Map // reflect.Type -> map[string]*fieldInfo
+	scannerType      = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType         = reflect.TypeOf(time.Time{})
+)
+
+// structFields pemetaan nama kolom -> field untuk tipe struct, di-cache per tipe
+func structFields(t reflect.Type) map[string]*fieldInfo {
+	if cached, ok := structFieldCache.Load(t); ok {
+		return cached.(map[string]*fieldInfo)
+	}
+
+	mapping := make(map[string]*fieldInfo)
+	collectFields(t, nil, mapping, map[reflect.Type]bool{t: true})
+	structFieldCache.Store(t, mapping)
+	return mapping
+}
+
+// collectFields field bertag di level yang sama didahulukan, baru relasi di bawahnya,
+// sehingga kolom dengan nama sama dipetakan ke field yang paling dangkal
+func collectFields(t reflect.Type, parent []int, mapping map[string]*fieldInfo, visited map[reflect.Type]bool) {
+	var relations []reflect.StructField
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if tag == "" {
+			relations = append(relations, field)
+			continue
+		}
+
+		column := strings.Split(tag, ",")[0]
+		if _, exists := mapping[column]; exists {
+			continue
+		}
+		mapping[column] = &fieldInfo{
+			index: appendIndex(parent, i),
+			kind:  fieldScanKind(field.Type),
+		}
+	}
+
+	for _, field := range relations {
+		relType := field.Type
+		if relType.Kind() == reflect.Ptr {
+			relType = relType.Elem()
+		}
+		if relType.Kind() != reflect.Struct || relType == timeType || visited[relType] {
+			continue
+		}
+
+		visited[relType] = true
+		collectFields(relType, appendIndex(parent, field.Index[0]), mapping, visited)
+		delete(visited, relType)
+	}
+}
+
+func appendIndex(parent []int, i int) []int {
+	index := make([]int, len(parent)+1)
+	copy(index, parent)
+	index[len(parent)] = i
+	return index
+}
+
+func fieldScanKind(t reflect.Type) scanKind {
+	if reflect.PtrTo(t).Implements(scannerType) {
+		return scanDirect
+	}
+
+	base := t
+	if base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+
+	switch base.Kind() {
+	case reflect.Map:
+		return scanJSON
+	case reflect.Struct:
+		if base == timeType {
+			return scanDirect
+		}
+		return scanJSON
+	case reflect.Slice:
+		if base.Elem().Kind() == reflect.Uint8 {
+			return scanDirect
+		}
+		return scanArray
+	}
+
+	return scanDirect
+}
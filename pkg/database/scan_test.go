@@ -0,0 +1,275 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeConnector driver database/sql minimal yang mengembalikan baris tetap
+// untuk query apa pun, cukup untuk menguji pemetaan kolom ke field
+type fakeConnector struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("fake driver: use connector")
+}
+
+type fakeConn struct{ c *fakeConnector }
+
+func (*fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("fake driver: prepare") }
+func (*fakeConn) Close() error                        { return nil }
+func (*fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("fake driver: begin") }
+
+func (conn *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{columns: conn.c.columns, rows: conn.c.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func newFakeDB(t *testing.T, columns []string, rows ...[]driver.Value) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(&fakeConnector{columns: columns, rows: rows})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type testOwner struct {
+	ID   int64  `db:"o_id"`
+	Name string `db:"o_name"`
+}
+
+type testItem struct {
+	ID        int64                  `db:"i_id"`
+	Name      string                 `db:"i_name"`
+	Note      sql.NullString         `db:"i_note"`
+	Price     *float64               `db:"i_price"`
+	DeletedAt *time.Time             `db:"i_deleted_at"`
+	Meta      map[string]interface{} `db:"i_meta"`
+	Tags      []string               `db:"i_tags"`
+	Internal  string                 `db:"-"`
+	Untagged  string
+
+	Owner *testOwner
+}
+
+func TestSelectMapsColumnsByName(t *testing.T) {
+	// Urutan kolom sengaja berbeda dari urutan field
+	db := newFakeDB(t,
+		[]string{"i_name", "o_name", "i_id", "i_tags", "i_meta"},
+		[]driver.Value{"Kopi", "Toko A", int64(1), []byte(`{kopi,susu}`), []byte(`{"color":"red"}`)},
+		[]driver.Value{"Teh", "Toko B", int64(2), []byte(`{}`), nil},
+	)
+
+	items, err := Select[testItem](context.Background(), db, "SELECT")
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+
+	first := items[0]
+	if first.ID != 1 || first.Name != "Kopi" {
+		t.Errorf("got id=%d name=%q, want id=1 name=Kopi", first.ID, first.Name)
+	}
+	if first.Owner == nil || first.Owner.Name != "Toko A" {
+		t.Errorf("relation not mapped: %+v", first.Owner)
+	}
+	if len(first.Tags) != 2 || first.Tags[0] != "kopi" || first.Tags[1] != "susu" {
+		t.Errorf("got tags %v, want [kopi susu]", first.Tags)
+	}
+	if first.Meta["color"] != "red" {
+		t.Errorf("got meta %v, want color=red", first.Meta)
+	}
+
+	if items[1].Meta != nil {
+		t.Errorf("NULL JSON should stay nil, got %v", items[1].Meta)
+	}
+}
+
+func TestScanUnknownColumn(t *testing.T) {
+	db := newFakeDB(t, []string{"i_id", "i_renamed"}, []driver.Value{int64(1), "x"})
+
+	_, err := Get[testItem](context.Background(), db, "SELECT")
+	if err == nil {
+		t.Fatal("expected error for column without matching field")
+	}
+	if !strings.Contains(err.Error(), `"i_renamed"`) {
+		t.Errorf("error should name the column, got %v", err)
+	}
+}
+
+func TestScanMissingDBTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+	}{
+		{name: "field without tag", column: "Untagged"},
+		{name: "field tagged -", column: "Internal"},
+		{name: "lowercase field name", column: "untagged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeDB(t, []string{"i_id", tt.column}, []driver.Value{int64(1), "x"})
+
+			if _, err := Select[testItem](context.Background(), db, "SELECT"); err == nil {
+				t.Fatalf("column %q should not map to a field without db tag", tt.column)
+			}
+		})
+	}
+}
+
+func TestScanNullValues(t *testing.T) {
+	db := newFakeDB(t,
+		[]string{"i_id", "i_note", "i_price", "i_deleted_at", "i_meta", "i_tags"},
+		[]driver.Value{int64(1), nil, nil, nil, nil, nil},
+	)
+
+	item, err := Get[testItem](context.Background(), db, "SELECT")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if item.Note.Valid {
+		t.Errorf("NULL into sql.NullString should be invalid, got %q", item.Note.String)
+	}
+	if item.Price != nil || item.DeletedAt != nil {
+		t.Errorf("NULL into pointer should stay nil, got price=%v deleted_at=%v", item.Price, item.DeletedAt)
+	}
+	if item.Meta != nil || item.Tags != nil {
+		t.Errorf("NULL JSON/array should stay nil, got meta=%v tags=%v", item.Meta, item.Tags)
+	}
+	if item.Owner != nil {
+		t.Errorf("relation without columns should stay nil, got %+v", item.Owner)
+	}
+}
+
+func TestScanNullIntoNonNullableField(t *testing.T) {
+	db := newFakeDB(t, []string{"i_id", "i_name"}, []driver.Value{int64(1), nil})
+
+	if _, err := Get[testItem](context.Background(), db, "SELECT"); err == nil {
+		t.Fatal("expected error scanning NULL into string field")
+	}
+}
+
+func TestScanNonNullValues(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	db := newFakeDB(t,
+		[]string{"i_id", "i_note", "i_price", "i_deleted_at"},
+		[]driver.Value{int64(1), "catatan", 12.5, deletedAt},
+	)
+
+	item, err := Get[testItem](context.Background(), db, "SELECT")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !item.Note.Valid || item.Note.String != "catatan" {
+		t.Errorf("got note %+v, want catatan", item.Note)
+	}
+	if item.Price == nil || *item.Price != 12.5 {
+		t.Errorf("got price %v, want 12.5", item.Price)
+	}
+	if item.DeletedAt == nil || !item.DeletedAt.Equal(deletedAt) {
+		t.Errorf("got deleted_at %v, want %v", item.DeletedAt, deletedAt)
+	}
+}
+
+// TestItem diekspor agar bisa di-embed seperti entity di row struct repository
+type TestItem struct {
+	ID    int64  `db:"i_id"`
+	Name  string `db:"i_name"`
+	Owner *testOwner
+}
+
+// testItemRow baris LEFT JOIN: kolom owner nullable di level row
+type testItemRow struct {
+	TestItem
+	OwnerID   sql.NullInt64  `db:"o_id"`
+	OwnerName sql.NullString `db:"o_name"`
+}
+
+func TestSelectEmbeddedRow(t *testing.T) {
+	db := newFakeDB(t,
+		[]string{"i_id", "i_name", "o_id", "o_name"},
+		[]driver.Value{int64(1), "Kopi", int64(7), "Toko A"},
+		[]driver.Value{int64(2), "Teh", nil, nil},
+	)
+
+	rows, err := Select[testItemRow](context.Background(), db, "SELECT")
+	if err != nil {
+		t.Fatalf("Select: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	if rows[0].ID != 1 || rows[0].Name != "Kopi" {
+		t.Errorf("embedded fields not mapped: %+v", rows[0].TestItem)
+	}
+	if !rows[0].OwnerID.Valid || rows[0].OwnerID.Int64 != 7 || rows[0].OwnerName.String != "Toko A" {
+		t.Errorf("row-level columns not mapped: id=%+v name=%+v", rows[0].OwnerID, rows[0].OwnerName)
+	}
+	if rows[1].OwnerID.Valid || rows[1].OwnerName.Valid {
+		t.Errorf("NULL join columns should be invalid, got id=%+v name=%+v", rows[1].OwnerID, rows[1].OwnerName)
+	}
+
+	// Kolom row menutupi relasi embedded, relasi tidak dialokasikan
+	for _, row := range rows {
+		if row.Owner != nil {
+			t.Errorf("row %d: shadowed relation should stay nil, got %+v", row.ID, row.Owner)
+		}
+	}
+}
+
+func TestGetNoRows(t *testing.T) {
+	db := newFakeDB(t, []string{"i_id"})
+
+	if _, err := Get[testItem](context.Background(), db, "SELECT"); err != sql.ErrNoRows {
+		t.Fatalf("got %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestScanRowRejectsNonStruct(t *testing.T) {
+	db := newFakeDB(t, []string{"i_id"}, []driver.Value{int64(1)})
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	defer rows.Close()
+	rows.Next()
+
+	var id int64
+	if err := ScanRow(rows, &id); err == nil {
+		t.Fatal("expected error for non-struct destination")
+	}
+}
@@ -0,0 +1,235 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"math/rand"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/atam/atamlink/pkg/logger"
+)
+
+// SlowQueryOptions konfigurasi log query lambat
+type SlowQueryOptions struct {
+	Threshold      time.Duration // 0 = nonaktif
+	AnalyzePercent int           // persentase query SELECT lambat tanpa lock yang di-EXPLAIN ANALYZE, sisanya EXPLAIN biasa
+}
+
+// slowQueryHook catat statement yang melewati threshold beserta query plan-nya
+type slowQueryHook struct {
+	opts      SlowQueryOptions
+	explainDB *sql.DB       // koneksi tanpa hook agar EXPLAIN tidak ikut tercatat
+	explainer chan struct{} // batasi EXPLAIN yang berjalan bersamaan
+	log       logger.Logger
+}
+
+// observe dipanggil setelah statement selesai
+func (h *slowQueryHook) observe(start time.Time, query string, args []driver.NamedValue) {
+	duration := time.Since(start)
+	if duration < h.opts.Threshold {
+		return
+	}
+
+	method := callerMethod()
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+
+	select {
+	case h.explainer <- struct{}{}:
+		go func() {
+			defer func() { <-h.explainer }()
+			h.report(method, duration, query, h.explain(query, values))
+		}()
+	default:
+		h.report(method, duration, query, "")
+	}
+}
+
+// sideEffectPattern klausa lock dan fungsi yang tetap berefek walau statement
+// SELECT di-rollback: row lock, sequence dan advisory lock level session
+var sideEffectPattern = regexp.MustCompile(`(?i)\bFOR\s+(NO\s+KEY\s+UPDATE|UPDATE|KEY\s+SHARE|SHARE)\b|\b(nextval|setval|pg_advisory\w*|pg_try_advisory\w*|pg_notify|dblink\w*)\s*\(`)
+
+// analyzable statement SELECT yang aman dijalankan ulang untuk EXPLAIN ANALYZE
+func analyzable(query string) bool {
+	keyword := strings.ToUpper(strings.SplitN(strings.TrimSpace(query), " ", 2)[0])
+	return keyword == "SELECT" && !sideEffectPattern.MatchString(query)
+}
+
+// explain ambil query plan. ANALYZE menjalankan ulang statement, jadi hanya
+// dipakai untuk SELECT tanpa lock dan fungsi berefek samping, disampling
+// sesuai konfigurasi. Plan diambil dalam transaksi READ ONLY yang selalu
+// di-rollback.
+func (h *slowQueryHook) explain(query string, args []interface{}) string {
+	keyword := strings.ToUpper(strings.SplitN(strings.TrimSpace(query), " ", 2)[0])
+	switch keyword {
+	case "SELECT", "WITH", "INSERT", "UPDATE", "DELETE":
+	default:
+		return ""
+	}
+
+	prefix := "EXPLAIN "
+	if analyzable(query) && rand.Intn(100) < h.opts.AnalyzePercent {
+		prefix = "EXPLAIN (ANALYZE, BUFFERS) "
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tx, err := h.explainDB.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		h.log.Debug("Failed to explain slow query", logger.Error(err))
+		return ""
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, prefix+query, args...)
+	if err != nil {
+		h.log.Debug("Failed to explain slow query", logger.Error(err))
+		return ""
+	}
+	defer rows.Close()
+
+	var lines []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return ""
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (h *slowQueryHook) report(method string, duration time.Duration, query, plan string) {
+	h.log.Warn("Slow query",
+		logger.String("method", method),
+		logger.Duration("duration", duration),
+		logger.String("query", strings.Join(strings.Fields(query), " ")),
+		logger.String("plan", plan),
+	)
+}
+
+// callerMethod nama method repository yang menjalankan statement,
+// fallback ke caller pertama di luar database/sql dan driver
+func callerMethod() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	fallback := "unknown"
+	for {
+		frame, more := frames.Next()
+		fn := frame.Function
+		internal := strings.HasPrefix(fn, "database/sql.") ||
+			strings.Contains(fn, "/pkg/database.") ||
+			strings.Contains(fn, "github.com/lib/pq")
+
+		if !internal {
+			short := fn[strings.LastIndex(fn, "/")+1:]
+			if strings.Contains(fn, "/repository.") {
+				return short
+			}
+			if fallback == "unknown" {
+				fallback = short
+			}
+		}
+
+		if !more {
+			return fallback
+		}
+	}
+}
+
+// slowQueryConnector bungkus connector driver agar setiap koneksi dicatat hook
+type slowQueryConnector struct {
+	base driver.Connector
+	hook *slowQueryHook
+}
+
+func (c *slowQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryConn{Conn: conn, hook: c.hook}, nil
+}
+
+func (c *slowQueryConnector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// slowQueryConn ukur durasi Query/Exec, sisanya diteruskan ke koneksi driver
+type slowQueryConn struct {
+	driver.Conn
+	hook *slowQueryHook
+}
+
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err == nil {
+		c.hook.observe(start, query, args)
+	}
+	return rows, err
+}
+
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if err == nil {
+		c.hook.observe(start, query, args)
+	}
+	return result, err
+}
+
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *slowQueryConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package database
+
+import "testing"
+
+func TestAnalyzable(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"SELECT c_id FROM atamlink.catalogs WHERE c_id = $1", true},
+		{"  select * from atamlink.catalogs", true},
+		{"SELECT * FROM atamlink.catalogs WHERE c_id = $1 FOR UPDATE", false},
+		{"SELECT * FROM atamlink.catalogs FOR NO KEY UPDATE SKIP LOCKED", false},
+		{"SELECT * FROM atamlink.catalogs for share", false},
+		{"SELECT * FROM atamlink.catalogs FOR KEY SHARE", false},
+		{"SELECT nextval('atamlink.catalogs_c_id_seq')", false},
+		{"SELECT pg_advisory_lock($1)", false},
+		{"SELECT pg_try_advisory_xact_lock($1)", false},
+		{"SELECT pg_notify('catalog', $1)", false},
+		{"WITH x AS (SELECT 1) SELECT * FROM x", false},
+		{"UPDATE atamlink.catalogs SET c_title = $1", false},
+		{"SELECT c_updated_for FROM atamlink.catalogs", true},
+	}
+
+	for _, tt := range tests {
+		if got := analyzable(tt.query); got != tt.want {
+			t.Errorf("analyzable(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
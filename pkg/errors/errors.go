@@ -15,6 +15,7 @@ var (
 	ErrNotFound       = errors.New("data tidak ditemukan")
 	ErrConflict       = errors.New("terjadi konflik data")
 	ErrValidation     = errors.New("validasi gagal")
+	ErrRateLimited    = errors.New("terlalu banyak permintaan")
 
 	// Database errors
 	ErrDatabaseConnection = errors.New("koneksi database gagal")
@@ -37,6 +38,7 @@ var (
 	ErrBusinessSuspended  = errors.New("bisnis ditangguhkan")
 	ErrDuplicateSlug      = errors.New("slug sudah digunakan")
 	ErrInvalidBusinessType = errors.New("tipe bisnis tidak valid")
+	ErrNotMember          = errors.New("bukan anggota bisnis")
 
 	// Catalog specific
 	ErrCatalogNotFound    = errors.New("katalog tidak ditemukan")
@@ -55,6 +57,8 @@ var (
 	ErrSubscriptionExpired = errors.New("subscription sudah kadaluarsa")
 	ErrPlanNotFound        = errors.New("plan tidak ditemukan")
 	ErrInvalidPlan         = errors.New("plan tidak valid")
+	ErrPlanLimitExceeded   = errors.New("batas plan tercapai")
+	ErrFeatureNotInPlan    = errors.New("fitur tidak tersedia di plan")
 )
 
 // AppError struktur error aplikasi dengan context tambahan
@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextFrom context request gin, context.Background jika tanpa request (job background)
+func ContextFrom(c *gin.Context) context.Context {
+	if c == nil || c.Request == nil {
+		return context.Background()
+	}
+	return c.Request.Context()
+}
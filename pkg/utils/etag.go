@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag hitung ETag dari representasi JSON data, berubah setiap kali isi data berubah
+func ETag(data interface{}) string {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// OKWithETag kirim response 200 beserta header ETag, 304 jika If-None-Match cocok
+func OKWithETag(c *gin.Context, message string, data interface{}) {
+	if NotModified(c, ETag(data)) {
+		return
+	}
+	OK(c, message, data)
+}
+
+// NotModified set header ETag lalu kirim 304 jika If-None-Match cocok. Dipakai
+// saat ETag bisa dihitung sebelum data di-load
+func NotModified(c *gin.Context, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	c.Header("ETag", etag)
+	if matchETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// HasIfMatch check apakah klien mengirim precondition If-Match
+func HasIfMatch(c *gin.Context) bool {
+	return strings.TrimSpace(c.GetHeader("If-Match")) != ""
+}
+
+// IfMatch check apakah header If-Match cocok dengan ETag data saat ini
+func IfMatch(c *gin.Context, current interface{}) bool {
+	return matchETag(c.GetHeader("If-Match"), ETag(current))
+}
+
+// IfUnmodifiedSince waktu dari header If-Unmodified-Since, nil jika tidak dikirim
+// atau format tanggal tidak valid (header diabaikan sesuai RFC 9110)
+func IfUnmodifiedSince(c *gin.Context) *time.Time {
+	header := strings.TrimSpace(c.GetHeader("If-Unmodified-Since"))
+	if header == "" {
+		return nil
+	}
+	t, err := http.ParseTime(header)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// ModifiedSince check apakah data berubah setelah waktu precondition. Header HTTP
+// hanya presisi detik, jadi waktu perubahan dibulatkan ke bawah
+func ModifiedSince(lastModified, since time.Time) bool {
+	return lastModified.Truncate(time.Second).After(since)
+}
+
+// PreconditionFailed response 412 saat If-Match tidak cocok
+func PreconditionFailed(c *gin.Context, message string) {
+	Error(c, http.StatusPreconditionFailed, message)
+}
+
+// matchETag cocokkan daftar ETag di header (dipisah koma, "*" = apa saja)
+func matchETag(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" || etag == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		// Proxy bisa melemahkan ETag (W/) saat mengompres response
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
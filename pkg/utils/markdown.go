@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"bytes"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// Policy UGC: tag format umum, link diberi rel="nofollow", script/style/event handler dibuang
+	markdownPolicy = func() *bluemonday.Policy {
+		policy := bluemonday.UGCPolicy()
+		policy.AddTargetBlankToFullyQualifiedLinks(true)
+		return policy
+	}()
+)
+
+// RenderMarkdown render Markdown ke HTML yang sudah disanitasi.
+// HTML mentah di dalam Markdown tidak dirender, hasil akhir tetap disaring policy.
+func RenderMarkdown(source string) string {
+	if source == "" {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return markdownPolicy.Sanitize(source)
+	}
+
+	return markdownPolicy.Sanitize(buf.String())
+}
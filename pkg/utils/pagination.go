@@ -1,18 +1,33 @@
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/atam/atamlink/pkg/database"
 )
 
+// ErrInvalidCursor cursor pagination tidak bisa dibaca
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ErrCursorSortMismatch cursor dibuat untuk sort/order yang berbeda dari request
+var ErrCursorSortMismatch = errors.New("cursor sort mismatch")
+
 // PaginationParams parameter untuk pagination
 type PaginationParams struct {
 	Page    int    `json:"page"`
 	PerPage int    `json:"per_page"`
 	Sort    string `json:"sort"`
 	Order   string `json:"order"`
+
+	// Cursor pagination, aktif jika request mengirim ?cursor= atau ?limit=
+	Cursor     string `json:"cursor,omitempty"`
+	CursorMode bool   `json:"-"`
 }
 
 // GetOffset menghitung offset untuk query
@@ -65,6 +80,18 @@ func GetPaginationParams(c *gin.Context) *PaginationParams {
 		}
 	}
 
+	// Cursor pagination, limit menggantikan per_page
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		params.Cursor = cursor
+		params.CursorMode = true
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if l, err := strconv.Atoi(limit); err == nil {
+			params.PerPage = l
+			params.CursorMode = true
+		}
+	}
+
 	// Parse sort
 	if sort := c.Query("sort"); sort != "" {
 		params.Sort = sort
@@ -81,6 +108,69 @@ func GetPaginationParams(c *gin.Context) *PaginationParams {
 	return params
 }
 
+// Cursor posisi baris terakhir halaman sebelumnya untuk cursor pagination.
+// Sort dan Desc disimpan supaya cursor tidak dipakai dengan urutan lain
+type Cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+// EncodeCursor encode posisi baris dan urutan keyset jadi cursor opaque (base64 URL-safe)
+func EncodeCursor(keyset *database.Keyset, value string, id int64) string {
+	raw, _ := json.Marshal(Cursor{Sort: keyset.Sort, Desc: keyset.Desc, Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor decode cursor dari query ?cursor=
+func DecodeCursor(encoded string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(raw, cursor); err != nil || cursor.ID <= 0 || cursor.Sort == "" {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// BuildKeyset keyset pagination dari sort/order dan cursor request. Sort yang
+// tidak diizinkan jatuh ke created_at, kolom di allowedSorts harus NOT NULL.
+// Cursor dari sort/order lain ditolak dengan ErrCursorSortMismatch
+func BuildKeyset(params *PaginationParams, allowedSorts map[string]string, idColumn string) (*database.Keyset, error) {
+	sort := params.Sort
+	column, allowed := allowedSorts[sort]
+	if !allowed {
+		sort = "created_at"
+		column = allowedSorts[sort]
+	}
+
+	keyset := &database.Keyset{
+		Sort:     sort,
+		Column:   column,
+		IDColumn: idColumn,
+		Desc:     params.Order != "asc",
+	}
+
+	if params.Cursor != "" {
+		cursor, err := DecodeCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Sort != keyset.Sort || cursor.Desc != keyset.Desc {
+			return nil, ErrCursorSortMismatch
+		}
+		keyset.After = true
+		keyset.Value = cursor.Value
+		keyset.ID = cursor.ID
+	}
+
+	return keyset, nil
+}
+
 // FilterParams parameter untuk filtering
 type FilterParams struct {
 	Search  string                 `json:"search"`
@@ -8,18 +8,63 @@ import (
 
 // Response struktur standar untuk semua API response
 type Response struct {
-	Code    int         `json:"code"`
-	Status  string      `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
+	Code    int           `json:"code"`
+	Status  string        `json:"status"`
+	Message string        `json:"message"`
+	Data    interface{}   `json:"data"`
+	Meta    *ResponseMeta `json:"meta,omitempty"`
+}
+
+// ResponseMeta metadata opsional response non-paginated
+type ResponseMeta struct {
+	Hints []Hint `json:"hints,omitempty"`
+}
+
+// Hint petunjuk pemakaian untuk client, mis. prompt upgrade saat mendekati batas plan
+type Hint struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Feature    string `json:"feature"`
+	Used       int    `json:"used"`
+	Limit      int    `json:"limit"`
+	Plan       string `json:"plan,omitempty"`
+	ResourceID int64  `json:"resource_id,omitempty"` // mis. ID section untuk batas card per section
 }
 
 // PaginationMeta metadata untuk pagination
 type PaginationMeta struct {
-	Page       int   `json:"page"`
-	PerPage    int   `json:"per_page"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"total_pages"`
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	Hints      []Hint `json:"hints,omitempty"`
+}
+
+// CursorMeta metadata untuk cursor pagination
+type CursorMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"` // kosong = halaman terakhir
+	HasMore    bool   `json:"has_more"`
+	Hints      []Hint `json:"hints,omitempty"`
+}
+
+// ginKeyHints key context untuk hint yang ikut dikirim di meta response sukses
+const ginKeyHints = "response_hints"
+
+// SetHints simpan hint untuk response sukses berikutnya pada request ini
+func SetHints(c *gin.Context, hints []Hint) {
+	if len(hints) > 0 {
+		c.Set(ginKeyHints, hints)
+	}
+}
+
+func getHints(c *gin.Context) []Hint {
+	if value, exists := c.Get(ginKeyHints); exists {
+		if hints, ok := value.([]Hint); ok {
+			return hints
+		}
+	}
+	return nil
 }
 
 // PaginatedResponse response dengan pagination
@@ -31,18 +76,32 @@ type PaginatedResponse struct {
 	Meta    PaginationMeta `json:"meta"`
 }
 
+// CursorResponse response dengan cursor pagination
+type CursorResponse struct {
+	Code    int         `json:"code"`
+	Status  string      `json:"status"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+	Meta    CursorMeta  `json:"meta"`
+}
+
 // Success mengirim response sukses
 func Success(c *gin.Context, code int, message string, data interface{}) {
-	c.JSON(code, Response{
+	resp := Response{
 		Code:    code,
 		Status:  "success",
 		Message: message,
 		Data:    data,
-	})
+	}
+	if hints := getHints(c); len(hints) > 0 {
+		resp.Meta = &ResponseMeta{Hints: hints}
+	}
+	c.JSON(code, resp)
 }
 
 // SuccessPaginated mengirim response sukses dengan pagination
 func SuccessPaginated(c *gin.Context, code int, message string, data interface{}, meta PaginationMeta) {
+	meta.Hints = getHints(c)
 	c.JSON(code, PaginatedResponse{
 		Code:    code,
 		Status:  "success",
@@ -52,6 +111,18 @@ func SuccessPaginated(c *gin.Context, code int, message string, data interface{}
 	})
 }
 
+// SuccessCursor mengirim response sukses dengan cursor pagination
+func SuccessCursor(c *gin.Context, code int, message string, data interface{}, meta CursorMeta) {
+	meta.Hints = getHints(c)
+	c.JSON(code, CursorResponse{
+		Code:    code,
+		Status:  "success",
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
 // Error mengirim response error
 func Error(c *gin.Context, code int, message string) {
 	c.JSON(code, Response{
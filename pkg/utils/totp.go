@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTPPeriod lama satu langkah kode TOTP (RFC 6238)
+const TOTPPeriod = 30 * time.Second
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret membuat secret TOTP baru dalam format base32
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TOTPURI URI otpauth:// untuk QR code aplikasi authenticator
+func TOTPURI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// ValidateTOTP cek kode 6 digit untuk waktu t, toleransi satu langkah sebelum
+// dan sesudah. Return langkah yang cocok agar kode tidak bisa dipakai ulang.
+func ValidateTOTP(secret, code string, t time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil || len(code) != 6 {
+		return 0, false
+	}
+
+	step := t.Unix() / int64(TOTPPeriod.Seconds())
+	for _, candidate := range []int64{step - 1, step, step + 1} {
+		if totpCodeEqual(totpCode(key, candidate), code) {
+			return candidate, true
+		}
+	}
+	return 0, false
+}
+
+// totpCodeEqual bandingkan kode dalam waktu konstan agar tidak bocor lewat timing
+func totpCodeEqual(expected, code string) bool {
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1
+}
+
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// Secret ASCII "12345678901234567890" dari RFC 6238 Appendix B (SHA1)
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTPRFC6238Vectors(t *testing.T) {
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, v := range vectors {
+		step, ok := ValidateTOTP(rfc6238Secret, v.code, time.Unix(v.unix, 0))
+		if !ok {
+			t.Errorf("T=%d: kode %s ditolak", v.unix, v.code)
+			continue
+		}
+		if want := v.unix / 30; step != want {
+			t.Errorf("T=%d: step = %d, want %d", v.unix, step, want)
+		}
+	}
+}
+
+func TestValidateTOTPSkewWindow(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / 30
+
+	for _, offset := range []int64{-1, 0, 1} {
+		got, ok := ValidateTOTP(rfc6238Secret, totpCode(key, step+offset), now)
+		if !ok {
+			t.Errorf("offset %d: kode ditolak", offset)
+			continue
+		}
+		if got != step+offset {
+			t.Errorf("offset %d: step = %d, want %d", offset, got, step+offset)
+		}
+	}
+
+	for _, offset := range []int64{-2, 2} {
+		code := totpCode(key, step+offset)
+		// Lewati jika kebetulan sama dengan kode di dalam window
+		if code == totpCode(key, step-1) || code == totpCode(key, step) || code == totpCode(key, step+1) {
+			continue
+		}
+		if _, ok := ValidateTOTP(rfc6238Secret, code, now); ok {
+			t.Errorf("offset %d: kode di luar window diterima", offset)
+		}
+	}
+}
+
+func TestValidateTOTPRejectsMalformed(t *testing.T) {
+	now := time.Unix(59, 0)
+	cases := map[string]struct {
+		secret string
+		code   string
+	}{
+		"kode kosong":          {rfc6238Secret, ""},
+		"kode terlalu pendek":  {rfc6238Secret, "28708"},
+		"kode terlalu panjang": {rfc6238Secret, "2870820"},
+		"digit terakhir beda":  {rfc6238Secret, "287083"},
+		"secret bukan base32":  {"not-base32!", "287082"},
+	}
+
+	for name, tc := range cases {
+		if _, ok := ValidateTOTP(tc.secret, tc.code, now); ok {
+			t.Errorf("%s: kode diterima", name)
+		}
+	}
+
+	// Secret huruf kecil dan spasi tetap diterima
+	if _, ok := ValidateTOTP(" gezdgnbvgy3tqojqgezdgnbvgy3tqojq ", "287082", now); !ok {
+		t.Error("secret huruf kecil ditolak")
+	}
+}
+
+func TestTOTPCodeEqual(t *testing.T) {
+	cases := []struct {
+		expected string
+		code     string
+		want     bool
+	}{
+		{"287082", "287082", true},
+		{"287082", "287083", false},
+		{"287082", "187082", false},
+		{"287082", "28708", false},
+		{"287082", "2870820", false},
+		{"287082", "", false},
+	}
+
+	for _, tc := range cases {
+		if got := totpCodeEqual(tc.expected, tc.code); got != tc.want {
+			t.Errorf("totpCodeEqual(%q, %q) = %v, want %v", tc.expected, tc.code, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// UTMParams parameter UTM yang ditambahkan ke URL keluar
+type UTMParams struct {
+	Source   string `json:"source,omitempty"`
+	Medium   string `json:"medium,omitempty"`
+	Campaign string `json:"campaign,omitempty"`
+}
+
+// UTMOverride override UTM per link. Field kosong mengikuti UTM katalog,
+// Disabled mematikan UTM untuk link tersebut
+type UTMOverride struct {
+	Disabled bool `json:"disabled,omitempty"`
+	UTMParams
+}
+
+// IsZero check apakah tidak ada parameter UTM yang diset
+func (p UTMParams) IsZero() bool {
+	return p.Source == "" && p.Medium == "" && p.Campaign == ""
+}
+
+// WithOverride gabungkan UTM dengan override link, override nil berarti tetap
+func (p UTMParams) WithOverride(o *UTMOverride) UTMParams {
+	if o == nil {
+		return p
+	}
+	if o.Disabled {
+		return UTMParams{}
+	}
+	if o.Source != "" {
+		p.Source = o.Source
+	}
+	if o.Medium != "" {
+		p.Medium = o.Medium
+	}
+	if o.Campaign != "" {
+		p.Campaign = o.Campaign
+	}
+	return p
+}
+
+// AppendUTM tambahkan parameter UTM ke URL http/https. Parameter utm_* yang
+// sudah ada di URL tidak ditimpa, URL lain (mailto, tel, dll) dikembalikan apa adanya
+func AppendUTM(rawURL string, p UTMParams) string {
+	if p.IsZero() {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "https" {
+		return rawURL
+	}
+
+	query := u.Query()
+	changed := false
+	for key, value := range map[string]string{
+		"utm_source":   p.Source,
+		"utm_medium":   p.Medium,
+		"utm_campaign": p.Campaign,
+	} {
+		if value == "" || query.Has(key) {
+			continue
+		}
+		query.Set(key, value)
+		changed = true
+	}
+	if !changed {
+		return rawURL
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String()
+}
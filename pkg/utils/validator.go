@@ -175,6 +175,7 @@ func getErrorMessage(e validator.FieldError) string {
 		"url":       fmt.Sprintf("%s harus berupa URL yang valid", field),
 		"uuid":      fmt.Sprintf("%s harus berupa UUID yang valid", field),
 		"oneof":     fmt.Sprintf("%s harus salah satu dari: %s", field, param),
+		"hexcolor":  fmt.Sprintf("%s harus berupa kode warna hex (contoh: #1A2B3C)", field),
 	}
 
 	if msg, exists := messages[tag]; exists {